@@ -21,6 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"sync"
 	"time"
@@ -66,6 +67,9 @@ var (
 	prometheusMetricsPath     string
 	adapterClientRequestQPS   float32
 	adapterClientRequestBurst int
+	forbidUnsafeSsl           bool
+	disabledScalers           []string
+	installIdentity           string
 )
 
 func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Duration, maxConcurrentReconciles int) (provider.MetricsProvider, <-chan struct{}, error) {
@@ -129,7 +133,7 @@ func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Durat
 
 	broadcaster := record.NewBroadcaster()
 	recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: "keda-metrics-adapter"})
-	handler := scaling.NewScaleHandler(mgr.GetClient(), nil, scheme, globalHTTPTimeout, recorder)
+	handler := scaling.NewScaleHandler(mgr.GetClient(), nil, scheme, globalHTTPTimeout, forbidUnsafeSsl, disabledScalers, recorder)
 	externalMetricsInfo := &[]provider.ExternalMetricInfo{}
 	externalMetricsInfoLock := &sync.RWMutex{}
 
@@ -141,7 +145,7 @@ func (a *Adapter) makeProvider(ctx context.Context, globalHTTPTimeout time.Durat
 		return nil, nil, err
 	}
 
-	return kedaprovider.NewProvider(ctx, logger, handler, mgr.GetClient(), namespace, externalMetricsInfo, externalMetricsInfoLock), stopCh, nil
+	return kedaprovider.NewProvider(ctx, logger, handler, mgr.GetClient(), namespace, externalMetricsInfo, externalMetricsInfoLock, installIdentity), stopCh, nil
 }
 
 func runScaledObjectController(ctx context.Context, mgr manager.Manager, scaleHandler scaling.ScaleHandler, logger logr.Logger, externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex, maxConcurrentReconciles int, stopCh chan<- struct{}) error {
@@ -153,6 +157,14 @@ func runScaledObjectController(ctx context.Context, mgr manager.Manager, scaleHa
 	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}); err != nil {
 		return err
 	}
+	if err := (&kedacontrollers.MetricsMetricSourceReconciler{
+		Client:                  mgr.GetClient(),
+		ScaleHandler:            scaleHandler,
+		ExternalMetricsInfo:     externalMetricsInfo,
+		ExternalMetricsInfoLock: externalMetricsInfoLock,
+	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: maxConcurrentReconciles}); err != nil {
+		return err
+	}
 
 	go func() {
 		if err := mgr.Start(ctx); err != nil {
@@ -170,6 +182,7 @@ func printVersion() {
 	logger.Info(fmt.Sprintf("KEDA Commit: %s", version.GitCommit))
 	logger.Info(fmt.Sprintf("Go Version: %s", runtime.Version()))
 	logger.Info(fmt.Sprintf("Go OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH))
+	logger.Info(fmt.Sprintf("Crypto Mode: %s", kedautil.CryptoMode()))
 }
 
 // getWatchNamespace returns the namespace the operator should be watching for changes
@@ -205,6 +218,13 @@ func main() {
 	cmd.Flags().StringVar(&prometheusMetricsPath, "metrics-path", "/metrics", "Set the path for the prometheus metrics endpoint")
 	cmd.Flags().Float32Var(&adapterClientRequestQPS, "kube-api-qps", 20.0, "Set the QPS rate for throttling requests sent to the apiserver")
 	cmd.Flags().IntVar(&adapterClientRequestBurst, "kube-api-burst", 30, "Set the burst for throttling requests sent to the apiserver")
+	cmd.Flags().BoolVar(&forbidUnsafeSsl, "forbid-unsafe-ssl", false, "Reject triggers which skip TLS certificate verification with unsafeSsl")
+	cmd.Flags().StringSliceVar(&disabledScalers, "disabled-scalers", nil, "A comma separated list of the scaler types whose triggers are rejected, e.g. postgresql,mssql")
+	cmd.Flags().StringVar(&installIdentity, "install-identity", "", "The identity of the KEDA install whose metrics are served, must match the --install-identity of its operator when running several KEDA installs")
+
+	// The self-signed certificate is generated in a temporary directory by default, so the adapter can run with a
+	// read-only root filesystem and with the arbitrary user ids assigned by the OpenShift restricted SCCs
+	cmd.SecureServing.ServerCert.CertDirectory = filepath.Join(os.TempDir(), "apiserver.local.config", "certificates")
 	if err := cmd.Flags().Parse(os.Args); err != nil {
 		return
 	}
@@ -220,6 +240,15 @@ func main() {
 		return
 	}
 
+	customCADir := os.Getenv("KEDA_CUSTOM_CA_DIR")
+	if customCADir == "" {
+		customCADir = kedautil.DefaultCustomCADir
+	}
+	if err := kedautil.LoadCustomCAs(customCADir); err != nil {
+		logger.Error(err, "Invalid KEDA_CUSTOM_CA_DIR")
+		return
+	}
+
 	controllerMaxReconciles, err := kedautil.ResolveOsEnvInt("KEDA_METRICS_CTRL_MAX_RECONCILES", 1)
 	if err != nil {
 		logger.Error(err, "Invalid KEDA_METRICS_CTRL_MAX_RECONCILES")
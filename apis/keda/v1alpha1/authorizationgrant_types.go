@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuthorizationGrant allows a set of namespaces to use a ClusterTriggerAuthentication
+// when KEDA runs with authorization grants enforced
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:path=authorizationgrants,scope=Cluster,shortName=ag;authgrant
+// +kubebuilder:printcolumn:name="ClusterTriggerAuthentication",type="string",JSONPath=".spec.clusterTriggerAuthentication"
+// +kubebuilder:printcolumn:name="Namespaces",type="string",JSONPath=".spec.namespaces"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type AuthorizationGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AuthorizationGrantSpec `json:"spec"`
+}
+
+// AuthorizationGrantSpec defines which namespaces are allowed to reference a ClusterTriggerAuthentication
+type AuthorizationGrantSpec struct {
+	// ClusterTriggerAuthentication is the name of the granted ClusterTriggerAuthentication
+	ClusterTriggerAuthentication string `json:"clusterTriggerAuthentication"`
+
+	// Namespaces which are allowed to reference the ClusterTriggerAuthentication, "*" matches all namespaces
+	Namespaces []string `json:"namespaces"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuthorizationGrantList contains a list of AuthorizationGrant
+type AuthorizationGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []AuthorizationGrant `json:"items"`
+}
+
+// Grants returns true if the AuthorizationGrant allows the namespace to use the ClusterTriggerAuthentication
+func (g *AuthorizationGrant) Grants(clusterTriggerAuthentication, namespace string) bool {
+	if g.Spec.ClusterTriggerAuthentication != clusterTriggerAuthentication {
+		return false
+	}
+	for _, ns := range g.Spec.Namespaces {
+		if ns == namespace || ns == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	SchemeBuilder.Register(&AuthorizationGrant{}, &AuthorizationGrantList{})
+}
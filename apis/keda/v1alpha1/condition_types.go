@@ -32,6 +32,15 @@ const (
 	ConditionActive ConditionType = "Active"
 	// ConditionFallback specifies that the resource has a fallback active.
 	ConditionFallback ConditionType = "Fallback"
+	// ConditionTargetNotFound specifies that the scale target of the resource doesn't exist.
+	// Only set once the target has been checked.
+	ConditionTargetNotFound ConditionType = "TargetNotFound"
+	// ConditionPaused specifies that the scaling of the resource is paused.
+	// Only set once the resource has been paused.
+	ConditionPaused ConditionType = "Paused"
+	// ConditionPushStreamsConnected specifies that the streams of the push scalers of the resource are connected.
+	// Only set for resources with push scalers.
+	ConditionPushStreamsConnected ConditionType = "PushStreamsConnected"
 )
 
 const (
@@ -39,6 +48,29 @@ const (
 	ScaledObjectConditionReadySucccesReason = "ScaledObjectReady"
 	// ScaledObjectConditionReadySuccessMessage defines the default Message for correct ScaledObject
 	ScaledObjectConditionReadySuccessMessage = "ScaledObject is defined correctly and is ready for scaling"
+	// ScaledObjectConditionMetricSpecGenerationFailedReason defines the Reason for ScaledObject whose HPA metric specs
+	// can't be generated, the HPA keeps the last ones which could be generated
+	ScaledObjectConditionMetricSpecGenerationFailedReason = "MetricSpecGenerationFailed"
+	// ScaledObjectConditionTargetNotFoundReason defines the Reason for ScaledObject whose scale target doesn't exist
+	ScaledObjectConditionTargetNotFoundReason = "ScaleTargetNotFound"
+	// ScaledObjectConditionTargetFoundReason defines the Reason for ScaledObject whose missing scale target exists again
+	ScaledObjectConditionTargetFoundReason = "ScaleTargetFound"
+	// ScaledObjectConditionHPANotCreatedReason defines the Reason for ScaledObject whose HPA can't be created
+	ScaledObjectConditionHPANotCreatedReason = "HPANotCreated"
+	// ScaledObjectConditionHPAConflictReason defines the Reason for ScaledObject whose scale target is already
+	// scaled by an HPA which isn't owned by the ScaledObject
+	ScaledObjectConditionHPAConflictReason = "HPAConflict"
+	// ScaledObjectConditionPausedReason defines the Reason for ScaledObject paused by the paused-replicas annotation
+	ScaledObjectConditionPausedReason = "ScaledObjectPaused"
+	// ScaledObjectConditionScaleDirectionPausedReason defines the Reason for ScaledObject whose scale-down or scale-up
+	// is paused by the paused-scale-direction annotation
+	ScaledObjectConditionScaleDirectionPausedReason = "ScaledObjectScaleDirectionPaused"
+	// ScaledObjectConditionUnpausedReason defines the Reason for ScaledObject whose pause annotations were removed
+	ScaledObjectConditionUnpausedReason = "ScaledObjectUnpaused"
+	// ScaledObjectConditionPushStreamsConnectedReason defines the Reason for ScaledObject whose push scaler streams are connected
+	ScaledObjectConditionPushStreamsConnectedReason = "PushStreamsConnected"
+	// ScaledObjectConditionPushStreamDisconnectedReason defines the Reason for ScaledObject with a disconnected push scaler stream
+	ScaledObjectConditionPushStreamDisconnectedReason = "PushStreamDisconnected"
 )
 
 // Condition to store the condition state
@@ -147,6 +179,39 @@ func (c *Conditions) SetFallbackCondition(status metav1.ConditionStatus, reason
 	c.setCondition(ConditionFallback, status, reason, message)
 }
 
+// SetTargetNotFoundCondition modifies TargetNotFound Condition according to input parameters, adding it if it isn't set
+func (c *Conditions) SetTargetNotFoundCondition(status metav1.ConditionStatus, reason string, message string) {
+	for i := range *c {
+		if (*c)[i].Type == ConditionTargetNotFound {
+			c.setCondition(ConditionTargetNotFound, status, reason, message)
+			return
+		}
+	}
+	*c = append(*c, Condition{Type: ConditionTargetNotFound, Status: status, Reason: reason, Message: message})
+}
+
+// SetPausedCondition modifies Paused Condition according to input parameters, adding it if it isn't set
+func (c *Conditions) SetPausedCondition(status metav1.ConditionStatus, reason string, message string) {
+	for i := range *c {
+		if (*c)[i].Type == ConditionPaused {
+			c.setCondition(ConditionPaused, status, reason, message)
+			return
+		}
+	}
+	*c = append(*c, Condition{Type: ConditionPaused, Status: status, Reason: reason, Message: message})
+}
+
+// SetPushStreamsConnectedCondition modifies PushStreamsConnected Condition according to input parameters, adding it if it isn't set
+func (c *Conditions) SetPushStreamsConnectedCondition(status metav1.ConditionStatus, reason string, message string) {
+	for i := range *c {
+		if (*c)[i].Type == ConditionPushStreamsConnected {
+			c.setCondition(ConditionPushStreamsConnected, status, reason, message)
+			return
+		}
+	}
+	*c = append(*c, Condition{Type: ConditionPushStreamsConnected, Status: status, Reason: reason, Message: message})
+}
+
 // GetActiveCondition returns Condition of type Active
 func (c *Conditions) GetActiveCondition() Condition {
 	if *c == nil {
@@ -171,6 +236,33 @@ func (c *Conditions) GetFallbackCondition() Condition {
 	return c.getCondition(ConditionFallback)
 }
 
+// GetTargetNotFoundCondition returns Condition of type TargetNotFound, which is Unknown if it isn't set
+func (c *Conditions) GetTargetNotFoundCondition() Condition {
+	condition := c.getCondition(ConditionTargetNotFound)
+	if condition.Type == "" {
+		return Condition{Type: ConditionTargetNotFound, Status: metav1.ConditionUnknown}
+	}
+	return condition
+}
+
+// GetPushStreamsConnectedCondition returns Condition of type PushStreamsConnected, which is Unknown if it isn't set
+func (c *Conditions) GetPushStreamsConnectedCondition() Condition {
+	condition := c.getCondition(ConditionPushStreamsConnected)
+	if condition.Type == "" {
+		return Condition{Type: ConditionPushStreamsConnected, Status: metav1.ConditionUnknown}
+	}
+	return condition
+}
+
+// GetPausedCondition returns Condition of type Paused, which is Unknown if it isn't set
+func (c *Conditions) GetPausedCondition() Condition {
+	condition := c.getCondition(ConditionPaused)
+	if condition.Type == "" {
+		return Condition{Type: ConditionPaused, Status: metav1.ConditionUnknown}
+	}
+	return condition
+}
+
 func (c Conditions) getCondition(conditionType ConditionType) Condition {
 	for i := range c {
 		if c[i].Type == conditionType {
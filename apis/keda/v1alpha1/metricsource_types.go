@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=metricsources,scope=Namespaced,shortName=ms
+// +kubebuilder:printcolumn:name="Triggers",type="string",JSONPath=".spec.triggers[*].type"
+// +kubebuilder:printcolumn:name="Metrics",type="string",JSONPath=".status.externalMetricNames"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// MetricSource exposes the metrics of its triggers through the KEDA metrics server, without creating an HPA
+// or managing a scale target, so they can be consumed by other HPAs or controllers. The metrics are selected
+// with the metricsource.keda.sh/name label.
+type MetricSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MetricSourceSpec   `json:"spec"`
+	Status MetricSourceStatus `json:"status,omitempty"`
+}
+
+// MetricSourceSpec defines the triggers whose metrics are exposed
+type MetricSourceSpec struct {
+	Triggers []ScaleTriggers `json:"triggers"`
+}
+
+// MetricSourceStatus is the status for a MetricSource resource
+// +optional
+type MetricSourceStatus struct {
+	// ExternalMetricNames are the names of the external metrics of the triggers
+	// +optional
+	ExternalMetricNames []string `json:"externalMetricNames,omitempty"`
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+}
+
+// MetricSourceList contains a list of MetricSource
+// +kubebuilder:object:root=true
+type MetricSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []MetricSource `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MetricSource{}, &MetricSourceList{})
+}
@@ -62,7 +62,9 @@ type ScaledJobSpec struct {
 	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
 	// +optional
 	ScalingStrategy ScalingStrategy `json:"scalingStrategy,omitempty"`
-	Triggers        []ScaleTriggers `json:"triggers"`
+	// +optional
+	PriorityMapping []JobPriorityMapping `json:"priorityMapping,omitempty"`
+	Triggers        []ScaleTriggers      `json:"triggers"`
 }
 
 // ScaledJobStatus defines the observed state of ScaledJob
@@ -97,6 +99,16 @@ type ScalingStrategy struct {
 	MultipleScalersCalculation string `json:"multipleScalersCalculation,omitempty"`
 }
 
+// JobPriorityMapping maps a priority reported by the triggers to the priority class and deadline of the created jobs,
+// the first mapping whose priority is reported is applied
+type JobPriorityMapping struct {
+	Priority string `json:"priority"`
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
 // Rollout defines the strategy for job rollouts
 // +optional
 type Rollout struct {
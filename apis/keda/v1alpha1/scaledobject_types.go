@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -34,6 +35,9 @@ import (
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
 // +kubebuilder:printcolumn:name="Active",type="string",JSONPath=".status.conditions[?(@.type==\"Active\")].status"
 // +kubebuilder:printcolumn:name="Fallback",type="string",JSONPath=".status.conditions[?(@.type==\"Fallback\")].status"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=".status.conditions[?(@.type==\"Paused\")].status"
+// +kubebuilder:printcolumn:name="HPA",type="string",JSONPath=".status.hpaName"
+// +kubebuilder:printcolumn:name="LastActive",type="date",JSONPath=".status.lastActiveTime"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ScaledObject is a specification for a ScaledObject resource
@@ -78,36 +82,132 @@ type ScaledObjectSpec struct {
 	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
 	// +optional
 	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+	// InitialReplicaCount is applied to the scale target when KEDA first adopts it with zero replicas,
+	// so it starts at a sensible size before the first metrics are polled
+	// +optional
+	InitialReplicaCount *int32 `json:"initialReplicaCount,omitempty"`
 	// +optional
 	Advanced *AdvancedConfig `json:"advanced,omitempty"`
+	// TemplateRef references the ScalingTemplate providing the default triggers and advanced configuration
+	// +optional
+	TemplateRef *ScalingTemplateRef `json:"templateRef,omitempty"`
 
+	// Triggers can be omitted when they are all provided by the ScalingTemplate
+	// +optional
 	Triggers []ScaleTriggers `json:"triggers"`
+	// ActivationSources are request interceptors, e.g. the http-add-on, which activate the ScaledObject
+	// in addition to its triggers
+	// +optional
+	ActivationSources []ActivationSource `json:"activationSources,omitempty"`
 	// +optional
 	Fallback *Fallback `json:"fallback,omitempty"`
 }
 
+// ActivationSource is a gRPC server implementing the IsActive and StreamIsActive methods of the external
+// scaler contract. It activates the ScaledObject when a request arrives, but doesn't provide metrics to the HPA.
+type ActivationSource struct {
+	Name          string `json:"name"`
+	ScalerAddress string `json:"scalerAddress"`
+	// +optional
+	TLSCertFile string `json:"tlsCertFile,omitempty"`
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
 // Fallback is the spec for fallback options
 type Fallback struct {
 	FailureThreshold int32 `json:"failureThreshold"`
 	Replicas         int32 `json:"replicas"`
 }
 
+// TriggerFallback is the fallback of the metric of a trigger, either a replica count or a metric value
+type TriggerFallback struct {
+	FailureThreshold int32 `json:"failureThreshold"`
+	// Replicas is the replica count the metric of the trigger falls back to, its target must be an AverageValue
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+	// MetricValue is the value the metric of the trigger falls back to, for any metric type
+	// +optional
+	MetricValue *resource.Quantity `json:"metricValue,omitempty"`
+}
+
 // AdvancedConfig specifies advance scaling options
 type AdvancedConfig struct {
 	// +optional
 	HorizontalPodAutoscalerConfig *HorizontalPodAutoscalerConfig `json:"horizontalPodAutoscalerConfig,omitempty"`
 	// +optional
 	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
+	// ScalingHistoryLimit is the number of scaling decisions kept in the status, 0 disables the history
+	// +optional
+	ScalingHistoryLimit *int32 `json:"scalingHistoryLimit,omitempty"`
+	// TriggerAggregation defines how the weighted metrics of the triggers are combined, max by default
+	// +optional
+	TriggerAggregation TriggerAggregation `json:"triggerAggregation,omitempty"`
+	// ScalingModifiers combine the metrics of the triggers into a single composite metric
+	// +optional
+	ScalingModifiers *ScalingModifiers `json:"scalingModifiers,omitempty"`
+}
+
+// ScalingModifiers combine the metrics of the named triggers of a ScaledObject with a formula, the HPA scales
+// on the resulting composite metric instead of the metrics of the triggers
+type ScalingModifiers struct {
+	// Formula references the metrics of the triggers by their names, e.g. (trig1 + trig2)/2 > 10 ? trig1 : trig2,
+	// with the arithmetic, comparison, logical and ternary operators
+	Formula string `json:"formula"`
+	// Target is the target value of the composite metric
+	Target string `json:"target"`
+	// ActivationTarget is the value above which the composite metric activates the ScaledObject, 0 by default
+	// +optional
+	ActivationTarget string `json:"activationTarget,omitempty"`
+	// MetricType is the type of the target of the composite metric, AverageValue by default
+	// +kubebuilder:validation:Enum=AverageValue;Value
+	// +optional
+	MetricType autoscalingv2beta2.MetricTargetType `json:"metricType,omitempty"`
 }
 
+// TriggerAggregation defines how the weighted metrics of the triggers of a ScaledObject are combined
+// +kubebuilder:validation:Enum=max;sum
+type TriggerAggregation string
+
+const (
+	// TriggerAggregationMax scales to the highest number of replicas required by a trigger, as the HPA does
+	TriggerAggregationMax TriggerAggregation = "max"
+	// TriggerAggregationSum scales to the sum of the replicas required by the triggers, through a single
+	// external metric named WeightedSumMetricName. Only triggers with an AverageValue metric can be summed.
+	TriggerAggregationSum TriggerAggregation = "sum"
+
+	// WeightedSumMetricName is the name of the external metric of the TriggerAggregationSum aggregation
+	WeightedSumMetricName = "keda-weighted-sum"
+	// CompositeMetricName is the name of the external metric of the ScalingModifiers
+	CompositeMetricName = "keda-composite-metric"
+)
+
 // HorizontalPodAutoscalerConfig specifies horizontal scale config
 type HorizontalPodAutoscalerConfig struct {
 	// +optional
 	Behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+	// BehaviorPreset expands to curated scaleUp and scaleDown policies, a direction defined in the
+	// behavior overrides its preset
+	// +optional
+	BehaviorPreset BehaviorPreset `json:"behaviorPreset,omitempty"`
 	// +optional
 	Name string `json:"name,omitempty"`
 }
 
+// BehaviorPreset is a curated HPA behavior maintained by KEDA
+// +kubebuilder:validation:Enum=conservative;aggressive;batch
+type BehaviorPreset string
+
+const (
+	// BehaviorPresetConservative scales gradually in both directions, with long stabilization windows
+	BehaviorPresetConservative BehaviorPreset = "conservative"
+	// BehaviorPresetAggressive follows the metrics closely, doubling the replicas on scale-up
+	BehaviorPresetAggressive BehaviorPreset = "aggressive"
+	// BehaviorPresetBatch scales up at once for bursts of work and only scales down once the work has long
+	// been done, not to interrupt running jobs
+	BehaviorPresetBatch BehaviorPreset = "batch"
+)
+
 // ScaleTarget holds the a reference to the scale target Object
 type ScaleTarget struct {
 	Name string `json:"name"`
@@ -129,6 +229,37 @@ type ScaleTriggers struct {
 	AuthenticationRef *ScaledObjectAuthRef `json:"authenticationRef,omitempty"`
 	// +optional
 	MetricType autoscalingv2beta2.MetricTargetType `json:"metricType,omitempty"`
+	// Labels are added to the selector of the external metrics generated for the trigger
+	// and to the metrics exported by KEDA
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Weight multiplies the metric value of the trigger when the triggers of a ScaledObject are combined,
+	// 1 by default
+	// +optional
+	Weight *resource.Quantity `json:"weight,omitempty"`
+	// UseCachedMetrics serves the HPA the metric value of the trigger fetched by the polling loop of KEDA
+	// instead of querying the scaler on every request, ScaledObjects only
+	// +optional
+	UseCachedMetrics bool `json:"useCachedMetrics,omitempty"`
+	// Fallback overrides the fallback of the ScaledObject for the metric of the trigger, ScaledObjects only
+	// +optional
+	Fallback *TriggerFallback `json:"fallback,omitempty"`
+}
+
+// GetWeight returns the weight of the trigger, 1 if it isn't set
+func (t *ScaleTriggers) GetWeight() float64 {
+	if t.Weight == nil {
+		return 1
+	}
+	return t.Weight.AsApproximateFloat64()
+}
+
+// GetTriggerAggregation returns how the metrics of the triggers are combined, max by default
+func (so *ScaledObject) GetTriggerAggregation() TriggerAggregation {
+	if so.Spec.Advanced == nil || so.Spec.Advanced.TriggerAggregation == "" {
+		return TriggerAggregationMax
+	}
+	return so.Spec.Advanced.TriggerAggregation
 }
 
 // +k8s:openapi-gen=true
@@ -154,8 +285,41 @@ type ScaledObjectStatus struct {
 	Health map[string]HealthStatus `json:"health,omitempty"`
 	// +optional
 	PausedReplicaCount *int32 `json:"pausedReplicaCount,omitempty"`
+	// FrozenReplicaCount is the replica count pinned by the freeze-duration annotation
+	// +optional
+	FrozenReplicaCount *int32 `json:"frozenReplicaCount,omitempty"`
+	// FrozenUntil is the time the freeze-duration annotation is removed
+	// +optional
+	FrozenUntil *metav1.Time `json:"frozenUntil,omitempty"`
+	// TargetNotFoundSince is the time the scale target was first found missing
+	// +optional
+	TargetNotFoundSince *metav1.Time `json:"targetNotFoundSince,omitempty"`
 	// +optional
 	HpaName string `json:"hpaName,omitempty"`
+	// +optional
+	ScalingHistory []ScalingDecision `json:"scalingHistory,omitempty"`
+}
+
+// ScalingDecision records a change of the scale target replica count done by KEDA
+type ScalingDecision struct {
+	Time         metav1.Time `json:"time"`
+	FromReplicas int32       `json:"fromReplicas"`
+	ToReplicas   int32       `json:"toReplicas"`
+	Reason       string      `json:"reason"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AddScalingDecision appends the decision to the scaling history, dropping the oldest decisions to keep at most limit entries
+func (status *ScaledObjectStatus) AddScalingDecision(decision ScalingDecision, limit int32) {
+	if limit <= 0 {
+		status.ScalingHistory = nil
+		return
+	}
+	status.ScalingHistory = append(status.ScalingHistory, decision)
+	if overflow := len(status.ScalingHistory) - int(limit); overflow > 0 {
+		status.ScalingHistory = append([]ScalingDecision{}, status.ScalingHistory[overflow:]...)
+	}
 }
 
 // +kubebuilder:object:root=true
@@ -174,6 +338,13 @@ type ScaledObjectAuthRef struct {
 	// Kind of the resource being referred to. Defaults to TriggerAuthentication.
 	// +optional
 	Kind string `json:"kind,omitempty"`
+	// Overlay is the name of a TriggerAuthentication in the namespace of the scaled object
+	// whose parameters override the ones of the referenced resource
+	// +optional
+	Overlay string `json:"overlay,omitempty"`
+	// Parameters override the authentication parameters of the referenced resource and of the overlay
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 func init() {
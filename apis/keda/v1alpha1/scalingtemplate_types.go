@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScalingTemplate provides the triggers and the advanced configuration of the ScaledObjects referencing it
+// through spec.templateRef, so a configuration shared by many ScaledObjects is maintained in one place
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:path=scalingtemplates,scope=Cluster,shortName=st
+// +kubebuilder:printcolumn:name="Triggers",type="string",JSONPath=".spec.triggers[*].type"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ScalingTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScalingTemplateSpec `json:"spec"`
+}
+
+// ScalingTemplateSpec defines the defaults of the ScaledObjects referencing the ScalingTemplate
+type ScalingTemplateSpec struct {
+	// Triggers are added to the triggers of the ScaledObjects. A trigger of a ScaledObject with the name
+	// of a template trigger overrides it, its metadata and labels being merged with those of the template.
+	// +optional
+	Triggers []ScaleTriggers `json:"triggers,omitempty"`
+	// Advanced is used by the ScaledObjects which don't have an advanced configuration
+	// +optional
+	Advanced *AdvancedConfig `json:"advanced,omitempty"`
+}
+
+// ScalingTemplateRef references the ScalingTemplate of a ScaledObject
+type ScalingTemplateRef struct {
+	Name string `json:"name"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ScalingTemplateList contains a list of ScalingTemplate
+type ScalingTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ScalingTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScalingTemplate{}, &ScalingTemplateList{})
+}
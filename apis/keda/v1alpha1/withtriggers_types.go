@@ -44,8 +44,9 @@ type WithTriggers struct {
 
 // WithTriggersSpec is the spec for a an object with triggers resource
 type WithTriggersSpec struct {
-	PollingInterval *int32          `json:"pollingInterval,omitempty"`
-	Triggers        []ScaleTriggers `json:"triggers"`
+	PollingInterval   *int32             `json:"pollingInterval,omitempty"`
+	Triggers          []ScaleTriggers    `json:"triggers"`
+	ActivationSources []ActivationSource `json:"activationSources,omitempty"`
 }
 
 // Assert that we implement the interfaces necessary to
@@ -27,6 +27,28 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActivationSource) DeepCopyInto(out *ActivationSource) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActivationSource.
+func (in *ActivationSource) DeepCopy() *ActivationSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ActivationSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 	*out = *in
@@ -35,6 +57,16 @@ func (in *AdvancedConfig) DeepCopyInto(out *AdvancedConfig) {
 		*out = new(HorizontalPodAutoscalerConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ScalingHistoryLimit != nil {
+		in, out := &in.ScalingHistoryLimit, &out.ScalingHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScalingModifiers != nil {
+		in, out := &in.ScalingModifiers, &out.ScalingModifiers
+		*out = new(ScalingModifiers)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedConfig.
@@ -92,6 +124,84 @@ func (in *AuthSecretTargetRef) DeepCopy() *AuthSecretTargetRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationGrant) DeepCopyInto(out *AuthorizationGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationGrant.
+func (in *AuthorizationGrant) DeepCopy() *AuthorizationGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AuthorizationGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationGrantList) DeepCopyInto(out *AuthorizationGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AuthorizationGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationGrantList.
+func (in *AuthorizationGrantList) DeepCopy() *AuthorizationGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AuthorizationGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationGrantSpec) DeepCopyInto(out *AuthorizationGrantSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationGrantSpec.
+func (in *AuthorizationGrantSpec) DeepCopy() *AuthorizationGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureKeyVault) DeepCopyInto(out *AzureKeyVault) {
 	*out = *in
@@ -390,6 +500,132 @@ func (in *HorizontalPodAutoscalerConfig) DeepCopy() *HorizontalPodAutoscalerConf
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobPriorityMapping) DeepCopyInto(out *JobPriorityMapping) {
+	*out = *in
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobPriorityMapping.
+func (in *JobPriorityMapping) DeepCopy() *JobPriorityMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(JobPriorityMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricSource) DeepCopyInto(out *MetricSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSource.
+func (in *MetricSource) DeepCopy() *MetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MetricSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricSourceList) DeepCopyInto(out *MetricSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MetricSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSourceList.
+func (in *MetricSourceList) DeepCopy() *MetricSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MetricSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricSourceSpec) DeepCopyInto(out *MetricSourceSpec) {
+	*out = *in
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]ScaleTriggers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSourceSpec.
+func (in *MetricSourceSpec) DeepCopy() *MetricSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricSourceStatus) DeepCopyInto(out *MetricSourceStatus) {
+	*out = *in
+	if in.ExternalMetricNames != nil {
+		in, out := &in.ExternalMetricNames, &out.ExternalMetricNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(Conditions, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSourceStatus.
+func (in *MetricSourceStatus) DeepCopy() *MetricSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rollout) DeepCopyInto(out *Rollout) {
 	*out = *in
@@ -433,7 +669,24 @@ func (in *ScaleTriggers) DeepCopyInto(out *ScaleTriggers) {
 	if in.AuthenticationRef != nil {
 		in, out := &in.AuthenticationRef, &out.AuthenticationRef
 		*out = new(ScaledObjectAuthRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(TriggerFallback)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -541,6 +794,13 @@ func (in *ScaledJobSpec) DeepCopyInto(out *ScaledJobSpec) {
 		**out = **in
 	}
 	in.ScalingStrategy.DeepCopyInto(&out.ScalingStrategy)
+	if in.PriorityMapping != nil {
+		in, out := &in.PriorityMapping, &out.PriorityMapping
+		*out = make([]JobPriorityMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]ScaleTriggers, len(*in))
@@ -614,6 +874,13 @@ func (in *ScaledObject) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaledObjectAuthRef) DeepCopyInto(out *ScaledObjectAuthRef) {
 	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectAuthRef.
@@ -691,11 +958,21 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.InitialReplicaCount != nil {
+		in, out := &in.InitialReplicaCount, &out.InitialReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Advanced != nil {
 		in, out := &in.Advanced, &out.Advanced
 		*out = new(AdvancedConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(ScalingTemplateRef)
+		**out = **in
+	}
 	if in.Triggers != nil {
 		in, out := &in.Triggers, &out.Triggers
 		*out = make([]ScaleTriggers, len(*in))
@@ -703,6 +980,13 @@ func (in *ScaledObjectSpec) DeepCopyInto(out *ScaledObjectSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ActivationSources != nil {
+		in, out := &in.ActivationSources, &out.ActivationSources
+		*out = make([]ActivationSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Fallback != nil {
 		in, out := &in.Fallback, &out.Fallback
 		*out = new(Fallback)
@@ -764,6 +1048,26 @@ func (in *ScaledObjectStatus) DeepCopyInto(out *ScaledObjectStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.FrozenReplicaCount != nil {
+		in, out := &in.FrozenReplicaCount, &out.FrozenReplicaCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FrozenUntil != nil {
+		in, out := &in.FrozenUntil, &out.FrozenUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.TargetNotFoundSince != nil {
+		in, out := &in.TargetNotFoundSince, &out.TargetNotFoundSince
+		*out = (*in).DeepCopy()
+	}
+	if in.ScalingHistory != nil {
+		in, out := &in.ScalingHistory, &out.ScalingHistory
+		*out = make([]ScalingDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaledObjectStatus.
@@ -776,6 +1080,37 @@ func (in *ScaledObjectStatus) DeepCopy() *ScaledObjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingDecision) DeepCopyInto(out *ScalingDecision) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingDecision.
+func (in *ScalingDecision) DeepCopy() *ScalingDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingModifiers) DeepCopyInto(out *ScalingModifiers) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingModifiers.
+func (in *ScalingModifiers) DeepCopy() *ScalingModifiers {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingModifiers)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScalingStrategy) DeepCopyInto(out *ScalingStrategy) {
 	*out = *in
@@ -801,6 +1136,106 @@ func (in *ScalingStrategy) DeepCopy() *ScalingStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingTemplate) DeepCopyInto(out *ScalingTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingTemplate.
+func (in *ScalingTemplate) DeepCopy() *ScalingTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScalingTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingTemplateList) DeepCopyInto(out *ScalingTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScalingTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingTemplateList.
+func (in *ScalingTemplateList) DeepCopy() *ScalingTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScalingTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingTemplateRef) DeepCopyInto(out *ScalingTemplateRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingTemplateRef.
+func (in *ScalingTemplateRef) DeepCopy() *ScalingTemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingTemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingTemplateSpec) DeepCopyInto(out *ScalingTemplateSpec) {
+	*out = *in
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]ScaleTriggers, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Advanced != nil {
+		in, out := &in.Advanced, &out.Advanced
+		*out = new(AdvancedConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingTemplateSpec.
+func (in *ScalingTemplateSpec) DeepCopy() *ScalingTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
 	*out = *in
@@ -914,6 +1349,31 @@ func (in *TriggerAuthenticationSpec) DeepCopy() *TriggerAuthenticationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerFallback) DeepCopyInto(out *TriggerFallback) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MetricValue != nil {
+		in, out := &in.MetricValue, &out.MetricValue
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerFallback.
+func (in *TriggerFallback) DeepCopy() *TriggerFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ValueFromSecret) DeepCopyInto(out *ValueFromSecret) {
 	*out = *in
@@ -1018,6 +1478,13 @@ func (in *WithTriggersSpec) DeepCopyInto(out *WithTriggersSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ActivationSources != nil {
+		in, out := &in.ActivationSources, &out.ActivationSources
+		*out = make([]ActivationSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WithTriggersSpec.
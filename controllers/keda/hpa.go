@@ -18,6 +18,7 @@ package keda
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -26,11 +27,18 @@ import (
 	"github.com/go-logr/logr"
 	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/scaling/cache"
 	"github.com/kedacore/keda/v2/pkg/scaling/executor"
 	version "github.com/kedacore/keda/v2/version"
 )
@@ -38,8 +46,122 @@ import (
 const (
 	defaultHPAMinReplicas int32 = 1
 	defaultHPAMaxReplicas int32 = 100
+
+	// labelScaledObjectName is how the MetricsAdapter knows which ScaledObject a metric is for
+	labelScaledObjectName = "scaledobject.keda.sh/name"
+	// labelInstallIdentity is how the MetricsAdapter knows which KEDA install a metric is for
+	labelInstallIdentity = "keda.sh/install-identity"
+
+	// hpaScaleTargetIndex is the field index of the HPAs by the API group, kind and name of their scale target
+	hpaScaleTargetIndex = "spec.scaleTargetRef"
 )
 
+// metricSpecGenerationError is returned when the metric specs of the HPA can't be generated from the
+// ScaledObject, in which case the HPA keeps the metric specs of the last generation which could be generated
+type metricSpecGenerationError struct {
+	err error
+}
+
+func (e *metricSpecGenerationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *metricSpecGenerationError) Unwrap() error {
+	return e.err
+}
+
+func isMetricSpecGenerationError(err error) bool {
+	var metricSpecErr *metricSpecGenerationError
+	return errors.As(err, &metricSpecErr)
+}
+
+// hpaNotCreatedError is returned when the HPA of the ScaledObject can't be created in the cluster
+type hpaNotCreatedError struct {
+	err error
+}
+
+func (e *hpaNotCreatedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *hpaNotCreatedError) Unwrap() error {
+	return e.err
+}
+
+func isHPANotCreatedError(err error) bool {
+	var hpaErr *hpaNotCreatedError
+	return errors.As(err, &hpaErr)
+}
+
+// hpaConflictError is returned when the scale target of the ScaledObject is scaled by an HPA which the
+// ScaledObject doesn't own, KEDA doesn't create or update its HPA until the conflict is resolved
+type hpaConflictError struct {
+	hpaName string
+}
+
+func (e *hpaConflictError) Error() string {
+	return fmt.Sprintf("the scale target is already scaled by HPA %s, which isn't owned by the ScaledObject", e.hpaName)
+}
+
+func isHPAConflictError(err error) bool {
+	var conflictErr *hpaConflictError
+	return errors.As(err, &conflictErr)
+}
+
+// isHPAOwnedByScaledObject checks the HPA is controlled by a ScaledObject of the name. The UID isn't compared,
+// so the HPA of a deleted ScaledObject not yet garbage collected is taken over by the ScaledObject recreated.
+func isHPAOwnedByScaledObject(hpa *autoscalingv2beta2.HorizontalPodAutoscaler, scaledObject *kedav1alpha1.ScaledObject) bool {
+	owner := metav1.GetControllerOf(hpa)
+	return owner != nil && owner.Kind == "ScaledObject" && owner.Name == scaledObject.Name
+}
+
+// hpaScaleTargetKey returns the hpaScaleTargetIndex value of a scale target
+func hpaScaleTargetKey(group, kind, name string) string {
+	return group + "/" + kind + "/" + name
+}
+
+// indexHPAScaleTarget returns the API group, kind and name of the scale target of the HPA, indexed as hpaScaleTargetIndex
+func indexHPAScaleTarget(obj client.Object) []string {
+	hpa, ok := obj.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+	if !ok {
+		return nil
+	}
+	target := hpa.Spec.ScaleTargetRef
+	groupVersion, err := schema.ParseGroupVersion(target.APIVersion)
+	if err != nil {
+		return nil
+	}
+	return []string{hpaScaleTargetKey(groupVersion.Group, target.Kind, target.Name)}
+}
+
+// findConflictingHPA returns the name of an HPA of the namespace scaling the scale target of the ScaledObject,
+// or having the name of its HPA, which isn't owned by the ScaledObject, empty if there is none
+func (r *ScaledObjectReconciler) findConflictingHPA(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource, hpaName string) (string, error) {
+	hpaList := &autoscalingv2beta2.HorizontalPodAutoscalerList{}
+	targetKey := hpaScaleTargetKey(gvkr.Group, gvkr.Kind, scaledObject.Spec.ScaleTargetRef.Name)
+	if err := r.Client.List(ctx, hpaList, client.InNamespace(scaledObject.Namespace), client.MatchingFields{hpaScaleTargetIndex: targetKey}); err != nil {
+		return "", err
+	}
+	for i := range hpaList.Items {
+		if !isHPAOwnedByScaledObject(&hpaList.Items[i], scaledObject) {
+			return hpaList.Items[i].Name, nil
+		}
+	}
+
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: hpaName, Namespace: scaledObject.Namespace}, hpa)
+	switch {
+	case apierrors.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", err
+	case !isHPAOwnedByScaledObject(hpa, scaledObject):
+		return hpa.Name, nil
+	default:
+		return "", nil
+	}
+}
+
 // createAndDeployNewHPA creates and deploy HPA in the cluster for specified ScaledObject
 func (r *ScaledObjectReconciler) createAndDeployNewHPA(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource) error {
 	hpaName := getHPAName(scaledObject)
@@ -53,7 +175,7 @@ func (r *ScaledObjectReconciler) createAndDeployNewHPA(ctx context.Context, logg
 	err = r.Client.Create(ctx, hpa)
 	if err != nil {
 		logger.Error(err, "Failed to create new HPA in cluster", "HPA.Namespace", scaledObject.Namespace, "HPA.Name", hpaName)
-		return err
+		return &hpaNotCreatedError{err: err}
 	}
 
 	// store hpaName in the ScaledObject
@@ -77,10 +199,23 @@ func (r *ScaledObjectReconciler) newHPAForScaledObject(ctx context.Context, logg
 	}
 
 	var behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior
-	if r.kubeVersion.MinorVersion >= 18 && scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig != nil {
-		behavior = scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior
-	} else {
-		behavior = nil
+	if r.kubeVersion.MinorVersion >= 18 {
+		behavior, err = getHPABehavior(scaledObject)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pausedDirection, err := executor.GetPausedScaleDirection(scaledObject)
+	if err != nil {
+		return nil, err
+	}
+	if pausedDirection != "" {
+		if r.kubeVersion.MinorVersion >= 18 {
+			behavior = pausedScaleDirectionBehavior(behavior, pausedDirection)
+		} else {
+			logger.Info("The HPA keeps scaling in the paused direction, its behavior is only supported on Kubernetes >= 1.18", "pausedDirection", pausedDirection)
+		}
 	}
 
 	// label can have max 63 chars
@@ -156,7 +291,11 @@ func (r *ScaledObjectReconciler) updateHPAIfNeeded(ctx context.Context, logger l
 	}
 
 	// DeepDerivative ignores extra entries in arrays which makes removing the last trigger not update things, so trigger and update any time the metrics count is different.
-	if len(hpa.Spec.Metrics) != len(foundHpa.Spec.Metrics) || !equality.Semantic.DeepDerivative(hpa.Spec, foundHpa.Spec) {
+	// It ignores the unset select policies as well, which makes resuming a paused scale direction not update things.
+	upDisabled, downDisabled := scaleDirectionsDisabled(hpa.Spec.Behavior)
+	foundUpDisabled, foundDownDisabled := scaleDirectionsDisabled(foundHpa.Spec.Behavior)
+	if len(hpa.Spec.Metrics) != len(foundHpa.Spec.Metrics) || upDisabled != foundUpDisabled || downDisabled != foundDownDisabled ||
+		!equality.Semantic.DeepDerivative(hpa.Spec, foundHpa.Spec) {
 		logger.V(1).Info("Found difference in the HPA spec accordint to ScaledObject", "currentHPA", foundHpa.Spec, "newHPA", hpa.Spec)
 		if err = r.Client.Update(ctx, hpa); err != nil {
 			foundHpa.Spec = hpa.Spec
@@ -202,29 +341,63 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 	cache, err := r.scaleHandler.GetScalersCache(ctx, scaledObject)
 	if err != nil {
 		logger.Error(err, "Error getting scalers")
-		return nil, err
+		return nil, &metricSpecGenerationError{err: err}
+	}
+	if cache.BuildError != nil {
+		return nil, &metricSpecGenerationError{err: cache.BuildError}
 	}
 
-	metricSpecs := cache.GetMetricSpecForScaling(ctx)
+	for scalerIndex, scaler := range cache.GetScalers() {
+		metricSpecs := scaler.GetMetricSpecForScaling(ctx)
 
-	for _, metricSpec := range metricSpecs {
-		if metricSpec.Resource != nil {
-			resourceMetricNames = append(resourceMetricNames, string(metricSpec.Resource.Name))
+		var triggerLabels map[string]string
+		if scalerIndex < len(scaledObject.Spec.Triggers) {
+			triggerLabels = scaledObject.Spec.Triggers[scalerIndex].Labels
+			if err := validateTriggerLabels(triggerLabels); err != nil {
+				return nil, &metricSpecGenerationError{err: fmt.Errorf("invalid labels for trigger %d in ScaledObject %s: %s", scalerIndex, scaledObject.Name, err)}
+			}
 		}
 
-		if metricSpec.External != nil {
-			externalMetricName := metricSpec.External.Metric.Name
-			if kedacontrollerutil.Contains(externalMetricNames, externalMetricName) {
-				return nil, fmt.Errorf("metricName %s defined multiple times in ScaledObject %s, please refer the documentation how to define metricName manually", externalMetricName, scaledObject.Name)
+		for _, metricSpec := range metricSpecs {
+			if metricSpec.Resource != nil {
+				resourceMetricNames = append(resourceMetricNames, string(metricSpec.Resource.Name))
 			}
 
-			// add the scaledobject.keda.sh/name label. This is how the MetricsAdapter will know which scaledobject a metric is for when the HPA queries it.
-			metricSpec.External.Metric.Selector = &metav1.LabelSelector{MatchLabels: make(map[string]string)}
-			metricSpec.External.Metric.Selector.MatchLabels["scaledobject.keda.sh/name"] = scaledObject.Name
-			externalMetricNames = append(externalMetricNames, externalMetricName)
+			if metricSpec.External != nil {
+				externalMetricName := metricSpec.External.Metric.Name
+				if kedacontrollerutil.Contains(externalMetricNames, externalMetricName) {
+					return nil, &metricSpecGenerationError{err: fmt.Errorf("metricName %s defined multiple times in ScaledObject %s, please refer the documentation how to define metricName manually", externalMetricName, scaledObject.Name)}
+				}
+
+				// add the scaledobject.keda.sh/name label. This is how the MetricsAdapter will know which scaledobject a metric is for when the HPA queries it.
+				metricSpec.External.Metric.Selector = &metav1.LabelSelector{MatchLabels: make(map[string]string)}
+				for key, value := range triggerLabels {
+					metricSpec.External.Metric.Selector.MatchLabels[key] = value
+				}
+				for key, value := range r.metricSelectorLabels(scaledObject.Name) {
+					metricSpec.External.Metric.Selector.MatchLabels[key] = value
+				}
+				externalMetricNames = append(externalMetricNames, externalMetricName)
+			}
 		}
+		scaledObjectMetricSpecs = append(scaledObjectMetricSpecs, metricSpecs...)
+	}
+
+	if scaledObject.GetTriggerAggregation() == kedav1alpha1.TriggerAggregationSum {
+		scaledObjectMetricSpecs, err = weightedSumMetricSpecs(r.metricSelectorLabels(scaledObject.Name), scaledObjectMetricSpecs)
+		if err != nil {
+			return nil, &metricSpecGenerationError{err: fmt.Errorf("invalid triggerAggregation in ScaledObject %s: %s", scaledObject.Name, err)}
+		}
+		externalMetricNames = append(externalMetricNames, kedav1alpha1.WeightedSumMetricName)
+	}
+
+	if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.ScalingModifiers != nil {
+		scaledObjectMetricSpecs, err = compositeMetricSpecs(r.metricSelectorLabels(scaledObject.Name), scaledObject, scaledObjectMetricSpecs)
+		if err != nil {
+			return nil, &metricSpecGenerationError{err: fmt.Errorf("invalid scalingModifiers in ScaledObject %s: %s", scaledObject.Name, err)}
+		}
+		externalMetricNames = append(externalMetricNames, kedav1alpha1.CompositeMetricName)
 	}
-	scaledObjectMetricSpecs = append(scaledObjectMetricSpecs, metricSpecs...)
 
 	// sort metrics in ScaledObject, this way we always check the same resource in Reconcile loop and we can prevent unnecessary HPA updates,
 	// see https://github.com/kedacore/keda/issues/1531 for details
@@ -248,6 +421,128 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(ctx context.Context,
 	return scaledObjectMetricSpecs, nil
 }
 
+// metricSelectorLabels returns the labels identifying the ScaledObject and the KEDA install in the selectors of the
+// external metrics, the install identity lets the MetricsAdapter of each KEDA install reject the metrics of the others
+func (r *ScaledObjectReconciler) metricSelectorLabels(scaledObjectName string) map[string]string {
+	selectorLabels := map[string]string{labelScaledObjectName: scaledObjectName}
+	if r.InstallIdentity != "" {
+		selectorLabels[labelInstallIdentity] = r.InstallIdentity
+	}
+	return selectorLabels
+}
+
+// weightedSumMetricSpecs replaces the external metrics of the triggers with a single metric, the weighted
+// sum of the replicas they require which the MetricsAdapter computes, so the HPA adds them up instead of
+// scaling to the maximum. The replicas of a trigger are only known for AverageValue targets.
+func weightedSumMetricSpecs(selectorLabels map[string]string, metricSpecs []autoscalingv2beta2.MetricSpec) ([]autoscalingv2beta2.MetricSpec, error) {
+	weightedSpecs := make([]autoscalingv2beta2.MetricSpec, 0, len(metricSpecs))
+	for _, metricSpec := range metricSpecs {
+		if metricSpec.External == nil {
+			weightedSpecs = append(weightedSpecs, metricSpec)
+			continue
+		}
+		if metricSpec.External.Target.Type != autoscalingv2beta2.AverageValueMetricType {
+			return nil, fmt.Errorf("metric %s has a %s target, only AverageValue targets can be summed", metricSpec.External.Metric.Name, metricSpec.External.Target.Type)
+		}
+	}
+
+	weightedSpecs = append(weightedSpecs, autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.ExternalMetricSourceType,
+		External: &autoscalingv2beta2.ExternalMetricSource{
+			Metric: autoscalingv2beta2.MetricIdentifier{
+				Name:     kedav1alpha1.WeightedSumMetricName,
+				Selector: &metav1.LabelSelector{MatchLabels: selectorLabels},
+			},
+			Target: autoscalingv2beta2.MetricTarget{
+				Type:         autoscalingv2beta2.AverageValueMetricType,
+				AverageValue: resource.NewQuantity(1, resource.DecimalSI),
+			},
+		},
+	})
+	return weightedSpecs, nil
+}
+
+// pausedScaleDirectionBehavior returns a copy of the HPA behavior with the scaling in the paused direction disabled
+func pausedScaleDirectionBehavior(behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior, pausedDirection string) *autoscalingv2beta2.HorizontalPodAutoscalerBehavior {
+	paused := &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{}
+	if behavior != nil {
+		paused = behavior.DeepCopy()
+	}
+	rules := &paused.ScaleUp
+	if pausedDirection == kedacontrollerutil.PausedScaleDirectionDown {
+		rules = &paused.ScaleDown
+	}
+	if *rules == nil {
+		*rules = &autoscalingv2beta2.HPAScalingRules{}
+	}
+	disabled := autoscalingv2beta2.DisabledPolicySelect
+	(*rules).SelectPolicy = &disabled
+	return paused
+}
+
+// scaleDirectionsDisabled returns whether the scale-up and the scale-down of the HPA behavior are disabled
+func scaleDirectionsDisabled(behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior) (bool, bool) {
+	if behavior == nil {
+		return false, false
+	}
+	isDisabled := func(rules *autoscalingv2beta2.HPAScalingRules) bool {
+		return rules != nil && rules.SelectPolicy != nil && *rules.SelectPolicy == autoscalingv2beta2.DisabledPolicySelect
+	}
+	return isDisabled(behavior.ScaleUp), isDisabled(behavior.ScaleDown)
+}
+
+// compositeMetricSpecs replaces the external metrics of the triggers with the composite metric of the
+// ScalingModifiers, which the MetricsAdapter computes from the metrics of the triggers with the formula
+func compositeMetricSpecs(selectorLabels map[string]string, scaledObject *kedav1alpha1.ScaledObject, metricSpecs []autoscalingv2beta2.MetricSpec) ([]autoscalingv2beta2.MetricSpec, error) {
+	compositeMetric, err := cache.ParseScalingModifiers(scaledObject)
+	if err != nil {
+		return nil, err
+	}
+
+	compositeSpecs := make([]autoscalingv2beta2.MetricSpec, 0, len(metricSpecs))
+	for _, metricSpec := range metricSpecs {
+		if metricSpec.External == nil {
+			compositeSpecs = append(compositeSpecs, metricSpec)
+		}
+	}
+
+	target := autoscalingv2beta2.MetricTarget{Type: compositeMetric.MetricType}
+	value := resource.NewMilliQuantity(int64(compositeMetric.Target*1000), resource.DecimalSI)
+	if compositeMetric.MetricType == autoscalingv2beta2.ValueMetricType {
+		target.Value = value
+	} else {
+		target.AverageValue = value
+	}
+	compositeSpecs = append(compositeSpecs, autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.ExternalMetricSourceType,
+		External: &autoscalingv2beta2.ExternalMetricSource{
+			Metric: autoscalingv2beta2.MetricIdentifier{
+				Name:     kedav1alpha1.CompositeMetricName,
+				Selector: &metav1.LabelSelector{MatchLabels: selectorLabels},
+			},
+			Target: target,
+		},
+	})
+	return compositeSpecs, nil
+}
+
+// validateTriggerLabels checks that the trigger labels are valid label selector requirements
+// and don't override the labels identifying the ScaledObject and the KEDA install
+func validateTriggerLabels(triggerLabels map[string]string) error {
+	for key, value := range triggerLabels {
+		if key == labelScaledObjectName || key == labelInstallIdentity {
+			return fmt.Errorf("label %s is reserved", key)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %s: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid label value %s for key %s: %s", value, key, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
 func updateHealthStatus(scaledObject *kedav1alpha1.ScaledObject, externalMetricNames []string, status *kedav1alpha1.ScaledObjectStatus) {
 	health := scaledObject.Status.Health
 	newHealth := make(map[string]kedav1alpha1.HealthStatus)
@@ -263,7 +558,8 @@ func updateHealthStatus(scaledObject *kedav1alpha1.ScaledObject, externalMetricN
 // checkMinK8sVersionforHPABehavior min version (k8s v1.18) for HPA Behavior
 func (r *ScaledObjectReconciler) checkMinK8sVersionforHPABehavior(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) {
 	if r.kubeVersion.MinorVersion < 18 {
-		if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior != nil {
+		if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig != nil &&
+			(scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior != nil || scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.BehaviorPreset != "") {
 			logger.Info("Warning: Ignoring scaledObject.spec.behavior, it is only supported on kubernetes version >= 1.18", "kubernetes.version", r.kubeVersion.PrettyVersion)
 		}
 	}
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"fmt"
+
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// behaviorPresets are the HPA behaviors of the behavior presets
+var behaviorPresets = map[kedav1alpha1.BehaviorPreset]autoscalingv2beta2.HorizontalPodAutoscalerBehavior{
+	kedav1alpha1.BehaviorPresetConservative: {
+		ScaleUp: &autoscalingv2beta2.HPAScalingRules{
+			StabilizationWindowSeconds: int32Ptr(120),
+			SelectPolicy:               selectPolicyPtr(autoscalingv2beta2.MinPolicySelect),
+			Policies: []autoscalingv2beta2.HPAScalingPolicy{
+				{Type: autoscalingv2beta2.PercentScalingPolicy, Value: 25, PeriodSeconds: 60},
+				{Type: autoscalingv2beta2.PodsScalingPolicy, Value: 2, PeriodSeconds: 60},
+			},
+		},
+		ScaleDown: &autoscalingv2beta2.HPAScalingRules{
+			StabilizationWindowSeconds: int32Ptr(600),
+			SelectPolicy:               selectPolicyPtr(autoscalingv2beta2.MinPolicySelect),
+			Policies: []autoscalingv2beta2.HPAScalingPolicy{
+				{Type: autoscalingv2beta2.PercentScalingPolicy, Value: 10, PeriodSeconds: 120},
+				{Type: autoscalingv2beta2.PodsScalingPolicy, Value: 1, PeriodSeconds: 120},
+			},
+		},
+	},
+	kedav1alpha1.BehaviorPresetAggressive: {
+		ScaleUp: &autoscalingv2beta2.HPAScalingRules{
+			StabilizationWindowSeconds: int32Ptr(0),
+			SelectPolicy:               selectPolicyPtr(autoscalingv2beta2.MaxPolicySelect),
+			Policies: []autoscalingv2beta2.HPAScalingPolicy{
+				{Type: autoscalingv2beta2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+				{Type: autoscalingv2beta2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+			},
+		},
+		ScaleDown: &autoscalingv2beta2.HPAScalingRules{
+			StabilizationWindowSeconds: int32Ptr(60),
+			SelectPolicy:               selectPolicyPtr(autoscalingv2beta2.MaxPolicySelect),
+			Policies: []autoscalingv2beta2.HPAScalingPolicy{
+				{Type: autoscalingv2beta2.PercentScalingPolicy, Value: 50, PeriodSeconds: 15},
+			},
+		},
+	},
+	kedav1alpha1.BehaviorPresetBatch: {
+		ScaleUp: &autoscalingv2beta2.HPAScalingRules{
+			StabilizationWindowSeconds: int32Ptr(0),
+			SelectPolicy:               selectPolicyPtr(autoscalingv2beta2.MaxPolicySelect),
+			Policies: []autoscalingv2beta2.HPAScalingPolicy{
+				{Type: autoscalingv2beta2.PercentScalingPolicy, Value: 400, PeriodSeconds: 15},
+				{Type: autoscalingv2beta2.PodsScalingPolicy, Value: 10, PeriodSeconds: 15},
+			},
+		},
+		ScaleDown: &autoscalingv2beta2.HPAScalingRules{
+			StabilizationWindowSeconds: int32Ptr(900),
+			SelectPolicy:               selectPolicyPtr(autoscalingv2beta2.MaxPolicySelect),
+			Policies: []autoscalingv2beta2.HPAScalingPolicy{
+				{Type: autoscalingv2beta2.PercentScalingPolicy, Value: 100, PeriodSeconds: 60},
+			},
+		},
+	},
+}
+
+// getHPABehavior returns the HPA behavior of the ScaledObject, the scaleUp and scaleDown rules of its
+// behavior preset unless its behavior defines them
+func getHPABehavior(scaledObject *kedav1alpha1.ScaledObject) (*autoscalingv2beta2.HorizontalPodAutoscalerBehavior, error) {
+	if scaledObject.Spec.Advanced == nil || scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig == nil {
+		return nil, nil
+	}
+	config := scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig
+	if config.BehaviorPreset == "" {
+		return config.Behavior, nil
+	}
+
+	preset, ok := behaviorPresets[config.BehaviorPreset]
+	if !ok {
+		return nil, fmt.Errorf("unknown behaviorPreset %s, must be conservative, aggressive or batch", config.BehaviorPreset)
+	}
+	behavior := preset.DeepCopy()
+	if config.Behavior != nil && config.Behavior.ScaleUp != nil {
+		behavior.ScaleUp = config.Behavior.ScaleUp.DeepCopy()
+	}
+	if config.Behavior != nil && config.Behavior.ScaleDown != nil {
+		behavior.ScaleDown = config.Behavior.ScaleDown.DeepCopy()
+	}
+	return behavior, nil
+}
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+func selectPolicyPtr(policy autoscalingv2beta2.ScalingPolicySelect) *autoscalingv2beta2.ScalingPolicySelect {
+	return &policy
+}
@@ -24,7 +24,11 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
@@ -118,6 +122,207 @@ var _ = Describe("hpa", func() {
 		Expect(capturedScaledObject.Status.Health).To(Equal(expectedHealth))
 	})
 
+	It("should add trigger labels to the metric selector", func() {
+		scaledObject := setupTest(nil, scaler, scaleHandler)
+		scaledObject.Spec.Triggers = []v1alpha1.ScaleTriggers{{
+			Type:   "cron",
+			Labels: map[string]string{"team": "payments", "app.kubernetes.io/name": "checkout"},
+		}}
+
+		client.EXPECT().Status().Return(statusWriter)
+		statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any())
+
+		metricSpecs, err := reconciler.getScaledObjectMetricSpecs(context.Background(), logger, scaledObject)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metricSpecs).To(HaveLen(1))
+		Expect(metricSpecs[0].External.Metric.Selector.MatchLabels).To(Equal(map[string]string{
+			"scaledobject.keda.sh/name": "some scaled object name",
+			"team":                      "payments",
+			"app.kubernetes.io/name":    "checkout",
+		}))
+	})
+
+	It("should reject trigger labels overriding the ScaledObject label", func() {
+		scaledObject := setupTest(nil, scaler, scaleHandler)
+		scaledObject.Spec.Triggers = []v1alpha1.ScaleTriggers{{
+			Type:   "cron",
+			Labels: map[string]string{"scaledobject.keda.sh/name": "another scaled object"},
+		}}
+
+		_, err := reconciler.getScaledObjectMetricSpecs(context.Background(), logger, scaledObject)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject summing triggers without an AverageValue target", func() {
+		scaledObject := setupTest(nil, scaler, scaleHandler)
+		scaledObject.Spec.Advanced = &v1alpha1.AdvancedConfig{TriggerAggregation: v1alpha1.TriggerAggregationSum}
+
+		_, err := reconciler.getScaledObjectMetricSpecs(context.Background(), logger, scaledObject)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should replace the trigger metrics with their weighted sum", func() {
+		metricSpecs := []v2beta2.MetricSpec{
+			{
+				Type: v2beta2.ResourceMetricSourceType,
+				Resource: &v2beta2.ResourceMetricSource{
+					Name: "cpu",
+				},
+			},
+			{
+				Type: v2beta2.ExternalMetricSourceType,
+				External: &v2beta2.ExternalMetricSource{
+					Metric: v2beta2.MetricIdentifier{Name: "s0-queue"},
+					Target: v2beta2.MetricTarget{Type: v2beta2.AverageValueMetricType, AverageValue: resource.NewQuantity(5, resource.DecimalSI)},
+				},
+			},
+		}
+
+		reconciler.InstallIdentity = "shard-a"
+		weightedSpecs, err := weightedSumMetricSpecs(reconciler.metricSelectorLabels("some scaled object name"), metricSpecs)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(weightedSpecs).To(HaveLen(2))
+		Expect(weightedSpecs[0].Resource.Name).To(Equal(corev1.ResourceName("cpu")))
+		Expect(weightedSpecs[1].External.Metric.Name).To(Equal(v1alpha1.WeightedSumMetricName))
+		Expect(weightedSpecs[1].External.Metric.Selector.MatchLabels).To(Equal(map[string]string{
+			"scaledobject.keda.sh/name": "some scaled object name",
+			"keda.sh/install-identity":  "shard-a",
+		}))
+		Expect(weightedSpecs[1].External.Target.AverageValue.Value()).To(Equal(int64(1)))
+	})
+
+	It("should replace the trigger metrics with the composite metric of the scaling modifiers", func() {
+		metricSpecs := []v2beta2.MetricSpec{
+			{
+				Type: v2beta2.ResourceMetricSourceType,
+				Resource: &v2beta2.ResourceMetricSource{
+					Name: "cpu",
+				},
+			},
+			{
+				Type: v2beta2.ExternalMetricSourceType,
+				External: &v2beta2.ExternalMetricSource{
+					Metric: v2beta2.MetricIdentifier{Name: "s0-queue"},
+					Target: v2beta2.MetricTarget{Type: v2beta2.AverageValueMetricType, AverageValue: resource.NewQuantity(5, resource.DecimalSI)},
+				},
+			},
+		}
+		scaledObject := setupTest(nil, scaler, scaleHandler)
+		scaledObject.Spec.Triggers = []v1alpha1.ScaleTriggers{{Type: "rabbitmq", Name: "queue"}}
+		scaledObject.Spec.Advanced = &v1alpha1.AdvancedConfig{
+			ScalingModifiers: &v1alpha1.ScalingModifiers{Formula: "queue > 10 ? queue : 0", Target: "2.5", MetricType: v2beta2.ValueMetricType},
+		}
+
+		compositeSpecs, err := compositeMetricSpecs(reconciler.metricSelectorLabels(scaledObject.Name), scaledObject, metricSpecs)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(compositeSpecs).To(HaveLen(2))
+		Expect(compositeSpecs[0].Resource.Name).To(Equal(corev1.ResourceName("cpu")))
+		Expect(compositeSpecs[1].External.Metric.Name).To(Equal(v1alpha1.CompositeMetricName))
+		Expect(compositeSpecs[1].External.Target.Type).To(Equal(v2beta2.ValueMetricType))
+		Expect(compositeSpecs[1].External.Target.Value.MilliValue()).To(Equal(int64(2500)))
+	})
+
+	It("should disable the paused scale direction in the HPA behavior", func() {
+		stabilization := int32(60)
+		behavior := &v2beta2.HorizontalPodAutoscalerBehavior{
+			ScaleUp: &v2beta2.HPAScalingRules{StabilizationWindowSeconds: &stabilization},
+		}
+
+		pausedDown := pausedScaleDirectionBehavior(behavior, "down")
+		upDisabled, downDisabled := scaleDirectionsDisabled(pausedDown)
+		Expect(upDisabled).To(BeFalse())
+		Expect(downDisabled).To(BeTrue())
+		Expect(*pausedDown.ScaleUp.StabilizationWindowSeconds).To(Equal(stabilization))
+
+		upDisabled, downDisabled = scaleDirectionsDisabled(pausedScaleDirectionBehavior(nil, "up"))
+		Expect(upDisabled).To(BeTrue())
+		Expect(downDisabled).To(BeFalse())
+
+		// the behavior of the ScaledObject is left unchanged
+		upDisabled, downDisabled = scaleDirectionsDisabled(behavior)
+		Expect(upDisabled).To(BeFalse())
+		Expect(downDisabled).To(BeFalse())
+	})
+
+	It("should reject a formula referencing unknown triggers", func() {
+		scaledObject := setupTest(nil, scaler, scaleHandler)
+		scaledObject.Spec.Advanced = &v1alpha1.AdvancedConfig{
+			ScalingModifiers: &v1alpha1.ScalingModifiers{Formula: "unknown * 2", Target: "1"},
+		}
+
+		_, err := compositeMetricSpecs(reconciler.metricSelectorLabels(scaledObject.Name), scaledObject, nil)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should expand the behavior preset, keeping the directions defined in the behavior", func() {
+		stabilization := int32(30)
+		scaledObject := setupTest(nil, scaler, scaleHandler)
+		scaledObject.Spec.Advanced = &v1alpha1.AdvancedConfig{
+			HorizontalPodAutoscalerConfig: &v1alpha1.HorizontalPodAutoscalerConfig{
+				BehaviorPreset: v1alpha1.BehaviorPresetBatch,
+				Behavior: &v2beta2.HorizontalPodAutoscalerBehavior{
+					ScaleUp: &v2beta2.HPAScalingRules{StabilizationWindowSeconds: &stabilization},
+				},
+			},
+		}
+
+		behavior, err := getHPABehavior(scaledObject)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*behavior.ScaleUp.StabilizationWindowSeconds).To(Equal(stabilization))
+		Expect(behavior.ScaleUp.Policies).To(BeEmpty())
+		Expect(behavior.ScaleDown).To(Equal(behaviorPresets[v1alpha1.BehaviorPresetBatch].ScaleDown))
+		// the preset isn't modified
+		Expect(*behaviorPresets[v1alpha1.BehaviorPresetBatch].ScaleUp.StabilizationWindowSeconds).To(Equal(int32(0)))
+	})
+
+	It("should reject an unknown behavior preset", func() {
+		scaledObject := setupTest(nil, scaler, scaleHandler)
+		scaledObject.Spec.Advanced = &v1alpha1.AdvancedConfig{
+			HorizontalPodAutoscalerConfig: &v1alpha1.HorizontalPodAutoscalerConfig{BehaviorPreset: "unknown"},
+		}
+
+		_, err := getHPABehavior(scaledObject)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should index the HPAs by the API group, kind and name of their scale target", func() {
+		hpa := func(apiVersion string) *v2beta2.HorizontalPodAutoscaler {
+			return &v2beta2.HorizontalPodAutoscaler{
+				Spec: v2beta2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: v2beta2.CrossVersionObjectReference{APIVersion: apiVersion, Kind: "Deployment", Name: "app"},
+				},
+			}
+		}
+		targetKey := hpaScaleTargetKey("apps", "Deployment", "app")
+
+		Expect(indexHPAScaleTarget(hpa("apps/v1"))).To(Equal([]string{targetKey}))
+		Expect(indexHPAScaleTarget(hpa("example.com/v1"))).NotTo(ContainElement(targetKey))
+	})
+
+	It("should report the HPA having the name of the HPA of the ScaledObject as conflicting", func() {
+		scaledObject := setupTest(nil, scaler, scaleHandler)
+		gvkr := &v1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"}
+
+		client.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key types.NamespacedName, obj runtimeclient.Object) error {
+			obj.SetName(key.Name)
+			return nil
+		})
+
+		conflictingHPA, err := reconciler.findConflictingHPA(context.Background(), scaledObject, gvkr, "keda-hpa-some-name")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(conflictingHPA).To(Equal("keda-hpa-some-name"))
+	})
+
 })
 
 func setupTest(health map[string]v1alpha1.HealthStatus, scaler *mock_scalers.MockScaler, scaleHandler *mock_scaling.MockScaleHandler) *v1alpha1.ScaledObject {
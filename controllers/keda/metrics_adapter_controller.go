@@ -61,7 +61,7 @@ func (r *MetricsScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.
 			if err != nil {
 				reqLogger.Error(err, "error clearing scalers cache")
 			}
-			r.removeFromMetricsCache(req.NamespacedName.String())
+			removeFromMetricsCache(r.ExternalMetricsInfo, r.ExternalMetricsInfoLock, req.NamespacedName.String())
 			return ctrl.Result{}, err
 		}
 		// Error reading the object - requeue the request.
@@ -77,7 +77,7 @@ func (r *MetricsScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.
 		if err != nil {
 			reqLogger.Error(err, "error clearing scalers cache")
 		}
-		r.removeFromMetricsCache(req.NamespacedName.String())
+		removeFromMetricsCache(r.ExternalMetricsInfo, r.ExternalMetricsInfoLock, req.NamespacedName.String())
 		return ctrl.Result{}, err
 	}
 
@@ -88,7 +88,7 @@ func (r *MetricsScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	r.addToMetricsCache(req.NamespacedName.String(), scaledObject.Status.ExternalMetricNames)
+	addToMetricsCache(r.ExternalMetricsInfo, r.ExternalMetricsInfoLock, req.NamespacedName.String(), scaledObject.Status.ExternalMetricNames)
 	err = r.ScaleHandler.ClearScalersCache(ctx, scaledObject)
 	if err != nil {
 		reqLogger.Error(err, "error clearing scalers cache")
@@ -104,39 +104,92 @@ func (r *MetricsScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, optio
 		Complete(r)
 }
 
-func (r *MetricsScaledObjectReconciler) addToMetricsCache(namespacedName string, metrics []string) {
+// addToMetricsCache sets the external metrics of the object, the ScaledObjects and the MetricSources
+// share the external metrics listed by the metrics server
+func addToMetricsCache(externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex, key string, metrics []string) {
 	scaledObjectsMetricsLock.Lock()
 	defer scaledObjectsMetricsLock.Unlock()
-	scaledObjectsMetrics[namespacedName] = metrics
+	scaledObjectsMetrics[key] = metrics
 	extMetrics := populateExternalMetrics(scaledObjectsMetrics)
 
-	r.ExternalMetricsInfoLock.Lock()
-	defer r.ExternalMetricsInfoLock.Unlock()
-	(*r.ExternalMetricsInfo) = extMetrics
+	externalMetricsInfoLock.Lock()
+	defer externalMetricsInfoLock.Unlock()
+	(*externalMetricsInfo) = extMetrics
 }
 
-func (r *MetricsScaledObjectReconciler) removeFromMetricsCache(namespacedName string) {
+func removeFromMetricsCache(externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex, key string) {
 	scaledObjectsMetricsLock.Lock()
 	defer scaledObjectsMetricsLock.Unlock()
-	delete(scaledObjectsMetrics, namespacedName)
+	delete(scaledObjectsMetrics, key)
 	extMetrics := populateExternalMetrics(scaledObjectsMetrics)
 
 	// the metric could have been already removed by the previous call
-	// in this case we don't have to rewrite r.ExternalMetricsInfo
+	// in this case we don't have to rewrite externalMetricsInfo
 	changed := false
-	r.ExternalMetricsInfoLock.RLock()
-	if len(*r.ExternalMetricsInfo) != len(extMetrics) {
+	externalMetricsInfoLock.RLock()
+	if len(*externalMetricsInfo) != len(extMetrics) {
 		changed = true
 	}
-	r.ExternalMetricsInfoLock.RUnlock()
+	externalMetricsInfoLock.RUnlock()
 
 	if changed {
-		r.ExternalMetricsInfoLock.Lock()
-		defer r.ExternalMetricsInfoLock.Unlock()
-		(*r.ExternalMetricsInfo) = extMetrics
+		externalMetricsInfoLock.Lock()
+		defer externalMetricsInfoLock.Unlock()
+		(*externalMetricsInfo) = extMetrics
 	}
 }
 
+// MetricsMetricSourceReconciler lists the external metrics of the MetricSources in the metrics server
+type MetricsMetricSourceReconciler struct {
+	Client                  client.Client
+	ScaleHandler            scaling.ScaleHandler
+	ExternalMetricsInfo     *[]provider.ExternalMetricInfo
+	ExternalMetricsInfoLock *sync.RWMutex
+}
+
+func (r *MetricsMetricSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+	// the MetricSources and the ScaledObjects can have the same name
+	key := "metricsource/" + req.NamespacedName.String()
+
+	metricSource := &kedav1alpha1.MetricSource{}
+	err := r.Client.Get(ctx, req.NamespacedName, metricSource)
+	if err != nil && !errors.IsNotFound(err) {
+		reqLogger.Error(err, "Failed to get MetricSource")
+		return ctrl.Result{}, err
+	}
+	if err != nil || metricSource.GetDeletionTimestamp() != nil {
+		metricSource.Name, metricSource.Namespace = req.Name, req.Namespace
+		err := r.ScaleHandler.ClearScalersCache(ctx, metricSource)
+		if err != nil {
+			reqLogger.Error(err, "error clearing scalers cache")
+		}
+		removeFromMetricsCache(r.ExternalMetricsInfo, r.ExternalMetricsInfoLock, key)
+		return ctrl.Result{}, err
+	}
+
+	reqLogger.V(1).Info("Reconciling MetricSource", "externalMetricNames", metricSource.Status.ExternalMetricNames)
+
+	// the external metric names are set by the operator once the scalers could be built
+	if len(metricSource.Status.ExternalMetricNames) < 1 {
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	addToMetricsCache(r.ExternalMetricsInfo, r.ExternalMetricsInfoLock, key, metricSource.Status.ExternalMetricNames)
+	err = r.ScaleHandler.ClearScalersCache(ctx, metricSource)
+	if err != nil {
+		reqLogger.Error(err, "error clearing scalers cache")
+	}
+	return ctrl.Result{}, err
+}
+
+func (r *MetricsMetricSourceReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kedav1alpha1.MetricSource{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithOptions(options).
+		Complete(r)
+}
+
 func populateExternalMetrics(scaledObjectsMetrics map[string][]string) []provider.ExternalMetricInfo {
 	externalMetrics := []provider.ExternalMetricInfo{}
 	for _, metrics := range scaledObjectsMetrics {
@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/scaling"
+)
+
+// +kubebuilder:rbac:groups=keda.sh,resources=metricsources;metricsources/status,verbs="*"
+
+// MetricSourceReconciler reconciles a MetricSource object, it validates its triggers and publishes the names
+// of their external metrics. Unlike a ScaledObject, a MetricSource has no scale loop and no HPA.
+type MetricSourceReconciler struct {
+	client.Client
+	Scheme            *runtime.Scheme
+	GlobalHTTPTimeout time.Duration
+	ForbidUnsafeSsl   bool
+	DisabledScalers   []string
+	Recorder          record.EventRecorder
+
+	scaleHandler scaling.ScaleHandler
+}
+
+// SetupWithManager initializes the MetricSourceReconciler instance and starts a new controller managed by the passed Manager instance.
+func (r *MetricSourceReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, r.ForbidUnsafeSsl, r.DisabledScalers, mgr.GetEventRecorderFor("scale-handler"))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		// Ignore updates to MetricSource Status (in this case metadata.Generation does not change)
+		For(&kedav1alpha1.MetricSource{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile performs reconciliation on the identified MetricSource resource based on the request information passed, returns the result and an error (if any).
+func (r *MetricSourceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	metricSource := &kedav1alpha1.MetricSource{}
+	err := r.Client.Get(ctx, req.NamespacedName, metricSource)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// the metrics server stops serving the metrics of the deleted MetricSource on its own
+			return ctrl.Result{}, nil
+		}
+		reqLogger.Error(err, "Failed to get MetricSource")
+		return ctrl.Result{}, err
+	}
+
+	reqLogger.Info("Reconciling MetricSource")
+
+	status := metricSource.Status.DeepCopy()
+	if status.Conditions == nil {
+		status.Conditions = kedav1alpha1.Conditions{{Type: kedav1alpha1.ConditionReady, Status: metav1.ConditionUnknown}}
+	}
+	metricNames, err := r.getExternalMetricNames(ctx, metricSource)
+	if err != nil {
+		msg := fmt.Sprintf("Failed to build the scalers of the MetricSource: %s", err)
+		reqLogger.Error(err, "Failed to build the scalers of the MetricSource")
+		status.Conditions.SetReadyCondition(metav1.ConditionFalse, "MetricSourceCheckFailed", msg)
+		r.Recorder.Event(metricSource, corev1.EventTypeWarning, eventreason.MetricSourceCheckFailed, msg)
+	} else {
+		if wasReady := status.Conditions.GetReadyCondition(); !wasReady.IsTrue() {
+			r.Recorder.Event(metricSource, corev1.EventTypeNormal, eventreason.MetricSourceReady, "MetricSource is ready to serve its metrics")
+		}
+		status.ExternalMetricNames = metricNames
+		status.Conditions.SetReadyCondition(metav1.ConditionTrue, "MetricSourceReady", "MetricSource is defined correctly and its metrics are served")
+	}
+
+	patch := client.MergeFrom(metricSource.DeepCopy())
+	metricSource.Status = *status
+	if err := r.Client.Status().Patch(ctx, metricSource, patch); err != nil {
+		reqLogger.Error(err, "Failed to patch MetricSource Status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, err
+}
+
+// getExternalMetricNames builds the scalers of the MetricSource and returns the names of their external metrics,
+// the scalers are only kept by the metrics server
+func (r *MetricSourceReconciler) getExternalMetricNames(ctx context.Context, metricSource *kedav1alpha1.MetricSource) ([]string, error) {
+	if len(metricSource.Spec.Triggers) == 0 {
+		return nil, fmt.Errorf("no triggers defined in the MetricSource")
+	}
+
+	cache, err := r.scaleHandler.GetScalersCache(ctx, metricSource)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := r.scaleHandler.ClearScalersCache(ctx, metricSource); err != nil {
+			log.FromContext(ctx).Error(err, "error clearing scalers cache")
+		}
+	}()
+	if cache.BuildError != nil {
+		return nil, cache.BuildError
+	}
+
+	var metricNames []string
+	for scalerIndex, scaler := range cache.GetScalers() {
+		for _, metricSpec := range scaler.GetMetricSpecForScaling(ctx) {
+			if metricSpec.External == nil {
+				return nil, fmt.Errorf("trigger %d has no external metric, the cpu and memory triggers can't be used in a MetricSource", scalerIndex)
+			}
+			metricName := metricSpec.External.Metric.Name
+			if kedacontrollerutil.Contains(metricNames, metricName) {
+				return nil, fmt.Errorf("metricName %s defined multiple times in MetricSource %s", metricName, metricSource.Name)
+			}
+			metricNames = append(metricNames, metricName)
+		}
+	}
+	return metricNames, nil
+}
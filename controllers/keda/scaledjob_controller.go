@@ -49,6 +49,8 @@ type ScaledJobReconciler struct {
 	client.Client
 	Scheme            *runtime.Scheme
 	GlobalHTTPTimeout time.Duration
+	ForbidUnsafeSsl   bool
+	DisabledScalers   []string
 	Recorder          record.EventRecorder
 
 	scaleHandler scaling.ScaleHandler
@@ -56,13 +58,16 @@ type ScaledJobReconciler struct {
 
 // SetupWithManager initializes the ScaledJobReconciler instance and starts a new controller managed by the passed Manager instance.
 func (r *ScaledJobReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
-	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, mgr.GetEventRecorderFor("scale-handler"))
+	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), nil, mgr.GetScheme(), r.GlobalHTTPTimeout, r.ForbidUnsafeSsl, r.DisabledScalers, mgr.GetEventRecorderFor("scale-handler"))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// Ignore updates to ScaledJob Status (in this case metadata.Generation does not change)
-		// so reconcile loop is not started on Status updates
-		For(&kedav1alpha1.ScaledJob{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// so reconcile loop is not started on Status updates, nor on the annotations written by the polling loop
+		For(&kedav1alpha1.ScaledJob{}, builder.WithPredicates(
+			kedacontrollerutil.PollingLoopAnnotationsPredicate{},
+			predicate.GenerationChangedPredicate{},
+		)).
 		Complete(r)
 }
 
@@ -143,7 +148,10 @@ func (r *ScaledJobReconciler) reconcileScaledJob(ctx context.Context, logger log
 	}
 
 	// Check ScaledJob is Ready or not
-	_, err = r.scaleHandler.GetScalersCache(ctx, scaledJob)
+	cache, err := r.scaleHandler.GetScalersCache(ctx, scaledJob)
+	if err == nil {
+		err = cache.BuildError
+	}
 	if err != nil {
 		logger.Error(err, "Error getting scalers")
 		return "Failed to ensure ScaledJob is correctly created", err
@@ -31,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
@@ -41,33 +42,44 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 // +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects;scaledobjects/finalizers;scaledobjects/status,verbs="*"
+// +kubebuilder:rbac:groups=keda.sh,resources=scalingtemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs="*"
 // +kubebuilder:rbac:groups="",resources=configmaps;configmaps/status;events,verbs="*"
-// +kubebuilder:rbac:groups="",resources=pods;services;services;secrets;external,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes;pods;services;services;secrets;external,verbs=get;list;watch
 // +kubebuilder:rbac:groups="*",resources="*/scale",verbs="*"
 // +kubebuilder:rbac:groups="",resources="serviceaccounts",verbs=list;watch
 // +kubebuilder:rbac:groups="*",resources="*",verbs=get
 // +kubebuilder:rbac:groups="apps",resources=deployments;statefulsets,verbs=list;watch
 // +kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs="*"
+// +kubebuilder:rbac:groups="kubevirt.io",resources=virtualmachineinstancemigrations;virtualmachines,verbs=list
 
 // ScaledObjectReconciler reconciles a ScaledObject object
 type ScaledObjectReconciler struct {
 	Client            client.Client
 	Scheme            *runtime.Scheme
 	GlobalHTTPTimeout time.Duration
+	ForbidUnsafeSsl   bool
+	DisabledScalers   []string
 	Recorder          record.EventRecorder
+	// InstallIdentity is added to the selectors of the external metrics of the HPAs,
+	// so the MetricsAdapter of a sharded KEDA install only serves its own metrics
+	InstallIdentity string
 
 	scaleClient              scale.ScalesGetter
 	restMapper               meta.RESTMapper
@@ -114,25 +126,53 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager, options cont
 	// Init the rest of ScaledObjectReconciler
 	r.restMapper = mgr.GetRESTMapper()
 	r.scaledObjectsGenerations = &sync.Map{}
-	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), r.scaleClient, mgr.GetScheme(), r.GlobalHTTPTimeout, r.Recorder)
+	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), r.scaleClient, mgr.GetScheme(), r.GlobalHTTPTimeout, r.ForbidUnsafeSsl, r.DisabledScalers, r.Recorder)
+
+	// the HPAs are looked up by their scale target in the cache of the owned HPAs to find the conflicting ones
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &autoscalingv2beta2.HorizontalPodAutoscaler{}, hpaScaleTargetIndex, indexHPAScaleTarget); err != nil {
+		setupLog.Error(err, "Not able to index the HPAs by scale target")
+		return err
+	}
 
 	// Start controller
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		// predicate.GenerationChangedPredicate{} ignore updates to ScaledObject Status
 		// (in this case metadata.Generation does not change)
-		// so reconcile loop is not started on Status updates
+		// so reconcile loop is not started on Status updates, nor on the annotations written by the polling loop
 		For(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(
+			kedacontrollerutil.PollingLoopAnnotationsPredicate{},
 			predicate.Or(
 				kedacontrollerutil.PausedReplicasPredicate{},
+				kedacontrollerutil.FreezeDurationPredicate{},
+				kedacontrollerutil.PausedScaleDirectionPredicate{},
 				kedacontrollerutil.ScaleObjectReadyConditionPredicate{},
 				predicate.GenerationChangedPredicate{},
 			),
 		)).
 		Owns(&autoscalingv2beta2.HorizontalPodAutoscaler{}).
+		Watches(&source.Kind{Type: &kedav1alpha1.ScalingTemplate{}}, handler.EnqueueRequestsFromMapFunc(r.scaledObjectsForScalingTemplate)).
 		Complete(r)
 }
 
+// scaledObjectsForScalingTemplate returns the ScaledObjects referencing the ScalingTemplate, so their HPA
+// is updated when the template changes
+func (r *ScaledObjectReconciler) scaledObjectsForScalingTemplate(obj client.Object) []reconcile.Request {
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := r.Client.List(context.Background(), scaledObjects); err != nil {
+		log.Log.Error(err, "Failed to list the ScaledObjects referencing ScalingTemplate", "scalingTemplate", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, scaledObject := range scaledObjects.Items {
+		if scaledObject.Spec.TemplateRef != nil && scaledObject.Spec.TemplateRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: scaledObject.Namespace, Name: scaledObject.Name}})
+		}
+	}
+	return requests
+}
+
 func initScaleClient(mgr manager.Manager, clientset *discovery.DiscoveryClient) scale.ScalesGetter {
 	scaleKindResolver := scale.NewDiscoveryScaleKindResolver(clientset)
 	return scale.New(
@@ -185,12 +225,32 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	// reconcile ScaledObject and set status appropriately
 	msg, err := r.reconcileScaledObject(ctx, reqLogger, scaledObject)
 	conditions := scaledObject.Status.Conditions.DeepCopy()
-	if err != nil {
+	switch {
+	case err != nil && isMetricSpecGenerationError(err):
+		// the HPA and the scalers keep the metric specs of the last generation which could be generated
+		reqLogger.Error(err, msg)
+		msg = fmt.Sprintf("%s, the HPA keeps the last metric specs which could be generated: %s", msg, err)
+		conditions.SetReadyCondition(metav1.ConditionFalse, kedav1alpha1.ScaledObjectConditionMetricSpecGenerationFailedReason, msg)
+		r.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.ScaledObjectMetricSpecGenerationFailed, msg)
+	case err != nil && isHPAConflictError(err):
+		// KEDA leaves the scale target to the other HPA until it is removed
+		reqLogger.Error(err, msg)
+		msg = fmt.Sprintf("%s: %s", msg, err)
+		conditions.SetReadyCondition(metav1.ConditionFalse, kedav1alpha1.ScaledObjectConditionHPAConflictReason, msg)
+		conditions.SetActiveCondition(metav1.ConditionUnknown, "UnkownState", "ScaledObject check failed")
+		r.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.ScaledObjectHPAConflict, msg)
+	case err != nil && isHPANotCreatedError(err):
+		reqLogger.Error(err, msg)
+		msg = fmt.Sprintf("%s: %s", msg, err)
+		conditions.SetReadyCondition(metav1.ConditionFalse, kedav1alpha1.ScaledObjectConditionHPANotCreatedReason, msg)
+		conditions.SetActiveCondition(metav1.ConditionUnknown, "UnkownState", "ScaledObject check failed")
+		r.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.ScaledObjectHPANotCreated, msg)
+	case err != nil:
 		reqLogger.Error(err, msg)
 		conditions.SetReadyCondition(metav1.ConditionFalse, "ScaledObjectCheckFailed", msg)
 		conditions.SetActiveCondition(metav1.ConditionUnknown, "UnkownState", "ScaledObject check failed")
 		r.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.ScaledObjectCheckFailed, msg)
-	} else {
+	default:
 		wasReady := conditions.GetReadyCondition()
 		if wasReady.IsFalse() || wasReady.IsUnknown() {
 			r.Recorder.Event(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectReady, "ScaledObject is ready for scaling")
@@ -199,11 +259,47 @@ func (r *ScaledObjectReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		conditions.SetReadyCondition(metav1.ConditionTrue, kedav1alpha1.ScaledObjectConditionReadySucccesReason, msg)
 	}
 
+	r.updatePausedCondition(scaledObject, &conditions)
+
 	if err := kedacontrollerutil.SetStatusConditions(ctx, r.Client, reqLogger, scaledObject, &conditions); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, err
+	// reconcile again once the freeze has elapsed to unfreeze the ScaledObject
+	result := ctrl.Result{}
+	if frozenUntil := scaledObject.Status.FrozenUntil; frozenUntil != nil {
+		result.RequeueAfter = time.Until(frozenUntil.Time) + time.Second
+	}
+	return result, err
+}
+
+// updatePausedCondition reflects the paused-replicas and paused-scale-direction annotations of the ScaledObject
+// in its Paused condition, which is only added once the ScaledObject has been paused
+func (r *ScaledObjectReconciler) updatePausedCondition(scaledObject *kedav1alpha1.ScaledObject, conditions *kedav1alpha1.Conditions) {
+	pausedReplicas, paused := scaledObject.GetAnnotations()[kedacontrollerutil.PausedReplicasAnnotation]
+	reason := kedav1alpha1.ScaledObjectConditionPausedReason
+	msg := fmt.Sprintf("ScaledObject is paused at %s replicas", pausedReplicas)
+	if pausedDirection, ok := scaledObject.GetAnnotations()[kedacontrollerutil.PausedScaleDirectionAnnotation]; ok && !paused {
+		paused = true
+		reason = kedav1alpha1.ScaledObjectConditionScaleDirectionPausedReason
+		msg = fmt.Sprintf("ScaledObject scale-%s is paused", pausedDirection)
+	}
+
+	wasPaused := conditions.GetPausedCondition()
+	switch {
+	case paused:
+		if wasPaused.IsTrue() && wasPaused.Message == msg {
+			return
+		}
+		conditions.SetPausedCondition(metav1.ConditionTrue, reason, msg)
+		if !wasPaused.IsTrue() {
+			r.Recorder.Event(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectPaused, msg)
+		}
+	case wasPaused.IsTrue():
+		msg := "ScaledObject is no longer paused"
+		conditions.SetPausedCondition(metav1.ConditionFalse, kedav1alpha1.ScaledObjectConditionUnpausedReason, msg)
+		r.Recorder.Event(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectUnpaused, msg)
+	}
 }
 
 // reconcileScaledObject implements reconciler logic for ScaledObject
@@ -231,9 +327,28 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 		return "ScaledObject doesn't have correct Idle/Min/Max Replica Counts specification", err
 	}
 
+	err = r.reconcileFreeze(ctx, logger, scaledObject, gvkr)
+	if err != nil {
+		return "Failed to freeze the replica count of ScaledObject", err
+	}
+
+	// the HPA and the scale loop use the triggers and the advanced configuration of the ScalingTemplate,
+	// which are never written to the ScaledObject
+	resolved, _, err := resolver.ResolveScalingTemplate(ctx, r.Client, scaledObject)
+	if err != nil {
+		return "Failed to resolve the ScalingTemplate of ScaledObject", err
+	}
+
 	// Create a new HPA or update existing one according to ScaledObject
-	newHPACreated, err := r.ensureHPAForScaledObjectExists(ctx, logger, scaledObject, &gvkr)
+	newHPACreated, err := r.ensureHPAForScaledObjectExists(ctx, logger, resolved, &gvkr)
+	scaledObject.Status = resolved.Status
 	if err != nil {
+		if isMetricSpecGenerationError(err) {
+			return "Failed to generate the HPA metric specs for ScaledObject", err
+		}
+		if isHPAConflictError(err) {
+			return "ScaledObject's scale target is scaled by another HPA", err
+		}
 		return "Failed to ensure HPA is correctly created for ScaledObject", err
 	}
 	scaleObjectSpecChanged := false
@@ -249,7 +364,7 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 
 	// Notify ScaleHandler if a new HPA was created or if ScaledObject was updated
 	if newHPACreated || scaleObjectSpecChanged {
-		if r.requestScaleLoop(ctx, logger, scaledObject) != nil {
+		if r.requestScaleLoop(ctx, logger, resolved) != nil {
 			return "Failed to start a new scale loop with scaling logic", err
 		}
 		logger.Info("Initializing Scaling logic according to ScaledObject Specification")
@@ -260,8 +375,6 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(ctx context.Context, logg
 // ensureScaledObjectLabel ensures that scaledobject.keda.sh/name=<scaledObject.Name> label exist in the ScaledObject
 // This is how the MetricsAdapter will know which ScaledObject a metric is for when the HPA queries it.
 func (r *ScaledObjectReconciler) ensureScaledObjectLabel(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
-	const labelScaledObjectName = "scaledobject.keda.sh/name"
-
 	if scaledObject.Labels == nil {
 		scaledObject.Labels = map[string]string{labelScaledObjectName: scaledObject.Name}
 	} else {
@@ -327,6 +440,9 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 		}
 		if scaledObject.Status.OriginalReplicaCount == nil {
 			status.OriginalReplicaCount = &scale.Spec.Replicas
+			if err := r.applyInitialReplicaCount(ctx, logger, scaledObject, gr, scale); err != nil {
+				return gvkr, err
+			}
 		}
 
 		if removePausedStatus {
@@ -342,6 +458,90 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(ctx context.Conte
 	return gvkr, nil
 }
 
+// applyInitialReplicaCount scales the target KEDA adopts for the first time to the initialReplicaCount when it has
+// no replicas, so it doesn't wait for the first metrics to start. Paused ScaledObjects keep their paused replicas.
+func (r *ScaledObjectReconciler) applyInitialReplicaCount(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gr schema.GroupResource, scale *autoscalingv1.Scale) error {
+	initialReplicaCount := scaledObject.Spec.InitialReplicaCount
+	if initialReplicaCount == nil || *initialReplicaCount == 0 || scale.Spec.Replicas != 0 {
+		return nil
+	}
+	if _, paused := scaledObject.GetAnnotations()[kedacontrollerutil.PausedReplicasAnnotation]; paused {
+		return nil
+	}
+	if err := r.checkReplicaCountBoundsAreValid(scaledObject); err != nil {
+		return err
+	}
+
+	scale = scale.DeepCopy()
+	scale.Spec.Replicas = *initialReplicaCount
+	if _, err := r.scaleClient.Scales(scaledObject.Namespace).Update(ctx, gr, scale, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	logger.Info("Applied initial replica count to scale target", "replicas", *initialReplicaCount)
+	r.Recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectInitialReplicaCountApplied, "Scaled target to the initial replica count %d", *initialReplicaCount)
+	return nil
+}
+
+// reconcileFreeze pins the current replica count of the scale target when the freeze-duration annotation is added
+// and removes the annotation once the duration has elapsed
+func (r *ScaledObjectReconciler) reconcileFreeze(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr kedav1alpha1.GroupVersionKindResource) error {
+	val, present := scaledObject.GetAnnotations()[kedacontrollerutil.FreezeDurationAnnotation]
+	now := time.Now()
+	status := scaledObject.Status.DeepCopy()
+
+	switch {
+	case !present:
+		if scaledObject.Status.FrozenUntil == nil {
+			return nil
+		}
+		// the annotation has been removed before the freeze elapsed
+		status.FrozenReplicaCount = nil
+		status.FrozenUntil = nil
+		if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status); err != nil {
+			return err
+		}
+		r.Recorder.Event(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectUnfrozen, "Replica count is no longer frozen")
+		return nil
+
+	case scaledObject.Status.FrozenUntil == nil:
+		duration, err := time.ParseDuration(val)
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("%s must be a positive duration, got %q", kedacontrollerutil.FreezeDurationAnnotation, val)
+		}
+		scale, err := r.scaleClient.Scales(scaledObject.Namespace).Get(ctx, gvkr.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		frozenUntil := metav1.NewTime(now.Add(duration))
+		status.FrozenReplicaCount = &scale.Spec.Replicas
+		status.FrozenUntil = &frozenUntil
+		if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status); err != nil {
+			return err
+		}
+		logger.Info("Froze replica count", "replicas", scale.Spec.Replicas, "until", frozenUntil)
+		r.Recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectFrozen, "Replica count frozen at %d until %s", scale.Spec.Replicas, frozenUntil.Format(time.RFC3339))
+		return nil
+
+	case !now.Before(scaledObject.Status.FrozenUntil.Time):
+		patch := client.MergeFrom(scaledObject.DeepCopy())
+		annotations := scaledObject.GetAnnotations()
+		delete(annotations, kedacontrollerutil.FreezeDurationAnnotation)
+		scaledObject.SetAnnotations(annotations)
+		if err := r.Client.Patch(ctx, scaledObject, patch); err != nil {
+			return err
+		}
+		status.FrozenReplicaCount = nil
+		status.FrozenUntil = nil
+		if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, r.Client, logger, scaledObject, status); err != nil {
+			return err
+		}
+		logger.Info("Unfroze replica count")
+		r.Recorder.Event(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectUnfrozen, "Replica count is no longer frozen, the freeze duration has elapsed")
+		return nil
+	}
+	return nil
+}
+
 // checkReplicaCountBoundsAreValid checks that Idle/Min/Max ReplicaCount defined in ScaledObject are correctly specified
 // ie. that Min is not greater then Max or Idle greater or equal to Min
 func (r *ScaledObjectReconciler) checkReplicaCountBoundsAreValid(scaledObject *kedav1alpha1.ScaledObject) error {
@@ -359,6 +559,10 @@ func (r *ScaledObjectReconciler) checkReplicaCountBoundsAreValid(scaledObject *k
 		return fmt.Errorf("IdleReplicaCount=%d must be less than MinReplicaCount=%d", *scaledObject.Spec.IdleReplicaCount, min)
 	}
 
+	if initial := scaledObject.Spec.InitialReplicaCount; initial != nil && (*initial < 0 || *initial > max) {
+		return fmt.Errorf("InitialReplicaCount=%d must be between 0 and MaxReplicaCount=%d", *initial, max)
+	}
+
 	return nil
 }
 
@@ -370,9 +574,19 @@ func (r *ScaledObjectReconciler) ensureHPAForScaledObjectExists(ctx context.Cont
 	} else {
 		hpaName = getHPAName(scaledObject)
 	}
+	// Check the scale target isn't scaled by another HPA, they would fight over its replica count
+	conflictingHpa, err := r.findConflictingHPA(ctx, scaledObject, gvkr, hpaName)
+	if err != nil {
+		logger.Error(err, "Failed to list HPAs from cluster")
+		return false, err
+	}
+	if conflictingHpa != "" {
+		return false, &hpaConflictError{hpaName: conflictingHpa}
+	}
+
 	foundHpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
 	// Check if HPA for this ScaledObject already exists
-	err := r.Client.Get(ctx, types.NamespacedName{Name: hpaName, Namespace: scaledObject.Namespace}, foundHpa)
+	err = r.Client.Get(ctx, types.NamespacedName{Name: hpaName, Namespace: scaledObject.Namespace}, foundHpa)
 	if err != nil && errors.IsNotFound(err) {
 		// HPA wasn't found -> let's create a new one
 		err = r.createAndDeployNewHPA(ctx, logger, scaledObject, gvkr)
@@ -659,6 +659,264 @@ var _ = Describe("ScaledObjectController", func() {
 				return so.Status.Conditions.GetReadyCondition().Status
 			}, 20*time.Second).Should(Equal(metav1.ConditionFalse))
 		})
+
+		It("applies InitialReplicaCount to a scale target without replicas", func() {
+			deploymentName := "initial"
+			soName := "so-" + deploymentName
+
+			// Create the scaling target without replicas.
+			var zero int32
+			deployment := generateDeployment(deploymentName)
+			deployment.Spec.Replicas = &zero
+			err := k8sClient.Create(context.Background(), deployment)
+			Expect(err).ToNot(HaveOccurred())
+
+			var one int32 = 1
+			var three int32 = 3
+
+			// Create the ScaledObject
+			so := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: soName, Namespace: "default"},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+						Name: deploymentName,
+					},
+					MinReplicaCount:     &one,
+					InitialReplicaCount: &three,
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{
+							Type: "cron",
+							Metadata: map[string]string{
+								"timezone":        "UTC",
+								"start":           "0 * * * *",
+								"end":             "1 * * * *",
+								"desiredReplicas": "1",
+							},
+						},
+					},
+				},
+			}
+			err = k8sClient.Create(context.Background(), so)
+			Ω(err).ToNot(HaveOccurred())
+
+			Eventually(func() int32 {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: deploymentName, Namespace: "default"}, deployment)
+				Ω(err).ToNot(HaveOccurred())
+				return *deployment.Spec.Replicas
+			}, 20*time.Second).Should(Equal(three))
+
+			Eventually(func() *int32 {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: soName, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				return so.Status.OriginalReplicaCount
+			}, 20*time.Second).Should(Equal(&zero))
+		})
+
+		It("doesn't allow InitialReplicaCount > MaxReplicaCount", func() {
+			deploymentName := "initialmax"
+			soName := "so-" + deploymentName
+
+			// Create the scaling target.
+			err := k8sClient.Create(context.Background(), generateDeployment(deploymentName))
+			Expect(err).ToNot(HaveOccurred())
+
+			var five int32 = 5
+			var ten int32 = 10
+
+			// Create the ScaledObject
+			so := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: soName, Namespace: "default"},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+						Name: deploymentName,
+					},
+					InitialReplicaCount: &ten,
+					MaxReplicaCount:     &five,
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{
+							Type: "cron",
+							Metadata: map[string]string{
+								"timezone":        "UTC",
+								"start":           "0 * * * *",
+								"end":             "1 * * * *",
+								"desiredReplicas": "1",
+							},
+						},
+					},
+				},
+			}
+			err = k8sClient.Create(context.Background(), so)
+			Ω(err).ToNot(HaveOccurred())
+
+			Eventually(func() metav1.ConditionStatus {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: soName, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				return so.Status.Conditions.GetReadyCondition().Status
+			}, 20*time.Second).Should(Equal(metav1.ConditionFalse))
+		})
+
+		It("reports a conflict when the scale target is scaled by another HPA", func() {
+			deploymentName := "hpaconflict"
+			soName := "so-" + deploymentName
+
+			// Create the scaling target and an HPA scaling it.
+			err := k8sClient.Create(context.Background(), generateDeployment(deploymentName))
+			Expect(err).ToNot(HaveOccurred())
+
+			var one int32 = 1
+			err = k8sClient.Create(context.Background(), &autoscalingv2beta2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-" + deploymentName, Namespace: "default"},
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						Name:       deploymentName,
+					},
+					MinReplicas: &one,
+					MaxReplicas: 5,
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Create the ScaledObject
+			so := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: soName, Namespace: "default"},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+						Name: deploymentName,
+					},
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{
+							Type: "cron",
+							Metadata: map[string]string{
+								"timezone":        "UTC",
+								"start":           "0 * * * *",
+								"end":             "1 * * * *",
+								"desiredReplicas": "1",
+							},
+						},
+					},
+				},
+			}
+			err = k8sClient.Create(context.Background(), so)
+			Ω(err).ToNot(HaveOccurred())
+
+			Eventually(func() string {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: soName, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				return so.Status.Conditions.GetReadyCondition().Reason
+			}, 20*time.Second).Should(Equal(kedav1alpha1.ScaledObjectConditionHPAConflictReason))
+			Expect(so.Status.HpaName).To(BeEmpty())
+		})
+
+		It("doesn't report a conflict with an HPA scaling a target of the same name in another API group", func() {
+			deploymentName := "hpaothergroup"
+			soName := "so-" + deploymentName
+
+			// Create the scaling target and an HPA scaling a target of the same kind and name in another API group.
+			err := k8sClient.Create(context.Background(), generateDeployment(deploymentName))
+			Expect(err).ToNot(HaveOccurred())
+
+			var one int32 = 1
+			err = k8sClient.Create(context.Background(), &autoscalingv2beta2.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-" + deploymentName, Namespace: "default"},
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+						APIVersion: "example.com/v1",
+						Kind:       "Deployment",
+						Name:       deploymentName,
+					},
+					MinReplicas: &one,
+					MaxReplicas: 5,
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Create the ScaledObject
+			so := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{Name: soName, Namespace: "default"},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+						Name: deploymentName,
+					},
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{
+							Type: "cron",
+							Metadata: map[string]string{
+								"timezone":        "UTC",
+								"start":           "0 * * * *",
+								"end":             "1 * * * *",
+								"desiredReplicas": "1",
+							},
+						},
+					},
+				},
+			}
+			err = k8sClient.Create(context.Background(), so)
+			Ω(err).ToNot(HaveOccurred())
+
+			Eventually(func() string {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: soName, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				return so.Status.HpaName
+			}, 20*time.Second).Should(Equal("keda-hpa-" + soName))
+		})
+
+		It("sets the Paused condition while the ScaledObject is paused", func() {
+			deploymentName := "pausedcondition"
+			soName := "so-" + deploymentName
+
+			// Create the scaling target.
+			err := k8sClient.Create(context.Background(), generateDeployment(deploymentName))
+			Expect(err).ToNot(HaveOccurred())
+
+			// Create the paused ScaledObject
+			so := &kedav1alpha1.ScaledObject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        soName,
+					Namespace:   "default",
+					Annotations: map[string]string{"autoscaling.keda.sh/paused-replicas": "1"},
+				},
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+						Name: deploymentName,
+					},
+					Triggers: []kedav1alpha1.ScaleTriggers{
+						{
+							Type: "cron",
+							Metadata: map[string]string{
+								"timezone":        "UTC",
+								"start":           "0 * * * *",
+								"end":             "1 * * * *",
+								"desiredReplicas": "1",
+							},
+						},
+					},
+				},
+			}
+			err = k8sClient.Create(context.Background(), so)
+			Ω(err).ToNot(HaveOccurred())
+
+			Eventually(func() metav1.ConditionStatus {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: soName, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				return so.Status.Conditions.GetPausedCondition().Status
+			}, 20*time.Second).Should(Equal(metav1.ConditionTrue))
+
+			// Unpause the ScaledObject
+			Eventually(func() error {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: soName, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				so.Annotations = map[string]string{}
+				return k8sClient.Update(context.Background(), so)
+			}).ShouldNot(HaveOccurred())
+
+			Eventually(func() metav1.ConditionStatus {
+				err = k8sClient.Get(context.Background(), types.NamespacedName{Name: soName, Namespace: "default"}, so)
+				Ω(err).ToNot(HaveOccurred())
+				return so.Status.Conditions.GetPausedCondition().Status
+			}, 20*time.Second).Should(Equal(metav1.ConditionFalse))
+		})
 	})
 
 	It("scaleobject ready condition 'False/Unknow' to 'True' will requeue", func() {
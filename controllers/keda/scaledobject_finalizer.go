@@ -27,6 +27,7 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 )
 
 const (
@@ -43,8 +44,16 @@ func (r *ScaledObjectReconciler) finalizeScaledObject(ctx context.Context, logge
 			return err
 		}
 
+		// the advanced configuration can come from the ScalingTemplate
+		advanced := scaledObject.Spec.Advanced
+		if resolved, _, err := resolver.ResolveScalingTemplate(ctx, r.Client, scaledObject); err == nil {
+			advanced = resolved.Spec.Advanced
+		} else {
+			logger.Error(err, "Failed to resolve the ScalingTemplate, using the advanced configuration of the ScaledObject", "finalizer", scaledObjectFinalizer)
+		}
+
 		// if enabled, scale scaleTarget back to the original replica count (to the state it was before scaling with KEDA)
-		if scaledObject.Spec.Advanced != nil && scaledObject.Spec.Advanced.RestoreToOriginalReplicaCount {
+		if advanced != nil && advanced.RestoreToOriginalReplicaCount {
 			// If the scaling hasn't been yet initialized (for example due to the missing scaleTarget), we don't have the GVKR information about the scaleTarget.
 			// Thus we don't have enough information needed to properly set the number of replicas on the scaleTarget.
 			// Let's skip in this case.
@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const defaultTargetCheckInterval = 5 * time.Minute
+
+var (
+	scaledObjectTargetNotFound = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda",
+			Subsystem: "operator",
+			Name:      "scaled_object_target_not_found",
+			Help:      "Set to 1 for the ScaledObjects whose scale target doesn't exist",
+		},
+		[]string{"namespace", "scaledObject"},
+	)
+	scaledObjectTargetNotFoundDeletions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "keda",
+			Subsystem: "operator",
+			Name:      "scaled_object_target_not_found_deletions_total",
+			Help:      "Total number of ScaledObjects deleted because their scale target was missing for longer than the TTL",
+		},
+		[]string{"namespace"},
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(scaledObjectTargetNotFound)
+	ctrlmetrics.Registry.MustRegister(scaledObjectTargetNotFoundDeletions)
+}
+
+// ScaledObjectTargetChecker periodically checks the scale targets of the ScaledObjects exist, sets the
+// TargetNotFound condition of the ones whose target is missing and optionally deletes them after a TTL,
+// so clusters don't accumulate autoscaling configuration for deleted workloads
+type ScaledObjectTargetChecker struct {
+	Client client.Client
+	// Reader is used to read the scale targets, which aren't cached
+	Reader     client.Reader
+	RESTMapper meta.RESTMapper
+	Recorder   record.EventRecorder
+	Logger     logr.Logger
+
+	// CheckInterval is how often the scale targets are checked
+	CheckInterval time.Duration
+	// DeletionTTL is how long the scale target has to be missing before the ScaledObject is deleted,
+	// ScaledObjects are never deleted when it is zero
+	DeletionTTL time.Duration
+
+	now func() time.Time
+}
+
+// NeedLeaderElection makes sure only the leader updates and deletes the ScaledObjects
+func (c *ScaledObjectTargetChecker) NeedLeaderElection() bool {
+	return true
+}
+
+// Start checks the scale targets until the context is done
+func (c *ScaledObjectTargetChecker) Start(ctx context.Context) error {
+	c.setDefaults()
+
+	ticker := time.NewTicker(c.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.CheckTargets(ctx); err != nil {
+			c.Logger.Error(err, "error checking the scale targets of ScaledObjects")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *ScaledObjectTargetChecker) setDefaults() {
+	if c.CheckInterval == 0 {
+		c.CheckInterval = defaultTargetCheckInterval
+	}
+	if c.now == nil {
+		c.now = time.Now
+	}
+}
+
+// CheckTargets checks the scale target of every ScaledObject once
+func (c *ScaledObjectTargetChecker) CheckTargets(ctx context.Context) error {
+	c.setDefaults()
+
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := c.Client.List(ctx, scaledObjects); err != nil {
+		return fmt.Errorf("error listing ScaledObjects: %s", err)
+	}
+
+	scaledObjectTargetNotFound.Reset()
+	for i := range scaledObjects.Items {
+		scaledObject := &scaledObjects.Items[i]
+		if scaledObject.GetDeletionTimestamp() != nil || scaledObject.Spec.ScaleTargetRef == nil || scaledObject.Spec.ScaleTargetRef.Name == "" {
+			continue
+		}
+		logger := c.Logger.WithValues("scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+
+		exists, err := c.targetExists(ctx, scaledObject)
+		if err != nil {
+			logger.Error(err, "error checking the scale target of ScaledObject")
+			continue
+		}
+		if exists {
+			err = c.targetFound(ctx, logger, scaledObject)
+		} else {
+			scaledObjectTargetNotFound.WithLabelValues(scaledObject.Namespace, scaledObject.Name).Set(1)
+			err = c.targetNotFound(ctx, logger, scaledObject)
+		}
+		if err != nil {
+			logger.Error(err, "error updating ScaledObject with missing scale target")
+		}
+	}
+	return nil
+}
+
+// targetExists returns false when the scale target or its kind doesn't exist
+func (c *ScaledObjectTargetChecker) targetExists(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, error) {
+	gvkr, err := kedautil.ParseGVKR(c.RESTMapper, scaledObject.Spec.ScaleTargetRef.APIVersion, scaledObject.Spec.ScaleTargetRef.Kind)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	target := &unstructured.Unstructured{}
+	target.SetGroupVersionKind(gvkr.GroupVersionKind())
+	err = c.Reader.Get(ctx, client.ObjectKey{Namespace: scaledObject.Namespace, Name: scaledObject.Spec.ScaleTargetRef.Name}, target)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.IsNotFound(err):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// targetNotFound marks the ScaledObject the first time its scale target is found missing
+// and deletes it once the target has been missing for longer than the DeletionTTL
+func (c *ScaledObjectTargetChecker) targetNotFound(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	now := c.now()
+	since := scaledObject.Status.TargetNotFoundSince
+	if since == nil {
+		msg := fmt.Sprintf("Scale target %s %s doesn't exist", scaledObject.Spec.ScaleTargetRef.Kind, scaledObject.Spec.ScaleTargetRef.Name)
+		if c.DeletionTTL > 0 {
+			msg = fmt.Sprintf("%s, ScaledObject is deleted if it is still missing after %s", msg, c.DeletionTTL)
+		}
+
+		status := scaledObject.Status.DeepCopy()
+		status.TargetNotFoundSince = &metav1.Time{Time: now}
+		status.Conditions.SetTargetNotFoundCondition(metav1.ConditionTrue, kedav1alpha1.ScaledObjectConditionTargetNotFoundReason, msg)
+		if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, c.Client, logger, scaledObject, status); err != nil {
+			return err
+		}
+		logger.Info(msg)
+		c.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.ScaledObjectTargetNotFound, msg)
+		return nil
+	}
+
+	if c.DeletionTTL <= 0 || now.Sub(since.Time) < c.DeletionTTL {
+		return nil
+	}
+	if err := c.Client.Delete(ctx, scaledObject); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	logger.Info("Deleted ScaledObject whose scale target is missing", "since", since.Time)
+	scaledObjectTargetNotFoundDeletions.WithLabelValues(scaledObject.Namespace).Inc()
+	return nil
+}
+
+// targetFound clears the TargetNotFound condition of the ScaledObject once its scale target exists again
+func (c *ScaledObjectTargetChecker) targetFound(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	if scaledObject.Status.TargetNotFoundSince == nil {
+		return nil
+	}
+
+	msg := fmt.Sprintf("Scale target %s %s exists", scaledObject.Spec.ScaleTargetRef.Kind, scaledObject.Spec.ScaleTargetRef.Name)
+	status := scaledObject.Status.DeepCopy()
+	status.TargetNotFoundSince = nil
+	status.Conditions.SetTargetNotFoundCondition(metav1.ConditionFalse, kedav1alpha1.ScaledObjectConditionTargetFoundReason, msg)
+	if err := kedacontrollerutil.UpdateScaledObjectStatus(ctx, c.Client, logger, scaledObject, status); err != nil {
+		return err
+	}
+	c.Recorder.Event(scaledObject, corev1.EventTypeNormal, eventreason.ScaledObjectTargetFound, msg)
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+var _ = Describe("ScaledObjectTargetChecker", func() {
+	var (
+		now     time.Time
+		checker *ScaledObjectTargetChecker
+	)
+
+	newScaledObject := func(name string, target string, targetNotFoundSince *time.Time) *v1alpha1.ScaledObject {
+		scaledObject := &v1alpha1.ScaledObject{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: v1alpha1.ScaledObjectSpec{
+				ScaleTargetRef: &v1alpha1.ScaleTarget{Name: target},
+			},
+		}
+		if targetNotFoundSince != nil {
+			scaledObject.Status.TargetNotFoundSince = &metav1.Time{Time: *targetNotFoundSince}
+			scaledObject.Status.Conditions = v1alpha1.Conditions{{Type: v1alpha1.ConditionTargetNotFound, Status: metav1.ConditionTrue}}
+		}
+		return scaledObject
+	}
+
+	setup := func(deletionTTL time.Duration, objects ...client.Object) {
+		Expect(v1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+		checker = &ScaledObjectTargetChecker{
+			Client:      fakeClient,
+			Reader:      fakeClient,
+			Recorder:    record.NewFakeRecorder(10),
+			Logger:      logr.Discard(),
+			DeletionTTL: deletionTTL,
+			now:         func() time.Time { return now },
+		}
+	}
+
+	get := func(name string) (*v1alpha1.ScaledObject, error) {
+		scaledObject := &v1alpha1.ScaledObject{}
+		err := checker.Client.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, scaledObject)
+		return scaledObject, err
+	}
+
+	BeforeEach(func() {
+		now = time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("marks the ScaledObjects whose target doesn't exist", func() {
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"}}
+		setup(0, deployment, newScaledObject("found", "existing", nil), newScaledObject("missing", "deleted", nil))
+
+		Expect(checker.CheckTargets(context.Background())).To(Succeed())
+
+		found, err := get("found")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found.Status.TargetNotFoundSince).To(BeNil())
+		condition := found.Status.Conditions.GetTargetNotFoundCondition()
+		Expect(condition.IsUnknown()).To(BeTrue())
+
+		missing, err := get("missing")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(missing.Status.TargetNotFoundSince.Time.Equal(now)).To(BeTrue())
+		condition = missing.Status.Conditions.GetTargetNotFoundCondition()
+		Expect(condition.IsTrue()).To(BeTrue())
+		Expect(condition.Reason).To(Equal(v1alpha1.ScaledObjectConditionTargetNotFoundReason))
+	})
+
+	It("clears the condition once the target exists again", func() {
+		since := now.Add(-time.Hour)
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "recreated", Namespace: "default"}}
+		setup(0, deployment, newScaledObject("found", "recreated", &since))
+
+		Expect(checker.CheckTargets(context.Background())).To(Succeed())
+
+		found, err := get("found")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found.Status.TargetNotFoundSince).To(BeNil())
+		condition := found.Status.Conditions.GetTargetNotFoundCondition()
+		Expect(condition.IsFalse()).To(BeTrue())
+	})
+
+	It("deletes the ScaledObjects whose target is missing for longer than the TTL", func() {
+		expired := now.Add(-2 * time.Hour)
+		recent := now.Add(-30 * time.Minute)
+		setup(time.Hour, newScaledObject("expired", "deleted", &expired), newScaledObject("recent", "deleted", &recent))
+
+		Expect(checker.CheckTargets(context.Background())).To(Succeed())
+
+		_, err := get("expired")
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		_, err = get("recent")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("never deletes the ScaledObjects without a TTL", func() {
+		expired := now.Add(-24 * time.Hour)
+		setup(0, newScaledObject("expired", "deleted", &expired))
+
+		Expect(checker.CheckTargets(context.Background())).To(Succeed())
+
+		_, err := get("expired")
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -9,6 +9,33 @@ import (
 
 const PausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
 
+// FreezeDurationAnnotation pins the current replica count of the ScaledObject for the given duration,
+// the annotation is removed by KEDA once the duration has elapsed
+const FreezeDurationAnnotation = "autoscaling.keda.sh/freeze-duration"
+
+// PausedScaleDirectionAnnotation pauses the scale-down or the scale-up of the ScaledObject, the other direction
+// keeps being scaled by the triggers
+const PausedScaleDirectionAnnotation = "autoscaling.keda.sh/paused-scale-direction"
+
+const (
+	// PausedScaleDirectionDown is the PausedScaleDirectionAnnotation value pausing the scale-down
+	PausedScaleDirectionDown = "down"
+	// PausedScaleDirectionUp is the PausedScaleDirectionAnnotation value pausing the scale-up
+	PausedScaleDirectionUp = "up"
+)
+
+// ProcessingRateSamplesAnnotation is written by KEDA with the last samples of the backlog of the triggers,
+// so the estimated processing rates survive the restarts of KEDA
+const ProcessingRateSamplesAnnotation = "autoscaling.keda.sh/processing-rate-samples"
+
+// CachedMetricsAnnotation is written by the polling loop of KEDA with the metric values of the triggers
+// using cached metrics, so the metrics server doesn't query the scalers again
+const CachedMetricsAnnotation = "autoscaling.keda.sh/cached-metrics"
+
+// DebugQueriesAnnotation makes the polling loop of KEDA log the last query each trigger of the ScaledObject or
+// ScaledJob issued to its upstream system, with the secrets redacted, when set to "true"
+const DebugQueriesAnnotation = "autoscaling.keda.sh/debug-queries"
+
 type PausedReplicasPredicate struct {
 	predicate.Funcs
 }
@@ -31,6 +58,59 @@ func (PausedReplicasPredicate) Update(e event.UpdateEvent) bool {
 	return false
 }
 
+// FreezeDurationPredicate triggers a reconcile when the freeze-duration annotation is added, changed or removed
+type FreezeDurationPredicate struct {
+	predicate.Funcs
+}
+
+func (FreezeDurationPredicate) Update(e event.UpdateEvent) bool {
+	return annotationChanged(e, FreezeDurationAnnotation)
+}
+
+// PausedScaleDirectionPredicate triggers a reconcile when the paused-scale-direction annotation is added, changed or removed
+type PausedScaleDirectionPredicate struct {
+	predicate.Funcs
+}
+
+func (PausedScaleDirectionPredicate) Update(e event.UpdateEvent) bool {
+	return annotationChanged(e, PausedScaleDirectionAnnotation)
+}
+
+// annotationChanged returns true if the annotation was added, changed or removed by the update
+func annotationChanged(e event.UpdateEvent, annotation string) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+
+	newVal, newOk := e.ObjectNew.GetAnnotations()[annotation]
+	oldVal, oldOk := e.ObjectOld.GetAnnotations()[annotation]
+	return newOk != oldOk || newVal != oldVal
+}
+
+// pollingLoopAnnotations are the annotations written by the polling loop of KEDA
+var pollingLoopAnnotations = []string{CachedMetricsAnnotation, ProcessingRateSamplesAnnotation}
+
+// PollingLoopAnnotationsPredicate drops the updates of the annotations written by the polling loop of KEDA, they
+// don't change the spec and would otherwise be reconciled at every write
+type PollingLoopAnnotationsPredicate struct {
+	predicate.Funcs
+}
+
+func (PollingLoopAnnotationsPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+	if e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() {
+		return true
+	}
+	for _, annotation := range pollingLoopAnnotations {
+		if annotationChanged(e, annotation) {
+			return false
+		}
+	}
+	return true
+}
+
 type ScaleObjectReadyConditionPredicate struct {
 	predicate.Funcs
 }
@@ -0,0 +1,34 @@
+package util
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestPollingLoopAnnotationsPredicate(t *testing.T) {
+	scaledObject := func(generation int64, annotations map[string]string) *kedav1alpha1.ScaledObject {
+		return &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Generation: generation, Annotations: annotations}}
+	}
+
+	testCases := []struct {
+		name     string
+		old, new *kedav1alpha1.ScaledObject
+		expected bool
+	}{
+		{"cached metrics written", scaledObject(1, nil), scaledObject(1, map[string]string{CachedMetricsAnnotation: "{}"}), false},
+		{"cached metrics refreshed", scaledObject(1, map[string]string{CachedMetricsAnnotation: "{}"}), scaledObject(1, map[string]string{CachedMetricsAnnotation: "{\"metrics\":[]}"}), false},
+		{"rate samples written", scaledObject(1, nil), scaledObject(1, map[string]string{ProcessingRateSamplesAnnotation: "{}"}), false},
+		{"spec changed", scaledObject(1, map[string]string{CachedMetricsAnnotation: "{}"}), scaledObject(2, map[string]string{CachedMetricsAnnotation: "{\"metrics\":[]}"}), true},
+		{"other annotation changed", scaledObject(1, nil), scaledObject(1, map[string]string{PausedReplicasAnnotation: "1"}), true},
+	}
+
+	for _, tc := range testCases {
+		if got := (PollingLoopAnnotationsPredicate{}).Update(event.UpdateEvent{ObjectOld: tc.old, ObjectNew: tc.new}); got != tc.expected {
+			t.Errorf("%s: expected %v but got %v", tc.name, tc.expected, got)
+		}
+	}
+}
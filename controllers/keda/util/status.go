@@ -38,6 +38,9 @@ func SetStatusConditions(ctx context.Context, client runtimeclient.StatusClient,
 	case *kedav1alpha1.ScaledJob:
 		patch = runtimeclient.MergeFrom(obj.DeepCopy())
 		obj.Status.Conditions = *conditions
+	case *kedav1alpha1.MetricSource:
+		patch = runtimeclient.MergeFrom(obj.DeepCopy())
+		obj.Status.Conditions = *conditions
 	default:
 		err := fmt.Errorf("unknown scalable object type %v", obj)
 		logger.Error(err, "Failed to patch Objects Status with Conditions")
@@ -17,14 +17,17 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	"runtime"
+	"strings"
 	"time"
 
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -34,6 +37,9 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	kedacontrollers "github.com/kedacore/keda/v2/controllers/keda"
+	"github.com/kedacore/keda/v2/pkg/certificates"
+	"github.com/kedacore/keda/v2/pkg/hpaconverter"
+	"github.com/kedacore/keda/v2/pkg/profiling"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 	"github.com/kedacore/keda/v2/version"
 	//nolint:gci
@@ -62,15 +68,71 @@ func getWatchNamespace() (string, error) {
 	return ns, nil
 }
 
+// getPodNamespace returns the namespace the operator is running in
+func getPodNamespace() (string, error) {
+	data, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// getServiceDNSNames returns the DNS names of the services in the namespace
+func getServiceDNSNames(namespace string, services ...string) []string {
+	var dnsNames []string
+	for _, service := range services {
+		dnsNames = append(dnsNames,
+			fmt.Sprintf("%s.%s.svc", service, namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace))
+	}
+	return dnsNames
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == hpaconverter.CommandName {
+		if err := hpaconverter.RunCommand(os.Args[2:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var enableCertRotation bool
+	var certSecretName string
+	var operatorServiceName string
+	var metricsServerServiceName string
+	var validatingWebhookConfigurationName string
+	var forbidUnsafeSsl bool
+	var disabledScalers string
+	var profilingAddr string
+	var profilingBucket string
+	var profilingCPUDuration time.Duration
+	var targetCheckInterval time.Duration
+	var targetNotFoundTTL time.Duration
+	var installIdentity string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableCertRotation, "enable-cert-rotation", false,
+		"Enable the generation and rotation of self-signed certificates, and the injection of their CA bundle "+
+			"into the external metrics APIService and the validating webhook configuration.")
+	flag.StringVar(&certSecretName, "cert-secret-name", "kedaorg-certs", "The name of the secret the certificates are stored in.")
+	flag.StringVar(&operatorServiceName, "operator-service-name", "keda-operator", "The name of the operator service.")
+	flag.StringVar(&metricsServerServiceName, "metrics-server-service-name", "keda-metrics-apiserver", "The name of the metrics server service.")
+	flag.StringVar(&validatingWebhookConfigurationName, "validating-webhook-configuration-name", "", "The name of the validating webhook configuration which gets the CA bundle injected.")
+	flag.BoolVar(&forbidUnsafeSsl, "forbid-unsafe-ssl", false, "Reject triggers which skip TLS certificate verification with unsafeSsl.")
+	flag.StringVar(&disabledScalers, "disabled-scalers", "", "A comma separated list of the scaler types whose triggers are rejected, e.g. postgresql,mssql.")
+	flag.StringVar(&profilingAddr, "profiling-bind-address", "", "The address the pprof endpoints bind to, requires the KEDA_PROFILING_TOKEN bearer token. Disabled when empty.")
+	flag.StringVar(&profilingBucket, "profiling-bucket", "", "The s3://bucket/prefix or gs://bucket/prefix the heap and CPU profiles captured on SIGUSR1 or through the capture endpoint are uploaded to.")
+	flag.DurationVar(&profilingCPUDuration, "profiling-cpu-duration", 30*time.Second, "How long the captured CPU profiles last.")
+	flag.DurationVar(&targetCheckInterval, "scaledobject-target-check-interval", 5*time.Minute, "How often the scale targets of the ScaledObjects are checked to exist. Disabled when zero.")
+	flag.DurationVar(&targetNotFoundTTL, "scaledobject-target-not-found-ttl", 0, "How long the scale target of a ScaledObject can be missing before the ScaledObject is deleted. ScaledObjects are never deleted when zero.")
+	flag.StringVar(&installIdentity, "install-identity", "", "The identity of this KEDA install added to the selectors of the external metrics of the HPAs, must match the --install-identity of its metrics server when running several KEDA installs.")
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 
@@ -78,6 +140,11 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if errs := validation.IsValidLabelValue(installIdentity); len(errs) > 0 {
+		setupLog.Error(fmt.Errorf("%s", strings.Join(errs, ", ")), "invalid --install-identity")
+		os.Exit(1)
+	}
+
 	namespace, err := getWatchNamespace()
 	if err != nil {
 		setupLog.Error(err, "failed to get watch namespace")
@@ -126,6 +193,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	customCADir := os.Getenv("KEDA_CUSTOM_CA_DIR")
+	if customCADir == "" {
+		customCADir = kedautil.DefaultCustomCADir
+	}
+	if err := kedautil.LoadCustomCAs(customCADir); err != nil {
+		setupLog.Error(err, "Invalid KEDA_CUSTOM_CA_DIR")
+		os.Exit(1)
+	}
+
 	scaledObjectMaxReconciles, err := kedautil.ResolveOsEnvInt("KEDA_SCALEDOBJECT_CTRL_MAX_RECONCILES", 5)
 	if err != nil {
 		setupLog.Error(err, "Invalid KEDA_SCALEDOBJECT_CTRL_MAX_RECONCILES")
@@ -145,7 +221,10 @@ func main() {
 		Client:            mgr.GetClient(),
 		Scheme:            mgr.GetScheme(),
 		GlobalHTTPTimeout: globalHTTPTimeout,
+		ForbidUnsafeSsl:   forbidUnsafeSsl,
+		DisabledScalers:   strings.Split(disabledScalers, ","),
 		Recorder:          eventRecorder,
+		InstallIdentity:   installIdentity,
 	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: scaledObjectMaxReconciles}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledObject")
 		os.Exit(1)
@@ -154,11 +233,24 @@ func main() {
 		Client:            mgr.GetClient(),
 		Scheme:            mgr.GetScheme(),
 		GlobalHTTPTimeout: globalHTTPTimeout,
+		ForbidUnsafeSsl:   forbidUnsafeSsl,
+		DisabledScalers:   strings.Split(disabledScalers, ","),
 		Recorder:          eventRecorder,
 	}).SetupWithManager(mgr, controller.Options{MaxConcurrentReconciles: scaledJobMaxReconciles}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledJob")
 		os.Exit(1)
 	}
+	if err = (&kedacontrollers.MetricSourceReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		GlobalHTTPTimeout: globalHTTPTimeout,
+		ForbidUnsafeSsl:   forbidUnsafeSsl,
+		DisabledScalers:   strings.Split(disabledScalers, ","),
+		Recorder:          eventRecorder,
+	}).SetupWithManager(mgr, controller.Options{}); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MetricSource")
+		os.Exit(1)
+	}
 	if err = (&kedacontrollers.TriggerAuthenticationReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
@@ -175,8 +267,75 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterTriggerAuthentication")
 		os.Exit(1)
 	}
+	if targetCheckInterval > 0 {
+		if err := mgr.Add(&kedacontrollers.ScaledObjectTargetChecker{
+			Client:        mgr.GetClient(),
+			Reader:        mgr.GetAPIReader(),
+			RESTMapper:    mgr.GetRESTMapper(),
+			Recorder:      eventRecorder,
+			Logger:        ctrl.Log.WithName("targetchecker"),
+			CheckInterval: targetCheckInterval,
+			DeletionTTL:   targetNotFoundTTL,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up the scale target checks")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	if enableCertRotation {
+		podNamespace, err := getPodNamespace()
+		if err != nil {
+			setupLog.Error(err, "failed to get the operator namespace")
+			os.Exit(1)
+		}
+
+		certManager := &certificates.CertManager{
+			Client:          mgr.GetClient(),
+			Reader:          mgr.GetAPIReader(),
+			Logger:          ctrl.Log.WithName("certificates"),
+			SecretName:      certSecretName,
+			SecretNamespace: podNamespace,
+			DNSNames:        getServiceDNSNames(podNamespace, operatorServiceName, metricsServerServiceName),
+			APIServices:     []string{"v1beta1.external.metrics.k8s.io"},
+		}
+		if validatingWebhookConfigurationName != "" {
+			certManager.ValidatingWebhookConfigurations = []string{validatingWebhookConfigurationName}
+		}
+		if err := mgr.Add(certManager); err != nil {
+			setupLog.Error(err, "unable to set up certificates rotation")
+			os.Exit(1)
+		}
+	}
+
+	if profilingAddr != "" || profilingBucket != "" {
+		profilingToken := os.Getenv("KEDA_PROFILING_TOKEN")
+		if profilingAddr != "" && profilingToken == "" {
+			setupLog.Error(fmt.Errorf("KEDA_PROFILING_TOKEN is not set"), "the profiling endpoints require a token")
+			os.Exit(1)
+		}
+
+		profiler := &profiling.Profiler{
+			Logger:      ctrl.Log.WithName("profiling"),
+			BindAddress: profilingAddr,
+			Token:       profilingToken,
+			CPUDuration: profilingCPUDuration,
+		}
+		if profilingBucket != "" {
+			uploader, err := profiling.NewUploader(context.Background(), profilingBucket)
+			if err != nil {
+				setupLog.Error(err, "unable to set up profiling uploads")
+				os.Exit(1)
+			}
+			profiler.Uploader = uploader
+			profiler.Name, _ = os.Hostname()
+		}
+		if err := mgr.Add(profiler); err != nil {
+			setupLog.Error(err, "unable to set up profiling")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -191,6 +350,7 @@ func main() {
 	setupLog.Info(fmt.Sprintf("Git Commit: %s", version.GitCommit))
 	setupLog.Info(fmt.Sprintf("Go Version: %s", runtime.Version()))
 	setupLog.Info(fmt.Sprintf("Go OS/Arch: %s/%s", runtime.GOOS, runtime.GOARCH))
+	setupLog.Info(fmt.Sprintf("Crypto Mode: %s", kedautil.CryptoMode()))
 
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -0,0 +1,249 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// CACertKey is the key of the CA certificate in the certificates Secret
+	CACertKey = "ca.crt"
+	// CAKeyKey is the key of the CA private key in the certificates Secret
+	CAKeyKey = "ca.key"
+
+	defaultCAValidity        = 10 * 365 * 24 * time.Hour
+	defaultCertValidity      = 365 * 24 * time.Hour
+	defaultLookaheadInterval = 90 * 24 * time.Hour
+	defaultCheckInterval     = 12 * time.Hour
+)
+
+var apiServiceGVK = schema.GroupVersionKind{Group: "apiregistration.k8s.io", Version: "v1", Kind: "APIService"}
+
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=create;update
+// +kubebuilder:rbac:groups="apiregistration.k8s.io",resources=apiservices,verbs=get;patch
+// +kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=validatingwebhookconfigurations,verbs=get;patch
+
+// CertManager generates a self-signed CA and a serving certificate stored in a Secret, rotates them
+// before they expire and injects the CA bundle into the APIServices and webhook configurations,
+// so KEDA can be installed without cert-manager
+type CertManager struct {
+	Client client.Client
+	// Reader is used to read the Secret, APIServices and webhook configurations without caching them
+	Reader client.Reader
+	Logger logr.Logger
+
+	SecretName      string
+	SecretNamespace string
+	// DNSNames are the names the serving certificate is valid for
+	DNSNames []string
+	// APIServices which get the CA bundle injected
+	APIServices []string
+	// ValidatingWebhookConfigurations which get the CA bundle injected
+	ValidatingWebhookConfigurations []string
+
+	CAValidity   time.Duration
+	CertValidity time.Duration
+	// LookaheadInterval is how long before their expiration the certificates are rotated
+	LookaheadInterval time.Duration
+	// CheckInterval is how often the certificates are checked
+	CheckInterval time.Duration
+
+	now func() time.Time
+}
+
+// NeedLeaderElection makes sure only the leader manages the certificates
+func (cm *CertManager) NeedLeaderElection() bool {
+	return true
+}
+
+// Start manages the certificates until the context is done
+func (cm *CertManager) Start(ctx context.Context) error {
+	cm.setDefaults()
+
+	ticker := time.NewTicker(cm.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := cm.EnsureCertificates(ctx); err != nil {
+			cm.Logger.Error(err, "error ensuring certificates")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (cm *CertManager) setDefaults() {
+	if cm.CAValidity == 0 {
+		cm.CAValidity = defaultCAValidity
+	}
+	if cm.CertValidity == 0 {
+		cm.CertValidity = defaultCertValidity
+	}
+	if cm.LookaheadInterval == 0 {
+		cm.LookaheadInterval = defaultLookaheadInterval
+	}
+	if cm.CheckInterval == 0 {
+		cm.CheckInterval = defaultCheckInterval
+	}
+	if cm.now == nil {
+		cm.now = time.Now
+	}
+}
+
+// EnsureCertificates makes sure the Secret contains valid certificates and the CA bundle is injected
+func (cm *CertManager) EnsureCertificates(ctx context.Context) error {
+	cm.setDefaults()
+
+	secret := &corev1.Secret{}
+	err := cm.Reader.Get(ctx, types.NamespacedName{Name: cm.SecretName, Namespace: cm.SecretNamespace}, secret)
+	exists := err == nil
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("error getting certificates secret: %s", err)
+	}
+
+	now := cm.now()
+	data, rotated, err := cm.rotate(secret.Data, now)
+	if err != nil {
+		return err
+	}
+
+	if rotated {
+		switch {
+		case exists:
+			secret.Data = data
+			if err := cm.Client.Update(ctx, secret); err != nil {
+				return fmt.Errorf("error updating certificates secret: %s", err)
+			}
+		default:
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cm.SecretName,
+					Namespace: cm.SecretNamespace,
+				},
+				Type: corev1.SecretTypeOpaque,
+				Data: data,
+			}
+			if err := cm.Client.Create(ctx, secret); err != nil {
+				return fmt.Errorf("error creating certificates secret: %s", err)
+			}
+		}
+		cm.Logger.Info("Certificates rotated", "secret", cm.SecretName, "namespace", cm.SecretNamespace)
+	}
+
+	return cm.injectCABundle(ctx, data[CACertKey])
+}
+
+// rotate returns the certificates to store, generating a new CA and serving certificate when they aren't valid
+// or expire within the lookahead interval. The boolean is true when the certificates have changed.
+func (cm *CertManager) rotate(data map[string][]byte, now time.Time) (map[string][]byte, bool, error) {
+	caCertPEM, caKeyPEM := data[CACertKey], data[CAKeyKey]
+	caValid := isCAValid(caCertPEM, caKeyPEM, now.Add(cm.LookaheadInterval))
+	if caValid && isCertValid(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey], caCertPEM, cm.DNSNames, now.Add(cm.LookaheadInterval)) {
+		return data, false, nil
+	}
+
+	if !caValid {
+		var err error
+		caCertPEM, caKeyPEM, err = generateCA(now, cm.CAValidity)
+		if err != nil {
+			return nil, false, fmt.Errorf("error generating CA: %s", err)
+		}
+	}
+
+	certPEM, keyPEM, err := generateCert(caCertPEM, caKeyPEM, cm.DNSNames, now, cm.CertValidity)
+	if err != nil {
+		return nil, false, fmt.Errorf("error generating serving certificate: %s", err)
+	}
+
+	return map[string][]byte{
+		CACertKey:               caCertPEM,
+		CAKeyKey:                caKeyPEM,
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}, true, nil
+}
+
+// injectCABundle sets the CA bundle on the APIServices and webhook configurations when it has changed
+func (cm *CertManager) injectCABundle(ctx context.Context, caBundle []byte) error {
+	encodedCABundle := base64.StdEncoding.EncodeToString(caBundle)
+	for _, name := range cm.APIServices {
+		apiService := &unstructured.Unstructured{}
+		apiService.SetGroupVersionKind(apiServiceGVK)
+		if err := cm.Reader.Get(ctx, types.NamespacedName{Name: name}, apiService); err != nil {
+			return fmt.Errorf("error getting APIService %s: %s", name, err)
+		}
+
+		current, _, _ := unstructured.NestedString(apiService.Object, "spec", "caBundle")
+		if current == encodedCABundle {
+			continue
+		}
+
+		patch := client.MergeFrom(apiService.DeepCopy())
+		if err := unstructured.SetNestedField(apiService.Object, encodedCABundle, "spec", "caBundle"); err != nil {
+			return err
+		}
+		unstructured.RemoveNestedField(apiService.Object, "spec", "insecureSkipTLSVerify")
+		if err := cm.Client.Patch(ctx, apiService, patch); err != nil {
+			return fmt.Errorf("error injecting CA bundle in APIService %s: %s", name, err)
+		}
+		cm.Logger.Info("CA bundle injected", "apiService", name)
+	}
+
+	for _, name := range cm.ValidatingWebhookConfigurations {
+		webhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := cm.Reader.Get(ctx, types.NamespacedName{Name: name}, webhookConfiguration); err != nil {
+			return fmt.Errorf("error getting ValidatingWebhookConfiguration %s: %s", name, err)
+		}
+
+		patch := client.MergeFrom(webhookConfiguration.DeepCopy())
+		changed := false
+		for i := range webhookConfiguration.Webhooks {
+			if !bytes.Equal(webhookConfiguration.Webhooks[i].ClientConfig.CABundle, caBundle) {
+				webhookConfiguration.Webhooks[i].ClientConfig.CABundle = caBundle
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		if err := cm.Client.Patch(ctx, webhookConfiguration, patch); err != nil {
+			return fmt.Errorf("error injecting CA bundle in ValidatingWebhookConfiguration %s: %s", name, err)
+		}
+		cm.Logger.Info("CA bundle injected", "validatingWebhookConfiguration", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testDNSNames = []string{"keda-metrics-apiserver.keda.svc", "keda-metrics-apiserver.keda.svc.cluster.local"}
+
+func TestGenerateCertificates(t *testing.T) {
+	now := time.Now()
+	caCert, caKey, err := generateCA(now, 24*time.Hour)
+	if err != nil {
+		t.Fatal("Expected success generating CA but got error", err)
+	}
+	if !isCAValid(caCert, caKey, now) {
+		t.Error("Expected generated CA to be valid")
+	}
+	if isCAValid(caCert, caKey, now.Add(48*time.Hour)) {
+		t.Error("Expected generated CA to expire")
+	}
+
+	cert, key, err := generateCert(caCert, caKey, testDNSNames, now, 48*time.Hour)
+	if err != nil {
+		t.Fatal("Expected success generating certificate but got error", err)
+	}
+	if !isCertValid(cert, key, caCert, testDNSNames, now) {
+		t.Error("Expected generated certificate to be valid")
+	}
+	if isCertValid(cert, key, caCert, testDNSNames, now.Add(25*time.Hour)) {
+		t.Error("Expected generated certificate to expire with its CA")
+	}
+	if isCertValid(cert, key, caCert, []string{"keda-operator.keda.svc"}, now) {
+		t.Error("Expected generated certificate to be invalid for other DNS names")
+	}
+
+	otherCACert, _, err := generateCA(now, 24*time.Hour)
+	if err != nil {
+		t.Fatal("Expected success generating CA but got error", err)
+	}
+	if isCertValid(cert, key, otherCACert, testDNSNames, now) {
+		t.Error("Expected generated certificate to be invalid for another CA")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	now := time.Now()
+	cm := &CertManager{DNSNames: testDNSNames, now: func() time.Time { return now }}
+	cm.setDefaults()
+
+	data, rotated, err := cm.rotate(nil, now)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if !rotated {
+		t.Error("Expected certificates to be generated")
+	}
+
+	same, rotated, err := cm.rotate(data, now)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if rotated || !bytes.Equal(same[corev1.TLSCertKey], data[corev1.TLSCertKey]) {
+		t.Error("Expected valid certificates to be kept")
+	}
+
+	// the serving certificate expires before the CA, so only the serving certificate is rotated
+	later := now.Add(defaultCertValidity)
+	renewed, rotated, err := cm.rotate(data, later)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if !rotated || bytes.Equal(renewed[corev1.TLSCertKey], data[corev1.TLSCertKey]) {
+		t.Error("Expected expiring serving certificate to be rotated")
+	}
+	if !bytes.Equal(renewed[CACertKey], data[CACertKey]) {
+		t.Error("Expected CA to be kept when it doesn't expire")
+	}
+
+	cm.DNSNames = append(cm.DNSNames, "keda-operator.keda.svc")
+	_, rotated, err = cm.rotate(data, now)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if !rotated {
+		t.Error("Expected certificates to be rotated when DNS names change")
+	}
+}
+
+func TestEnsureCertificates(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	apiService := &unstructured.Unstructured{}
+	apiService.SetGroupVersionKind(apiServiceGVK)
+	apiService.SetName("v1beta1.external.metrics.k8s.io")
+	_ = unstructured.SetNestedField(apiService.Object, true, "spec", "insecureSkipTLSVerify")
+
+	webhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "keda-admission"},
+		Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "vscaledobject.kb.io"}},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(apiService, webhookConfiguration).Build()
+	cm := &CertManager{
+		Client:                          client,
+		Reader:                          client,
+		Logger:                          logr.Discard(),
+		SecretName:                      "kedaorg-certs",
+		SecretNamespace:                 "keda",
+		DNSNames:                        testDNSNames,
+		APIServices:                     []string{"v1beta1.external.metrics.k8s.io"},
+		ValidatingWebhookConfigurations: []string{"keda-admission"},
+	}
+
+	if err := cm.EnsureCertificates(context.Background()); err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	secret := &corev1.Secret{}
+	if err := client.Get(context.Background(), types.NamespacedName{Name: "kedaorg-certs", Namespace: "keda"}, secret); err != nil {
+		t.Fatal("Expected certificates secret to be created but got error", err)
+	}
+	caBundle := secret.Data[CACertKey]
+	if !isCertValid(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], caBundle, testDNSNames, time.Now()) {
+		t.Error("Expected valid serving certificate in the secret")
+	}
+
+	updatedAPIService := &unstructured.Unstructured{}
+	updatedAPIService.SetGroupVersionKind(apiServiceGVK)
+	if err := client.Get(context.Background(), types.NamespacedName{Name: "v1beta1.external.metrics.k8s.io"}, updatedAPIService); err != nil {
+		t.Fatal(err)
+	}
+	if injected, _, _ := unstructured.NestedString(updatedAPIService.Object, "spec", "caBundle"); injected != base64.StdEncoding.EncodeToString(caBundle) {
+		t.Error("Expected CA bundle to be injected in the APIService")
+	}
+	if _, found, _ := unstructured.NestedBool(updatedAPIService.Object, "spec", "insecureSkipTLSVerify"); found {
+		t.Error("Expected insecureSkipTLSVerify to be removed from the APIService")
+	}
+
+	updatedWebhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := client.Get(context.Background(), types.NamespacedName{Name: "keda-admission"}, updatedWebhookConfiguration); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(updatedWebhookConfiguration.Webhooks[0].ClientConfig.CABundle, caBundle) {
+		t.Error("Expected CA bundle to be injected in the ValidatingWebhookConfiguration")
+	}
+
+	// certificates are kept when they are valid
+	if err := cm.EnsureCertificates(context.Background()); err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	unchanged := &corev1.Secret{}
+	if err := client.Get(context.Background(), types.NamespacedName{Name: "kedaorg-certs", Namespace: "keda"}, unchanged); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(unchanged.Data[corev1.TLSCertKey], secret.Data[corev1.TLSCertKey]) {
+		t.Error("Expected valid certificates not to be rotated")
+	}
+}
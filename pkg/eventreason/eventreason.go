@@ -23,12 +23,48 @@ const (
 	// ScaledJobReady is for event when a new ScaledJob is ready
 	ScaledJobReady = "ScaledJobReady"
 
+	// MetricSourceReady is for event when a new MetricSource is ready
+	MetricSourceReady = "MetricSourceReady"
+
 	// ScaledObjectCheckFailed is for event when ScaledObject validation check fails
 	ScaledObjectCheckFailed = "ScaledObjectCheckFailed"
 
 	// ScaledJobCheckFailed is for event when ScaledJob validation check fails
 	ScaledJobCheckFailed = "ScaledJobCheckFailed"
 
+	// MetricSourceCheckFailed is for event when MetricSource validation check fails
+	MetricSourceCheckFailed = "MetricSourceCheckFailed"
+
+	// ScaledObjectMetricSpecGenerationFailed is for event when the HPA metric specs of ScaledObject can't be generated
+	ScaledObjectMetricSpecGenerationFailed = "MetricSpecGenerationFailed"
+
+	// ScaledObjectHPANotCreated is for event when the HPA of ScaledObject can't be created
+	ScaledObjectHPANotCreated = "HPANotCreated"
+
+	// ScaledObjectHPAConflict is for event when the scale target of ScaledObject is scaled by another HPA
+	ScaledObjectHPAConflict = "HPAConflict"
+
+	// ScaledObjectPaused is for event when the scaling of ScaledObject is paused
+	ScaledObjectPaused = "ScaledObjectPaused"
+
+	// ScaledObjectUnpaused is for event when the scaling of ScaledObject is no longer paused
+	ScaledObjectUnpaused = "ScaledObjectUnpaused"
+
+	// ScaledObjectFrozen is for event when the replica count of ScaledObject is frozen
+	ScaledObjectFrozen = "ScaledObjectFrozen"
+
+	// ScaledObjectUnfrozen is for event when the replica count of ScaledObject is no longer frozen
+	ScaledObjectUnfrozen = "ScaledObjectUnfrozen"
+
+	// ScaledObjectInitialReplicaCountApplied is for event when the initial replica count of ScaledObject is applied to its new scale target
+	ScaledObjectInitialReplicaCountApplied = "ScaledObjectInitialReplicaCountApplied"
+
+	// ScaledObjectTargetNotFound is for event when the scale target of ScaledObject doesn't exist
+	ScaledObjectTargetNotFound = "ScaledObjectTargetNotFound"
+
+	// ScaledObjectTargetFound is for event when the missing scale target of ScaledObject exists again
+	ScaledObjectTargetFound = "ScaledObjectTargetFound"
+
 	// ScaledObjectDeleted is for event when ScaledObject is deleted
 	ScaledObjectDeleted = "ScaledObjectDeleted"
 
@@ -44,6 +80,15 @@ const (
 	// KEDAScalerFailed is for event when a scaler fails for a ScaledJob or a ScaledObject
 	KEDAScalerFailed = "KEDAScalerFailed"
 
+	// KEDAPushStreamDisconnected is for event when the stream of a push scaler of a ScaledObject is disconnected
+	KEDAPushStreamDisconnected = "KEDAPushStreamDisconnected"
+
+	// KEDAPushStreamConnected is for event when the disconnected stream of a push scaler of a ScaledObject is connected again
+	KEDAPushStreamConnected = "KEDAPushStreamConnected"
+
+	// KEDAScalerUnsafeSsl is for event when a scaler skips TLS certificate verification
+	KEDAScalerUnsafeSsl = "KEDAScalerUnsafeSsl"
+
 	// KEDAScaleTargetActivated is for event when the scale target of ScaledObject was activated
 	KEDAScaleTargetActivated = "KEDAScaleTargetActivated"
 
@@ -28,10 +28,18 @@ type FakeKedaV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeKedaV1alpha1) AuthorizationGrants() v1alpha1.AuthorizationGrantInterface {
+	return &FakeAuthorizationGrants{c}
+}
+
 func (c *FakeKedaV1alpha1) ClusterTriggerAuthentications() v1alpha1.ClusterTriggerAuthenticationInterface {
 	return &FakeClusterTriggerAuthentications{c}
 }
 
+func (c *FakeKedaV1alpha1) MetricSources(namespace string) v1alpha1.MetricSourceInterface {
+	return &FakeMetricSources{c, namespace}
+}
+
 func (c *FakeKedaV1alpha1) ScaledJobs(namespace string) v1alpha1.ScaledJobInterface {
 	return &FakeScaledJobs{c, namespace}
 }
@@ -40,6 +48,10 @@ func (c *FakeKedaV1alpha1) ScaledObjects(namespace string) v1alpha1.ScaledObject
 	return &FakeScaledObjects{c, namespace}
 }
 
+func (c *FakeKedaV1alpha1) ScalingTemplates() v1alpha1.ScalingTemplateInterface {
+	return &FakeScalingTemplates{c}
+}
+
 func (c *FakeKedaV1alpha1) TriggerAuthentications(namespace string) v1alpha1.TriggerAuthenticationInterface {
 	return &FakeTriggerAuthentications{c, namespace}
 }
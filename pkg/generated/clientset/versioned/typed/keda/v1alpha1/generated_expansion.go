@@ -18,10 +18,16 @@ limitations under the License.
 
 package v1alpha1
 
+type AuthorizationGrantExpansion interface{}
+
 type ClusterTriggerAuthenticationExpansion interface{}
 
+type MetricSourceExpansion interface{}
+
 type ScaledJobExpansion interface{}
 
 type ScaledObjectExpansion interface{}
 
+type ScalingTemplateExpansion interface{}
+
 type TriggerAuthenticationExpansion interface{}
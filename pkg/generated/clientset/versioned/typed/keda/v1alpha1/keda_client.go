@@ -28,9 +28,12 @@ import (
 
 type KedaV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	AuthorizationGrantsGetter
 	ClusterTriggerAuthenticationsGetter
+	MetricSourcesGetter
 	ScaledJobsGetter
 	ScaledObjectsGetter
+	ScalingTemplatesGetter
 	TriggerAuthenticationsGetter
 }
 
@@ -39,10 +42,18 @@ type KedaV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *KedaV1alpha1Client) AuthorizationGrants() AuthorizationGrantInterface {
+	return newAuthorizationGrants(c)
+}
+
 func (c *KedaV1alpha1Client) ClusterTriggerAuthentications() ClusterTriggerAuthenticationInterface {
 	return newClusterTriggerAuthentications(c)
 }
 
+func (c *KedaV1alpha1Client) MetricSources(namespace string) MetricSourceInterface {
+	return newMetricSources(c, namespace)
+}
+
 func (c *KedaV1alpha1Client) ScaledJobs(namespace string) ScaledJobInterface {
 	return newScaledJobs(c, namespace)
 }
@@ -51,6 +62,10 @@ func (c *KedaV1alpha1Client) ScaledObjects(namespace string) ScaledObjectInterfa
 	return newScaledObjects(c, namespace)
 }
 
+func (c *KedaV1alpha1Client) ScalingTemplates() ScalingTemplateInterface {
+	return newScalingTemplates(c)
+}
+
 func (c *KedaV1alpha1Client) TriggerAuthentications(namespace string) TriggerAuthenticationInterface {
 	return newTriggerAuthentications(c, namespace)
 }
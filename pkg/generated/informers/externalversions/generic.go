@@ -53,12 +53,18 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=keda, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("authorizationgrants"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().AuthorizationGrants().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("clustertriggerauthentications"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ClusterTriggerAuthentications().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("metricsources"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().MetricSources().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("scaledjobs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScaledJobs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("scaledobjects"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScaledObjects().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("scalingtemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().ScalingTemplates().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("triggerauthentications"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Keda().V1alpha1().TriggerAuthentications().Informer()}, nil
 
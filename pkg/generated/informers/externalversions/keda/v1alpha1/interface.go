@@ -24,12 +24,18 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// AuthorizationGrants returns a AuthorizationGrantInformer.
+	AuthorizationGrants() AuthorizationGrantInformer
 	// ClusterTriggerAuthentications returns a ClusterTriggerAuthenticationInformer.
 	ClusterTriggerAuthentications() ClusterTriggerAuthenticationInformer
+	// MetricSources returns a MetricSourceInformer.
+	MetricSources() MetricSourceInformer
 	// ScaledJobs returns a ScaledJobInformer.
 	ScaledJobs() ScaledJobInformer
 	// ScaledObjects returns a ScaledObjectInformer.
 	ScaledObjects() ScaledObjectInformer
+	// ScalingTemplates returns a ScalingTemplateInformer.
+	ScalingTemplates() ScalingTemplateInformer
 	// TriggerAuthentications returns a TriggerAuthenticationInformer.
 	TriggerAuthentications() TriggerAuthenticationInformer
 }
@@ -45,11 +51,21 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// AuthorizationGrants returns a AuthorizationGrantInformer.
+func (v *version) AuthorizationGrants() AuthorizationGrantInformer {
+	return &authorizationGrantInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterTriggerAuthentications returns a ClusterTriggerAuthenticationInformer.
 func (v *version) ClusterTriggerAuthentications() ClusterTriggerAuthenticationInformer {
 	return &clusterTriggerAuthenticationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// MetricSources returns a MetricSourceInformer.
+func (v *version) MetricSources() MetricSourceInformer {
+	return &metricSourceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ScaledJobs returns a ScaledJobInformer.
 func (v *version) ScaledJobs() ScaledJobInformer {
 	return &scaledJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -60,6 +76,11 @@ func (v *version) ScaledObjects() ScaledObjectInformer {
 	return &scaledObjectInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ScalingTemplates returns a ScalingTemplateInformer.
+func (v *version) ScalingTemplates() ScalingTemplateInformer {
+	return &scalingTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // TriggerAuthentications returns a TriggerAuthenticationInformer.
 func (v *version) TriggerAuthentications() TriggerAuthenticationInformer {
 	return &triggerAuthenticationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
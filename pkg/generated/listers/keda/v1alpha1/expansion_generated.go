@@ -18,10 +18,22 @@ limitations under the License.
 
 package v1alpha1
 
+// AuthorizationGrantListerExpansion allows custom methods to be added to
+// AuthorizationGrantLister.
+type AuthorizationGrantListerExpansion interface{}
+
 // ClusterTriggerAuthenticationListerExpansion allows custom methods to be added to
 // ClusterTriggerAuthenticationLister.
 type ClusterTriggerAuthenticationListerExpansion interface{}
 
+// MetricSourceListerExpansion allows custom methods to be added to
+// MetricSourceLister.
+type MetricSourceListerExpansion interface{}
+
+// MetricSourceNamespaceListerExpansion allows custom methods to be added to
+// MetricSourceNamespaceLister.
+type MetricSourceNamespaceListerExpansion interface{}
+
 // ScaledJobListerExpansion allows custom methods to be added to
 // ScaledJobLister.
 type ScaledJobListerExpansion interface{}
@@ -38,6 +50,10 @@ type ScaledObjectListerExpansion interface{}
 // ScaledObjectNamespaceLister.
 type ScaledObjectNamespaceListerExpansion interface{}
 
+// ScalingTemplateListerExpansion allows custom methods to be added to
+// ScalingTemplateLister.
+type ScalingTemplateListerExpansion interface{}
+
 // TriggerAuthenticationListerExpansion allows custom methods to be added to
 // TriggerAuthenticationLister.
 type TriggerAuthenticationListerExpansion interface{}
@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hpaconverter
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// CommandName is the name of the subcommand of the operator which converts HorizontalPodAutoscalers
+const CommandName = "convert-hpa"
+
+// manifest is a Kubernetes object, or a list of objects like the output of kubectl get -o yaml
+type manifest struct {
+	metav1.TypeMeta `json:",inline"`
+	Items           []json.RawMessage `json:"items,omitempty"`
+}
+
+// RunCommand reads the HorizontalPodAutoscaler manifests given by the arguments and writes the converted
+// ScaledObjects to out, the constructs which couldn't be converted are reported to errOut
+func RunCommand(args []string, in io.Reader, out, errOut io.Writer) error {
+	flags := flag.NewFlagSet(CommandName, flag.ContinueOnError)
+	flags.SetOutput(errOut)
+	file := flags.String("f", "-", "The file the HorizontalPodAutoscaler manifests are read from, - for stdin.")
+	prometheusServerAddress := flags.String("prometheus-server-address", "", "The Prometheus server external metrics are queried from, they are not converted if empty.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	options := Options{PrometheusServerAddress: *prometheusServerAddress}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(in, 4096)
+	converted := 0
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("error decoding manifests: %s", err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+
+		n, err := convertManifest(raw, options, out, errOut)
+		if err != nil {
+			return err
+		}
+		converted += n
+	}
+
+	if converted == 0 {
+		return errors.New("no HorizontalPodAutoscaler converted")
+	}
+	return nil
+}
+
+// convertManifest converts the HorizontalPodAutoscalers of the manifest and returns how many were converted
+func convertManifest(raw json.RawMessage, options Options, out, errOut io.Writer) (int, error) {
+	var m manifest
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return 0, fmt.Errorf("error decoding manifest: %s", err)
+	}
+
+	switch {
+	case m.Kind == "List" || len(m.Items) > 0:
+		converted := 0
+		for _, item := range m.Items {
+			n, err := convertManifest(item, options, out, errOut)
+			if err != nil {
+				return converted, err
+			}
+			converted += n
+		}
+		return converted, nil
+	case m.Kind != "HorizontalPodAutoscaler":
+		fmt.Fprintf(errOut, "# skipping %s %s, only HorizontalPodAutoscalers are converted\n", m.APIVersion, m.Kind)
+		return 0, nil
+	case m.APIVersion != autoscalingv2.SchemeGroupVersion.String() && m.APIVersion != "autoscaling/v2beta2":
+		fmt.Fprintf(errOut, "# skipping HorizontalPodAutoscaler of %s, only autoscaling/v2 and autoscaling/v2beta2 are converted\n", m.APIVersion)
+		return 0, nil
+	}
+
+	// autoscaling/v2beta2 has the same schema
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	if err := json.Unmarshal(raw, hpa); err != nil {
+		return 0, fmt.Errorf("error decoding HorizontalPodAutoscaler: %s", err)
+	}
+
+	result, err := Convert(hpa, options)
+	if err != nil {
+		fmt.Fprintf(errOut, "# skipping %s\n", err)
+		return 0, nil
+	}
+	for _, warning := range result.Warnings {
+		fmt.Fprintf(errOut, "# HorizontalPodAutoscaler %s/%s: %s\n", hpa.Namespace, hpa.Name, warning)
+	}
+
+	data, err := yaml.Marshal(result.ScaledObject)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := fmt.Fprintf(out, "---\n%s", data); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hpaconverter converts autoscaling/v2 HorizontalPodAutoscalers into equivalent ScaledObjects
+package hpaconverter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// Options configures the conversion
+type Options struct {
+	// PrometheusServerAddress is the Prometheus server external metrics are queried from,
+	// they are not converted if it is empty
+	PrometheusServerAddress string
+}
+
+// Result is the ScaledObject converted from a HorizontalPodAutoscaler
+type Result struct {
+	ScaledObject *kedav1alpha1.ScaledObject
+	// Warnings describe the constructs of the HorizontalPodAutoscaler which couldn't be converted
+	Warnings []string
+}
+
+// Convert converts the HorizontalPodAutoscaler into an equivalent ScaledObject, the metrics which can't be
+// converted are reported as warnings, it returns an error if none of the metrics can be converted
+func Convert(hpa *autoscalingv2.HorizontalPodAutoscaler, options Options) (*Result, error) {
+	result := &Result{}
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kedav1alpha1.SchemeGroupVersion.String(),
+			Kind:       "ScaledObject",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        hpa.Name,
+			Namespace:   hpa.Namespace,
+			Labels:      hpa.Labels,
+			Annotations: filterAnnotations(hpa.Annotations),
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				Name:       hpa.Spec.ScaleTargetRef.Name,
+				Kind:       hpa.Spec.ScaleTargetRef.Kind,
+				APIVersion: hpa.Spec.ScaleTargetRef.APIVersion,
+			},
+			MaxReplicaCount: &hpa.Spec.MaxReplicas,
+		},
+	}
+
+	// the HPA defaults to a single replica, while ScaledObjects default to zero
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+	scaledObject.Spec.MinReplicaCount = &minReplicas
+
+	if hpa.Spec.Behavior != nil {
+		scaledObject.Spec.Advanced = &kedav1alpha1.AdvancedConfig{
+			HorizontalPodAutoscalerConfig: &kedav1alpha1.HorizontalPodAutoscalerConfig{
+				Behavior: convertBehavior(hpa.Spec.Behavior),
+			},
+		}
+	}
+
+	for i, metric := range hpa.Spec.Metrics {
+		trigger, warning := convertMetric(metric, options)
+		if warning != "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("metric %d: %s", i, warning))
+			continue
+		}
+		scaledObject.Spec.Triggers = append(scaledObject.Spec.Triggers, *trigger)
+	}
+
+	if len(scaledObject.Spec.Triggers) == 0 {
+		return nil, fmt.Errorf("HorizontalPodAutoscaler %s/%s has no metric which can be converted: %s", hpa.Namespace, hpa.Name, strings.Join(result.Warnings, ", "))
+	}
+
+	result.ScaledObject = scaledObject
+	return result, nil
+}
+
+// filterAnnotations drops the annotations kubectl and the HPA controller manage
+func filterAnnotations(annotations map[string]string) map[string]string {
+	var filtered map[string]string
+	for key, value := range annotations {
+		if key == corev1.LastAppliedConfigAnnotation || strings.HasPrefix(key, "autoscaling.alpha.kubernetes.io/") {
+			continue
+		}
+		if filtered == nil {
+			filtered = make(map[string]string)
+		}
+		filtered[key] = value
+	}
+	return filtered
+}
+
+func convertMetric(metric autoscalingv2.MetricSpec, options Options) (*kedav1alpha1.ScaleTriggers, string) {
+	switch metric.Type {
+	case autoscalingv2.ResourceMetricSourceType:
+		if metric.Resource == nil {
+			return nil, "resource metric without resource"
+		}
+		return convertResourceMetric(metric.Resource.Name, "", metric.Resource.Target)
+	case autoscalingv2.ContainerResourceMetricSourceType:
+		if metric.ContainerResource == nil {
+			return nil, "container resource metric without container resource"
+		}
+		return convertResourceMetric(metric.ContainerResource.Name, metric.ContainerResource.Container, metric.ContainerResource.Target)
+	case autoscalingv2.ExternalMetricSourceType:
+		if metric.External == nil {
+			return nil, "external metric without external"
+		}
+		return convertExternalMetric(metric.External, options)
+	default:
+		return nil, fmt.Sprintf("%s metrics are not supported", metric.Type)
+	}
+}
+
+func convertResourceMetric(name corev1.ResourceName, container string, target autoscalingv2.MetricTarget) (*kedav1alpha1.ScaleTriggers, string) {
+	if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+		return nil, fmt.Sprintf("resource %s is not supported, only cpu and memory are", name)
+	}
+
+	trigger := &kedav1alpha1.ScaleTriggers{
+		Type:     string(name),
+		Metadata: map[string]string{},
+	}
+	if container != "" {
+		trigger.Metadata["containerName"] = container
+	}
+
+	switch target.Type {
+	case autoscalingv2.UtilizationMetricType:
+		if target.AverageUtilization == nil {
+			return nil, fmt.Sprintf("%s utilization target without averageUtilization", name)
+		}
+		trigger.MetricType = autoscalingv2beta2.UtilizationMetricType
+		trigger.Metadata["value"] = strconv.Itoa(int(*target.AverageUtilization))
+	case autoscalingv2.AverageValueMetricType:
+		if target.AverageValue == nil {
+			return nil, fmt.Sprintf("%s average value target without averageValue", name)
+		}
+		trigger.MetricType = autoscalingv2beta2.AverageValueMetricType
+		trigger.Metadata["value"] = target.AverageValue.String()
+	default:
+		return nil, fmt.Sprintf("%s target type %s is not supported", name, target.Type)
+	}
+	return trigger, ""
+}
+
+// convertExternalMetric converts the external metric into a prometheus trigger summing the metric series
+// matched by its selector, as exposed by an adapter like the Prometheus adapter
+func convertExternalMetric(external *autoscalingv2.ExternalMetricSource, options Options) (*kedav1alpha1.ScaleTriggers, string) {
+	if options.PrometheusServerAddress == "" {
+		return nil, fmt.Sprintf("external metric %s is not supported without a Prometheus server address", external.Metric.Name)
+	}
+
+	query, err := prometheusQuery(external.Metric)
+	if err != nil {
+		return nil, fmt.Sprintf("external metric %s: %s", external.Metric.Name, err)
+	}
+
+	trigger := &kedav1alpha1.ScaleTriggers{
+		Type: "prometheus",
+		Metadata: map[string]string{
+			"serverAddress": options.PrometheusServerAddress,
+			"metricName":    external.Metric.Name,
+			"query":         query,
+		},
+	}
+
+	switch external.Target.Type {
+	case autoscalingv2.ValueMetricType:
+		if external.Target.Value == nil {
+			return nil, fmt.Sprintf("external metric %s value target without value", external.Metric.Name)
+		}
+		trigger.MetricType = autoscalingv2beta2.ValueMetricType
+		trigger.Metadata["threshold"] = formatQuantity(external.Target.Value)
+	case autoscalingv2.AverageValueMetricType:
+		if external.Target.AverageValue == nil {
+			return nil, fmt.Sprintf("external metric %s average value target without averageValue", external.Metric.Name)
+		}
+		trigger.MetricType = autoscalingv2beta2.AverageValueMetricType
+		trigger.Metadata["threshold"] = formatQuantity(external.Target.AverageValue)
+	default:
+		return nil, fmt.Sprintf("external metric %s target type %s is not supported", external.Metric.Name, external.Target.Type)
+	}
+	return trigger, ""
+}
+
+// prometheusQuery returns the PromQL query summing the series of the metric matched by its selector
+func prometheusQuery(metric autoscalingv2.MetricIdentifier) (string, error) {
+	var matchers []string
+	if metric.Selector != nil {
+		for key, value := range metric.Selector.MatchLabels {
+			matchers = append(matchers, fmt.Sprintf("%s=%q", key, value))
+		}
+		for _, expression := range metric.Selector.MatchExpressions {
+			values := strings.Join(expression.Values, "|")
+			switch expression.Operator {
+			case metav1.LabelSelectorOpIn:
+				matchers = append(matchers, fmt.Sprintf("%s=~%q", expression.Key, values))
+			case metav1.LabelSelectorOpNotIn:
+				matchers = append(matchers, fmt.Sprintf("%s!~%q", expression.Key, values))
+			case metav1.LabelSelectorOpExists:
+				matchers = append(matchers, fmt.Sprintf("%s!=\"\"", expression.Key))
+			case metav1.LabelSelectorOpDoesNotExist:
+				matchers = append(matchers, fmt.Sprintf("%s=\"\"", expression.Key))
+			default:
+				return "", fmt.Errorf("selector operator %s is not supported", expression.Operator)
+			}
+		}
+	}
+	// the order of the labels in the map is random
+	sort.Strings(matchers)
+
+	return fmt.Sprintf("sum(%s{%s})", metric.Name, strings.Join(matchers, ",")), nil
+}
+
+func formatQuantity(quantity *resource.Quantity) string {
+	return strconv.FormatFloat(quantity.AsApproximateFloat64(), 'f', -1, 64)
+}
+
+func convertBehavior(behavior *autoscalingv2.HorizontalPodAutoscalerBehavior) *autoscalingv2beta2.HorizontalPodAutoscalerBehavior {
+	return &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{
+		ScaleUp:   convertScalingRules(behavior.ScaleUp),
+		ScaleDown: convertScalingRules(behavior.ScaleDown),
+	}
+}
+
+func convertScalingRules(rules *autoscalingv2.HPAScalingRules) *autoscalingv2beta2.HPAScalingRules {
+	if rules == nil {
+		return nil
+	}
+
+	converted := &autoscalingv2beta2.HPAScalingRules{
+		StabilizationWindowSeconds: rules.StabilizationWindowSeconds,
+	}
+	if rules.SelectPolicy != nil {
+		selectPolicy := autoscalingv2beta2.ScalingPolicySelect(*rules.SelectPolicy)
+		converted.SelectPolicy = &selectPolicy
+	}
+	for _, policy := range rules.Policies {
+		converted.Policies = append(converted.Policies, autoscalingv2beta2.HPAScalingPolicy{
+			Type:          autoscalingv2beta2.HPAScalingPolicyType(policy.Type),
+			Value:         policy.Value,
+			PeriodSeconds: policy.PeriodSeconds,
+		})
+	}
+	return converted
+}
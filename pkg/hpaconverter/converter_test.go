@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hpaconverter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func quantityPtr(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
+
+func TestConvertMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		metric   autoscalingv2.MetricSpec
+		options  Options
+		expected *kedav1alpha1.ScaleTriggers
+	}{
+		{
+			name: "cpu utilization",
+			metric: autoscalingv2.MetricSpec{
+				Type:     autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{Name: corev1.ResourceCPU, Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: int32Ptr(80)}},
+			},
+			expected: &kedav1alpha1.ScaleTriggers{Type: "cpu", MetricType: autoscalingv2beta2.UtilizationMetricType, Metadata: map[string]string{"value": "80"}},
+		},
+		{
+			name: "container memory average value",
+			metric: autoscalingv2.MetricSpec{
+				Type:              autoscalingv2.ContainerResourceMetricSourceType,
+				ContainerResource: &autoscalingv2.ContainerResourceMetricSource{Name: corev1.ResourceMemory, Container: "app", Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: quantityPtr("512Mi")}},
+			},
+			expected: &kedav1alpha1.ScaleTriggers{Type: "memory", MetricType: autoscalingv2beta2.AverageValueMetricType, Metadata: map[string]string{"value": "512Mi", "containerName": "app"}},
+		},
+		{
+			name: "external metric with prometheus",
+			metric: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{
+					Metric: autoscalingv2.MetricIdentifier{
+						Name: "queue_messages_ready",
+						Selector: &metav1.LabelSelector{
+							MatchLabels:      map[string]string{"queue": "orders"},
+							MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod", "staging"}}},
+						},
+					},
+					Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: quantityPtr("500m")},
+				},
+			},
+			options: Options{PrometheusServerAddress: "http://prometheus:9090"},
+			expected: &kedav1alpha1.ScaleTriggers{Type: "prometheus", MetricType: autoscalingv2beta2.AverageValueMetricType, Metadata: map[string]string{
+				"serverAddress": "http://prometheus:9090",
+				"metricName":    "queue_messages_ready",
+				"query":         `sum(queue_messages_ready{env=~"prod|staging",queue="orders"})`,
+				"threshold":     "0.5",
+			}},
+		},
+		{
+			name: "external metric without prometheus",
+			metric: autoscalingv2.MetricSpec{
+				Type:     autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricSource{Metric: autoscalingv2.MetricIdentifier{Name: "queue_messages_ready"}, Target: autoscalingv2.MetricTarget{Type: autoscalingv2.ValueMetricType, Value: quantityPtr("10")}},
+			},
+		},
+		{
+			name: "unsupported resource",
+			metric: autoscalingv2.MetricSpec{
+				Type:     autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{Name: corev1.ResourceEphemeralStorage, Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: quantityPtr("1Gi")}},
+			},
+		},
+		{
+			name: "pods metric",
+			metric: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.PodsMetricSourceType,
+				Pods: &autoscalingv2.PodsMetricSource{Metric: autoscalingv2.MetricIdentifier{Name: "requests"}, Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType, AverageValue: quantityPtr("10")}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		trigger, warning := convertMetric(test.metric, test.options)
+		assert.Equal(t, test.expected, trigger, test.name)
+		if test.expected == nil {
+			assert.NotEmpty(t, warning, test.name)
+		} else {
+			assert.Empty(t, warning, test.name)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	selectPolicy := autoscalingv2.MinChangePolicySelect
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Annotations: map[string]string{corev1.LastAppliedConfigAnnotation: "{}", "team": "a"}},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "app"},
+			MaxReplicas:    10,
+			Metrics: []autoscalingv2.MetricSpec{
+				{Type: autoscalingv2.ResourceMetricSourceType, Resource: &autoscalingv2.ResourceMetricSource{Name: corev1.ResourceCPU, Target: autoscalingv2.MetricTarget{Type: autoscalingv2.UtilizationMetricType, AverageUtilization: int32Ptr(80)}}},
+				{Type: autoscalingv2.ObjectMetricSourceType, Object: &autoscalingv2.ObjectMetricSource{Metric: autoscalingv2.MetricIdentifier{Name: "hits"}}},
+			},
+			Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: int32Ptr(300),
+					SelectPolicy:               &selectPolicy,
+					Policies:                   []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PercentScalingPolicy, Value: 50, PeriodSeconds: 60}},
+				},
+			},
+		},
+	}
+
+	result, err := Convert(hpa, Options{})
+	assert.NoError(t, err)
+	assert.Len(t, result.Warnings, 1)
+
+	scaledObject := result.ScaledObject
+	assert.Equal(t, "app", scaledObject.Name)
+	assert.Equal(t, map[string]string{"team": "a"}, scaledObject.Annotations)
+	assert.Equal(t, &kedav1alpha1.ScaleTarget{APIVersion: "apps/v1", Kind: "Deployment", Name: "app"}, scaledObject.Spec.ScaleTargetRef)
+	assert.Equal(t, int32(1), *scaledObject.Spec.MinReplicaCount)
+	assert.Equal(t, int32(10), *scaledObject.Spec.MaxReplicaCount)
+	assert.Len(t, scaledObject.Spec.Triggers, 1)
+
+	scaleDown := scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior.ScaleDown
+	assert.Equal(t, int32(300), *scaleDown.StabilizationWindowSeconds)
+	assert.Equal(t, autoscalingv2beta2.MinPolicySelect, *scaleDown.SelectPolicy)
+	assert.Equal(t, []autoscalingv2beta2.HPAScalingPolicy{{Type: autoscalingv2beta2.PercentScalingPolicy, Value: 50, PeriodSeconds: 60}}, scaleDown.Policies)
+
+	// an HPA without any metric which can be converted
+	hpa.Spec.Metrics = hpa.Spec.Metrics[1:]
+	_, err = Convert(hpa, Options{})
+	assert.Error(t, err)
+}
+
+func TestRunCommand(t *testing.T) {
+	manifests := `apiVersion: v1
+kind: List
+items:
+- apiVersion: autoscaling/v2
+  kind: HorizontalPodAutoscaler
+  metadata:
+    name: web
+    namespace: default
+  spec:
+    scaleTargetRef:
+      apiVersion: apps/v1
+      kind: Deployment
+      name: web
+    minReplicas: 2
+    maxReplicas: 5
+    metrics:
+    - type: Resource
+      resource:
+        name: cpu
+        target:
+          type: Utilization
+          averageUtilization: 60
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	err := RunCommand([]string{}, strings.NewReader(manifests), out, errOut)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "kind: ScaledObject")
+	assert.Contains(t, out.String(), "minReplicaCount: 2")
+	assert.Contains(t, errOut.String(), "skipping v1 Service")
+
+	err = RunCommand([]string{}, strings.NewReader("apiVersion: v1\nkind: Service\n"), out, errOut)
+	assert.Error(t, err)
+}
@@ -19,10 +19,14 @@ package metrics
 import (
 	"log"
 	"net/http"
+	"sort"
 	"strconv"
+	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 var (
@@ -63,6 +67,15 @@ var (
 		},
 		[]string{"namespace", "scaledObject"},
 	)
+	cryptoMode = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "keda_metrics_adapter",
+			Name:      "crypto_mode",
+			Help:      "Crypto mode in use, fips when the host runs in FIPS mode",
+		},
+		[]string{"mode"},
+	)
+	scalerLabels = newScalerLabelsCollector()
 )
 
 // PrometheusMetricServer the type of MetricsServer
@@ -76,6 +89,10 @@ func init() {
 	registry.MustRegister(scalerMetricsValue)
 	registry.MustRegister(scalerErrors)
 	registry.MustRegister(scaledObjectErrors)
+	registry.MustRegister(cryptoMode)
+	registry.MustRegister(scalerLabels)
+
+	cryptoMode.With(prometheus.Labels{"mode": kedautil.CryptoMode()}).Set(1)
 }
 
 // NewServer creates a new http serving instance of prometheus metrics
@@ -100,8 +117,8 @@ func (metricsServer PrometheusMetricServer) NewServer(address string, pattern st
 }
 
 // RecordHPAScalerMetric create a measurement of the external metric used by the HPA
-func (metricsServer PrometheusMetricServer) RecordHPAScalerMetric(namespace string, scaledObject string, scaler string, scalerIndex int, metric string, value int64) {
-	scalerMetricsValue.With(getLabels(namespace, scaledObject, scaler, scalerIndex, metric)).Set(float64(value))
+func (metricsServer PrometheusMetricServer) RecordHPAScalerMetric(namespace string, scaledObject string, scaler string, scalerIndex int, metric string, value float64) {
+	scalerMetricsValue.With(getLabels(namespace, scaledObject, scaler, scalerIndex, metric)).Set(value)
 }
 
 // RecordHPAScalerError counts the number of errors occurred in trying get an external metric used by the HPA
@@ -135,6 +152,85 @@ func (metricsServer PrometheusMetricServer) RecordScalerObjectError(namespace st
 	}
 }
 
+// RecordScalerLabels exposes the labels of the trigger, so the scaler metrics can be joined with them
+func (metricsServer PrometheusMetricServer) RecordScalerLabels(namespace string, scaledObject string, scaler string, scalerIndex int, triggerLabels map[string]string) {
+	scalerLabels.set(getLabels(namespace, scaledObject, scaler, scalerIndex, ""), triggerLabels)
+}
+
 func getLabels(namespace string, scaledObject string, scaler string, scalerIndex int, metric string) prometheus.Labels {
 	return prometheus.Labels{"namespace": namespace, "scaledObject": scaledObject, "scaler": scaler, "scalerIndex": strconv.Itoa(scalerIndex), "metric": metric}
 }
+
+// scalerLabelsCollector exposes the trigger labels as keda_metrics_adapter_scaler_labels, with one label_<name>
+// label per trigger label. It is an unchecked collector because the label names differ from trigger to trigger.
+type scalerLabelsCollector struct {
+	lock   sync.RWMutex
+	labels map[string]prometheus.Labels
+}
+
+func newScalerLabelsCollector() *scalerLabelsCollector {
+	return &scalerLabelsCollector{labels: make(map[string]prometheus.Labels)}
+}
+
+func (c *scalerLabelsCollector) set(scalerLabels prometheus.Labels, triggerLabels map[string]string) {
+	key := scalerLabels["namespace"] + "/" + scalerLabels["scaledObject"] + "/" + scalerLabels["scalerIndex"]
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(triggerLabels) == 0 {
+		delete(c.labels, key)
+		return
+	}
+
+	labels := prometheus.Labels{
+		"namespace":    scalerLabels["namespace"],
+		"scaledObject": scalerLabels["scaledObject"],
+		"scaler":       scalerLabels["scaler"],
+		"scalerIndex":  scalerLabels["scalerIndex"],
+	}
+	for name, value := range triggerLabels {
+		labels[sanitizeLabelName(name)] = value
+	}
+	c.labels[key] = labels
+}
+
+// Describe doesn't send any descriptor, which makes the collector unchecked
+func (c *scalerLabelsCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (c *scalerLabelsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	for _, labels := range c.labels {
+		names := make([]string, 0, len(labels))
+		for name := range labels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		values := make([]string, 0, len(names))
+		for _, name := range names {
+			values = append(values, labels[name])
+		}
+
+		desc := prometheus.NewDesc("keda_metrics_adapter_scaler_labels", "Labels of the scaler triggers", names, nil)
+		metric, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, 1, values...)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(desc, err)
+			continue
+		}
+		ch <- metric
+	}
+}
+
+// sanitizeLabelName turns a Kubernetes label name, e.g. app.kubernetes.io/team, into a valid
+// Prometheus label name prefixed with label_, e.g. label_app_kubernetes_io_team
+func sanitizeLabelName(name string) string {
+	sanitized := []byte("label_" + name)
+	for i, c := range sanitized {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			sanitized[i] = '_'
+		}
+	}
+	return string(sanitized)
+}
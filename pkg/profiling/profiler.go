@@ -0,0 +1,211 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"os/signal"
+	"path"
+	runtimepprof "runtime/pprof"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	// HeapProfile is the heap allocations profile
+	HeapProfile = "heap"
+	// CPUProfile is the CPU profile, captured for CPUDuration
+	CPUProfile = "cpu"
+
+	defaultCPUDuration = 30 * time.Second
+	shutdownTimeout    = 5 * time.Second
+)
+
+// Profiler exposes the pprof endpoints behind a bearer token, and captures heap and CPU profiles on SIGUSR1
+// or through the capture endpoint to upload them to an object storage bucket, so the profiles of a cluster
+// can be collected during an incident without port-forwarding to the pods
+type Profiler struct {
+	Logger logr.Logger
+	// BindAddress is the address the endpoints bind to, empty to disable them
+	BindAddress string
+	// Token authenticates the requests to the endpoints, as an Authorization bearer token
+	Token string
+	// Uploader uploads the captured profiles, nil to disable the captures
+	Uploader Uploader
+	// Name identifies the process in the object names of the captured profiles, e.g. the pod name
+	Name string
+	// CPUDuration is how long the CPU profiles are captured for
+	CPUDuration time.Duration
+
+	// lock makes sure a single capture runs at a time
+	lock sync.Mutex
+	now  func() time.Time
+}
+
+// NeedLeaderElection makes every replica profilable, not only the leader
+func (p *Profiler) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the endpoints and captures the profiles on SIGUSR1 until the context is done
+func (p *Profiler) Start(ctx context.Context) error {
+	p.setDefaults()
+
+	var server *http.Server
+	serverErr := make(chan error, 1)
+	if p.BindAddress != "" {
+		server = &http.Server{Addr: p.BindAddress, Handler: p.Handler()}
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				serverErr <- err
+			}
+		}()
+		p.Logger.Info("Serving the profiling endpoints", "address", p.BindAddress)
+	}
+
+	signals := make(chan os.Signal, 1)
+	if p.Uploader != nil {
+		signal.Notify(signals, syscall.SIGUSR1)
+		defer signal.Stop(signals)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			if server != nil {
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+				defer cancel()
+				return server.Shutdown(shutdownCtx)
+			}
+			return nil
+		case err := <-serverErr:
+			return fmt.Errorf("error serving the profiling endpoints: %s", err)
+		case <-signals:
+			go func() {
+				for _, profile := range []string{HeapProfile, CPUProfile} {
+					if _, err := p.Capture(ctx, profile); err != nil {
+						p.Logger.Error(err, "error capturing profile", "profile", profile)
+					}
+				}
+			}()
+		}
+	}
+}
+
+func (p *Profiler) setDefaults() {
+	if p.CPUDuration == 0 {
+		p.CPUDuration = defaultCPUDuration
+	}
+	if p.now == nil {
+		p.now = time.Now
+	}
+}
+
+// Handler returns the handler of the pprof endpoints and, when there is an Uploader, of the
+// /debug/pprof/capture?profile=heap|cpu endpoint, all of them requiring the Token
+func (p *Profiler) Handler() http.Handler {
+	p.setDefaults()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	if p.Uploader != nil {
+		mux.HandleFunc("/debug/pprof/capture", p.serveCapture)
+	}
+	return p.authenticate(mux)
+}
+
+func (p *Profiler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if p.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(p.Token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (p *Profiler) serveCapture(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	profile := r.URL.Query().Get("profile")
+	if profile != HeapProfile && profile != CPUProfile {
+		http.Error(w, fmt.Sprintf("profile must be %s or %s", HeapProfile, CPUProfile), http.StatusBadRequest)
+		return
+	}
+
+	name, err := p.Capture(r.Context(), profile)
+	if err != nil {
+		p.Logger.Error(err, "error capturing profile", "profile", profile)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintln(w, name)
+}
+
+// Capture captures the profile and uploads it, returning the name of the uploaded object
+func (p *Profiler) Capture(ctx context.Context, profile string) (string, error) {
+	if p.Uploader == nil {
+		return "", fmt.Errorf("no profiling bucket configured")
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var buf bytes.Buffer
+	switch profile {
+	case HeapProfile:
+		if err := runtimepprof.Lookup(HeapProfile).WriteTo(&buf, 0); err != nil {
+			return "", fmt.Errorf("error writing heap profile: %s", err)
+		}
+	case CPUProfile:
+		if err := runtimepprof.StartCPUProfile(&buf); err != nil {
+			return "", fmt.Errorf("error starting CPU profile: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(p.CPUDuration):
+		}
+		runtimepprof.StopCPUProfile()
+	default:
+		return "", fmt.Errorf("unknown profile %s", profile)
+	}
+
+	name := path.Join(p.Name, fmt.Sprintf("%s-%s.pprof", profile, p.now().UTC().Format("20060102T150405Z")))
+	if err := p.Uploader.Upload(ctx, name, buf.Bytes()); err != nil {
+		return "", fmt.Errorf("error uploading %s profile: %s", profile, err)
+	}
+	p.Logger.Info("Uploaded profile", "profile", profile, "name", name)
+	return name, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiling
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+type fakeUploader struct {
+	uploads map[string][]byte
+	lock    sync.Mutex
+}
+
+func (u *fakeUploader) Upload(ctx context.Context, name string, data []byte) error {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	u.uploads[name] = data
+	return nil
+}
+
+func newTestProfiler() (*Profiler, *fakeUploader) {
+	uploader := &fakeUploader{uploads: map[string][]byte{}}
+	return &Profiler{
+		Logger:      logr.Discard(),
+		Token:       "secret",
+		Uploader:    uploader,
+		Name:        "keda-operator-0",
+		CPUDuration: 100 * time.Millisecond,
+		now: func() time.Time {
+			return time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC)
+		},
+	}, uploader
+}
+
+func TestProfilerAuthentication(t *testing.T) {
+	profiler, _ := newTestProfiler()
+	handler := profiler.Handler()
+
+	testCases := []struct {
+		name          string
+		authorization string
+		expected      int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"wrong token", "Bearer wrong", http.StatusUnauthorized},
+		{"valid token", "Bearer secret", http.StatusOK},
+	}
+
+	for _, testCase := range testCases {
+		request := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+		if testCase.authorization != "" {
+			request.Header.Set("Authorization", testCase.authorization)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != testCase.expected {
+			t.Errorf("%s: expected status %d but got %d", testCase.name, testCase.expected, recorder.Code)
+		}
+	}
+}
+
+func TestProfilerRejectsEmptyToken(t *testing.T) {
+	profiler, _ := newTestProfiler()
+	profiler.Token = ""
+
+	request := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+	request.Header.Set("Authorization", "Bearer ")
+	recorder := httptest.NewRecorder()
+	profiler.Handler().ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d but got %d", http.StatusUnauthorized, recorder.Code)
+	}
+}
+
+func TestProfilerCapture(t *testing.T) {
+	profiler, uploader := newTestProfiler()
+	handler := profiler.Handler()
+
+	testCases := []struct {
+		method       string
+		profile      string
+		expected     int
+		expectedName string
+	}{
+		{http.MethodPost, HeapProfile, http.StatusOK, "keda-operator-0/heap-20220801T100000Z.pprof"},
+		{http.MethodPost, CPUProfile, http.StatusOK, "keda-operator-0/cpu-20220801T100000Z.pprof"},
+		{http.MethodPost, "goroutine", http.StatusBadRequest, ""},
+		{http.MethodGet, HeapProfile, http.StatusMethodNotAllowed, ""},
+	}
+
+	for _, testCase := range testCases {
+		request := httptest.NewRequest(testCase.method, "/debug/pprof/capture?profile="+testCase.profile, nil)
+		request.Header.Set("Authorization", "Bearer secret")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != testCase.expected {
+			t.Errorf("%s %s: expected status %d but got %d", testCase.method, testCase.profile, testCase.expected, recorder.Code)
+			continue
+		}
+		if testCase.expectedName == "" {
+			continue
+		}
+		if name := strings.TrimSpace(recorder.Body.String()); name != testCase.expectedName {
+			t.Errorf("%s: expected object %s but got %s", testCase.profile, testCase.expectedName, name)
+		}
+		if len(uploader.uploads[testCase.expectedName]) == 0 {
+			t.Errorf("%s: expected a non empty profile to be uploaded", testCase.profile)
+		}
+	}
+}
+
+func TestProfilerCaptureWithoutUploader(t *testing.T) {
+	profiler, _ := newTestProfiler()
+	profiler.Uploader = nil
+
+	if _, err := profiler.Capture(context.Background(), HeapProfile); err == nil {
+		t.Error("Expected an error capturing a profile without a bucket")
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/debug/pprof/capture?profile=heap", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	recorder := httptest.NewRecorder()
+	profiler.Handler().ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d but got %d", http.StatusNotFound, recorder.Code)
+	}
+}
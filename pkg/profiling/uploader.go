@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// Uploader uploads the captured profiles to an object storage bucket
+type Uploader interface {
+	Upload(ctx context.Context, name string, data []byte) error
+}
+
+// bucketLocation is a bucket and the prefix of the objects in it
+type bucketLocation struct {
+	scheme string
+	bucket string
+	prefix string
+}
+
+func (l bucketLocation) key(name string) string {
+	return path.Join(l.prefix, name)
+}
+
+// parseBucketURL parses a s3://bucket/prefix or gs://bucket/prefix URL
+func parseBucketURL(bucketURL string) (bucketLocation, error) {
+	parsed, err := url.Parse(bucketURL)
+	if err != nil {
+		return bucketLocation{}, fmt.Errorf("invalid profiling bucket %s: %s", bucketURL, err)
+	}
+	if parsed.Scheme != "s3" && parsed.Scheme != "gs" {
+		return bucketLocation{}, fmt.Errorf("invalid profiling bucket %s: the scheme must be s3 or gs", bucketURL)
+	}
+	if parsed.Host == "" {
+		return bucketLocation{}, fmt.Errorf("invalid profiling bucket %s: no bucket name", bucketURL)
+	}
+	return bucketLocation{
+		scheme: parsed.Scheme,
+		bucket: parsed.Host,
+		prefix: strings.Trim(parsed.Path, "/"),
+	}, nil
+}
+
+// NewUploader creates the Uploader of a s3://bucket/prefix or gs://bucket/prefix URL, authenticated with
+// the default credentials of the cloud provider, e.g. those of the pod identity
+func NewUploader(ctx context.Context, bucketURL string) (Uploader, error) {
+	location, err := parseBucketURL(bucketURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch location.scheme {
+	case "s3":
+		sess, err := session.NewSession()
+		if err != nil {
+			return nil, fmt.Errorf("error creating AWS session: %s", err)
+		}
+		return &s3Uploader{location: location, uploader: s3manager.NewUploader(sess)}, nil
+	default:
+		client, err := storage.NewClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error creating Google Cloud Storage client: %s", err)
+		}
+		return &gcsUploader{location: location, client: client}, nil
+	}
+}
+
+type s3Uploader struct {
+	location bucketLocation
+	uploader *s3manager.Uploader
+}
+
+func (u *s3Uploader) Upload(ctx context.Context, name string, data []byte) error {
+	_, err := u.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(u.location.bucket),
+		Key:    aws.String(u.location.key(name)),
+		Body:   bytes.NewReader(data),
+	})
+	return err
+}
+
+type gcsUploader struct {
+	location bucketLocation
+	client   *storage.Client
+}
+
+func (u *gcsUploader) Upload(ctx context.Context, name string, data []byte) error {
+	writer := u.client.Bucket(u.location.bucket).Object(u.location.key(name)).NewWriter(ctx)
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profiling
+
+import (
+	"testing"
+)
+
+func TestParseBucketURL(t *testing.T) {
+	testCases := []struct {
+		bucketURL   string
+		expected    bucketLocation
+		expectedKey string
+		isError     bool
+	}{
+		{"s3://profiles/keda/prod", bucketLocation{scheme: "s3", bucket: "profiles", prefix: "keda/prod"}, "keda/prod/pod/heap.pprof", false},
+		{"gs://profiles/", bucketLocation{scheme: "gs", bucket: "profiles"}, "pod/heap.pprof", false},
+		{"gs://profiles", bucketLocation{scheme: "gs", bucket: "profiles"}, "pod/heap.pprof", false},
+		{"https://profiles.example.com/keda", bucketLocation{}, "", true},
+		{"s3:///keda", bucketLocation{}, "", true},
+		{"profiles", bucketLocation{}, "", true},
+	}
+
+	for _, testCase := range testCases {
+		location, err := parseBucketURL(testCase.bucketURL)
+		if testCase.isError {
+			if err == nil {
+				t.Errorf("%s: expected an error", testCase.bucketURL)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", testCase.bucketURL, err)
+			continue
+		}
+		if location != testCase.expected {
+			t.Errorf("%s: expected %+v but got %+v", testCase.bucketURL, testCase.expected, location)
+		}
+		if key := location.key("pod/heap.pprof"); key != testCase.expectedKey {
+			t.Errorf("%s: expected key %s but got %s", testCase.bucketURL, testCase.expectedKey, key)
+		}
+	}
+}
@@ -29,12 +29,32 @@ import (
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
-func isFallbackEnabled(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2beta2.MetricSpec) bool {
+// getFallback returns the fallback of the trigger at scalerIndex, the fallback of the ScaledObject when the
+// trigger doesn't define one or is unknown and nil without fallback
+func getFallback(scaledObject *kedav1alpha1.ScaledObject, scalerIndex int) *kedav1alpha1.TriggerFallback {
+	if scalerIndex >= 0 && scalerIndex < len(scaledObject.Spec.Triggers) {
+		if fallback := scaledObject.Spec.Triggers[scalerIndex].Fallback; fallback != nil {
+			return fallback
+		}
+	}
 	if scaledObject.Spec.Fallback == nil {
+		return nil
+	}
+
+	replicas := scaledObject.Spec.Fallback.Replicas
+	return &kedav1alpha1.TriggerFallback{
+		FailureThreshold: scaledObject.Spec.Fallback.FailureThreshold,
+		Replicas:         &replicas,
+	}
+}
+
+func isFallbackEnabled(fallback *kedav1alpha1.TriggerFallback, metricSpec v2beta2.MetricSpec) bool {
+	if fallback == nil {
 		return false
 	}
 
-	if metricSpec.External.Target.Type != v2beta2.AverageValueMetricType {
+	// a fallback metric value doesn't depend on the target
+	if fallback.MetricValue == nil && metricSpec.External.Target.Type != v2beta2.AverageValueMetricType {
 		logger.V(0).Info("Fallback can only be enabled for triggers with metric of type AverageValue")
 		return false
 	}
@@ -42,7 +62,10 @@ func isFallbackEnabled(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2bet
 	return true
 }
 
-func (p *KedaProvider) getMetricsWithFallback(ctx context.Context, metrics []external_metrics.ExternalMetricValue, suppressedError error, metricName string, scaledObject *kedav1alpha1.ScaledObject, metricSpec v2beta2.MetricSpec) ([]external_metrics.ExternalMetricValue, error) {
+// getMetricsWithFallback records the health of the metric of the trigger at scalerIndex and returns its fallback
+// metrics once it failed more than the failure threshold of its fallback, in which case it returns true.
+// triggerIndexes maps the metric names of the ScaledObject to the index of their trigger.
+func (p *KedaProvider) getMetricsWithFallback(ctx context.Context, metrics []external_metrics.ExternalMetricValue, suppressedError error, metricName string, scalerIndex int, scaledObject *kedav1alpha1.ScaledObject, metricSpec v2beta2.MetricSpec, triggerIndexes map[string]int) ([]external_metrics.ExternalMetricValue, bool, error) {
 	status := scaledObject.Status.DeepCopy()
 
 	initHealthStatus(status)
@@ -54,36 +77,43 @@ func (p *KedaProvider) getMetricsWithFallback(ctx context.Context, metrics []ext
 		healthStatus.Status = kedav1alpha1.HealthStatusHappy
 		status.Health[metricName] = *healthStatus
 
-		p.updateStatus(ctx, scaledObject, status, metricSpec)
-		return metrics, nil
+		p.updateStatus(ctx, scaledObject, status, triggerIndexes)
+		return metrics, false, nil
 	}
 
 	healthStatus.Status = kedav1alpha1.HealthStatusFailing
 	*healthStatus.NumberOfFailures++
 	status.Health[metricName] = *healthStatus
 
-	p.updateStatus(ctx, scaledObject, status, metricSpec)
+	p.updateStatus(ctx, scaledObject, status, triggerIndexes)
 
+	fallback := getFallback(scaledObject, scalerIndex)
 	switch {
-	case !isFallbackEnabled(scaledObject, metricSpec):
-		return nil, suppressedError
-	case !validateFallback(scaledObject):
-		logger.Info("Failed to validate ScaledObject Spec. Please check that parameters are positive integers")
-		return nil, suppressedError
-	case *healthStatus.NumberOfFailures > scaledObject.Spec.Fallback.FailureThreshold:
-		return doFallback(scaledObject, metricSpec, metricName, suppressedError), nil
+	case !isFallbackEnabled(fallback, metricSpec):
+		return nil, false, suppressedError
+	case !validateFallback(fallback):
+		logger.Info("Failed to validate ScaledObject Spec. Please check that parameters are positive integers and that a trigger fallback has either replicas or a metricValue")
+		return nil, false, suppressedError
+	case *healthStatus.NumberOfFailures > fallback.FailureThreshold:
+		return doFallback(fallback, metricSpec, metricName, suppressedError), true, nil
 	default:
-		return nil, suppressedError
+		return nil, false, suppressedError
 	}
 }
 
-func fallbackExistsInScaledObject(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2beta2.MetricSpec) bool {
-	if !isFallbackEnabled(scaledObject, metricSpec) || !validateFallback(scaledObject) {
-		return false
-	}
-
-	for _, element := range scaledObject.Status.Health {
-		if element.Status == kedav1alpha1.HealthStatusFailing && *element.NumberOfFailures > scaledObject.Spec.Fallback.FailureThreshold {
+// fallbackExistsInScaledObject returns whether a failing metric of the ScaledObject is above the failure threshold
+// of the fallback of its trigger, the metrics missing from triggerIndexes use the fallback of the ScaledObject
+func fallbackExistsInScaledObject(scaledObject *kedav1alpha1.ScaledObject, triggerIndexes map[string]int) bool {
+	for metricName, element := range scaledObject.Status.Health {
+		scalerIndex, ok := triggerIndexes[metricName]
+		if !ok {
+			scalerIndex = -1
+		}
+		fallback := getFallback(scaledObject, scalerIndex)
+		if fallback == nil || !validateFallback(fallback) {
+			continue
+		}
+		if element.Status == kedav1alpha1.HealthStatusFailing && *element.NumberOfFailures > fallback.FailureThreshold {
 			return true
 		}
 	}
@@ -91,17 +121,32 @@ func fallbackExistsInScaledObject(scaledObject *kedav1alpha1.ScaledObject, metri
 	return false
 }
 
-func validateFallback(scaledObject *kedav1alpha1.ScaledObject) bool {
-	return scaledObject.Spec.Fallback.FailureThreshold >= 0 &&
-		scaledObject.Spec.Fallback.Replicas >= 0
+func validateFallback(fallback *kedav1alpha1.TriggerFallback) bool {
+	if fallback.FailureThreshold < 0 {
+		return false
+	}
+	if fallback.MetricValue != nil {
+		return fallback.Replicas == nil
+	}
+	return fallback.Replicas != nil && *fallback.Replicas >= 0
 }
 
-func doFallback(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2beta2.MetricSpec, metricName string, suppressedError error) []external_metrics.ExternalMetricValue {
-	replicas := int64(scaledObject.Spec.Fallback.Replicas)
-	normalisationValue, _ := metricSpec.External.Target.AverageValue.AsInt64()
+func doFallback(fallback *kedav1alpha1.TriggerFallback, metricSpec v2beta2.MetricSpec, metricName string, suppressedError error) []external_metrics.ExternalMetricValue {
+	if fallback.MetricValue != nil {
+		logger.Info(fmt.Sprintf("Suppressing error %s, falling back to the metric value %s", suppressedError, fallback.MetricValue.String()))
+		return []external_metrics.ExternalMetricValue{{
+			MetricName: metricName,
+			Value:      fallback.MetricValue.DeepCopy(),
+			Timestamp:  metav1.Now(),
+		}}
+	}
+
+	replicas := int64(*fallback.Replicas)
+	// in mili scale as the target can be fractional
+	normalisationValue := metricSpec.External.Target.AverageValue.MilliValue()
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(normalisationValue*replicas, resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(normalisationValue*replicas, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 	fallbackMetrics := []external_metrics.ExternalMetricValue{metric}
@@ -110,10 +155,10 @@ func doFallback(scaledObject *kedav1alpha1.ScaledObject, metricSpec v2beta2.Metr
 	return fallbackMetrics
 }
 
-func (p *KedaProvider) updateStatus(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, status *kedav1alpha1.ScaledObjectStatus, metricSpec v2beta2.MetricSpec) {
+func (p *KedaProvider) updateStatus(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, status *kedav1alpha1.ScaledObjectStatus, triggerIndexes map[string]int) {
 	patch := runtimeclient.MergeFrom(scaledObject.DeepCopy())
 
-	if fallbackExistsInScaledObject(scaledObject, metricSpec) {
+	if fallbackExistsInScaledObject(scaledObject, triggerIndexes) {
 		status.Conditions.SetFallbackCondition(metav1.ConditionTrue, "FallbackExists", "At least one trigger is falling back on this scaled object")
 	} else {
 		status.Conditions.SetFallbackCondition(metav1.ConditionFalse, "NoFallbackFound", "No fallbacks are active on this scaled object")
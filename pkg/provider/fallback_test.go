@@ -85,7 +85,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		metrics, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		metrics, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
 
 		Expect(err).ToNot(HaveOccurred())
 		value, _ := metrics[0].Value.AsInt64()
@@ -116,7 +116,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		metrics, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		metrics, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
 
 		Expect(err).ToNot(HaveOccurred())
 		value, _ := metrics[0].Value.AsInt64()
@@ -132,7 +132,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		_, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		_, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
@@ -161,7 +161,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		_, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		_, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
@@ -191,7 +191,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		metrics, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		metrics, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
 
 		Expect(err).ToNot(HaveOccurred())
 		value, _ := metrics[0].Value.AsInt64()
@@ -199,6 +199,41 @@ var _ = Describe("fallback", func() {
 		Expect(so.Status.Health[metricName]).To(haveFailureAndStatus(4, kedav1alpha1.HealthStatusFailing))
 	})
 
+	It("should return a normalised metric for a fractional target when number of failures are beyond threshold", func() {
+		scaler.EXPECT().GetMetrics(gomock.Any(), gomock.Eq(metricName), gomock.Any()).Return(nil, errors.New("Some error"))
+		startingNumberOfFailures := int32(3)
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(3),
+			},
+			&kedav1alpha1.ScaledObjectStatus{
+				Health: map[string]kedav1alpha1.HealthStatus{
+					metricName: {
+						NumberOfFailures: &startingNumberOfFailures,
+						Status:           kedav1alpha1.HealthStatusHappy,
+					},
+				},
+			},
+		)
+		metricSpec := v2beta2.MetricSpec{
+			External: &v2beta2.ExternalMetricSource{
+				Target: v2beta2.MetricTarget{
+					Type:         v2beta2.AverageValueMetricType,
+					AverageValue: resource.NewMilliQuantity(500, resource.DecimalSI),
+				},
+			},
+		}
+		expectStatusPatch(ctrl, client)
+
+		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
+		metrics, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metrics[0].Value.MilliValue()).Should(Equal(int64(1500)))
+	})
+
 	It("should behave as if fallback is disabled when the metrics spec target type is not average value metric", func() {
 		so := buildScaledObject(
 			&kedav1alpha1.Fallback{
@@ -217,7 +252,7 @@ var _ = Describe("fallback", func() {
 			},
 		}
 
-		isEnabled := isFallbackEnabled(so, metricsSpec)
+		isEnabled := isFallbackEnabled(getFallback(so, 0), metricsSpec)
 		Expect(isEnabled).Should(BeFalse())
 	})
 
@@ -247,7 +282,7 @@ var _ = Describe("fallback", func() {
 		client.EXPECT().Status().Return(statusWriter)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		metrics, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		metrics, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
 
 		Expect(err).ToNot(HaveOccurred())
 		value, _ := metrics[0].Value.AsInt64()
@@ -277,7 +312,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		_, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		_, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
 
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
@@ -311,7 +346,7 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		_, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		_, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
 		Expect(err).ToNot(HaveOccurred())
 		condition := so.Status.Conditions.GetFallbackCondition()
 		Expect(condition.IsTrue()).Should(BeTrue())
@@ -345,12 +380,153 @@ var _ = Describe("fallback", func() {
 		expectStatusPatch(ctrl, client)
 
 		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
-		_, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, so, metricSpec)
+		_, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
 		Expect(err).ShouldNot(BeNil())
 		Expect(err.Error()).Should(Equal("Some error"))
 		condition := so.Status.Conditions.GetFallbackCondition()
 		Expect(condition.IsTrue()).Should(BeFalse())
 	})
+
+	It("should use the fallback of the trigger over the fallback of the scaled object", func() {
+		triggerMetricName := "s0-" + metricName
+		scaler.EXPECT().GetMetrics(gomock.Any(), gomock.Eq(triggerMetricName), gomock.Any()).Return(nil, errors.New("Some error"))
+		startingNumberOfFailures := int32(1)
+		triggerReplicas := int32(4)
+
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+			},
+			&kedav1alpha1.ScaledObjectStatus{
+				Health: map[string]kedav1alpha1.HealthStatus{
+					triggerMetricName: {
+						NumberOfFailures: &startingNumberOfFailures,
+						Status:           kedav1alpha1.HealthStatusFailing,
+					},
+				},
+			},
+		)
+		so.Spec.Triggers[0].Fallback = &kedav1alpha1.TriggerFallback{
+			FailureThreshold: int32(1),
+			Replicas:         &triggerReplicas,
+		}
+		metricSpec := createMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+
+		metrics, err := scaler.GetMetrics(context.Background(), triggerMetricName, nil)
+		metrics, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, triggerMetricName, 0, so, metricSpec, map[string]int{triggerMetricName: 0})
+
+		Expect(err).ToNot(HaveOccurred())
+		value, _ := metrics[0].Value.AsInt64()
+		Expect(value).Should(Equal(int64(40)))
+		condition := so.Status.Conditions.GetFallbackCondition()
+		Expect(condition.IsTrue()).Should(BeTrue())
+	})
+
+	It("should return the fallback metric value of the trigger for any metric type", func() {
+		triggerMetricName := "s0-" + metricName
+		scaler.EXPECT().GetMetrics(gomock.Any(), gomock.Eq(triggerMetricName), gomock.Any()).Return(nil, errors.New("Some error"))
+		metricValue := resource.MustParse("2500m")
+
+		so := buildScaledObject(nil, nil)
+		so.Spec.Triggers[0].Fallback = &kedav1alpha1.TriggerFallback{
+			FailureThreshold: int32(0),
+			MetricValue:      &metricValue,
+		}
+		metricSpec := v2beta2.MetricSpec{
+			External: &v2beta2.ExternalMetricSource{
+				Target: v2beta2.MetricTarget{
+					Type:  v2beta2.ValueMetricType,
+					Value: resource.NewQuantity(int64(3), resource.DecimalSI),
+				},
+			},
+		}
+		expectStatusPatch(ctrl, client)
+
+		metrics, err := scaler.GetMetrics(context.Background(), triggerMetricName, nil)
+		metrics, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, triggerMetricName, 0, so, metricSpec, map[string]int{triggerMetricName: 0})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metrics[0].Value.MilliValue()).Should(Equal(int64(2500)))
+	})
+
+	It("should propagate the error when the fallback of the trigger has both replicas and a metric value", func() {
+		triggerMetricName := "s0-" + metricName
+		scaler.EXPECT().GetMetrics(gomock.Any(), gomock.Eq(triggerMetricName), gomock.Any()).Return(nil, errors.New("Some error"))
+		replicas := int32(4)
+		metricValue := resource.MustParse("3")
+
+		so := buildScaledObject(nil, nil)
+		so.Spec.Triggers[0].Fallback = &kedav1alpha1.TriggerFallback{
+			FailureThreshold: int32(0),
+			Replicas:         &replicas,
+			MetricValue:      &metricValue,
+		}
+		metricSpec := createMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+
+		metrics, err := scaler.GetMetrics(context.Background(), triggerMetricName, nil)
+		_, _, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, triggerMetricName, 0, so, metricSpec, map[string]int{triggerMetricName: 0})
+
+		Expect(err).ShouldNot(BeNil())
+		Expect(err.Error()).Should(Equal("Some error"))
+	})
+
+	It("should weight the metrics of the trigger but not its fallback replicas", func() {
+		// the metric name doesn't need the trigger index prefix, the fallback is found by the index of the scaler
+		scaler.EXPECT().GetMetrics(gomock.Any(), gomock.Eq(metricName), gomock.Any()).Return(nil, errors.New("Some error"))
+		primeGetMetrics(scaler, int64(5))
+		weight := resource.MustParse("2")
+		replicas := int32(4)
+
+		so := buildScaledObject(nil, nil)
+		so.Spec.Triggers[0].Weight = &weight
+		so.Spec.Triggers[0].Fallback = &kedav1alpha1.TriggerFallback{
+			FailureThreshold: int32(0),
+			Replicas:         &replicas,
+		}
+		metricSpec := createMetricSpec(10)
+		expectStatusPatch(ctrl, client)
+		expectStatusPatch(ctrl, client)
+
+		metrics, err := scaler.GetMetrics(context.Background(), metricName, nil)
+		metrics, fallback, err := providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fallback).Should(BeTrue())
+		weighted := weightMetricValues(metrics, triggerWeight(so, 0, fallback))
+		Expect(weighted[0].Value.MilliValue()).Should(Equal(int64(40000)))
+		Expect(weightedReplicas(metrics, metricSpec, triggerWeight(so, 0, fallback))).Should(Equal(float64(4)))
+
+		metrics, err = scaler.GetMetrics(context.Background(), metricName, nil)
+		metrics, fallback, err = providerUnderTest.getMetricsWithFallback(context.Background(), metrics, err, metricName, 0, so, metricSpec, map[string]int{metricName: 0})
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fallback).Should(BeFalse())
+		weighted = weightMetricValues(metrics, triggerWeight(so, 0, fallback))
+		Expect(weighted[0].Value.MilliValue()).Should(Equal(int64(10000)))
+	})
+
+	It("should use the fallback of the scaled object for the triggers without fallback", func() {
+		so := buildScaledObject(
+			&kedav1alpha1.Fallback{
+				FailureThreshold: int32(3),
+				Replicas:         int32(10),
+			}, nil,
+		)
+		so.Spec.Triggers = append(so.Spec.Triggers, kedav1alpha1.ScaleTriggers{
+			Type:     "cron",
+			Fallback: &kedav1alpha1.TriggerFallback{FailureThreshold: int32(1)},
+		})
+
+		fallback := getFallback(so, 0)
+		Expect(fallback.FailureThreshold).Should(Equal(int32(3)))
+		Expect(*fallback.Replicas).Should(Equal(int32(10)))
+		Expect(getFallback(so, 1).FailureThreshold).Should(Equal(int32(1)))
+		Expect(getFallback(so, -1).FailureThreshold).Should(Equal(int32(3)))
+		Expect(getFallback(buildScaledObject(nil, nil), 0)).Should(BeNil())
+	})
 })
 
 func haveFailureAndStatus(numberOfFailures int, status kedav1alpha1.HealthStatusType) types.GomegaMatcher {
@@ -21,9 +21,12 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	"k8s.io/api/autoscaling/v2beta2"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/metrics/pkg/apis/custom_metrics"
@@ -33,7 +36,10 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	prommetrics "github.com/kedacore/keda/v2/pkg/metrics"
+	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling"
+	scalingcache "github.com/kedacore/keda/v2/pkg/scaling/cache"
+	"github.com/kedacore/keda/v2/pkg/scaling/resolver"
 )
 
 // KedaProvider implements External Metrics Provider
@@ -44,15 +50,22 @@ type KedaProvider struct {
 	ctx                     context.Context
 	externalMetricsInfo     *[]provider.ExternalMetricInfo
 	externalMetricsInfoLock *sync.RWMutex
+	installIdentity         string
 }
 
+const (
+	labelScaledObjectName = "scaledobject.keda.sh/name"
+	labelMetricSourceName = "metricsource.keda.sh/name"
+	labelInstallIdentity  = "keda.sh/install-identity"
+)
+
 var (
 	logger        logr.Logger
 	metricsServer prommetrics.PrometheusMetricServer
 )
 
-// NewProvider returns an instance of KedaProvider
-func NewProvider(ctx context.Context, adapterLogger logr.Logger, scaleHandler scaling.ScaleHandler, client client.Client, watchedNamespace string, externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex) provider.MetricsProvider {
+// NewProvider returns an instance of KedaProvider, which only serves the metrics of the KEDA install with the installIdentity
+func NewProvider(ctx context.Context, adapterLogger logr.Logger, scaleHandler scaling.ScaleHandler, client client.Client, watchedNamespace string, externalMetricsInfo *[]provider.ExternalMetricInfo, externalMetricsInfoLock *sync.RWMutex, installIdentity string) provider.MetricsProvider {
 	provider := &KedaProvider{
 		client:                  client,
 		scaleHandler:            scaleHandler,
@@ -60,6 +73,7 @@ func NewProvider(ctx context.Context, adapterLogger logr.Logger, scaleHandler sc
 		ctx:                     ctx,
 		externalMetricsInfo:     externalMetricsInfo,
 		externalMetricsInfoLock: externalMetricsInfoLock,
+		installIdentity:         installIdentity,
 	}
 	logger = adapterLogger.WithName("provider")
 	logger.Info("starting")
@@ -81,6 +95,21 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 		return nil, err
 	}
 
+	// the metrics of the other KEDA installs are served by their own MetricsAdapter
+	if identity := selector[labelInstallIdentity]; identity != p.installIdentity {
+		return nil, fmt.Errorf("metric %s belongs to KEDA install %q but this metrics server serves install %q", info.Metric, identity, p.installIdentity)
+	}
+
+	// the metrics of a MetricSource are served as they are, for HPAs and controllers not managed by KEDA
+	if name, ok := selector[labelMetricSourceName]; ok {
+		return p.getMetricSourceMetrics(ctx, namespace, name, metricSelector, info)
+	}
+
+	// the selector contains the trigger labels as well, only the scaledobject.keda.sh/name label identifies the ScaledObject
+	if name, ok := selector[labelScaledObjectName]; ok {
+		selector = labels.Set{labelScaledObjectName: name}
+	}
+
 	// get the scaled objects matching namespace and labels
 	scaledObjects := &kedav1alpha1.ScaledObjectList{}
 	opts := []client.ListOption{
@@ -94,7 +123,11 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 		return nil, fmt.Errorf("exactly one ScaledObject should match label %s", metricSelector.String())
 	}
 
-	scaledObject := &scaledObjects.Items[0]
+	// the weights and labels of the triggers can come from the ScalingTemplate
+	scaledObject, _, err := resolver.ResolveScalingTemplate(ctx, p.client, &scaledObjects.Items[0])
+	if err != nil {
+		return nil, fmt.Errorf("error resolving the ScalingTemplate: %s", err)
+	}
 	var matchingMetrics []external_metrics.ExternalMetricValue
 
 	cache, err := p.scaleHandler.GetScalersCache(ctx, scaledObject)
@@ -104,32 +137,69 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 	}
 
 	scalerError := false
+	weightedSum := strings.EqualFold(info.Metric, kedav1alpha1.WeightedSumMetricName)
+	sum := float64(0)
+	composite := strings.EqualFold(info.Metric, kedav1alpha1.CompositeMetricName)
+	triggerValues := map[string]float64{}
 
-	for scalerIndex, scaler := range cache.GetScalers() {
-		metricSpecs := scaler.GetMetricSpecForScaling(ctx)
+	// the fallbacks are looked up by the index of the trigger of each metric
+	scalersList := cache.GetScalers()
+	scalersMetricSpecs := make([][]v2beta2.MetricSpec, len(scalersList))
+	triggerIndexes := map[string]int{}
+	for scalerIndex, scaler := range scalersList {
+		scalersMetricSpecs[scalerIndex] = scaler.GetMetricSpecForScaling(ctx)
+		for _, metricSpec := range scalersMetricSpecs[scalerIndex] {
+			if metricSpec.External != nil {
+				triggerIndexes[metricSpec.External.Metric.Name] = scalerIndex
+			}
+		}
+	}
+
+	for scalerIndex, scaler := range scalersList {
 		scalerName := strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
 
-		for _, metricSpec := range metricSpecs {
+		for _, metricSpec := range scalersMetricSpecs[scalerIndex] {
 			// skip cpu/memory resource scaler
 			if metricSpec.External == nil {
 				continue
 			}
-			// Filter only the desired metric
-			if strings.EqualFold(metricSpec.External.Metric.Name, info.Metric) {
-				metrics, err := cache.GetMetricsForScaler(ctx, scalerIndex, info.Metric, metricSelector)
-				metrics, err = p.getMetricsWithFallback(ctx, metrics, err, info.Metric, scaledObject, metricSpec)
+			// Filter only the desired metric, the weighted sum and the composite metric are computed from the metrics of all the triggers
+			metricName := metricSpec.External.Metric.Name
+			if weightedSum || composite || strings.EqualFold(metricName, info.Metric) {
+				// the triggers using cached metrics are served the values fetched by the polling loop of KEDA
+				metrics, found := cache.GetCachedMetricsForScaler(scaledObject, scalerIndex, metricName, time.Now())
+				var err error
+				fallback := false
+				if !found {
+					metrics, err = cache.GetMetricsForScaler(ctx, scalerIndex, metricName, metricSelector)
+					metrics, fallback, err = p.getMetricsWithFallback(ctx, metrics, err, metricName, scalerIndex, scaledObject, metricSpec, triggerIndexes)
+				}
+				weight := triggerWeight(scaledObject, scalerIndex, fallback)
 
 				if err != nil {
 					scalerError = true
 					logger.Error(err, "error getting metric for scaler", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "scaler", scaler)
 				} else {
 					for _, metric := range metrics {
-						metricValue, _ := metric.Value.AsInt64()
+						// AsInt64 fails on the fractional values of the milli-scaled metrics
+						metricValue := float64(metric.Value.MilliValue()) / 1000
 						metricsServer.RecordHPAScalerMetric(namespace, scaledObject.Name, scalerName, scalerIndex, metric.MetricName, metricValue)
 					}
-					matchingMetrics = append(matchingMetrics, metrics...)
+					if scalerIndex < len(scaledObject.Spec.Triggers) {
+						metricsServer.RecordScalerLabels(namespace, scaledObject.Name, scalerName, scalerIndex, scaledObject.Spec.Triggers[scalerIndex].Labels)
+					}
+					switch {
+					case weightedSum:
+						sum += weightedReplicas(metrics, metricSpec, weight)
+					case composite:
+						if scalerIndex < len(scaledObject.Spec.Triggers) {
+							scalingcache.AddTriggerMetrics(triggerValues, scaledObject.Spec.Triggers[scalerIndex], metrics)
+						}
+					default:
+						matchingMetrics = append(matchingMetrics, weightMetricValues(metrics, weight)...)
+					}
 				}
-				metricsServer.RecordHPAScalerError(namespace, scaledObject.Name, scalerName, scalerIndex, info.Metric, err)
+				metricsServer.RecordHPAScalerError(namespace, scaledObject.Name, scalerName, scalerIndex, metricName, err)
 			}
 		}
 	}
@@ -144,6 +214,26 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 		logger.V(1).Info("scaler error encountered, clearing scaler cache")
 	}
 
+	// a partial weighted sum would scale the target down, so the HPA keeps its replicas instead
+	if weightedSum {
+		if scalerError {
+			return nil, fmt.Errorf("error getting the metrics of the triggers for %s", info.Metric)
+		}
+		matchingMetrics = append(matchingMetrics, scalers.GenerateMetricInMili(info.Metric, sum))
+	}
+
+	// the formula of the composite metric needs the metrics of all the triggers it references
+	if composite {
+		if scalerError {
+			return nil, fmt.Errorf("error getting the metrics of the triggers for %s", info.Metric)
+		}
+		value, err := compositeMetricValue(scaledObject, triggerValues)
+		if err != nil {
+			return nil, err
+		}
+		matchingMetrics = append(matchingMetrics, scalers.GenerateMetricInMili(info.Metric, value))
+	}
+
 	if len(matchingMetrics) == 0 {
 		return nil, fmt.Errorf("no matching metrics found for " + info.Metric)
 	}
@@ -153,6 +243,108 @@ func (p *KedaProvider) GetExternalMetric(ctx context.Context, namespace string,
 	}, nil
 }
 
+// getMetricSourceMetrics returns the metric of the MetricSource, without the fallback, the weights and the
+// aggregations of the ScaledObjects
+func (p *KedaProvider) getMetricSourceMetrics(ctx context.Context, namespace, name string, metricSelector labels.Selector, info provider.ExternalMetricInfo) (*external_metrics.ExternalMetricValueList, error) {
+	metricSource := &kedav1alpha1.MetricSource{}
+	if err := p.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, metricSource); err != nil {
+		return nil, fmt.Errorf("error getting MetricSource %s: %s", name, err)
+	}
+
+	cache, err := p.scaleHandler.GetScalersCache(ctx, metricSource)
+	if err != nil {
+		return nil, fmt.Errorf("error when getting scalers %s", err)
+	}
+
+	var matchingMetrics []external_metrics.ExternalMetricValue
+	for scalerIndex, scaler := range cache.GetScalers() {
+		scalerName := strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
+		for _, metricSpec := range scaler.GetMetricSpecForScaling(ctx) {
+			if metricSpec.External == nil || !strings.EqualFold(metricSpec.External.Metric.Name, info.Metric) {
+				continue
+			}
+			metricName := metricSpec.External.Metric.Name
+			metrics, err := cache.GetMetricsForScaler(ctx, scalerIndex, metricName, metricSelector)
+			metricsServer.RecordHPAScalerError(namespace, metricSource.Name, scalerName, scalerIndex, metricName, err)
+			if err != nil {
+				logger.Error(err, "error getting metric for scaler", "metricSource.Namespace", namespace, "metricSource.Name", name, "scaler", scaler)
+				// the scalers are built again on the next request
+				if err := p.scaleHandler.ClearScalersCache(ctx, metricSource); err != nil {
+					logger.Error(err, "error clearing scalers cache")
+				}
+				return nil, fmt.Errorf("error getting metric %s of MetricSource %s: %s", info.Metric, name, err)
+			}
+			for _, metric := range metrics {
+				metricValue := float64(metric.Value.MilliValue()) / 1000
+				metricsServer.RecordHPAScalerMetric(namespace, metricSource.Name, scalerName, scalerIndex, metric.MetricName, metricValue)
+			}
+			matchingMetrics = append(matchingMetrics, metrics...)
+		}
+	}
+
+	if len(matchingMetrics) == 0 {
+		return nil, fmt.Errorf("no matching metrics found for " + info.Metric)
+	}
+	return &external_metrics.ExternalMetricValueList{
+		Items: matchingMetrics,
+	}, nil
+}
+
+// compositeMetricValue evaluates the formula of the ScalingModifiers of the ScaledObject with the metric values of its triggers
+func compositeMetricValue(scaledObject *kedav1alpha1.ScaledObject, triggerValues map[string]float64) (float64, error) {
+	compositeMetric, err := scalingcache.ParseScalingModifiers(scaledObject)
+	if err != nil {
+		return 0, err
+	}
+	if compositeMetric == nil {
+		return 0, fmt.Errorf("ScaledObject %s has no scalingModifiers", scaledObject.Name)
+	}
+	value, err := compositeMetric.Formula.Evaluate(triggerValues)
+	if err != nil {
+		return 0, fmt.Errorf("error evaluating the formula of scalingModifiers: %s", err)
+	}
+	return value, nil
+}
+
+// triggerWeight returns the weight of the metrics of the trigger at scalerIndex, 1 for its fallback metrics as
+// they are the values the HPA has to be served
+func triggerWeight(scaledObject *kedav1alpha1.ScaledObject, scalerIndex int, fallback bool) float64 {
+	if fallback || scalerIndex >= len(scaledObject.Spec.Triggers) {
+		return 1
+	}
+	return scaledObject.Spec.Triggers[scalerIndex].GetWeight()
+}
+
+// weightMetricValues multiplies the values of the metrics by the weight of their trigger
+func weightMetricValues(metrics []external_metrics.ExternalMetricValue, weight float64) []external_metrics.ExternalMetricValue {
+	if weight == 1 {
+		return metrics
+	}
+	weighted := make([]external_metrics.ExternalMetricValue, 0, len(metrics))
+	for _, metric := range metrics {
+		metric.Value = *resource.NewMilliQuantity(int64(float64(metric.Value.MilliValue())*weight), resource.DecimalSI)
+		weighted = append(weighted, metric)
+	}
+	return weighted
+}
+
+// weightedReplicas returns the replicas the metrics of a trigger require for its AverageValue target,
+// multiplied by the weight of the trigger
+func weightedReplicas(metrics []external_metrics.ExternalMetricValue, metricSpec v2beta2.MetricSpec, weight float64) float64 {
+	if metricSpec.External == nil || metricSpec.External.Target.AverageValue == nil {
+		return 0
+	}
+	target := metricSpec.External.Target.AverageValue.AsApproximateFloat64()
+	if target <= 0 {
+		return 0
+	}
+	replicas := float64(0)
+	for _, metric := range metrics {
+		replicas += metric.Value.AsApproximateFloat64() / target
+	}
+	return replicas * weight
+}
+
 // ListAllExternalMetrics returns the supported external metrics for this provider
 func (p *KedaProvider) ListAllExternalMetrics() []provider.ExternalMetricInfo {
 	logger.V(1).Info("KEDA Metrics Server received request for list of all provided external metrics names")
@@ -0,0 +1,171 @@
+/*
+Copyright 2021 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/custom-metrics-apiserver/pkg/provider"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_client"
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/mock/mock_scaling"
+	scalingcache "github.com/kedacore/keda/v2/pkg/scaling/cache"
+)
+
+func TestWeightMetricValues(t *testing.T) {
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queue", Value: *resource.NewMilliQuantity(4000, resource.DecimalSI)},
+	}
+
+	weighted := weightMetricValues(metrics, 0.5)
+	if got := weighted[0].Value.MilliValue(); got != 2000 {
+		t.Errorf("Expected weighted value 2000m but got %dm", got)
+	}
+	if got := metrics[0].Value.MilliValue(); got != 4000 {
+		t.Errorf("Expected the metrics to be left unchanged but got %dm", got)
+	}
+	if got := weightMetricValues(metrics, 1)[0].Value.MilliValue(); got != 4000 {
+		t.Errorf("Expected unweighted value 4000m but got %dm", got)
+	}
+}
+
+func TestWeightedReplicas(t *testing.T) {
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queue", Value: *resource.NewQuantity(30, resource.DecimalSI)},
+	}
+	averageValue := func(target int64) v2beta2.MetricSpec {
+		return v2beta2.MetricSpec{
+			External: &v2beta2.ExternalMetricSource{
+				Target: v2beta2.MetricTarget{Type: v2beta2.AverageValueMetricType, AverageValue: resource.NewQuantity(target, resource.DecimalSI)},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		metricSpec v2beta2.MetricSpec
+		weight     float64
+		expected   float64
+	}{
+		{"unweighted", averageValue(10), 1, 3},
+		{"weighted", averageValue(10), 2.5, 7.5},
+		{"zero target", averageValue(0), 1, 0},
+		{"no average value", v2beta2.MetricSpec{External: &v2beta2.ExternalMetricSource{}}, 1, 0},
+	}
+
+	for _, testCase := range testCases {
+		if got := weightedReplicas(metrics, testCase.metricSpec, testCase.weight); got != testCase.expected {
+			t.Errorf("%s: expected %v replicas but got %v", testCase.name, testCase.expected, got)
+		}
+	}
+}
+
+func TestCompositeMetricValue(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "(trig1 + trig2)/2 > 10 ? trig1 : trig2", Target: "5"},
+			},
+			Triggers: []kedav1alpha1.ScaleTriggers{{Name: "trig1"}, {Name: "trig2"}},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		triggerValues map[string]float64
+		expected      float64
+		isError       bool
+	}{
+		{"first trigger", map[string]float64{"trig1": 30, "trig2": 4}, 30, false},
+		{"second trigger", map[string]float64{"trig1": 6, "trig2": 4}, 4, false},
+		{"missing trigger", map[string]float64{"trig1": 6}, 0, true},
+	}
+
+	for _, testCase := range testCases {
+		value, err := compositeMetricValue(scaledObject, testCase.triggerValues)
+		if testCase.isError != (err != nil) {
+			t.Errorf("%s: expected error %v but got %v", testCase.name, testCase.isError, err)
+			continue
+		}
+		if value != testCase.expected {
+			t.Errorf("%s: expected %v but got %v", testCase.name, testCase.expected, value)
+		}
+	}
+}
+
+func TestGetExternalMetricRejectsOtherInstalls(t *testing.T) {
+	logger = logr.Discard()
+	p := &KedaProvider{installIdentity: "shard-a"}
+
+	tests := []struct {
+		name     string
+		selector labels.Set
+	}{
+		{"other install", labels.Set{labelScaledObjectName: "demo", labelInstallIdentity: "shard-b"}},
+		{"default install", labels.Set{labelScaledObjectName: "demo"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := p.GetExternalMetric(context.Background(), "default", test.selector.AsSelector(), provider.ExternalMetricInfo{Metric: "s0-queue"})
+			if err == nil || !strings.Contains(err.Error(), "belongs to KEDA install") {
+				t.Errorf("expected the metric to be rejected but got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetExternalMetricOfMetricSource(t *testing.T) {
+	logger = logr.Discard()
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	scaleHandler := mock_scaling.NewMockScaleHandler(ctrl)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	p := &KedaProvider{client: client, scaleHandler: scaleHandler}
+
+	client.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: "default", Name: "queue"}, gomock.Any()).Return(nil)
+	scaleHandler.EXPECT().GetScalersCache(gomock.Any(), gomock.Any()).Return(&scalingcache.ScalersCache{
+		Scalers: []scalingcache.ScalerBuilder{{Scaler: scaler}},
+	}, nil)
+	scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2beta2.MetricSpec{
+		{External: &v2beta2.ExternalMetricSource{Metric: v2beta2.MetricIdentifier{Name: "s0-queue"}}},
+		{External: &v2beta2.ExternalMetricSource{Metric: v2beta2.MetricIdentifier{Name: "s0-lag"}}},
+	})
+	scaler.EXPECT().GetMetrics(gomock.Any(), "s0-queue", gomock.Any()).Return([]external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queue", Value: *resource.NewQuantity(7, resource.DecimalSI)},
+	}, nil)
+
+	// no ScaledObject is listed, the metric is served from the scalers of the MetricSource
+	selector := labels.Set{labelMetricSourceName: "queue"}.AsSelector()
+	metrics, err := p.GetExternalMetric(context.Background(), "default", selector, provider.ExternalMetricInfo{Metric: "s0-queue"})
+	if err != nil {
+		t.Fatalf("Expected no error but got %v", err)
+	}
+	if len(metrics.Items) != 1 || metrics.Items[0].Value.Value() != 7 {
+		t.Errorf("Expected the metric value 7 but got %v", metrics.Items)
+	}
+}
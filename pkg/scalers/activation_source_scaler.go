@@ -0,0 +1,50 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+)
+
+// activationSourceScaler activates a ScaledObject through the IsActive and StreamIsActive methods of the
+// external scaler contract. It doesn't provide any metric, so the HPA only scales on the triggers.
+type activationSourceScaler struct {
+	externalPushScaler
+}
+
+// NewActivationSourceScaler creates a new activationSourceScaler for a request interceptor
+func NewActivationSourceScaler(config *ScalerConfig) (PushScaler, error) {
+	meta, err := parseExternalScalerMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing activation source metadata: %s", err)
+	}
+
+	return &activationSourceScaler{
+		externalPushScaler{
+			externalScaler: externalScaler{
+				metadata: meta,
+				scaledObjectRef: pb.ScaledObjectRef{
+					Name:           config.ScalableObjectName,
+					Namespace:      config.ScalableObjectNamespace,
+					ScalerMetadata: meta.originalMetadata,
+				},
+				logger: InitializeLogger(config, "activation_source_scaler"),
+			},
+		},
+	}, nil
+}
+
+// GetMetricSpecForScaling returns no metric spec, activation sources only activate the ScaledObject
+func (s *activationSourceScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	return nil
+}
+
+// GetMetrics returns no metric, activation sources only activate the ScaledObject
+func (s *activationSourceScaler) GetMetrics(context.Context, string, labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	return []external_metrics.ExternalMetricValue{}, nil
+}
@@ -0,0 +1,31 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestActivationSourceScalerHasNoMetrics(t *testing.T) {
+	scaler, err := NewActivationSourceScaler(&ScalerConfig{ScalableObjectName: "app", ScalableObjectNamespace: "namespace", TriggerMetadata: map[string]string{"scalerAddress": "interceptor:9090", "host": "app.example.com"}, ResolvedEnv: map[string]string{}})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+
+	if metricSpecs := scaler.GetMetricSpecForScaling(context.Background()); len(metricSpecs) != 0 {
+		t.Errorf("Expected no metric spec but got %v", metricSpecs)
+	}
+	metrics, err := scaler.GetMetrics(context.Background(), "s0-http", nil)
+	if err != nil {
+		t.Error("Expected success but got error", err)
+	}
+	if len(metrics) != 0 {
+		t.Errorf("Expected no metric but got %v", metrics)
+	}
+}
+
+func TestActivationSourceScalerRequiresAddress(t *testing.T) {
+	_, err := NewActivationSourceScaler(&ScalerConfig{TriggerMetadata: map[string]string{"host": "app.example.com"}, ResolvedEnv: map[string]string{}})
+	if err == nil {
+		t.Error("Expected error but got success")
+	}
+}
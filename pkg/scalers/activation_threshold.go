@@ -0,0 +1,145 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+)
+
+// activationThresholdKeys are the standard metadata of the triggers setting the value of their metric above which
+// they are active, independently of the target value of the HPA
+var activationThresholdKeys = []string{"activationThreshold", "activationValue"}
+
+// MetricsAndActivityScaler is implemented by the scalers returning their metrics and whether they are active from a
+// single read of the source
+type MetricsAndActivityScaler interface {
+	GetMetricsAndActivity(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, bool, error)
+}
+
+// rawMetricValueScaler is implemented by the scalers whose metric isn't the value read from the source, because it
+// is smoothed or converted to the time to empty the backlog. The activation compares the raw value.
+type rawMetricValueScaler interface {
+	// getRawMetricValue reads the value from the source, it doesn't update the state the metric is computed with
+	getRawMetricValue(ctx context.Context) (float64, error)
+	// metricValue returns the metric value computed from the raw value, updating that state
+	metricValue(rawValue float64) float64
+}
+
+// activationThresholdScaler decides whether the trigger is active by comparing the values of its metrics to the
+// activation threshold, instead of asking the scaler
+type activationThresholdScaler struct {
+	Scaler
+	threshold float64
+}
+
+// activationThresholdJobPriorityScaler keeps the job priorities of the scalers reporting them
+type activationThresholdJobPriorityScaler struct {
+	activationThresholdScaler
+	jobPriorityScaler JobPriorityScaler
+}
+
+// GetActivationThreshold returns the standard activation threshold of the trigger, nil if it isn't set
+func GetActivationThreshold(triggerMetadata map[string]string) (*float64, error) {
+	var threshold *float64
+	for _, key := range activationThresholdKeys {
+		val, ok := triggerMetadata[key]
+		if !ok || val == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %s", key, err)
+		}
+		if threshold != nil && *threshold != value {
+			return nil, fmt.Errorf("%s and %s are set to different values", activationThresholdKeys[0], activationThresholdKeys[1])
+		}
+		threshold = &value
+	}
+	return threshold, nil
+}
+
+// NewActivationThresholdScaler wraps the scaler so that it is active once one of its metrics is above the threshold.
+// Push scalers report their activity in Run, they must not be wrapped.
+func NewActivationThresholdScaler(scaler Scaler, threshold float64) Scaler {
+	s := activationThresholdScaler{Scaler: scaler, threshold: threshold}
+	if ps, ok := scaler.(JobPriorityScaler); ok {
+		return &activationThresholdJobPriorityScaler{activationThresholdScaler: s, jobPriorityScaler: ps}
+	}
+	return &s
+}
+
+// IsActive returns true if the value of one of the external metrics of the scaler is above the threshold. The
+// smoothed or time to empty metrics are compared before conversion, without updating their state.
+func (s *activationThresholdScaler) IsActive(ctx context.Context) (bool, error) {
+	if rs, ok := s.Scaler.(rawMetricValueScaler); ok {
+		value, err := rs.getRawMetricValue(ctx)
+		if err != nil {
+			return false, err
+		}
+		return value > s.threshold, nil
+	}
+
+	metricSpecs := s.Scaler.GetMetricSpecForScaling(ctx)
+	for _, metricSpec := range metricSpecs {
+		if metricSpec.External == nil {
+			continue
+		}
+		metrics, err := s.Scaler.GetMetrics(ctx, metricSpec.External.Metric.Name, labels.Everything())
+		if err != nil {
+			return false, err
+		}
+		if metricsAboveThreshold(metrics, s.threshold) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetMetricsAndActivity returns the metrics of the scaler and whether one of them is above the threshold, the smoothed
+// or time to empty metrics are compared before conversion
+func (s *activationThresholdScaler) GetMetricsAndActivity(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if rs, ok := s.Scaler.(rawMetricValueScaler); ok {
+		value, err := rs.getRawMetricValue(ctx)
+		if err != nil {
+			return []external_metrics.ExternalMetricValue{}, false, err
+		}
+		metric := GenerateMetricInMili(metricName, rs.metricValue(value))
+		return []external_metrics.ExternalMetricValue{metric}, value > s.threshold, nil
+	}
+	metrics, err := s.Scaler.GetMetrics(ctx, metricName, metricSelector)
+	if err != nil {
+		return metrics, false, err
+	}
+	return metrics, metricsAboveThreshold(metrics, s.threshold), nil
+}
+
+// GetMetricsAndActivity returns the metrics of the scaler and whether it is active from a single read of the source.
+// The scalers which don't implement MetricsAndActivityScaler are active once one of their metrics is above the
+// threshold.
+func GetMetricsAndActivity(ctx context.Context, scaler Scaler, metricName string, metricSelector labels.Selector, threshold float64) ([]external_metrics.ExternalMetricValue, bool, error) {
+	if ms, ok := scaler.(MetricsAndActivityScaler); ok {
+		return ms.GetMetricsAndActivity(ctx, metricName, metricSelector)
+	}
+	metrics, err := scaler.GetMetrics(ctx, metricName, metricSelector)
+	if err != nil {
+		return metrics, false, err
+	}
+	return metrics, metricsAboveThreshold(metrics, threshold), nil
+}
+
+// metricsAboveThreshold returns true if the value of one of the metrics is above the threshold
+func metricsAboveThreshold(metrics []external_metrics.ExternalMetricValue, threshold float64) bool {
+	for _, metric := range metrics {
+		if metric.Value.AsApproximateFloat64() > threshold {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *activationThresholdJobPriorityScaler) GetJobPriorities(ctx context.Context) ([]string, error) {
+	return s.jobPriorityScaler.GetJobPriorities(ctx)
+}
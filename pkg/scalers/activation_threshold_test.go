@@ -0,0 +1,189 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+)
+
+// activationThresholdTestScaler has a single external metric, it is always active
+type activationThresholdTestScaler struct {
+	value float64
+	err   error
+}
+
+func (s *activationThresholdTestScaler) GetMetrics(_ context.Context, metricName string, _ labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []external_metrics.ExternalMetricValue{GenerateMetricInMili(metricName, s.value)}, nil
+}
+
+func (s *activationThresholdTestScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	return []v2beta2.MetricSpec{{
+		External: &v2beta2.ExternalMetricSource{
+			Metric: v2beta2.MetricIdentifier{Name: "s0-test"},
+			Target: GetMetricTargetMili(v2beta2.AverageValueMetricType, 10),
+		},
+		Type: "External",
+	}}
+}
+
+func (s *activationThresholdTestScaler) IsActive(context.Context) (bool, error) {
+	return true, nil
+}
+
+func (s *activationThresholdTestScaler) Close(context.Context) error {
+	return nil
+}
+
+// activationThresholdTestRawScaler smooths its metric, it counts the reads of the source and the updates of the
+// smoothing state
+type activationThresholdTestRawScaler struct {
+	activationThresholdTestScaler
+	rawValue float64
+	reads    int
+	updates  int
+}
+
+func (s *activationThresholdTestRawScaler) GetMetrics(ctx context.Context, metricName string, _ labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getRawMetricValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []external_metrics.ExternalMetricValue{GenerateMetricInMili(metricName, s.metricValue(value))}, nil
+}
+
+func (s *activationThresholdTestRawScaler) getRawMetricValue(context.Context) (float64, error) {
+	s.reads++
+	return s.rawValue, s.err
+}
+
+func (s *activationThresholdTestRawScaler) metricValue(float64) float64 {
+	s.updates++
+	return s.value
+}
+
+type activationThresholdTestJobPriorityScaler struct {
+	activationThresholdTestScaler
+}
+
+func (s *activationThresholdTestJobPriorityScaler) GetJobPriorities(context.Context) ([]string, error) {
+	return []string{"high"}, nil
+}
+
+func TestGetActivationThreshold(t *testing.T) {
+	testCases := []struct {
+		metadata map[string]string
+		expected *float64
+		isError  bool
+	}{
+		{map[string]string{}, nil, false},
+		{map[string]string{"activationThreshold": ""}, nil, false},
+		{map[string]string{"activationThreshold": "2.5"}, func() *float64 { v := 2.5; return &v }(), false},
+		{map[string]string{"activationValue": "3"}, func() *float64 { v := 3.0; return &v }(), false},
+		{map[string]string{"activationThreshold": "3", "activationValue": "3"}, func() *float64 { v := 3.0; return &v }(), false},
+		{map[string]string{"activationThreshold": "3", "activationValue": "4"}, nil, true},
+		{map[string]string{"activationThreshold": "a"}, nil, true},
+	}
+
+	for _, tc := range testCases {
+		threshold, err := GetActivationThreshold(tc.metadata)
+		if tc.isError != (err != nil) {
+			t.Errorf("%v: expected error %v but got %v", tc.metadata, tc.isError, err)
+			continue
+		}
+		if (threshold == nil) != (tc.expected == nil) || (threshold != nil && *threshold != *tc.expected) {
+			t.Errorf("%v: expected %v but got %v", tc.metadata, tc.expected, threshold)
+		}
+	}
+}
+
+func TestActivationThresholdScalerIsActive(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     float64
+		threshold float64
+		err       error
+		expected  bool
+	}{
+		{"above the threshold", 5, 2, nil, true},
+		{"at the threshold", 2, 2, nil, false},
+		{"below the target but above the threshold", 0.5, 0, nil, true},
+		{"above the target but below the threshold", 20, 50, nil, false},
+		{"metric error", 0, 0, errors.New("unavailable"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewActivationThresholdScaler(&activationThresholdTestScaler{value: tc.value, err: tc.err}, tc.threshold)
+
+			active, err := s.IsActive(context.Background())
+			if (tc.err != nil) != (err != nil) {
+				t.Fatalf("expected error %v but got %v", tc.err, err)
+			}
+			if active != tc.expected {
+				t.Errorf("expected active %v but got %v", tc.expected, active)
+			}
+		})
+	}
+}
+
+func TestActivationThresholdScalerIsActiveRawValue(t *testing.T) {
+	inner := &activationThresholdTestRawScaler{activationThresholdTestScaler: activationThresholdTestScaler{value: 0.5}, rawValue: 5}
+	s := NewActivationThresholdScaler(inner, 2)
+
+	active, err := s.IsActive(context.Background())
+	if err != nil {
+		t.Fatal("expected success but got error", err)
+	}
+	if !active {
+		t.Error("expected the raw value above the threshold to be active while the smoothed value is below it")
+	}
+	if inner.updates != 0 {
+		t.Errorf("expected IsActive not to update the smoothing state but it was updated %d times", inner.updates)
+	}
+
+	metrics, err := s.GetMetrics(context.Background(), "s0-test", labels.Everything())
+	if err != nil {
+		t.Fatal("expected success but got error", err)
+	}
+	if value := metrics[0].Value.AsApproximateFloat64(); value != 0.5 {
+		t.Errorf("expected the smoothed metric value 0.5 but got %v", value)
+	}
+	if inner.reads != 2 || inner.updates != 1 {
+		t.Errorf("expected one read per call and the smoothing state updated once but got %d reads and %d updates", inner.reads, inner.updates)
+	}
+	metrics, active, err = s.(MetricsAndActivityScaler).GetMetricsAndActivity(context.Background(), "s0-test", labels.Everything())
+	if err != nil {
+		t.Fatal("expected success but got error", err)
+	}
+	if !active || metrics[0].Value.AsApproximateFloat64() != 0.5 {
+		t.Errorf("expected the smoothed metric value 0.5 and the raw value to be active but got %v, %v", metrics[0].Value.AsApproximateFloat64(), active)
+	}
+	if inner.reads != 3 || inner.updates != 2 {
+		t.Errorf("expected a single read for the metrics and the activity but got %d reads and %d updates", inner.reads, inner.updates)
+	}
+}
+
+func TestActivationThresholdScalerKeepsJobPriorities(t *testing.T) {
+	if _, ok := NewActivationThresholdScaler(&activationThresholdTestScaler{}, 1).(JobPriorityScaler); ok {
+		t.Error("expected the scaler not to report job priorities")
+	}
+
+	s, ok := NewActivationThresholdScaler(&activationThresholdTestJobPriorityScaler{activationThresholdTestScaler{value: 5}}, 1).(JobPriorityScaler)
+	if !ok {
+		t.Fatal("expected the scaler to report job priorities")
+	}
+	priorities, err := s.GetJobPriorities(context.Background())
+	if err != nil || len(priorities) != 1 || priorities[0] != "high" {
+		t.Errorf("expected the priorities of the wrapped scaler but got %v, %v", priorities, err)
+	}
+	if active, err := s.IsActive(context.Background()); err != nil || !active {
+		t.Errorf("expected the scaler to be active but got %v, %v", active, err)
+	}
+}
@@ -3,6 +3,7 @@ package scalers
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,8 +11,11 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
 
+	amqp "github.com/Azure/go-amqp"
 	"github.com/go-logr/logr"
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/labels"
@@ -24,21 +28,36 @@ type activeMQScaler struct {
 	metricType v2beta2.MetricTargetType
 	metadata   *activeMQMetadata
 	httpClient *http.Client
+	tlsConfig  *tls.Config
 	logger     logr.Logger
+
+	// the AMQP connection is reused by the polls until it fails
+	amqpLock      sync.Mutex
+	amqpClient    *amqp.Client
+	amqpSession   *amqp.Session
+	amqpBrokerURI string
 }
 
 type activeMQMetadata struct {
+	protocol                  string
+	brokerURIs                []string
 	managementEndpoint        string
 	destinationName           string
 	brokerName                string
 	username                  string
 	password                  string
 	restAPITemplate           string
-	targetQueueSize           int64
+	targetQueueSize           float64
 	activationTargetQueueSize int64
 	corsHeader                string
 	metricName                string
 	scalerIndex               int
+	// timeout bounds the exchange with the broker of the AMQP protocol
+	timeout time.Duration
+
+	// TLS
+	unsafeSsl bool
+	tlsAuthParams
 }
 
 type activeMQMonitoring struct {
@@ -51,6 +70,13 @@ const (
 	defaultTargetQueueSize           = 10
 	defaultActivationTargetQueueSize = 0
 	defaultActiveMQRestAPITemplate   = "http://{{.ManagementEndpoint}}/api/jolokia/read/org.apache.activemq:type=Broker,brokerName={{.BrokerName}},destinationType=Queue,destinationName={{.DestinationName}}/QueueSize"
+	activeMQStatisticsDestination    = "ActiveMQ.Statistics.Destination."
+	defaultActiveMQTimeout           = 3 * time.Second
+)
+
+const (
+	activeMQJolokiaProtocol = "jolokia"
+	activeMQAMQPProtocol    = "amqp"
 )
 
 // NewActiveMQScaler creates a new activeMQ Scaler
@@ -64,12 +90,21 @@ func NewActiveMQScaler(config *ScalerConfig) (Scaler, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error parsing ActiveMQ metadata: %s", err)
 	}
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient := createHTTPClient(config, meta.unsafeSsl)
+
+	var tlsConfig *tls.Config
+	if meta.enableTLS {
+		tlsConfig, err = meta.newTLSConfig(meta.unsafeSsl)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ActiveMQ tls config: %s", err)
+		}
+	}
 
 	return &activeMQScaler{
 		metricType: metricType,
 		metadata:   meta,
 		httpClient: httpClient,
+		tlsConfig:  tlsConfig,
 		logger:     InitializeLogger(config, "active_mq_scaler"),
 	}, nil
 }
@@ -77,34 +112,28 @@ func NewActiveMQScaler(config *ScalerConfig) (Scaler, error) {
 func parseActiveMQMetadata(config *ScalerConfig) (*activeMQMetadata, error) {
 	meta := activeMQMetadata{}
 
-	if val, ok := config.TriggerMetadata["restAPITemplate"]; ok && val != "" {
-		meta.restAPITemplate = config.TriggerMetadata["restAPITemplate"]
-		var err error
-		if meta, err = getRestAPIParameters(meta); err != nil {
-			return nil, fmt.Errorf("can't parse restAPITemplate : %s ", err)
-		}
-	} else {
-		meta.restAPITemplate = defaultActiveMQRestAPITemplate
-		if config.TriggerMetadata["managementEndpoint"] == "" {
-			return nil, errors.New("no management endpoint given")
-		}
-		meta.managementEndpoint = config.TriggerMetadata["managementEndpoint"]
+	meta.protocol = activeMQJolokiaProtocol
+	if val, ok := config.TriggerMetadata["protocol"]; ok && val != "" {
+		meta.protocol = val
+	}
 
-		if config.TriggerMetadata["destinationName"] == "" {
-			return nil, errors.New("no destination name given")
+	switch meta.protocol {
+	case activeMQAMQPProtocol:
+		if err := parseActiveMQAMQPMetadata(config, &meta); err != nil {
+			return nil, err
 		}
-		meta.destinationName = config.TriggerMetadata["destinationName"]
-
-		if config.TriggerMetadata["brokerName"] == "" {
-			return nil, errors.New("no broker name given")
+	case activeMQJolokiaProtocol:
+		if err := parseActiveMQJolokiaMetadata(config, &meta); err != nil {
+			return nil, err
 		}
-		meta.brokerName = config.TriggerMetadata["brokerName"]
+	default:
+		return nil, fmt.Errorf("protocol must be either %s or %s but is %s", activeMQJolokiaProtocol, activeMQAMQPProtocol, meta.protocol)
 	}
 
 	if val, ok := config.TriggerMetadata["targetQueueSize"]; ok {
-		queueSize, err := strconv.ParseInt(val, 10, 64)
+		queueSize, err := strconv.ParseFloat(val, 64)
 		if err != nil {
-			return nil, fmt.Errorf("invalid targetQueueSize - must be an integer")
+			return nil, fmt.Errorf("invalid targetQueueSize - must be a number")
 		}
 
 		meta.targetQueueSize = queueSize
@@ -162,15 +191,116 @@ func parseActiveMQMetadata(config *ScalerConfig) (*activeMQMetadata, error) {
 
 	meta.metricName = GenerateMetricNameWithIndex(config.ScalerIndex, kedautil.NormalizeString(fmt.Sprintf("activemq-%s", meta.destinationName)))
 
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.unsafeSsl = unsafeSsl
+
+	meta.timeout = config.GlobalHTTPTimeout
+	if meta.timeout <= 0 {
+		meta.timeout = defaultActiveMQTimeout
+	}
+
 	meta.scalerIndex = config.ScalerIndex
 
 	return &meta, nil
 }
 
+func parseActiveMQAMQPMetadata(config *ScalerConfig, meta *activeMQMetadata) error {
+	brokerURI, err := GetFromAuthOrMeta(config, "brokerURI")
+	if err != nil {
+		return err
+	}
+	meta.brokerURIs, err = parseActiveMQBrokerURI(brokerURI)
+	if err != nil {
+		return err
+	}
+
+	if config.TriggerMetadata["destinationName"] == "" {
+		return errors.New("no destination name given")
+	}
+	meta.destinationName = config.TriggerMetadata["destinationName"]
+
+	tlsParams, err := parseTLSAuthParams(config)
+	if err != nil {
+		return err
+	}
+	meta.tlsAuthParams = tlsParams
+
+	return nil
+}
+
+// parseActiveMQBrokerURI returns the list of broker URIs to try in order,
+// supporting both plain URIs and failover:(uri1,uri2)?options URIs
+func parseActiveMQBrokerURI(brokerURI string) ([]string, error) {
+	brokerURI = strings.TrimSpace(brokerURI)
+	if strings.HasPrefix(brokerURI, "failover:") {
+		brokerURI = strings.TrimPrefix(brokerURI, "failover:")
+		if strings.HasPrefix(brokerURI, "(") {
+			end := strings.Index(brokerURI, ")")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid failover brokerURI, missing closing parenthesis")
+			}
+			brokerURI = brokerURI[1:end]
+		}
+	}
+
+	var uris []string
+	for _, uri := range strings.Split(brokerURI, ",") {
+		uri = strings.TrimSpace(uri)
+		if uri == "" {
+			continue
+		}
+		u, err := url.Parse(uri)
+		if err != nil {
+			return nil, fmt.Errorf("invalid brokerURI %s: %s", uri, err)
+		}
+		if u.Scheme != "amqp" && u.Scheme != "amqps" {
+			return nil, fmt.Errorf("brokerURI scheme must be amqp or amqps but is %s", u.Scheme)
+		}
+		// ActiveMQ transport options are not understood by the AMQP client
+		u.RawQuery = ""
+		uris = append(uris, u.String())
+	}
+	if len(uris) == 0 {
+		return nil, errors.New("no brokerURI given")
+	}
+	return uris, nil
+}
+
+func parseActiveMQJolokiaMetadata(config *ScalerConfig, meta *activeMQMetadata) error {
+	if val, ok := config.TriggerMetadata["restAPITemplate"]; ok && val != "" {
+		meta.restAPITemplate = config.TriggerMetadata["restAPITemplate"]
+		var err error
+		if *meta, err = getRestAPIParameters(*meta); err != nil {
+			return fmt.Errorf("can't parse restAPITemplate : %s ", err)
+		}
+	} else {
+		meta.restAPITemplate = defaultActiveMQRestAPITemplate
+		if config.TriggerMetadata["managementEndpoint"] == "" {
+			return errors.New("no management endpoint given")
+		}
+		meta.managementEndpoint = config.TriggerMetadata["managementEndpoint"]
+
+		if config.TriggerMetadata["destinationName"] == "" {
+			return errors.New("no destination name given")
+		}
+		meta.destinationName = config.TriggerMetadata["destinationName"]
+
+		if config.TriggerMetadata["brokerName"] == "" {
+			return errors.New("no broker name given")
+		}
+		meta.brokerName = config.TriggerMetadata["brokerName"]
+	}
+
+	return nil
+}
+
 func (s *activeMQScaler) IsActive(ctx context.Context) (bool, error) {
 	queueSize, err := s.getQueueMessageCount(ctx)
 	if err != nil {
-		s.logger.Error(err, "Unable to access activeMQ management endpoint", "managementEndpoint", s.metadata.managementEndpoint)
+		s.logger.Error(err, "Unable to access activeMQ queue statistics", "protocol", s.metadata.protocol, "managementEndpoint", s.metadata.managementEndpoint)
 		return false, err
 	}
 
@@ -225,6 +355,178 @@ func (s *activeMQScaler) getMonitoringEndpoint() (string, error) {
 }
 
 func (s *activeMQScaler) getQueueMessageCount(ctx context.Context) (int64, error) {
+	if s.metadata.protocol == activeMQAMQPProtocol {
+		return s.getQueueMessageCountViaAMQP(ctx)
+	}
+	return s.getQueueMessageCountViaJolokia(ctx)
+}
+
+// getQueueMessageCountViaAMQP queries the ActiveMQ statistics broker plugin, trying the broker of the open
+// connection first and then every broker of the failover list in order
+func (s *activeMQScaler) getQueueMessageCountViaAMQP(ctx context.Context) (int64, error) {
+	s.amqpLock.Lock()
+	defer s.amqpLock.Unlock()
+
+	brokerURIs := make([]string, 0, len(s.metadata.brokerURIs))
+	if s.amqpClient != nil {
+		brokerURIs = append(brokerURIs, s.amqpBrokerURI)
+	}
+	for _, brokerURI := range s.metadata.brokerURIs {
+		if s.amqpClient == nil || brokerURI != s.amqpBrokerURI {
+			brokerURIs = append(brokerURIs, brokerURI)
+		}
+	}
+
+	var lastErr error
+	for _, brokerURI := range brokerURIs {
+		queueMessageCount, err := s.getQueueStatisticsViaAMQP(ctx, brokerURI)
+		if err != nil {
+			s.logger.V(1).Info("ActiveMQ scaler: unable to get queue statistics, trying next broker", "broker", brokerURI, "error", err.Error())
+			// the connection may be broken, the next poll opens a new one
+			s.closeAMQPConnection()
+			lastErr = err
+			continue
+		}
+
+		s.logger.V(1).Info(fmt.Sprintf("ActiveMQ scaler: Providing metrics based on current queue size %d queue size limit %v", queueMessageCount, s.metadata.targetQueueSize))
+		return queueMessageCount, nil
+	}
+	return -1, fmt.Errorf("unable to get queue statistics from any ActiveMQ broker: %s", lastErr)
+}
+
+// getQueueStatisticsViaAMQP requests the statistics of the destination to the broker and waits for the reply,
+// the whole exchange is bounded by the timeout of the trigger so a broker without the statistics plugin,
+// which never replies, doesn't block the polling loop. It must be called with amqpLock held.
+func (s *activeMQScaler) getQueueStatisticsViaAMQP(ctx context.Context, brokerURI string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.metadata.timeout)
+	defer cancel()
+
+	session, err := s.getAMQPSession(ctx, brokerURI)
+	if err != nil {
+		return -1, err
+	}
+	defer closeAMQPOnDone(ctx, s.amqpClient)()
+
+	receiver, err := session.NewReceiver(amqp.LinkAddressDynamic())
+	if err != nil {
+		return -1, fmt.Errorf("error creating reply receiver: %s", err)
+	}
+	defer receiver.Close(ctx)
+
+	sender, err := session.NewSender(amqp.LinkTargetAddress(activeMQStatisticsDestination + s.metadata.destinationName))
+	if err != nil {
+		return -1, fmt.Errorf("error creating statistics sender: %s", err)
+	}
+	defer sender.Close(ctx)
+
+	request := amqp.NewMessage(nil)
+	request.Properties = &amqp.MessageProperties{ReplyTo: receiver.Address()}
+	if err := sender.Send(ctx, request); err != nil {
+		return -1, fmt.Errorf("error requesting queue statistics: %s", err)
+	}
+
+	response, err := receiver.Receive(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("error receiving queue statistics: %s", err)
+	}
+	if err := receiver.AcceptMessage(ctx, response); err != nil {
+		return -1, fmt.Errorf("error accepting queue statistics: %s", err)
+	}
+
+	return getActiveMQStatisticsQueueSize(response.Value)
+}
+
+// getAMQPSession returns the session of the connection to the broker, the connection is opened on the first
+// poll and reused by the next ones. It must be called with amqpLock held.
+func (s *activeMQScaler) getAMQPSession(ctx context.Context, brokerURI string) (*amqp.Session, error) {
+	if s.amqpClient != nil && s.amqpBrokerURI == brokerURI {
+		return s.amqpSession, nil
+	}
+	s.closeAMQPConnection()
+
+	opts := []amqp.ConnOption{
+		amqp.ConnSASLPlain(s.metadata.username, s.metadata.password),
+		amqp.ConnConnectTimeout(s.metadata.timeout),
+	}
+	if s.tlsConfig != nil {
+		opts = append(opts, amqp.ConnTLSConfig(s.tlsConfig))
+	}
+	client, err := amqp.Dial(brokerURI, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %s", brokerURI, err)
+	}
+
+	stop := closeAMQPOnDone(ctx, client)
+	session, err := client.NewSession()
+	stop()
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("error creating session: %s", err)
+	}
+
+	s.amqpClient, s.amqpSession, s.amqpBrokerURI = client, session, brokerURI
+	return session, nil
+}
+
+// closeAMQPOnDone closes the AMQP connection once the context is done, which unblocks the calls of go-amqp
+// taking no context like the session begin and the link attachments. The returned func stops watching.
+func closeAMQPOnDone(ctx context.Context, client *amqp.Client) func() {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			client.Close()
+		case <-done:
+		}
+	}()
+	return func() { close(done) }
+}
+
+// closeAMQPConnection closes the connection to the broker, if any. It must be called with amqpLock held.
+func (s *activeMQScaler) closeAMQPConnection() {
+	if s.amqpClient == nil {
+		return
+	}
+	if err := s.amqpClient.Close(); err != nil {
+		s.logger.V(1).Info("ActiveMQ scaler: error closing connection", "broker", s.amqpBrokerURI, "error", err.Error())
+	}
+	s.amqpClient, s.amqpSession, s.amqpBrokerURI = nil, nil, ""
+}
+
+// getActiveMQStatisticsQueueSize extracts the queue size from the map message
+// sent by the statistics broker plugin
+func getActiveMQStatisticsQueueSize(value interface{}) (int64, error) {
+	var size interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		size = v["size"]
+	case map[interface{}]interface{}:
+		size = v["size"]
+	default:
+		return -1, fmt.Errorf("unexpected statistics message body %T", value)
+	}
+
+	switch v := size.(type) {
+	case int64:
+		return v, nil
+	case int32:
+		return int64(v), nil
+	case int:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case nil:
+		return -1, errors.New("statistics message does not contain size")
+	default:
+		return -1, fmt.Errorf("unexpected statistics size type %T", size)
+	}
+}
+
+func (s *activeMQScaler) getQueueMessageCountViaJolokia(ctx context.Context) (int64, error) {
 	var monitoringInfo *activeMQMonitoring
 	var queueMessageCount int64
 
@@ -260,7 +562,7 @@ func (s *activeMQScaler) getQueueMessageCount(ctx context.Context) (int64, error
 		return -1, fmt.Errorf("ActiveMQ management endpoint response error code : %d %d", resp.StatusCode, monitoringInfo.Status)
 	}
 
-	s.logger.V(1).Info(fmt.Sprintf("ActiveMQ scaler: Providing metrics based on current queue size %d queue size limit %d", queueMessageCount, s.metadata.targetQueueSize))
+	s.logger.V(1).Info(fmt.Sprintf("ActiveMQ scaler: Providing metrics based on current queue size %d queue size limit %v", queueMessageCount, s.metadata.targetQueueSize))
 
 	return queueMessageCount, nil
 }
@@ -271,7 +573,7 @@ func (s *activeMQScaler) GetMetricSpecForScaling(context.Context) []v2beta2.Metr
 		Metric: v2beta2.MetricIdentifier{
 			Name: s.metadata.metricName,
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.targetQueueSize),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetQueueSize),
 	}
 	metricSpec := v2beta2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
@@ -291,5 +593,8 @@ func (s *activeMQScaler) GetMetrics(ctx context.Context, metricName string, metr
 }
 
 func (s *activeMQScaler) Close(context.Context) error {
+	s.amqpLock.Lock()
+	defer s.amqpLock.Unlock()
+	s.closeAMQPConnection()
 	return nil
 }
@@ -3,8 +3,13 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
 )
 
 const (
@@ -217,6 +222,201 @@ var testActiveMQMetadata = []parseActiveMQMetadataTestData{
 		},
 		isError: true,
 	},
+	{
+		name: "properly formed amqp metadata",
+		metadata: map[string]string{
+			"protocol":        "amqp",
+			"brokerURI":       "amqp://localhost:5672",
+			"destinationName": "testQueue",
+		},
+		authParams: map[string]string{
+			"username": "testUsername",
+			"password": "pass123",
+		},
+		isError: false,
+	},
+	{
+		name: "amqp metadata with failover brokerURI and tls",
+		metadata: map[string]string{
+			"protocol":        "amqp",
+			"destinationName": "testQueue",
+		},
+		authParams: map[string]string{
+			"brokerURI": "failover:(amqps://broker-1:5671,amqps://broker-2:5671)?randomize=false",
+			"username":  "testUsername",
+			"password":  "pass123",
+			"tls":       "enable",
+			"ca":        "caaa",
+		},
+		isError: false,
+	},
+	{
+		name: "amqp metadata without brokerURI, should fail",
+		metadata: map[string]string{
+			"protocol":        "amqp",
+			"destinationName": "testQueue",
+		},
+		authParams: map[string]string{
+			"username": "testUsername",
+			"password": "pass123",
+		},
+		isError: true,
+	},
+	{
+		name: "amqp metadata with openwire brokerURI, should fail",
+		metadata: map[string]string{
+			"protocol":        "amqp",
+			"brokerURI":       "tcp://localhost:61616",
+			"destinationName": "testQueue",
+		},
+		authParams: map[string]string{
+			"username": "testUsername",
+			"password": "pass123",
+		},
+		isError: true,
+	},
+	{
+		name: "amqp metadata with cert and without key, should fail",
+		metadata: map[string]string{
+			"protocol":        "amqp",
+			"brokerURI":       "amqps://localhost:5671",
+			"destinationName": "testQueue",
+		},
+		authParams: map[string]string{
+			"username": "testUsername",
+			"password": "pass123",
+			"tls":      "enable",
+			"cert":     "ceert",
+		},
+		isError: true,
+	},
+	{
+		name: "invalid protocol, should fail",
+		metadata: map[string]string{
+			"protocol":           "stomp",
+			"managementEndpoint": "localhost:8161",
+			"destinationName":    "testQueue",
+			"brokerName":         "localhost",
+		},
+		authParams: map[string]string{
+			"username": "testUsername",
+			"password": "pass123",
+		},
+		isError: true,
+	},
+}
+
+type parseActiveMQBrokerURITestData struct {
+	brokerURI string
+	expected  []string
+	isError   bool
+}
+
+var testActiveMQBrokerURIs = []parseActiveMQBrokerURITestData{
+	{"amqp://localhost:5672", []string{"amqp://localhost:5672"}, false},
+	{"amqps://localhost:5671?transport.verifyHost=false", []string{"amqps://localhost:5671"}, false},
+	{"failover:(amqp://broker-1:5672,amqp://broker-2:5672)", []string{"amqp://broker-1:5672", "amqp://broker-2:5672"}, false},
+	{"failover:(amqp://broker-1:5672, amqps://broker-2:5671)?randomize=false", []string{"amqp://broker-1:5672", "amqps://broker-2:5671"}, false},
+	{"failover:amqp://broker-1:5672,amqp://broker-2:5672", []string{"amqp://broker-1:5672", "amqp://broker-2:5672"}, false},
+	{"failover:(amqp://broker-1:5672", nil, true},
+	{"tcp://localhost:61616", nil, true},
+	{"failover:()", nil, true},
+}
+
+func TestParseActiveMQBrokerURI(t *testing.T) {
+	for _, testData := range testActiveMQBrokerURIs {
+		uris, err := parseActiveMQBrokerURI(testData.brokerURI)
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success for %s but got error %s", testData.brokerURI, err)
+			continue
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error for %s but got success", testData.brokerURI)
+			continue
+		}
+		if !reflect.DeepEqual(uris, testData.expected) {
+			t.Errorf("Expected %v for %s but got %v", testData.expected, testData.brokerURI, uris)
+		}
+	}
+}
+
+func TestActiveMQStatisticsQueueSize(t *testing.T) {
+	testCases := []struct {
+		value    interface{}
+		expected int64
+		isError  bool
+	}{
+		{map[string]interface{}{"size": int64(12)}, 12, false},
+		{map[interface{}]interface{}{"size": int32(3)}, 3, false},
+		{map[string]interface{}{"enqueueCount": int64(12)}, -1, true},
+		{"size", -1, true},
+	}
+	for _, tc := range testCases {
+		size, err := getActiveMQStatisticsQueueSize(tc.value)
+		if err != nil && !tc.isError {
+			t.Errorf("Expected success for %v but got error %s", tc.value, err)
+		}
+		if tc.isError && err == nil {
+			t.Errorf("Expected error for %v but got success", tc.value)
+		}
+		if size != tc.expected {
+			t.Errorf("Expected %d for %v but got %d", tc.expected, tc.value, size)
+		}
+	}
+}
+
+func TestActiveMQAMQPStatisticsTimeout(t *testing.T) {
+	// the broker accepts the connections but never answers, like a broker hanging on the statistics request
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				<-stop
+				conn.Close()
+			}()
+		}
+	}()
+
+	meta, err := parseActiveMQMetadata(&ScalerConfig{
+		TriggerMetadata:   map[string]string{"protocol": "amqp", "brokerURI": "amqp://" + lis.Addr().String(), "destinationName": "queue1", "username": "myUserName", "password": "myPassword"},
+		AuthParams:        map[string]string{},
+		GlobalHTTPTimeout: 200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scaler := &activeMQScaler{metadata: meta, logger: logr.Discard()}
+
+	start := time.Now()
+	if _, err := scaler.getQueueMessageCount(context.Background()); err == nil {
+		t.Error("Expected error from a broker which doesn't answer but got success")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the exchange to be bounded by the timeout but it took %s", elapsed)
+	}
+	if scaler.amqpClient != nil {
+		t.Error("Expected the failed connection not to be reused")
+	}
+
+	scaler.amqpLock.Lock()
+	_, err = scaler.getQueueStatisticsViaAMQP(context.Background(), "amqp://127.0.0.1:1")
+	scaler.amqpLock.Unlock()
+	if err == nil {
+		t.Error("Expected error from an unreachable broker but got success")
+	}
+	if err := scaler.Close(context.Background()); err != nil {
+		t.Errorf("Expected no error closing the scaler but got %s", err)
+	}
 }
 
 func TestActiveMQDefaultCorsHeader(t *testing.T) {
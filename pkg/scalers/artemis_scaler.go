@@ -34,9 +34,10 @@ type artemisMetadata struct {
 	username              string
 	password              string
 	restAPITemplate       string
-	queueLength           int64
+	queueLength           float64
 	activationQueueLength int64
 	corsHeader            string
+	unsafeSsl             bool
 	scalerIndex           int
 }
 
@@ -58,11 +59,6 @@ const (
 
 // NewArtemisQueueScaler creates a new artemis queue Scaler
 func NewArtemisQueueScaler(config *ScalerConfig) (Scaler, error) {
-	// do we need to guarantee this timeout for a specific
-	// reason? if not, we can have buildScaler pass in
-	// the global client
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
-
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
 		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
@@ -73,6 +69,11 @@ func NewArtemisQueueScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing artemis metadata: %s", err)
 	}
 
+	// do we need to guarantee this timeout for a specific
+	// reason? if not, we can have buildScaler pass in
+	// the global client
+	httpClient := createHTTPClient(config, artemisMetadata.unsafeSsl)
+
 	return &artemisScaler{
 		metricType: metricType,
 		metadata:   artemisMetadata,
@@ -123,7 +124,7 @@ func parseArtemisMetadata(config *ScalerConfig) (*artemisMetadata, error) {
 	}
 
 	if val, ok := config.TriggerMetadata["queueLength"]; ok {
-		queueLength, err := strconv.ParseInt(val, 10, 64)
+		queueLength, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("can't parse queueLength: %s", err)
 		}
@@ -172,6 +173,12 @@ func parseArtemisMetadata(config *ScalerConfig) (*artemisMetadata, error) {
 		return nil, fmt.Errorf("password cannot be empty")
 	}
 
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.unsafeSsl = unsafeSsl
+
 	meta.scalerIndex = config.ScalerIndex
 
 	return &meta, nil
@@ -262,7 +269,7 @@ func (s *artemisScaler) getQueueMessageCount(ctx context.Context) (int64, error)
 		return -1, fmt.Errorf("artemis management endpoint response error code : %d %d", resp.StatusCode, monitoringInfo.Status)
 	}
 
-	s.logger.V(1).Info(fmt.Sprintf("Artemis scaler: Providing metrics based on current queue length %d queue length limit %d", messageCount, s.metadata.queueLength))
+	s.logger.V(1).Info(fmt.Sprintf("Artemis scaler: Providing metrics based on current queue length %d queue length limit %v", messageCount, s.metadata.queueLength))
 
 	return messageCount, nil
 }
@@ -272,7 +279,7 @@ func (s *artemisScaler) GetMetricSpecForScaling(ctx context.Context) []v2beta2.M
 		Metric: v2beta2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("artemis-%s", s.metadata.queueName))),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.queueLength),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.queueLength),
 	}
 	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: artemisMetricType}
 	return []v2beta2.MetricSpec{metricSpec}
@@ -0,0 +1,295 @@
+package scalers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// awsBatchWindowEnv sets how long the results of the batched CloudWatch and SQS requests are shared
+	// by the triggers of the operator or metrics server, the requests aren't batched when it isn't set
+	awsBatchWindowEnv = "KEDA_AWS_BATCH_WINDOW"
+	// awsMaxMetricDataQueries is the maximum number of queries of a GetMetricData request
+	awsMaxMetricDataQueries = 500
+)
+
+var (
+	awsBatchWindow    = getAwsBatchWindow()
+	cloudwatchBatches = newCloudwatchBatcher(awsBatchWindow)
+	sqsBatches        = newSqsBatcher(awsBatchWindow)
+)
+
+func getAwsBatchWindow() time.Duration {
+	window, err := kedautil.ResolveOsEnvDuration(awsBatchWindowEnv)
+	if err != nil {
+		logf.Log.WithName("aws_batching").Error(err, "invalid "+awsBatchWindowEnv+", the AWS requests aren't batched")
+		return 0
+	}
+	if window == nil || *window < 0 {
+		return 0
+	}
+	return *window
+}
+
+// awsBatchIdentity identifies the credentials of the requests, only requests made with the same credentials are batched
+func awsBatchIdentity(region string, auth awsAuthorizationMetadata, roleArn string) string {
+	identity := "operator"
+	if auth.podIdentityOwner {
+		identity = auth.awsRoleArn
+		if identity == "" {
+			identity = auth.awsAccessKeyID
+		}
+	}
+	return fmt.Sprintf("%s|%s|%s", region, identity, roleArn)
+}
+
+// cloudwatchBatcher fetches the metrics of all the triggers of a group, which share the credentials and the query
+// window, in as few GetMetricData requests as possible, and shares the values for the batch window
+type cloudwatchBatcher struct {
+	window time.Duration
+	now    func() time.Time
+	lock   sync.Mutex
+	groups map[string]*cloudwatchBatchGroup
+}
+
+type cloudwatchBatchGroup struct {
+	lock    sync.Mutex
+	client  cloudwatchiface.CloudWatchAPI
+	members map[*awsCloudwatchScaler]bool
+	// values are nil for the members whose metric has no data
+	values  map[*awsCloudwatchScaler]*float64
+	fetched time.Time
+}
+
+func newCloudwatchBatcher(window time.Duration) *cloudwatchBatcher {
+	return &cloudwatchBatcher{
+		window: window,
+		now:    time.Now,
+		groups: map[string]*cloudwatchBatchGroup{},
+	}
+}
+
+func (b *cloudwatchBatcher) enabled() bool {
+	return b.window > 0
+}
+
+// cloudwatchBatchKey groups the triggers whose queries can be sent in the same GetMetricData request
+func cloudwatchBatchKey(metadata *awsCloudwatchMetadata) string {
+	return fmt.Sprintf("%s|%d|%d|%d", awsBatchIdentity(metadata.awsRegion, metadata.awsAuthorization, ""),
+		metadata.metricStatPeriod, metadata.metricEndTimeOffset, metadata.metricCollectionTime)
+}
+
+func (b *cloudwatchBatcher) register(s *awsCloudwatchScaler) *cloudwatchBatchGroup {
+	key := cloudwatchBatchKey(s.metadata)
+
+	b.lock.Lock()
+	group, ok := b.groups[key]
+	if !ok {
+		group = &cloudwatchBatchGroup{
+			client:  s.cwClient,
+			members: map[*awsCloudwatchScaler]bool{},
+			values:  map[*awsCloudwatchScaler]*float64{},
+		}
+		b.groups[key] = group
+	}
+	b.lock.Unlock()
+
+	group.lock.Lock()
+	defer group.lock.Unlock()
+	group.members[s] = true
+	return group
+}
+
+func (b *cloudwatchBatcher) unregister(s *awsCloudwatchScaler) {
+	key := cloudwatchBatchKey(s.metadata)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	group, ok := b.groups[key]
+	if !ok {
+		return
+	}
+
+	group.lock.Lock()
+	defer group.lock.Unlock()
+	delete(group.members, s)
+	delete(group.values, s)
+	if len(group.members) == 0 {
+		delete(b.groups, key)
+	}
+}
+
+// getValue returns the latest value of the metric of the trigger, nil if it has no data. The metrics of all the
+// triggers of the group are fetched again once the values are older than the batch window.
+func (b *cloudwatchBatcher) getValue(s *awsCloudwatchScaler) (*float64, error) {
+	group := b.register(s)
+
+	group.lock.Lock()
+	defer group.lock.Unlock()
+
+	now := b.now()
+	if value, ok := group.values[s]; ok && now.Sub(group.fetched) < b.window {
+		return value, nil
+	}
+
+	members := make([]*awsCloudwatchScaler, 0, len(group.members))
+	queries := make([]*cloudwatch.MetricDataQuery, 0, len(group.members))
+	for member := range group.members {
+		query := member.metricDataQuery()
+		query.Id = aws.String(fmt.Sprintf("q%d", len(queries)))
+		members = append(members, member)
+		queries = append(queries, query)
+	}
+
+	startTime, endTime := computeQueryWindow(now, s.metadata.metricStatPeriod, s.metadata.metricEndTimeOffset, s.metadata.metricCollectionTime)
+	values, err := getMetricDataValues(group.client, queries, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	group.values = make(map[*awsCloudwatchScaler]*float64, len(members))
+	for i, member := range members {
+		group.values[member] = values[*queries[i].Id]
+	}
+	group.fetched = now
+	return group.values[s], nil
+}
+
+// getMetricDataValues runs the queries in requests of at most awsMaxMetricDataQueries queries and returns
+// the latest value of each query by id
+func getMetricDataValues(client cloudwatchiface.CloudWatchAPI, queries []*cloudwatch.MetricDataQuery, startTime, endTime time.Time) (map[string]*float64, error) {
+	values := map[string]*float64{}
+	for start := 0; start < len(queries); start += awsMaxMetricDataQueries {
+		end := start + awsMaxMetricDataQueries
+		if end > len(queries) {
+			end = len(queries)
+		}
+
+		input := &cloudwatch.GetMetricDataInput{
+			StartTime:         aws.Time(startTime),
+			EndTime:           aws.Time(endTime),
+			ScanBy:            aws.String(cloudwatch.ScanByTimestampDescending),
+			MetricDataQueries: queries[start:end],
+		}
+		for {
+			output, err := client.GetMetricData(input)
+			if err != nil {
+				return nil, err
+			}
+			for _, result := range output.MetricDataResults {
+				// the values are sorted by descending timestamp, the first page has the latest one
+				if result.Id == nil || len(result.Values) == 0 || values[*result.Id] != nil {
+					continue
+				}
+				values[*result.Id] = result.Values[0]
+			}
+			if output.NextToken == nil {
+				break
+			}
+			input.NextToken = output.NextToken
+		}
+	}
+	return values, nil
+}
+
+// sqsBatcher fetches all the message counts of a queue in a single GetQueueAttributes request and shares them
+// for the batch window with the triggers of the queue. SQS has no API returning the attributes of several
+// queues, so the requests are coalesced by queue.
+type sqsBatcher struct {
+	window time.Duration
+	now    func() time.Time
+	lock   sync.Mutex
+	queues map[string]*sqsBatchQueue
+}
+
+type sqsBatchQueue struct {
+	lock       sync.Mutex
+	members    int
+	attributes map[string]*string
+	fetched    time.Time
+}
+
+func newSqsBatcher(window time.Duration) *sqsBatcher {
+	return &sqsBatcher{
+		window: window,
+		now:    time.Now,
+		queues: map[string]*sqsBatchQueue{},
+	}
+}
+
+func (b *sqsBatcher) enabled() bool {
+	return b.window > 0
+}
+
+func sqsBatchKey(metadata *awsSqsQueueMetadata) string {
+	return fmt.Sprintf("%s|%s", awsBatchIdentity(metadata.awsRegion, metadata.awsAuthorization, metadata.roleArn), metadata.queueURL)
+}
+
+func (b *sqsBatcher) register(metadata *awsSqsQueueMetadata) {
+	key := sqsBatchKey(metadata)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	queue, ok := b.queues[key]
+	if !ok {
+		queue = &sqsBatchQueue{}
+		b.queues[key] = queue
+	}
+	queue.members++
+}
+
+func (b *sqsBatcher) unregister(metadata *awsSqsQueueMetadata) {
+	key := sqsBatchKey(metadata)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if queue, ok := b.queues[key]; ok {
+		queue.members--
+		if queue.members <= 0 {
+			delete(b.queues, key)
+		}
+	}
+}
+
+// getAttributes returns the visible, in-flight and delayed message counts of the queue, fetched again once
+// they are older than the batch window
+func (b *sqsBatcher) getAttributes(client sqsiface.SQSAPI, metadata *awsSqsQueueMetadata) (map[string]*string, error) {
+	key := sqsBatchKey(metadata)
+
+	b.lock.Lock()
+	queue, ok := b.queues[key]
+	if !ok {
+		// the queue of a scaler which wasn't registered is fetched every time
+		queue = &sqsBatchQueue{}
+	}
+	b.lock.Unlock()
+
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	now := b.now()
+	if queue.attributes != nil && now.Sub(queue.fetched) < b.window {
+		return queue.attributes, nil
+	}
+
+	output, err := client.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		AttributeNames: aws.StringSlice([]string{awsSqsVisibleMessagesAttribute, awsSqsInFlightMessagesAttribute, awsSqsDelayedMessagesAttribute}),
+		QueueUrl:       aws.String(metadata.queueURL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	queue.attributes = output.Attributes
+	queue.fetched = now
+	return queue.attributes, nil
+}
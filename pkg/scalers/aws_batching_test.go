@@ -0,0 +1,193 @@
+package scalers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/go-logr/logr"
+)
+
+// mockBatchCloudwatch returns the first letter of the metric name of each query as its latest value,
+// followed by a second page of older values
+type mockBatchCloudwatch struct {
+	cloudwatchiface.CloudWatchAPI
+	calls   int
+	queries []int
+}
+
+func (m *mockBatchCloudwatch) GetMetricData(input *cloudwatch.GetMetricDataInput) (*cloudwatch.GetMetricDataOutput, error) {
+	m.calls++
+	if input.NextToken == nil {
+		m.queries = append(m.queries, len(input.MetricDataQueries))
+	}
+
+	output := &cloudwatch.GetMetricDataOutput{}
+	for _, query := range input.MetricDataQueries {
+		metricName := *query.MetricStat.Metric.MetricName
+		if metricName == "empty" {
+			continue
+		}
+		value := float64(metricName[0])
+		if input.NextToken != nil {
+			// older values are on the next page
+			value = -1
+		}
+		output.MetricDataResults = append(output.MetricDataResults, &cloudwatch.MetricDataResult{
+			Id:     query.Id,
+			Values: []*float64{aws.Float64(value)},
+		})
+	}
+	if input.NextToken == nil {
+		output.NextToken = aws.String("next")
+	}
+	return output, nil
+}
+
+type mockBatchSqs struct {
+	sqsiface.SQSAPI
+	calls int
+}
+
+func (m *mockBatchSqs) GetQueueAttributes(input *sqs.GetQueueAttributesInput) (*sqs.GetQueueAttributesOutput, error) {
+	m.calls++
+	return &sqs.GetQueueAttributesOutput{
+		Attributes: map[string]*string{
+			awsSqsVisibleMessagesAttribute:  aws.String("20"),
+			awsSqsInFlightMessagesAttribute: aws.String("10"),
+			awsSqsDelayedMessagesAttribute:  aws.String("5"),
+		},
+	}, nil
+}
+
+func newBatchCloudwatchScaler(client cloudwatchiface.CloudWatchAPI, metricName string, region string) *awsCloudwatchScaler {
+	return &awsCloudwatchScaler{
+		metadata: &awsCloudwatchMetadata{
+			namespace:            "AWS/SQS",
+			metricsName:          metricName,
+			metricStat:           "Average",
+			metricStatPeriod:     300,
+			metricCollectionTime: 300,
+			minMetricValue:       -2,
+			awsRegion:            region,
+		},
+		cwClient: client,
+		logger:   logr.Discard(),
+	}
+}
+
+func TestCloudwatchBatcherGetValue(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	batcher := newCloudwatchBatcher(time.Minute)
+	batcher.now = func() time.Time { return now }
+
+	client := &mockBatchCloudwatch{}
+	a := newBatchCloudwatchScaler(client, "a", "eu-west-1")
+	b := newBatchCloudwatchScaler(client, "b", "eu-west-1")
+	empty := newBatchCloudwatchScaler(client, "empty", "eu-west-1")
+	otherRegion := newBatchCloudwatchScaler(&mockBatchCloudwatch{}, "c", "us-east-1")
+	for _, s := range []*awsCloudwatchScaler{a, b, empty, otherRegion} {
+		batcher.register(s)
+	}
+	if len(batcher.groups) != 2 {
+		t.Fatalf("expected 2 groups but got %d", len(batcher.groups))
+	}
+
+	for _, test := range []struct {
+		scaler   *awsCloudwatchScaler
+		expected *float64
+	}{
+		{a, aws.Float64('a')},
+		{b, aws.Float64('b')},
+		{empty, nil},
+	} {
+		value, err := batcher.getValue(test.scaler)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (value == nil) != (test.expected == nil) || (value != nil && *value != *test.expected) {
+			t.Errorf("%s: expected %v but got %v", test.scaler.metadata.metricsName, test.expected, value)
+		}
+	}
+	// a request of the first page and one of the second page for the three queries
+	if client.calls != 2 {
+		t.Errorf("expected 2 requests but got %d", client.calls)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := batcher.getValue(a); err != nil {
+		t.Fatal(err)
+	}
+	if client.calls != 4 {
+		t.Errorf("expected the values to be fetched again after the window, got %d requests", client.calls)
+	}
+
+	for _, s := range []*awsCloudwatchScaler{a, b, empty, otherRegion} {
+		batcher.unregister(s)
+	}
+	if len(batcher.groups) != 0 {
+		t.Errorf("expected no groups but got %d", len(batcher.groups))
+	}
+}
+
+func TestCloudwatchBatcherSplitsQueries(t *testing.T) {
+	batcher := newCloudwatchBatcher(time.Minute)
+	client := &mockBatchCloudwatch{}
+
+	scalers := make([]*awsCloudwatchScaler, 0, awsMaxMetricDataQueries+1)
+	for i := 0; i <= awsMaxMetricDataQueries; i++ {
+		s := newBatchCloudwatchScaler(client, "a", "eu-west-1")
+		batcher.register(s)
+		scalers = append(scalers, s)
+	}
+
+	if _, err := batcher.getValue(scalers[0]); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.queries) != 2 || client.queries[0] != awsMaxMetricDataQueries || client.queries[1] != 1 {
+		t.Errorf("expected requests of %d and 1 queries but got %v", awsMaxMetricDataQueries, client.queries)
+	}
+}
+
+func TestSqsBatcherGetAttributes(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	batcher := newSqsBatcher(time.Minute)
+	batcher.now = func() time.Time { return now }
+
+	client := &mockBatchSqs{}
+	visible := &awsSqsQueueMetadata{queueURL: testAWSSQSProperQueueURL, awsRegion: "eu-west-1"}
+	inFlight := &awsSqsQueueMetadata{queueURL: testAWSSQSProperQueueURL, awsRegion: "eu-west-1", scaleOnInFlight: true}
+	batcher.register(visible)
+	batcher.register(inFlight)
+
+	for _, metadata := range []*awsSqsQueueMetadata{visible, inFlight} {
+		attributes, err := batcher.getAttributes(client, metadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(attributes) != 3 {
+			t.Errorf("expected 3 attributes but got %d", len(attributes))
+		}
+	}
+	if client.calls != 1 {
+		t.Errorf("expected 1 request but got %d", client.calls)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := batcher.getAttributes(client, visible); err != nil {
+		t.Fatal(err)
+	}
+	if client.calls != 2 {
+		t.Errorf("expected the attributes to be fetched again after the window, got %d requests", client.calls)
+	}
+
+	batcher.unregister(visible)
+	batcher.unregister(inFlight)
+	if len(batcher.queues) != 0 {
+		t.Errorf("expected no queues but got %d", len(batcher.queues))
+	}
+}
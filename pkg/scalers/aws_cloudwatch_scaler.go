@@ -71,12 +71,16 @@ func NewAwsCloudwatchScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing cloudwatch metadata: %s", err)
 	}
 
-	return &awsCloudwatchScaler{
+	scaler := &awsCloudwatchScaler{
 		metricType: metricType,
 		metadata:   meta,
 		cwClient:   createCloudwatchClient(meta),
 		logger:     InitializeLogger(config, "aws_cloudwatch_scaler"),
-	}, nil
+	}
+	if cloudwatchBatches.enabled() {
+		cloudwatchBatches.register(scaler)
+	}
+	return scaler, nil
 }
 
 func getIntMetadataValue(metadata map[string]string, key string, required bool, defaultValue int64) (int64, error) {
@@ -333,63 +337,73 @@ func (s *awsCloudwatchScaler) IsActive(ctx context.Context) (bool, error) {
 }
 
 func (s *awsCloudwatchScaler) Close(context.Context) error {
+	if cloudwatchBatches.enabled() {
+		cloudwatchBatches.unregister(s)
+	}
 	return nil
 }
 
-func (s *awsCloudwatchScaler) GetCloudwatchMetrics() (float64, error) {
-	var input cloudwatch.GetMetricDataInput
+// metricDataQuery returns the query of the metric or expression of the trigger
+func (s *awsCloudwatchScaler) metricDataQuery() *cloudwatch.MetricDataQuery {
+	if s.metadata.expression != "" {
+		return &cloudwatch.MetricDataQuery{
+			Expression: aws.String(s.metadata.expression),
+			Id:         aws.String("q1"),
+			Period:     aws.Int64(s.metadata.metricStatPeriod),
+			Label:      aws.String(s.metadata.metricsName),
+		}
+	}
 
-	startTime, endTime := computeQueryWindow(time.Now(), s.metadata.metricStatPeriod, s.metadata.metricEndTimeOffset, s.metadata.metricCollectionTime)
+	dimensions := []*cloudwatch.Dimension{}
+	for i := range s.metadata.dimensionName {
+		dimensions = append(dimensions, &cloudwatch.Dimension{
+			Name:  &s.metadata.dimensionName[i],
+			Value: &s.metadata.dimensionValue[i],
+		})
+	}
 
-	if s.metadata.expression != "" {
-		input = cloudwatch.GetMetricDataInput{
-			StartTime: aws.Time(startTime),
-			EndTime:   aws.Time(endTime),
-			ScanBy:    aws.String(cloudwatch.ScanByTimestampDescending),
-			MetricDataQueries: []*cloudwatch.MetricDataQuery{
-				{
-					Expression: aws.String(s.metadata.expression),
-					Id:         aws.String("q1"),
-					Period:     aws.Int64(s.metadata.metricStatPeriod),
-					Label:      aws.String(s.metadata.metricsName),
-				},
+	var metricUnit *string
+	if s.metadata.metricUnit != "" {
+		metricUnit = aws.String(s.metadata.metricUnit)
+	}
+
+	return &cloudwatch.MetricDataQuery{
+		Id: aws.String("c1"),
+		MetricStat: &cloudwatch.MetricStat{
+			Metric: &cloudwatch.Metric{
+				Namespace:  aws.String(s.metadata.namespace),
+				Dimensions: dimensions,
+				MetricName: aws.String(s.metadata.metricsName),
 			},
+			Period: aws.Int64(s.metadata.metricStatPeriod),
+			Stat:   aws.String(s.metadata.metricStat),
+			Unit:   metricUnit,
+		},
+		ReturnData: aws.Bool(true),
+	}
+}
+
+func (s *awsCloudwatchScaler) GetCloudwatchMetrics() (float64, error) {
+	if cloudwatchBatches.enabled() {
+		value, err := cloudwatchBatches.getValue(s)
+		if err != nil {
+			s.logger.Error(err, "Failed to get batched output")
+			return -1, err
 		}
-	} else {
-		dimensions := []*cloudwatch.Dimension{}
-		for i := range s.metadata.dimensionName {
-			dimensions = append(dimensions, &cloudwatch.Dimension{
-				Name:  &s.metadata.dimensionName[i],
-				Value: &s.metadata.dimensionValue[i],
-			})
+		if value == nil {
+			s.logger.Info("empty metric data received, returning minMetricValue")
+			return s.metadata.minMetricValue, nil
 		}
+		return *value, nil
+	}
 
-		var metricUnit *string
-		if s.metadata.metricUnit != "" {
-			metricUnit = aws.String(s.metadata.metricUnit)
-		}
+	startTime, endTime := computeQueryWindow(time.Now(), s.metadata.metricStatPeriod, s.metadata.metricEndTimeOffset, s.metadata.metricCollectionTime)
 
-		input = cloudwatch.GetMetricDataInput{
-			StartTime: aws.Time(startTime),
-			EndTime:   aws.Time(endTime),
-			ScanBy:    aws.String(cloudwatch.ScanByTimestampDescending),
-			MetricDataQueries: []*cloudwatch.MetricDataQuery{
-				{
-					Id: aws.String("c1"),
-					MetricStat: &cloudwatch.MetricStat{
-						Metric: &cloudwatch.Metric{
-							Namespace:  aws.String(s.metadata.namespace),
-							Dimensions: dimensions,
-							MetricName: aws.String(s.metadata.metricsName),
-						},
-						Period: aws.Int64(s.metadata.metricStatPeriod),
-						Stat:   aws.String(s.metadata.metricStat),
-						Unit:   metricUnit,
-					},
-					ReturnData: aws.Bool(true),
-				},
-			},
-		}
+	input := cloudwatch.GetMetricDataInput{
+		StartTime:         aws.Time(startTime),
+		EndTime:           aws.Time(endTime),
+		ScanBy:            aws.String(cloudwatch.ScanByTimestampDescending),
+		MetricDataQueries: []*cloudwatch.MetricDataQuery{s.metricDataQuery()},
 	}
 
 	output, err := s.cwClient.GetMetricData(&input)
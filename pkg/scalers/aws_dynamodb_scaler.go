@@ -35,7 +35,7 @@ type awsDynamoDBMetadata struct {
 	keyConditionExpression    string
 	expressionAttributeNames  map[string]*string
 	expressionAttributeValues map[string]*dynamodb.AttributeValue
-	targetValue               int64
+	targetValue               float64
 	activationTargetValue     int64
 	awsAuthorization          awsAuthorizationMetadata
 	scalerIndex               int
@@ -107,7 +107,7 @@ func parseAwsDynamoDBMetadata(config *ScalerConfig) (*awsDynamoDBMetadata, error
 	}
 
 	if val, ok := config.TriggerMetadata["targetValue"]; ok && val != "" {
-		n, err := strconv.ParseInt(val, 10, 64)
+		n, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing metadata targetValue")
 		}
@@ -188,7 +188,7 @@ func (s *awsDynamoDBScaler) GetMetricSpecForScaling(context.Context) []v2beta2.M
 		Metric: v2beta2.MetricIdentifier{
 			Name: s.metadata.metricName,
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.targetValue),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
 	}
 	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: externalMetricType}
 
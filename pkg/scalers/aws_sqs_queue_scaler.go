@@ -25,22 +25,28 @@ const (
 	targetQueueLengthDefault           = 5
 	activationTargetQueueLengthDefault = 0
 	defaultScaleOnInFlight             = true
+	defaultScaleOnDelayed              = false
+	// awsSqsPrioritySampleSize is the maximum number of messages a receive returns
+	awsSqsPrioritySampleSize = 10
 )
 
-var awsSqsQueueMetricNames = []string{
-	"ApproximateNumberOfMessages",
-	"ApproximateNumberOfMessagesNotVisible",
-}
+const (
+	awsSqsVisibleMessagesAttribute  = "ApproximateNumberOfMessages"
+	awsSqsInFlightMessagesAttribute = "ApproximateNumberOfMessagesNotVisible"
+	awsSqsDelayedMessagesAttribute  = "ApproximateNumberOfMessagesDelayed"
+)
 
 type awsSqsQueueScaler struct {
 	metricType v2beta2.MetricTargetType
 	metadata   *awsSqsQueueMetadata
 	sqsClient  sqsiface.SQSAPI
+	smoothing  *kedautil.EWMA
+	tte        *kedautil.TimeToEmpty
 	logger     logr.Logger
 }
 
 type awsSqsQueueMetadata struct {
-	targetQueueLength           int64
+	targetQueueLength           float64
 	activationTargetQueueLength int64
 	queueURL                    string
 	queueName                   string
@@ -48,6 +54,22 @@ type awsSqsQueueMetadata struct {
 	awsAuthorization            awsAuthorizationMetadata
 	scalerIndex                 int
 	scaleOnInFlight             bool
+	scaleOnDelayed              bool
+	priorityAttributeName       string
+	// roleArn is assumed with the credentials of the pod or operator identity, to reach the queues of other accounts
+	roleArn string
+}
+
+// queueAttributeNames returns the attributes of the queue whose message counts are summed
+func (m *awsSqsQueueMetadata) queueAttributeNames() []string {
+	names := []string{awsSqsVisibleMessagesAttribute}
+	if m.scaleOnInFlight {
+		names = append(names, awsSqsInFlightMessagesAttribute)
+	}
+	if m.scaleOnDelayed {
+		names = append(names, awsSqsDelayedMessagesAttribute)
+	}
+	return names
 }
 
 // NewAwsSqsQueueScaler creates a new awsSqsQueueScaler
@@ -64,10 +86,26 @@ func NewAwsSqsQueueScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing SQS queue metadata: %s", err)
 	}
 
+	smoothing, err := GetSmoothingEWMA(config)
+	if err != nil {
+		return nil, err
+	}
+
+	tte, err := GetTimeToEmpty(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if sqsBatches.enabled() {
+		sqsBatches.register(meta)
+	}
+
 	return &awsSqsQueueScaler{
 		metricType: metricType,
 		metadata:   meta,
 		sqsClient:  createSqsClient(meta),
+		smoothing:  smoothing,
+		tte:        tte,
 		logger:     logger,
 	}, nil
 }
@@ -76,9 +114,10 @@ func parseAwsSqsQueueMetadata(config *ScalerConfig, logger logr.Logger) (*awsSqs
 	meta := awsSqsQueueMetadata{}
 	meta.targetQueueLength = defaultTargetQueueLength
 	meta.scaleOnInFlight = defaultScaleOnInFlight
+	meta.scaleOnDelayed = defaultScaleOnDelayed
 
 	if val, ok := config.TriggerMetadata["queueLength"]; ok && val != "" {
-		queueLength, err := strconv.ParseInt(val, 10, 64)
+		queueLength, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			meta.targetQueueLength = targetQueueLengthDefault
 			logger.Error(err, "Error parsing SQS queue metadata queueLength, using default %n", targetQueueLengthDefault)
@@ -107,10 +146,12 @@ func parseAwsSqsQueueMetadata(config *ScalerConfig, logger logr.Logger) (*awsSqs
 		}
 	}
 
-	if !meta.scaleOnInFlight {
-		awsSqsQueueMetricNames = []string{
-			"ApproximateNumberOfMessages",
+	if val, ok := config.TriggerMetadata["scaleOnDelayed"]; ok && val != "" {
+		scaleOnDelayed, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing scaleOnDelayed: %s", err)
 		}
+		meta.scaleOnDelayed = scaleOnDelayed
 	}
 
 	if val, ok := config.TriggerMetadata["queueURL"]; ok && val != "" {
@@ -139,6 +180,10 @@ func parseAwsSqsQueueMetadata(config *ScalerConfig, logger logr.Logger) (*awsSqs
 		return nil, fmt.Errorf("no awsRegion given")
 	}
 
+	// the priorities are sampled by receiving messages without hiding them, which increases their receive count,
+	// so the maxReceiveCount of the redrive policy must leave room for the samples
+	meta.priorityAttributeName = config.TriggerMetadata["priorityAttributeName"]
+
 	auth, err := getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
 	if err != nil {
 		return nil, err
@@ -146,6 +191,13 @@ func parseAwsSqsQueueMetadata(config *ScalerConfig, logger logr.Logger) (*awsSqs
 
 	meta.awsAuthorization = auth
 
+	if val, ok := config.TriggerMetadata["roleArn"]; ok && val != "" {
+		if !strings.HasPrefix(val, "arn:") {
+			return nil, fmt.Errorf("roleArn must be the ARN of an IAM role")
+		}
+		meta.roleArn = val
+	}
+
 	meta.scalerIndex = config.ScalerIndex
 
 	return &meta, nil
@@ -156,24 +208,22 @@ func createSqsClient(metadata *awsSqsQueueMetadata) *sqs.SQS {
 		Region: aws.String(metadata.awsRegion),
 	}))
 
-	var sqsClient *sqs.SQS
+	config := &aws.Config{
+		Region: aws.String(metadata.awsRegion),
+	}
 	if metadata.awsAuthorization.podIdentityOwner {
 		creds := credentials.NewStaticCredentials(metadata.awsAuthorization.awsAccessKeyID, metadata.awsAuthorization.awsSecretAccessKey, metadata.awsAuthorization.awsSessionToken)
 
 		if metadata.awsAuthorization.awsRoleArn != "" {
 			creds = stscreds.NewCredentials(sess, metadata.awsAuthorization.awsRoleArn)
 		}
+		config.Credentials = creds
+	}
 
-		sqsClient = sqs.New(sess, &aws.Config{
-			Region:      aws.String(metadata.awsRegion),
-			Credentials: creds,
-		})
-	} else {
-		sqsClient = sqs.New(sess, &aws.Config{
-			Region: aws.String(metadata.awsRegion),
-		})
+	if metadata.roleArn != "" {
+		config.Credentials = stscreds.NewCredentials(sess.Copy(&aws.Config{Credentials: config.Credentials}), metadata.roleArn)
 	}
-	return sqsClient
+	return sqs.New(sess, config)
 }
 
 // IsActive determines if we need to scale from zero
@@ -184,10 +234,14 @@ func (s *awsSqsQueueScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
+	// the activation compares the raw queue length, only the metric is smoothed
 	return length > s.metadata.activationTargetQueueLength, nil
 }
 
 func (s *awsSqsQueueScaler) Close(context.Context) error {
+	if sqsBatches.enabled() {
+		sqsBatches.unregister(s.metadata)
+	}
 	return nil
 }
 
@@ -196,7 +250,7 @@ func (s *awsSqsQueueScaler) GetMetricSpecForScaling(context.Context) []v2beta2.M
 		Metric: v2beta2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("aws-sqs-%s", s.metadata.queueName))),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.targetQueueLength),
+		Target: timeToEmptyMetricTarget(s.tte, s.metricType, s.metadata.targetQueueLength),
 	}
 	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta2.MetricSpec{metricSpec}
@@ -204,33 +258,95 @@ func (s *awsSqsQueueScaler) GetMetricSpecForScaling(context.Context) []v2beta2.M
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *awsSqsQueueScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	metrics, _, err := s.GetMetricsAndActivity(ctx, metricName, metricSelector)
+	return metrics, err
+}
+
+// GetMetricsAndActivity returns the metric and whether the scaler is active from a single read of the queue length
+func (s *awsSqsQueueScaler) GetMetricsAndActivity(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, bool, error) {
 	queuelen, err := s.getAwsSqsQueueLength()
 
 	if err != nil {
 		s.logger.Error(err, "Error getting queue length")
-		return []external_metrics.ExternalMetricValue{}, err
+		return []external_metrics.ExternalMetricValue{}, false, err
 	}
 
-	metric := GenerateMetricInMili(metricName, float64(queuelen))
+	metric := GenerateMetricInMili(metricName, s.metricValue(float64(queuelen)))
 
-	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+	// the activation compares the raw queue length, only the metric is smoothed
+	return append([]external_metrics.ExternalMetricValue{}, metric), queuelen > s.metadata.activationTargetQueueLength, nil
 }
 
-// Get SQS Queue Length
-func (s *awsSqsQueueScaler) getAwsSqsQueueLength() (int64, error) {
-	input := &sqs.GetQueueAttributesInput{
-		AttributeNames: aws.StringSlice(awsSqsQueueMetricNames),
-		QueueUrl:       aws.String(s.metadata.queueURL),
+// getRawMetricValue returns the queue length, before smoothing and time to empty
+func (s *awsSqsQueueScaler) getRawMetricValue(context.Context) (float64, error) {
+	queuelen, err := s.getAwsSqsQueueLength()
+	return float64(queuelen), err
+}
+
+// metricValue smooths the queue length and converts it to the time to empty the queue, if they are enabled
+func (s *awsSqsQueueScaler) metricValue(rawValue float64) float64 {
+	return timeToEmptyMetricValue(s.tte, smoothMetricValue(s.smoothing, rawValue))
+}
+
+// GetJobPriorities samples the messages of the queue and returns the values of their priority attribute
+func (s *awsSqsQueueScaler) GetJobPriorities(ctx context.Context) ([]string, error) {
+	if s.metadata.priorityAttributeName == "" {
+		return nil, nil
 	}
 
-	output, err := s.sqsClient.GetQueueAttributes(input)
+	input := &sqs.ReceiveMessageInput{
+		QueueUrl:              aws.String(s.metadata.queueURL),
+		MaxNumberOfMessages:   aws.Int64(awsSqsPrioritySampleSize),
+		MessageAttributeNames: aws.StringSlice([]string{s.metadata.priorityAttributeName}),
+		// the sampled messages stay visible to the consumers
+		VisibilityTimeout: aws.Int64(0),
+	}
+
+	output, err := s.sqsClient.ReceiveMessageWithContext(ctx, input)
 	if err != nil {
-		return -1, err
+		return nil, err
+	}
+
+	var priorities []string
+	for _, message := range output.Messages {
+		if attribute, ok := message.MessageAttributes[s.metadata.priorityAttributeName]; ok && attribute.StringValue != nil {
+			priorities = append(priorities, *attribute.StringValue)
+		}
+	}
+	return priorities, nil
+}
+
+// Get SQS Queue Length
+func (s *awsSqsQueueScaler) getAwsSqsQueueLength() (int64, error) {
+	attributeNames := s.metadata.queueAttributeNames()
+
+	var attributes map[string]*string
+	if sqsBatches.enabled() {
+		var err error
+		attributes, err = sqsBatches.getAttributes(s.sqsClient, s.metadata)
+		if err != nil {
+			return -1, err
+		}
+	} else {
+		input := &sqs.GetQueueAttributesInput{
+			AttributeNames: aws.StringSlice(attributeNames),
+			QueueUrl:       aws.String(s.metadata.queueURL),
+		}
+
+		output, err := s.sqsClient.GetQueueAttributes(input)
+		if err != nil {
+			return -1, err
+		}
+		attributes = output.Attributes
 	}
 
 	var approximateNumberOfMessages int64
-	for _, awsSqsQueueMetric := range awsSqsQueueMetricNames {
-		metricValue, err := strconv.ParseInt(*output.Attributes[awsSqsQueueMetric], 10, 32)
+	for _, awsSqsQueueMetric := range attributeNames {
+		attribute, ok := attributes[awsSqsQueueMetric]
+		if !ok || attribute == nil {
+			return -1, fmt.Errorf("queue attributes have no %s", awsSqsQueueMetric)
+		}
+		metricValue, err := strconv.ParseInt(*attribute, 10, 32)
 		if err != nil {
 			return -1, err
 		}
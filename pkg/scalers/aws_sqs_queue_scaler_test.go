@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/go-logr/logr"
@@ -67,6 +68,22 @@ func (m *mockSqs) GetQueueAttributes(input *sqs.GetQueueAttributesInput) (*sqs.G
 		Attributes: map[string]*string{
 			"ApproximateNumberOfMessages":           aws.String("200"),
 			"ApproximateNumberOfMessagesNotVisible": aws.String("100"),
+			"ApproximateNumberOfMessagesDelayed":    aws.String("50"),
+		},
+	}, nil
+}
+
+func (m *mockSqs) ReceiveMessageWithContext(_ aws.Context, input *sqs.ReceiveMessageInput, _ ...request.Option) (*sqs.ReceiveMessageOutput, error) {
+	if *input.QueueUrl == testAWSSQSErrorQueueURL {
+		return nil, errors.New("some error")
+	}
+
+	attribute := *input.MessageAttributeNames[0]
+	return &sqs.ReceiveMessageOutput{
+		Messages: []*sqs.Message{
+			{MessageAttributes: map[string]*sqs.MessageAttributeValue{attribute: {DataType: aws.String("String"), StringValue: aws.String("high")}}},
+			{MessageAttributes: map[string]*sqs.MessageAttributeValue{}},
+			{MessageAttributes: map[string]*sqs.MessageAttributeValue{attribute: {DataType: aws.String("String"), StringValue: aws.String("low")}}},
 		},
 	}, nil
 }
@@ -240,6 +257,38 @@ var testAWSSQSMetadata = []parseAWSSQSMetadataTestData{
 		testAWSSQSAuthentication,
 		false,
 		"properly formed queue and region"},
+	{map[string]string{
+		"queueURL":       testAWSSimpleQueueURL,
+		"queueLength":    "1",
+		"awsRegion":      "eu-west-1",
+		"scaleOnDelayed": "true"},
+		testAWSSQSAuthentication,
+		false,
+		"counting the delayed messages"},
+	{map[string]string{
+		"queueURL":       testAWSSimpleQueueURL,
+		"queueLength":    "1",
+		"awsRegion":      "eu-west-1",
+		"scaleOnDelayed": "maybe"},
+		testAWSSQSAuthentication,
+		true,
+		"invalid scaleOnDelayed"},
+	{map[string]string{
+		"queueURL":    testAWSSQSProperQueueURL,
+		"queueLength": "1",
+		"awsRegion":   "eu-west-1",
+		"roleArn":     "arn:aws:iam::123456789012:role/keda-sqs"},
+		testAWSSQSAuthentication,
+		false,
+		"cross-account role of the trigger"},
+	{map[string]string{
+		"queueURL":    testAWSSQSProperQueueURL,
+		"queueLength": "1",
+		"awsRegion":   "eu-west-1",
+		"roleArn":     "keda-sqs"},
+		testAWSSQSAuthentication,
+		true,
+		"roleArn isn't an ARN"},
 }
 
 var awsSQSMetricIdentifiers = []awsSQSMetricIdentifier{
@@ -249,6 +298,9 @@ var awsSQSMetricIdentifiers = []awsSQSMetricIdentifier{
 
 var awsSQSGetMetricTestData = []*awsSqsQueueMetadata{
 	{queueURL: testAWSSQSProperQueueURL},
+	{queueURL: testAWSSQSProperQueueURL, scaleOnInFlight: true},
+	{queueURL: testAWSSQSProperQueueURL, scaleOnInFlight: true, scaleOnDelayed: true},
+	{queueURL: testAWSSQSProperQueueURL, scaleOnDelayed: true},
 	{queueURL: testAWSSQSErrorQueueURL},
 	{queueURL: testAWSSQSBadDataQueueURL},
 }
@@ -272,7 +324,7 @@ func TestAWSSQSGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockAWSSQSScaler := awsSqsQueueScaler{"", meta, &mockSqs{}, logr.Discard()}
+		mockAWSSQSScaler := awsSqsQueueScaler{metadata: meta, sqsClient: &mockSqs{}, logger: logr.Discard()}
 
 		metricSpec := mockAWSSQSScaler.GetMetricSpecForScaling(ctx)
 		metricName := metricSpec[0].External.Metric.Name
@@ -285,7 +337,7 @@ func TestAWSSQSGetMetricSpecForScaling(t *testing.T) {
 func TestAWSSQSScalerGetMetrics(t *testing.T) {
 	var selector labels.Selector
 	for _, meta := range awsSQSGetMetricTestData {
-		scaler := awsSqsQueueScaler{"", meta, &mockSqs{}, logr.Discard()}
+		scaler := awsSqsQueueScaler{metadata: meta, sqsClient: &mockSqs{}, logger: logr.Discard()}
 		value, err := scaler.GetMetrics(context.Background(), "MetricName", selector)
 		switch meta.queueURL {
 		case testAWSSQSErrorQueueURL:
@@ -293,11 +345,30 @@ func TestAWSSQSScalerGetMetrics(t *testing.T) {
 		case testAWSSQSBadDataQueueURL:
 			assert.Error(t, err, "expect error because of bad data return from sqs")
 		default:
+			expected := int64(200)
 			if meta.scaleOnInFlight {
-				assert.EqualValues(t, int64(300.0), value[0].Value.Value())
-			} else {
-				assert.EqualValues(t, int64(200.0), value[0].Value.Value())
+				expected += 100
 			}
+			if meta.scaleOnDelayed {
+				expected += 50
+			}
+			assert.EqualValues(t, expected, value[0].Value.Value())
 		}
 	}
 }
+
+func TestAWSSQSScalerGetJobPriorities(t *testing.T) {
+	scaler := awsSqsQueueScaler{metadata: &awsSqsQueueMetadata{queueURL: testAWSSQSProperQueueURL}, sqsClient: &mockSqs{}, logger: logr.Discard()}
+	priorities, err := scaler.GetJobPriorities(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, priorities, "expect no sampling without priorityAttributeName")
+
+	scaler.metadata.priorityAttributeName = "priority"
+	priorities, err = scaler.GetJobPriorities(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"high", "low"}, priorities)
+
+	scaler.metadata.queueURL = testAWSSQSErrorQueueURL
+	_, err = scaler.GetJobPriorities(context.Background())
+	assert.Error(t, err)
+}
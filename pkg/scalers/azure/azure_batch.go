@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	az "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+const batchAPIVersion = "2022-01-01.15.0"
+
+// BatchInfo to query the task counts of a Batch job or of the active jobs of a pool
+type BatchInfo struct {
+	AccountURL              string
+	JobID                   string
+	PoolID                  string
+	TenantID                string
+	ClientID                string
+	ClientPassword          string
+	BatchResourceURL        string
+	ActiveDirectoryEndpoint string
+}
+
+// BatchTaskCounts are the task counts of a Batch job by state
+type BatchTaskCounts struct {
+	Active    int64 `json:"active"`
+	Running   int64 `json:"running"`
+	Completed int64 `json:"completed"`
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+}
+
+type batchJobTaskCountsResult struct {
+	TaskCounts BatchTaskCounts `json:"taskCounts"`
+}
+
+type batchJobListResult struct {
+	Value []struct {
+		ID string `json:"id"`
+	} `json:"value"`
+	NextLink string `json:"odata.nextLink"`
+}
+
+// ParseBatchResourceURL returns the Azure AD resource of the Batch service of the cloud
+func ParseBatchResourceURL(metadata map[string]string) (string, error) {
+	return ParseEnvironmentProperty(metadata, "batchResourceURL", func(env az.Environment) (string, error) {
+		return env.ResourceIdentifiers.Batch, nil
+	})
+}
+
+// GetAzureBatchTaskCounts returns the task counts of the job, or the sum of the task counts of the active jobs of the pool
+func GetAzureBatchTaskCounts(ctx context.Context, info BatchInfo, podIdentity kedav1alpha1.AuthPodIdentity) (BatchTaskCounts, error) {
+	config := getBatchAuthConfig(ctx, info, podIdentity)
+	if config == nil {
+		return BatchTaskCounts{}, fmt.Errorf("azure batch doesn't support pod identity %s", podIdentity.Provider)
+	}
+	authorizer, err := config.Authorizer()
+	if err != nil {
+		return BatchTaskCounts{}, err
+	}
+
+	return getBatchTaskCounts(ctx, info, authorizer)
+}
+
+func getBatchAuthConfig(ctx context.Context, info BatchInfo, podIdentity kedav1alpha1.AuthPodIdentity) auth.AuthorizerConfig {
+	switch podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		config := auth.NewClientCredentialsConfig(info.ClientID, info.ClientPassword, info.TenantID)
+		config.Resource = info.BatchResourceURL
+		config.AADEndpoint = info.ActiveDirectoryEndpoint
+		return config
+	case kedav1alpha1.PodIdentityProviderAzure:
+		config := auth.NewMSIConfig()
+		config.Resource = info.BatchResourceURL
+		config.ClientID = podIdentity.IdentityID
+		return config
+	case kedav1alpha1.PodIdentityProviderAzureWorkload:
+		return NewAzureADWorkloadIdentityConfig(ctx, podIdentity.IdentityID, info.BatchResourceURL)
+	}
+	return nil
+}
+
+func getBatchTaskCounts(ctx context.Context, info BatchInfo, authorizer autorest.Authorizer) (BatchTaskCounts, error) {
+	if info.JobID != "" {
+		return getBatchJobTaskCounts(ctx, info, authorizer, info.JobID)
+	}
+
+	jobIDs, err := getBatchPoolActiveJobIDs(ctx, info, authorizer)
+	if err != nil {
+		return BatchTaskCounts{}, err
+	}
+
+	total := BatchTaskCounts{}
+	for _, jobID := range jobIDs {
+		counts, err := getBatchJobTaskCounts(ctx, info, authorizer, jobID)
+		if err != nil {
+			return BatchTaskCounts{}, err
+		}
+		total.Active += counts.Active
+		total.Running += counts.Running
+		total.Completed += counts.Completed
+		total.Succeeded += counts.Succeeded
+		total.Failed += counts.Failed
+	}
+	return total, nil
+}
+
+func getBatchJobTaskCounts(ctx context.Context, info BatchInfo, authorizer autorest.Authorizer, jobID string) (BatchTaskCounts, error) {
+	result := batchJobTaskCountsResult{}
+	err := sendBatchRequest(ctx, authorizer, &result,
+		autorest.WithBaseURL(info.AccountURL),
+		autorest.WithPathParameters("/jobs/{jobId}/taskcounts", map[string]interface{}{"jobId": autorest.Encode("path", jobID)}),
+		autorest.WithQueryParameters(map[string]interface{}{"api-version": batchAPIVersion}))
+	if err != nil {
+		return BatchTaskCounts{}, fmt.Errorf("error getting task counts of job %s: %s", jobID, err)
+	}
+	return result.TaskCounts, nil
+}
+
+// getBatchPoolActiveJobIDs returns the ids of the active jobs which run on the pool
+func getBatchPoolActiveJobIDs(ctx context.Context, info BatchInfo, authorizer autorest.Authorizer) ([]string, error) {
+	var jobIDs []string
+	decorators := []autorest.PrepareDecorator{
+		autorest.WithBaseURL(info.AccountURL),
+		autorest.WithPath("/jobs"),
+		autorest.WithQueryParameters(map[string]interface{}{
+			"api-version": batchAPIVersion,
+			"$filter":     autorest.Encode("query", fmt.Sprintf("state eq 'active' and executionInfo/poolId eq '%s'", info.PoolID)),
+			"$select":     "id",
+		}),
+	}
+	for {
+		result := batchJobListResult{}
+		if err := sendBatchRequest(ctx, authorizer, &result, decorators...); err != nil {
+			return nil, fmt.Errorf("error listing jobs of pool %s: %s", info.PoolID, err)
+		}
+		for _, job := range result.Value {
+			jobIDs = append(jobIDs, job.ID)
+		}
+		if result.NextLink == "" {
+			return jobIDs, nil
+		}
+		decorators = []autorest.PrepareDecorator{autorest.WithBaseURL(result.NextLink)}
+	}
+}
+
+func sendBatchRequest(ctx context.Context, authorizer autorest.Authorizer, result interface{}, decorators ...autorest.PrepareDecorator) error {
+	decorators = append([]autorest.PrepareDecorator{autorest.AsGet()}, decorators...)
+	decorators = append(decorators, authorizer.WithAuthorization())
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx), decorators...)
+	if err != nil {
+		return err
+	}
+
+	resp, err := autorest.Send(req,
+		autorest.DoErrorUnlessStatusCode(http.StatusOK),
+		autorest.DoCloseIfError())
+	if err != nil {
+		return err
+	}
+
+	return autorest.Respond(resp,
+		autorest.ByUnmarshallingJSON(result),
+		autorest.ByClosing())
+}
@@ -20,10 +20,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/imdario/mergo"
@@ -62,6 +66,7 @@ type pythonCheckpoint struct {
 	SequenceNumber int64  `json:"sequence_number"`
 }
 
+// checkpointer reads the checkpoint of a partition with the blob layout of a consumer
 type checkpointer interface {
 	resolvePath(info EventHubInfo) (*url.URL, error)
 	extractCheckpoint(get *azblob.DownloadResponse) (Checkpoint, error)
@@ -75,6 +80,9 @@ type azureFunctionCheckpointer struct {
 type blobMetadataCheckpointer struct {
 	partitionID   string
 	containerName string
+	// caseSensitive keeps the case of the namespace, event hub and consumer group in the blob name,
+	// the azeventhubs Go SDK doesn't lowercase them unlike the other Azure SDKs
+	caseSensitive bool
 }
 
 type goSdkCheckpointer struct {
@@ -82,11 +90,79 @@ type goSdkCheckpointer struct {
 	containerName string
 }
 
+type daprCheckpointer struct {
+	partitionID   string
+	containerName string
+}
+
 type defaultCheckpointer struct {
 	partitionID   string
 	containerName string
 }
 
+// cachedCheckpoint is the last checkpoint read from a blob, identified by the blob ETag
+type cachedCheckpoint struct {
+	etag       azblob.ETag
+	checkpoint Checkpoint
+}
+
+// checkpointCache caches checkpoints per blob url, so that blobs which haven't changed
+// since the last poll aren't downloaded and parsed again
+var checkpointCache sync.Map
+
+// supported checkpoint strategies
+const (
+	CheckpointStrategyAzureFunction = "azureFunction"
+	CheckpointStrategyBlobMetadata  = "blobMetadata"
+	CheckpointStrategyGoSdk         = "goSdk"
+	CheckpointStrategyAzeventhubs   = "azeventhubs"
+	CheckpointStrategyDapr          = "dapr"
+)
+
+// checkpointStrategies creates the checkpointer of each strategy for a partition
+var checkpointStrategies = map[string]func(info EventHubInfo, partitionID string) checkpointer{
+	// checkpoints of the Azure Functions Event Hubs extension before v5
+	CheckpointStrategyAzureFunction: func(info EventHubInfo, partitionID string) checkpointer {
+		return &azureFunctionCheckpointer{containerName: "azure-webjobs-eventhub", partitionID: partitionID}
+	},
+	// checkpoints in the blob metadata, written by the .NET, Java, Python and JavaScript Azure SDKs
+	// and the Azure Functions Event Hubs extension v5
+	CheckpointStrategyBlobMetadata: func(info EventHubInfo, partitionID string) checkpointer {
+		return &blobMetadataCheckpointer{containerName: info.BlobContainer, partitionID: partitionID}
+	},
+	// checkpoints of the legacy azure-event-hubs-go SDK
+	CheckpointStrategyGoSdk: func(info EventHubInfo, partitionID string) checkpointer {
+		return &goSdkCheckpointer{containerName: info.BlobContainer, partitionID: partitionID}
+	},
+	// checkpoints in the blob metadata written by the azeventhubs Go SDK, also used by the recent Dapr versions
+	CheckpointStrategyAzeventhubs: func(info EventHubInfo, partitionID string) checkpointer {
+		return &blobMetadataCheckpointer{containerName: info.BlobContainer, partitionID: partitionID, caseSensitive: true}
+	},
+	// checkpoints of the Dapr Event Hubs bindings and pubsub based on the azure-event-hubs-go SDK
+	CheckpointStrategyDapr: func(info EventHubInfo, partitionID string) checkpointer {
+		return &daprCheckpointer{containerName: info.BlobContainer, partitionID: partitionID}
+	},
+}
+
+// IsValidCheckpointStrategy returns true if the checkpoint strategy is supported, empty being the default strategy
+func IsValidCheckpointStrategy(strategy string) bool {
+	if strategy == "" {
+		return true
+	}
+	_, ok := checkpointStrategies[strategy]
+	return ok
+}
+
+// CheckpointStrategies returns the supported checkpoint strategies, sorted by name
+func CheckpointStrategies() []string {
+	strategies := make([]string, 0, len(checkpointStrategies))
+	for strategy := range checkpointStrategies {
+		strategies = append(strategies, strategy)
+	}
+	sort.Strings(strategies)
+	return strategies
+}
+
 // GetCheckpointFromBlobStorage reads depending of the CheckpointStrategy the checkpoint from a azure storage
 func GetCheckpointFromBlobStorage(ctx context.Context, httpClient util.HTTPDoer, info EventHubInfo, partitionID string) (Checkpoint, error) {
 	checkpointer := newCheckpointer(info, partitionID)
@@ -94,27 +170,17 @@ func GetCheckpointFromBlobStorage(ctx context.Context, httpClient util.HTTPDoer,
 }
 
 func newCheckpointer(info EventHubInfo, partitionID string) checkpointer {
-	switch {
-	case (info.CheckpointStrategy == "goSdk"):
-		return &goSdkCheckpointer{
-			containerName: info.BlobContainer,
-			partitionID:   partitionID,
-		}
-	case (info.CheckpointStrategy == "blobMetadata"):
-		return &blobMetadataCheckpointer{
-			containerName: info.BlobContainer,
-			partitionID:   partitionID,
-		}
-	case (info.CheckpointStrategy == "azureFunction" || info.BlobContainer == ""):
-		return &azureFunctionCheckpointer{
-			containerName: "azure-webjobs-eventhub",
-			partitionID:   partitionID,
-		}
-	default:
-		return &defaultCheckpointer{
-			containerName: info.BlobContainer,
-			partitionID:   partitionID,
-		}
+	if newStrategyCheckpointer, ok := checkpointStrategies[info.CheckpointStrategy]; ok {
+		return newStrategyCheckpointer(info, partitionID)
+	}
+
+	// without strategy, the Azure Functions layout is used when no container is given
+	if info.BlobContainer == "" {
+		return checkpointStrategies[CheckpointStrategyAzureFunction](info, partitionID)
+	}
+	return &defaultCheckpointer{
+		containerName: info.BlobContainer,
+		partitionID:   partitionID,
 	}
 }
 
@@ -151,7 +217,14 @@ func (checkpointer *blobMetadataCheckpointer) resolvePath(info EventHubInfo) (*u
 		return nil, err
 	}
 
-	path, err := url.Parse(fmt.Sprintf("/%s/%s/%s/%s/checkpoint/%s", checkpointer.containerName, eventHubNamespace, eventHubName, strings.ToLower(info.EventHubConsumerGroup), checkpointer.partitionID))
+	consumerGroup := info.EventHubConsumerGroup
+	if !checkpointer.caseSensitive {
+		eventHubNamespace = strings.ToLower(eventHubNamespace)
+		eventHubName = strings.ToLower(eventHubName)
+		consumerGroup = strings.ToLower(consumerGroup)
+	}
+
+	path, err := url.Parse(fmt.Sprintf("/%s/%s/%s/%s/checkpoint/%s", checkpointer.containerName, eventHubNamespace, eventHubName, consumerGroup, checkpointer.partitionID))
 	if err != nil {
 		return nil, err
 	}
@@ -176,6 +249,30 @@ func (checkpointer *goSdkCheckpointer) resolvePath(info EventHubInfo) (*url.URL,
 
 // extract checkpoint for goSdkCheckpointer
 func (checkpointer *goSdkCheckpointer) extractCheckpoint(get *azblob.DownloadResponse) (Checkpoint, error) {
+	return newGoSdkCheckpoint(get)
+}
+
+// resolve path for daprCheckpointer
+func (checkpointer *daprCheckpointer) resolvePath(info EventHubInfo) (*url.URL, error) {
+	_, eventHubName, err := getHubAndNamespace(info)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := url.Parse(fmt.Sprintf("/%s/dapr-%s-%s-%s", info.BlobContainer, eventHubName, info.EventHubConsumerGroup, checkpointer.partitionID))
+	if err != nil {
+		return nil, err
+	}
+
+	return path, nil
+}
+
+// extract checkpoint for daprCheckpointer, dapr stores checkpoints with the go sdk format
+func (checkpointer *daprCheckpointer) extractCheckpoint(get *azblob.DownloadResponse) (Checkpoint, error) {
+	return newGoSdkCheckpoint(get)
+}
+
+func newGoSdkCheckpoint(get *azblob.DownloadResponse) (Checkpoint, error) {
 	var checkpoint goCheckpoint
 	err := readToCheckpointFromBody(get, &checkpoint)
 	if err != nil {
@@ -237,12 +334,38 @@ func getCheckpoint(ctx context.Context, httpClient util.HTTPDoer, info EventHubI
 
 	baseURL := storageEndpoint.ResolveReference(path)
 
-	get, err := downloadBlob(ctx, baseURL, blobCreds)
+	var etag azblob.ETag
+	cached, found := checkpointCache.Load(baseURL.String())
+	if found {
+		etag = cached.(cachedCheckpoint).etag
+	}
+
+	get, err := downloadBlob(ctx, baseURL, blobCreds, etag)
+	if err != nil {
+		if found && isBlobNotModified(err) {
+			return cached.(cachedCheckpoint).checkpoint, nil
+		}
+		return Checkpoint{}, err
+	}
+
+	checkpoint, err := checkpointer.extractCheckpoint(get)
 	if err != nil {
 		return Checkpoint{}, err
 	}
 
-	return checkpointer.extractCheckpoint(get)
+	if get.ETag() != azblob.ETagNone {
+		checkpointCache.Store(baseURL.String(), cachedCheckpoint{etag: get.ETag(), checkpoint: checkpoint})
+	}
+	return checkpoint, nil
+}
+
+// isBlobNotModified returns true if the blob download failed because the blob matches the given ETag
+func isBlobNotModified(err error) bool {
+	var stErr azblob.StorageError
+	if errors.As(err, &stErr) && stErr.Response() != nil {
+		return stErr.Response().StatusCode == http.StatusNotModified
+	}
+	return false
 }
 
 func getCheckpointFromStorageMetadata(get *azblob.DownloadResponse, partitionID string) (Checkpoint, error) {
@@ -294,10 +417,13 @@ func readToCheckpointFromBody(get *azblob.DownloadResponse, checkpoint interface
 	return nil
 }
 
-func downloadBlob(ctx context.Context, baseURL *url.URL, blobCreds azblob.Credential) (*azblob.DownloadResponse, error) {
+func downloadBlob(ctx context.Context, baseURL *url.URL, blobCreds azblob.Credential, ifNoneMatch azblob.ETag) (*azblob.DownloadResponse, error) {
 	blobURL := azblob.NewBlockBlobURL(*baseURL, azblob.NewPipeline(blobCreds, azblob.PipelineOptions{}))
 
-	get, err := blobURL.Download(ctx, 0, 0, azblob.BlobAccessConditions{}, false, azblob.ClientProvidedKeyOptions{})
+	accessConditions := azblob.BlobAccessConditions{
+		ModifiedAccessConditions: azblob.ModifiedAccessConditions{IfNoneMatch: ifNoneMatch},
+	}
+	get, err := blobURL.Download(ctx, 0, 0, accessConditions, false, azblob.ClientProvidedKeyOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("unable to download file from blob storage: %w", err)
 	}
@@ -354,6 +354,34 @@ func TestShouldParseCheckpointForBlobMetadataWithPodIdentity(t *testing.T) {
 	assert.Equal(t, url.Path, "/containername/eventhubnamespace.servicebus.windows.net/hub-test/$default/checkpoint/0")
 }
 
+func TestShouldParseCheckpointForBlobMetadataLowercase(t *testing.T) {
+	eventHubInfo := EventHubInfo{
+		EventHubConnection:    "Endpoint=sb://EventHubNamespace.servicebus.windows.net/;EntityPath=Hub-Test",
+		EventHubConsumerGroup: "$Default",
+		BlobContainer:         "containername",
+		CheckpointStrategy:    "blobMetadata",
+	}
+
+	cp := newCheckpointer(eventHubInfo, "0")
+	url, _ := cp.resolvePath(eventHubInfo)
+
+	assert.Equal(t, url.Path, "/containername/eventhubnamespace.servicebus.windows.net/hub-test/$default/checkpoint/0")
+}
+
+func TestShouldParseCheckpointForAzeventhubs(t *testing.T) {
+	eventHubInfo := EventHubInfo{
+		EventHubConnection:    "Endpoint=sb://eventhubnamespace.servicebus.windows.net/;EntityPath=Hub-Test",
+		EventHubConsumerGroup: "$Default",
+		BlobContainer:         "containername",
+		CheckpointStrategy:    "azeventhubs",
+	}
+
+	cp := newCheckpointer(eventHubInfo, "0")
+	url, _ := cp.resolvePath(eventHubInfo)
+
+	assert.Equal(t, url.Path, "/containername/eventhubnamespace.servicebus.windows.net/Hub-Test/$Default/checkpoint/0")
+}
+
 func TestShouldParseCheckpointForGoSdk(t *testing.T) {
 	eventHubInfo := EventHubInfo{
 		EventHubConnection:    "Endpoint=sb://eventhubnamespace.servicebus.windows.net/;EntityPath=hub-test",
@@ -368,6 +396,31 @@ func TestShouldParseCheckpointForGoSdk(t *testing.T) {
 	assert.Equal(t, url.Path, "/containername/0")
 }
 
+func TestShouldParseCheckpointForDapr(t *testing.T) {
+	eventHubInfo := EventHubInfo{
+		EventHubConnection:    "Endpoint=sb://eventhubnamespace.servicebus.windows.net/;EntityPath=hub-test",
+		EventHubConsumerGroup: "$Default",
+		BlobContainer:         "containername",
+		CheckpointStrategy:    "dapr",
+	}
+
+	cp := newCheckpointer(eventHubInfo, "0")
+	url, _ := cp.resolvePath(eventHubInfo)
+
+	assert.Equal(t, url.Path, "/containername/dapr-hub-test-$Default-0")
+}
+
+func TestIsValidCheckpointStrategy(t *testing.T) {
+	for _, strategy := range []string{"", "azureFunction", "blobMetadata", "goSdk", "azeventhubs", "dapr"} {
+		assert.True(t, IsValidCheckpointStrategy(strategy), strategy)
+	}
+	assert.False(t, IsValidCheckpointStrategy("javaSdk"))
+}
+
+func TestCheckpointStrategies(t *testing.T) {
+	assert.Equal(t, []string{"azeventhubs", "azureFunction", "blobMetadata", "dapr", "goSdk"}, CheckpointStrategies())
+}
+
 func createNewCheckpointInStorage(urlPath string, containerName string, partitionID string, checkpoint string, metadata map[string]string) (context.Context, error) {
 	ctx := context.Background()
 
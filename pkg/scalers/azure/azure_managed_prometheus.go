@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// DefaultManagedPrometheusResourceURL is the resource of the Azure Monitor managed Prometheus query endpoints
+const DefaultManagedPrometheusResourceURL = "https://prometheus.monitor.azure.com"
+
+// ManagedPrometheusInfo to authenticate to an Azure Monitor managed Prometheus workspace
+type ManagedPrometheusInfo struct {
+	ResourceURL             string
+	TenantID                string
+	ClientID                string
+	ClientPassword          string
+	ActiveDirectoryEndpoint string
+}
+
+// NewManagedPrometheusAuthorizer returns the authorizer of the queries, which caches and refreshes the token
+func NewManagedPrometheusAuthorizer(ctx context.Context, info ManagedPrometheusInfo, podIdentity kedav1alpha1.AuthPodIdentity) (autorest.Authorizer, error) {
+	var config auth.AuthorizerConfig
+	switch podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		clientCredentialsConfig := auth.NewClientCredentialsConfig(info.ClientID, info.ClientPassword, info.TenantID)
+		clientCredentialsConfig.Resource = info.ResourceURL
+		clientCredentialsConfig.AADEndpoint = info.ActiveDirectoryEndpoint
+		config = clientCredentialsConfig
+	case kedav1alpha1.PodIdentityProviderAzure:
+		msiConfig := auth.NewMSIConfig()
+		msiConfig.Resource = info.ResourceURL
+		msiConfig.ClientID = podIdentity.IdentityID
+		config = msiConfig
+	case kedav1alpha1.PodIdentityProviderAzureWorkload:
+		config = NewAzureADWorkloadIdentityConfig(ctx, podIdentity.IdentityID, info.ResourceURL)
+	default:
+		return nil, fmt.Errorf("azure managed prometheus doesn't support pod identity %s", podIdentity.Provider)
+	}
+
+	return config.Authorizer()
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	az "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// MSGraphInfo to run a Microsoft Graph query
+type MSGraphInfo struct {
+	GraphEndpoint           string
+	APIVersion              string
+	Query                   string
+	TenantID                string
+	ClientID                string
+	ClientPassword          string
+	ActiveDirectoryEndpoint string
+}
+
+// ParseMSGraphEndpoint returns the Microsoft Graph endpoint of the cloud
+func ParseMSGraphEndpoint(metadata map[string]string) (string, error) {
+	return ParseEnvironmentProperty(metadata, "graphEndpoint", func(env az.Environment) (string, error) {
+		return env.MicrosoftGraphEndpoint, nil
+	})
+}
+
+// GetAzureMSGraphQueryResult runs the query and returns the body of the response
+func GetAzureMSGraphQueryResult(ctx context.Context, info MSGraphInfo, podIdentity kedav1alpha1.AuthPodIdentity) ([]byte, error) {
+	config := getMSGraphAuthConfig(ctx, info, podIdentity)
+	if config == nil {
+		return nil, fmt.Errorf("msgraph doesn't support pod identity %s", podIdentity.Provider)
+	}
+	authorizer, err := config.Authorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	return runMSGraphQuery(ctx, info, authorizer)
+}
+
+func getMSGraphAuthConfig(ctx context.Context, info MSGraphInfo, podIdentity kedav1alpha1.AuthPodIdentity) auth.AuthorizerConfig {
+	switch podIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		config := auth.NewClientCredentialsConfig(info.ClientID, info.ClientPassword, info.TenantID)
+		config.Resource = info.GraphEndpoint
+		config.AADEndpoint = info.ActiveDirectoryEndpoint
+		return config
+	case kedav1alpha1.PodIdentityProviderAzure:
+		config := auth.NewMSIConfig()
+		config.Resource = info.GraphEndpoint
+		config.ClientID = podIdentity.IdentityID
+		return config
+	case kedav1alpha1.PodIdentityProviderAzureWorkload:
+		return NewAzureADWorkloadIdentityConfig(ctx, podIdentity.IdentityID, info.GraphEndpoint)
+	}
+	return nil
+}
+
+// msGraphQueryURL returns the URL of the query, which is relative to the API version of the endpoint
+func msGraphQueryURL(info MSGraphInfo) string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(info.GraphEndpoint, "/"), info.APIVersion, strings.TrimPrefix(info.Query, "/"))
+}
+
+func runMSGraphQuery(ctx context.Context, info MSGraphInfo, authorizer autorest.Authorizer) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, msGraphQueryURL(info), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// the advanced queries, like $count on directory objects, require the eventual consistency level
+	req, err = autorest.Prepare(req,
+		autorest.WithHeader("ConsistencyLevel", "eventual"),
+		authorizer.WithAuthorization())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := autorest.Send(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("msgraph query returned %s: %s", resp.Status, body)
+	}
+	return body, nil
+}
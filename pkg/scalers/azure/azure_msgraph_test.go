@@ -0,0 +1,48 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+func TestMSGraphQueryURL(t *testing.T) {
+	info := MSGraphInfo{GraphEndpoint: "https://graph.microsoft.com/", APIVersion: "v1.0", Query: "/users/shared@contoso.com/mailFolders/inbox/messages/$count"}
+	expected := "https://graph.microsoft.com/v1.0/users/shared@contoso.com/mailFolders/inbox/messages/$count"
+	if url := msGraphQueryURL(info); url != expected {
+		t.Errorf("Expected url %s but got %s", expected, url)
+	}
+}
+
+func TestRunMSGraphQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/beta/users/$count" {
+			t.Errorf("Expected request path /beta/users/$count but got %s", r.URL.Path)
+		}
+		if r.Header.Get("ConsistencyLevel") != "eventual" {
+			t.Errorf("Expected ConsistencyLevel header eventual but got %s", r.Header.Get("ConsistencyLevel"))
+		}
+		if r.URL.Query().Get("$filter") == "forbidden" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("42"))
+	}))
+	defer server.Close()
+
+	body, err := runMSGraphQuery(context.Background(), MSGraphInfo{GraphEndpoint: server.URL, APIVersion: "beta", Query: "/users/$count"}, autorest.NullAuthorizer{})
+	if err != nil {
+		t.Fatalf("Expected success but got error: %s", err)
+	}
+	if string(body) != "42" {
+		t.Errorf("Expected body 42 but got %s", body)
+	}
+
+	_, err = runMSGraphQuery(context.Background(), MSGraphInfo{GraphEndpoint: server.URL, APIVersion: "beta", Query: "/users/$count?$filter=forbidden"}, autorest.NullAuthorizer{})
+	if err == nil {
+		t.Error("Expected error for a forbidden query but got success")
+	}
+}
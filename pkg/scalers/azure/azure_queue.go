@@ -18,6 +18,7 @@ package azure
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Azure/azure-storage-queue-go/azqueue"
 
@@ -29,8 +30,29 @@ const (
 	maxPeekMessages int32 = 32
 )
 
+// supported queue length strategies
+const (
+	// QueueLengthStrategyDefault counts visible messages and falls back to the approximate
+	// count, including invisible messages, once more messages than can be peeked are visible
+	QueueLengthStrategyDefault = "default"
+	// QueueLengthStrategyAll always uses the approximate count, including invisible messages
+	QueueLengthStrategyAll = "all"
+	// QueueLengthStrategyVisibleOnly only counts visible messages, up to the peek limit
+	QueueLengthStrategyVisibleOnly = "visibleOnly"
+)
+
+// ValidateQueueLengthStrategy returns an error if the queue length strategy is not supported
+func ValidateQueueLengthStrategy(strategy string) error {
+	switch strategy {
+	case QueueLengthStrategyDefault, QueueLengthStrategyAll, QueueLengthStrategyVisibleOnly:
+		return nil
+	default:
+		return fmt.Errorf("queueLengthStrategy %s must be one of %s, %s, %s", strategy, QueueLengthStrategyDefault, QueueLengthStrategyAll, QueueLengthStrategyVisibleOnly)
+	}
+}
+
 // GetAzureQueueLength returns the length of a queue in int
-func GetAzureQueueLength(ctx context.Context, httpClient util.HTTPDoer, podIdentity kedav1alpha1.AuthPodIdentity, connectionString, queueName, accountName, endpointSuffix string) (int64, error) {
+func GetAzureQueueLength(ctx context.Context, httpClient util.HTTPDoer, podIdentity kedav1alpha1.AuthPodIdentity, connectionString, queueName, accountName, endpointSuffix, strategy string) (int64, error) {
 	credential, endpoint, err := ParseAzureStorageQueueConnection(ctx, httpClient, podIdentity, connectionString, accountName, endpointSuffix)
 	if err != nil {
 		return -1, err
@@ -40,14 +62,16 @@ func GetAzureQueueLength(ctx context.Context, httpClient util.HTTPDoer, podIdent
 	serviceURL := azqueue.NewServiceURL(*endpoint, p)
 	queueURL := serviceURL.NewQueueURL(queueName)
 
-	visibleMessageCount, err := getVisibleCount(ctx, &queueURL, maxPeekMessages)
-	if err != nil {
-		return -1, err
-	}
+	if strategy != QueueLengthStrategyAll {
+		visibleMessageCount, err := getVisibleCount(ctx, &queueURL, maxPeekMessages)
+		if err != nil {
+			return -1, err
+		}
 
-	// Queue has less messages than we allowed to peek for, so no need to get the approximation
-	if visibleMessageCount < int64(maxPeekMessages) {
-		return visibleMessageCount, nil
+		// Queue has less messages than we allowed to peek for, so no need to get the approximation
+		if visibleMessageCount < int64(maxPeekMessages) || strategy == QueueLengthStrategyVisibleOnly {
+			return visibleMessageCount, nil
+		}
 	}
 
 	props, err := queueURL.GetProperties(ctx)
@@ -10,7 +10,7 @@ import (
 )
 
 func TestGetQueueLength(t *testing.T) {
-	length, err := GetAzureQueueLength(context.TODO(), http.DefaultClient, kedav1alpha1.AuthPodIdentity{}, "", "queueName", "", "")
+	length, err := GetAzureQueueLength(context.TODO(), http.DefaultClient, kedav1alpha1.AuthPodIdentity{}, "", "queueName", "", "", QueueLengthStrategyDefault)
 	if length != -1 {
 		t.Error("Expected length to be -1, but got", length)
 	}
@@ -23,7 +23,7 @@ func TestGetQueueLength(t *testing.T) {
 		t.Error("Expected error to contain parsing error message, but got", err.Error())
 	}
 
-	length, err = GetAzureQueueLength(context.TODO(), http.DefaultClient, kedav1alpha1.AuthPodIdentity{}, "DefaultEndpointsProtocol=https;AccountName=name;AccountKey=key==;EndpointSuffix=core.windows.net", "queueName", "", "")
+	length, err = GetAzureQueueLength(context.TODO(), http.DefaultClient, kedav1alpha1.AuthPodIdentity{}, "DefaultEndpointsProtocol=https;AccountName=name;AccountKey=key==;EndpointSuffix=core.windows.net", "queueName", "", "", QueueLengthStrategyDefault)
 
 	if length != -1 {
 		t.Error("Expected length to be -1, but got", length)
@@ -37,3 +37,14 @@ func TestGetQueueLength(t *testing.T) {
 		t.Error("Expected error to contain base64 error message, but got", err.Error())
 	}
 }
+
+func TestValidateQueueLengthStrategy(t *testing.T) {
+	for _, strategy := range []string{QueueLengthStrategyDefault, QueueLengthStrategyAll, QueueLengthStrategyVisibleOnly} {
+		if err := ValidateQueueLengthStrategy(strategy); err != nil {
+			t.Errorf("Expected %s to be valid but got error %s", strategy, err)
+		}
+	}
+	if err := ValidateQueueLengthStrategy("invisibleOnly"); err == nil {
+		t.Error("Expected error for unknown strategy but got nil")
+	}
+}
@@ -0,0 +1,184 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/azure"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	azureBatchMetricType             = "External"
+	defaultAzureBatchTargetTaskCount = 5
+)
+
+type azureBatchScaler struct {
+	metricType  v2beta2.MetricTargetType
+	metadata    *azureBatchMetadata
+	podIdentity kedav1alpha1.AuthPodIdentity
+	logger      logr.Logger
+}
+
+type azureBatchMetadata struct {
+	batchInfo                 azure.BatchInfo
+	includeRunningTasks       bool
+	targetTaskCount           float64
+	activationTargetTaskCount float64
+	scalerIndex               int
+}
+
+// NewAzureBatchScaler creates a new azureBatchScaler
+func NewAzureBatchScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseAzureBatchMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing azure batch metadata: %s", err)
+	}
+
+	return &azureBatchScaler{
+		metricType:  metricType,
+		metadata:    meta,
+		podIdentity: config.PodIdentity,
+		logger:      InitializeLogger(config, "azure_batch_scaler"),
+	}, nil
+}
+
+func parseAzureBatchMetadata(config *ScalerConfig) (*azureBatchMetadata, error) {
+	meta := azureBatchMetadata{}
+	var err error
+
+	meta.batchInfo.AccountURL, err = GetFromAuthOrMeta(config, "accountURL")
+	if err != nil {
+		return nil, err
+	}
+	meta.batchInfo.AccountURL = strings.TrimSuffix(meta.batchInfo.AccountURL, "/")
+
+	meta.batchInfo.JobID = config.TriggerMetadata["jobId"]
+	meta.batchInfo.PoolID = config.TriggerMetadata["poolId"]
+	if (meta.batchInfo.JobID == "") == (meta.batchInfo.PoolID == "") {
+		return nil, errors.New("either jobId or poolId must be provided")
+	}
+
+	if val, ok := config.TriggerMetadata["includeRunningTasks"]; ok && val != "" {
+		meta.includeRunningTasks, err = strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing includeRunningTasks: %s", err)
+		}
+	}
+
+	meta.targetTaskCount = defaultAzureBatchTargetTaskCount
+	if val, ok := config.TriggerMetadata["targetTaskCount"]; ok && val != "" {
+		meta.targetTaskCount, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing targetTaskCount: %s", err)
+		}
+	}
+
+	meta.activationTargetTaskCount = 0
+	if val, ok := config.TriggerMetadata["activationTargetTaskCount"]; ok && val != "" {
+		meta.activationTargetTaskCount, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetTaskCount: %s", err)
+		}
+	}
+
+	meta.batchInfo.BatchResourceURL, err = azure.ParseBatchResourceURL(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.batchInfo.ActiveDirectoryEndpoint, err = azure.ParseActiveDirectoryEndpoint(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	switch config.PodIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		meta.batchInfo.TenantID, err = getParameterFromConfig(config, "tenantId", true)
+		if err != nil {
+			return nil, err
+		}
+		meta.batchInfo.ClientID, meta.batchInfo.ClientPassword, err = parseAzurePodIdentityParams(config)
+		if err != nil {
+			return nil, err
+		}
+	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+		// no params required to be parsed
+	default:
+		return nil, fmt.Errorf("azure batch doesn't support pod identity %s", config.PodIdentity.Provider)
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func (s *azureBatchScaler) IsActive(ctx context.Context) (bool, error) {
+	count, err := s.getTaskCount(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting azure batch task counts")
+		return false, err
+	}
+
+	return float64(count) > s.metadata.activationTargetTaskCount, nil
+}
+
+func (s *azureBatchScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *azureBatchScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	var metricName string
+	if s.metadata.batchInfo.JobID != "" {
+		metricName = fmt.Sprintf("azure-batch-job-%s", s.metadata.batchInfo.JobID)
+	} else {
+		metricName = fmt.Sprintf("azure-batch-pool-%s", s.metadata.batchInfo.PoolID)
+	}
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetTaskCount),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: azureBatchMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *azureBatchScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	count, err := s.getTaskCount(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting azure batch task counts")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// getTaskCount returns the number of queued tasks, including the running ones if includeRunningTasks is set
+func (s *azureBatchScaler) getTaskCount(ctx context.Context) (int64, error) {
+	counts, err := azure.GetAzureBatchTaskCounts(ctx, s.metadata.batchInfo, s.podIdentity)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.metadata.includeRunningTasks {
+		return counts.Active + counts.Running, nil
+	}
+	return counts.Active, nil
+}
@@ -0,0 +1,109 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+type parseAzureBatchMetadataTestData struct {
+	metadata    map[string]string
+	isError     bool
+	resolvedEnv map[string]string
+	authParams  map[string]string
+	podIdentity kedav1alpha1.PodIdentityProvider
+}
+
+type azureBatchMetricIdentifier struct {
+	metadataTestData *parseAzureBatchMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testAzureBatchResolvedEnv = map[string]string{
+	"CLIENT_PASSWORD": "yyy",
+}
+
+var testParseAzureBatchMetadata = []parseAzureBatchMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true, map[string]string{}, map[string]string{}, ""},
+	// properly formed job
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD", "targetTaskCount": "5"}, false, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// properly formed pool
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "poolId": "pool", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD", "targetTaskCount": "5"}, false, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// no optional parameters
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, false, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// fractional targetTaskCount with running tasks
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD", "targetTaskCount": "0.5", "includeRunningTasks": "true"}, false, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// missing accountURL
+	{map[string]string{"jobId": "job", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// missing jobId and poolId
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// both jobId and poolId
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job", "poolId": "pool", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// invalid includeRunningTasks
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD", "includeRunningTasks": "A"}, true, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// invalid targetTaskCount
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD", "targetTaskCount": "A"}, true, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// invalid activationTargetTaskCount
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD", "activationTargetTaskCount": "A"}, true, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// missing tenantId
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// missing activeDirectoryClientId
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job", "tenantId": "123", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// missing activeDirectoryClientPassword
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID"}, true, testAzureBatchResolvedEnv, map[string]string{}, ""},
+	// connection from authParams
+	{map[string]string{"jobId": "job"}, false, map[string]string{}, map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "tenantId": "123", "activeDirectoryClientId": "zzz", "activeDirectoryClientPassword": "password"}, ""},
+	// connection with podIdentity
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job"}, false, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzure},
+	// connection with workload Identity
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job"}, false, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
+	// wrong podIdentity
+	{map[string]string{"accountURL": "https://account.westeurope.batch.azure.com", "jobId": "job"}, true, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProvider("notAzure")},
+	// known azure cloud
+	{map[string]string{"accountURL": "https://account.chinaeast2.batch.chinacloudapi.cn", "jobId": "job", "cloud": "azureChinaCloud"}, false, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzure},
+	// private cloud
+	{map[string]string{"accountURL": "https://account.batch.private", "jobId": "job", "cloud": "private", "batchResourceURL": "https://batch.private/", "activeDirectoryEndpoint": testActiveDirectoryEndpoint}, false, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzure},
+	// private cloud with missing batch resource
+	{map[string]string{"accountURL": "https://account.batch.private", "jobId": "job", "cloud": "private", "activeDirectoryEndpoint": testActiveDirectoryEndpoint}, true, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzure},
+}
+
+var azureBatchMetricIdentifiers = []azureBatchMetricIdentifier{
+	{&testParseAzureBatchMetadata[1], 0, "s0-azure-batch-job-job"},
+	{&testParseAzureBatchMetadata[2], 1, "s1-azure-batch-pool-pool"},
+}
+
+func TestAzureBatchParseMetadata(t *testing.T) {
+	for _, testData := range testParseAzureBatchMetadata {
+		_, err := parseAzureBatchMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ResolvedEnv: testData.resolvedEnv,
+			AuthParams: testData.authParams, PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.podIdentity}})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success. testData: %v", testData)
+		}
+	}
+}
+
+func TestAzureBatchGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range azureBatchMetricIdentifiers {
+		meta, err := parseAzureBatchMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
+			ResolvedEnv: testData.metadataTestData.resolvedEnv, AuthParams: testData.metadataTestData.authParams,
+			PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.metadataTestData.podIdentity}, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockAzureBatchScaler := azureBatchScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockAzureBatchScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
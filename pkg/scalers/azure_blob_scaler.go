@@ -67,7 +67,7 @@ func NewAzureBlobScaler(config *ScalerConfig) (Scaler, error) {
 		metricType:  metricType,
 		metadata:    meta,
 		podIdentity: podIdentity,
-		httpClient:  kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		httpClient:  createHTTPClient(config, false),
 	}, nil
 }
 
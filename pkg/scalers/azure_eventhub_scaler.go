@@ -84,7 +84,7 @@ func NewAzureEventHubScaler(ctx context.Context, config *ScalerConfig) (Scaler,
 		metricType: metricType,
 		metadata:   parsedMetadata,
 		client:     hub,
-		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		httpClient: createHTTPClient(config, false),
 		logger:     InitializeLogger(config, "azure_eventhub_scaler"),
 	}, nil
 }
@@ -132,6 +132,9 @@ func parseAzureEventHubMetadata(config *ScalerConfig) (*eventHubMetadata, error)
 
 	meta.eventHubInfo.CheckpointStrategy = defaultCheckpointStrategy
 	if val, ok := config.TriggerMetadata["checkpointStrategy"]; ok {
+		if !azure.IsValidCheckpointStrategy(val) {
+			return nil, fmt.Errorf("checkpointStrategy %s must be one of %s", val, strings.Join(azure.CheckpointStrategies(), ", "))
+		}
 		meta.eventHubInfo.CheckpointStrategy = val
 	}
 
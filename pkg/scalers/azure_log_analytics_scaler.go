@@ -134,7 +134,7 @@ func NewAzureLogAnalyticsScaler(config *ScalerConfig) (Scaler, error) {
 		cache:      &sessionCache{metricValue: -1, metricThreshold: -1},
 		name:       config.ScalableObjectName,
 		namespace:  config.ScalableObjectNamespace,
-		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		httpClient: createHTTPClient(config, false),
 		logger:     InitializeLogger(config, "azure_log_analytics_scaler"),
 	}, nil
 }
@@ -19,6 +19,10 @@ import (
 
 const (
 	defaultTargetPipelinesQueueLength = 1
+
+	// azurePipelinesAgentVersionDemand is checked by Azure Pipelines against the version of the agents,
+	// which isn't one of the capabilities given in the demands
+	azurePipelinesAgentVersionDemand = "Agent.Version"
 )
 
 type azurePipelinesPoolNameResponse struct {
@@ -44,15 +48,16 @@ type azurePipelinesMetadata struct {
 	personalAccessToken                  string
 	parent                               string
 	demands                              string
+	requireAllDemands                    bool
 	poolID                               int
-	targetPipelinesQueueLength           int64
+	targetPipelinesQueueLength           float64
 	activationTargetPipelinesQueueLength int64
 	scalerIndex                          int
 }
 
 // NewAzurePipelinesScaler creates a new AzurePipelinesScaler
 func NewAzurePipelinesScaler(ctx context.Context, config *ScalerConfig) (Scaler, error) {
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient := createHTTPClient(config, false)
 
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
@@ -77,7 +82,7 @@ func parseAzurePipelinesMetadata(ctx context.Context, config *ScalerConfig, http
 	meta.targetPipelinesQueueLength = defaultTargetPipelinesQueueLength
 
 	if val, ok := config.TriggerMetadata["targetPipelinesQueueLength"]; ok {
-		queueLength, err := strconv.ParseInt(val, 10, 64)
+		queueLength, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing azure pipelines metadata targetPipelinesQueueLength: %s", err.Error())
 		}
@@ -131,6 +136,14 @@ func parseAzurePipelinesMetadata(ctx context.Context, config *ScalerConfig, http
 		meta.demands = ""
 	}
 
+	if val, ok := config.TriggerMetadata["requireAllDemands"]; ok && val != "" {
+		requireAllDemands, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing azure pipelines metadata requireAllDemands: %s", err.Error())
+		}
+		meta.requireAllDemands = requireAllDemands
+	}
+
 	if val, ok := config.TriggerMetadata["poolName"]; ok && val != "" {
 		var err error
 		meta.poolID, err = getPoolIDFromName(ctx, val, &meta, httpClient)
@@ -281,23 +294,54 @@ func (s *azurePipelinesScaler) GetAzurePipelinesQueueLength(ctx context.Context)
 	return count, err
 }
 
-// Determine if the scaledjob has the right demands to spin up
+// Determine if the scaledjob has the right demands to spin up. The demands metadata lists the capabilities of
+// the agents as name, name=value or "name -equals value", a job is fulfilled when each of its demands is: a name or "name -exists" demand
+// needs the capability, a "name -equals value" demand needs the capability with that value. With requireAllDemands
+// the job has to demand all the capabilities as well, so the jobs of generic agents don't scale specialized ones.
 func getCanAgentDemandFulfilJob(v map[string]interface{}, metadata *azurePipelinesMetadata) bool {
-	var demandsReq = v["demands"].([]interface{})
-	var demandsAvail = strings.Split(metadata.demands, ",")
-	var countDemands = 0
+	capabilities := map[string]string{}
+	for _, capability := range strings.Split(metadata.demands, ",") {
+		capability = strings.TrimSpace(capability)
+		if capability == "" {
+			continue
+		}
+		name, value := capability, ""
+		if fields := strings.Fields(capability); len(fields) >= 3 && strings.EqualFold(fields[1], "-equals") {
+			// the demands of the jobs can be copied as they are
+			name, value = fields[0], strings.Join(fields[2:], " ")
+		} else if i := strings.Index(capability, "="); i >= 0 {
+			name, value = strings.TrimSpace(capability[:i]), strings.TrimSpace(capability[i+1:])
+		}
+		capabilities[strings.ToLower(name)] = value
+	}
+
+	demandsReq, _ := v["demands"].([]interface{})
+	demanded := map[string]bool{}
 	for _, dr := range demandsReq {
-		for _, da := range demandsAvail {
-			strDr := fmt.Sprintf("%v", dr)
-			if !strings.HasPrefix(strDr, "Agent.Version") {
-				if strDr == da {
-					countDemands++
-				}
-			}
+		demand, ok := dr.(string)
+		if !ok {
+			return false
+		}
+		fields := strings.Fields(demand)
+		if len(fields) == 0 || strings.EqualFold(fields[0], azurePipelinesAgentVersionDemand) {
+			continue
+		}
+
+		name := strings.ToLower(fields[0])
+		value, ok := capabilities[name]
+		if !ok {
+			return false
+		}
+		if len(fields) >= 3 && strings.EqualFold(fields[1], "-equals") && !strings.EqualFold(value, strings.Join(fields[2:], " ")) {
+			return false
 		}
+		demanded[name] = true
 	}
 
-	return countDemands == len(demandsReq)-1
+	if metadata.requireAllDemands {
+		return len(demanded) == len(capabilities)
+	}
+	return true
 }
 
 // Determine if the Job and Parent Agent Template have matching capabilities
@@ -309,8 +353,11 @@ func getCanAgentParentFulfilJob(v map[string]interface{}, metadata *azurePipelin
 	}
 
 	for _, m := range matchedAgents {
-		n := m.(map[string]interface{})
-		if metadata.parent == n["name"].(string) {
+		n, ok := m.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := n["name"].(string); ok && metadata.parent == name {
 			return true
 		}
 	}
@@ -322,7 +369,7 @@ func (s *azurePipelinesScaler) GetMetricSpecForScaling(context.Context) []v2beta
 		Metric: v2beta2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("azure-pipelines-%d", s.metadata.poolID))),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.targetPipelinesQueueLength),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetPipelinesQueueLength),
 	}
 	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta2.MetricSpec{metricSpec}
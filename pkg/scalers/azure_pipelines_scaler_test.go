@@ -38,6 +38,10 @@ var testAzurePipelinesMetadata = []parseAzurePipelinesMetadataTestData{
 	{"missing poolID", map[string]string{"organizationURLFromEnv": "AZP_URL", "personalAccessTokenFromEnv": "AZP_TOKEN", "poolID": "", "targetPipelinesQueueLength": "1"}, true, testAzurePipelinesResolvedEnv, map[string]string{}},
 	// activationTargetPipelinesQueueLength malformed
 	{"all properly formed", map[string]string{"organizationURLFromEnv": "AZP_URL", "personalAccessTokenFromEnv": "AZP_TOKEN", "poolID": "1", "targetPipelinesQueueLength": "1", "activationTargetPipelinesQueueLength": "A"}, true, testAzurePipelinesResolvedEnv, map[string]string{}},
+	// demands with requireAllDemands
+	{"demands with requireAllDemands", map[string]string{"organizationURLFromEnv": "AZP_URL", "personalAccessTokenFromEnv": "AZP_TOKEN", "poolID": "1", "demands": "maven,java=11", "requireAllDemands": "true"}, false, testAzurePipelinesResolvedEnv, map[string]string{}},
+	// requireAllDemands malformed
+	{"requireAllDemands malformed", map[string]string{"organizationURLFromEnv": "AZP_URL", "personalAccessTokenFromEnv": "AZP_TOKEN", "poolID": "1", "demands": "maven", "requireAllDemands": "yes please"}, true, testAzurePipelinesResolvedEnv, map[string]string{}},
 }
 
 func TestParseAzurePipelinesMetadata(t *testing.T) {
@@ -274,3 +278,39 @@ func TestAzurePipelinesNonMatchedDemandAgent(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestAzurePipelinesGetCanAgentDemandFulfilJob(t *testing.T) {
+	tests := []struct {
+		name              string
+		demands           string
+		requireAllDemands bool
+		jobDemands        []interface{}
+		expected          bool
+	}{
+		{"no job demands", "maven", false, nil, true},
+		{"only the agent version", "maven", false, []interface{}{"Agent.Version -gtVersion 2.144.0"}, true},
+		{"all demands fulfilled", "maven,java=11", false, []interface{}{"Agent.Version -gtVersion 2.144.0", "maven", "java -equals 11"}, true},
+		{"exists demand", "maven", false, []interface{}{"maven -exists"}, true},
+		{"capability names are case insensitive", "Maven", false, []interface{}{"maven"}, true},
+		{"demand copied from the job", "java -equals 11", false, []interface{}{"java -equals 11"}, true},
+		{"missing capability", "maven", false, []interface{}{"maven", "docker"}, false},
+		{"wrong capability value", "java=8", false, []interface{}{"java -equals 11"}, false},
+		{"capability without value", "java", false, []interface{}{"java -equals 11"}, false},
+		{"requireAllDemands with all demands", "maven,java=11", true, []interface{}{"maven", "java -equals 11"}, true},
+		{"requireAllDemands with some demands", "maven,java=11", true, []interface{}{"maven"}, false},
+		{"requireAllDemands without demands", "maven", true, nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			meta := &azurePipelinesMetadata{demands: test.demands, requireAllDemands: test.requireAllDemands}
+			job := map[string]interface{}{}
+			if test.jobDemands != nil {
+				job["demands"] = test.jobDemands
+			}
+			if result := getCanAgentDemandFulfilJob(job, meta); result != test.expected {
+				t.Errorf("expected %v but got %v", test.expected, result)
+			}
+		})
+	}
+}
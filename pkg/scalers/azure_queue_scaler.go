@@ -44,16 +44,19 @@ type azureQueueScaler struct {
 	metadata    *azureQueueMetadata
 	podIdentity kedav1alpha1.AuthPodIdentity
 	httpClient  *http.Client
+	smoothing   *kedautil.EWMA
+	tte         *kedautil.TimeToEmpty
 	logger      logr.Logger
 }
 
 type azureQueueMetadata struct {
-	targetQueueLength           int64
+	targetQueueLength           float64
 	activationTargetQueueLength int64
 	queueName                   string
 	connection                  string
 	accountName                 string
 	endpointSuffix              string
+	queueLengthStrategy         string
 	scalerIndex                 int
 }
 
@@ -71,11 +74,23 @@ func NewAzureQueueScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing azure queue metadata: %s", err)
 	}
 
+	smoothing, err := GetSmoothingEWMA(config)
+	if err != nil {
+		return nil, err
+	}
+
+	tte, err := GetTimeToEmpty(config)
+	if err != nil {
+		return nil, err
+	}
+
 	return &azureQueueScaler{
 		metricType:  metricType,
 		metadata:    meta,
 		podIdentity: podIdentity,
-		httpClient:  kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		httpClient:  createHTTPClient(config, false),
+		smoothing:   smoothing,
+		tte:         tte,
 		logger:      logger,
 	}, nil
 }
@@ -85,7 +100,7 @@ func parseAzureQueueMetadata(config *ScalerConfig, logger logr.Logger) (*azureQu
 	meta.targetQueueLength = defaultTargetQueueLength
 
 	if val, ok := config.TriggerMetadata[queueLengthMetricName]; ok {
-		queueLength, err := strconv.ParseInt(val, 10, 64)
+		queueLength, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			logger.Error(err, "Error parsing azure queue metadata", "queueLengthMetricName", queueLengthMetricName)
 			return nil, kedav1alpha1.AuthPodIdentity{},
@@ -114,6 +129,14 @@ func parseAzureQueueMetadata(config *ScalerConfig, logger logr.Logger) (*azureQu
 
 	meta.endpointSuffix = endpointSuffix
 
+	meta.queueLengthStrategy = azure.QueueLengthStrategyDefault
+	if val, ok := config.TriggerMetadata["queueLengthStrategy"]; ok && val != "" {
+		if err := azure.ValidateQueueLengthStrategy(val); err != nil {
+			return nil, kedav1alpha1.AuthPodIdentity{}, err
+		}
+		meta.queueLengthStrategy = val
+	}
+
 	if val, ok := config.TriggerMetadata["queueName"]; ok && val != "" {
 		meta.queueName = val
 	} else {
@@ -169,6 +192,7 @@ func (s *azureQueueScaler) IsActive(ctx context.Context) (bool, error) {
 		s.metadata.queueName,
 		s.metadata.accountName,
 		s.metadata.endpointSuffix,
+		s.metadata.queueLengthStrategy,
 	)
 
 	if err != nil {
@@ -176,6 +200,7 @@ func (s *azureQueueScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
+	// smoothingWindow doesn't apply to the activation, see GetSmoothingEWMA
 	return length > s.metadata.activationTargetQueueLength, nil
 }
 
@@ -188,7 +213,7 @@ func (s *azureQueueScaler) GetMetricSpecForScaling(context.Context) []v2beta2.Me
 		Metric: v2beta2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("azure-queue-%s", s.metadata.queueName))),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.targetQueueLength),
+		Target: timeToEmptyMetricTarget(s.tte, s.metricType, s.metadata.targetQueueLength),
 	}
 	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta2.MetricSpec{metricSpec}
@@ -196,6 +221,26 @@ func (s *azureQueueScaler) GetMetricSpecForScaling(context.Context) []v2beta2.Me
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *azureQueueScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	metrics, _, err := s.GetMetricsAndActivity(ctx, metricName, metricSelector)
+	return metrics, err
+}
+
+// GetMetricsAndActivity returns the metric and whether the scaler is active from a single read of the queue length
+func (s *azureQueueScaler) GetMetricsAndActivity(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, bool, error) {
+	queuelen, err := s.getRawMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting queue length")
+		return []external_metrics.ExternalMetricValue{}, false, err
+	}
+
+	metric := GenerateMetricInMili(metricName, s.metricValue(queuelen))
+
+	// smoothingWindow doesn't apply to the activation, see GetSmoothingEWMA
+	return append([]external_metrics.ExternalMetricValue{}, metric), queuelen > float64(s.metadata.activationTargetQueueLength), nil
+}
+
+// getRawMetricValue returns the queue length, before smoothing and time to empty
+func (s *azureQueueScaler) getRawMetricValue(ctx context.Context) (float64, error) {
 	queuelen, err := azure.GetAzureQueueLength(
 		ctx,
 		s.httpClient,
@@ -204,14 +249,13 @@ func (s *azureQueueScaler) GetMetrics(ctx context.Context, metricName string, me
 		s.metadata.queueName,
 		s.metadata.accountName,
 		s.metadata.endpointSuffix,
+		s.metadata.queueLengthStrategy,
 	)
 
-	if err != nil {
-		s.logger.Error(err, "error getting queue length")
-		return []external_metrics.ExternalMetricValue{}, err
-	}
-
-	metric := GenerateMetricInMili(metricName, float64(queuelen))
+	return float64(queuelen), err
+}
 
-	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+// metricValue smooths the queue length and converts it to the time to empty the queue, if they are enabled
+func (s *azureQueueScaler) metricValue(rawValue float64) float64 {
+	return timeToEmptyMetricValue(s.tte, smoothMetricValue(s.smoothing, rawValue))
 }
@@ -95,6 +95,12 @@ var testAzQueueMetadata = []parseAzQueueMetadataTestData{
 	{map[string]string{"accountName": "sample_acc", "queueName": "sample_queue", "cloud": "", "endpointSuffix": "ignored"}, false, testAzQueueResolvedEnv, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
 	// connection from authParams
 	{map[string]string{"queueName": "sample", "queueLength": "5"}, false, testAzQueueResolvedEnv, map[string]string{"connection": "value"}, kedav1alpha1.PodIdentityProviderNone},
+	// only visible messages
+	{map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "queueLengthStrategy": "visibleOnly"}, false, testAzQueueResolvedEnv, map[string]string{}, ""},
+	// approximate count including invisible messages
+	{map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "queueLengthStrategy": "all"}, false, testAzQueueResolvedEnv, map[string]string{}, ""},
+	// invalid queueLengthStrategy
+	{map[string]string{"connectionFromEnv": "CONNECTION", "queueName": "sample", "queueLengthStrategy": "invisible"}, true, testAzQueueResolvedEnv, map[string]string{}, ""},
 }
 
 var azQueueMetricIdentifiers = []azQueueMetricIdentifier{
@@ -18,6 +18,7 @@ limitations under the License.
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -44,10 +45,27 @@ const (
 	messageCountMetricName                      = "messageCount"
 	activationMessageCountMetricName            = "activationMessageCount"
 	defaultTargetMessageCount                   = 5
+	sessionCountMetricName                      = "sessionCount"
+	activationSessionCountMetricName            = "activationSessionCount"
+	defaultTargetSessionCount                   = 1
+	defaultMaxPeekMessageCount                  = 1000
+	serviceBusPeekPageSize                      = 100
 	// Service bus resource id is "https://servicebus.azure.net/" in all cloud environments
 	serviceBusResource = "https://servicebus.azure.net/"
 )
 
+type serviceBusScalingMode string
+
+const (
+	serviceBusScalingModeMessageCount serviceBusScalingMode = "messageCount"
+	serviceBusScalingModeSessionCount serviceBusScalingMode = "sessionCount"
+)
+
+// serviceBusPeeker peeks the messages of a queue or subscription without locking them
+type serviceBusPeeker interface {
+	Peek(ctx context.Context, options ...servicebus.PeekOption) (servicebus.MessageIterator, error)
+}
+
 type azureServiceBusScaler struct {
 	ctx         context.Context
 	metricType  v2beta2.MetricTargetType
@@ -58,8 +76,10 @@ type azureServiceBusScaler struct {
 }
 
 type azureServiceBusMetadata struct {
-	targetLength           int64
+	targetLength           float64
 	activationTargetLength int64
+	scalingMode            serviceBusScalingMode
+	maxPeekMessageCount    int
 	queueName              string
 	topicName              string
 	subscriptionName       string
@@ -89,7 +109,7 @@ func NewAzureServiceBusScaler(ctx context.Context, config *ScalerConfig) (Scaler
 		metricType:  metricType,
 		metadata:    meta,
 		podIdentity: config.PodIdentity,
-		httpClient:  kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		httpClient:  createHTTPClient(config, false),
 		logger:      logger,
 	}, nil
 }
@@ -98,26 +118,23 @@ func NewAzureServiceBusScaler(ctx context.Context, config *ScalerConfig) (Scaler
 func parseAzureServiceBusMetadata(config *ScalerConfig, logger logr.Logger) (*azureServiceBusMetadata, error) {
 	meta := azureServiceBusMetadata{}
 	meta.entityType = none
-	meta.targetLength = defaultTargetMessageCount
 
-	// get target metric value
-	if val, ok := config.TriggerMetadata[messageCountMetricName]; ok {
-		messageCount, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			logger.Error(err, "Error parsing azure queue metadata", "messageCount", messageCountMetricName)
-		} else {
-			meta.targetLength = messageCount
+	meta.scalingMode = serviceBusScalingModeMessageCount
+	if val, ok := config.TriggerMetadata["scalingMode"]; ok && val != "" {
+		switch mode := serviceBusScalingMode(val); mode {
+		case serviceBusScalingModeMessageCount, serviceBusScalingModeSessionCount:
+			meta.scalingMode = mode
+		default:
+			return nil, fmt.Errorf("scalingMode must be one of %s, %s but is %s", serviceBusScalingModeMessageCount, serviceBusScalingModeSessionCount, val)
 		}
 	}
 
-	meta.activationTargetLength = 0
-	if val, ok := config.TriggerMetadata[activationMessageCountMetricName]; ok {
-		activationMessageCount, err := strconv.ParseInt(val, 10, 64)
-		if err != nil {
-			logger.Error(err, "Error parsing azure queue metadata", activationMessageCountMetricName, activationMessageCountMetricName)
-			return nil, fmt.Errorf("error parsing azure queue metadata %s", activationMessageCountMetricName)
+	if meta.scalingMode == serviceBusScalingModeSessionCount {
+		if err := parseAzureServiceBusSessionMetadata(config, &meta); err != nil {
+			return nil, err
 		}
-		meta.activationTargetLength = activationMessageCount
+	} else if err := parseAzureServiceBusMessageMetadata(config, &meta, logger); err != nil {
+		return nil, err
 	}
 
 	// get queue name OR topic and subscription name & set entity type accordingly
@@ -184,6 +201,68 @@ func parseAzureServiceBusMetadata(config *ScalerConfig, logger logr.Logger) (*az
 	return &meta, nil
 }
 
+func parseAzureServiceBusMessageMetadata(config *ScalerConfig, meta *azureServiceBusMetadata, logger logr.Logger) error {
+	meta.targetLength = defaultTargetMessageCount
+
+	// get target metric value
+	if val, ok := config.TriggerMetadata[messageCountMetricName]; ok {
+		messageCount, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			logger.Error(err, "Error parsing azure queue metadata", "messageCount", messageCountMetricName)
+		} else {
+			meta.targetLength = messageCount
+		}
+	}
+
+	meta.activationTargetLength = 0
+	if val, ok := config.TriggerMetadata[activationMessageCountMetricName]; ok {
+		activationMessageCount, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			logger.Error(err, "Error parsing azure queue metadata", activationMessageCountMetricName, activationMessageCountMetricName)
+			return fmt.Errorf("error parsing azure queue metadata %s", activationMessageCountMetricName)
+		}
+		meta.activationTargetLength = activationMessageCount
+	}
+
+	return nil
+}
+
+// parseAzureServiceBusSessionMetadata parses the targets of the sessionCount mode, which scales
+// session-enabled entities on the number of sessions with active messages
+func parseAzureServiceBusSessionMetadata(config *ScalerConfig, meta *azureServiceBusMetadata) error {
+	meta.targetLength = defaultTargetSessionCount
+	if val, ok := config.TriggerMetadata[sessionCountMetricName]; ok {
+		sessionCount, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing azure service bus metadata %s: %s", sessionCountMetricName, err)
+		}
+		meta.targetLength = sessionCount
+	}
+
+	meta.activationTargetLength = 0
+	if val, ok := config.TriggerMetadata[activationSessionCountMetricName]; ok {
+		activationSessionCount, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing azure service bus metadata %s: %s", activationSessionCountMetricName, err)
+		}
+		meta.activationTargetLength = activationSessionCount
+	}
+
+	meta.maxPeekMessageCount = defaultMaxPeekMessageCount
+	if val, ok := config.TriggerMetadata["maxPeekMessageCount"]; ok {
+		maxPeekMessageCount, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("error parsing azure service bus metadata maxPeekMessageCount: %s", err)
+		}
+		if maxPeekMessageCount < 1 {
+			return fmt.Errorf("maxPeekMessageCount must be greater than 0")
+		}
+		meta.maxPeekMessageCount = maxPeekMessageCount
+	}
+
+	return nil
+}
+
 // Returns true if the scaler's queue has messages in it, false otherwise
 func (s *azureServiceBusScaler) IsActive(ctx context.Context) (bool, error) {
 	length, err := s.getAzureServiceBusLength(ctx)
@@ -213,7 +292,7 @@ func (s *azureServiceBusScaler) GetMetricSpecForScaling(context.Context) []v2bet
 		Metric: v2beta2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("azure-servicebus-%s", metricName))),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.targetLength),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetLength),
 	}
 	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta2.MetricSpec{metricSpec}
@@ -261,13 +340,16 @@ func (a azureTokenProvider) GetToken(uri string) (*auth.Token, error) {
 	return auth.NewToken(auth.CBSTokenTypeJWT, token.AccessToken, token.ExpiresOn), nil
 }
 
-// Returns the length of the queue or subscription
+// Returns the length of the queue or subscription, or its number of sessions in the sessionCount mode
 func (s *azureServiceBusScaler) getAzureServiceBusLength(ctx context.Context) (int64, error) {
 	// get namespace
 	namespace, err := s.getServiceBusNamespace(ctx)
 	if err != nil {
 		return -1, err
 	}
+	if s.metadata.scalingMode == serviceBusScalingModeSessionCount {
+		return s.getAzureServiceBusSessionCount(ctx, namespace)
+	}
 	// switch case for queue vs topic here
 	switch s.metadata.entityType {
 	case queue:
@@ -335,3 +417,56 @@ func getSubscriptionEntityFromNamespace(ctx context.Context, ns *servicebus.Name
 
 	return int64(*subscriptionEntity.CountDetails.ActiveMessageCount), nil
 }
+
+// getAzureServiceBusSessionCount returns the number of sessions of the queue or subscription
+func (s *azureServiceBusScaler) getAzureServiceBusSessionCount(ctx context.Context, ns *servicebus.Namespace) (int64, error) {
+	switch s.metadata.entityType {
+	case queue:
+		q, err := ns.NewQueue(s.metadata.queueName)
+		if err != nil {
+			return -1, err
+		}
+		defer q.Close(ctx)
+		return getSessionCount(ctx, q, s.metadata.maxPeekMessageCount)
+	case subscription:
+		topic, err := ns.NewTopic(s.metadata.topicName)
+		if err != nil {
+			return -1, err
+		}
+		defer topic.Close(ctx)
+		sub, err := topic.NewSubscription(s.metadata.subscriptionName)
+		if err != nil {
+			return -1, err
+		}
+		defer sub.Close(ctx)
+		return getSessionCount(ctx, sub, s.metadata.maxPeekMessageCount)
+	default:
+		return -1, fmt.Errorf("no entity type")
+	}
+}
+
+// getSessionCount returns the number of distinct sessions of the active messages, as the messages of a
+// session are processed in order the sessions are the work which can be done in parallel. At most
+// maxPeekMessageCount messages are peeked, messages without a session are ignored
+func getSessionCount(ctx context.Context, peeker serviceBusPeeker, maxPeekMessageCount int) (int64, error) {
+	iterator, err := peeker.Peek(ctx, servicebus.PeekWithPageSize(serviceBusPeekPageSize))
+	if err != nil {
+		return -1, err
+	}
+
+	sessions := map[string]bool{}
+	for peeked := 0; peeked < maxPeekMessageCount && !iterator.Done(); peeked++ {
+		message, err := iterator.Next(ctx)
+		if err != nil {
+			if errors.As(err, &servicebus.ErrNoMessages{}) {
+				break
+			}
+			return -1, err
+		}
+		if message.SessionID != nil {
+			sessions[*message.SessionID] = true
+		}
+	}
+
+	return int64(len(sessions)), nil
+}
@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	servicebus "github.com/Azure/azure-service-bus-go"
 	"github.com/go-logr/logr"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -105,6 +106,18 @@ var parseServiceBusMetadataDataset = []parseServiceBusMetadataTestData{
 	{map[string]string{"queueName": queueName, "namespace": namespaceName}, false, queue, defaultSuffix, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
 	// invalid activation message count
 	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "messageCount": messageCount, "activationMessageCount": "AA"}, true, queue, defaultSuffix, map[string]string{}, ""},
+	// session count mode
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "scalingMode": "sessionCount", "sessionCount": "2", "activationSessionCount": "1", "maxPeekMessageCount": "500"}, false, queue, defaultSuffix, map[string]string{}, ""},
+	// session count mode with topic & subscription
+	{map[string]string{"topicName": topicName, "subscriptionName": subscriptionName, "connectionFromEnv": connectionSetting, "scalingMode": "sessionCount"}, false, subscription, defaultSuffix, map[string]string{}, ""},
+	// invalid scaling mode
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "scalingMode": "partitions"}, true, none, "", map[string]string{}, ""},
+	// invalid session count
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "scalingMode": "sessionCount", "sessionCount": "AA"}, true, none, "", map[string]string{}, ""},
+	// invalid activation session count
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "scalingMode": "sessionCount", "activationSessionCount": "AA"}, true, none, "", map[string]string{}, ""},
+	// max peek message count less than 1
+	{map[string]string{"queueName": queueName, "connectionFromEnv": connectionSetting, "scalingMode": "sessionCount", "maxPeekMessageCount": "0"}, true, none, "", map[string]string{}, ""},
 }
 
 var azServiceBusMetricIdentifiers = []azServiceBusMetricIdentifier{
@@ -230,3 +243,51 @@ func TestAzServiceBusGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+type fakeServiceBusPeeker struct {
+	sessionIDs []*string
+}
+
+func (p fakeServiceBusPeeker) Peek(context.Context, ...servicebus.PeekOption) (servicebus.MessageIterator, error) {
+	return &fakeServiceBusMessageIterator{sessionIDs: p.sessionIDs}, nil
+}
+
+type fakeServiceBusMessageIterator struct {
+	sessionIDs []*string
+}
+
+func (it *fakeServiceBusMessageIterator) Done() bool {
+	return len(it.sessionIDs) == 0
+}
+
+func (it *fakeServiceBusMessageIterator) Next(context.Context) (*servicebus.Message, error) {
+	if len(it.sessionIDs) == 0 {
+		return nil, servicebus.ErrNoMessages{}
+	}
+	message := &servicebus.Message{SessionID: it.sessionIDs[0]}
+	it.sessionIDs = it.sessionIDs[1:]
+	return message, nil
+}
+
+func TestGetServiceBusSessionCount(t *testing.T) {
+	session := func(id string) *string { return &id }
+	sessionIDs := []*string{session("a"), session("b"), session("a"), nil, session("c"), session("b")}
+
+	tests := []struct {
+		maxPeekMessageCount int
+		expected            int64
+	}{
+		{1000, 3},
+		{3, 2},
+		{1, 1},
+	}
+	for _, test := range tests {
+		count, err := getSessionCount(context.TODO(), fakeServiceBusPeeker{sessionIDs: sessionIDs}, test.maxPeekMessageCount)
+		if err != nil {
+			t.Errorf("Expected success but got error: %s", err)
+		}
+		if count != test.expected {
+			t.Errorf("Expected %d sessions peeking %d messages, got %d", test.expected, test.maxPeekMessageCount, count)
+		}
+	}
+}
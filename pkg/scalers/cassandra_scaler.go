@@ -33,10 +33,14 @@ type CassandraMetadata struct {
 	protocolVersion            int
 	keyspace                   string
 	query                      string
-	targetQueryValue           int64
+	targetQueryValue           float64
 	activationTargetQueryValue int64
 	metricName                 string
 	scalerIndex                int
+
+	// TLS
+	unsafeSsl bool
+	tlsAuthParams
 }
 
 // NewCassandraScaler creates a new Cassandra scaler.
@@ -77,7 +81,7 @@ func parseCassandraMetadata(config *ScalerConfig) (*CassandraMetadata, error) {
 	}
 
 	if val, ok := config.TriggerMetadata["targetQueryValue"]; ok {
-		targetQueryValue, err := strconv.ParseInt(val, 10, 64)
+		targetQueryValue, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("targetQueryValue parsing error %s", err.Error())
 		}
@@ -133,7 +137,11 @@ func parseCassandraMetadata(config *ScalerConfig) (*CassandraMetadata, error) {
 	}
 
 	if val, ok := config.TriggerMetadata["consistency"]; ok {
-		meta.consistency = gocql.ParseConsistency(val)
+		consistency, err := gocql.ParseConsistencyWrapper(val)
+		if err != nil {
+			return nil, fmt.Errorf("consistency parsing error %s", err.Error())
+		}
+		meta.consistency = consistency
 	} else {
 		meta.consistency = gocql.One
 	}
@@ -156,6 +164,18 @@ func parseCassandraMetadata(config *ScalerConfig) (*CassandraMetadata, error) {
 		return nil, fmt.Errorf("no password given")
 	}
 
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.unsafeSsl = unsafeSsl
+
+	tlsParams, err := parseTLSAuthParams(config)
+	if err != nil {
+		return nil, err
+	}
+	meta.tlsAuthParams = tlsParams
+
 	meta.scalerIndex = config.ScalerIndex
 
 	return &meta, nil
@@ -171,6 +191,18 @@ func newCassandraSession(meta *CassandraMetadata, logger logr.Logger) (*gocql.Se
 		Password: meta.password,
 	}
 
+	if meta.enableTLS {
+		tlsConfig, err := meta.newTLSConfig(meta.unsafeSsl)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cassandra tls config: %s", err)
+		}
+		// gocql overrides InsecureSkipVerify with the host verification option
+		cluster.SslOpts = &gocql.SslOptions{
+			Config:                 tlsConfig,
+			EnableHostVerification: !meta.unsafeSsl,
+		}
+	}
+
 	session, err := cluster.CreateSession()
 	if err != nil {
 		logger.Error(err, "found error creating session")
@@ -196,7 +228,7 @@ func (s *cassandraScaler) GetMetricSpecForScaling(ctx context.Context) []v2beta2
 		Metric: v2beta2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.targetQueryValue),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetQueryValue),
 	}
 	metricSpec := v2beta2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
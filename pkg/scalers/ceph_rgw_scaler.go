@@ -0,0 +1,261 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/go-logr/logr"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	cephRGWMetricType    = "External"
+	defaultCephRGWRegion = "us-east-1"
+	// cephRGWService is the service the admin ops requests are signed for, RGW authenticates them like S3 requests
+	cephRGWService = "s3"
+)
+
+// cephRGWMeasure is read from the bucket stats of the admin ops API, which doesn't expose the garbage collection queue
+type cephRGWMeasure string
+
+const (
+	// cephRGWMeasureObjectsPerShard scales on the objects per bucket index shard, the backlog of the index resharding
+	cephRGWMeasureObjectsPerShard cephRGWMeasure = "objectsPerShard"
+	// cephRGWMeasureObjects scales on the objects of the bucket
+	cephRGWMeasureObjects cephRGWMeasure = "objects"
+	// cephRGWMeasureMultipartUploads scales on the incomplete multipart uploads of the bucket
+	cephRGWMeasureMultipartUploads cephRGWMeasure = "multipartUploads"
+)
+
+type cephRGWScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *cephRGWMetadata
+	httpClient *http.Client
+	signer     *v4.Signer
+	logger     logr.Logger
+}
+
+type cephRGWMetadata struct {
+	endpoint              string
+	adminPath             string
+	accessKey             string
+	secretKey             string
+	region                string
+	bucketName            string
+	measure               cephRGWMeasure
+	targetValue           float64
+	activationTargetValue float64
+	unsafeSsl             bool
+	scalerIndex           int
+}
+
+type cephRGWUsage struct {
+	NumObjects int64 `json:"num_objects"`
+}
+
+type cephRGWBucketStats struct {
+	NumShards int64                   `json:"num_shards"`
+	Usage     map[string]cephRGWUsage `json:"usage"`
+}
+
+// NewCephRGWScaler creates a new cephRGWScaler
+func NewCephRGWScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseCephRGWMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ceph rgw metadata: %s", err)
+	}
+
+	return &cephRGWScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: createHTTPClient(config, meta.unsafeSsl),
+		signer:     v4.NewSigner(credentials.NewStaticCredentials(meta.accessKey, meta.secretKey, "")),
+		logger:     InitializeLogger(config, "ceph_rgw_scaler"),
+	}, nil
+}
+
+func parseCephRGWMetadata(config *ScalerConfig) (*cephRGWMetadata, error) {
+	meta := cephRGWMetadata{}
+	var err error
+
+	meta.endpoint, err = GetFromAuthOrMeta(config, "endpoint")
+	if err != nil {
+		return nil, err
+	}
+	meta.endpoint = strings.TrimSuffix(meta.endpoint, "/")
+
+	// the admin API is served under rgw_admin_entry, which is admin by default
+	meta.adminPath = "/admin"
+	if val, ok := config.TriggerMetadata["adminPath"]; ok && val != "" {
+		meta.adminPath = "/" + strings.Trim(val, "/")
+	}
+
+	// the keys are only read from the authentication, the user needs the buckets=read admin capability
+	meta.accessKey = config.AuthParams["accessKey"]
+	if meta.accessKey == "" {
+		return nil, fmt.Errorf("no accessKey given")
+	}
+	meta.secretKey = config.AuthParams["secretKey"]
+	if meta.secretKey == "" {
+		return nil, fmt.Errorf("no secretKey given")
+	}
+
+	meta.region = defaultCephRGWRegion
+	if val, ok := config.TriggerMetadata["region"]; ok && val != "" {
+		meta.region = val
+	}
+
+	meta.bucketName = config.TriggerMetadata["bucketName"]
+	if meta.bucketName == "" {
+		return nil, fmt.Errorf("no bucketName given")
+	}
+
+	meta.measure = cephRGWMeasureObjectsPerShard
+	if val, ok := config.TriggerMetadata["measure"]; ok && val != "" {
+		meta.measure = cephRGWMeasure(val)
+	}
+	switch meta.measure {
+	case cephRGWMeasureObjectsPerShard, cephRGWMeasureObjects, cephRGWMeasureMultipartUploads:
+	default:
+		return nil, fmt.Errorf("measure must be one of %s, %s, %s but is %s", cephRGWMeasureObjectsPerShard, cephRGWMeasureObjects, cephRGWMeasureMultipartUploads, meta.measure)
+	}
+
+	val, err := getParameterFromConfig(config, "targetValue", false)
+	if err != nil {
+		return nil, err
+	}
+	meta.targetValue, err = strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing targetValue: %s", err)
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok && val != "" {
+		meta.activationTargetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetValue: %s", err)
+		}
+	}
+
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func (s *cephRGWScaler) getBucketStats(ctx context.Context) (*cephRGWBucketStats, error) {
+	query := url.Values{}
+	query.Set("bucket", s.metadata.bucketName)
+	query.Set("stats", "true")
+	query.Set("format", "json")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s/bucket?%s", s.metadata.endpoint, s.metadata.adminPath, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.signer.Sign(req, nil, cephRGWService, s.metadata.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("error signing ceph rgw request: %s", err)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ceph rgw admin api returned %d: %s", resp.StatusCode, body)
+	}
+
+	var stats cephRGWBucketStats
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return nil, fmt.Errorf("error parsing ceph rgw bucket stats: %s", err)
+	}
+	return &stats, nil
+}
+
+func (s *cephRGWScaler) getMetricValue(ctx context.Context) (float64, error) {
+	stats, err := s.getBucketStats(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	objects := float64(stats.Usage["rgw.main"].NumObjects)
+	switch s.metadata.measure {
+	case cephRGWMeasureObjects:
+		return objects, nil
+	case cephRGWMeasureMultipartUploads:
+		return float64(stats.Usage["rgw.multimeta"].NumObjects), nil
+	default:
+		// buckets created without sharding have a single index object
+		shards := float64(stats.NumShards)
+		if shards < 1 {
+			shards = 1
+		}
+		return objects / shards, nil
+	}
+}
+
+func (s *cephRGWScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting ceph rgw bucket stats")
+		return false, err
+	}
+
+	return value > s.metadata.activationTargetValue, nil
+}
+
+func (s *cephRGWScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *cephRGWScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	metricName := fmt.Sprintf("ceph-rgw-%s-%s", s.metadata.measure, s.metadata.bucketName)
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: cephRGWMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *cephRGWScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting ceph rgw bucket stats")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
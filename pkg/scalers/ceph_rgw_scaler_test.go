@@ -0,0 +1,140 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+type parseCephRGWMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type cephRGWMetricIdentifier struct {
+	metadataTestData *parseCephRGWMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testCephRGWAuthParams = map[string]string{"accessKey": "admin", "secretKey": "secret"}
+
+var testCephRGWMetadata = []parseCephRGWMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed objects per shard
+	{map[string]string{"endpoint": "https://rgw:7480", "bucketName": "uploads", "targetValue": "100000"}, testCephRGWAuthParams, false},
+	// properly formed multipart uploads with custom admin path and region
+	{map[string]string{"endpoint": "https://rgw:7480/", "adminPath": "/rgw-admin/", "region": "eu", "bucketName": "uploads", "measure": "multipartUploads", "targetValue": "10", "activationTargetValue": "1"}, testCephRGWAuthParams, false},
+	// endpoint from authParams
+	{map[string]string{"bucketName": "uploads", "measure": "objects", "targetValue": "100"}, map[string]string{"endpoint": "https://rgw:7480", "accessKey": "admin", "secretKey": "secret"}, false},
+	// missing endpoint
+	{map[string]string{"bucketName": "uploads", "targetValue": "100"}, testCephRGWAuthParams, true},
+	// missing accessKey
+	{map[string]string{"endpoint": "https://rgw:7480", "bucketName": "uploads", "targetValue": "100"}, map[string]string{"secretKey": "secret"}, true},
+	// missing secretKey
+	{map[string]string{"endpoint": "https://rgw:7480", "bucketName": "uploads", "targetValue": "100", "secretKey": "secret"}, map[string]string{"accessKey": "admin"}, true},
+	// missing bucketName
+	{map[string]string{"endpoint": "https://rgw:7480", "targetValue": "100"}, testCephRGWAuthParams, true},
+	// invalid measure
+	{map[string]string{"endpoint": "https://rgw:7480", "bucketName": "uploads", "measure": "gc", "targetValue": "100"}, testCephRGWAuthParams, true},
+	// missing targetValue
+	{map[string]string{"endpoint": "https://rgw:7480", "bucketName": "uploads"}, testCephRGWAuthParams, true},
+	// invalid targetValue
+	{map[string]string{"endpoint": "https://rgw:7480", "bucketName": "uploads", "targetValue": "A"}, testCephRGWAuthParams, true},
+	// invalid activationTargetValue
+	{map[string]string{"endpoint": "https://rgw:7480", "bucketName": "uploads", "targetValue": "100", "activationTargetValue": "A"}, testCephRGWAuthParams, true},
+	// invalid unsafeSsl
+	{map[string]string{"endpoint": "https://rgw:7480", "bucketName": "uploads", "targetValue": "100", "unsafeSsl": "A"}, testCephRGWAuthParams, true},
+}
+
+var cephRGWMetricIdentifiers = []cephRGWMetricIdentifier{
+	{&testCephRGWMetadata[1], 0, "s0-ceph-rgw-objectsPerShard-uploads"},
+	{&testCephRGWMetadata[2], 1, "s1-ceph-rgw-multipartUploads-uploads"},
+}
+
+const testCephRGWBucketStats = `{"bucket":"uploads","num_shards":%s,"tenant":"","id":"1","marker":"1","owner":"admin",
+"usage":{"rgw.main":{"size":4096,"size_actual":8192,"num_objects":300000},"rgw.multimeta":{"size":0,"size_actual":0,"num_objects":4}}}`
+
+func TestCephRGWParseMetadata(t *testing.T) {
+	for _, testData := range testCephRGWMetadata {
+		_, err := parseCephRGWMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success. testData: %v", testData)
+		}
+	}
+}
+
+func TestCephRGWGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range cephRGWMetricIdentifiers {
+		meta, err := parseCephRGWMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
+			AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockCephRGWScaler := cephRGWScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockCephRGWScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestCephRGWGetMetricValue(t *testing.T) {
+	testCases := []struct {
+		measure       string
+		numShards     string
+		expectedValue float64
+	}{
+		{"objectsPerShard", "3", 100000},
+		{"objectsPerShard", "0", 300000},
+		{"objects", "3", 300000},
+		{"multipartUploads", "3", 4},
+	}
+
+	for _, testCase := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if request.URL.Path != "/admin/bucket" || request.URL.Query().Get("bucket") != "uploads" || request.URL.Query().Get("stats") != "true" ||
+				!strings.Contains(request.Header.Get("Authorization"), "Credential=admin/") || request.Header.Get("X-Amz-Content-Sha256") == "" {
+				writer.WriteHeader(http.StatusForbidden)
+				return
+			}
+			if _, err := writer.Write([]byte(fmt.Sprintf(testCephRGWBucketStats, testCase.numShards))); err != nil {
+				t.Fatal(err)
+			}
+		}))
+
+		meta, err := parseCephRGWMetadata(&ScalerConfig{
+			TriggerMetadata: map[string]string{"endpoint": server.URL, "bucketName": "uploads", "measure": testCase.measure, "targetValue": "10"},
+			AuthParams:      testCephRGWAuthParams,
+		})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := cephRGWScaler{
+			metadata:   meta,
+			httpClient: http.DefaultClient,
+			signer:     v4.NewSigner(credentials.NewStaticCredentials(meta.accessKey, meta.secretKey, "")),
+			logger:     logr.Discard(),
+		}
+
+		value, err := scaler.getMetricValue(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedValue, value, testCase.measure)
+		server.Close()
+	}
+}
@@ -0,0 +1,289 @@
+package scalers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	couchDBMetricType = "External"
+	// couchDBDefaultFindLimit is used when the Mango query doesn't set a limit, as CouchDB returns
+	// only 25 documents by default
+	couchDBDefaultFindLimit = 10000
+)
+
+type couchDBScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *couchDBMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type couchDBMetadata struct {
+	host                 string
+	dbName               string
+	query                map[string]interface{}
+	designDoc            string
+	view                 string
+	queryValue           float64
+	activationQueryValue int64
+	metricName           string
+	scalerIndex          int
+
+	// Auth
+	username string
+	password string
+
+	// TLS
+	unsafeSsl bool
+	tlsAuthParams
+}
+
+type couchDBFindResponse struct {
+	Docs []json.RawMessage `json:"docs"`
+}
+
+type couchDBViewResponse struct {
+	Rows []struct {
+		Value interface{} `json:"value"`
+	} `json:"rows"`
+}
+
+// NewCouchDBScaler creates a new couchDBScaler
+func NewCouchDBScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseCouchDBMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing couchdb metadata: %s", err)
+	}
+
+	httpClient, err := createTLSHTTPClient(config, meta.unsafeSsl, meta.tlsAuthParams)
+	if err != nil {
+		return nil, fmt.Errorf("error creating couchdb tls config: %s", err)
+	}
+
+	return &couchDBScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "couchdb_scaler"),
+	}, nil
+}
+
+func parseCouchDBMetadata(config *ScalerConfig) (*couchDBMetadata, error) {
+	meta := couchDBMetadata{}
+	var err error
+
+	meta.host, err = GetFromAuthOrMeta(config, "host")
+	if err != nil {
+		return nil, err
+	}
+	meta.host = strings.TrimSuffix(meta.host, "/")
+
+	meta.dbName, err = GetFromAuthOrMeta(config, "dbName")
+	if err != nil {
+		return nil, err
+	}
+
+	meta.designDoc = config.TriggerMetadata["designDoc"]
+	meta.view = config.TriggerMetadata["view"]
+	query := config.TriggerMetadata["query"]
+	switch {
+	case query != "" && (meta.designDoc != "" || meta.view != ""):
+		return nil, errors.New("query can't be used together with designDoc and view")
+	case query != "":
+		if err := json.Unmarshal([]byte(query), &meta.query); err != nil {
+			return nil, fmt.Errorf("error parsing query: %s", err)
+		}
+		if _, ok := meta.query["selector"]; !ok {
+			return nil, errors.New("query must contain a selector")
+		}
+		if _, ok := meta.query["fields"]; !ok {
+			meta.query["fields"] = []string{"_id"}
+		}
+		if _, ok := meta.query["limit"]; !ok {
+			meta.query["limit"] = couchDBDefaultFindLimit
+		}
+	case meta.designDoc == "" && meta.view == "":
+		return nil, errors.New("either query or designDoc and view must be given")
+	case meta.designDoc == "" || meta.view == "":
+		return nil, errors.New("designDoc and view must be given together")
+	}
+
+	if val, ok := config.TriggerMetadata["queryValue"]; ok {
+		queryValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing queryValue: %s", err)
+		}
+		meta.queryValue = queryValue
+	} else {
+		return nil, errors.New("no queryValue given")
+	}
+
+	meta.activationQueryValue = 0
+	if val, ok := config.TriggerMetadata["activationQueryValue"]; ok {
+		activationQueryValue, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationQueryValue: %s", err)
+		}
+		meta.activationQueryValue = activationQueryValue
+	}
+
+	meta.username = config.AuthParams["username"]
+	if config.AuthParams["password"] != "" {
+		meta.password = config.AuthParams["password"]
+	} else if config.TriggerMetadata["passwordFromEnv"] != "" {
+		meta.password = config.ResolvedEnv[config.TriggerMetadata["passwordFromEnv"]]
+	}
+	if meta.password != "" && meta.username == "" {
+		return nil, errors.New("password must be provided with username")
+	}
+
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsParams, err := parseTLSAuthParams(config)
+	if err != nil {
+		return nil, err
+	}
+	meta.tlsAuthParams = tlsParams
+
+	if val, ok := config.TriggerMetadata["metricName"]; ok {
+		meta.metricName = kedautil.NormalizeString(fmt.Sprintf("couchdb-%s", val))
+	} else {
+		meta.metricName = kedautil.NormalizeString(fmt.Sprintf("couchdb-%s", meta.dbName))
+	}
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func (s *couchDBScaler) IsActive(ctx context.Context) (bool, error) {
+	count, err := s.getQueryResult(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return count > s.metadata.activationQueryValue, nil
+}
+
+func (s *couchDBScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (s *couchDBScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.queryValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: couchDBMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *couchDBScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	count, err := s.getQueryResult(ctx)
+	if err != nil {
+		s.logger.Error(err, "error inspecting couchdb")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(count))
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// getQueryResult returns the number of documents matched by the Mango query, or the reduce value of the view
+func (s *couchDBScaler) getQueryResult(ctx context.Context) (int64, error) {
+	if s.metadata.query != nil {
+		return s.getFindCount(ctx)
+	}
+	return s.getViewValue(ctx)
+}
+
+func (s *couchDBScaler) getFindCount(ctx context.Context) (int64, error) {
+	body, err := json.Marshal(s.metadata.query)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s/_find", s.metadata.host, url.PathEscape(s.metadata.dbName)), bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result couchDBFindResponse
+	if err := s.doRequest(req, &result); err != nil {
+		return 0, err
+	}
+	return int64(len(result.Docs)), nil
+}
+
+func (s *couchDBScaler) getViewValue(ctx context.Context) (int64, error) {
+	viewURL := fmt.Sprintf("%s/%s/_design/%s/_view/%s?reduce=true", s.metadata.host, url.PathEscape(s.metadata.dbName), url.PathEscape(s.metadata.designDoc), url.PathEscape(s.metadata.view))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, viewURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var result couchDBViewResponse
+	if err := s.doRequest(req, &result); err != nil {
+		return 0, err
+	}
+	if len(result.Rows) == 0 {
+		return 0, nil
+	}
+
+	value, ok := result.Rows[0].Value.(float64)
+	if !ok {
+		return 0, fmt.Errorf("view %s/%s must reduce to a number, got %v", s.metadata.designDoc, s.metadata.view, result.Rows[0].Value)
+	}
+	return int64(value), nil
+}
+
+func (s *couchDBScaler) doRequest(req *http.Request, result interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	if s.metadata.username != "" {
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("couchdb returned status code %d for %s", resp.StatusCode, req.URL.Path)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("error decoding couchdb response: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+type parseCouchDBMetadataTestData struct {
+	metadata    map[string]string
+	authParams  map[string]string
+	resolvedEnv map[string]string
+	isError     bool
+}
+
+type couchDBMetricIdentifier struct {
+	metadataTestData *parseCouchDBMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testCouchDBResolvedEnv = map[string]string{
+	"COUCHDB_PASSWORD": "secret",
+}
+
+var testCouchDBMetadata = []parseCouchDBMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, testCouchDBResolvedEnv, true},
+	// properly formed query
+	{map[string]string{"host": "http://couchdb:5984", "dbName": "jobs", "query": `{"selector":{"status":"pending"}}`, "queryValue": "5"}, map[string]string{}, testCouchDBResolvedEnv, false},
+	// properly formed view
+	{map[string]string{"host": "http://couchdb:5984", "dbName": "jobs", "designDoc": "queue", "view": "pending", "queryValue": "5", "metricName": "pending"}, map[string]string{}, testCouchDBResolvedEnv, false},
+	// host from auth params
+	{map[string]string{"dbName": "jobs", "designDoc": "queue", "view": "pending", "queryValue": "5"}, map[string]string{"host": "https://couchdb:6984"}, testCouchDBResolvedEnv, false},
+	// missing dbName
+	{map[string]string{"host": "http://couchdb:5984", "designDoc": "queue", "view": "pending", "queryValue": "5"}, map[string]string{}, testCouchDBResolvedEnv, true},
+	// missing query and view
+	{map[string]string{"host": "http://couchdb:5984", "dbName": "jobs", "queryValue": "5"}, map[string]string{}, testCouchDBResolvedEnv, true},
+	// query and view together
+	{map[string]string{"host": "http://couchdb:5984", "dbName": "jobs", "query": `{"selector":{}}`, "designDoc": "queue", "view": "pending", "queryValue": "5"}, map[string]string{}, testCouchDBResolvedEnv, true},
+	// view without designDoc
+	{map[string]string{"host": "http://couchdb:5984", "dbName": "jobs", "view": "pending", "queryValue": "5"}, map[string]string{}, testCouchDBResolvedEnv, true},
+	// invalid query
+	{map[string]string{"host": "http://couchdb:5984", "dbName": "jobs", "query": `{"selector":`, "queryValue": "5"}, map[string]string{}, testCouchDBResolvedEnv, true},
+	// query without selector
+	{map[string]string{"host": "http://couchdb:5984", "dbName": "jobs", "query": `{"limit":10}`, "queryValue": "5"}, map[string]string{}, testCouchDBResolvedEnv, true},
+	// missing queryValue
+	{map[string]string{"host": "http://couchdb:5984", "dbName": "jobs", "designDoc": "queue", "view": "pending"}, map[string]string{}, testCouchDBResolvedEnv, true},
+	// invalid activationQueryValue
+	{map[string]string{"host": "http://couchdb:5984", "dbName": "jobs", "designDoc": "queue", "view": "pending", "queryValue": "5", "activationQueryValue": "a"}, map[string]string{}, testCouchDBResolvedEnv, true},
+	// basic auth with password from env
+	{map[string]string{"host": "http://couchdb:5984", "dbName": "jobs", "designDoc": "queue", "view": "pending", "queryValue": "5", "passwordFromEnv": "COUCHDB_PASSWORD"}, map[string]string{"username": "admin"}, testCouchDBResolvedEnv, false},
+	// password without username
+	{map[string]string{"host": "http://couchdb:5984", "dbName": "jobs", "designDoc": "queue", "view": "pending", "queryValue": "5"}, map[string]string{"password": "secret"}, testCouchDBResolvedEnv, true},
+	// tls
+	{map[string]string{"host": "https://couchdb:6984", "dbName": "jobs", "designDoc": "queue", "view": "pending", "queryValue": "5"}, map[string]string{"tls": "enable", "ca": "caaa", "cert": "ceert", "key": "keey"}, testCouchDBResolvedEnv, false},
+	// invalid tls
+	{map[string]string{"host": "https://couchdb:6984", "dbName": "jobs", "designDoc": "queue", "view": "pending", "queryValue": "5"}, map[string]string{"tls": "yes"}, testCouchDBResolvedEnv, true},
+}
+
+var couchDBMetricIdentifiers = []couchDBMetricIdentifier{
+	{&testCouchDBMetadata[1], 0, "s0-couchdb-jobs"},
+	{&testCouchDBMetadata[2], 1, "s1-couchdb-pending"},
+}
+
+func TestCouchDBParseMetadata(t *testing.T) {
+	for _, testData := range testCouchDBMetadata {
+		_, err := parseCouchDBMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: testData.resolvedEnv})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestCouchDBGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range couchDBMetricIdentifiers {
+		meta, err := parseCouchDBMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ResolvedEnv: testData.metadataTestData.resolvedEnv, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockCouchDBScaler := couchDBScaler{
+			metadata: meta,
+			logger:   logr.Discard(),
+		}
+
+		metricSpec := mockCouchDBScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestCouchDBGetQueryResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/jobs/_find":
+			var query map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&query); err != nil || query["limit"] == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"docs":[{"_id":"a"},{"_id":"b"},{"_id":"c"}]}`))
+		case "/jobs/_design/queue/_view/pending":
+			_, _ = w.Write([]byte(`{"rows":[{"key":null,"value":7}]}`))
+		case "/jobs/_design/queue/_view/empty":
+			_, _ = w.Write([]byte(`{"rows":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		metadata map[string]string
+		expected int64
+		isError  bool
+	}{
+		{map[string]string{"host": server.URL, "dbName": "jobs", "query": `{"selector":{"status":"pending"}}`, "queryValue": "5"}, 3, false},
+		{map[string]string{"host": server.URL, "dbName": "jobs", "designDoc": "queue", "view": "pending", "queryValue": "5"}, 7, false},
+		{map[string]string{"host": server.URL, "dbName": "jobs", "designDoc": "queue", "view": "empty", "queryValue": "5"}, 0, false},
+		{map[string]string{"host": server.URL, "dbName": "jobs", "designDoc": "queue", "view": "missing", "queryValue": "5"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		meta, err := parseCouchDBMetadata(&ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"username": "admin", "password": "secret"}})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := couchDBScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+		value, err := scaler.getQueryResult(context.Background())
+		if err != nil && !tc.isError {
+			t.Errorf("Expected success for %v but got error %s", tc.metadata, err)
+		}
+		if tc.isError && err == nil {
+			t.Errorf("Expected error for %v but got success", tc.metadata)
+		}
+		if value != tc.expected {
+			t.Errorf("Expected %d for %v but got %d", tc.expected, tc.metadata, value)
+		}
+	}
+}
@@ -62,7 +62,10 @@ func parseResourceMetadata(config *ScalerConfig, logger logr.Logger) (*cpuMemory
 	}
 	switch meta.Type {
 	case v2beta2.AverageValueMetricType:
-		averageValueQuantity := resource.MustParse(value)
+		averageValueQuantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing value: %s", err)
+		}
 		meta.AverageValue = &averageValueQuantity
 	case v2beta2.UtilizationMetricType:
 		valueNum, err := strconv.ParseInt(value, 10, 32)
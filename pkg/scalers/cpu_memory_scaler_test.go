@@ -39,6 +39,7 @@ var testCPUMemoryMetadata = []parseCPUMemoryMetadataTestData{
 	{v2beta2.ValueMetricType, map[string]string{"value": "50"}, true},
 	{"", map[string]string{"type": "AverageValue"}, true},
 	{"", map[string]string{"type": "xxx", "value": "50"}, true},
+	{v2beta2.AverageValueMetricType, map[string]string{"value": "50 Mi"}, true},
 }
 
 func TestCPUMemoryParseMetadata(t *testing.T) {
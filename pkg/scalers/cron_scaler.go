@@ -28,11 +28,19 @@ type cronScaler struct {
 }
 
 type cronMetadata struct {
+	timezone    string
+	location    *time.Location
+	windows     []cronWindow
+	scalerIndex int
+}
+
+// cronWindow scales to desiredReplicas between the start and the end schedules
+type cronWindow struct {
 	start           string
 	end             string
-	timezone        string
+	startSchedule   cron.Schedule
+	endSchedule     cron.Schedule
 	desiredReplicas int64
-	scalerIndex     int
 }
 
 // NewCronScaler creates a new cronScaler
@@ -54,20 +62,6 @@ func NewCronScaler(config *ScalerConfig) (Scaler, error) {
 	}, nil
 }
 
-func getCronTime(location *time.Location, spec string) (int64, error) {
-	c := cron.New(cron.WithLocation(location))
-	_, err := c.AddFunc(spec, func() { _ = fmt.Sprintf("Cron initialized for location %s", location.String()) })
-	if err != nil {
-		return 0, err
-	}
-
-	c.Start()
-	cronTime := c.Entries()[0].Next.Unix()
-	c.Stop()
-
-	return cronTime, nil
-}
-
 func parseCronMetadata(config *ScalerConfig) (*cronMetadata, error) {
 	if len(config.TriggerMetadata) == 0 {
 		return nil, fmt.Errorf("invalid Input Metadata. %s", config.TriggerMetadata)
@@ -79,69 +73,122 @@ func parseCronMetadata(config *ScalerConfig) (*cronMetadata, error) {
 	} else {
 		return nil, fmt.Errorf("no timezone specified. %s", config.TriggerMetadata)
 	}
-	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-	if val, ok := config.TriggerMetadata["start"]; ok && val != "" {
-		_, err := parser.Parse(val)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing start schedule: %s", err)
-		}
-		meta.start = val
-	} else {
-		return nil, fmt.Errorf("no start schedule specified. %s", config.TriggerMetadata)
+	location, err := time.LoadLocation(meta.timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load timezone. Error: %s", err)
 	}
-	if val, ok := config.TriggerMetadata["end"]; ok && val != "" {
-		_, err := parser.Parse(val)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing end schedule: %s", err)
+	meta.location = location
+
+	if val, ok := config.TriggerMetadata["windows"]; ok && val != "" {
+		for _, key := range []string{"start", "end", "desiredReplicas"} {
+			if config.TriggerMetadata[key] != "" {
+				return nil, fmt.Errorf("%s can not be specified with windows", key)
+			}
+		}
+		if meta.windows, err = parseCronWindows(val); err != nil {
+			return nil, err
 		}
-		meta.end = val
 	} else {
-		return nil, fmt.Errorf("no end schedule specified. %s", config.TriggerMetadata)
-	}
-	if meta.start == meta.end {
-		return nil, fmt.Errorf("error parsing schedule. %s: start and end can not have exactly same time input", config.TriggerMetadata)
-	}
-	if val, ok := config.TriggerMetadata["desiredReplicas"]; ok && val != "" {
-		metadataDesiredReplicas, err := strconv.Atoi(val)
+		window, err := parseCronWindow(config.TriggerMetadata["start"], config.TriggerMetadata["end"], config.TriggerMetadata["desiredReplicas"])
 		if err != nil {
-			return nil, fmt.Errorf("error parsing desiredReplicas metadata. %s", config.TriggerMetadata)
+			return nil, fmt.Errorf("%s. %s", err, config.TriggerMetadata)
 		}
-
-		meta.desiredReplicas = int64(metadataDesiredReplicas)
-	} else {
-		return nil, fmt.Errorf("no DesiredReplicas specified. %s", config.TriggerMetadata)
+		meta.windows = []cronWindow{window}
 	}
+
 	meta.scalerIndex = config.ScalerIndex
 	return &meta, nil
 }
 
-// IsActive checks if the startTime or endTime has reached
-func (s *cronScaler) IsActive(ctx context.Context) (bool, error) {
-	location, err := time.LoadLocation(s.metadata.timezone)
-	if err != nil {
-		return false, fmt.Errorf("unable to load timezone. Error: %s", err)
+// parseCronWindows parses one window per line, with its start and end schedules and desired replicas
+// separated by |, like "0 8 * * 1-5 | 0 18 * * 1-5 | 10"
+func parseCronWindows(windows string) ([]cronWindow, error) {
+	var out []cronWindow
+	for i, line := range strings.Split(windows, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, "|")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("error parsing window %d: expected start | end | desiredReplicas but got %s", i+1, line)
+		}
+		window, err := parseCronWindow(strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1]), strings.TrimSpace(fields[2]))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing window %d: %s", i+1, err)
+		}
+		out = append(out, window)
 	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no windows specified")
+	}
+	return out, nil
+}
 
-	nextStartTime, startTimecronErr := getCronTime(location, s.metadata.start)
-	if startTimecronErr != nil {
-		return false, fmt.Errorf("error initializing start cron: %s", startTimecronErr)
+func parseCronWindow(start, end, desiredReplicas string) (cronWindow, error) {
+	window := cronWindow{start: start, end: end}
+	var err error
+
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	if start == "" {
+		return window, fmt.Errorf("no start schedule specified")
+	}
+	if window.startSchedule, err = parser.Parse(start); err != nil {
+		return window, fmt.Errorf("error parsing start schedule: %s", err)
+	}
+	if end == "" {
+		return window, fmt.Errorf("no end schedule specified")
+	}
+	if window.endSchedule, err = parser.Parse(end); err != nil {
+		return window, fmt.Errorf("error parsing end schedule: %s", err)
+	}
+	if start == end {
+		return window, fmt.Errorf("error parsing schedule: start and end can not have exactly same time input")
 	}
 
-	nextEndTime, endTimecronErr := getCronTime(location, s.metadata.end)
-	if endTimecronErr != nil {
-		return false, fmt.Errorf("error intializing end cron: %s", endTimecronErr)
+	if desiredReplicas == "" {
+		return window, fmt.Errorf("no DesiredReplicas specified")
 	}
+	metadataDesiredReplicas, err := strconv.Atoi(desiredReplicas)
+	if err != nil {
+		return window, fmt.Errorf("error parsing desiredReplicas metadata")
+	}
+	window.desiredReplicas = int64(metadataDesiredReplicas)
 
-	// Since we are considering the timestamp here and not the exact time, timezone does matter.
-	currentTime := time.Now().Unix()
-	switch {
-	case nextStartTime < nextEndTime && currentTime < nextStartTime:
-		return false, nil
-	case currentTime <= nextEndTime:
-		return true, nil
-	default:
-		return false, nil
+	return window, nil
+}
+
+// cronWallClock returns the wall clock time of the location as UTC, so the schedules are evaluated
+// on the wall clock: during DST transitions the times in the skipped hour have passed once
+// the clock jumped, instead of being skipped until the next day
+func cronWallClock(now time.Time, location *time.Location) time.Time {
+	t := now.In(location)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// isActive checks if the window started, which is when its end comes before its next start
+func (w cronWindow) isActive(wallClock time.Time) bool {
+	return !w.startSchedule.Next(wallClock).Before(w.endSchedule.Next(wallClock))
+}
+
+// getDesiredReplicas returns the highest desired replicas of the active windows
+func (s *cronScaler) getDesiredReplicas(now time.Time) (int64, bool) {
+	wallClock := cronWallClock(now, s.metadata.location)
+
+	var desiredReplicas int64
+	active := false
+	for _, window := range s.metadata.windows {
+		if window.isActive(wallClock) && (!active || window.desiredReplicas > desiredReplicas) {
+			desiredReplicas = window.desiredReplicas
+			active = true
+		}
 	}
+	return desiredReplicas, active
+}
+
+// IsActive checks if one of the windows is active
+func (s *cronScaler) IsActive(ctx context.Context) (bool, error) {
+	_, active := s.getDesiredReplicas(time.Now())
+	return active, nil
 }
 
 func (s *cronScaler) Close(context.Context) error {
@@ -161,7 +208,7 @@ func (s *cronScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSp
 	var specReplicas int64 = 1
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("cron-%s-%s-%s", s.metadata.timezone, parseCronTimeFormat(s.metadata.start), parseCronTimeFormat(s.metadata.end)))),
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("cron-%s-%s-%s", s.metadata.timezone, parseCronTimeFormat(s.metadata.windows[0].start), parseCronTimeFormat(s.metadata.windows[0].end)))),
 		},
 		Target: GetMetricTarget(s.metricType, specReplicas),
 	}
@@ -172,13 +219,8 @@ func (s *cronScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSp
 // GetMetrics finds the current value of the metric
 func (s *cronScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
 	var currentReplicas = int64(defaultDesiredReplicas)
-	isActive, err := s.IsActive(ctx)
-	if err != nil {
-		s.logger.Error(err, "error")
-		return []external_metrics.ExternalMetricValue{}, err
-	}
-	if isActive {
-		currentReplicas = s.metadata.desiredReplicas
+	if desiredReplicas, active := s.getDesiredReplicas(time.Now()); active {
+		currentReplicas = desiredReplicas
 	}
 
 	/*******************************************************************************/
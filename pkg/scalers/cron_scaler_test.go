@@ -36,6 +36,15 @@ var validCronMetadata2 = map[string]string{
 	"desiredReplicas": "10",
 }
 
+// A valid metadata example with a business hours window and an overlapping lunch time window
+var validCronWindowsMetadata = map[string]string{
+	"timezone": "America/New_York",
+	"windows": `
+0 9 * * * | 0 17 * * * | 5
+0 12 * * * | 0 14 * * * | 10
+`,
+}
+
 var testCronMetadata = []parseCronMetadataTestData{
 	{map[string]string{}, true},
 	{validCronMetadata, false},
@@ -48,11 +57,20 @@ var testCronMetadata = []parseCronMetadataTestData{
 	{map[string]string{"timezone": "Asia/Kolkata", "start": "30 * * * *", "end": "-50 * * * *", "desiredReplicas": "10"}, true},
 	{map[string]string{"timezone": "Asia/Kolkata", "start": "30 * * * *", "end": "50 * * -3 *", "desiredReplicas": "10"}, true},
 	{map[string]string{"timezone": "Asia/Kolkata", "start": "30 * * * *", "end": "30 * * * *", "desiredReplicas": "10"}, true},
+	{map[string]string{"timezone": "Mars/Olympus_Mons", "start": "30 * * * *", "end": "45 * * * *", "desiredReplicas": "10"}, true},
+	{validCronWindowsMetadata, false},
+	{map[string]string{"timezone": "Europe/Berlin", "windows": "0 8 * * 1-5 | 0 18 * * 1-5 | 10", "desiredReplicas": "10"}, true},
+	{map[string]string{"timezone": "Europe/Berlin", "windows": "0 8 * * 1-5 | 0 18 * * 1-5"}, true},
+	{map[string]string{"timezone": "Europe/Berlin", "windows": "0 8 * * 1-5 | 0 18 * * 1-5 | ten"}, true},
+	{map[string]string{"timezone": "Europe/Berlin", "windows": "0 8 * * 1-5 | 0 8 * * 1-5 | 10"}, true},
+	{map[string]string{"timezone": "Europe/Berlin", "windows": "0 8 * * 1-5 | 0 18 * * 8 | 10"}, true},
+	{map[string]string{"timezone": "Europe/Berlin", "windows": "\n \n"}, true},
 }
 
 var cronMetricIdentifiers = []cronMetricIdentifier{
 	{&testCronMetadata[1], 0, "s0-cron-Etc-UTC-00xxThu-5923xxThu"},
 	{&testCronMetadata[2], 1, "s1-cron-Etc-UTC-0xSl2xxx-01-23Sl2xxx"},
+	{&testCronMetadata[12], 2, "s2-cron-America-New_York-09xxx-017xxx"},
 }
 
 var tz, _ = time.LoadLocation(validCronMetadata2["timezone"])
@@ -128,3 +146,53 @@ func TestCronGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestCronWindowsDesiredReplicas(t *testing.T) {
+	meta, err := parseCronMetadata(&ScalerConfig{TriggerMetadata: validCronWindowsMetadata})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := cronScaler{metadata: meta, logger: logr.Discard()}
+
+	testCases := []struct {
+		name            string
+		now             time.Time
+		desiredReplicas int64
+		active          bool
+	}{
+		{"before the windows", time.Date(2022, 6, 1, 12, 59, 0, 0, time.UTC), 0, false},
+		{"business hours", time.Date(2022, 6, 1, 13, 0, 0, 0, time.UTC), 5, true},
+		{"overlapping windows", time.Date(2022, 6, 1, 16, 30, 0, 0, time.UTC), 10, true},
+		{"after the windows", time.Date(2022, 6, 1, 21, 0, 0, 0, time.UTC), 0, false},
+		// the windows follow the wall clock across the DST transitions
+		{"before the windows after spring forward", time.Date(2022, 3, 13, 12, 30, 0, 0, time.UTC), 0, false},
+		{"business hours after spring forward", time.Date(2022, 3, 13, 13, 30, 0, 0, time.UTC), 5, true},
+		{"before the windows after fall back", time.Date(2022, 11, 6, 13, 30, 0, 0, time.UTC), 0, false},
+		{"business hours after fall back", time.Date(2022, 11, 6, 14, 30, 0, 0, time.UTC), 5, true},
+		{"after the windows after fall back", time.Date(2022, 11, 6, 22, 30, 0, 0, time.UTC), 0, false},
+	}
+
+	for _, testCase := range testCases {
+		desiredReplicas, active := scaler.getDesiredReplicas(testCase.now)
+		assert.Equal(t, testCase.desiredReplicas, desiredReplicas, testCase.name)
+		assert.Equal(t, testCase.active, active, testCase.name)
+	}
+}
+
+func TestCronWindowSkippedByDST(t *testing.T) {
+	meta, err := parseCronMetadata(&ScalerConfig{TriggerMetadata: map[string]string{
+		"timezone":        "America/New_York",
+		"start":           "30 2 * * *",
+		"end":             "0 6 * * *",
+		"desiredReplicas": "3",
+	}})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := cronScaler{metadata: meta, logger: logr.Discard()}
+
+	// 02:30 doesn't exist on the day of spring forward, the window starts when the clock jumps to 03:00
+	desiredReplicas, active := scaler.getDesiredReplicas(time.Date(2022, 3, 13, 7, 0, 0, 0, time.UTC))
+	assert.True(t, active)
+	assert.Equal(t, int64(3), desiredReplicas)
+}
@@ -0,0 +1,26 @@
+package scalers
+
+import (
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// CustomCAKey is the parameter of the trigger authentication setting the PEM encoded CA certificates the
+// HTTP clients of the scaler trust, on top of the custom CA certificates of the cluster
+const CustomCAKey = "customCA"
+
+// ResolveCustomCA sets the CA certificates of the config from its auth params, it removes them so they
+// aren't taken for the CA of the scalers supporting TLS client authentication
+func ResolveCustomCA(config *ScalerConfig) error {
+	caCert := config.AuthParams[CustomCAKey]
+	delete(config.AuthParams, CustomCAKey)
+	if caCert == "" {
+		return nil
+	}
+
+	rootCAs, err := kedautil.NewRootCAs(caCert)
+	if err != nil {
+		return err
+	}
+	config.RootCAs, config.CustomCA = rootCAs, caCert
+	return nil
+}
@@ -0,0 +1,140 @@
+package scalers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestResolveCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	testCases := []struct {
+		name       string
+		authParams map[string]string
+		isTrusted  bool
+		isError    bool
+	}{
+		{"no custom CA", map[string]string{"ca": "scaler-ca"}, false, false},
+		{"custom CA", map[string]string{"ca": "scaler-ca", CustomCAKey: serverCA}, true, false},
+		{"invalid custom CA", map[string]string{CustomCAKey: "not a certificate"}, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &ScalerConfig{AuthParams: tc.authParams}
+			err := ResolveCustomCA(config)
+			if tc.isError != (err != nil) {
+				t.Fatalf("expected error %v but got %v", tc.isError, err)
+			}
+			if tc.isError {
+				return
+			}
+			if _, ok := config.AuthParams[CustomCAKey]; ok {
+				t.Errorf("expected %s to be removed from the auth params", CustomCAKey)
+			}
+			if config.AuthParams["ca"] != "scaler-ca" {
+				t.Error("expected the CA of the scaler to be kept")
+			}
+
+			resp, err := createHTTPClient(config, false).Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if tc.isTrusted != (err == nil) {
+				t.Errorf("expected the certificate of the server to be trusted %v but got %v", tc.isTrusted, err)
+			}
+		})
+	}
+}
+
+func TestCreateTLSHTTPClientWithCustomCA(t *testing.T) {
+	clientCert, clientKey := newTestClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(clientCert))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	testCases := []struct {
+		name       string
+		authParams map[string]string
+		params     tlsAuthParams
+		unsafeSsl  bool
+		isTrusted  bool
+	}{
+		{"custom CA and client certificate", map[string]string{CustomCAKey: serverCA}, tlsAuthParams{cert: clientCert, key: clientKey}, false, true},
+		{"CA of the scaler and client certificate", map[string]string{}, tlsAuthParams{ca: serverCA, cert: clientCert, key: clientKey}, false, true},
+		{"custom CA, CA of the scaler and client certificate", map[string]string{CustomCAKey: serverCA}, tlsAuthParams{ca: serverCA, cert: clientCert, key: clientKey}, false, true},
+		{"custom CA without client certificate", map[string]string{CustomCAKey: serverCA}, tlsAuthParams{}, false, false},
+		{"unsafeSsl and client certificate", map[string]string{}, tlsAuthParams{cert: clientCert, key: clientKey}, true, true},
+		{"client certificate only", map[string]string{}, tlsAuthParams{cert: clientCert, key: clientKey}, false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &ScalerConfig{AuthParams: tc.authParams}
+			if err := ResolveCustomCA(config); err != nil {
+				t.Fatal(err)
+			}
+			httpClient, err := createTLSHTTPClient(config, tc.unsafeSsl, tc.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := httpClient.Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if tc.isTrusted != (err == nil) {
+				t.Errorf("expected the request to succeed %v but got %v", tc.isTrusted, err)
+			}
+		})
+	}
+
+	if _, err := createTLSHTTPClient(&ScalerConfig{}, false, tlsAuthParams{ca: "caaa"}); err == nil {
+		t.Error("expected an error for a CA without PEM encoded certificate")
+	}
+}
+
+// newTestClientCertificate returns a self-signed client certificate and its key, PEM encoded
+func newTestClientCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "keda"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}))
+}
@@ -24,10 +24,13 @@ type datadogScaler struct {
 }
 
 type datadogMetadata struct {
-	apiKey               string
-	appKey               string
-	datadogSite          string
-	query                string
+	apiKey      string
+	appKey      string
+	datadogSite string
+	query       string
+	// queries in the query, the formula references their results as a, b, c...
+	queries              []string
+	formula              *kedautil.Formula
 	queryValue           float64
 	activationQueryValue float64
 	vType                v2beta2.MetricTargetType
@@ -63,6 +66,38 @@ func NewDatadogScaler(ctx context.Context, config *ScalerConfig) (Scaler, error)
 	}, nil
 }
 
+// maxDatadogQueries is the number of queries a formula can reference, as a to z
+const maxDatadogQueries = 26
+
+// splitDatadogQueries splits the comma separated queries, ignoring the commas of the scopes and functions
+func splitDatadogQueries(q string) []string {
+	var queries []string
+	depth, start := 0, 0
+	for i, c := range q {
+		switch c {
+		case '{', '(':
+			depth++
+		case '}', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				queries = append(queries, strings.TrimSpace(q[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(queries, strings.TrimSpace(q[start:]))
+}
+
+// datadogFormulaNames returns the names the formula references the results of the queries with
+func datadogFormulaNames(queries int) []string {
+	names := make([]string, queries)
+	for i := range names {
+		names[i] = string(rune('a' + i))
+	}
+	return names
+}
+
 // parseDatadogQuery checks correctness of the user query
 func parseDatadogQuery(q string) (bool, error) {
 	// Wellformed Datadog queries require a filter (between curly brackets)
@@ -91,16 +126,33 @@ func parseDatadogMetadata(config *ScalerConfig, logger logr.Logger) (*datadogMet
 	}
 
 	if val, ok := config.TriggerMetadata["query"]; ok {
-		_, err := parseDatadogQuery(val)
+		queries := splitDatadogQueries(val)
+		if len(queries) > maxDatadogQueries {
+			return nil, fmt.Errorf("at most %d queries can be given", maxDatadogQueries)
+		}
+		for _, query := range queries {
+			_, err := parseDatadogQuery(query)
 
-		if err != nil {
-			return nil, fmt.Errorf("error in query: %s", err.Error())
+			if err != nil {
+				return nil, fmt.Errorf("error in query: %s", err.Error())
+			}
 		}
 		meta.query = val
+		meta.queries = queries
 	} else {
 		return nil, fmt.Errorf("no query given")
 	}
 
+	if val, ok := config.TriggerMetadata["formula"]; ok && val != "" {
+		formula, err := kedautil.ParseFormula(val, datadogFormulaNames(len(meta.queries)))
+		if err != nil {
+			return nil, fmt.Errorf("error in formula: %s", err)
+		}
+		meta.formula = formula
+	} else if len(meta.queries) > 1 {
+		return nil, fmt.Errorf("a formula must be given to combine several queries")
+	}
+
 	if val, ok := config.TriggerMetadata["queryValue"]; ok {
 		queryValue, err := strconv.ParseFloat(val, 64)
 		if err != nil {
@@ -171,7 +223,7 @@ func parseDatadogMetadata(config *ScalerConfig, logger logr.Logger) (*datadogMet
 
 	meta.datadogSite = siteVal
 
-	metricName := meta.query[0:strings.Index(meta.query, "{")]
+	metricName := meta.queries[0][0:strings.Index(meta.queries[0], "{")]
 	meta.metricName = GenerateMetricNameWithIndex(config.ScalerIndex, kedautil.NormalizeString(fmt.Sprintf("datadog-%s", metricName)))
 
 	return &meta, nil
@@ -199,7 +251,7 @@ func newDatadogConnection(ctx context.Context, meta *datadogMetadata, config *Sc
 		})
 
 	configuration := datadog.NewConfiguration()
-	configuration.HTTPClient = kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	configuration.HTTPClient = createHTTPClient(config, false)
 	apiClient := datadog.NewAPIClient(configuration)
 
 	_, _, err := apiClient.AuthenticationApi.Validate(ctx) //nolint:bodyclose
@@ -272,29 +324,78 @@ func (s *datadogScaler) getQueryResult(ctx context.Context) (float64, error) {
 
 	series := resp.GetSeries()
 
-	if len(series) > 1 {
-		return 0, fmt.Errorf("query returned more than 1 series; modify the query to return only 1 series")
+	if s.metadata.formula == nil {
+		if len(series) > 1 {
+			return 0, fmt.Errorf("query returned more than 1 series; modify the query to return only 1 series")
+		}
+		if len(series) == 0 {
+			return s.noDatadogMetrics()
+		}
+		value, ok := lastDatadogPoint(series[0])
+		if !ok {
+			return s.noDatadogMetrics()
+		}
+		return value, nil
 	}
 
-	if len(series) == 0 {
-		if !s.metadata.useFiller {
-			return 0, fmt.Errorf("no Datadog metrics returned for the given time window")
+	values, err := datadogQueryValues(series, len(s.metadata.queries))
+	if err != nil {
+		return 0, err
+	}
+	names := datadogFormulaNames(len(s.metadata.queries))
+	formulaValues := make(map[string]float64, len(names))
+	for i, name := range names {
+		if values[i] == nil {
+			return s.noDatadogMetrics()
 		}
-		return s.metadata.fillValue, nil
+		formulaValues[name] = *values[i]
+	}
+
+	value, err := s.metadata.formula.Evaluate(formulaValues)
+	if err != nil {
+		return 0, fmt.Errorf("error evaluating formula: %s", err)
 	}
+	return value, nil
+}
 
-	points := series[0].GetPointlist()
+// noDatadogMetrics returns the metricUnavailableValue if given, an error otherwise
+func (s *datadogScaler) noDatadogMetrics() (float64, error) {
+	if !s.metadata.useFiller {
+		return 0, fmt.Errorf("no Datadog metrics returned for the given time window")
+	}
+	return s.metadata.fillValue, nil
+}
+
+// lastDatadogPoint returns the value of the last point of the series, false if there is none
+func lastDatadogPoint(series datadog.MetricsQueryMetadata) (float64, bool) {
+	points := series.GetPointlist()
 
 	index := len(points) - 1
-	if len(points) == 0 || len(points[index]) < 2 {
-		if !s.metadata.useFiller {
-			return 0, fmt.Errorf("no Datadog metrics returned for the given time window")
-		}
-		return s.metadata.fillValue, nil
+	if len(points) == 0 || len(points[index]) < 2 || points[index][1] == nil {
+		return 0, false
 	}
+	return *points[index][1], true
+}
 
-	// Return the last point from the series
-	return *points[index][1], nil
+// datadogQueryValues returns the last value of the series of each query, by query index, nil if the
+// query returned no points. Each query must return at most 1 series.
+func datadogQueryValues(series []datadog.MetricsQueryMetadata, queries int) ([]*float64, error) {
+	values := make([]*float64, queries)
+	seen := make([]bool, queries)
+	for _, s := range series {
+		index := int(s.GetQueryIndex())
+		if index < 0 || index >= queries {
+			return nil, fmt.Errorf("unexpected series for query %d", index)
+		}
+		if seen[index] {
+			return nil, fmt.Errorf("query %d returned more than 1 series; modify the query to return only 1 series", index)
+		}
+		seen[index] = true
+		if value, ok := lastDatadogPoint(s); ok {
+			values[index] = &value
+		}
+	}
+	return values, nil
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
@@ -2,8 +2,10 @@ package scalers
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
+	datadog "github.com/DataDog/datadog-api-client-go/api/v1/datadog"
 	"github.com/go-logr/logr"
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 )
@@ -93,6 +95,18 @@ var testDatadogMetadata = []datadogAuthMetadataTestData{
 	{"", map[string]string{"query": "sum:trace.redis.command.hits{env:none,service:redis}.as_count()", "queryValue": "7"}, map[string]string{"apiKey": "apiKey"}, true},
 	// invalid query missing {
 	{"", map[string]string{"query": "sum:trace.redis.command.hits.as_count()", "queryValue": "7"}, map[string]string{}, true},
+	// multiple queries with formula
+	{"", map[string]string{"query": "sum:http.requests{service:myapp,dc:us-west-2}.rollup(max, 2),sum:kubernetes.pods.running{service:myapp}", "formula": "a/b", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, false},
+	// formula on a single query
+	{"", map[string]string{"query": "sum:http.requests{service:myapp}", "formula": "a*60", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, false},
+	// multiple queries without formula
+	{"", map[string]string{"query": "sum:http.requests{service:myapp},sum:kubernetes.pods.running{service:myapp}", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// formula referencing a missing query
+	{"", map[string]string{"query": "sum:http.requests{service:myapp},sum:kubernetes.pods.running{service:myapp}", "formula": "a/c", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// invalid formula
+	{"", map[string]string{"query": "sum:http.requests{service:myapp},sum:kubernetes.pods.running{service:myapp}", "formula": "a/", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
+	// malformed second query
+	{"", map[string]string{"query": "sum:http.requests{service:myapp},sum:kubernetes.pods.running", "formula": "a/b", "queryValue": "7"}, map[string]string{"apiKey": "apiKey", "appKey": "appKey"}, true},
 }
 
 func TestDatadogScalerAuthParams(t *testing.T) {
@@ -131,3 +145,35 @@ func TestDatadogGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitDatadogQueries(t *testing.T) {
+	queries := splitDatadogQueries("top(per_second(sum:http.requests{service:myapp,dc:us-west-2}), 5, 'mean', 'desc'), sum:kubernetes.pods.running{service:myapp}")
+	expected := []string{"top(per_second(sum:http.requests{service:myapp,dc:us-west-2}), 5, 'mean', 'desc')", "sum:kubernetes.pods.running{service:myapp}"}
+	if !reflect.DeepEqual(expected, queries) {
+		t.Errorf("Expected %v but got %v", expected, queries)
+	}
+}
+
+func TestDatadogQueryValues(t *testing.T) {
+	point := func(value float64) [][]*float64 {
+		timestamp := float64(1)
+		return [][]*float64{{&timestamp, &value}}
+	}
+	series := func(index int64, points [][]*float64) datadog.MetricsQueryMetadata {
+		s := datadog.MetricsQueryMetadata{Pointlist: points}
+		s.SetQueryIndex(index)
+		return s
+	}
+
+	values, err := datadogQueryValues([]datadog.MetricsQueryMetadata{series(1, point(4)), series(0, point(10))}, 3)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if values[0] == nil || *values[0] != 10 || values[1] == nil || *values[1] != 4 || values[2] != nil {
+		t.Errorf("Expected the last values of the queries by index but got %v", values)
+	}
+
+	if _, err := datadogQueryValues([]datadog.MetricsQueryMetadata{series(0, point(10)), series(0, point(4))}, 2); err == nil {
+		t.Error("Expected error for a query returning several series but got success")
+	}
+}
@@ -0,0 +1,249 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	defaultDruidLagThreshold            = 10000
+	defaultDruidPendingHandoffThreshold = 10
+	druidMetricType                     = "External"
+)
+
+type druidScalingMode string
+
+const (
+	// druidScalingModeLag scales on the aggregate lag of the streaming ingestion supervisor
+	druidScalingModeLag druidScalingMode = "lag"
+	// druidScalingModePendingHandoff scales on the segments of the datasource which aren't loaded by the historicals yet
+	druidScalingModePendingHandoff druidScalingMode = "pendingHandoff"
+)
+
+type druidScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *druidMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type druidMetadata struct {
+	host                  string
+	scalingMode           druidScalingMode
+	supervisorID          string
+	dataSource            string
+	username              string
+	password              string
+	targetValue           float64
+	activationTargetValue float64
+	unsafeSsl             bool
+	scalerIndex           int
+}
+
+type druidSupervisorStatus struct {
+	Payload struct {
+		AggregateLag  *int64 `json:"aggregateLag"`
+		DetailedState string `json:"detailedState"`
+	} `json:"payload"`
+}
+
+// NewDruidScaler creates a new druidScaler
+func NewDruidScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseDruidMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing druid metadata: %s", err)
+	}
+
+	return &druidScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: createHTTPClient(config, meta.unsafeSsl),
+		logger:     InitializeLogger(config, "druid_scaler"),
+	}, nil
+}
+
+func parseDruidMetadata(config *ScalerConfig) (*druidMetadata, error) {
+	meta := druidMetadata{}
+	var err error
+
+	// the router proxies the requests to the overlord and the coordinator
+	meta.host, err = GetFromAuthOrMeta(config, "host")
+	if err != nil {
+		return nil, err
+	}
+	meta.host = strings.TrimSuffix(meta.host, "/")
+
+	meta.scalingMode = druidScalingModeLag
+	if val, ok := config.TriggerMetadata["scalingMode"]; ok && val != "" {
+		meta.scalingMode = druidScalingMode(val)
+	}
+
+	var defaultTargetValue float64
+	switch meta.scalingMode {
+	case druidScalingModeLag:
+		meta.supervisorID = config.TriggerMetadata["supervisorId"]
+		if meta.supervisorID == "" {
+			return nil, fmt.Errorf("no supervisorId given")
+		}
+		defaultTargetValue = defaultDruidLagThreshold
+	case druidScalingModePendingHandoff:
+		meta.dataSource = config.TriggerMetadata["dataSource"]
+		if meta.dataSource == "" {
+			return nil, fmt.Errorf("no dataSource given")
+		}
+		defaultTargetValue = defaultDruidPendingHandoffThreshold
+	default:
+		return nil, fmt.Errorf("scalingMode must be one of %s, %s but is %s", druidScalingModeLag, druidScalingModePendingHandoff, meta.scalingMode)
+	}
+
+	meta.targetValue = defaultTargetValue
+	if val, ok := config.TriggerMetadata["targetValue"]; ok && val != "" {
+		meta.targetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing targetValue: %s", err)
+		}
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok && val != "" {
+		meta.activationTargetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetValue: %s", err)
+		}
+	}
+
+	// basic auth of the druid-basic-security extension, password is only read from the authentication
+	meta.username, _ = GetFromAuthOrMeta(config, "username")
+	meta.password = config.AuthParams["password"]
+	if meta.password != "" && meta.username == "" {
+		return nil, fmt.Errorf("no username given")
+	}
+
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func (s *druidScaler) getJSON(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.host+path, nil)
+	if err != nil {
+		return err
+	}
+	if s.metadata.username != "" {
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("druid api %s returned %d: %s", path, resp.StatusCode, body)
+	}
+	return json.Unmarshal(body, result)
+}
+
+// getSupervisorLag returns the aggregate lag of the supervisor, which is 0 until the supervisor computed it
+func (s *druidScaler) getSupervisorLag(ctx context.Context) (float64, error) {
+	var status druidSupervisorStatus
+	path := fmt.Sprintf("/druid/indexer/v1/supervisor/%s/status", url.PathEscape(s.metadata.supervisorID))
+	if err := s.getJSON(ctx, path, &status); err != nil {
+		return 0, err
+	}
+
+	if status.Payload.AggregateLag == nil {
+		s.logger.V(1).Info("druid supervisor reported no lag", "supervisorId", s.metadata.supervisorID, "state", status.Payload.DetailedState)
+		return 0, nil
+	}
+	return float64(*status.Payload.AggregateLag), nil
+}
+
+// getPendingHandoff returns the number of segments of the datasource left to be loaded by the historicals
+func (s *druidScaler) getPendingHandoff(ctx context.Context) (float64, error) {
+	var loadStatus map[string]int64
+	path := fmt.Sprintf("/druid/coordinator/v1/datasources/%s/loadstatus?simple", url.PathEscape(s.metadata.dataSource))
+	if err := s.getJSON(ctx, path, &loadStatus); err != nil {
+		return 0, err
+	}
+	return float64(loadStatus[s.metadata.dataSource]), nil
+}
+
+func (s *druidScaler) getMetricValue(ctx context.Context) (float64, error) {
+	if s.metadata.scalingMode == druidScalingModePendingHandoff {
+		return s.getPendingHandoff(ctx)
+	}
+	return s.getSupervisorLag(ctx)
+}
+
+func (s *druidScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting druid metric")
+		return false, err
+	}
+
+	return value > s.metadata.activationTargetValue, nil
+}
+
+func (s *druidScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *druidScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	var metricName string
+	if s.metadata.scalingMode == druidScalingModePendingHandoff {
+		metricName = fmt.Sprintf("druid-pending-handoff-%s", s.metadata.dataSource)
+	} else {
+		metricName = fmt.Sprintf("druid-lag-%s", s.metadata.supervisorID)
+	}
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: druidMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *druidScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting druid metric")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
@@ -0,0 +1,139 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+type parseDruidMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type druidMetricIdentifier struct {
+	metadataTestData *parseDruidMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testDruidMetadata = []parseDruidMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed lag
+	{map[string]string{"host": "http://router:8888", "supervisorId": "wikipedia", "targetValue": "1000"}, map[string]string{}, false},
+	// properly formed pending handoff
+	{map[string]string{"host": "http://router:8888", "scalingMode": "pendingHandoff", "dataSource": "wikipedia", "activationTargetValue": "1"}, map[string]string{}, false},
+	// host and basic auth from authParams
+	{map[string]string{"supervisorId": "wikipedia"}, map[string]string{"host": "https://router:9088", "username": "admin", "password": "secret"}, false},
+	// missing host
+	{map[string]string{"supervisorId": "wikipedia"}, map[string]string{}, true},
+	// missing supervisorId
+	{map[string]string{"host": "http://router:8888"}, map[string]string{}, true},
+	// missing dataSource
+	{map[string]string{"host": "http://router:8888", "scalingMode": "pendingHandoff", "supervisorId": "wikipedia"}, map[string]string{}, true},
+	// invalid scalingMode
+	{map[string]string{"host": "http://router:8888", "scalingMode": "segments", "supervisorId": "wikipedia"}, map[string]string{}, true},
+	// invalid targetValue
+	{map[string]string{"host": "http://router:8888", "supervisorId": "wikipedia", "targetValue": "A"}, map[string]string{}, true},
+	// invalid activationTargetValue
+	{map[string]string{"host": "http://router:8888", "supervisorId": "wikipedia", "activationTargetValue": "A"}, map[string]string{}, true},
+	// password without username
+	{map[string]string{"host": "http://router:8888", "supervisorId": "wikipedia"}, map[string]string{"password": "secret"}, true},
+	// invalid unsafeSsl
+	{map[string]string{"host": "http://router:8888", "supervisorId": "wikipedia", "unsafeSsl": "A"}, map[string]string{}, true},
+}
+
+var druidMetricIdentifiers = []druidMetricIdentifier{
+	{&testDruidMetadata[1], 0, "s0-druid-lag-wikipedia"},
+	{&testDruidMetadata[2], 1, "s1-druid-pending-handoff-wikipedia"},
+}
+
+func TestDruidParseMetadata(t *testing.T) {
+	for _, testData := range testDruidMetadata {
+		_, err := parseDruidMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success. testData: %v", testData)
+		}
+	}
+}
+
+func TestDruidGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range druidMetricIdentifiers {
+		meta, err := parseDruidMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
+			AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockDruidScaler := druidScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockDruidScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestDruidGetMetricValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if username, password, _ := request.BasicAuth(); username != "admin" || password != "secret" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var body string
+		switch request.URL.Path {
+		case "/druid/indexer/v1/supervisor/wikipedia/status":
+			body = `{"id":"wikipedia","payload":{"dataSource":"wikipedia","detailedState":"RUNNING","aggregateLag":1500}}`
+		case "/druid/indexer/v1/supervisor/starting/status":
+			body = `{"id":"starting","payload":{"dataSource":"starting","detailedState":"CONNECTING_TO_STREAM"}}`
+		case "/druid/coordinator/v1/datasources/wikipedia/loadstatus":
+			body = `{"wikipedia":12}`
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := writer.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		metadata      map[string]string
+		expectedValue float64
+		isError       bool
+	}{
+		{map[string]string{"supervisorId": "wikipedia"}, 1500, false},
+		{map[string]string{"supervisorId": "starting"}, 0, false},
+		{map[string]string{"scalingMode": "pendingHandoff", "dataSource": "wikipedia"}, 12, false},
+		{map[string]string{"supervisorId": "unknown"}, 0, true},
+	}
+
+	for _, testCase := range testCases {
+		testCase.metadata["host"] = server.URL
+		meta, err := parseDruidMetadata(&ScalerConfig{TriggerMetadata: testCase.metadata,
+			AuthParams: map[string]string{"username": "admin", "password": "secret"}})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := druidScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+		value, err := scaler.getMetricValue(context.Background())
+		if testCase.isError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedValue, value)
+		}
+	}
+}
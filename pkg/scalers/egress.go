@@ -0,0 +1,136 @@
+package scalers
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// EgressProxyKey is the parameter of the trigger authentication, or the metadata of the trigger, setting the
+	// gateway, a SOCKS5 or HTTP CONNECT proxy, the traffic of the scaler goes through instead of the proxies of the
+	// environment, so that it gets the network identity of the tenant or reaches the target system
+	EgressProxyKey = "egressProxy"
+	// EgressUsernameKey and EgressPasswordKey are the credentials of the egress proxy
+	EgressUsernameKey = "egressUsername"
+	EgressPasswordKey = "egressPassword"
+)
+
+// ResolveEgressProxy sets the egress proxy of the config from its auth params, it removes them so they aren't
+// taken for the credentials of the scaler. The proxy of the trigger authentication takes precedence over the
+// one of the trigger metadata, its credentials can only be given by the trigger authentication.
+func ResolveEgressProxy(config *ScalerConfig) error {
+	proxyURL, username, password := config.AuthParams[EgressProxyKey], config.AuthParams[EgressUsernameKey], config.AuthParams[EgressPasswordKey]
+	delete(config.AuthParams, EgressProxyKey)
+	delete(config.AuthParams, EgressUsernameKey)
+	delete(config.AuthParams, EgressPasswordKey)
+	if proxyURL == "" {
+		proxyURL = config.TriggerMetadata[EgressProxyKey]
+	}
+	if proxyURL == "" {
+		return nil
+	}
+
+	var err error
+	config.EgressProxy, err = kedautil.ParseEgressProxy(proxyURL, username, password)
+	return err
+}
+
+// createHTTPClient creates the HTTP client of the scaler with the global timeout, its requests go through
+// the egress proxy and it trusts the CA certificates of the trigger authentication
+func createHTTPClient(config *ScalerConfig, unsafeSsl bool) *http.Client {
+	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, unsafeSsl)
+	kedautil.SetEgressProxy(httpClient.Transport.(*http.Transport), config.EgressProxy)
+	kedautil.SetRootCAs(httpClient.Transport.(*http.Transport), config.RootCAs)
+	return httpClient
+}
+
+// createTLSHTTPClient creates the HTTP client of the scaler like createHTTPClient, the client also presents the
+// client certificate of the TLS auth params and trusts their CA certificate. They are merged into the TLS config
+// of the client, so the custom CA certificates, unsafeSsl and the FIPS settings still apply.
+func createTLSHTTPClient(config *ScalerConfig, unsafeSsl bool, params tlsAuthParams) (*http.Client, error) {
+	httpClient := createHTTPClient(config, unsafeSsl)
+	tlsConfig := httpClient.Transport.(*http.Transport).TLSClientConfig
+	if params.cert != "" && params.key != "" {
+		clientConfig, err := kedautil.NewTLSConfigWithPassword(params.cert, params.key, params.keyPassword, "")
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = clientConfig.Certificates
+	}
+	if params.ca != "" {
+		rootCAs, err := kedautil.NewRootCAs(config.CustomCA, params.ca)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	return httpClient, nil
+}
+
+// tlsAuthParams are the TLS settings of the trigger authentication: tls enables TLS, ca is the CA certificate
+// the server is verified with, cert and key the client certificate and keyPassword decrypts the key
+type tlsAuthParams struct {
+	enableTLS   bool
+	ca          string
+	cert        string
+	key         string
+	keyPassword string
+}
+
+// parseTLSAuthParams parses the TLS settings of the trigger authentication
+func parseTLSAuthParams(config *ScalerConfig) (tlsAuthParams, error) {
+	params := tlsAuthParams{}
+	val, ok := config.AuthParams["tls"]
+	if !ok {
+		return params, nil
+	}
+
+	switch val = strings.TrimSpace(val); val {
+	case "enable":
+		certGiven := config.AuthParams["cert"] != ""
+		keyGiven := config.AuthParams["key"] != ""
+		if certGiven && !keyGiven {
+			return params, errors.New("key must be provided with cert")
+		}
+		if keyGiven && !certGiven {
+			return params, errors.New("cert must be provided with key")
+		}
+		params.ca = config.AuthParams["ca"]
+		params.cert = config.AuthParams["cert"]
+		params.key = config.AuthParams["key"]
+		params.keyPassword = config.AuthParams["keyPassword"]
+		params.enableTLS = true
+	case "disable":
+	default:
+		return params, fmt.Errorf("err incorrect value for TLS given: %s", val)
+	}
+	return params, nil
+}
+
+// newTLSConfig returns the TLS config presenting the client certificate and verifying the server with the CA
+// certificate, unless unsafeSsl is set. Without CA certificate the server is verified with the system CAs.
+func (p tlsAuthParams) newTLSConfig(unsafeSsl bool) (*tls.Config, error) {
+	tlsConfig, err := kedautil.NewTLSConfigWithPassword(p.cert, p.key, p.keyPassword, p.ca)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	tlsConfig.InsecureSkipVerify = unsafeSsl
+	return tlsConfig, nil
+}
+
+// getEgressDialer returns the dialer opening the TCP connections of the scaler through the egress proxy,
+// nil without egress proxy
+func getEgressDialer(config *ScalerConfig) (kedautil.EgressDialer, error) {
+	if config.EgressProxy == nil {
+		return nil, nil
+	}
+	return kedautil.NewEgressDialer(config.EgressProxy)
+}
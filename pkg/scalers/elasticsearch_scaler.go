@@ -21,11 +21,15 @@ import (
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
+// defaultElasticsearchValueLocation is the hit count of the search template
+const defaultElasticsearchValueLocation = "hits.total.value"
+
 type elasticsearchScaler struct {
-	metricType v2beta2.MetricTargetType
-	metadata   *elasticsearchMetadata
-	esClient   *elasticsearch.Client
-	logger     logr.Logger
+	metricType    v2beta2.MetricTargetType
+	metadata      *elasticsearchMetadata
+	esClient      *elasticsearch.Client
+	queryRecorder *QueryRecorder
+	logger        logr.Logger
 }
 
 type elasticsearchMetadata struct {
@@ -40,6 +44,8 @@ type elasticsearchMetadata struct {
 	targetValue           float64
 	activationTargetValue float64
 	metricName            string
+	// query is the Query DSL counted with the _count API instead of running a search template
+	query string
 }
 
 // NewElasticsearchScaler creates a new elasticsearch scaler
@@ -61,15 +67,14 @@ func NewElasticsearchScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error getting elasticsearch client: %s", err)
 	}
 	return &elasticsearchScaler{
-		metricType: metricType,
-		metadata:   meta,
-		esClient:   esClient,
-		logger:     logger,
+		metricType:    metricType,
+		metadata:      meta,
+		esClient:      esClient,
+		queryRecorder: config.QueryRecorder,
+		logger:        logger,
 	}, nil
 }
 
-const defaultUnsafeSsl = false
-
 func parseElasticsearchMetadata(config *ScalerConfig) (*elasticsearchMetadata, error) {
 	meta := elasticsearchMetadata{}
 
@@ -80,13 +85,9 @@ func parseElasticsearchMetadata(config *ScalerConfig) (*elasticsearchMetadata, e
 	}
 	meta.addresses = splitAndTrimBySep(addresses, ",")
 
-	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok {
-		meta.unsafeSsl, err = strconv.ParseBool(val)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing unsafeSsl: %s", err)
-		}
-	} else {
-		meta.unsafeSsl = defaultUnsafeSsl
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
 	}
 
 	if val, ok := config.AuthParams["username"]; ok {
@@ -107,18 +108,35 @@ func parseElasticsearchMetadata(config *ScalerConfig) (*elasticsearchMetadata, e
 	}
 	meta.indexes = splitAndTrimBySep(index, ";")
 
-	meta.searchTemplateName, err = GetFromAuthOrMeta(config, "searchTemplateName")
-	if err != nil {
-		return nil, err
+	meta.searchTemplateName, _ = GetFromAuthOrMeta(config, "searchTemplateName")
+	meta.query = config.TriggerMetadata["query"]
+	switch {
+	case meta.searchTemplateName != "" && meta.query != "":
+		return nil, fmt.Errorf("searchTemplateName and query can't be used together")
+	case meta.searchTemplateName == "" && meta.query == "":
+		return nil, fmt.Errorf("no searchTemplateName or query given")
+	case meta.query != "" && !json.Valid([]byte(meta.query)):
+		return nil, fmt.Errorf("query must be a JSON Query DSL object")
 	}
 
 	if val, ok := config.TriggerMetadata["parameters"]; ok {
+		if meta.query != "" {
+			return nil, fmt.Errorf("parameters can only be used with searchTemplateName")
+		}
 		meta.parameters = splitAndTrimBySep(val, ";")
+		for _, p := range meta.parameters {
+			if p != "" && !strings.Contains(p, ":") {
+				return nil, fmt.Errorf("invalid parameter %s, must be name:value", p)
+			}
+		}
 	}
 
-	meta.valueLocation, err = GetFromAuthOrMeta(config, "valueLocation")
-	if err != nil {
-		return nil, err
+	// the _count API returns the count, the hit count of the search template is used by default
+	if meta.searchTemplateName != "" {
+		meta.valueLocation = defaultElasticsearchValueLocation
+		if val, _ := GetFromAuthOrMeta(config, "valueLocation"); val != "" {
+			meta.valueLocation = val
+		}
 	}
 
 	targetValue, err := GetFromAuthOrMeta(config, "targetValue")
@@ -138,7 +156,11 @@ func parseElasticsearchMetadata(config *ScalerConfig) (*elasticsearchMetadata, e
 		}
 	}
 
-	meta.metricName = GenerateMetricNameWithIndex(config.ScalerIndex, kedautil.NormalizeString(fmt.Sprintf("elasticsearch-%s", meta.searchTemplateName)))
+	if meta.query != "" {
+		meta.metricName = GenerateMetricNameWithIndex(config.ScalerIndex, kedautil.NormalizeString(fmt.Sprintf("elasticsearch-count-%s", strings.Join(meta.indexes, "-"))))
+	} else {
+		meta.metricName = GenerateMetricNameWithIndex(config.ScalerIndex, kedautil.NormalizeString(fmt.Sprintf("elasticsearch-%s", meta.searchTemplateName)))
+	}
 	return &meta, nil
 }
 
@@ -186,6 +208,10 @@ func (s *elasticsearchScaler) IsActive(ctx context.Context) (bool, error) {
 
 // getQueryResult returns result of the scaler query
 func (s *elasticsearchScaler) getQueryResult(ctx context.Context) (float64, error) {
+	if s.metadata.query != "" {
+		return s.getCount(ctx)
+	}
+
 	// Build the request body.
 	var body bytes.Buffer
 	if err := json.NewEncoder(&body).Encode(buildQuery(s.metadata)); err != nil {
@@ -193,6 +219,7 @@ func (s *elasticsearchScaler) getQueryResult(ctx context.Context) (float64, erro
 	}
 
 	// Run the templated search
+	s.queryRecorder.Record(fmt.Sprintf("search template on %s: %s", strings.Join(s.metadata.indexes, ","), strings.TrimSpace(body.String())))
 	res, err := s.esClient.SearchTemplate(
 		&body,
 		s.esClient.SearchTemplate.WithIndex(s.metadata.indexes...),
@@ -215,12 +242,41 @@ func (s *elasticsearchScaler) getQueryResult(ctx context.Context) (float64, erro
 	return v, nil
 }
 
+// getCount returns the number of documents of the indexes matching the query
+func (s *elasticsearchScaler) getCount(ctx context.Context) (float64, error) {
+	body, err := json.Marshal(map[string]json.RawMessage{"query": json.RawMessage(s.metadata.query)})
+	if err != nil {
+		return 0, err
+	}
+
+	s.queryRecorder.Record(fmt.Sprintf("count on %s: %s", strings.Join(s.metadata.indexes, ","), body))
+	res, err := s.esClient.Count(
+		s.esClient.Count.WithIndex(s.metadata.indexes...),
+		s.esClient.Count.WithBody(bytes.NewReader(body)),
+		s.esClient.Count.WithContext(ctx),
+	)
+	if err != nil {
+		s.logger.Error(err, fmt.Sprintf("Could not count elasticsearch documents: %s", err))
+		return 0, err
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, err
+	}
+	if res.IsError() {
+		return 0, fmt.Errorf("error counting elasticsearch documents: %s", b)
+	}
+	return getValueFromSearch(b, "count")
+}
+
 func buildQuery(metadata *elasticsearchMetadata) map[string]interface{} {
 	parameters := map[string]interface{}{}
 	for _, p := range metadata.parameters {
 		if p != "" {
-			kv := splitAndTrimBySep(p, ":")
-			parameters[kv[0]] = kv[1]
+			kv := strings.SplitN(p, ":", 2)
+			parameters[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
 		}
 	}
 	query := map[string]interface{}{
@@ -4,8 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/elastic/go-elasticsearch/v7"
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,23 +50,24 @@ var testCases = []parseElasticsearchMetadataTestData{
 		expectedError: errors.New("no index given"),
 	},
 	{
-		name: "no searchTemplateName given",
+		name: "no searchTemplateName or query given",
 		metadata: map[string]string{
 			"addresses": "http://localhost:9200",
 			"index":     "index1",
 		},
 		authParams:    map[string]string{"username": "admin"},
-		expectedError: errors.New("no searchTemplateName given"),
+		expectedError: errors.New("no searchTemplateName or query given"),
 	},
 	{
-		name: "no valueLocation given",
+		name: "searchTemplateName and query given",
 		metadata: map[string]string{
 			"addresses":          "http://localhost:9200",
 			"index":              "index1",
 			"searchTemplateName": "searchTemplateName",
+			"query":              `{"match_all": {}}`,
 		},
 		authParams:    map[string]string{"username": "admin"},
-		expectedError: errors.New("no valueLocation given"),
+		expectedError: errors.New("searchTemplateName and query can't be used together"),
 	},
 	{
 		name: "no targetValue given",
@@ -278,6 +284,82 @@ var testCases = []parseElasticsearchMetadataTestData{
 		},
 		expectedError: nil,
 	},
+	{
+		name: "valueLocation defaults to the hit count",
+		metadata: map[string]string{
+			"addresses":          "http://localhost:9200",
+			"index":              "index1",
+			"searchTemplateName": "myAwesomeSearch",
+			"parameters":         "since:now-5m;url:http://localhost",
+			"targetValue":        "12",
+		},
+		authParams: map[string]string{"username": "admin"},
+		expectedMetadata: &elasticsearchMetadata{
+			addresses:          []string{"http://localhost:9200"},
+			indexes:            []string{"index1"},
+			username:           "admin",
+			searchTemplateName: "myAwesomeSearch",
+			parameters:         []string{"since:now-5m", "url:http://localhost"},
+			valueLocation:      "hits.total.value",
+			targetValue:        12,
+			metricName:         "s0-elasticsearch-myAwesomeSearch",
+		},
+		expectedError: nil,
+	},
+	{
+		name: "invalid parameter",
+		metadata: map[string]string{
+			"addresses":          "http://localhost:9200",
+			"index":              "index1",
+			"searchTemplateName": "myAwesomeSearch",
+			"parameters":         "param1",
+			"targetValue":        "12",
+		},
+		authParams:    map[string]string{"username": "admin"},
+		expectedError: errors.New("invalid parameter param1, must be name:value"),
+	},
+	{
+		name: "count query",
+		metadata: map[string]string{
+			"addresses":   "http://localhost:9200",
+			"index":       "index1;index2",
+			"query":       `{"term": {"status": "pending"}}`,
+			"targetValue": "100",
+		},
+		authParams: map[string]string{"username": "admin"},
+		expectedMetadata: &elasticsearchMetadata{
+			addresses:   []string{"http://localhost:9200"},
+			indexes:     []string{"index1", "index2"},
+			username:    "admin",
+			query:       `{"term": {"status": "pending"}}`,
+			targetValue: 100,
+			metricName:  "s0-elasticsearch-count-index1-index2",
+		},
+		expectedError: nil,
+	},
+	{
+		name: "invalid count query",
+		metadata: map[string]string{
+			"addresses":   "http://localhost:9200",
+			"index":       "index1",
+			"query":       `{"term": `,
+			"targetValue": "100",
+		},
+		authParams:    map[string]string{"username": "admin"},
+		expectedError: errors.New("query must be a JSON Query DSL object"),
+	},
+	{
+		name: "count query with parameters",
+		metadata: map[string]string{
+			"addresses":   "http://localhost:9200",
+			"index":       "index1",
+			"query":       `{"match_all": {}}`,
+			"parameters":  "param1:value1",
+			"targetValue": "100",
+		},
+		authParams:    map[string]string{"username": "admin"},
+		expectedError: errors.New("parameters can only be used with searchTemplateName"),
+	},
 }
 
 func TestParseElasticsearchMetadata(t *testing.T) {
@@ -399,6 +481,26 @@ func TestBuildQuery(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "param values with colons",
+			metadata: map[string]string{
+				"addresses":          "http://localhost:9200",
+				"index":              "index1",
+				"searchTemplateName": "myAwesomeSearch",
+				"parameters":         "url:http://localhost:8080",
+				"targetValue":        "12",
+			},
+			authParams: map[string]string{
+				"username": "admin",
+				"password": "password",
+			},
+			expectedQuery: map[string]interface{}{
+				"id": "myAwesomeSearch",
+				"params": map[string]interface{}{
+					"url": "http://localhost:8080",
+				},
+			},
+		},
 		{
 			name: "params are trimmed",
 			metadata: map[string]string{
@@ -438,6 +540,7 @@ func TestElasticsearchGetMetricSpecForScaling(t *testing.T) {
 	var elasticsearchMetricIdentifiers = []elasticsearchMetricIdentifier{
 		{&testCases[7], 0, "s0-elasticsearch-myAwesomeSearch"},
 		{&testCases[8], 1, "s1-elasticsearch-myAwesomeSearch"},
+		{&testCases[15], 2, "s2-elasticsearch-count-index1-index2"},
 	}
 
 	for _, testData := range elasticsearchMetricIdentifiers {
@@ -455,3 +558,37 @@ func TestElasticsearchGetMetricSpecForScaling(t *testing.T) {
 		assert.Equal(t, metricSpec[0].External.Metric.Name, testData.name)
 	}
 }
+
+func TestElasticsearchGetCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		// the client checks the product before the first request
+		if r.URL.Path == "/" {
+			_, _ = w.Write([]byte(`{"version": {"number": "7.17.1", "build_flavor": "default"}, "tagline": "You Know, for Search"}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "/index1,index2/_count", r.URL.Path)
+		assert.JSONEq(t, `{"query": {"term": {"status": "pending"}}}`, string(body))
+		_, _ = w.Write([]byte(`{"count": 42, "_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0}}`))
+	}))
+	defer server.Close()
+
+	meta, err := parseElasticsearchMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"addresses":   server.URL,
+			"index":       "index1;index2",
+			"query":       `{"term": {"status": "pending"}}`,
+			"targetValue": "100",
+		},
+	})
+	assert.NoError(t, err)
+	esClient, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: meta.addresses})
+	assert.NoError(t, err)
+
+	scaler := elasticsearchScaler{metadata: meta, esClient: esClient, logger: logr.Discard()}
+	value, err := scaler.getQueryResult(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(42), value)
+}
@@ -0,0 +1,300 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	etcdMetricType         = "External"
+	defaultEtcdDialTimeout = 5 * time.Second
+)
+
+type etcdScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *etcdMetadata
+	client     *clientv3.Client
+	logger     logr.Logger
+}
+
+type etcdPushScaler struct {
+	etcdScaler
+}
+
+type etcdMetadata struct {
+	endpoints       []string
+	key             string
+	prefix          string
+	value           float64
+	activationValue float64
+	enableWatch     bool
+	scalerIndex     int
+
+	// TLS
+	tlsAuthParams
+}
+
+// NewEtcdScaler creates a new etcdScaler, or an etcdPushScaler when watch based activation is enabled
+func NewEtcdScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseEtcdMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing etcd metadata: %s", err)
+	}
+
+	client, err := getEtcdClient(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	scaler := etcdScaler{
+		metricType: metricType,
+		metadata:   meta,
+		client:     client,
+		logger:     InitializeLogger(config, "etcd_scaler"),
+	}
+
+	if meta.enableWatch {
+		return &etcdPushScaler{scaler}, nil
+	}
+	return &scaler, nil
+}
+
+func parseEtcdMetadata(config *ScalerConfig) (*etcdMetadata, error) {
+	meta := etcdMetadata{}
+
+	endpoints, err := GetFromAuthOrMeta(config, "endpoints")
+	if err != nil {
+		return nil, err
+	}
+	for _, endpoint := range strings.Split(endpoints, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			meta.endpoints = append(meta.endpoints, endpoint)
+		}
+	}
+	if len(meta.endpoints) == 0 {
+		return nil, errors.New("no endpoints given")
+	}
+
+	meta.key = config.TriggerMetadata["key"]
+	meta.prefix = config.TriggerMetadata["prefix"]
+	switch {
+	case meta.key == "" && meta.prefix == "":
+		return nil, errors.New("either key or prefix must be given")
+	case meta.key != "" && meta.prefix != "":
+		return nil, errors.New("key and prefix can't be used together")
+	}
+
+	if val, ok := config.TriggerMetadata["value"]; ok {
+		value, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing value: %s", err)
+		}
+		meta.value = value
+	} else {
+		return nil, errors.New("no value given")
+	}
+
+	meta.activationValue = 0
+	if val, ok := config.TriggerMetadata["activationValue"]; ok {
+		activationValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationValue: %s", err)
+		}
+		meta.activationValue = activationValue
+	}
+
+	meta.enableWatch = false
+	if val, ok := config.TriggerMetadata["enableWatch"]; ok {
+		meta.enableWatch, err = strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing enableWatch: %s", err)
+		}
+	}
+
+	tlsParams, err := parseTLSAuthParams(config)
+	if err != nil {
+		return nil, err
+	}
+	meta.tlsAuthParams = tlsParams
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func getEtcdClient(meta *etcdMetadata) (*clientv3.Client, error) {
+	config := clientv3.Config{
+		Endpoints:   meta.endpoints,
+		DialTimeout: defaultEtcdDialTimeout,
+	}
+
+	if meta.enableTLS {
+		tlsConfig, err := kedautil.NewTLSConfigWithPassword(meta.cert, meta.key, meta.keyPassword, meta.ca)
+		if err != nil {
+			return nil, fmt.Errorf("error creating etcd tls config: %s", err)
+		}
+		config.TLS = tlsConfig
+	}
+
+	client, err := clientv3.New(config)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to etcd: %s", err)
+	}
+	return client, nil
+}
+
+func (s *etcdScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return value > s.metadata.activationValue, nil
+}
+
+func (s *etcdScaler) Close(context.Context) error {
+	if s.client != nil {
+		return s.client.Close()
+	}
+	return nil
+}
+
+func (s *etcdScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	name := s.metadata.key
+	if name == "" {
+		name = s.metadata.prefix
+	}
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("etcd-%s", name))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: etcdMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *etcdScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting etcd metric value")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// getMetricValue returns the numeric value stored at the key, or the number of keys under the prefix.
+// A missing key is reported as 0.
+func (s *etcdScaler) getMetricValue(ctx context.Context) (float64, error) {
+	if s.metadata.prefix != "" {
+		resp, err := s.client.Get(ctx, s.metadata.prefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+		if err != nil {
+			return 0, fmt.Errorf("error counting etcd keys with prefix %s: %s", s.metadata.prefix, err)
+		}
+		return float64(resp.Count), nil
+	}
+
+	resp, err := s.client.Get(ctx, s.metadata.key)
+	if err != nil {
+		return 0, fmt.Errorf("error getting etcd key %s: %s", s.metadata.key, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, nil
+	}
+	return parseEtcdValue(resp.Kvs[0].Value)
+}
+
+func parseEtcdValue(value []byte) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(string(value)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing etcd value: %s", err)
+	}
+	return v, nil
+}
+
+// Run watches the key or prefix and reports the activity every time it changes
+func (s *etcdPushScaler) Run(ctx context.Context, active chan<- bool) {
+	defer close(active)
+	// The watch can be canceled by the server anytime, we need to run this in a retry loop
+	runWithLog := func() {
+		if err := s.watch(ctx, active); err != nil {
+			s.logger.Error(err, "error watching etcd")
+		}
+	}
+
+	// retry on error from runWithLog() starting by 2 sec backing off * 2 with a max of 1 minute
+	retryDuration := time.Second * 2
+	retryBackoff := func() *time.Timer {
+		tmr := time.NewTimer(retryDuration)
+		retryDuration *= 2
+		if retryDuration > time.Minute*1 {
+			retryDuration = time.Minute * 1
+		}
+		return tmr
+	}
+
+	// start the first run without delay
+	runWithLog()
+
+	for {
+		backoffTimer := retryBackoff()
+		select {
+		case <-ctx.Done():
+			backoffTimer.Stop()
+			return
+		case <-backoffTimer.C:
+			backoffTimer.Stop()
+			runWithLog()
+		}
+	}
+}
+
+// watch blocks until the watch is canceled or ctx is done
+func (s *etcdPushScaler) watch(ctx context.Context, active chan<- bool) error {
+	watchCtx, cancel := context.WithCancel(clientv3.WithRequireLeader(ctx))
+	defer cancel()
+
+	var watchChan clientv3.WatchChan
+	if s.metadata.prefix != "" {
+		watchChan = s.client.Watch(watchCtx, s.metadata.prefix, clientv3.WithPrefix())
+	} else {
+		watchChan = s.client.Watch(watchCtx, s.metadata.key)
+	}
+
+	for resp := range watchChan {
+		if err := resp.Err(); err != nil {
+			return err
+		}
+
+		isActive, err := s.IsActive(ctx)
+		if err != nil {
+			return err
+		}
+		active <- isActive
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return errors.New("etcd watch channel closed")
+}
@@ -0,0 +1,124 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+type parseEtcdMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type etcdMetricIdentifier struct {
+	metadataTestData *parseEtcdMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testEtcdMetadata = []parseEtcdMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed key
+	{map[string]string{"endpoints": "etcd-0:2379,etcd-1:2379", "key": "/jobs/pending", "value": "10"}, map[string]string{}, false},
+	// properly formed prefix
+	{map[string]string{"endpoints": "etcd-0:2379", "prefix": "/jobs/queue/", "value": "5", "activationValue": "1"}, map[string]string{}, false},
+	// endpoints from auth params
+	{map[string]string{"key": "/jobs/pending", "value": "10"}, map[string]string{"endpoints": "etcd-0:2379"}, false},
+	// empty endpoints
+	{map[string]string{"endpoints": " , ", "key": "/jobs/pending", "value": "10"}, map[string]string{}, true},
+	// missing key and prefix
+	{map[string]string{"endpoints": "etcd-0:2379", "value": "10"}, map[string]string{}, true},
+	// key and prefix together
+	{map[string]string{"endpoints": "etcd-0:2379", "key": "/jobs/pending", "prefix": "/jobs/queue/", "value": "10"}, map[string]string{}, true},
+	// missing value
+	{map[string]string{"endpoints": "etcd-0:2379", "key": "/jobs/pending"}, map[string]string{}, true},
+	// invalid value
+	{map[string]string{"endpoints": "etcd-0:2379", "key": "/jobs/pending", "value": "a"}, map[string]string{}, true},
+	// invalid activationValue
+	{map[string]string{"endpoints": "etcd-0:2379", "key": "/jobs/pending", "value": "10", "activationValue": "a"}, map[string]string{}, true},
+	// watch enabled
+	{map[string]string{"endpoints": "etcd-0:2379", "key": "/jobs/pending", "value": "10", "enableWatch": "true"}, map[string]string{}, false},
+	// invalid enableWatch
+	{map[string]string{"endpoints": "etcd-0:2379", "key": "/jobs/pending", "value": "10", "enableWatch": "sometimes"}, map[string]string{}, true},
+	// mTLS
+	{map[string]string{"endpoints": "etcd-0:2379", "key": "/jobs/pending", "value": "10"}, map[string]string{"tls": "enable", "ca": "caaa", "cert": "ceert", "key": "keey"}, false},
+	// cert without key
+	{map[string]string{"endpoints": "etcd-0:2379", "key": "/jobs/pending", "value": "10"}, map[string]string{"tls": "enable", "cert": "ceert"}, true},
+	// invalid tls value
+	{map[string]string{"endpoints": "etcd-0:2379", "key": "/jobs/pending", "value": "10"}, map[string]string{"tls": "yes"}, true},
+}
+
+var etcdMetricIdentifiers = []etcdMetricIdentifier{
+	{&testEtcdMetadata[1], 0, "s0-etcd--jobs-pending"},
+	{&testEtcdMetadata[2], 1, "s1-etcd--jobs-queue-"},
+}
+
+func TestEtcdParseMetadata(t *testing.T) {
+	for _, testData := range testEtcdMetadata {
+		_, err := parseEtcdMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestEtcdParseEndpoints(t *testing.T) {
+	meta, err := parseEtcdMetadata(&ScalerConfig{TriggerMetadata: testEtcdMetadata[1].metadata})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if len(meta.endpoints) != 2 || meta.endpoints[0] != "etcd-0:2379" || meta.endpoints[1] != "etcd-1:2379" {
+		t.Errorf("Expected endpoints [etcd-0:2379 etcd-1:2379] but got %v", meta.endpoints)
+	}
+}
+
+func TestEtcdGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range etcdMetricIdentifiers {
+		meta, err := parseEtcdMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockEtcdScaler := etcdScaler{
+			metadata: meta,
+			logger:   logr.Discard(),
+		}
+
+		metricSpec := mockEtcdScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestParseEtcdValue(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected float64
+		isError  bool
+	}{
+		{"42", 42, false},
+		{" 1.5\n", 1.5, false},
+		{"", 0, true},
+		{"pending", 0, true},
+	}
+	for _, tc := range testCases {
+		value, err := parseEtcdValue([]byte(tc.value))
+		if err != nil && !tc.isError {
+			t.Errorf("Expected success for %q but got error %s", tc.value, err)
+		}
+		if tc.isError && err == nil {
+			t.Errorf("Expected error for %q but got success", tc.value)
+		}
+		if err == nil && value != tc.expected {
+			t.Errorf("Expected %v for %q but got %v", tc.expected, tc.value, value)
+		}
+	}
+}
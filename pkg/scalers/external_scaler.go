@@ -2,7 +2,12 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,9 +20,11 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
 	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 type externalScaler struct {
@@ -29,13 +36,53 @@ type externalScaler struct {
 
 type externalPushScaler struct {
 	externalScaler
+	streamStateHandler func(PushScalerStreamState)
 }
 
+const (
+	// externalPushMinBackoff and externalPushMaxBackoff bound the delay before opening the stream again,
+	// which doubles with the consecutive failures
+	externalPushMinBackoff = 2 * time.Second
+	externalPushMaxBackoff = time.Minute
+	// externalPushBackoffJitter randomizes the delay, so the triggers of a restarted scaler don't reconnect at once
+	externalPushBackoffJitter = 0.2
+	// externalPushStableStream is how long a stream must stay open without a message to be considered connected,
+	// the external scalers only send a message when the activity changes
+	externalPushStableStream = 10 * time.Second
+)
+
 type externalScalerMetadata struct {
 	scalerAddress    string
 	tlsCertFile      string
 	originalMetadata map[string]string
 	scalerIndex      int
+
+	// TLS and mTLS of the gRPC connection, configured through TriggerAuthentication
+	tlsAuthParams
+	tlsServerName string
+	unsafeSsl     bool
+
+	// static header sent with every gRPC call, configured through TriggerAuthentication
+	authHeaderName  string
+	authHeaderValue string
+
+	// egressProxy is the proxy the gRPC connection goes through instead of the one of the environment
+	egressProxy *url.URL
+}
+
+// externalScalerAuthHeader sends a static header with every gRPC call
+type externalScalerAuthHeader struct {
+	name       string
+	value      string
+	requireTLS bool
+}
+
+func (h externalScalerAuthHeader) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{h.name: h.value}, nil
+}
+
+func (h externalScalerAuthHeader) RequireTransportSecurity() bool {
+	return h.requireTLS
 }
 
 type connectionGroup struct {
@@ -83,7 +130,7 @@ func NewExternalPushScaler(config *ScalerConfig) (PushScaler, error) {
 	}
 
 	return &externalPushScaler{
-		externalScaler{
+		externalScaler: externalScaler{
 			metricType: metricType,
 			metadata:   meta,
 			scaledObjectRef: pb.ScaledObjectRef{
@@ -112,6 +159,11 @@ func parseExternalScalerMetadata(config *ScalerConfig) (externalScalerMetadata,
 		meta.tlsCertFile = val
 	}
 
+	if err := parseExternalScalerAuthParams(config, &meta); err != nil {
+		return meta, err
+	}
+	meta.egressProxy = config.EgressProxy
+
 	meta.originalMetadata = make(map[string]string)
 
 	// Add elements to metadata
@@ -129,6 +181,55 @@ func parseExternalScalerMetadata(config *ScalerConfig) (externalScalerMetadata,
 	return meta, nil
 }
 
+// parseExternalScalerAuthParams reads the TLS settings and the static auth header of the gRPC connection
+func parseExternalScalerAuthParams(config *ScalerConfig, meta *externalScalerMetadata) error {
+	tlsParams, err := parseTLSAuthParams(config)
+	if err != nil {
+		return err
+	}
+	meta.tlsAuthParams = tlsParams
+	if meta.enableTLS {
+		meta.tlsServerName = config.AuthParams["tlsServerName"]
+	}
+
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return err
+	}
+	meta.unsafeSsl = unsafeSsl
+
+	if val, ok := config.AuthParams["bearerToken"]; ok && val != "" {
+		meta.authHeaderName = "authorization"
+		meta.authHeaderValue = "Bearer " + val
+	}
+	if val, ok := config.AuthParams["authHeaderValue"]; ok && val != "" {
+		if meta.authHeaderValue != "" {
+			return errors.New("bearerToken and authHeaderValue can't be given together")
+		}
+		name := strings.ToLower(strings.TrimSpace(config.AuthParams["authHeaderName"]))
+		if name == "" {
+			return errors.New("authHeaderName must be provided with authHeaderValue")
+		}
+		meta.authHeaderName = name
+		meta.authHeaderValue = val
+	}
+	return nil
+}
+
+// grpcTLSConfig returns the TLS configuration of the gRPC connection, nil when TLS isn't enabled
+func (metadata externalScalerMetadata) grpcTLSConfig() (*tls.Config, error) {
+	if !metadata.enableTLS {
+		return nil, nil
+	}
+
+	config, err := metadata.newTLSConfig(metadata.unsafeSsl)
+	if err != nil {
+		return nil, err
+	}
+	config.ServerName = metadata.tlsServerName
+	return config, nil
+}
+
 // IsActive checks if there are any messages in the subscription
 func (s *externalScaler) IsActive(ctx context.Context) (bool, error) {
 	grpcClient, err := getClientForConnectionPool(s.metadata)
@@ -218,53 +319,117 @@ func (s *externalScaler) GetMetrics(ctx context.Context, metricName string, metr
 	return metrics, nil
 }
 
+// SetStreamStateHandler sets the function called when the stream connects or fails
+func (s *externalPushScaler) SetStreamStateHandler(handler func(PushScalerStreamState)) {
+	s.streamStateHandler = handler
+}
+
+// externalPushStream tracks the attempts to open the stream and reports the changes of its state
+type externalPushStream struct {
+	lock        sync.Mutex
+	scalerIndex int
+	attempt     int
+	connected   bool
+	failures    int
+	handler     func(PushScalerStreamState)
+}
+
+// setConnected reports the stream of the attempt as connected, unless it already failed
+func (st *externalPushStream) setConnected(attempt int) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	if attempt != st.attempt || st.connected {
+		return
+	}
+	st.connected = true
+	st.failures = 0
+	if st.handler != nil {
+		st.handler(PushScalerStreamState{ScalerIndex: st.scalerIndex, Connected: true})
+	}
+}
+
+// setFailed reports the failure of the stream of the current attempt and returns the consecutive failures
+func (st *externalPushStream) setFailed(err error) int {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	st.attempt++
+	st.connected = false
+	st.failures++
+	if st.handler != nil {
+		st.handler(PushScalerStreamState{ScalerIndex: st.scalerIndex, ConsecutiveFailures: st.failures, Err: err})
+	}
+	return st.failures
+}
+
+func (st *externalPushStream) currentAttempt() int {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	return st.attempt
+}
+
+// externalPushBackoff returns the delay before opening the stream again after the consecutive failures
+func externalPushBackoff(failures int) time.Duration {
+	backoff := externalPushMinBackoff
+	for i := 1; i < failures && backoff < externalPushMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > externalPushMaxBackoff {
+		backoff = externalPushMaxBackoff
+	}
+	return wait.Jitter(backoff, externalPushBackoffJitter)
+}
+
 // handleIsActiveStream is the only writer to the active channel and will close it on return.
 func (s *externalPushScaler) Run(ctx context.Context, active chan<- bool) {
 	defer close(active)
-	// It's possible for the connection to get terminated anytime, we need to run this in a retry loop
-	runWithLog := func() {
-		grpcClient, err := getClientForConnectionPool(s.metadata)
-		if err != nil {
-			s.logger.Error(err, "error running internalRun")
-			return
-		}
-		if err := handleIsActiveStream(ctx, s.scaledObjectRef, grpcClient, active); err != nil {
-			s.logger.Error(err, "error running internalRun")
+
+	stream := &externalPushStream{scalerIndex: s.metadata.scalerIndex, handler: s.streamStateHandler}
+	// It's possible for the connection to get terminated anytime, we need to run this in a retry loop.
+	// The backoff is reset once the stream is connected again.
+	for {
+		err := s.runStream(ctx, active, stream)
+		if ctx.Err() != nil {
 			return
 		}
-	}
-
-	// retry on error from runWithLog() starting by 2 sec backing off * 2 with a max of 1 minute
-	retryDuration := time.Second * 2
-	// the caller of this function needs to ensure that they call Stop() on the resulting
-	// timer, to release background resources.
-	retryBackoff := func() *time.Timer {
-		tmr := time.NewTimer(retryDuration)
-		retryDuration *= 2
-		if retryDuration > time.Minute*1 {
-			retryDuration = time.Minute * 1
+		if err == nil {
+			err = fmt.Errorf("the stream was closed by the external scaler")
 		}
-		return tmr
-	}
+		failures := stream.setFailed(err)
+		backoff := externalPushBackoff(failures)
+		s.logger.Error(err, "error running the external push scaler stream", "consecutiveFailures", failures, "retryIn", backoff)
 
-	// start the first run without delay
-	runWithLog()
-
-	for {
-		backoffTimer := retryBackoff()
+		backoffTimer := time.NewTimer(backoff)
 		select {
 		case <-ctx.Done():
 			backoffTimer.Stop()
 			return
 		case <-backoffTimer.C:
-			backoffTimer.Stop()
-			runWithLog()
 		}
 	}
 }
 
+// runStream blocks on the stream until it fails, the stream is connected once it delivered a message
+// or stayed open for externalPushStableStream
+func (s *externalPushScaler) runStream(ctx context.Context, active chan<- bool, stream *externalPushStream) error {
+	grpcClient, err := getClientForConnectionPool(s.metadata)
+	if err != nil {
+		return err
+	}
+
+	attempt := stream.currentAttempt()
+	stableTimer := time.AfterFunc(externalPushStableStream, func() {
+		stream.setConnected(attempt)
+	})
+	defer stableTimer.Stop()
+
+	return handleIsActiveStream(ctx, s.scaledObjectRef, grpcClient, active, func() {
+		stream.setConnected(attempt)
+	})
+}
+
 // handleIsActiveStream calls blocks on a stream call from the GRPC server. It'll only terminate on error, stream completion, or ctx cancellation.
-func handleIsActiveStream(ctx context.Context, scaledObjectRef pb.ScaledObjectRef, grpcClient pb.ExternalScalerClient, active chan<- bool) error {
+// onReceived is called for each message received.
+func handleIsActiveStream(ctx context.Context, scaledObjectRef pb.ScaledObjectRef, grpcClient pb.ExternalScalerClient, active chan<- bool, onReceived func()) error {
 	stream, err := grpcClient.StreamIsActive(ctx, &scaledObjectRef)
 	if err != nil {
 		return err
@@ -275,6 +440,7 @@ func handleIsActiveStream(ctx context.Context, scaledObjectRef pb.ScaledObjectRe
 		if err != nil {
 			return err
 		}
+		onReceived()
 
 		active <- resp.Result
 	}
@@ -289,20 +455,48 @@ func getClientForConnectionPool(metadata externalScalerMetadata) (pb.ExternalSca
 	defer connectionPoolMutex.Unlock()
 
 	buildGRPCConnection := func(metadata externalScalerMetadata) (*grpc.ClientConn, error) {
-		if metadata.tlsCertFile != "" {
+		var options []grpc.DialOption
+		if metadata.authHeaderValue != "" {
+			options = append(options, grpc.WithPerRPCCredentials(externalScalerAuthHeader{
+				name:       metadata.authHeaderName,
+				value:      metadata.authHeaderValue,
+				requireTLS: metadata.enableTLS || metadata.tlsCertFile != "",
+			}))
+		}
+
+		tlsConfig, err := metadata.grpcTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case tlsConfig != nil:
+			options = append(options, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+		case metadata.tlsCertFile != "":
 			creds, err := credentials.NewClientTLSFromFile(metadata.tlsCertFile, "")
 			if err != nil {
 				return nil, err
 			}
-			return grpc.Dial(metadata.scalerAddress, grpc.WithTransportCredentials(creds))
+			options = append(options, grpc.WithTransportCredentials(creds))
+		default:
+			options = append(options, grpc.WithTransportCredentials(insecure.NewCredentials()))
 		}
 
-		return grpc.Dial(metadata.scalerAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if metadata.egressProxy != nil {
+			dialer, err := kedautil.NewEgressDialer(metadata.egressProxy)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+				return dialer.DialContext(ctx, "tcp", address)
+			}))
+		}
+
+		return grpc.Dial(metadata.scalerAddress, options...)
 	}
 
 	// create a unique key per-metadata. If scaledObjects share the same connection properties
 	// in the metadata, they will share the same grpc.ClientConn
-	key, err := hashstructure.Hash(metadata.scalerAddress, nil)
+	key, err := hashstructure.Hash(metadata.connectionProperties(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -337,6 +531,25 @@ func getClientForConnectionPool(metadata externalScalerMetadata) (pb.ExternalSca
 	return pb.NewExternalScalerClient(connGroup.grpcConnection), nil
 }
 
+// connectionProperties are the properties of the grpc.ClientConn, the triggers with the same ones share it
+func (metadata externalScalerMetadata) connectionProperties() []string {
+	return []string{
+		metadata.scalerAddress, metadata.tlsCertFile,
+		strconv.FormatBool(metadata.enableTLS), metadata.ca, metadata.cert, metadata.key, metadata.keyPassword,
+		metadata.tlsServerName, strconv.FormatBool(metadata.unsafeSsl),
+		metadata.authHeaderName, metadata.authHeaderValue,
+		metadata.egressProxyAddress(),
+	}
+}
+
+// egressProxyAddress returns the URL of the egress proxy, empty without egress proxy
+func (metadata externalScalerMetadata) egressProxyAddress() string {
+	if metadata.egressProxy == nil {
+		return ""
+	}
+	return metadata.egressProxy.String()
+}
+
 func waitForState(ctx context.Context, conn *grpc.ClientConn, states ...connectivity.State) (done chan struct{}) {
 	done = make(chan struct{})
 
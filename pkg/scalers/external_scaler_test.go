@@ -1,38 +1,63 @@
 package scalers
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"net/url"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/mitchellh/hashstructure"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/kedacore/keda/v2/pkg/scalers/externalscaler"
 )
 
 type parseExternalScalerMetadataTestData struct {
-	metadata map[string]string
-	isError  bool
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
 }
 
 var testExternalScalerMetadata = []parseExternalScalerMetadataTestData{
-	{map[string]string{}, true},
+	{map[string]string{}, map[string]string{}, true},
 	// all properly formed
-	{map[string]string{"scalerAddress": "myservice", "test1": "7", "test2": "SAMPLE_CREDS"}, false},
+	{map[string]string{"scalerAddress": "myservice", "test1": "7", "test2": "SAMPLE_CREDS"}, map[string]string{}, false},
 	// missing scalerAddress
-	{map[string]string{"test1": "1", "test2": "SAMPLE_CREDS"}, true},
+	{map[string]string{"test1": "1", "test2": "SAMPLE_CREDS"}, map[string]string{}, true},
+	// tls with the server CA
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"tls": "enable", "ca": "caaa", "tlsServerName": "myservice.keda"}, false},
+	// mtls
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"tls": "enable", "ca": "caaa", "cert": "ceert", "key": "keey"}, false},
+	// cert without key
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"tls": "enable", "cert": "ceert"}, true},
+	// invalid tls
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"tls": "yes"}, true},
+	// invalid unsafeSsl
+	{map[string]string{"scalerAddress": "myservice", "unsafeSsl": "no"}, map[string]string{"tls": "enable"}, true},
+	// bearer token
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"bearerToken": "token"}, false},
+	// custom auth header
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"authHeaderName": "X-Api-Key", "authHeaderValue": "key"}, false},
+	// auth header without name
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"authHeaderValue": "key"}, true},
+	// bearer token and auth header
+	{map[string]string{"scalerAddress": "myservice"}, map[string]string{"bearerToken": "token", "authHeaderName": "X-Api-Key", "authHeaderValue": "key"}, true},
 }
 
 func TestExternalScalerParseMetadata(t *testing.T) {
 	for _, testData := range testExternalScalerMetadata {
-		_, err := parseExternalScalerMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ResolvedEnv: map[string]string{}})
+		_, err := parseExternalScalerMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: map[string]string{}})
 		if err != nil && !testData.isError {
 			t.Error("Expected success but got error", err)
 		}
@@ -42,6 +67,178 @@ func TestExternalScalerParseMetadata(t *testing.T) {
 	}
 }
 
+func TestExternalScalerGRPCTLSConfig(t *testing.T) {
+	meta, err := parseExternalScalerMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"scalerAddress": "myservice"},
+		AuthParams:      map[string]string{},
+		ResolvedEnv:     map[string]string{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config, err := meta.grpcTLSConfig(); err != nil || config != nil {
+		t.Errorf("expected no TLS config but got %v, %v", config, err)
+	}
+
+	meta, err = parseExternalScalerMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"scalerAddress": "myservice"},
+		AuthParams:      map[string]string{"tls": "enable", "tlsServerName": "myservice.keda"},
+		ResolvedEnv:     map[string]string{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := meta.grpcTLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config == nil || config.InsecureSkipVerify || config.ServerName != "myservice.keda" {
+		t.Errorf("expected a verifying TLS config for myservice.keda but got %v", config)
+	}
+}
+
+func TestExternalScalerConnectionProperties(t *testing.T) {
+	plain := externalScalerMetadata{scalerAddress: "myservice"}
+	withTLS := externalScalerMetadata{scalerAddress: "myservice", tlsAuthParams: tlsAuthParams{enableTLS: true}}
+	withHeader := externalScalerMetadata{scalerAddress: "myservice", authHeaderName: "authorization", authHeaderValue: "Bearer token"}
+	withProxy := externalScalerMetadata{scalerAddress: "myservice", egressProxy: &url.URL{Scheme: "http", Host: "proxy:3128"}}
+
+	keys := map[uint64]bool{}
+	for _, meta := range []externalScalerMetadata{plain, withTLS, withHeader, withProxy} {
+		key, err := hashstructure.Hash(meta.connectionProperties(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[key] = true
+	}
+	if len(keys) != 4 {
+		t.Errorf("expected the connections to differ by TLS, auth header and egress proxy, got %d keys", len(keys))
+	}
+}
+
+func TestExternalScalerSendsAuthHeader(t *testing.T) {
+	address := "127.0.0.1:15060"
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := make(chan []string, 1)
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		headers <- md.Get("x-api-key")
+		return handler(ctx, req)
+	}))
+	pb.RegisterExternalScalerServer(grpcServer, &testExternalScaler{t: t})
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	defer grpcServer.Stop()
+
+	meta, err := parseExternalScalerMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"scalerAddress": address},
+		AuthParams:      map[string]string{"authHeaderName": "X-Api-Key", "authHeaderValue": "key"},
+		ResolvedEnv:     map[string]string{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcClient, err := getClientForConnectionPool(meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// the test server doesn't implement IsActive, only the headers of the call are checked
+	_, _ = grpcClient.IsActive(ctx, &pb.ScaledObjectRef{Name: "app", Namespace: "namespace"})
+	select {
+	case values := <-headers:
+		if len(values) != 1 || values[0] != "key" {
+			t.Errorf("expected the x-api-key header to be key but got %v", values)
+		}
+	case <-ctx.Done():
+		t.Fatal("the call didn't reach the server")
+	}
+}
+
+func TestExternalScalerThroughEgressProxy(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterExternalScalerServer(grpcServer, &testExternalScaler{t: t})
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	defer grpcServer.Stop()
+
+	// HTTP CONNECT proxy recording the addresses it tunnels to
+	proxyLis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxyLis.Close()
+	tunneled := make(chan string, 1)
+	go func() {
+		for {
+			conn, err := proxyLis.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				req, err := http.ReadRequest(bufio.NewReader(conn))
+				if err != nil || req.Method != http.MethodConnect {
+					return
+				}
+				target, err := net.Dial("tcp", req.Host)
+				if err != nil {
+					return
+				}
+				defer target.Close()
+				tunneled <- req.Host
+				_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+				go func() {
+					_, _ = io.Copy(target, conn)
+				}()
+				_, _ = io.Copy(conn, target)
+			}(conn)
+		}
+	}()
+
+	config := &ScalerConfig{
+		TriggerMetadata: map[string]string{"scalerAddress": lis.Addr().String(), EgressProxyKey: "http://" + proxyLis.Addr().String()},
+		AuthParams:      map[string]string{},
+		ResolvedEnv:     map[string]string{},
+	}
+	if err := ResolveEgressProxy(config); err != nil {
+		t.Fatal(err)
+	}
+	meta, err := parseExternalScalerMetadata(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcClient, err := getClientForConnectionPool(meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// the test server doesn't implement IsActive, only the path of the connection is checked
+	_, _ = grpcClient.IsActive(ctx, &pb.ScaledObjectRef{Name: "app", Namespace: "namespace"})
+	select {
+	case host := <-tunneled:
+		if host != lis.Addr().String() {
+			t.Errorf("expected the proxy to tunnel to %s but got %s", lis.Addr(), host)
+		}
+	case <-ctx.Done():
+		t.Fatal("the connection didn't go through the proxy")
+	}
+}
+
 func TestExternalPushScaler_Run(t *testing.T) {
 	const serverCount = 5
 	const iterationCount = 500
@@ -96,6 +293,111 @@ func TestExternalPushScaler_Run(t *testing.T) {
 	}
 }
 
+func TestExternalPushBackoff(t *testing.T) {
+	for _, test := range []struct {
+		failures int
+		expected time.Duration
+	}{
+		{1, externalPushMinBackoff},
+		{2, 2 * externalPushMinBackoff},
+		{3, 4 * externalPushMinBackoff},
+		{100, externalPushMaxBackoff},
+	} {
+		backoff := externalPushBackoff(test.failures)
+		maxBackoff := time.Duration(float64(test.expected) * (1 + externalPushBackoffJitter))
+		if backoff < test.expected || backoff > maxBackoff {
+			t.Errorf("%d failures: expected a backoff between %s and %s but got %s", test.failures, test.expected, maxBackoff, backoff)
+		}
+	}
+}
+
+func TestExternalPushScalerReportsStreamState(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the external scaler is restarted on the same address
+	address := lis.Addr().String()
+	publish := make(chan bool)
+	grpcServer := grpc.NewServer()
+	pb.RegisterExternalScalerServer(grpcServer, &testExternalScaler{t: t, active: publish})
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+
+	states := make(chan PushScalerStreamState, 10)
+	pushScaler, err := NewExternalPushScaler(&ScalerConfig{ScalableObjectName: "app", ScalableObjectNamespace: "namespace", TriggerMetadata: map[string]string{"scalerAddress": address}, ResolvedEnv: map[string]string{}, ScalerIndex: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pushScaler.(StreamStateReporter).SetStreamStateHandler(func(state PushScalerStreamState) {
+		states <- state
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	active := make(chan bool)
+	go pushScaler.Run(ctx, active)
+
+	// the stream is connected once it delivered a message
+	publish <- true
+	<-active
+	select {
+	case state := <-states:
+		if !state.Connected || state.ScalerIndex != 2 {
+			t.Errorf("expected the stream of trigger 2 to be connected but got %+v", state)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the connection of the stream wasn't reported")
+	}
+
+	grpcServer.Stop()
+	select {
+	case state := <-states:
+		if state.Connected || state.ConsecutiveFailures != 1 || state.Err == nil {
+			t.Errorf("expected the first failure of the stream but got %+v", state)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the failure of the stream wasn't reported")
+	}
+
+	// the stream is opened again once the external scaler is restarted
+	lis, err = net.Listen("tcp", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	grpcServer = grpc.NewServer()
+	pb.RegisterExternalScalerServer(grpcServer, &testExternalScaler{t: t, active: publish})
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	defer grpcServer.Stop()
+
+	select {
+	case publish <- false:
+	case <-time.After(30 * time.Second):
+		t.Fatal("the stream wasn't opened again")
+	}
+	if isActive := <-active; isActive {
+		t.Error("expected the message published after the restart")
+	}
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case state := <-states:
+			if !state.Connected {
+				continue
+			}
+			if state.ScalerIndex != 2 {
+				t.Errorf("expected the stream of trigger 2 to be connected again but got %+v", state)
+			}
+			return
+		case <-timeout:
+			t.Fatal("the reconnection of the stream wasn't reported")
+		}
+	}
+}
+
 type testServer struct {
 	grpcServer *grpc.Server
 	address    string
@@ -0,0 +1,242 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudtasks "google.golang.org/api/cloudtasks/v2beta3"
+	option "google.golang.org/api/option"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	defaultCloudTasksTargetQueueSize     = 100
+	defaultCloudTasksTargetOldestTaskAge = 60
+
+	cloudTasksModeQueueSize     = "QueueSize"
+	cloudTasksModeOldestTaskAge = "OldestTaskAge"
+)
+
+var regexpCloudTasksQueueName = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/queues/[^/]+$`)
+
+type cloudTasksScaler struct {
+	service    *cloudtasks.Service
+	metricType v2beta2.MetricTargetType
+	metadata   *cloudTasksMetadata
+	logger     logr.Logger
+}
+
+type cloudTasksMetadata struct {
+	mode            string
+	value           float64
+	activationValue int64
+
+	// queueName is the full resource name of the queue, projects/PROJECT_ID/locations/LOCATION_ID/queues/QUEUE_ID
+	queueName        string
+	gcpAuthorization *gcpAuthorizationMetadata
+	scalerIndex      int
+}
+
+// NewCloudTasksScaler creates a new cloudTasksScaler
+func NewCloudTasksScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseCloudTasksMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Cloud Tasks metadata: %s", err)
+	}
+
+	return &cloudTasksScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     InitializeLogger(config, "gcp_cloud_tasks_scaler"),
+	}, nil
+}
+
+func parseCloudTasksMetadata(config *ScalerConfig) (*cloudTasksMetadata, error) {
+	meta := cloudTasksMetadata{}
+
+	meta.mode = cloudTasksModeQueueSize
+	if val, ok := config.TriggerMetadata["mode"]; ok {
+		meta.mode = val
+	}
+
+	switch meta.mode {
+	case cloudTasksModeQueueSize:
+		meta.value = defaultCloudTasksTargetQueueSize
+	case cloudTasksModeOldestTaskAge:
+		meta.value = defaultCloudTasksTargetOldestTaskAge
+	default:
+		return nil, fmt.Errorf("trigger mode %s must be one of %s, %s", meta.mode, cloudTasksModeQueueSize, cloudTasksModeOldestTaskAge)
+	}
+
+	if val, ok := config.TriggerMetadata["value"]; ok {
+		value, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value parsing error %s", err.Error())
+		}
+		meta.value = value
+	}
+
+	meta.activationValue = 0
+	if val, ok := config.TriggerMetadata["activationValue"]; ok {
+		activationValue, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("activationValue parsing error %s", err.Error())
+		}
+		meta.activationValue = activationValue
+	}
+
+	queueName := config.TriggerMetadata["queueName"]
+	switch {
+	case queueName == "":
+		return nil, errors.New("no queue name given")
+	case regexpCloudTasksQueueName.MatchString(queueName):
+		meta.queueName = queueName
+	default:
+		projectID := config.TriggerMetadata["projectID"]
+		if projectID == "" {
+			return nil, errors.New("no projectID given")
+		}
+		location := config.TriggerMetadata["location"]
+		if location == "" {
+			return nil, errors.New("no location given")
+		}
+		meta.queueName = fmt.Sprintf("projects/%s/locations/%s/queues/%s", projectID, location, queueName)
+	}
+
+	auth, err := getGcpAuthorization(config, config.ResolvedEnv)
+	if err != nil {
+		return nil, err
+	}
+	meta.gcpAuthorization = auth
+	meta.scalerIndex = config.ScalerIndex
+	return &meta, nil
+}
+
+// IsActive checks if there are any tasks in the queue
+func (s *cloudTasksScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getQueueValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting Active Status")
+		return false, err
+	}
+	return value > s.metadata.activationValue, nil
+}
+
+func (s *cloudTasksScaler) Close(context.Context) error {
+	s.service = nil
+	return nil
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *cloudTasksScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("gcp-ct-%s", s.queueID()))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
+	}
+
+	metricSpec := v2beta2.MetricSpec{
+		External: externalMetric,
+		Type:     externalMetricType,
+	}
+
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+// GetMetrics returns the number of tasks or the age of the oldest task in the queue
+func (s *cloudTasksScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getQueueValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting Cloud Tasks queue stats")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(value))
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+func (s *cloudTasksScaler) setCloudTasksService(ctx context.Context) error {
+	var service *cloudtasks.Service
+	var err error
+
+	switch {
+	case s.metadata.gcpAuthorization.podIdentityProviderEnabled:
+		service, err = cloudtasks.NewService(ctx)
+	case s.metadata.gcpAuthorization.GoogleApplicationCredentialsFile != "":
+		service, err = cloudtasks.NewService(ctx, option.WithCredentialsFile(s.metadata.gcpAuthorization.GoogleApplicationCredentialsFile))
+	default:
+		service, err = cloudtasks.NewService(ctx, option.WithCredentialsJSON([]byte(s.metadata.gcpAuthorization.GoogleApplicationCredentials)))
+	}
+
+	if err != nil {
+		return err
+	}
+	s.service = service
+	return nil
+}
+
+// getQueueValue returns the value for the configured mode from the queue stats
+func (s *cloudTasksScaler) getQueueValue(ctx context.Context) (int64, error) {
+	if s.service == nil {
+		if err := s.setCloudTasksService(ctx); err != nil {
+			return -1, err
+		}
+	}
+
+	queue, err := s.service.Projects.Locations.Queues.Get(s.metadata.queueName).ReadMask("stats").Context(ctx).Do()
+	if err != nil {
+		return -1, err
+	}
+
+	return getCloudTasksQueueValue(s.metadata.mode, queue.Stats, time.Now())
+}
+
+// getCloudTasksQueueValue returns the number of tasks in the queue, or the age in seconds of the
+// task with the oldest estimated arrival time
+func getCloudTasksQueueValue(mode string, stats *cloudtasks.QueueStats, now time.Time) (int64, error) {
+	if stats == nil {
+		return 0, nil
+	}
+
+	switch mode {
+	case cloudTasksModeQueueSize:
+		return stats.TasksCount, nil
+	case cloudTasksModeOldestTaskAge:
+		// the oldest estimated arrival time is empty when the queue is empty
+		if stats.OldestEstimatedArrivalTime == "" {
+			return 0, nil
+		}
+		oldest, err := time.Parse(time.RFC3339Nano, stats.OldestEstimatedArrivalTime)
+		if err != nil {
+			return -1, fmt.Errorf("error parsing oldest estimated arrival time: %s", err)
+		}
+		age := int64(now.Sub(oldest).Seconds())
+		if age < 0 {
+			return 0, nil
+		}
+		return age, nil
+	default:
+		return -1, errors.New("unknown mode")
+	}
+}
+
+func (s *cloudTasksScaler) queueID() string {
+	return s.metadata.queueName[strings.LastIndex(s.metadata.queueName, "/")+1:]
+}
@@ -0,0 +1,116 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	cloudtasks "google.golang.org/api/cloudtasks/v2beta3"
+)
+
+var testCloudTasksResolvedEnv = map[string]string{
+	"SAMPLE_CREDS": "{}",
+}
+
+type parseCloudTasksMetadataTestData struct {
+	authParams map[string]string
+	metadata   map[string]string
+	isError    bool
+}
+
+type gcpCloudTasksMetricIdentifier struct {
+	metadataTestData *parseCloudTasksMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testCloudTasksMetadata = []parseCloudTasksMetadataTestData{
+	{map[string]string{}, map[string]string{}, true},
+	// all properly formed
+	{nil, map[string]string{"queueName": "myqueue", "projectID": "myproject", "location": "europe-west1", "value": "7", "activationValue": "5", "credentialsFromEnv": "SAMPLE_CREDS"}, false},
+	// all properly formed with oldest task age mode
+	{nil, map[string]string{"queueName": "myqueue", "projectID": "myproject", "location": "europe-west1", "mode": cloudTasksModeOldestTaskAge, "value": "30", "credentialsFromEnv": "SAMPLE_CREDS"}, false},
+	// with full queue name
+	{nil, map[string]string{"queueName": "projects/myproject/locations/europe-west1/queues/myqueue", "credentialsFromEnv": "SAMPLE_CREDS"}, false},
+	// missing queueName
+	{nil, map[string]string{"queueName": "", "projectID": "myproject", "location": "europe-west1", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// missing projectID
+	{nil, map[string]string{"queueName": "myqueue", "location": "europe-west1", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// missing location
+	{nil, map[string]string{"queueName": "myqueue", "projectID": "myproject", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// missing credentials
+	{nil, map[string]string{"queueName": "myqueue", "projectID": "myproject", "location": "europe-west1", "credentialsFromEnv": ""}, true},
+	// malformed value
+	{nil, map[string]string{"queueName": "myqueue", "projectID": "myproject", "location": "europe-west1", "value": "AA", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// malformed mode
+	{nil, map[string]string{"queueName": "myqueue", "projectID": "myproject", "location": "europe-west1", "mode": "AA", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// malformed activationValue
+	{nil, map[string]string{"queueName": "myqueue", "projectID": "myproject", "location": "europe-west1", "activationValue": "AA", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// Credentials from AuthParams
+	{map[string]string{"GoogleApplicationCredentials": "Creds"}, map[string]string{"queueName": "myqueue", "projectID": "myproject", "location": "europe-west1"}, false},
+}
+
+var gcpCloudTasksMetricIdentifiers = []gcpCloudTasksMetricIdentifier{
+	{&testCloudTasksMetadata[1], 0, "s0-gcp-ct-myqueue"},
+	{&testCloudTasksMetadata[3], 1, "s1-gcp-ct-myqueue"},
+}
+
+func TestCloudTasksParseMetadata(t *testing.T) {
+	for _, testData := range testCloudTasksMetadata {
+		_, err := parseCloudTasksMetadata(&ScalerConfig{AuthParams: testData.authParams, TriggerMetadata: testData.metadata, ResolvedEnv: testCloudTasksResolvedEnv})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestCloudTasksGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range gcpCloudTasksMetricIdentifiers {
+		meta, err := parseCloudTasksMetadata(&ScalerConfig{AuthParams: testData.metadataTestData.authParams, TriggerMetadata: testData.metadataTestData.metadata, ResolvedEnv: testCloudTasksResolvedEnv, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockCloudTasksScaler := cloudTasksScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockCloudTasksScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestCloudTasksGetQueueValue(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		mode     string
+		stats    *cloudtasks.QueueStats
+		expected int64
+		isError  bool
+	}{
+		{cloudTasksModeQueueSize, &cloudtasks.QueueStats{TasksCount: 42}, 42, false},
+		{cloudTasksModeQueueSize, nil, 0, false},
+		{cloudTasksModeOldestTaskAge, &cloudtasks.QueueStats{TasksCount: 3, OldestEstimatedArrivalTime: "2022-08-01T11:58:30.5Z"}, 89, false},
+		{cloudTasksModeOldestTaskAge, &cloudtasks.QueueStats{}, 0, false},
+		{cloudTasksModeOldestTaskAge, &cloudtasks.QueueStats{OldestEstimatedArrivalTime: "2022-08-01T12:05:00Z"}, 0, false},
+		{cloudTasksModeOldestTaskAge, &cloudtasks.QueueStats{OldestEstimatedArrivalTime: "yesterday"}, -1, true},
+	}
+
+	for _, tc := range testCases {
+		value, err := getCloudTasksQueueValue(tc.mode, tc.stats, now)
+		if err != nil && !tc.isError {
+			t.Errorf("Expected success for %v but got error %s", tc.stats, err)
+		}
+		if tc.isError && err == nil {
+			t.Errorf("Expected error for %v but got success", tc.stats)
+		}
+		if value != tc.expected {
+			t.Errorf("Expected %d for %v but got %d", tc.expected, tc.stats, value)
+		}
+	}
+}
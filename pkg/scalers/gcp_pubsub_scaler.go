@@ -7,6 +7,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/api/autoscaling/v2beta2"
@@ -22,6 +24,7 @@ const (
 	defaultTargetOldestUnackedMessageAge               = 10
 	pubSubStackDriverSubscriptionSizeMetricName        = "pubsub.googleapis.com/subscription/num_undelivered_messages"
 	pubSubStackDriverOldestUnackedMessageAgeMetricName = "pubsub.googleapis.com/subscription/oldest_unacked_message_age"
+	pubSubStackDriverSeekRequestCountMetricName        = "pubsub.googleapis.com/subscription/seek_request_count"
 
 	pubsubModeSubscriptionSize        = "SubscriptionSize"
 	pubsubModeOldestUnackedMessageAge = "OldestUnackedMessageAge"
@@ -34,6 +37,11 @@ type pubsubScaler struct {
 	metricType v2beta2.MetricTargetType
 	metadata   *pubsubMetadata
 	logger     logr.Logger
+
+	// last reported subscription size, used to dampen sudden increases
+	lastSize     int64
+	lastSizeTime time.Time
+	lastSizeLock sync.Mutex
 }
 
 type pubsubMetadata struct {
@@ -41,6 +49,11 @@ type pubsubMetadata struct {
 	value           int64
 	activationValue int64
 
+	// maximum increase of the reported subscription size per minute, 0 disables the dampening
+	maxIncreasePerMinute int64
+	// value reported instead of the subscription size when the subscription has been seeked recently
+	valueIfRecentSeek *int64
+
 	subscriptionName string
 	gcpAuthorization *gcpAuthorizationMetadata
 	scalerIndex      int
@@ -127,6 +140,31 @@ func parsePubSubMetadata(config *ScalerConfig, logger logr.Logger) (*pubsubMetad
 		meta.activationValue = activationValue
 	}
 
+	if val, ok := config.TriggerMetadata["maxIncreasePerMinute"]; ok {
+		if meta.mode != pubsubModeSubscriptionSize {
+			return nil, fmt.Errorf("maxIncreasePerMinute can only be used with mode %s", pubsubModeSubscriptionSize)
+		}
+		maxIncreasePerMinute, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("maxIncreasePerMinute parsing error %s", err.Error())
+		}
+		if maxIncreasePerMinute <= 0 {
+			return nil, errors.New("maxIncreasePerMinute must be positive")
+		}
+		meta.maxIncreasePerMinute = maxIncreasePerMinute
+	}
+
+	if val, ok := config.TriggerMetadata["valueIfRecentSeek"]; ok {
+		if meta.mode != pubsubModeSubscriptionSize {
+			return nil, fmt.Errorf("valueIfRecentSeek can only be used with mode %s", pubsubModeSubscriptionSize)
+		}
+		valueIfRecentSeek, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("valueIfRecentSeek parsing error %s", err.Error())
+		}
+		meta.valueIfRecentSeek = &valueIfRecentSeek
+	}
+
 	auth, err := getGcpAuthorization(config, config.ResolvedEnv)
 	if err != nil {
 		return nil, err
@@ -140,7 +178,7 @@ func parsePubSubMetadata(config *ScalerConfig, logger logr.Logger) (*pubsubMetad
 func (s *pubsubScaler) IsActive(ctx context.Context) (bool, error) {
 	switch s.metadata.mode {
 	case pubsubModeSubscriptionSize:
-		size, err := s.getMetrics(ctx, pubSubStackDriverSubscriptionSizeMetricName)
+		size, err := s.getSubscriptionSize(ctx)
 		if err != nil {
 			s.logger.Error(err, "error getting Active Status")
 			return false, err
@@ -195,7 +233,7 @@ func (s *pubsubScaler) GetMetrics(ctx context.Context, metricName string, metric
 
 	switch s.metadata.mode {
 	case pubsubModeSubscriptionSize:
-		value, err = s.getMetrics(ctx, pubSubStackDriverSubscriptionSizeMetricName)
+		value, err = s.getSubscriptionSize(ctx)
 		if err != nil {
 			s.logger.Error(err, "error getting subscription size")
 			return []external_metrics.ExternalMetricValue{}, err
@@ -229,6 +267,71 @@ func (s *pubsubScaler) setStackdriverClient(ctx context.Context) error {
 	return nil
 }
 
+// getSubscriptionSize returns the number of undelivered messages, replaced by valueIfRecentSeek when the
+// subscription has been seeked recently and dampened by maxIncreasePerMinute, so that backfills don't
+// scale out to maxReplicaCount instantly
+func (s *pubsubScaler) getSubscriptionSize(ctx context.Context) (int64, error) {
+	size, err := s.getMetrics(ctx, pubSubStackDriverSubscriptionSizeMetricName)
+	if err != nil {
+		return -1, err
+	}
+
+	if s.metadata.valueIfRecentSeek != nil {
+		seeked, err := s.hasRecentSeek(ctx)
+		if err != nil {
+			return -1, err
+		}
+		if seeked {
+			s.logger.V(1).Info("subscription has been seeked recently, using valueIfRecentSeek", "subscriptionSize", size, "valueIfRecentSeek", *s.metadata.valueIfRecentSeek)
+			return *s.metadata.valueIfRecentSeek, nil
+		}
+	}
+
+	return s.dampenSubscriptionSize(size, time.Now()), nil
+}
+
+// hasRecentSeek checks if seek requests, e.g. to a snapshot, have been sent to the subscription recently
+func (s *pubsubScaler) hasRecentSeek(ctx context.Context) (bool, error) {
+	aggregation, err := NewStackdriverAggregator(120, "sum", "sum")
+	if err != nil {
+		return false, err
+	}
+
+	subscriptionID, projectID := getSubscriptionData(s)
+	filter := `metric.type="` + pubSubStackDriverSeekRequestCountMetricName + `" AND resource.labels.subscription_id="` + subscriptionID + `"`
+	count, err := s.client.GetMetrics(ctx, filter, projectID, aggregation)
+	if errors.Is(err, errStackDriverMetricNotFound) {
+		// no seek request has been sent
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// dampenSubscriptionSize limits the increase of the subscription size to maxIncreasePerMinute
+// since the last reported size
+func (s *pubsubScaler) dampenSubscriptionSize(size int64, now time.Time) int64 {
+	if s.metadata.maxIncreasePerMinute == 0 {
+		return size
+	}
+
+	s.lastSizeLock.Lock()
+	defer s.lastSizeLock.Unlock()
+
+	if !s.lastSizeTime.IsZero() {
+		allowed := s.lastSize + int64(float64(s.metadata.maxIncreasePerMinute)*now.Sub(s.lastSizeTime).Minutes())
+		if size > allowed {
+			s.logger.V(1).Info("dampening subscription size increase", "subscriptionSize", size, "reportedSize", allowed)
+			size = allowed
+		}
+	}
+	s.lastSize = size
+	s.lastSizeTime = now
+	return size
+}
+
 // getMetrics gets metric type value from stackdriver api
 func (s *pubsubScaler) getMetrics(ctx context.Context, metricType string) (int64, error) {
 	if s.client == nil {
@@ -3,6 +3,7 @@ package scalers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 )
@@ -56,6 +57,16 @@ var testPubSubMetadata = []parsePubSubMetadataTestData{
 	{nil, map[string]string{"subscriptionName": "projects/myproject/subscriptions/mysubscription", "subscriptionSize": "7", "credentialsFromEnv": "SAMPLE_CREDS"}, false},
 	// with full (bad) link to subscription
 	{nil, map[string]string{"subscriptionName": "projects/myproject/mysubscription", "subscriptionSize": "7", "credentialsFromEnv": "SAMPLE_CREDS"}, false},
+	// with backfill dampening and recent seek value
+	{nil, map[string]string{"subscriptionName": "mysubscription", "value": "7", "credentialsFromEnv": "SAMPLE_CREDS", "maxIncreasePerMinute": "100", "valueIfRecentSeek": "10"}, false},
+	// malformed maxIncreasePerMinute
+	{nil, map[string]string{"subscriptionName": "mysubscription", "value": "7", "credentialsFromEnv": "SAMPLE_CREDS", "maxIncreasePerMinute": "AA"}, true},
+	// negative maxIncreasePerMinute
+	{nil, map[string]string{"subscriptionName": "mysubscription", "value": "7", "credentialsFromEnv": "SAMPLE_CREDS", "maxIncreasePerMinute": "-1"}, true},
+	// malformed valueIfRecentSeek
+	{nil, map[string]string{"subscriptionName": "mysubscription", "value": "7", "credentialsFromEnv": "SAMPLE_CREDS", "valueIfRecentSeek": "AA"}, true},
+	// valueIfRecentSeek with oldest unacked message age mode
+	{nil, map[string]string{"subscriptionName": "mysubscription", "mode": pubsubModeOldestUnackedMessageAge, "value": "7", "credentialsFromEnv": "SAMPLE_CREDS", "valueIfRecentSeek": "10"}, true},
 }
 
 var gcpPubSubMetricIdentifiers = []gcpPubSubMetricIdentifier{
@@ -86,7 +97,7 @@ func TestGcpPubSubGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockGcpPubSubScaler := pubsubScaler{nil, "", meta, logr.Discard()}
+		mockGcpPubSubScaler := pubsubScaler{metadata: meta, logger: logr.Discard()}
 
 		metricSpec := mockGcpPubSubScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -102,7 +113,7 @@ func TestGcpPubSubSubscriptionName(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockGcpPubSubScaler := pubsubScaler{nil, "", meta, logr.Discard()}
+		mockGcpPubSubScaler := pubsubScaler{metadata: meta, logger: logr.Discard()}
 		subscriptionID, projectID := getSubscriptionData(&mockGcpPubSubScaler)
 
 		if subscriptionID != testData.name || projectID != testData.projectID {
@@ -110,3 +121,27 @@ func TestGcpPubSubSubscriptionName(t *testing.T) {
 		}
 	}
 }
+
+func TestGcpPubSubDampenSubscriptionSize(t *testing.T) {
+	scaler := pubsubScaler{metadata: &pubsubMetadata{maxIncreasePerMinute: 100}, logger: logr.Discard()}
+	start := time.Now()
+
+	if size := scaler.dampenSubscriptionSize(50, start); size != 50 {
+		t.Errorf("Expected first size to be reported as is but got %d", size)
+	}
+	if size := scaler.dampenSubscriptionSize(10000, start.Add(time.Minute)); size != 150 {
+		t.Errorf("Expected size increase to be limited to 150 but got %d", size)
+	}
+	if size := scaler.dampenSubscriptionSize(10000, start.Add(2*time.Minute)); size != 250 {
+		t.Errorf("Expected size increase to be limited to 250 but got %d", size)
+	}
+	if size := scaler.dampenSubscriptionSize(20, start.Add(3*time.Minute)); size != 20 {
+		t.Errorf("Expected size decrease to be reported as is but got %d", size)
+	}
+
+	disabled := pubsubScaler{metadata: &pubsubMetadata{}, logger: logr.Discard()}
+	disabled.dampenSubscriptionSize(50, start)
+	if size := disabled.dampenSubscriptionSize(10000, start.Add(time.Minute)); size != 10000 {
+		t.Errorf("Expected size not to be dampened but got %d", size)
+	}
+}
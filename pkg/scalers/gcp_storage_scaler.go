@@ -37,7 +37,7 @@ type gcsMetadata struct {
 	gcpAuthorization            *gcpAuthorizationMetadata
 	maxBucketItemsToScan        int64
 	metricName                  string
-	targetObjectCount           int64
+	targetObjectCount           float64
 	activationTargetObjectCount int64
 }
 
@@ -108,7 +108,7 @@ func parseGcsMetadata(config *ScalerConfig, logger logr.Logger) (*gcsMetadata, e
 	}
 
 	if val, ok := config.TriggerMetadata["targetObjectCount"]; ok {
-		targetObjectCount, err := strconv.ParseInt(val, 10, 64)
+		targetObjectCount, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			logger.Error(err, "Error parsing targetObjectCount")
 			return nil, fmt.Errorf("error parsing targetObjectCount: %s", err.Error())
@@ -171,7 +171,7 @@ func (s *gcsScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpe
 		Metric: v2beta2.MetricIdentifier{
 			Name: s.metadata.metricName,
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.targetObjectCount),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetObjectCount),
 	}
 	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta2.MetricSpec{metricSpec}
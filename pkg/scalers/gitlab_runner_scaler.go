@@ -0,0 +1,308 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	defaultGitLabAPIURL         = "https://gitlab.com"
+	defaultTargetPendingJobs    = 1
+	gitLabRunnerMetricType      = "External"
+	gitLabAPIPageSize           = 100
+	gitLabPrivateTokenHeader    = "PRIVATE-TOKEN"
+	gitLabNextPageHeader        = "X-Next-Page"
+	gitLabPendingJobsQueryScope = "scope[]=pending"
+)
+
+type gitLabRunnerScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *gitLabRunnerMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type gitLabRunnerMetadata struct {
+	gitlabAPIURL                string
+	personalAccessToken         string
+	projectID                   string
+	groupID                     string
+	tags                        []string
+	runUntagged                 bool
+	targetPendingJobs           float64
+	activationTargetPendingJobs int64
+	unsafeSsl                   bool
+	scalerIndex                 int
+}
+
+type gitLabJob struct {
+	ID      int64    `json:"id"`
+	TagList []string `json:"tag_list"`
+}
+
+type gitLabProject struct {
+	ID int64 `json:"id"`
+}
+
+// NewGitLabRunnerScaler creates a new gitLabRunnerScaler
+func NewGitLabRunnerScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseGitLabRunnerMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing gitlab runner metadata: %s", err)
+	}
+
+	return &gitLabRunnerScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: createHTTPClient(config, meta.unsafeSsl),
+		logger:     InitializeLogger(config, "gitlab_runner_scaler"),
+	}, nil
+}
+
+func parseGitLabRunnerMetadata(config *ScalerConfig) (*gitLabRunnerMetadata, error) {
+	meta := gitLabRunnerMetadata{}
+
+	meta.gitlabAPIURL = defaultGitLabAPIURL
+	if val, ok := config.TriggerMetadata["gitlabAPIURL"]; ok && val != "" {
+		meta.gitlabAPIURL = strings.TrimSuffix(val, "/")
+	}
+
+	switch {
+	case config.AuthParams["personalAccessToken"] != "":
+		meta.personalAccessToken = config.AuthParams["personalAccessToken"]
+	case config.TriggerMetadata["personalAccessTokenFromEnv"] != "":
+		meta.personalAccessToken = config.ResolvedEnv[config.TriggerMetadata["personalAccessTokenFromEnv"]]
+	}
+	meta.personalAccessToken = strings.TrimSuffix(meta.personalAccessToken, "\n")
+	if meta.personalAccessToken == "" {
+		return nil, errors.New("no personalAccessToken given")
+	}
+
+	meta.projectID = config.TriggerMetadata["projectID"]
+	meta.groupID = config.TriggerMetadata["groupID"]
+	switch {
+	case meta.projectID == "" && meta.groupID == "":
+		return nil, errors.New("either projectID or groupID must be given")
+	case meta.projectID != "" && meta.groupID != "":
+		return nil, errors.New("projectID and groupID can't be used together")
+	}
+
+	if val, ok := config.TriggerMetadata["tags"]; ok && val != "" {
+		for _, tag := range strings.Split(val, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				meta.tags = append(meta.tags, tag)
+			}
+		}
+	}
+
+	// runners without tags pick up untagged jobs, runners with tags only if they are configured to
+	meta.runUntagged = len(meta.tags) == 0
+	if val, ok := config.TriggerMetadata["runUntagged"]; ok && val != "" {
+		runUntagged, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing runUntagged: %s", err)
+		}
+		meta.runUntagged = runUntagged
+	}
+
+	meta.targetPendingJobs = defaultTargetPendingJobs
+	if val, ok := config.TriggerMetadata["targetPendingJobs"]; ok && val != "" {
+		targetPendingJobs, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing targetPendingJobs: %s", err)
+		}
+		meta.targetPendingJobs = targetPendingJobs
+	}
+
+	meta.activationTargetPendingJobs = 0
+	if val, ok := config.TriggerMetadata["activationTargetPendingJobs"]; ok && val != "" {
+		activationTargetPendingJobs, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetPendingJobs: %s", err)
+		}
+		meta.activationTargetPendingJobs = activationTargetPendingJobs
+	}
+
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.unsafeSsl = unsafeSsl
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func (s *gitLabRunnerScaler) IsActive(ctx context.Context) (bool, error) {
+	pendingJobs, err := s.getPendingJobs(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting gitlab pending jobs")
+		return false, err
+	}
+
+	return pendingJobs > s.metadata.activationTargetPendingJobs, nil
+}
+
+func (s *gitLabRunnerScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (s *gitLabRunnerScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	var metricName string
+	if s.metadata.projectID != "" {
+		metricName = fmt.Sprintf("gitlab-runner-project-%s", s.metadata.projectID)
+	} else {
+		metricName = fmt.Sprintf("gitlab-runner-group-%s", s.metadata.groupID)
+	}
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetPendingJobs),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: gitLabRunnerMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *gitLabRunnerScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	pendingJobs, err := s.getPendingJobs(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting gitlab pending jobs")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(pendingJobs))
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// getPendingJobs returns the number of pending jobs of the project, or of all the projects of the group,
+// which can be picked up by a runner with the configured tags
+func (s *gitLabRunnerScaler) getPendingJobs(ctx context.Context) (int64, error) {
+	projectIDs := []string{s.metadata.projectID}
+	if s.metadata.groupID != "" {
+		var err error
+		projectIDs, err = s.getGroupProjectIDs(ctx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var count int64
+	for _, projectID := range projectIDs {
+		jobsURL := fmt.Sprintf("%s/api/v4/projects/%s/jobs?%s", s.metadata.gitlabAPIURL, url.PathEscape(projectID), gitLabPendingJobsQueryScope)
+		err := s.getPaginated(ctx, jobsURL, func(body []byte) error {
+			var jobs []gitLabJob
+			if err := json.Unmarshal(body, &jobs); err != nil {
+				return fmt.Errorf("error decoding gitlab jobs: %s", err)
+			}
+			for _, job := range jobs {
+				if s.canRunJob(job) {
+					count++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
+// getGroupProjectIDs returns the ids of the projects of the group and its subgroups
+func (s *gitLabRunnerScaler) getGroupProjectIDs(ctx context.Context) ([]string, error) {
+	var projectIDs []string
+	projectsURL := fmt.Sprintf("%s/api/v4/groups/%s/projects?include_subgroups=true&archived=false&simple=true", s.metadata.gitlabAPIURL, url.PathEscape(s.metadata.groupID))
+	err := s.getPaginated(ctx, projectsURL, func(body []byte) error {
+		var projects []gitLabProject
+		if err := json.Unmarshal(body, &projects); err != nil {
+			return fmt.Errorf("error decoding gitlab projects: %s", err)
+		}
+		for _, project := range projects {
+			projectIDs = append(projectIDs, strconv.FormatInt(project.ID, 10))
+		}
+		return nil
+	})
+	return projectIDs, err
+}
+
+// canRunJob returns true if a runner with the configured tags picks up the job, which is the case
+// when the runner has all the tags of the job
+func (s *gitLabRunnerScaler) canRunJob(job gitLabJob) bool {
+	if len(job.TagList) == 0 {
+		return s.metadata.runUntagged
+	}
+
+	for _, jobTag := range job.TagList {
+		found := false
+		for _, tag := range s.metadata.tags {
+			if jobTag == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// getPaginated calls handlePage with the body of every page of the GitLab API list endpoint
+func (s *gitLabRunnerScaler) getPaginated(ctx context.Context, listURL string, handlePage func([]byte) error) error {
+	page := "1"
+	for page != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s&per_page=%d&page=%s", listURL, gitLabAPIPageSize, page), nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set(gitLabPrivateTokenHeader, s.metadata.personalAccessToken)
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("the GitLab API returned error. url: %s status: %d response: %s", listURL, resp.StatusCode, string(body))
+		}
+
+		if err := handlePage(body); err != nil {
+			return err
+		}
+		page = resp.Header.Get(gitLabNextPageHeader)
+	}
+	return nil
+}
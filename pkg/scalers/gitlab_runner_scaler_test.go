@@ -0,0 +1,143 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+type parseGitLabRunnerMetadataTestData struct {
+	metadata    map[string]string
+	authParams  map[string]string
+	resolvedEnv map[string]string
+	isError     bool
+}
+
+type gitLabRunnerMetricIdentifier struct {
+	metadataTestData *parseGitLabRunnerMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testGitLabRunnerResolvedEnv = map[string]string{
+	"GITLAB_TOKEN": "glpat-sample",
+}
+
+var testGitLabRunnerMetadata = []parseGitLabRunnerMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, testGitLabRunnerResolvedEnv, true},
+	// properly formed project
+	{map[string]string{"projectID": "42", "personalAccessTokenFromEnv": "GITLAB_TOKEN"}, map[string]string{}, testGitLabRunnerResolvedEnv, false},
+	// properly formed group with token from auth params
+	{map[string]string{"groupID": "my-group/sub", "tags": "docker, linux", "gitlabAPIURL": "https://gitlab.example.com/"}, map[string]string{"personalAccessToken": "glpat-sample"}, testGitLabRunnerResolvedEnv, false},
+	// missing personalAccessToken
+	{map[string]string{"projectID": "42"}, map[string]string{}, testGitLabRunnerResolvedEnv, true},
+	// missing projectID and groupID
+	{map[string]string{"personalAccessTokenFromEnv": "GITLAB_TOKEN"}, map[string]string{}, testGitLabRunnerResolvedEnv, true},
+	// projectID and groupID together
+	{map[string]string{"projectID": "42", "groupID": "7", "personalAccessTokenFromEnv": "GITLAB_TOKEN"}, map[string]string{}, testGitLabRunnerResolvedEnv, true},
+	// invalid targetPendingJobs
+	{map[string]string{"projectID": "42", "personalAccessTokenFromEnv": "GITLAB_TOKEN", "targetPendingJobs": "a"}, map[string]string{}, testGitLabRunnerResolvedEnv, true},
+	// invalid activationTargetPendingJobs
+	{map[string]string{"projectID": "42", "personalAccessTokenFromEnv": "GITLAB_TOKEN", "activationTargetPendingJobs": "a"}, map[string]string{}, testGitLabRunnerResolvedEnv, true},
+	// invalid runUntagged
+	{map[string]string{"projectID": "42", "personalAccessTokenFromEnv": "GITLAB_TOKEN", "runUntagged": "a"}, map[string]string{}, testGitLabRunnerResolvedEnv, true},
+	// invalid unsafeSsl
+	{map[string]string{"projectID": "42", "personalAccessTokenFromEnv": "GITLAB_TOKEN", "unsafeSsl": "a"}, map[string]string{}, testGitLabRunnerResolvedEnv, true},
+}
+
+var gitLabRunnerMetricIdentifiers = []gitLabRunnerMetricIdentifier{
+	{&testGitLabRunnerMetadata[1], 0, "s0-gitlab-runner-project-42"},
+	{&testGitLabRunnerMetadata[2], 1, "s1-gitlab-runner-group-my-group-sub"},
+}
+
+func TestGitLabRunnerParseMetadata(t *testing.T) {
+	for _, testData := range testGitLabRunnerMetadata {
+		_, err := parseGitLabRunnerMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams, ResolvedEnv: testData.resolvedEnv})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestGitLabRunnerGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range gitLabRunnerMetricIdentifiers {
+		meta, err := parseGitLabRunnerMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ResolvedEnv: testData.metadataTestData.resolvedEnv, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockGitLabRunnerScaler := gitLabRunnerScaler{
+			metadata: meta,
+			logger:   logr.Discard(),
+		}
+
+		metricSpec := mockGitLabRunnerScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestGitLabRunnerGetPendingJobs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "glpat-sample" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/api/v4/groups/7/projects":
+			_, _ = w.Write([]byte(`[{"id":1},{"id":2}]`))
+		case r.URL.Path == "/api/v4/projects/1/jobs" && r.URL.Query().Get("page") == "1":
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = w.Write([]byte(`[{"id":10,"tag_list":[]},{"id":11,"tag_list":["docker"]}]`))
+		case r.URL.Path == "/api/v4/projects/1/jobs" && r.URL.Query().Get("page") == "2":
+			_, _ = w.Write([]byte(`[{"id":12,"tag_list":["docker","linux"]},{"id":13,"tag_list":["windows"]}]`))
+		case r.URL.Path == "/api/v4/projects/2/jobs":
+			_, _ = w.Write([]byte(`[{"id":20,"tag_list":["linux"]}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		metadata map[string]string
+		expected int64
+		isError  bool
+	}{
+		// runners without tags only pick up untagged jobs
+		{map[string]string{"projectID": "1"}, 1, false},
+		{map[string]string{"projectID": "1", "tags": "docker,linux"}, 2, false},
+		{map[string]string{"projectID": "1", "tags": "docker,linux", "runUntagged": "true"}, 3, false},
+		{map[string]string{"groupID": "7", "tags": "docker,linux"}, 3, false},
+		{map[string]string{"projectID": "3"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		tc.metadata["gitlabAPIURL"] = server.URL
+		meta, err := parseGitLabRunnerMetadata(&ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"personalAccessToken": "glpat-sample"}})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := gitLabRunnerScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+		pendingJobs, err := scaler.getPendingJobs(context.Background())
+		if err != nil && !tc.isError {
+			t.Errorf("Expected success for %v but got error %s", tc.metadata, err)
+		}
+		if tc.isError && err == nil {
+			t.Errorf("Expected error for %v but got success", tc.metadata)
+		}
+		if pendingJobs != tc.expected {
+			t.Errorf("Expected %d pending jobs for %v but got %d", tc.expected, tc.metadata, pendingJobs)
+		}
+	}
+}
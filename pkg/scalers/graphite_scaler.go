@@ -42,12 +42,21 @@ type graphiteMetadata struct {
 	threshold           float64
 	activationThreshold float64
 	from                string
+	unsafeSsl           bool
 
 	// basic auth
 	enableBasicAuth bool
 	username        string
 	password        string // +optional
-	scalerIndex     int
+
+	// bearer auth
+	enableBearerAuth bool
+	bearerToken      string
+
+	// custom CA of the server certificate
+	ca string
+
+	scalerIndex int
 }
 
 type grapQueryResult []struct {
@@ -68,7 +77,10 @@ func NewGraphiteScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing graphite metadata: %s", err)
 	}
 
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient, err := createTLSHTTPClient(config, meta.unsafeSsl, tlsAuthParams{ca: meta.ca})
+	if err != nil {
+		return nil, fmt.Errorf("error creating the graphite TLS config: %s", err)
+	}
 
 	return &graphiteScaler{
 		metricType: metricType,
@@ -125,26 +137,43 @@ func parseGraphiteMetadata(config *ScalerConfig) (*graphiteMetadata, error) {
 		meta.activationThreshold = t
 	}
 
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.unsafeSsl = unsafeSsl
+
 	meta.scalerIndex = config.ScalerIndex
 
+	meta.ca = config.AuthParams["ca"]
+
 	val, ok := config.TriggerMetadata["authMode"]
 	// no authMode specified
 	if !ok {
 		return &meta, nil
 	}
-	if val != "basic" {
-		return nil, fmt.Errorf("authMode must be 'basic'")
-	}
 
-	if len(config.AuthParams["username"]) == 0 {
-		return nil, fmt.Errorf("no username given")
-	}
+	switch val {
+	case "basic":
+		if len(config.AuthParams["username"]) == 0 {
+			return nil, fmt.Errorf("no username given")
+		}
 
-	meta.username = config.AuthParams["username"]
-	// password is optional. For convenience, many application implement basic auth with
-	// username as apikey and password as empty
-	meta.password = config.AuthParams["password"]
-	meta.enableBasicAuth = true
+		meta.username = config.AuthParams["username"]
+		// password is optional. For convenience, many application implement basic auth with
+		// username as apikey and password as empty
+		meta.password = config.AuthParams["password"]
+		meta.enableBasicAuth = true
+	case "bearer":
+		if len(config.AuthParams["bearerToken"]) == 0 {
+			return nil, fmt.Errorf("no bearerToken given")
+		}
+
+		meta.bearerToken = config.AuthParams["bearerToken"]
+		meta.enableBearerAuth = true
+	default:
+		return nil, fmt.Errorf("authMode must be 'basic' or 'bearer'")
+	}
 
 	return &meta, nil
 }
@@ -186,6 +215,9 @@ func (s *graphiteScaler) executeGrapQuery(ctx context.Context) (float64, error)
 	if s.metadata.enableBasicAuth {
 		req.SetBasicAuth(s.metadata.username, s.metadata.password)
 	}
+	if s.metadata.enableBearerAuth {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.bearerToken))
+	}
 	r, err := s.httpClient.Do(req)
 	if err != nil {
 		return -1, err
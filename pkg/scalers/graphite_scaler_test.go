@@ -57,6 +57,10 @@ var testGraphiteAuthMetadata = []graphiteAuthMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:81", "metricName": "request-count", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": "-30Seconds", "authMode": "basic"}, map[string]string{}, true},
 	// fail if using non-basicAuth authMode
 	{map[string]string{"serverAddress": "http://localhost:81", "metricName": "request-count", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": "-30Seconds", "authMode": "tls"}, map[string]string{"username": "user"}, true},
+	// success bearerAuth with a custom CA
+	{map[string]string{"serverAddress": "https://localhost:81", "metricName": "request-count", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": "-30Seconds", "authMode": "bearer"}, map[string]string{"bearerToken": "token", "ca": "caaa"}, false},
+	// fail bearerAuth with no token
+	{map[string]string{"serverAddress": "http://localhost:81", "metricName": "request-count", "threshold": "100", "query": "stats.counters.http.hello-world.request.count.count", "queryTime": "-30Seconds", "authMode": "bearer"}, map[string]string{"username": "user"}, true},
 }
 
 type grapQueryResultTestData struct {
@@ -158,6 +162,12 @@ func TestGraphiteScalerAuthParams(t *testing.T) {
 			if meta.enableBasicAuth && !strings.Contains(testData.metadata["authMode"], "basic") {
 				t.Error("wrong auth mode detected")
 			}
+			if meta.enableBearerAuth && !strings.Contains(testData.metadata["authMode"], "bearer") {
+				t.Error("wrong auth mode detected")
+			}
+			if meta.ca != testData.authParams["ca"] {
+				t.Errorf("Expected ca %s but got %s", testData.authParams["ca"], meta.ca)
+			}
 		}
 	}
 }
@@ -192,3 +202,30 @@ func TestGrapScalerExecuteGrapQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestGraphiteScalerBearerAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("Authorization") != "Bearer token" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if _, err := writer.Write([]byte(`[{"target":"sumSeries(metric)","datapoints":[[4,1656000000]]}]`)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	scaler := graphiteScaler{
+		metadata: &graphiteMetadata{
+			serverAddress:    server.URL,
+			enableBearerAuth: true,
+			bearerToken:      "token",
+		},
+		httpClient: http.DefaultClient,
+	}
+
+	value, err := scaler.executeGrapQuery(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(4), value)
+}
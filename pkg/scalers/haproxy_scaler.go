@@ -0,0 +1,318 @@
+package scalers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	haproxyMetricType     = "External"
+	defaultHAProxyTimeout = 3 * time.Second
+	// haproxyBackendServer is the server name of the row with the totals of a backend
+	haproxyBackendServer = "BACKEND"
+)
+
+type haproxyMetric string
+
+const (
+	// haproxyMetricQueue scales on the requests queued without server
+	haproxyMetricQueue haproxyMetric = "qcur"
+	// haproxyMetricSessions scales on the current sessions
+	haproxyMetricSessions haproxyMetric = "scur"
+	// haproxyMetricSessionRate scales on the sessions per second over the last second
+	haproxyMetricSessionRate haproxyMetric = "rate"
+)
+
+type haproxyScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *haproxyMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type haproxyMetadata struct {
+	statsURL              string
+	socketAddress         string
+	username              string
+	password              string
+	backend               string
+	server                string
+	metric                haproxyMetric
+	targetValue           float64
+	activationTargetValue float64
+	timeout               time.Duration
+	unsafeSsl             bool
+	scalerIndex           int
+}
+
+// NewHAProxyScaler creates a new haproxyScaler
+func NewHAProxyScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseHAProxyMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing haproxy metadata: %s", err)
+	}
+
+	return &haproxyScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: createHTTPClient(config, meta.unsafeSsl),
+		logger:     InitializeLogger(config, "haproxy_scaler"),
+	}, nil
+}
+
+func parseHAProxyMetadata(config *ScalerConfig) (*haproxyMetadata, error) {
+	meta := haproxyMetadata{}
+	var err error
+
+	// the stats are read from the CSV export of the stats page, or with show stat on a stats socket
+	meta.statsURL, _ = GetFromAuthOrMeta(config, "statsURL")
+	meta.socketAddress, _ = GetFromAuthOrMeta(config, "socketAddress")
+	switch {
+	case meta.statsURL == "" && meta.socketAddress == "":
+		return nil, fmt.Errorf("no statsURL or socketAddress given")
+	case meta.statsURL != "" && meta.socketAddress != "":
+		return nil, fmt.Errorf("statsURL and socketAddress can't be given both")
+	case meta.socketAddress != "" && !strings.HasPrefix(meta.socketAddress, "/"):
+		if _, _, err := net.SplitHostPort(meta.socketAddress); err != nil {
+			return nil, fmt.Errorf("socketAddress must be given as host:port or as the path of a unix socket: %s", err)
+		}
+	}
+
+	// basic auth of the stats page, password is only read from the authentication
+	meta.username, _ = GetFromAuthOrMeta(config, "username")
+	meta.password = config.AuthParams["password"]
+	if meta.password != "" && meta.username == "" {
+		return nil, fmt.Errorf("no username given")
+	}
+	if meta.username != "" && meta.statsURL == "" {
+		return nil, fmt.Errorf("username is only supported with statsURL")
+	}
+
+	meta.backend = config.TriggerMetadata["backend"]
+	if meta.backend == "" {
+		return nil, fmt.Errorf("no backend given")
+	}
+	meta.server = haproxyBackendServer
+	if val, ok := config.TriggerMetadata["server"]; ok && val != "" {
+		meta.server = val
+	}
+
+	meta.metric = haproxyMetricQueue
+	if val, ok := config.TriggerMetadata["metric"]; ok && val != "" {
+		meta.metric = haproxyMetric(val)
+	}
+	switch meta.metric {
+	case haproxyMetricQueue, haproxyMetricSessions, haproxyMetricSessionRate:
+	default:
+		return nil, fmt.Errorf("metric must be one of %s, %s, %s but is %s", haproxyMetricQueue, haproxyMetricSessions, haproxyMetricSessionRate, meta.metric)
+	}
+
+	val, err := getParameterFromConfig(config, "targetValue", false)
+	if err != nil {
+		return nil, err
+	}
+	meta.targetValue, err = strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing targetValue: %s", err)
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok && val != "" {
+		meta.activationTargetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetValue: %s", err)
+		}
+	}
+
+	meta.timeout = defaultHAProxyTimeout
+	if config.GlobalHTTPTimeout > 0 {
+		meta.timeout = config.GlobalHTTPTimeout
+	}
+
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// getStatsFromURL downloads the stats CSV, which the stats page exports with the ;csv suffix
+func (s *haproxyScaler) getStatsFromURL(ctx context.Context) ([]byte, error) {
+	statsURL := s.metadata.statsURL
+	if !strings.HasSuffix(statsURL, ";csv") {
+		statsURL += ";csv"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, statsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.metadata.username != "" {
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("haproxy stats returned %d", resp.StatusCode)
+	}
+	return body, nil
+}
+
+// getStatsFromSocket runs show stat on the stats socket, which closes the connection after the answer
+func (s *haproxyScaler) getStatsFromSocket(ctx context.Context) ([]byte, error) {
+	network := "tcp"
+	if strings.HasPrefix(s.metadata.socketAddress, "/") {
+		network = "unix"
+	}
+	dialer := &net.Dialer{Timeout: s.metadata.timeout}
+	conn, err := dialer.DialContext(ctx, network, s.metadata.socketAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.metadata.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(conn, "show stat\n"); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(conn)
+}
+
+// getHAProxyStat returns the value of the stat in the row of the proxy and server, empty values being 0
+func getHAProxyStat(stats []byte, backend, server string, metric haproxyMetric) (float64, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(bytes.TrimSpace(stats), []byte("# "))))
+	// the number of fields grows with the HAProxy versions, and the rows may end with a separator
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, fmt.Errorf("error parsing haproxy stats: %s", err)
+	}
+	column := -1
+	for i, name := range header {
+		if name == string(metric) {
+			column = i
+			break
+		}
+	}
+	if column == -1 || len(header) < 2 || header[0] != "pxname" || header[1] != "svname" {
+		return 0, fmt.Errorf("haproxy stats have no %s column", metric)
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("error parsing haproxy stats: %s", err)
+		}
+		if len(record) <= column || record[0] != backend || record[1] != server {
+			continue
+		}
+		if record[column] == "" {
+			return 0, nil
+		}
+		value, err := strconv.ParseFloat(record[column], 64)
+		if err != nil {
+			return 0, fmt.Errorf("error parsing %s of %s/%s: %s", metric, backend, server, err)
+		}
+		return value, nil
+	}
+	return 0, fmt.Errorf("haproxy stats have no %s/%s", backend, server)
+}
+
+func (s *haproxyScaler) getMetricValue(ctx context.Context) (float64, error) {
+	var stats []byte
+	var err error
+	if s.metadata.socketAddress != "" {
+		stats, err = s.getStatsFromSocket(ctx)
+	} else {
+		stats, err = s.getStatsFromURL(ctx)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return getHAProxyStat(stats, s.metadata.backend, s.metadata.server, s.metadata.metric)
+}
+
+func (s *haproxyScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting haproxy stats")
+		return false, err
+	}
+
+	return value > s.metadata.activationTargetValue, nil
+}
+
+func (s *haproxyScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *haproxyScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	metricName := fmt.Sprintf("haproxy-%s-%s", s.metadata.backend, s.metadata.metric)
+	if s.metadata.server != haproxyBackendServer {
+		metricName = fmt.Sprintf("haproxy-%s-%s-%s", s.metadata.backend, s.metadata.server, s.metadata.metric)
+	}
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: haproxyMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *haproxyScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting haproxy stats")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
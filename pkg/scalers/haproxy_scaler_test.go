@@ -0,0 +1,189 @@
+package scalers
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+type parseHAProxyMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type haproxyMetricIdentifier struct {
+	metadataTestData *parseHAProxyMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testHAProxyMetadata = []parseHAProxyMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed stats url
+	{map[string]string{"statsURL": "http://haproxy:8404/stats", "backend": "web", "targetValue": "10"}, map[string]string{}, false},
+	// properly formed stats socket with server and session rate
+	{map[string]string{"socketAddress": "haproxy:9999", "backend": "web", "server": "web1", "metric": "rate", "targetValue": "100", "activationTargetValue": "1"}, map[string]string{}, false},
+	// unix stats socket
+	{map[string]string{"socketAddress": "/var/run/haproxy.sock", "backend": "web", "metric": "scur", "targetValue": "10"}, map[string]string{}, false},
+	// stats url with basic auth
+	{map[string]string{"statsURL": "http://haproxy:8404/stats", "backend": "web", "targetValue": "10"}, map[string]string{"username": "admin", "password": "secret"}, false},
+	// both stats url and socket
+	{map[string]string{"statsURL": "http://haproxy:8404/stats", "socketAddress": "haproxy:9999", "backend": "web", "targetValue": "10"}, map[string]string{}, true},
+	// invalid socket address
+	{map[string]string{"socketAddress": "haproxy", "backend": "web", "targetValue": "10"}, map[string]string{}, true},
+	// password without username
+	{map[string]string{"statsURL": "http://haproxy:8404/stats", "backend": "web", "targetValue": "10"}, map[string]string{"password": "secret"}, true},
+	// username with socket
+	{map[string]string{"socketAddress": "haproxy:9999", "backend": "web", "targetValue": "10"}, map[string]string{"username": "admin", "password": "secret"}, true},
+	// missing backend
+	{map[string]string{"statsURL": "http://haproxy:8404/stats", "targetValue": "10"}, map[string]string{}, true},
+	// invalid metric
+	{map[string]string{"statsURL": "http://haproxy:8404/stats", "backend": "web", "metric": "qmax", "targetValue": "10"}, map[string]string{}, true},
+	// missing targetValue
+	{map[string]string{"statsURL": "http://haproxy:8404/stats", "backend": "web"}, map[string]string{}, true},
+	// invalid targetValue
+	{map[string]string{"statsURL": "http://haproxy:8404/stats", "backend": "web", "targetValue": "A"}, map[string]string{}, true},
+	// invalid activationTargetValue
+	{map[string]string{"statsURL": "http://haproxy:8404/stats", "backend": "web", "targetValue": "10", "activationTargetValue": "A"}, map[string]string{}, true},
+	// invalid unsafeSsl
+	{map[string]string{"statsURL": "https://haproxy:8404/stats", "backend": "web", "targetValue": "10", "unsafeSsl": "A"}, map[string]string{}, true},
+}
+
+var haproxyMetricIdentifiers = []haproxyMetricIdentifier{
+	{&testHAProxyMetadata[1], 0, "s0-haproxy-web-qcur"},
+	{&testHAProxyMetadata[2], 1, "s1-haproxy-web-web1-rate"},
+}
+
+const testHAProxyStats = `# pxname,svname,qcur,qmax,scur,smax,slim,stot,bin,bout,dreq,dresp,ereq,econ,eresp,wretr,wredis,status,weight,act,bck,chkfail,chkdown,lastchg,downtime,qlimit,pid,iid,sid,throttle,lbtot,tracked,type,rate,rate_lim,rate_max,
+stats,FRONTEND,,,1,2,2000,10,0,0,0,0,0,,,,,OPEN,,,,,,,,,1,1,0,,,,0,1,0,2,
+web,web1,3,5,12,20,,100,0,0,,0,,0,0,0,0,UP,1,1,0,0,0,100,0,,1,2,1,,100,,2,7,,9,
+web,web2,,,8,15,,80,0,0,,0,,0,0,0,0,UP,1,1,0,0,0,100,0,,1,2,2,,80,,2,5,,8,
+web,BACKEND,17,25,20,35,200,180,0,0,0,0,,0,0,0,0,UP,2,2,0,,0,100,0,,1,2,0,,180,,1,12,,17,
+`
+
+func TestHAProxyParseMetadata(t *testing.T) {
+	for _, testData := range testHAProxyMetadata {
+		_, err := parseHAProxyMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success. testData: %v", testData)
+		}
+	}
+}
+
+func TestHAProxyGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range haproxyMetricIdentifiers {
+		meta, err := parseHAProxyMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
+			AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockHAProxyScaler := haproxyScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockHAProxyScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestHAProxyStat(t *testing.T) {
+	testCases := []struct {
+		backend       string
+		server        string
+		metric        haproxyMetric
+		expectedValue float64
+		isError       bool
+	}{
+		{"web", haproxyBackendServer, haproxyMetricQueue, 17, false},
+		{"web", haproxyBackendServer, haproxyMetricSessions, 20, false},
+		{"web", haproxyBackendServer, haproxyMetricSessionRate, 12, false},
+		{"web", "web1", haproxyMetricQueue, 3, false},
+		{"web", "web2", haproxyMetricQueue, 0, false},
+		{"api", haproxyBackendServer, haproxyMetricQueue, 0, true},
+	}
+
+	for _, testCase := range testCases {
+		value, err := getHAProxyStat([]byte(testHAProxyStats), testCase.backend, testCase.server, testCase.metric)
+		if testCase.isError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedValue, value)
+		}
+	}
+}
+
+func TestHAProxyGetMetricValueFromURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		username, password, ok := request.BasicAuth()
+		if request.URL.Path != "/stats;csv" || !ok || username != "admin" || password != "secret" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if _, err := writer.Write([]byte(testHAProxyStats)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	meta, err := parseHAProxyMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"statsURL": server.URL + "/stats", "backend": "web", "targetValue": "10"},
+		AuthParams:      map[string]string{"username": "admin", "password": "secret"},
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := haproxyScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+	value, err := scaler.getMetricValue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(17), value)
+}
+
+func TestHAProxyGetMetricValueFromSocket(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		command := make([]byte, len("show stat\n"))
+		if _, err := io.ReadFull(conn, command); err != nil || string(command) != "show stat\n" {
+			return
+		}
+		_, _ = io.WriteString(conn, strings.TrimPrefix(testHAProxyStats, "# "))
+	}()
+
+	meta, err := parseHAProxyMetadata(&ScalerConfig{
+		TriggerMetadata:   map[string]string{"socketAddress": listener.Addr().String(), "backend": "web", "server": "web1", "metric": "scur", "targetValue": "10"},
+		GlobalHTTPTimeout: time.Second,
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := haproxyScaler{metadata: meta, logger: logr.Discard()}
+
+	value, err := scaler.getMetricValue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(12), value)
+}
@@ -3,14 +3,13 @@ package scalers
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/go-logr/logr"
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
@@ -23,28 +22,31 @@ import (
 // Default variables and settings
 const (
 	defaultTargetQueueDepth = 20
-	defaultTLSDisabled      = false
 )
 
 // IBMMQScaler assigns struct data pointer to metadata variable
 type IBMMQScaler struct {
-	metricType         v2beta2.MetricTargetType
-	metadata           *IBMMQMetadata
-	defaultHTTPTimeout time.Duration
-	logger             logr.Logger
+	metricType v2beta2.MetricTargetType
+	metadata   *IBMMQMetadata
+	httpClient *http.Client
+	logger     logr.Logger
 }
 
 // IBMMQMetadata Metadata used by KEDA to query IBM MQ queue depth and scale
 type IBMMQMetadata struct {
 	host                 string
 	queueManager         string
-	queueName            string
+	queueNames           []string
+	operation            string
 	username             string
 	password             string
-	queueDepth           int64
+	queueDepth           float64
 	activationQueueDepth int64
-	tlsDisabled          bool
+	unsafeSsl            bool
 	scalerIndex          int
+
+	// TLS of the REST admin endpoint
+	tlsAuthParams
 }
 
 // CommandResponse Full structured response from MQ admin REST query
@@ -74,11 +76,16 @@ func NewIBMMQScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing IBM MQ metadata: %s", err)
 	}
 
+	httpClient, err := createTLSHTTPClient(config, meta.unsafeSsl, meta.tlsAuthParams)
+	if err != nil {
+		return nil, fmt.Errorf("error creating IBM MQ tls config: %s", err)
+	}
+
 	return &IBMMQScaler{
-		metricType:         metricType,
-		metadata:           meta,
-		defaultHTTPTimeout: config.GlobalHTTPTimeout,
-		logger:             InitializeLogger(config, "ibm_mq_scaler"),
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "ibm_mq_scaler"),
 	}, nil
 }
 
@@ -107,16 +114,30 @@ func parseIBMMQMetadata(config *ScalerConfig) (*IBMMQMetadata, error) {
 		return nil, fmt.Errorf("no queue manager given")
 	}
 
+	// queueName is a comma separated list, the depths of the queues are aggregated with the operation
 	if val, ok := config.TriggerMetadata["queueName"]; ok {
-		meta.queueName = val
-	} else {
+		for _, queueName := range strings.Split(val, ",") {
+			if queueName = strings.TrimSpace(queueName); queueName != "" {
+				meta.queueNames = append(meta.queueNames, queueName)
+			}
+		}
+	}
+	if len(meta.queueNames) == 0 {
 		return nil, fmt.Errorf("no queue name given")
 	}
 
+	meta.operation = sumOperation
+	if val, ok := config.TriggerMetadata["operation"]; ok && val != "" {
+		if val != sumOperation && val != avgOperation && val != maxOperation {
+			return nil, fmt.Errorf("operation mode %s must be one of %s, %s, %s", val, sumOperation, avgOperation, maxOperation)
+		}
+		meta.operation = val
+	}
+
 	if val, ok := config.TriggerMetadata["queueDepth"]; ok && val != "" {
-		queueDepth, err := strconv.ParseInt(val, 10, 64)
+		queueDepth, err := strconv.ParseFloat(val, 64)
 		if err != nil {
-			return nil, fmt.Errorf("invalid queueDepth - must be an integer")
+			return nil, fmt.Errorf("invalid queueDepth - must be a number")
 		}
 		meta.queueDepth = queueDepth
 	} else {
@@ -133,15 +154,19 @@ func parseIBMMQMetadata(config *ScalerConfig) (*IBMMQMetadata, error) {
 		meta.activationQueueDepth = activationQueueDepth
 	}
 
-	if val, ok := config.TriggerMetadata["tls"]; ok {
-		tlsDisabled, err := strconv.ParseBool(val)
+	// tls is the deprecated name of unsafeSsl
+	if val, ok := config.TriggerMetadata["tls"]; ok && config.TriggerMetadata[UnsafeSslKey] == "" {
+		unsafeSsl, err := strconv.ParseBool(val)
 		if err != nil {
 			return nil, fmt.Errorf("invalid tls setting: %s", err)
 		}
-		meta.tlsDisabled = tlsDisabled
+		meta.unsafeSsl = unsafeSsl
 	} else {
-		fmt.Println("No tls setting defined - setting default")
-		meta.tlsDisabled = defaultTLSDisabled
+		unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+		if err != nil {
+			return nil, err
+		}
+		meta.unsafeSsl = unsafeSsl
 	}
 	val, ok := config.AuthParams["username"]
 	switch {
@@ -161,22 +186,51 @@ func parseIBMMQMetadata(config *ScalerConfig) (*IBMMQMetadata, error) {
 	default:
 		return nil, fmt.Errorf("no password given")
 	}
+
+	tlsParams, err := parseTLSAuthParams(config)
+	if err != nil {
+		return nil, err
+	}
+	meta.tlsAuthParams = tlsParams
 	meta.scalerIndex = config.ScalerIndex
 	return &meta, nil
 }
 
 // IsActive returns true if there are messages to be processed/if we need to scale from zero
 func (s *IBMMQScaler) IsActive(ctx context.Context) (bool, error) {
-	queueDepth, err := s.getQueueDepthViaHTTP(ctx)
+	queueDepth, err := s.getQueueDepth(ctx)
 	if err != nil {
 		return false, fmt.Errorf("error inspecting IBM MQ queue depth: %s", err)
 	}
-	return queueDepth > s.metadata.activationQueueDepth, nil
+	return queueDepth > float64(s.metadata.activationQueueDepth), nil
+}
+
+// getQueueDepth returns the depth of the queues aggregated with the operation
+func (s *IBMMQScaler) getQueueDepth(ctx context.Context) (float64, error) {
+	var sum, max float64
+	for _, queue := range s.metadata.queueNames {
+		depth, err := s.getQueueDepthViaHTTP(ctx, queue)
+		if err != nil {
+			return 0, fmt.Errorf("queue %s: %s", queue, err)
+		}
+		sum += float64(depth)
+		if float64(depth) > max {
+			max = float64(depth)
+		}
+	}
+
+	switch s.metadata.operation {
+	case maxOperation:
+		return max, nil
+	case avgOperation:
+		return sum / float64(len(s.metadata.queueNames)), nil
+	default:
+		return sum, nil
+	}
 }
 
 // getQueueDepthViaHTTP returns the depth of the MQ Queue from the Admin endpoint
-func (s *IBMMQScaler) getQueueDepthViaHTTP(ctx context.Context) (int64, error) {
-	queue := s.metadata.queueName
+func (s *IBMMQScaler) getQueueDepthViaHTTP(ctx context.Context, queue string) (int64, error) {
 	url := s.metadata.host
 
 	var requestJSON = []byte(`{"type": "runCommandJSON", "command": "display", "qualifier": "qlocal", "name": "` + queue + `", "responseParameters" : ["CURDEPTH"]}`)
@@ -188,13 +242,7 @@ func (s *IBMMQScaler) getQueueDepthViaHTTP(ctx context.Context) (int64, error) {
 	req.Header.Set("Content-Type", "application/json")
 	req.SetBasicAuth(s.metadata.username, s.metadata.password)
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: s.metadata.tlsDisabled},
-	}
-	client := kedautil.CreateHTTPClient(s.defaultHTTPTimeout, false)
-	client.Transport = tr
-
-	resp, err := client.Do(req)
+	resp, err := s.httpClient.Do(req)
 	if err != nil {
 		return 0, fmt.Errorf("failed to contact MQ via REST: %s", err)
 	}
@@ -221,9 +269,9 @@ func (s *IBMMQScaler) getQueueDepthViaHTTP(ctx context.Context) (int64, error) {
 func (s *IBMMQScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("ibmmq-%s", s.metadata.queueName))),
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("ibmmq-%s", strings.Join(s.metadata.queueNames, "-")))),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.queueDepth),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.queueDepth),
 	}
 	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta2.MetricSpec{metricSpec}
@@ -231,12 +279,12 @@ func (s *IBMMQScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricS
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *IBMMQScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	queueDepth, err := s.getQueueDepthViaHTTP(ctx)
+	queueDepth, err := s.getQueueDepth(ctx)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, fmt.Errorf("error inspecting IBM MQ queue depth: %s", err)
 	}
 
-	metric := GenerateMetricInMili(metricName, float64(queueDepth))
+	metric := GenerateMetricInMili(metricName, queueDepth)
 
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
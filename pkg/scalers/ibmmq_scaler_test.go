@@ -2,9 +2,13 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
-	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 // Test host URLs for validation
@@ -36,6 +40,7 @@ type IBMMQMetricIdentifier struct {
 var IBMMQMetricIdentifiers = []IBMMQMetricIdentifier{
 	{&testIBMMQMetadata[1], 0, "s0-ibmmq-testQueue"},
 	{&testIBMMQMetadata[1], 1, "s1-ibmmq-testQueue"},
+	{&testIBMMQMetadata[11], 2, "s2-ibmmq-testQueue1-testQueue2"},
 }
 
 // Test cases for TestIBMMQParseMetadata test
@@ -62,6 +67,20 @@ var testIBMMQMetadata = []parseIBMMQMetadataTestData{
 	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "queueDepth": "10"}, true, map[string]string{"password": "Pass123"}},
 	// No password provided
 	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "queueDepth": "10"}, true, map[string]string{"username": "testUsername"}},
+	// Multiple queues with max operation
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue1, testQueue2", "operation": "max", "queueDepth": "10"}, false, map[string]string{"username": "testUsername", "password": "Pass123"}},
+	// Empty queue list
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": " , ", "queueDepth": "10"}, true, map[string]string{"username": "testUsername", "password": "Pass123"}},
+	// Invalid operation
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue1,testQueue2", "operation": "min", "queueDepth": "10"}, true, map[string]string{"username": "testUsername", "password": "Pass123"}},
+	// TLS with ca only
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "queueDepth": "10"}, false, map[string]string{"username": "testUsername", "password": "Pass123", "tls": "enable", "ca": "caaa"}},
+	// TLS with cert and key
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "queueDepth": "10"}, false, map[string]string{"username": "testUsername", "password": "Pass123", "tls": "enable", "cert": "ceert", "key": "keey"}},
+	// TLS with cert but no key
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "queueDepth": "10"}, true, map[string]string{"username": "testUsername", "password": "Pass123", "tls": "enable", "cert": "ceert"}},
+	// Invalid TLS value
+	{map[string]string{"host": testValidMQQueueURL, "queueManager": "testQueueManager", "queueName": "testQueue", "queueDepth": "10"}, true, map[string]string{"username": "testUsername", "password": "Pass123", "tls": "yes"}},
 }
 
 // Test MQ Connection metadata is parsed correctly
@@ -109,14 +128,11 @@ func TestParseDefaultQueueDepth(t *testing.T) {
 func TestIBMMQGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range IBMMQMetricIdentifiers {
 		metadata, err := parseIBMMQMetadata(&ScalerConfig{ResolvedEnv: sampleIBMMQResolvedEnv, TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
-		httpTimeout := 100 * time.Millisecond
-
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
 		mockIBMMQScaler := IBMMQScaler{
-			metadata:           metadata,
-			defaultHTTPTimeout: httpTimeout,
+			metadata: metadata,
 		}
 		metricSpec := mockIBMMQScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -126,3 +142,44 @@ func TestIBMMQGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+// Test that the depths of multiple queues are aggregated with the operation
+func TestIBMMQGetQueueDepth(t *testing.T) {
+	depths := map[string]int{"testQueue1": 4, "testQueue2": 10, "testQueue3": 1}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var command struct {
+			Name string `json:"name"`
+		}
+		if err := json.NewDecoder(request.Body).Decode(&command); err != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(writer, `{"commandResponse": [{"completionCode": 0, "parameters": {"curdepth": %d}}]}`, depths[command.Name])
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		operation     string
+		expectedDepth float64
+	}{
+		{"", 15},
+		{"sum", 15},
+		{"max", 10},
+		{"avg", 5},
+	}
+
+	for _, testCase := range testCases {
+		metadata, err := parseIBMMQMetadata(&ScalerConfig{
+			TriggerMetadata: map[string]string{"host": server.URL, "queueManager": "testQueueManager", "queueName": "testQueue1,testQueue2,testQueue3", "operation": testCase.operation},
+			AuthParams:      map[string]string{"username": "testUsername", "password": "Pass123"},
+		})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := IBMMQScaler{metadata: metadata, httpClient: http.DefaultClient}
+
+		depth, err := scaler.getQueueDepth(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedDepth, depth, testCase.operation)
+	}
+}
@@ -3,8 +3,12 @@ package scalers
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
@@ -16,18 +20,31 @@ import (
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
+const (
+	influxDBFluxQueryLanguage     = "flux"
+	influxDBInfluxQLQueryLanguage = "influxql"
+)
+
 type influxDBScaler struct {
 	client     influxdb2.Client
 	metricType v2beta2.MetricTargetType
 	metadata   *influxDBMetadata
 	logger     logr.Logger
+	// httpClient queries the InfluxQL endpoint of InfluxDB 1.x, the client is only used for Flux
+	httpClient *http.Client
 }
 
 type influxDBMetadata struct {
-	authToken                string
-	metricName               string
-	organizationName         string
-	query                    string
+	authToken        string
+	metricName       string
+	organizationName string
+	query            string
+	queryLanguage    string
+	// database, retentionPolicy, username and password are only used by InfluxQL
+	database                 string
+	retentionPolicy          string
+	username                 string
+	password                 string
 	serverURL                string
 	unsafeSsl                bool
 	thresholdValue           float64
@@ -49,6 +66,15 @@ func NewInfluxDBScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing influxdb metadata: %s", err)
 	}
 
+	if meta.queryLanguage == influxDBInfluxQLQueryLanguage {
+		return &influxDBScaler{
+			metricType: metricType,
+			metadata:   meta,
+			logger:     logger,
+			httpClient: createHTTPClient(config, meta.unsafeSsl),
+		}, nil
+	}
+
 	logger.Info("starting up influxdb client")
 	client := influxdb2.NewClientWithOptions(
 		meta.serverURL,
@@ -73,6 +99,12 @@ func parseInfluxDBMetadata(config *ScalerConfig) (*influxDBMetadata, error) {
 	var unsafeSsl bool
 	var thresholdValue float64
 	var activationThresholdValue float64
+	var database, retentionPolicy, username, password string
+
+	queryLanguage := influxDBFluxQueryLanguage
+	if val, ok := config.TriggerMetadata["queryLanguage"]; ok && val != "" {
+		queryLanguage = strings.ToLower(val)
+	}
 
 	val, ok := config.TriggerMetadata["authToken"]
 	switch {
@@ -86,24 +118,53 @@ func parseInfluxDBMetadata(config *ScalerConfig) (*influxDBMetadata, error) {
 		}
 	case config.AuthParams["authToken"] != "":
 		authToken = config.AuthParams["authToken"]
-	default:
+	case queryLanguage == influxDBFluxQueryLanguage:
 		return nil, fmt.Errorf("no auth token given")
 	}
 
-	val, ok = config.TriggerMetadata["organizationName"]
-	switch {
-	case ok && val != "":
-		organizationName = val
-	case config.TriggerMetadata["organizationNameFromEnv"] != "":
-		if val, ok := config.ResolvedEnv[config.TriggerMetadata["organizationNameFromEnv"]]; ok {
+	switch queryLanguage {
+	case influxDBFluxQueryLanguage:
+		val, ok = config.TriggerMetadata["organizationName"]
+		switch {
+		case ok && val != "":
 			organizationName = val
-		} else {
+		case config.TriggerMetadata["organizationNameFromEnv"] != "":
+			if val, ok := config.ResolvedEnv[config.TriggerMetadata["organizationNameFromEnv"]]; ok {
+				organizationName = val
+			} else {
+				return nil, fmt.Errorf("no organization name given")
+			}
+		case config.AuthParams["organizationName"] != "":
+			organizationName = config.AuthParams["organizationName"]
+		default:
 			return nil, fmt.Errorf("no organization name given")
 		}
-	case config.AuthParams["organizationName"] != "":
-		organizationName = config.AuthParams["organizationName"]
+	case influxDBInfluxQLQueryLanguage:
+		var err error
+		database, err = GetFromAuthOrMeta(config, "database")
+		if err != nil {
+			return nil, err
+		}
+		retentionPolicy = config.TriggerMetadata["retentionPolicy"]
+
+		// InfluxDB 1.x authenticates with a username and password, or a token since 1.8
+		username, _ = GetFromAuthOrMeta(config, "username")
+		if username != "" {
+			switch {
+			case config.AuthParams["password"] != "":
+				password = config.AuthParams["password"]
+			case config.TriggerMetadata["passwordFromEnv"] != "":
+				password = config.ResolvedEnv[config.TriggerMetadata["passwordFromEnv"]]
+			}
+			if password == "" {
+				return nil, fmt.Errorf("no password given for username %s", username)
+			}
+			if authToken != "" {
+				return nil, fmt.Errorf("authToken and username can't be used together")
+			}
+		}
 	default:
-		return nil, fmt.Errorf("no organization name given")
+		return nil, fmt.Errorf("unsupported queryLanguage %s, must be %s or %s", queryLanguage, influxDBFluxQueryLanguage, influxDBInfluxQLQueryLanguage)
 	}
 
 	if val, ok := config.TriggerMetadata["query"]; ok {
@@ -122,6 +183,8 @@ func parseInfluxDBMetadata(config *ScalerConfig) (*influxDBMetadata, error) {
 
 	if val, ok := config.TriggerMetadata["metricName"]; ok {
 		metricName = kedautil.NormalizeString(fmt.Sprintf("influxdb-%s", val))
+	} else if queryLanguage == influxDBInfluxQLQueryLanguage {
+		metricName = kedautil.NormalizeString(fmt.Sprintf("influxdb-%s", database))
 	} else {
 		metricName = kedautil.NormalizeString(fmt.Sprintf("influxdb-%s", organizationName))
 	}
@@ -143,13 +206,9 @@ func parseInfluxDBMetadata(config *ScalerConfig) (*influxDBMetadata, error) {
 	} else {
 		return nil, fmt.Errorf("no threshold value given")
 	}
-	unsafeSsl = false
-	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok {
-		parsedVal, err := strconv.ParseBool(val)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing unsafeSsl: %s", err)
-		}
-		unsafeSsl = parsedVal
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
 	}
 
 	return &influxDBMetadata{
@@ -157,6 +216,11 @@ func parseInfluxDBMetadata(config *ScalerConfig) (*influxDBMetadata, error) {
 		metricName:               metricName,
 		organizationName:         organizationName,
 		query:                    query,
+		queryLanguage:            queryLanguage,
+		database:                 database,
+		retentionPolicy:          retentionPolicy,
+		username:                 username,
+		password:                 password,
 		serverURL:                serverURL,
 		thresholdValue:           thresholdValue,
 		activationThresholdValue: activationThresholdValue,
@@ -167,9 +231,7 @@ func parseInfluxDBMetadata(config *ScalerConfig) (*influxDBMetadata, error) {
 
 // IsActive returns true if queried value is above the minimum value
 func (s *influxDBScaler) IsActive(ctx context.Context) (bool, error) {
-	queryAPI := s.client.QueryAPI(s.metadata.organizationName)
-
-	value, err := queryInfluxDB(ctx, queryAPI, s.metadata.query)
+	value, err := s.getQueryResult(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -179,10 +241,22 @@ func (s *influxDBScaler) IsActive(ctx context.Context) (bool, error) {
 
 // Close closes the connection of the client to the server
 func (s *influxDBScaler) Close(context.Context) error {
-	s.client.Close()
+	if s.client != nil {
+		s.client.Close()
+	}
 	return nil
 }
 
+func (s *influxDBScaler) getQueryResult(ctx context.Context) (float64, error) {
+	if s.metadata.queryLanguage == influxDBInfluxQLQueryLanguage {
+		return s.queryInfluxQL(ctx)
+	}
+
+	// Grab QueryAPI to make queries to influxdb instance
+	queryAPI := s.client.QueryAPI(s.metadata.organizationName)
+	return queryInfluxDB(ctx, queryAPI, s.metadata.query)
+}
+
 // queryInfluxDB runs the query against the associated influxdb database
 // there is an implicit assumption here that the first value returned from the iterator
 // will be the value of interest
@@ -207,12 +281,88 @@ func queryInfluxDB(ctx context.Context, queryAPI api.QueryAPI, query string) (fl
 	}
 }
 
+// influxQLResponse is the response of the /query endpoint of InfluxDB 1.x
+type influxQLResponse struct {
+	Results []struct {
+		Series []struct {
+			Columns []string        `json:"columns"`
+			Values  [][]interface{} `json:"values"`
+		} `json:"series"`
+		Error string `json:"error"`
+	} `json:"results"`
+	Error string `json:"error"`
+}
+
+// queryInfluxQL runs the InfluxQL query against the /query endpoint of InfluxDB 1.x and returns
+// the first value which isn't the time of the first series
+func (s *influxDBScaler) queryInfluxQL(ctx context.Context) (float64, error) {
+	params := url.Values{}
+	params.Set("db", s.metadata.database)
+	params.Set("q", s.metadata.query)
+	if s.metadata.retentionPolicy != "" {
+		params.Set("rp", s.metadata.retentionPolicy)
+	}
+	queryURL := fmt.Sprintf("%s/query?%s", strings.TrimSuffix(s.metadata.serverURL, "/"), params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case s.metadata.username != "":
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	case s.metadata.authToken != "":
+		req.Header.Set("Authorization", fmt.Sprintf("Token %s", s.metadata.authToken))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var response influxQLResponse
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&response); err != nil {
+		return 0, fmt.Errorf("error decoding influxql response with status %s: %s", resp.Status, err)
+	}
+	if response.Error != "" {
+		return 0, fmt.Errorf("influxql query failed: %s", response.Error)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("influxql query failed with status %s", resp.Status)
+	}
+	if len(response.Results) == 0 {
+		return 0, fmt.Errorf("no results found from query")
+	}
+	if response.Results[0].Error != "" {
+		return 0, fmt.Errorf("influxql query failed: %s", response.Results[0].Error)
+	}
+	if len(response.Results[0].Series) == 0 || len(response.Results[0].Series[0].Values) == 0 {
+		return 0, fmt.Errorf("no results found from query")
+	}
+
+	series := response.Results[0].Series[0]
+	for i, column := range series.Columns {
+		if column == "time" || i >= len(series.Values[0]) {
+			continue
+		}
+		switch valRaw := series.Values[0][i].(type) {
+		case json.Number:
+			return valRaw.Float64()
+		case nil:
+			return 0, nil
+		default:
+			return 0, fmt.Errorf("value of type %T could not be converted into a float", valRaw)
+		}
+	}
+	return 0, fmt.Errorf("no value found in the columns %v of the query result", series.Columns)
+}
+
 // GetMetrics connects to influxdb via the client and returns a value based on the query
 func (s *influxDBScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	// Grab QueryAPI to make queries to influxdb instance
-	queryAPI := s.client.QueryAPI(s.metadata.organizationName)
-
-	value, err := queryInfluxDB(ctx, queryAPI, s.metadata.query)
+	value, err := s.getQueryResult(ctx)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, err
 	}
@@ -2,6 +2,8 @@ package scalers
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -9,8 +11,9 @@ import (
 )
 
 var testInfluxDBResolvedEnv = map[string]string{
-	"INFLUX_ORG":   "influx_org",
-	"INFLUX_TOKEN": "myToken",
+	"INFLUX_ORG":      "influx_org",
+	"INFLUX_TOKEN":    "myToken",
+	"INFLUX_PASSWORD": "myPassword",
 }
 
 type parseInfluxDBMetadataTestData struct {
@@ -48,11 +51,28 @@ var testInfluxDBMetadata = []parseInfluxDBMetadataTestData{
 	{map[string]string{"serverURL": "https://influxdata.com", "metricName": "influx_metric", "organizationName": "influx_org", "query": "from(bucket: hello)", "thresholdValue": "10", "authToken": "myToken"}, false, map[string]string{}},
 	// 11 wrong activationThreshold valuequeryInfluxDB
 	{map[string]string{"serverURL": "https://influxdata.com", "metricName": "influx_metric", "organizationName": "influx_org", "query": "from(bucket: hello)", "thresholdValue": "10", "activationThresholdValue": "aa", "authToken": "myToken", "unsafeSsl": "false"}, true, map[string]string{}},
+	// 12 influxql without authentication
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "influxql", "database": "telegraf", "query": "SELECT last(value) FROM queue", "thresholdValue": "10"}, false, map[string]string{}},
+	// 13 influxql with username and password from env
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "InfluxQL", "database": "telegraf", "username": "keda", "passwordFromEnv": "INFLUX_PASSWORD", "query": "SELECT last(value) FROM queue", "thresholdValue": "10"}, false, map[string]string{}},
+	// 14 influxql with username, password and database in authParams
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "influxql", "query": "SELECT last(value) FROM queue", "thresholdValue": "10"}, false, map[string]string{"database": "telegraf", "username": "keda", "password": "myPassword"}},
+	// 15 influxql with token
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "influxql", "database": "telegraf", "query": "SELECT last(value) FROM queue", "thresholdValue": "10", "authToken": "myToken"}, false, map[string]string{}},
+	// 16 influxql without database
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "influxql", "query": "SELECT last(value) FROM queue", "thresholdValue": "10"}, true, map[string]string{}},
+	// 17 influxql username without password
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "influxql", "database": "telegraf", "username": "keda", "query": "SELECT last(value) FROM queue", "thresholdValue": "10"}, true, map[string]string{}},
+	// 18 influxql with username and token
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "influxql", "database": "telegraf", "authToken": "myToken", "query": "SELECT last(value) FROM queue", "thresholdValue": "10"}, true, map[string]string{"username": "keda", "password": "myPassword"}},
+	// 19 unsupported query language
+	{map[string]string{"serverURL": "https://influxdata.com", "queryLanguage": "sql", "database": "telegraf", "query": "SELECT last(value) FROM queue", "thresholdValue": "10", "authToken": "myToken"}, true, map[string]string{}},
 }
 
 var influxDBMetricIdentifiers = []influxDBMetricIdentifier{
 	{&testInfluxDBMetadata[1], 0, "s0-influxdb-influx_metric"},
 	{&testInfluxDBMetadata[2], 1, "s1-influxdb-influx_org"},
+	{&testInfluxDBMetadata[11], 2, "s2-influxdb-telegraf"},
 }
 
 func TestInfluxDBParseMetadata(t *testing.T) {
@@ -75,7 +95,7 @@ func TestInfluxDBGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockInfluxDBScaler := influxDBScaler{influxdb2.NewClient("https://influxdata.com", "myToken"), "", meta, logr.Discard()}
+		mockInfluxDBScaler := influxDBScaler{influxdb2.NewClient("https://influxdata.com", "myToken"), "", meta, logr.Discard(), nil}
 
 		metricSpec := mockInfluxDBScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -84,3 +104,98 @@ func TestInfluxDBGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestInfluxDBQueryInfluxQL(t *testing.T) {
+	tests := []struct {
+		name          string
+		metadata      map[string]string
+		authParams    map[string]string
+		response      string
+		status        int
+		expectedAuth  string
+		expectedValue float64
+		isError       bool
+	}{
+		{
+			name:          "basic auth",
+			metadata:      map[string]string{"database": "telegraf", "retentionPolicy": "autogen"},
+			authParams:    map[string]string{"username": "keda", "password": "myPassword"},
+			response:      `{"results":[{"statement_id":0,"series":[{"name":"queue","columns":["time","last"],"values":[["2022-08-01T12:00:00Z",42.5]]}]}]}`,
+			status:        http.StatusOK,
+			expectedAuth:  "Basic a2VkYTpteVBhc3N3b3Jk",
+			expectedValue: 42.5,
+		},
+		{
+			name:          "token auth",
+			metadata:      map[string]string{"database": "telegraf", "authToken": "myToken"},
+			response:      `{"results":[{"statement_id":0,"series":[{"name":"queue","columns":["time","count"],"values":[[1659355200,7]]}]}]}`,
+			status:        http.StatusOK,
+			expectedAuth:  "Token myToken",
+			expectedValue: 7,
+		},
+		{
+			name:     "no series",
+			metadata: map[string]string{"database": "telegraf"},
+			response: `{"results":[{"statement_id":0}]}`,
+			status:   http.StatusOK,
+			isError:  true,
+		},
+		{
+			name:     "statement error",
+			metadata: map[string]string{"database": "telegraf"},
+			response: `{"results":[{"statement_id":0,"error":"database not found: telegraf"}]}`,
+			status:   http.StatusOK,
+			isError:  true,
+		},
+		{
+			name:     "unauthorized",
+			metadata: map[string]string{"database": "telegraf"},
+			response: `{"error":"authorization failed"}`,
+			status:   http.StatusUnauthorized,
+			isError:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/query" || r.URL.Query().Get("db") != "telegraf" || r.URL.Query().Get("q") != "SELECT last(value) FROM queue" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				if rp := test.metadata["retentionPolicy"]; r.URL.Query().Get("rp") != rp {
+					t.Errorf("expected retention policy %q but got %q", rp, r.URL.Query().Get("rp"))
+				}
+				if auth := r.Header.Get("Authorization"); auth != test.expectedAuth {
+					t.Errorf("expected authorization %q but got %q", test.expectedAuth, auth)
+				}
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(test.response))
+			}))
+			defer server.Close()
+
+			metadata := map[string]string{"serverURL": server.URL, "queryLanguage": "influxql", "query": "SELECT last(value) FROM queue", "thresholdValue": "10"}
+			for key, value := range test.metadata {
+				metadata[key] = value
+			}
+			meta, err := parseInfluxDBMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: test.authParams})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := influxDBScaler{metadata: meta, logger: logr.Discard(), httpClient: http.DefaultClient}
+
+			value, err := scaler.getQueryResult(context.Background())
+			if test.isError {
+				if err == nil {
+					t.Errorf("expected error but got value %v", value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if value != test.expectedValue {
+				t.Errorf("expected value %v but got %v", test.expectedValue, value)
+			}
+		})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/go-logr/logr"
@@ -22,11 +24,32 @@ type kafkaScaler struct {
 	metadata   kafkaMetadata
 	client     sarama.Client
 	admin      sarama.ClusterAdmin
+	tte        *kedautil.TimeToEmpty
+	rate       *kedautil.RateEstimator
 	logger     logr.Logger
+
+	// index of the bootstrap server set the clients are connected to
+	bootstrapServerSetIndex int
+
+	// last seen consumer offset per topic and partition, used to detect stale offset commits
+	offsetCommits     map[string]map[int32]offsetCommit
+	offsetCommitsLock sync.Mutex
+
+	// last seen consumer offsets per topic and partition, used to sample the consumed messages for the
+	// produce rate of the lagRatio scaling mode
+	consumerOffsets     map[string]map[int32]int64
+	consumerOffsetsLock sync.Mutex
+}
+
+type offsetCommit struct {
+	offset     int64
+	lastChange time.Time
 }
 
 type kafkaMetadata struct {
-	bootstrapServers       []string
+	bootstrapServers []string
+	// bootstrap server sets to fail over to, the first one is always bootstrapServers
+	bootstrapServerSets    [][]string
 	group                  string
 	topic                  string
 	lagThreshold           int64
@@ -35,15 +58,34 @@ type kafkaMetadata struct {
 	allowIdleConsumers     bool
 	version                sarama.KafkaVersion
 
+	// scalingMode defines the value the metric is based on, see the kafkaScalingMode constants.
+	// lagRatioThreshold is the target of the lagRatio mode in seconds.
+	scalingMode       kafkaScalingMode
+	lagRatioThreshold float64
+
 	// If an invalid offset is found, whether to scale to 1 (false - the default) so consumption can
 	// occur or scale to 0 (true). See discussion in https://github.com/kedacore/keda/issues/2612
 	scaleToZeroOnInvalidOffset bool
 
+	// If consumer offsets with lag haven't moved for maxOffsetCommitAge, the consumers are considered
+	// stuck and staleOffsetBehavior defines what is reported. Disabled when zero.
+	maxOffsetCommitAge  time.Duration
+	staleOffsetBehavior staleOffsetBehavior
+
 	// SASL
 	saslType kafkaSaslType
 	username string
 	password string
 
+	// OAUTHBEARER
+	scopes                []string
+	oauthTokenEndpointURI string
+	oauthExtensions       map[string]string
+
+	// AWS MSK IAM
+	awsRegion        string
+	awsAuthorization awsAuthorizationMetadata
+
 	// TLS
 	enableTLS   bool
 	cert        string
@@ -51,6 +93,9 @@ type kafkaMetadata struct {
 	keyPassword string
 	ca          string
 
+	// egressDialer opens the connections to the brokers through the egress proxy, nil without egress proxy
+	egressDialer kedautil.EgressDialer
+
 	scalerIndex int
 }
 
@@ -61,6 +106,28 @@ const (
 	earliest offsetResetPolicy = "earliest"
 )
 
+type staleOffsetBehavior string
+
+const (
+	// report the whole backlog, ignoring the partition count cap
+	staleOffsetMaxBacklog staleOffsetBehavior = "maxBacklog"
+	// return an error so the fallback replicas are used
+	staleOffsetFallback staleOffsetBehavior = "fallback"
+)
+
+type kafkaScalingMode string
+
+const (
+	// scale on the total lag of the consumer group, capped at the partition count unless allowIdleConsumers is set
+	kafkaScalingModeTotalLag kafkaScalingMode = "totalLag"
+	// scale on the total lag divided by the produce rate, which is how many seconds of produced messages
+	// the consumer group is behind, so that bursts of messages which are consumed quickly don't over-scale
+	kafkaScalingModeLagRatio kafkaScalingMode = "lagRatio"
+	// scale on the total lag, capped at the number of partitions with lag as the consumers of
+	// the other partitions are idle
+	kafkaScalingModePartitionLimit kafkaScalingMode = "partitionLimit"
+)
+
 type kafkaSaslType string
 
 // supported SASL types
@@ -69,6 +136,8 @@ const (
 	KafkaSASLTypePlaintext   kafkaSaslType = "plaintext"
 	KafkaSASLTypeSCRAMSHA256 kafkaSaslType = "scram_sha256"
 	KafkaSASLTypeSCRAMSHA512 kafkaSaslType = "scram_sha512"
+	KafkaSASLTypeOAuthbearer kafkaSaslType = "oauthbearer"
+	KafkaSASLTypeMSKIAM      kafkaSaslType = "aws_msk_iam"
 )
 
 const (
@@ -78,7 +147,12 @@ const (
 	defaultKafkaLagThreshold           = 10
 	defaultKafkaActivationLagThreshold = 0
 	defaultOffsetResetPolicy           = latest
-	invalidOffset                      = -1
+	defaultStaleOffsetBehavior         = staleOffsetMaxBacklog
+	defaultKafkaScalingMode            = kafkaScalingModeTotalLag
+	defaultKafkaLagRatioThreshold      = 60
+	// the produce rate is at least one message per second, so that a backlog on an idle topic is still scaled on
+	minKafkaProduceRate = 1
+	invalidOffset       = -1
 )
 
 // NewKafkaScaler creates a new kafkaScaler
@@ -94,19 +168,36 @@ func NewKafkaScaler(config *ScalerConfig) (Scaler, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error parsing kafka metadata: %s", err)
 	}
+	kafkaMetadata.egressDialer, err = getEgressDialer(config)
+	if err != nil {
+		return nil, err
+	}
 
-	client, admin, err := getKafkaClients(kafkaMetadata)
+	tte, err := GetTimeToEmpty(config)
 	if err != nil {
 		return nil, err
 	}
+	if tte != nil && kafkaMetadata.scalingMode == kafkaScalingModeLagRatio {
+		return nil, fmt.Errorf("%s isn't supported with scalingMode %s", targetTimeToEmptyKey, kafkaScalingModeLagRatio)
+	}
 
-	return &kafkaScaler{
-		client:     client,
-		admin:      admin,
+	rate := config.RateEstimator
+	if rate == nil {
+		rate = kedautil.NewRateEstimator(kedautil.DefaultRateSamples)
+	}
+
+	scaler := &kafkaScaler{
 		metricType: metricType,
 		metadata:   kafkaMetadata,
+		tte:        tte,
+		rate:       rate,
 		logger:     logger,
-	}, nil
+	}
+	if err := scaler.connect(0); err != nil {
+		return nil, err
+	}
+
+	return scaler, nil
 }
 
 func parseKafkaAuthParams(config *ScalerConfig, meta *kafkaMetadata) error {
@@ -115,7 +206,8 @@ func parseKafkaAuthParams(config *ScalerConfig, meta *kafkaMetadata) error {
 		val = strings.TrimSpace(val)
 		mode := kafkaSaslType(val)
 
-		if mode == KafkaSASLTypePlaintext || mode == KafkaSASLTypeSCRAMSHA256 || mode == KafkaSASLTypeSCRAMSHA512 {
+		switch mode {
+		case KafkaSASLTypePlaintext, KafkaSASLTypeSCRAMSHA256, KafkaSASLTypeSCRAMSHA512, KafkaSASLTypeOAuthbearer:
 			if config.AuthParams["username"] == "" {
 				return errors.New("no username given")
 			}
@@ -126,7 +218,29 @@ func parseKafkaAuthParams(config *ScalerConfig, meta *kafkaMetadata) error {
 			}
 			meta.password = strings.TrimSpace(config.AuthParams["password"])
 			meta.saslType = mode
-		} else {
+
+			if mode == KafkaSASLTypeOAuthbearer {
+				if err := parseKafkaOAuthbearerAuthParams(config, meta); err != nil {
+					return err
+				}
+			}
+		case KafkaSASLTypeMSKIAM:
+			switch {
+			case config.AuthParams["awsRegion"] != "":
+				meta.awsRegion = config.AuthParams["awsRegion"]
+			case config.TriggerMetadata["awsRegion"] != "":
+				meta.awsRegion = config.TriggerMetadata["awsRegion"]
+			default:
+				return errors.New("no awsRegion given")
+			}
+
+			auth, err := getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+			if err != nil {
+				return err
+			}
+			meta.awsAuthorization = auth
+			meta.saslType = mode
+		default:
 			return fmt.Errorf("err SASL mode %s given", mode)
 		}
 	}
@@ -158,19 +272,73 @@ func parseKafkaAuthParams(config *ScalerConfig, meta *kafkaMetadata) error {
 		}
 	}
 
+	if meta.saslType == KafkaSASLTypeMSKIAM && !meta.enableTLS {
+		return errors.New("tls must be enabled with SASL mode aws_msk_iam")
+	}
+
+	return nil
+}
+
+// parseKafkaOAuthbearerAuthParams parses the token endpoint, the scopes and the extensions of the client
+// credentials flow, in which username and password are the client id and secret
+func parseKafkaOAuthbearerAuthParams(config *ScalerConfig, meta *kafkaMetadata) error {
+	meta.oauthTokenEndpointURI = strings.TrimSpace(config.AuthParams["oauthTokenEndpointUri"])
+	if meta.oauthTokenEndpointURI == "" {
+		return errors.New("no oauth token endpoint uri given")
+	}
+
+	if val := strings.TrimSpace(config.AuthParams["scopes"]); val != "" {
+		for _, scope := range strings.Split(val, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				meta.scopes = append(meta.scopes, scope)
+			}
+		}
+	}
+
+	if val := strings.TrimSpace(config.AuthParams["oauthExtensions"]); val != "" {
+		meta.oauthExtensions = make(map[string]string)
+		for _, extension := range strings.Split(val, ",") {
+			parts := strings.SplitN(extension, "=", 2)
+			if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+				return fmt.Errorf("oauthExtensions must be a comma separated list of key=value, got %s", val)
+			}
+			meta.oauthExtensions[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+
 	return nil
 }
 
+// parseKafkaBootstrapServerSets splits bootstrap servers into sets separated by ';',
+// e.g. "primary-1:9092,primary-2:9092;dr-1:9092"
+func parseKafkaBootstrapServerSets(bootstrapServers string) ([][]string, error) {
+	var sets [][]string
+	for _, set := range strings.Split(bootstrapServers, ";") {
+		if strings.TrimSpace(set) == "" {
+			return nil, fmt.Errorf("empty bootstrap server set given in %q", bootstrapServers)
+		}
+		sets = append(sets, strings.Split(set, ","))
+	}
+	return sets, nil
+}
+
 func parseKafkaMetadata(config *ScalerConfig, logger logr.Logger) (kafkaMetadata, error) {
 	meta := kafkaMetadata{}
+	var bootstrapServers string
 	switch {
 	case config.TriggerMetadata["bootstrapServersFromEnv"] != "":
-		meta.bootstrapServers = strings.Split(config.ResolvedEnv[config.TriggerMetadata["bootstrapServersFromEnv"]], ",")
+		bootstrapServers = config.ResolvedEnv[config.TriggerMetadata["bootstrapServersFromEnv"]]
 	case config.TriggerMetadata["bootstrapServers"] != "":
-		meta.bootstrapServers = strings.Split(config.TriggerMetadata["bootstrapServers"], ",")
+		bootstrapServers = config.TriggerMetadata["bootstrapServers"]
 	default:
 		return meta, errors.New("no bootstrapServers given")
 	}
+	sets, err := parseKafkaBootstrapServerSets(bootstrapServers)
+	if err != nil {
+		return meta, err
+	}
+	meta.bootstrapServerSets = sets
+	meta.bootstrapServers = sets[0]
 
 	switch {
 	case config.TriggerMetadata["consumerGroupFromEnv"] != "":
@@ -250,6 +418,53 @@ func parseKafkaMetadata(config *ScalerConfig, logger logr.Logger) (kafkaMetadata
 		meta.scaleToZeroOnInvalidOffset = t
 	}
 
+	if val, ok := config.TriggerMetadata["maxOffsetCommitAge"]; ok {
+		age, err := time.ParseDuration(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing maxOffsetCommitAge: %s", err)
+		}
+		if age <= 0 {
+			return meta, errors.New("maxOffsetCommitAge must be a positive duration")
+		}
+		meta.maxOffsetCommitAge = age
+	}
+
+	meta.staleOffsetBehavior = defaultStaleOffsetBehavior
+	if val, ok := config.TriggerMetadata["staleOffsetBehavior"]; ok {
+		behavior := staleOffsetBehavior(val)
+		if behavior != staleOffsetMaxBacklog && behavior != staleOffsetFallback {
+			return meta, fmt.Errorf("err staleOffsetBehavior %q given", behavior)
+		}
+		meta.staleOffsetBehavior = behavior
+	}
+
+	meta.scalingMode = defaultKafkaScalingMode
+	if val, ok := config.TriggerMetadata["scalingMode"]; ok && val != "" {
+		mode := kafkaScalingMode(val)
+		if mode != kafkaScalingModeTotalLag && mode != kafkaScalingModeLagRatio && mode != kafkaScalingModePartitionLimit {
+			return meta, fmt.Errorf("err scalingMode %q given", mode)
+		}
+		if mode == kafkaScalingModePartitionLimit && meta.allowIdleConsumers {
+			return meta, errors.New("scalingMode partitionLimit can't be used with allowIdleConsumers")
+		}
+		meta.scalingMode = mode
+	}
+
+	meta.lagRatioThreshold = defaultKafkaLagRatioThreshold
+	if val, ok := config.TriggerMetadata["lagRatioThreshold"]; ok {
+		if meta.scalingMode != kafkaScalingModeLagRatio {
+			return meta, errors.New("lagRatioThreshold can only be used with scalingMode lagRatio")
+		}
+		t, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing lagRatioThreshold: %s", err)
+		}
+		if t <= 0 {
+			return meta, errors.New("lagRatioThreshold must be positive number")
+		}
+		meta.lagRatioThreshold = t
+	}
+
 	meta.version = sarama.V1_0_0_0
 	if val, ok := config.TriggerMetadata["version"]; ok {
 		val = strings.TrimSpace(val)
@@ -265,7 +480,7 @@ func parseKafkaMetadata(config *ScalerConfig, logger logr.Logger) (kafkaMetadata
 
 // IsActive determines if we need to scale from zero
 func (s *kafkaScaler) IsActive(ctx context.Context) (bool, error) {
-	totalLag, err := s.getTotalLag()
+	totalLag, _, err := s.getLag()
 	if err != nil {
 		return false, err
 	}
@@ -273,7 +488,55 @@ func (s *kafkaScaler) IsActive(ctx context.Context) (bool, error) {
 	return totalLag > s.metadata.activationLagThreshold, nil
 }
 
-func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin, error) {
+// connect creates the clients for the bootstrap server set at the given index
+func (s *kafkaScaler) connect(index int) error {
+	client, admin, err := getKafkaClients(s.metadata, s.metadata.bootstrapServerSets[index])
+	if err != nil {
+		return err
+	}
+	s.client = client
+	s.admin = admin
+	s.bootstrapServerSetIndex = index
+	return nil
+}
+
+// failover closes the current clients and connects to the first healthy bootstrap server set,
+// trying them in the configured order so that the primary cluster is preferred when it is back
+func (s *kafkaScaler) failover() error {
+	if len(s.metadata.bootstrapServerSets) < 2 {
+		return errors.New("no bootstrap server set to fail over to")
+	}
+
+	var lastErr error
+	for index, servers := range s.metadata.bootstrapServerSets {
+		client, admin, err := getKafkaClients(s.metadata, servers)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if _, err := client.Controller(); err != nil {
+			admin.Close()
+			lastErr = fmt.Errorf("error reaching kafka controller: %s", err)
+			continue
+		}
+
+		if s.admin != nil {
+			if err := s.admin.Close(); err != nil {
+				s.logger.V(1).Info("error closing kafka admin", "error", err.Error())
+			}
+		}
+		s.client = client
+		s.admin = admin
+		if index != s.bootstrapServerSetIndex {
+			s.logger.Info("Kafka scaler: failed over to another bootstrap server set", "bootstrapServers", servers)
+		}
+		s.bootstrapServerSetIndex = index
+		return nil
+	}
+	return fmt.Errorf("no healthy bootstrap server set found: %s", lastErr)
+}
+
+func getKafkaClients(metadata kafkaMetadata, bootstrapServers []string) (sarama.Client, sarama.ClusterAdmin, error) {
 	config := sarama.NewConfig()
 	config.Version = metadata.version
 
@@ -292,6 +555,11 @@ func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin
 		config.Net.TLS.Config = tlsConfig
 	}
 
+	if metadata.egressDialer != nil {
+		config.Net.Proxy.Enable = true
+		config.Net.Proxy.Dialer = metadata.egressDialer
+	}
+
 	if metadata.saslType == KafkaSASLTypePlaintext {
 		config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
 	}
@@ -306,7 +574,21 @@ func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin
 		config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
 	}
 
-	client, err := sarama.NewClient(metadata.bootstrapServers, config)
+	if metadata.saslType == KafkaSASLTypeOAuthbearer {
+		config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+		config.Net.SASL.TokenProvider = newKafkaOAuthBearerTokenProvider(metadata.username, metadata.password, metadata.oauthTokenEndpointURI, metadata.scopes, metadata.oauthExtensions)
+	}
+
+	if metadata.saslType == KafkaSASLTypeMSKIAM {
+		tokenProvider, err := newKafkaMSKIAMTokenProvider(metadata.awsRegion, metadata.awsAuthorization)
+		if err != nil {
+			return nil, nil, err
+		}
+		config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+		config.Net.SASL.TokenProvider = tokenProvider
+	}
+
+	client, err := sarama.NewClient(bootstrapServers, config)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating kafka client: %s", err)
 	}
@@ -441,6 +723,12 @@ func (s *kafkaScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricS
 		},
 		Target: GetMetricTarget(s.metricType, s.metadata.lagThreshold),
 	}
+	switch {
+	case s.metadata.scalingMode == kafkaScalingModeLagRatio:
+		externalMetric.Target = GetMetricTargetMili(s.metricType, s.metadata.lagRatioThreshold)
+	case s.tte != nil:
+		externalMetric.Target = timeToEmptyMetricTarget(s.tte, s.metricType, float64(s.metadata.lagThreshold))
+	}
 	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: kafkaMetricType}
 	return []v2beta2.MetricSpec{metricSpec}
 }
@@ -483,45 +771,188 @@ func (s *kafkaScaler) getConsumerAndProducerOffsets(topicPartitions map[string][
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *kafkaScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	totalLag, err := s.getTotalLag()
+	metrics, _, err := s.GetMetricsAndActivity(ctx, metricName, metricSelector)
+	return metrics, err
+}
+
+// GetMetricsAndActivity returns the metric and whether the scaler is active from a single read of the lag
+func (s *kafkaScaler) GetMetricsAndActivity(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, bool, error) {
+	totalLag, value, err := s.getLag()
 	if err != nil {
-		return []external_metrics.ExternalMetricValue{}, err
+		return []external_metrics.ExternalMetricValue{}, false, err
 	}
-	metric := GenerateMetricInMili(metricName, float64(totalLag))
+	if s.tte != nil {
+		value = float64(totalLag)
+	}
+	metric := GenerateMetricInMili(metricName, s.metricValue(value))
 
-	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+	return append([]external_metrics.ExternalMetricValue{}, metric), totalLag > s.metadata.activationLagThreshold, nil
 }
 
-func (s *kafkaScaler) getTotalLag() (int64, error) {
-	topicPartitions, err := s.getTopicPartitions()
+// getRawMetricValue returns the value of the scaling mode, or the total lag with the time to empty, as it is
+// estimated on the whole lag and the replicas are only capped by the maxReplicaCount
+func (s *kafkaScaler) getRawMetricValue(context.Context) (float64, error) {
+	totalLag, value, err := s.getLag()
 	if err != nil {
 		return 0, err
 	}
+	if s.tte != nil {
+		return float64(totalLag), nil
+	}
+	return value, nil
+}
+
+// metricValue converts the total lag to the time to empty it, if it is enabled
+func (s *kafkaScaler) metricValue(rawValue float64) float64 {
+	return timeToEmptyMetricValue(s.tte, rawValue)
+}
+
+// getLag returns the total lag and the metric value of the scaling mode, failing over to another
+// bootstrap server set if the lag can't be retrieved from the current one
+func (s *kafkaScaler) getLag() (int64, float64, error) {
+	totalLag, value, err := s.getLagFromCluster()
+	if err == nil || len(s.metadata.bootstrapServerSets) < 2 {
+		return totalLag, value, err
+	}
+
+	s.logger.Error(err, "error getting lag, trying to fail over", "bootstrapServers", s.metadata.bootstrapServerSets[s.bootstrapServerSetIndex])
+	if failoverErr := s.failover(); failoverErr != nil {
+		return 0, 0, fmt.Errorf("%s, %s", err, failoverErr)
+	}
+	return s.getLagFromCluster()
+}
+
+func (s *kafkaScaler) getLagFromCluster() (int64, float64, error) {
+	topicPartitions, err := s.getTopicPartitions()
+	if err != nil {
+		return 0, 0, err
+	}
 
 	consumerOffsets, producerOffsets, err := s.getConsumerAndProducerOffsets(topicPartitions)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
 
 	totalLag := int64(0)
 	totalTopicPartitions := int64(0)
+	partitionsWithLag := int64(0)
+	stalePartitions := 0
+	now := time.Now()
 
 	for topic, partitionsOffsets := range producerOffsets {
 		for partition := range partitionsOffsets {
 			lag, _ := s.getLagForPartition(topic, partition, consumerOffsets, producerOffsets)
 			totalLag += lag
+			if lag > 0 {
+				partitionsWithLag++
+			}
+
+			if block := consumerOffsets.GetBlock(topic, partition); block != nil && s.isOffsetCommitStale(topic, partition, block.Offset, lag, now) {
+				stalePartitions++
+			}
 		}
 		totalTopicPartitions += (int64)(len(partitionsOffsets))
 	}
 	s.logger.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topicPartitions %v, threshold %v", totalLag, len(topicPartitions), s.metadata.lagThreshold))
 
-	if !s.metadata.allowIdleConsumers {
+	produceRate := float64(0)
+	if s.metadata.scalingMode == kafkaScalingModeLagRatio {
+		s.rate.Add(kedautil.RateSample{Time: now, Backlog: float64(totalLag), Completed: s.getConsumed(topicPartitions, consumerOffsets)})
+		produceRate, _ = s.rate.ArrivalRate()
+	}
+
+	if stalePartitions > 0 {
+		s.logger.Info(fmt.Sprintf("Kafka scaler: consumer group %s hasn't committed offsets for %v on %d partitions with lag", s.metadata.group, s.metadata.maxOffsetCommitAge, stalePartitions))
+		if s.metadata.staleOffsetBehavior == staleOffsetFallback {
+			return 0, 0, fmt.Errorf("consumer group %s has stale offset commits on %d partitions", s.metadata.group, stalePartitions)
+		}
+		return totalLag, s.getMetricValue(totalLag, produceRate, -1), nil
+	}
+
+	maxReplicas := int64(-1)
+	switch {
+	case s.metadata.scalingMode == kafkaScalingModePartitionLimit:
+		maxReplicas = partitionsWithLag
+	case !s.metadata.allowIdleConsumers:
 		// don't scale out beyond the number of topicPartitions
-		if (totalLag / s.metadata.lagThreshold) > totalTopicPartitions {
-			totalLag = totalTopicPartitions * s.metadata.lagThreshold
+		maxReplicas = totalTopicPartitions
+	}
+	return totalLag, s.getMetricValue(totalLag, produceRate, maxReplicas), nil
+}
+
+// getMetricValue returns the metric value of the scaling mode, capped so that the desired replicas don't exceed
+// maxReplicas unless it is negative
+func (s *kafkaScaler) getMetricValue(totalLag int64, produceRate float64, maxReplicas int64) float64 {
+	if s.metadata.scalingMode == kafkaScalingModeLagRatio {
+		lagRatio := float64(totalLag) / math.Max(produceRate, minKafkaProduceRate)
+		if maxReplicas >= 0 && lagRatio/s.metadata.lagRatioThreshold > float64(maxReplicas) {
+			lagRatio = float64(maxReplicas) * s.metadata.lagRatioThreshold
 		}
+		return lagRatio
+	}
+
+	if maxReplicas >= 0 && (totalLag/s.metadata.lagThreshold) > maxReplicas {
+		totalLag = maxReplicas * s.metadata.lagThreshold
+	}
+	return float64(totalLag)
+}
+
+// getConsumed records the consumer offsets and returns how many messages were consumed since the previous
+// call, or -1 on the first call
+func (s *kafkaScaler) getConsumed(topicPartitions map[string][]int32, consumerOffsets *sarama.OffsetFetchResponse) float64 {
+	s.consumerOffsetsLock.Lock()
+	defer s.consumerOffsetsLock.Unlock()
+
+	previousOffsets := s.consumerOffsets
+	s.consumerOffsets = make(map[string]map[int32]int64, len(topicPartitions))
+
+	consumed := int64(0)
+	for topic, partitions := range topicPartitions {
+		s.consumerOffsets[topic] = make(map[int32]int64, len(partitions))
+		for _, partition := range partitions {
+			block := consumerOffsets.GetBlock(topic, partition)
+			if block == nil || block.Offset == invalidOffset {
+				continue
+			}
+			s.consumerOffsets[topic][partition] = block.Offset
+
+			previousOffset, found := previousOffsets[topic][partition]
+			// offsets of recreated topics start over
+			if found && block.Offset > previousOffset {
+				consumed += block.Offset - previousOffset
+			}
+		}
+	}
+
+	if previousOffsets == nil {
+		return -1
+	}
+	return float64(consumed)
+}
+
+// isOffsetCommitStale records the consumer offset of the partition and returns true if the partition
+// has lag but its offset hasn't changed for longer than maxOffsetCommitAge
+func (s *kafkaScaler) isOffsetCommitStale(topic string, partition int32, offset int64, lag int64, now time.Time) bool {
+	if s.metadata.maxOffsetCommitAge == 0 {
+		return false
+	}
+
+	s.offsetCommitsLock.Lock()
+	defer s.offsetCommitsLock.Unlock()
+
+	if s.offsetCommits == nil {
+		s.offsetCommits = make(map[string]map[int32]offsetCommit)
+	}
+	if _, found := s.offsetCommits[topic]; !found {
+		s.offsetCommits[topic] = make(map[int32]offsetCommit)
+	}
+
+	commit, found := s.offsetCommits[topic][partition]
+	if !found || commit.offset != offset || lag <= 0 {
+		s.offsetCommits[topic][partition] = offsetCommit{offset: offset, lastChange: now}
+		return false
 	}
-	return totalLag, nil
+	return now.Sub(commit.lastChange) > s.metadata.maxOffsetCommitAge
 }
 
 type brokerOffsetResult struct {
@@ -4,7 +4,9 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/Shopify/sarama"
 	"github.com/go-logr/logr"
 )
 
@@ -80,6 +82,181 @@ var parseKafkaMetadataTestDataset = []parseKafkaMetadataTestData{
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "allowIdleConsumers": "true"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", offsetResetPolicy("latest"), true},
 	// success, version supported
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "allowIdleConsumers": "true", "version": "1.0.0"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", offsetResetPolicy("latest"), true},
+	// success, failover bootstrap server sets, primary set is used first
+	{map[string]string{"bootstrapServers": "foo:9092,bar:9092;dr:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, 2, []string{"foo:9092", "bar:9092"}, "my-group", "my-topic", offsetResetPolicy("latest"), false},
+	// failure, empty bootstrap server set
+	{map[string]string{"bootstrapServers": "foo:9092;", "consumerGroup": "my-group", "topic": "my-topic"}, true, 0, nil, "", "", "", false},
+}
+
+func TestKafkaStaleOffsetMetadata(t *testing.T) {
+	testCases := []struct {
+		metadata map[string]string
+		isError  bool
+		age      time.Duration
+		behavior staleOffsetBehavior
+	}{
+		{map[string]string{}, false, 0, staleOffsetMaxBacklog},
+		{map[string]string{"maxOffsetCommitAge": "5m"}, false, 5 * time.Minute, staleOffsetMaxBacklog},
+		{map[string]string{"maxOffsetCommitAge": "5m", "staleOffsetBehavior": "fallback"}, false, 5 * time.Minute, staleOffsetFallback},
+		{map[string]string{"maxOffsetCommitAge": "five minutes"}, true, 0, ""},
+		{map[string]string{"maxOffsetCommitAge": "-1m"}, true, 0, ""},
+		{map[string]string{"maxOffsetCommitAge": "5m", "staleOffsetBehavior": "ignore"}, true, 0, ""},
+	}
+	for _, tc := range testCases {
+		metadata := map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}
+		for k, v := range tc.metadata {
+			metadata[k] = v
+		}
+		meta, err := parseKafkaMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: validWithoutAuthParams}, logr.Discard())
+		if err != nil && !tc.isError {
+			t.Error("Expected success but got error", err)
+			continue
+		}
+		if tc.isError {
+			if err == nil {
+				t.Errorf("Expected error for %v but got success", tc.metadata)
+			}
+			continue
+		}
+		if meta.maxOffsetCommitAge != tc.age {
+			t.Errorf("Expected maxOffsetCommitAge %v but got %v", tc.age, meta.maxOffsetCommitAge)
+		}
+		if meta.staleOffsetBehavior != tc.behavior {
+			t.Errorf("Expected staleOffsetBehavior %s but got %s", tc.behavior, meta.staleOffsetBehavior)
+		}
+	}
+}
+
+func TestKafkaIsOffsetCommitStale(t *testing.T) {
+	scaler := kafkaScaler{metadata: kafkaMetadata{maxOffsetCommitAge: time.Minute}}
+	start := time.Now()
+
+	if scaler.isOffsetCommitStale("my-topic", 0, 10, 5, start) {
+		t.Error("Expected first seen offset not to be stale")
+	}
+	if scaler.isOffsetCommitStale("my-topic", 0, 10, 5, start.Add(30*time.Second)) {
+		t.Error("Expected offset younger than maxOffsetCommitAge not to be stale")
+	}
+	if !scaler.isOffsetCommitStale("my-topic", 0, 10, 5, start.Add(2*time.Minute)) {
+		t.Error("Expected unchanged offset with lag older than maxOffsetCommitAge to be stale")
+	}
+	if scaler.isOffsetCommitStale("my-topic", 0, 11, 5, start.Add(3*time.Minute)) {
+		t.Error("Expected moved offset not to be stale")
+	}
+	if scaler.isOffsetCommitStale("my-topic", 0, 11, 0, start.Add(10*time.Minute)) {
+		t.Error("Expected offset without lag not to be stale")
+	}
+
+	disabled := kafkaScaler{}
+	if disabled.isOffsetCommitStale("my-topic", 0, 10, 5, start) || disabled.isOffsetCommitStale("my-topic", 0, 10, 5, start.Add(time.Hour)) {
+		t.Error("Expected no stale offsets when maxOffsetCommitAge is not set")
+	}
+}
+
+func TestKafkaScalingModeMetadata(t *testing.T) {
+	testCases := []struct {
+		metadata          map[string]string
+		isError           bool
+		scalingMode       kafkaScalingMode
+		lagRatioThreshold float64
+	}{
+		{map[string]string{}, false, kafkaScalingModeTotalLag, 60},
+		{map[string]string{"scalingMode": "lagRatio"}, false, kafkaScalingModeLagRatio, 60},
+		{map[string]string{"scalingMode": "lagRatio", "lagRatioThreshold": "2.5"}, false, kafkaScalingModeLagRatio, 2.5},
+		{map[string]string{"scalingMode": "partitionLimit"}, false, kafkaScalingModePartitionLimit, 60},
+		{map[string]string{"scalingMode": "partitionLimit", "allowIdleConsumers": "true"}, true, "", 0},
+		{map[string]string{"scalingMode": "rate"}, true, "", 0},
+		{map[string]string{"lagRatioThreshold": "10"}, true, "", 0},
+		{map[string]string{"scalingMode": "lagRatio", "lagRatioThreshold": "ten"}, true, "", 0},
+		{map[string]string{"scalingMode": "lagRatio", "lagRatioThreshold": "0"}, true, "", 0},
+	}
+	for _, tc := range testCases {
+		metadata := map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}
+		for k, v := range tc.metadata {
+			metadata[k] = v
+		}
+		meta, err := parseKafkaMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: validWithoutAuthParams}, logr.Discard())
+		if err != nil && !tc.isError {
+			t.Error("Expected success but got error", err)
+			continue
+		}
+		if tc.isError {
+			if err == nil {
+				t.Errorf("Expected error for %v but got success", tc.metadata)
+			}
+			continue
+		}
+		if meta.scalingMode != tc.scalingMode {
+			t.Errorf("Expected scalingMode %s but got %s", tc.scalingMode, meta.scalingMode)
+		}
+		if meta.lagRatioThreshold != tc.lagRatioThreshold {
+			t.Errorf("Expected lagRatioThreshold %v but got %v", tc.lagRatioThreshold, meta.lagRatioThreshold)
+		}
+	}
+}
+
+func TestKafkaGetMetricValue(t *testing.T) {
+	testCases := []struct {
+		name        string
+		metadata    kafkaMetadata
+		totalLag    int64
+		produceRate float64
+		maxReplicas int64
+		expected    float64
+	}{
+		{"total lag", kafkaMetadata{scalingMode: kafkaScalingModeTotalLag, lagThreshold: 10}, 35, 0, 5, 35},
+		{"total lag capped", kafkaMetadata{scalingMode: kafkaScalingModeTotalLag, lagThreshold: 10}, 100, 0, 3, 30},
+		{"total lag uncapped", kafkaMetadata{scalingMode: kafkaScalingModeTotalLag, lagThreshold: 10}, 100, 0, -1, 100},
+		{"partitions with lag", kafkaMetadata{scalingMode: kafkaScalingModePartitionLimit, lagThreshold: 10}, 100, 0, 2, 20},
+		{"lag ratio", kafkaMetadata{scalingMode: kafkaScalingModeLagRatio, lagRatioThreshold: 60}, 1000, 50, 5, 20},
+		{"lag ratio without production", kafkaMetadata{scalingMode: kafkaScalingModeLagRatio, lagRatioThreshold: 60}, 100, 0, 5, 100},
+		{"lag ratio capped", kafkaMetadata{scalingMode: kafkaScalingModeLagRatio, lagRatioThreshold: 10}, 1000, 2, 3, 30},
+	}
+	for _, tc := range testCases {
+		scaler := kafkaScaler{metadata: tc.metadata}
+		if value := scaler.getMetricValue(tc.totalLag, tc.produceRate, tc.maxReplicas); value != tc.expected {
+			t.Errorf("%s: expected metric value %v but got %v", tc.name, tc.expected, value)
+		}
+	}
+}
+
+func TestKafkaGetConsumed(t *testing.T) {
+	scaler := kafkaScaler{}
+	topicPartitions := map[string][]int32{"my-topic": {0, 1, 2}}
+
+	offsets := func(partitionOffsets ...int64) *sarama.OffsetFetchResponse {
+		response := &sarama.OffsetFetchResponse{}
+		for partition, offset := range partitionOffsets {
+			response.AddBlock("my-topic", int32(partition), &sarama.OffsetFetchResponseBlock{Offset: offset})
+		}
+		return response
+	}
+
+	if consumed := scaler.getConsumed(topicPartitions, offsets(100, 200, invalidOffset)); consumed != -1 {
+		t.Errorf("Expected no consumed count on the first call but got %v", consumed)
+	}
+	if consumed := scaler.getConsumed(topicPartitions, offsets(150, 250, 10)); consumed != 100 {
+		t.Errorf("Expected 100 consumed messages but got %v", consumed)
+	}
+	// the topic was recreated
+	if consumed := scaler.getConsumed(topicPartitions, offsets(20, 250, 30)); consumed != 20 {
+		t.Errorf("Expected 20 consumed messages but got %v", consumed)
+	}
+}
+
+func TestKafkaBootstrapServerSets(t *testing.T) {
+	sets, err := parseKafkaBootstrapServerSets("primary-1:9092,primary-2:9092;dr-1:9092")
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	expected := [][]string{{"primary-1:9092", "primary-2:9092"}, {"dr-1:9092"}}
+	if !reflect.DeepEqual(expected, sets) {
+		t.Errorf("Expected %v but got %v\n", expected, sets)
+	}
+
+	if _, err := parseKafkaBootstrapServerSets("primary-1:9092;;dr-1:9092"); err == nil {
+		t.Error("Expected error for empty set but got success")
+	}
 }
 
 var parseKafkaAuthParamsTestDataset = []parseKafkaAuthParamsTestData{
@@ -125,6 +302,18 @@ var parseKafkaAuthParamsTestDataset = []parseKafkaAuthParamsTestData{
 	{map[string]string{"sasl": "plaintext", "username": "admin", "password": "admin", "tls": "enable", "ca": "caaa", "key": "keey"}, true, false},
 	// failure, SASL + TLS, missing key
 	{map[string]string{"sasl": "plaintext", "username": "admin", "password": "admin", "tls": "enable", "ca": "caaa", "cert": "ceert"}, true, false},
+	// success, SASL OAUTHBEARER + TLS
+	{map[string]string{"sasl": "oauthbearer", "username": "client", "password": "secret", "oauthTokenEndpointUri": "https://idp/token", "scopes": "kafka, offline", "oauthExtensions": "logicalCluster=lkc-1,identityPoolId=pool-1", "tls": "enable"}, false, true},
+	// failure, SASL OAUTHBEARER missing token endpoint
+	{map[string]string{"sasl": "oauthbearer", "username": "client", "password": "secret"}, true, false},
+	// failure, SASL OAUTHBEARER invalid extensions
+	{map[string]string{"sasl": "oauthbearer", "username": "client", "password": "secret", "oauthTokenEndpointUri": "https://idp/token", "oauthExtensions": "logicalCluster"}, true, false},
+	// success, SASL AWS MSK IAM + TLS
+	{map[string]string{"sasl": "aws_msk_iam", "awsRegion": "eu-west-1", "awsRoleArn": "arn:aws:iam::123456789012:role/keda", "tls": "enable"}, false, true},
+	// failure, SASL AWS MSK IAM without TLS
+	{map[string]string{"sasl": "aws_msk_iam", "awsRegion": "eu-west-1", "awsRoleArn": "arn:aws:iam::123456789012:role/keda"}, true, false},
+	// failure, SASL AWS MSK IAM missing region
+	{map[string]string{"sasl": "aws_msk_iam", "awsRoleArn": "arn:aws:iam::123456789012:role/keda", "tls": "enable"}, true, false},
 }
 
 var kafkaMetricIdentifiers = []kafkaMetricIdentifier{
@@ -224,7 +413,7 @@ func TestKafkaGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockKafkaScaler := kafkaScaler{"", meta, nil, nil, logr.Discard()}
+		mockKafkaScaler := kafkaScaler{metadata: meta, logger: logr.Discard()}
 
 		metricSpec := mockKafkaScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -0,0 +1,117 @@
+package scalers
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	kafkaMSKIAMService     = "kafka-cluster"
+	kafkaMSKIAMAction      = "kafka-cluster:Connect"
+	kafkaMSKIAMTokenExpiry = 15 * time.Minute
+	kafkaMSKIAMUserAgent   = "keda"
+)
+
+// kafkaOAuthBearerTokenProvider retrieves the OAUTHBEARER tokens with the client credentials flow
+type kafkaOAuthBearerTokenProvider struct {
+	tokenSource oauth2.TokenSource
+	extensions  map[string]string
+}
+
+func newKafkaOAuthBearerTokenProvider(clientID, clientSecret, tokenURL string, scopes []string, extensions map[string]string) sarama.AccessTokenProvider {
+	config := clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+		Scopes:       scopes,
+	}
+
+	// the token source caches the token until it expires
+	return &kafkaOAuthBearerTokenProvider{
+		tokenSource: config.TokenSource(context.Background()),
+		extensions:  extensions,
+	}
+}
+
+// Token returns a new or the cached token
+func (p *kafkaOAuthBearerTokenProvider) Token() (*sarama.AccessToken, error) {
+	token, err := p.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("error getting oauth token: %s", err)
+	}
+	return &sarama.AccessToken{Token: token.AccessToken, Extensions: p.extensions}, nil
+}
+
+// kafkaMSKIAMTokenProvider creates the OAUTHBEARER tokens AWS MSK accepts for IAM authentication,
+// which are presigned kafka-cluster:Connect requests
+type kafkaMSKIAMTokenProvider struct {
+	region string
+	signer *v4.Signer
+}
+
+func newKafkaMSKIAMTokenProvider(region string, awsAuthorization awsAuthorizationMetadata) (sarama.AccessTokenProvider, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(region),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating aws session: %s", err)
+	}
+
+	creds := sess.Config.Credentials
+	if awsAuthorization.podIdentityOwner {
+		creds = credentials.NewStaticCredentials(awsAuthorization.awsAccessKeyID, awsAuthorization.awsSecretAccessKey, awsAuthorization.awsSessionToken)
+
+		if awsAuthorization.awsRoleArn != "" {
+			creds = stscreds.NewCredentials(sess, awsAuthorization.awsRoleArn)
+		}
+	}
+
+	return &kafkaMSKIAMTokenProvider{
+		region: region,
+		signer: v4.NewSigner(creds),
+	}, nil
+}
+
+// Token returns a new presigned token
+func (p *kafkaMSKIAMTokenProvider) Token() (*sarama.AccessToken, error) {
+	return p.token(time.Now())
+}
+
+func (p *kafkaMSKIAMTokenProvider) token(now time.Time) (*sarama.AccessToken, error) {
+	query := url.Values{}
+	query.Set("Action", kafkaMSKIAMAction)
+	endpoint := url.URL{
+		Scheme:   "https",
+		Host:     fmt.Sprintf("kafka.%s.amazonaws.com", p.region),
+		Path:     "/",
+		RawQuery: query.Encode(),
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.signer.Presign(req, nil, kafkaMSKIAMService, p.region, kafkaMSKIAMTokenExpiry, now); err != nil {
+		return nil, fmt.Errorf("error signing aws msk iam token: %s", err)
+	}
+
+	// the user agent isn't part of the signature
+	signedQuery := req.URL.Query()
+	signedQuery.Set("User-Agent", kafkaMSKIAMUserAgent)
+	req.URL.RawQuery = signedQuery.Encode()
+
+	return &sarama.AccessToken{Token: base64.RawURLEncoding.EncodeToString([]byte(req.URL.String()))}, nil
+}
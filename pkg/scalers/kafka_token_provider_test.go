@@ -0,0 +1,64 @@
+package scalers
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaOAuthBearerTokenProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("scope") != "kafka offline" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if clientID, clientSecret, ok := r.BasicAuth(); !ok || clientID != "client" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	extensions := map[string]string{"logicalCluster": "lkc-1"}
+	provider := newKafkaOAuthBearerTokenProvider("client", "secret", server.URL, []string{"kafka", "offline"}, extensions)
+	token, err := provider.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token", token.Token)
+	assert.Equal(t, extensions, token.Extensions)
+
+	provider = newKafkaOAuthBearerTokenProvider("client", "wrong", server.URL, []string{"kafka", "offline"}, nil)
+	_, err = provider.Token()
+	assert.Error(t, err)
+}
+
+func TestKafkaMSKIAMTokenProvider(t *testing.T) {
+	provider := &kafkaMSKIAMTokenProvider{
+		region: "eu-west-1",
+		signer: v4.NewSigner(credentials.NewStaticCredentials("AKIDEXAMPLE", "secret", "")),
+	}
+
+	token, err := provider.token(time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token.Token)
+	assert.NoError(t, err)
+	tokenURL, err := url.Parse(string(decoded))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "kafka.eu-west-1.amazonaws.com", tokenURL.Host)
+	query := tokenURL.Query()
+	assert.Equal(t, "kafka-cluster:Connect", query.Get("Action"))
+	assert.Equal(t, "keda", query.Get("User-Agent"))
+	assert.Equal(t, "AKIDEXAMPLE/20220801/eu-west-1/kafka-cluster/aws4_request", query.Get("X-Amz-Credential"))
+	assert.Equal(t, "900", query.Get("X-Amz-Expires"))
+	assert.NotEmpty(t, query.Get("X-Amz-Signature"))
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -25,8 +28,13 @@ type kubernetesWorkloadScaler struct {
 const (
 	kubernetesWorkloadMetricType = "External"
 	podSelectorKey               = "podSelector"
+	workloadKindKey              = "workloadKind"
+	workloadNameKey              = "workloadName"
 	valueKey                     = "value"
 	activationValueKey           = "activationValue"
+
+	workloadKindDeployment  = "Deployment"
+	workloadKindStatefulSet = "StatefulSet"
 )
 
 var phasesCountedAsTerminated = []corev1.PodPhase{
@@ -35,7 +43,11 @@ var phasesCountedAsTerminated = []corev1.PodPhase{
 }
 
 type kubernetesWorkloadMetadata struct {
-	podSelector     labels.Selector
+	podSelector labels.Selector
+	// workloadKind and workloadName select a Deployment or StatefulSet whose ready replicas are counted
+	// instead of the pods matching the podSelector
+	workloadKind    string
+	workloadName    string
 	namespace       string
 	value           float64
 	activationValue float64
@@ -66,9 +78,24 @@ func parseWorkloadMetadata(config *ScalerConfig) (*kubernetesWorkloadMetadata, e
 	meta := &kubernetesWorkloadMetadata{}
 	var err error
 	meta.namespace = config.ScalableObjectNamespace
-	meta.podSelector, err = labels.Parse(config.TriggerMetadata[podSelectorKey])
-	if err != nil || meta.podSelector.String() == "" {
-		return nil, fmt.Errorf("invalid pod selector")
+	meta.workloadName = config.TriggerMetadata[workloadNameKey]
+	if meta.workloadName != "" {
+		if config.TriggerMetadata[podSelectorKey] != "" {
+			return nil, fmt.Errorf("podSelector and workloadName can't be used together")
+		}
+		switch config.TriggerMetadata[workloadKindKey] {
+		case "", workloadKindDeployment:
+			meta.workloadKind = workloadKindDeployment
+		case workloadKindStatefulSet:
+			meta.workloadKind = workloadKindStatefulSet
+		default:
+			return nil, fmt.Errorf("workloadKind must be %s or %s", workloadKindDeployment, workloadKindStatefulSet)
+		}
+	} else {
+		meta.podSelector, err = labels.Parse(config.TriggerMetadata[podSelectorKey])
+		if err != nil || meta.podSelector.String() == "" {
+			return nil, fmt.Errorf("invalid pod selector")
+		}
 	}
 	meta.value, err = strconv.ParseFloat(config.TriggerMetadata[valueKey], 64)
 	if err != nil || meta.value == 0 {
@@ -105,9 +132,13 @@ func (s *kubernetesWorkloadScaler) Close(context.Context) error {
 
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *kubernetesWorkloadScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	metricName := fmt.Sprintf("workload-%s", s.metadata.namespace)
+	if s.metadata.workloadName != "" {
+		metricName = fmt.Sprintf("workload-%s-%s-%s", s.metadata.namespace, strings.ToLower(s.metadata.workloadKind), s.metadata.workloadName)
+	}
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("workload-%s", s.metadata.namespace))),
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
 		},
 		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
 	}
@@ -128,6 +159,10 @@ func (s *kubernetesWorkloadScaler) GetMetrics(ctx context.Context, metricName st
 }
 
 func (s *kubernetesWorkloadScaler) getMetricValue(ctx context.Context) (int64, error) {
+	if s.metadata.workloadName != "" {
+		return s.getReadyReplicas(ctx)
+	}
+
 	podList := &corev1.PodList{}
 	listOptions := client.ListOptions{}
 	listOptions.LabelSelector = s.metadata.podSelector
@@ -149,6 +184,25 @@ func (s *kubernetesWorkloadScaler) getMetricValue(ctx context.Context) (int64, e
 	return count, nil
 }
 
+// getReadyReplicas returns the number of ready replicas of the Deployment or StatefulSet
+func (s *kubernetesWorkloadScaler) getReadyReplicas(ctx context.Context) (int64, error) {
+	key := types.NamespacedName{Name: s.metadata.workloadName, Namespace: s.metadata.namespace}
+	switch s.metadata.workloadKind {
+	case workloadKindStatefulSet:
+		statefulSet := &appsv1.StatefulSet{}
+		if err := s.kubeClient.Get(ctx, key, statefulSet); err != nil {
+			return 0, err
+		}
+		return int64(statefulSet.Status.ReadyReplicas), nil
+	default:
+		deployment := &appsv1.Deployment{}
+		if err := s.kubeClient.Get(ctx, key, deployment); err != nil {
+			return 0, err
+		}
+		return int64(deployment.Status.ReadyReplicas), nil
+	}
+}
+
 func getCountValue(pod corev1.Pod) int64 {
 	for _, ignore := range phasesCountedAsTerminated {
 		if pod.Status.Phase == ignore {
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -32,6 +33,10 @@ var parseWorkloadMetadataTestDataset = []workloadMetadataTestData{
 	{map[string]string{"value": "0", "podSelector": "app=demo"}, "test", true},
 	{map[string]string{"value": "0", "podSelector": "app=demo"}, "default", true},
 	{map[string]string{"value": "1", "activationValue": "aa", "podSelector": "app=demo"}, "test", true},
+	{map[string]string{"value": "1", "workloadName": "demo"}, "test", false},
+	{map[string]string{"value": "1", "workloadKind": "StatefulSet", "workloadName": "demo"}, "test", false},
+	{map[string]string{"value": "1", "workloadKind": "DaemonSet", "workloadName": "demo"}, "test", true},
+	{map[string]string{"value": "1", "workloadName": "demo", "podSelector": "app=demo"}, "test", true},
 }
 
 func TestParseWorkloadMetadata(t *testing.T) {
@@ -101,6 +106,10 @@ var getMetricSpecForScalingTestDataset = []workloadGetMetricSpecForScalingTestDa
 	{parseWorkloadMetadataTestDataset[2].metadata, parseWorkloadMetadataTestDataset[2].namespace, 2, "s2-workload-test"},
 	// "podSelector": "app in (demo1, demo2),deploy in (deploy1, deploy2)", "namespace": "test"
 	{parseWorkloadMetadataTestDataset[3].metadata, parseWorkloadMetadataTestDataset[3].namespace, 3, "s3-workload-test"},
+	// "workloadName": "demo", "namespace": "test"
+	{parseWorkloadMetadataTestDataset[13].metadata, parseWorkloadMetadataTestDataset[13].namespace, 4, "s4-workload-test-deployment-demo"},
+	// "workloadKind": "StatefulSet", "workloadName": "demo", "namespace": "test"
+	{parseWorkloadMetadataTestDataset[14].metadata, parseWorkloadMetadataTestDataset[14].namespace, 5, "s5-workload-test-statefulset-demo"},
 }
 
 func TestWorkloadGetMetricSpecForScaling(t *testing.T) {
@@ -123,6 +132,47 @@ func TestWorkloadGetMetricSpecForScaling(t *testing.T) {
 	}
 }
 
+func TestWorkloadReadyReplicas(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{Name: "demo", Namespace: "default"}
+	client := fake.NewClientBuilder().WithRuntimeObjects(
+		&appsv1.Deployment{ObjectMeta: objectMeta, Status: appsv1.DeploymentStatus{Replicas: 5, ReadyReplicas: 3}},
+		&appsv1.StatefulSet{ObjectMeta: objectMeta, Status: appsv1.StatefulSetStatus{Replicas: 4, ReadyReplicas: 2}},
+	).Build()
+
+	testCases := []struct {
+		metadata map[string]string
+		expected int64
+		isError  bool
+	}{
+		{map[string]string{"value": "1", "workloadName": "demo"}, 3, false},
+		{map[string]string{"value": "1", "workloadKind": "Deployment", "workloadName": "demo"}, 3, false},
+		{map[string]string{"value": "1", "workloadKind": "StatefulSet", "workloadName": "demo"}, 2, false},
+		{map[string]string{"value": "1", "workloadName": "missing"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		s, err := NewKubernetesWorkloadScaler(client, &ScalerConfig{
+			TriggerMetadata:         tc.metadata,
+			AuthParams:              map[string]string{},
+			GlobalHTTPTimeout:       1000 * time.Millisecond,
+			ScalableObjectNamespace: "default",
+		})
+		if err != nil {
+			t.Fatalf("Failed to create test scaler -- %v", err)
+		}
+		value, err := s.(*kubernetesWorkloadScaler).getMetricValue(context.TODO())
+		if err != nil && !tc.isError {
+			t.Errorf("Expected success for %v but got error %s", tc.metadata, err)
+		}
+		if tc.isError && err == nil {
+			t.Errorf("Expected error for %v but got success", tc.metadata)
+		}
+		if value != tc.expected {
+			t.Errorf("Expected %d for %v but got %d", tc.expected, tc.metadata, value)
+		}
+	}
+}
+
 func createPodlist(count int) *v1.PodList {
 	list := &v1.PodList{}
 	for i := 0; i < count; i++ {
@@ -0,0 +1,195 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	kubeVirtMetricType = "External"
+)
+
+// the KubeVirt resources are listed as unstructured, so the scaler doesn't depend on the KubeVirt API module
+var (
+	kubeVirtMigrationListGVK = schema.GroupVersionKind{Group: "kubevirt.io", Version: "v1", Kind: "VirtualMachineInstanceMigrationList"}
+	kubeVirtVMListGVK        = schema.GroupVersionKind{Group: "kubevirt.io", Version: "v1", Kind: "VirtualMachineList"}
+)
+
+type kubeVirtMeasure string
+
+const (
+	// kubeVirtMeasurePendingMigrations counts the migrations which haven't completed yet
+	kubeVirtMeasurePendingMigrations kubeVirtMeasure = "pendingMigrations"
+	// kubeVirtMeasurePendingStarts counts the virtual machines which are being provisioned or started
+	kubeVirtMeasurePendingStarts kubeVirtMeasure = "pendingStarts"
+)
+
+// kubeVirtFinalMigrationPhases are the phases of the completed migrations
+var kubeVirtFinalMigrationPhases = map[string]bool{
+	"Succeeded": true,
+	"Failed":    true,
+}
+
+// kubeVirtPendingStartStatuses are the printable statuses of the virtual machines waiting to run
+var kubeVirtPendingStartStatuses = map[string]bool{
+	"Provisioning":            true,
+	"WaitingForVolumeBinding": true,
+	"Starting":                true,
+	"ErrorUnschedulable":      true,
+}
+
+type kubeVirtScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *kubeVirtMetadata
+	kubeClient client.Client
+	logger     logr.Logger
+}
+
+type kubeVirtMetadata struct {
+	measure         kubeVirtMeasure
+	selector        labels.Selector
+	namespace       string
+	value           float64
+	activationValue float64
+	scalerIndex     int
+}
+
+// NewKubeVirtScaler creates a new kubeVirtScaler
+func NewKubeVirtScaler(kubeClient client.Client, config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseKubeVirtMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing kubevirt metadata: %s", err)
+	}
+
+	return &kubeVirtScaler{
+		metricType: metricType,
+		metadata:   meta,
+		kubeClient: kubeClient,
+		logger:     InitializeLogger(config, "kubevirt_scaler"),
+	}, nil
+}
+
+func parseKubeVirtMetadata(config *ScalerConfig) (*kubeVirtMetadata, error) {
+	meta := kubeVirtMetadata{}
+	var err error
+
+	meta.measure = kubeVirtMeasurePendingMigrations
+	if val, ok := config.TriggerMetadata["measure"]; ok && val != "" {
+		meta.measure = kubeVirtMeasure(val)
+	}
+	switch meta.measure {
+	case kubeVirtMeasurePendingMigrations, kubeVirtMeasurePendingStarts:
+	default:
+		return nil, fmt.Errorf("measure must be one of %s, %s but is %s", kubeVirtMeasurePendingMigrations, kubeVirtMeasurePendingStarts, meta.measure)
+	}
+
+	// the resources are only read in the namespace of the scaled object, all of them if no selector is given
+	meta.namespace = config.ScalableObjectNamespace
+	meta.selector, err = labels.Parse(config.TriggerMetadata["labelSelector"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid labelSelector: %s", err)
+	}
+
+	meta.value, err = strconv.ParseFloat(config.TriggerMetadata[valueKey], 64)
+	if err != nil || meta.value <= 0 {
+		return nil, fmt.Errorf("value must be a float greater than 0")
+	}
+
+	meta.activationValue = 0
+	if val, ok := config.TriggerMetadata[activationValueKey]; ok {
+		meta.activationValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("activationValue must be a float")
+		}
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// getMetricValue counts the migrations or virtual machines matching the selector which are pending
+func (s *kubeVirtScaler) getMetricValue(ctx context.Context) (int64, error) {
+	list := &unstructured.UnstructuredList{}
+	statusField := "phase"
+	if s.metadata.measure == kubeVirtMeasurePendingStarts {
+		list.SetGroupVersionKind(kubeVirtVMListGVK)
+		statusField = "printableStatus"
+	} else {
+		list.SetGroupVersionKind(kubeVirtMigrationListGVK)
+	}
+
+	err := s.kubeClient.List(ctx, list, client.InNamespace(s.metadata.namespace), client.MatchingLabelsSelector{Selector: s.metadata.selector})
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	for _, item := range list.Items {
+		status, _, err := unstructured.NestedString(item.Object, "status", statusField)
+		if err != nil {
+			return 0, fmt.Errorf("error reading status of %s: %s", item.GetName(), err)
+		}
+		if s.metadata.measure == kubeVirtMeasurePendingStarts {
+			if kubeVirtPendingStartStatuses[status] {
+				count++
+			}
+		} else if !kubeVirtFinalMigrationPhases[status] {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *kubeVirtScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error inspecting kubevirt resources: %s", err)
+	}
+
+	return float64(value) > s.metadata.activationValue, nil
+}
+
+func (s *kubeVirtScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *kubeVirtScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	metricName := fmt.Sprintf("kubevirt-%s-%s", s.metadata.namespace, s.metadata.measure)
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: kubeVirtMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *kubeVirtScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		return []external_metrics.ExternalMetricValue{}, fmt.Errorf("error inspecting kubevirt resources: %s", err)
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(value))
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
@@ -0,0 +1,127 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type parseKubeVirtMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type kubeVirtMetricIdentifier struct {
+	metadataTestData *parseKubeVirtMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testKubeVirtMetadata = []parseKubeVirtMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// properly formed pending migrations
+	{map[string]string{"value": "2"}, false},
+	// properly formed pending starts with selector
+	{map[string]string{"measure": "pendingStarts", "labelSelector": "tier=gpu", "value": "5", "activationValue": "1"}, false},
+	// invalid measure
+	{map[string]string{"measure": "runningVMs", "value": "5"}, true},
+	// invalid selector
+	{map[string]string{"labelSelector": "tier in gpu", "value": "5"}, true},
+	// invalid value
+	{map[string]string{"value": "a"}, true},
+	// zero value
+	{map[string]string{"value": "0"}, true},
+	// invalid activationValue
+	{map[string]string{"value": "5", "activationValue": "a"}, true},
+}
+
+var kubeVirtMetricIdentifiers = []kubeVirtMetricIdentifier{
+	{&testKubeVirtMetadata[1], 0, "s0-kubevirt-vms-pendingMigrations"},
+	{&testKubeVirtMetadata[2], 1, "s1-kubevirt-vms-pendingStarts"},
+}
+
+func TestKubeVirtParseMetadata(t *testing.T) {
+	for _, testData := range testKubeVirtMetadata {
+		_, err := parseKubeVirtMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: "vms"})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success. testData: %v", testData)
+		}
+	}
+}
+
+func TestKubeVirtGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range kubeVirtMetricIdentifiers {
+		meta, err := parseKubeVirtMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
+			ScalableObjectNamespace: "vms", ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockKubeVirtScaler := kubeVirtScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockKubeVirtScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func newKubeVirtTestObject(kind, namespace, name string, objectLabels map[string]interface{}, statusField, status string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kubevirt.io/v1",
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace, "labels": objectLabels},
+		"status":     map[string]interface{}{statusField: status},
+	}}
+}
+
+func TestKubeVirtGetMetricValue(t *testing.T) {
+	gpu := map[string]interface{}{"tier": "gpu"}
+	cpu := map[string]interface{}{"tier": "cpu"}
+	objects := []runtime.Object{
+		newKubeVirtTestObject("VirtualMachineInstanceMigration", "vms", "m1", gpu, "phase", "Scheduling"),
+		newKubeVirtTestObject("VirtualMachineInstanceMigration", "vms", "m2", gpu, "phase", "Running"),
+		newKubeVirtTestObject("VirtualMachineInstanceMigration", "vms", "m3", gpu, "phase", "Succeeded"),
+		newKubeVirtTestObject("VirtualMachineInstanceMigration", "vms", "m4", cpu, "phase", ""),
+		newKubeVirtTestObject("VirtualMachineInstanceMigration", "other", "m5", gpu, "phase", "Pending"),
+		newKubeVirtTestObject("VirtualMachine", "vms", "vm1", gpu, "printableStatus", "Starting"),
+		newKubeVirtTestObject("VirtualMachine", "vms", "vm2", gpu, "printableStatus", "Running"),
+		newKubeVirtTestObject("VirtualMachine", "vms", "vm3", cpu, "printableStatus", "ErrorUnschedulable"),
+		newKubeVirtTestObject("VirtualMachine", "vms", "vm4", gpu, "printableStatus", "Stopped"),
+	}
+
+	testCases := []struct {
+		metadata      map[string]string
+		expectedValue int64
+	}{
+		{map[string]string{"value": "1"}, 3},
+		{map[string]string{"labelSelector": "tier=gpu", "value": "1"}, 2},
+		{map[string]string{"measure": "pendingStarts", "value": "1"}, 2},
+		{map[string]string{"measure": "pendingStarts", "labelSelector": "tier=gpu", "value": "1"}, 1},
+	}
+
+	for _, testCase := range testCases {
+		meta, err := parseKubeVirtMetadata(&ScalerConfig{TriggerMetadata: testCase.metadata, ScalableObjectNamespace: "vms"})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := kubeVirtScaler{
+			metadata:   meta,
+			kubeClient: fake.NewClientBuilder().WithRuntimeObjects(objects...).Build(),
+			logger:     logr.Discard(),
+		}
+
+		value, err := scaler.getMetricValue(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedValue, value, testCase.metadata)
+	}
+}
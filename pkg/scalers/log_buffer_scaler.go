@@ -0,0 +1,267 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	logBufferMetricType = "External"
+
+	// the internal metrics of Vector, as exposed by its prometheus_exporter sink
+	vectorBufferEventsMetric   = "vector_buffer_events"
+	vectorBufferByteSizeMetric = "vector_buffer_byte_size"
+	vectorComponentIDLabel     = "component_id"
+)
+
+type logBufferAgent string
+
+const (
+	// logBufferAgentVector reads the buffers of the Vector components from the Prometheus metrics
+	logBufferAgentVector logBufferAgent = "vector"
+	// logBufferAgentFluentBit reads the chunks of the Fluent Bit inputs from the storage monitoring endpoint
+	logBufferAgentFluentBit logBufferAgent = "fluentBit"
+)
+
+type logBufferMeasure string
+
+const (
+	logBufferMeasureEvents logBufferMeasure = "events"
+	logBufferMeasureBytes  logBufferMeasure = "bytes"
+	logBufferMeasureChunks logBufferMeasure = "chunks"
+)
+
+type logBufferScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *logBufferMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type logBufferMetadata struct {
+	agent                 logBufferAgent
+	url                   string
+	measure               logBufferMeasure
+	component             string
+	targetValue           float64
+	activationTargetValue float64
+	unsafeSsl             bool
+	scalerIndex           int
+}
+
+type fluentBitStorageResponse struct {
+	StorageLayer struct {
+		Chunks struct {
+			TotalChunks int64 `json:"total_chunks"`
+		} `json:"chunks"`
+	} `json:"storage_layer"`
+	InputChunks map[string]struct {
+		Chunks struct {
+			Total int64 `json:"total"`
+		} `json:"chunks"`
+	} `json:"input_chunks"`
+}
+
+// NewLogBufferScaler creates a new logBufferScaler
+func NewLogBufferScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseLogBufferMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing log buffer metadata: %s", err)
+	}
+
+	return &logBufferScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: createHTTPClient(config, meta.unsafeSsl),
+		logger:     InitializeLogger(config, "log_buffer_scaler"),
+	}, nil
+}
+
+func parseLogBufferMetadata(config *ScalerConfig) (*logBufferMetadata, error) {
+	meta := logBufferMetadata{}
+	var err error
+
+	meta.agent = logBufferAgent(config.TriggerMetadata["agent"])
+	switch meta.agent {
+	case logBufferAgentVector:
+		meta.measure = logBufferMeasureEvents
+	case logBufferAgentFluentBit:
+		meta.measure = logBufferMeasureChunks
+	default:
+		return nil, fmt.Errorf("agent must be one of %s, %s but is %s", logBufferAgentVector, logBufferAgentFluentBit, meta.agent)
+	}
+
+	meta.url, err = GetFromAuthOrMeta(config, "url")
+	if err != nil {
+		return nil, err
+	}
+
+	if val, ok := config.TriggerMetadata["measure"]; ok && val != "" {
+		meta.measure = logBufferMeasure(val)
+	}
+	switch {
+	case meta.agent == logBufferAgentVector && (meta.measure == logBufferMeasureEvents || meta.measure == logBufferMeasureBytes):
+	case meta.agent == logBufferAgentFluentBit && meta.measure == logBufferMeasureChunks:
+	default:
+		return nil, fmt.Errorf("measure %s isn't supported for agent %s", meta.measure, meta.agent)
+	}
+
+	// the id of the Vector component or the name of the Fluent Bit input, all of them are summed if not set
+	meta.component = config.TriggerMetadata["component"]
+
+	val, err := getParameterFromConfig(config, "targetValue", false)
+	if err != nil {
+		return nil, err
+	}
+	meta.targetValue, err = strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing targetValue: %s", err)
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok && val != "" {
+		meta.activationTargetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetValue: %s", err)
+		}
+	}
+
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func (s *logBufferScaler) getBody(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: api returned %d", req.URL.Path, resp.StatusCode)
+	}
+	return body, nil
+}
+
+// getVectorBuffer sums the buffered events or bytes of the Vector components
+func getVectorBuffer(body []byte, measure logBufferMeasure, component string) (float64, error) {
+	name := vectorBufferEventsMetric
+	if measure == logBufferMeasureBytes {
+		name = vectorBufferByteSizeMetric
+	}
+
+	seriesLabels := map[string]string{}
+	if component != "" {
+		seriesLabels[vectorComponentIDLabel] = component
+	}
+	sum, ok, err := getPrometheusExpositionSum(body, name, seriesLabels)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("vector metrics have no %s, check the internal_metrics source is exported", name)
+	}
+	return sum, nil
+}
+
+// getFluentBitChunks returns the chunks of the Fluent Bit input, or of the whole storage layer
+func getFluentBitChunks(body []byte, component string) (float64, error) {
+	var storage fluentBitStorageResponse
+	if err := json.Unmarshal(body, &storage); err != nil {
+		return 0, fmt.Errorf("error parsing fluent bit storage: %s", err)
+	}
+
+	if component == "" {
+		return float64(storage.StorageLayer.Chunks.TotalChunks), nil
+	}
+	input, ok := storage.InputChunks[component]
+	if !ok {
+		return 0, fmt.Errorf("fluent bit storage has no input %s", component)
+	}
+	return float64(input.Chunks.Total), nil
+}
+
+func (s *logBufferScaler) getBuffer(ctx context.Context) (float64, error) {
+	body, err := s.getBody(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.metadata.agent == logBufferAgentFluentBit {
+		return getFluentBitChunks(body, s.metadata.component)
+	}
+	return getVectorBuffer(body, s.metadata.measure, s.metadata.component)
+}
+
+func (s *logBufferScaler) IsActive(ctx context.Context) (bool, error) {
+	buffer, err := s.getBuffer(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting log buffer")
+		return false, err
+	}
+
+	return buffer > s.metadata.activationTargetValue, nil
+}
+
+func (s *logBufferScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *logBufferScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	metricName := fmt.Sprintf("log-buffer-%s-%s", s.metadata.agent, s.metadata.measure)
+	if s.metadata.component != "" {
+		metricName = fmt.Sprintf("%s-%s", metricName, s.metadata.component)
+	}
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: logBufferMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *logBufferScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	buffer, err := s.getBuffer(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting log buffer")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, buffer)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
@@ -0,0 +1,146 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+type parseLogBufferMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type logBufferMetricIdentifier struct {
+	metadataTestData *parseLogBufferMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testLogBufferMetadata = []parseLogBufferMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// properly formed vector
+	{map[string]string{"agent": "vector", "url": "http://vector:9598/metrics", "targetValue": "1000"}, false},
+	// properly formed fluent bit input
+	{map[string]string{"agent": "fluentBit", "url": "http://fluent-bit:2020/api/v1/storage", "component": "tail.0", "targetValue": "10", "activationTargetValue": "1"}, false},
+	// vector bytes of a component
+	{map[string]string{"agent": "vector", "url": "http://vector:9598/metrics", "measure": "bytes", "component": "elasticsearch", "targetValue": "1048576"}, false},
+	// invalid agent
+	{map[string]string{"agent": "fluentd", "url": "http://fluentd:24220/api/plugins.json", "targetValue": "10"}, true},
+	// missing url
+	{map[string]string{"agent": "vector", "targetValue": "1000"}, true},
+	// chunks with vector
+	{map[string]string{"agent": "vector", "url": "http://vector:9598/metrics", "measure": "chunks", "targetValue": "1000"}, true},
+	// events with fluent bit
+	{map[string]string{"agent": "fluentBit", "url": "http://fluent-bit:2020/api/v1/storage", "measure": "events", "targetValue": "10"}, true},
+	// missing targetValue
+	{map[string]string{"agent": "vector", "url": "http://vector:9598/metrics"}, true},
+	// invalid targetValue
+	{map[string]string{"agent": "vector", "url": "http://vector:9598/metrics", "targetValue": "A"}, true},
+	// invalid activationTargetValue
+	{map[string]string{"agent": "vector", "url": "http://vector:9598/metrics", "targetValue": "1000", "activationTargetValue": "A"}, true},
+	// invalid unsafeSsl
+	{map[string]string{"agent": "vector", "url": "http://vector:9598/metrics", "targetValue": "1000", "unsafeSsl": "A"}, true},
+}
+
+var logBufferMetricIdentifiers = []logBufferMetricIdentifier{
+	{&testLogBufferMetadata[1], 0, "s0-log-buffer-vector-events"},
+	{&testLogBufferMetadata[2], 1, "s1-log-buffer-fluentBit-chunks-tail-0"},
+}
+
+const testVectorMetrics = `# HELP vector_buffer_events buffer_events
+# TYPE vector_buffer_events gauge
+vector_buffer_events{buffer_type="disk",component_id="elasticsearch",component_kind="sink"} 1200
+vector_buffer_events{buffer_type="memory",component_id="s3",component_kind="sink"} 300
+# HELP vector_buffer_byte_size buffer_byte_size
+# TYPE vector_buffer_byte_size gauge
+vector_buffer_byte_size{buffer_type="disk",component_id="elasticsearch",component_kind="sink"} 524288
+vector_buffer_byte_size{buffer_type="memory",component_id="s3",component_kind="sink"} 1024
+`
+
+const testFluentBitStorage = `{"storage_layer":{"chunks":{"total_chunks":12,"mem_chunks":4,"fs_chunks":8,"fs_chunks_up":3,"fs_chunks_down":5}},
+"input_chunks":{"tail.0":{"status":{"overlimit":false,"mem_size":"1.2M","mem_limit":"0b"},"chunks":{"total":9,"up":4,"down":5,"busy":2,"busy_size":"1.1M"}},
+"systemd.1":{"status":{"overlimit":false,"mem_size":"0b","mem_limit":"0b"},"chunks":{"total":3,"up":0,"down":3,"busy":0,"busy_size":"0b"}}}}`
+
+func TestLogBufferParseMetadata(t *testing.T) {
+	for _, testData := range testLogBufferMetadata {
+		_, err := parseLogBufferMetadata(&ScalerConfig{TriggerMetadata: testData.metadata})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success. testData: %v", testData)
+		}
+	}
+}
+
+func TestLogBufferGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range logBufferMetricIdentifiers {
+		meta, err := parseLogBufferMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockLogBufferScaler := logBufferScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockLogBufferScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestLogBufferGetBuffer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body string
+		switch request.URL.Path {
+		case "/metrics":
+			body = testVectorMetrics
+		case "/api/v1/storage":
+			body = testFluentBitStorage
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, err := writer.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		metadata      map[string]string
+		expectedValue float64
+		isError       bool
+	}{
+		{map[string]string{"agent": "vector", "url": server.URL + "/metrics"}, 1500, false},
+		{map[string]string{"agent": "vector", "url": server.URL + "/metrics", "component": "s3"}, 300, false},
+		{map[string]string{"agent": "vector", "url": server.URL + "/metrics", "measure": "bytes", "component": "elasticsearch"}, 524288, false},
+		{map[string]string{"agent": "fluentBit", "url": server.URL + "/api/v1/storage"}, 12, false},
+		{map[string]string{"agent": "fluentBit", "url": server.URL + "/api/v1/storage", "component": "tail.0"}, 9, false},
+		{map[string]string{"agent": "fluentBit", "url": server.URL + "/api/v1/storage", "component": "tail.1"}, 0, true},
+		{map[string]string{"agent": "fluentBit", "url": server.URL + "/api/v2/storage"}, 0, true},
+	}
+
+	for _, testCase := range testCases {
+		testCase.metadata["targetValue"] = "10"
+		meta, err := parseLogBufferMetadata(&ScalerConfig{TriggerMetadata: testCase.metadata})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := logBufferScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+		value, err := scaler.getBuffer(context.Background())
+		if testCase.isError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedValue, value)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	url_pkg "net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
+)
+
+const (
+	lokiServerAddress       = "serverAddress"
+	lokiQuery               = "query"
+	lokiThreshold           = "threshold"
+	lokiActivationThreshold = "activationThreshold"
+	lokiTenantName          = "tenantName"
+	lokiTenantHeaderKey     = "X-Scope-OrgID"
+	lokiIgnoreNullValues    = "ignoreNullValues"
+)
+
+type lokiScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *lokiMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type lokiMetadata struct {
+	serverAddress       string
+	query               string
+	threshold           float64
+	activationThreshold float64
+	tenantName          string
+	// ignore empty results, e.g. when no log line matched the query, and report 0 instead
+	ignoreNullValues bool
+	lokiAuth         *authentication.AuthMeta
+	scalerIndex      int
+}
+
+type lokiQueryResult struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type lokiVectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+}
+
+// NewLokiScaler creates a new lokiScaler
+func NewLokiScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	logger := InitializeLogger(config, "loki_scaler")
+
+	meta, err := parseLokiMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing loki metadata: %s", err)
+	}
+
+	httpClient := createHTTPClient(config, false)
+
+	if meta.lokiAuth != nil && (meta.lokiAuth.CA != "" || meta.lokiAuth.EnableTLS) {
+		// create http.RoundTripper with auth settings from ScalerConfig
+		if httpClient.Transport, err = authentication.CreateHTTPRoundTripper(
+			authentication.NetHTTP,
+			meta.lokiAuth,
+		); err != nil {
+			logger.V(1).Error(err, "init Loki client http transport")
+			return nil, err
+		}
+	}
+
+	return &lokiScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     logger,
+	}, nil
+}
+
+func parseLokiMetadata(config *ScalerConfig) (meta *lokiMetadata, err error) {
+	meta = &lokiMetadata{}
+
+	if val, ok := config.TriggerMetadata[lokiServerAddress]; ok && val != "" {
+		meta.serverAddress = strings.TrimSuffix(val, "/")
+	} else {
+		return nil, fmt.Errorf("no %s given", lokiServerAddress)
+	}
+
+	if val, ok := config.TriggerMetadata[lokiQuery]; ok && val != "" {
+		meta.query = val
+	} else {
+		return nil, fmt.Errorf("no %s given", lokiQuery)
+	}
+
+	if val, ok := config.TriggerMetadata[lokiThreshold]; ok && val != "" {
+		t, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %s", lokiThreshold, err)
+		}
+
+		meta.threshold = t
+	} else {
+		return nil, fmt.Errorf("no %s given", lokiThreshold)
+	}
+
+	meta.activationThreshold = 0
+	if val, ok := config.TriggerMetadata[lokiActivationThreshold]; ok {
+		t, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("activationThreshold parsing error %s", err.Error())
+		}
+
+		meta.activationThreshold = t
+	}
+
+	if val, ok := config.TriggerMetadata[lokiTenantName]; ok && val != "" {
+		meta.tenantName = val
+	}
+
+	meta.ignoreNullValues = true
+	if val, ok := config.TriggerMetadata[lokiIgnoreNullValues]; ok && val != "" {
+		ignoreNullValues, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("err incorrect value for ignoreNullValues given: %s, "+
+				"please use true or false", val)
+		}
+		meta.ignoreNullValues = ignoreNullValues
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	// parse auth configs from ScalerConfig
+	meta.lokiAuth, err = authentication.GetAuthConfigs(config.TriggerMetadata, config.AuthParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
+
+func (s *lokiScaler) IsActive(ctx context.Context) (bool, error) {
+	val, err := s.ExecuteLokiQuery(ctx)
+	if err != nil {
+		s.logger.Error(err, "error executing loki query")
+		return false, err
+	}
+
+	return val > s.metadata.activationThreshold, nil
+}
+
+func (s *lokiScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (s *lokiScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, "loki"),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.threshold),
+	}
+	metricSpec := v2beta2.MetricSpec{
+		External: externalMetric, Type: externalMetricType,
+	}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+// ExecuteLokiQuery runs the LogQL query as an instant query and returns its single value
+func (s *lokiScaler) ExecuteLokiQuery(ctx context.Context) (float64, error) {
+	u, err := url_pkg.Parse(s.metadata.serverAddress + "/loki/api/v1/query")
+	if err != nil {
+		return -1, err
+	}
+	queryParameters := url_pkg.Values{}
+	queryParameters.Set("query", s.metadata.query)
+	queryParameters.Set("time", strconv.FormatInt(time.Now().UnixNano(), 10))
+	u.RawQuery = queryParameters.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return -1, err
+	}
+
+	if s.metadata.lokiAuth != nil && s.metadata.lokiAuth.EnableBearerAuth {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", s.metadata.lokiAuth.BearerToken))
+	} else if s.metadata.lokiAuth != nil && s.metadata.lokiAuth.EnableBasicAuth {
+		req.SetBasicAuth(s.metadata.lokiAuth.Username, s.metadata.lokiAuth.Password)
+	}
+
+	if s.metadata.tenantName != "" {
+		req.Header.Add(lokiTenantHeaderKey, s.metadata.tenantName)
+	}
+
+	r, err := s.httpClient.Do(req)
+	if err != nil {
+		return -1, err
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return -1, err
+	}
+	_ = r.Body.Close()
+
+	if !(r.StatusCode >= 200 && r.StatusCode <= 299) {
+		err := fmt.Errorf("loki query api returned error. status: %d response: %s", r.StatusCode, string(b))
+		s.logger.Error(err, "loki query api returned error")
+		return -1, err
+	}
+
+	var result lokiQueryResult
+	if err := json.Unmarshal(b, &result); err != nil {
+		return -1, err
+	}
+
+	var value []interface{}
+	switch result.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(result.Data.Result, &value); err != nil {
+			return -1, err
+		}
+	case "vector":
+		var samples []lokiVectorSample
+		if err := json.Unmarshal(result.Data.Result, &samples); err != nil {
+			return -1, err
+		}
+		// allow for zero element or single element result sets
+		if len(samples) == 0 {
+			if s.metadata.ignoreNullValues {
+				return 0, nil
+			}
+			return -1, fmt.Errorf("loki query %s returned an empty result", s.metadata.query)
+		} else if len(samples) > 1 {
+			return -1, fmt.Errorf("loki query %s returned multiple elements", s.metadata.query)
+		}
+		value = samples[0].Value
+	default:
+		return -1, fmt.Errorf("loki query %s returned %s instead of a vector or a scalar, only metric queries are supported", s.metadata.query, result.Data.ResultType)
+	}
+
+	if len(value) < 2 {
+		return -1, fmt.Errorf("loki query %s didn't return enough values", s.metadata.query)
+	}
+
+	str, ok := value[1].(string)
+	if !ok {
+		return -1, errors.New("loki returned a value which is not a string")
+	}
+	v, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		s.logger.Error(err, "Error converting loki value", "loki_value", str)
+		return -1, err
+	}
+
+	return v, nil
+}
+
+func (s *lokiScaler) GetMetrics(ctx context.Context, metricName string, _ labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	val, err := s.ExecuteLokiQuery(ctx)
+	if err != nil {
+		s.logger.Error(err, "error executing loki query")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, val)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
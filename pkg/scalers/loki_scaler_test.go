@@ -0,0 +1,187 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+type parseLokiMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type lokiMetricIdentifier struct {
+	metadataTestData *parseLokiMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testLokiMetadata = []parseLokiMetadataTestData{
+	{map[string]string{}, map[string]string{}, true},
+	// all properly formed
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "10", "query": `sum(rate({app="checkout"} |= "error" [1m]))`}, map[string]string{}, false},
+	// all properly formed, with tenantName, activationThreshold and ignoreNullValues
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "10", "activationThreshold": "1", "query": `sum(rate({app="checkout"}[1m]))`, "tenantName": "team-a", "ignoreNullValues": "false"}, map[string]string{}, false},
+	// missing serverAddress
+	{map[string]string{"serverAddress": "", "threshold": "10", "query": `sum(rate({app="checkout"}[1m]))`}, map[string]string{}, true},
+	// missing query
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "10", "query": ""}, map[string]string{}, true},
+	// missing threshold
+	{map[string]string{"serverAddress": "http://localhost:3100", "query": `sum(rate({app="checkout"}[1m]))`}, map[string]string{}, true},
+	// malformed threshold
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "one", "query": `sum(rate({app="checkout"}[1m]))`}, map[string]string{}, true},
+	// malformed activationThreshold
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "10", "activationThreshold": "one", "query": `sum(rate({app="checkout"}[1m]))`}, map[string]string{}, true},
+	// ignoreNullValues with wrong value
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "10", "query": `sum(rate({app="checkout"}[1m]))`, "ignoreNullValues": "xxxx"}, map[string]string{}, true},
+	// bearer auth
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "10", "query": `sum(rate({app="checkout"}[1m]))`, "authModes": "bearer"}, map[string]string{"bearerToken": "token"}, false},
+	// basic auth with custom CA
+	{map[string]string{"serverAddress": "https://localhost:3100", "threshold": "10", "query": `sum(rate({app="checkout"}[1m]))`, "authModes": "basic"}, map[string]string{"username": "user", "password": "pass", "ca": "caaa"}, false},
+	// bearer auth without token
+	{map[string]string{"serverAddress": "http://localhost:3100", "threshold": "10", "query": `sum(rate({app="checkout"}[1m]))`, "authModes": "bearer"}, map[string]string{}, true},
+}
+
+var lokiMetricIdentifiers = []lokiMetricIdentifier{
+	{&testLokiMetadata[1], 0, "s0-loki"},
+	{&testLokiMetadata[1], 1, "s1-loki"},
+}
+
+func TestLokiParseMetadata(t *testing.T) {
+	for _, testData := range testLokiMetadata {
+		_, err := parseLokiMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestLokiGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range lokiMetricIdentifiers {
+		meta, err := parseLokiMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockLokiScaler := lokiScaler{
+			metadata: meta,
+			logger:   logr.Discard(),
+		}
+
+		metricSpec := mockLokiScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+type lokiQueryResultTestData struct {
+	name             string
+	bodyStr          string
+	responseStatus   int
+	expectedValue    float64
+	isError          bool
+	ignoreNullValues bool
+}
+
+var testLokiQueryResult = []lokiQueryResultTestData{
+	{
+		name:             "vector with a single element",
+		bodyStr:          `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1660000000,"2.5"]}]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    2.5,
+		isError:          false,
+		ignoreNullValues: true,
+	},
+	{
+		name:             "scalar",
+		bodyStr:          `{"status":"success","data":{"resultType":"scalar","result":[1660000000,"4"]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    4,
+		isError:          false,
+		ignoreNullValues: true,
+	},
+	{
+		name:             "empty vector",
+		bodyStr:          `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    0,
+		isError:          false,
+		ignoreNullValues: true,
+	},
+	{
+		name:             "empty vector without ignoring null values",
+		bodyStr:          `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    -1,
+		isError:          true,
+		ignoreNullValues: false,
+	},
+	{
+		name:             "vector with multiple elements",
+		bodyStr:          `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"app":"a"},"value":[1660000000,"1"]},{"metric":{"app":"b"},"value":[1660000000,"2"]}]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    -1,
+		isError:          true,
+		ignoreNullValues: true,
+	},
+	{
+		name:             "log query",
+		bodyStr:          `{"status":"success","data":{"resultType":"streams","result":[]}}`,
+		responseStatus:   http.StatusOK,
+		expectedValue:    -1,
+		isError:          true,
+		ignoreNullValues: true,
+	},
+	{
+		name:             "error status code",
+		bodyStr:          `parse error`,
+		responseStatus:   http.StatusBadRequest,
+		expectedValue:    -1,
+		isError:          true,
+		ignoreNullValues: true,
+	},
+}
+
+func TestLokiScalerExecuteLokiQuery(t *testing.T) {
+	for _, testData := range testLokiQueryResult {
+		t.Run(testData.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				assert.Equal(t, "/loki/api/v1/query", request.URL.Path)
+				assert.Equal(t, "team-a", request.Header.Get("X-Scope-OrgID"))
+				writer.WriteHeader(testData.responseStatus)
+				_, _ = writer.Write([]byte(testData.bodyStr))
+			}))
+			defer server.Close()
+
+			scaler := lokiScaler{
+				metadata: &lokiMetadata{
+					serverAddress:    server.URL,
+					query:            `sum(rate({app="checkout"}[1m]))`,
+					tenantName:       "team-a",
+					ignoreNullValues: testData.ignoreNullValues,
+				},
+				httpClient: http.DefaultClient,
+				logger:     logr.Discard(),
+			}
+
+			value, err := scaler.ExecuteLokiQuery(context.TODO())
+
+			assert.Equal(t, testData.expectedValue, value)
+			if testData.isError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	memcachedMetricType     = "External"
+	defaultMemcachedTimeout = 3 * time.Second
+	// memcachedMaxKeyLength is the maximum key length of the memcached text protocol
+	memcachedMaxKeyLength = 250
+	// memcachedMaxValueSize is the default item size limit of memcached, larger values aren't counters
+	memcachedMaxValueSize = 1024 * 1024
+)
+
+type memcachedScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *memcachedMetadata
+	logger     logr.Logger
+}
+
+type memcachedMetadata struct {
+	address         string
+	key             string
+	value           float64
+	activationValue float64
+	timeout         time.Duration
+	scalerIndex     int
+}
+
+// NewMemcachedScaler creates a new memcachedScaler
+func NewMemcachedScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseMemcachedMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing memcached metadata: %s", err)
+	}
+
+	return &memcachedScaler{
+		metricType: metricType,
+		metadata:   meta,
+		logger:     InitializeLogger(config, "memcached_scaler"),
+	}, nil
+}
+
+func parseMemcachedMetadata(config *ScalerConfig) (*memcachedMetadata, error) {
+	meta := memcachedMetadata{}
+	var err error
+
+	meta.address, err = GetFromAuthOrMeta(config, "address")
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := net.SplitHostPort(meta.address); err != nil {
+		return nil, fmt.Errorf("address must be given as host:port: %s", err)
+	}
+
+	meta.key = config.TriggerMetadata["key"]
+	if meta.key == "" {
+		return nil, errors.New("no key given")
+	}
+	if len(meta.key) > memcachedMaxKeyLength || strings.IndexFunc(meta.key, func(r rune) bool { return r <= ' ' || r == 0x7f }) != -1 {
+		return nil, fmt.Errorf("key must be at most %d characters without whitespace or control characters", memcachedMaxKeyLength)
+	}
+
+	if val, ok := config.TriggerMetadata["value"]; ok {
+		value, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing value: %s", err)
+		}
+		meta.value = value
+	} else {
+		return nil, errors.New("no value given")
+	}
+
+	meta.activationValue = 0
+	if val, ok := config.TriggerMetadata["activationValue"]; ok {
+		activationValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationValue: %s", err)
+		}
+		meta.activationValue = activationValue
+	}
+
+	meta.timeout = defaultMemcachedTimeout
+	if config.GlobalHTTPTimeout > 0 {
+		meta.timeout = config.GlobalHTTPTimeout
+	}
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func (s *memcachedScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getCounter(ctx)
+	if err != nil {
+		s.logger.Error(err, "error reading memcached key")
+		return false, err
+	}
+
+	return value > s.metadata.activationValue, nil
+}
+
+func (s *memcachedScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *memcachedScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("memcached-%s", s.metadata.key))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: memcachedMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *memcachedScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getCounter(ctx)
+	if err != nil {
+		s.logger.Error(err, "error reading memcached key")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// getCounter reads the key with the memcached text protocol and returns its numeric value,
+// or 0 if the key doesn't exist
+func (s *memcachedScaler) getCounter(ctx context.Context) (float64, error) {
+	dialer := &net.Dialer{Timeout: s.metadata.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.metadata.address)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(s.metadata.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	if _, err := fmt.Fprintf(conn, "get %s\r\n", s.metadata.key); err != nil {
+		return 0, err
+	}
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "END" {
+		return 0, nil
+	}
+
+	// VALUE <key> <flags> <bytes>
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[0] != "VALUE" {
+		return 0, fmt.Errorf("unexpected memcached response: %s", line)
+	}
+	size, err := strconv.Atoi(fields[3])
+	if err != nil || size < 0 || size > memcachedMaxValueSize {
+		return 0, fmt.Errorf("unexpected memcached response: %s", line)
+	}
+	data := make([]byte, size+2)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return 0, err
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(data[:size])), 64)
+	if err != nil {
+		return 0, fmt.Errorf("value of key %s must be a number, got %s", s.metadata.key, string(data[:size]))
+	}
+	return value, nil
+}
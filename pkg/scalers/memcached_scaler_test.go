@@ -0,0 +1,141 @@
+package scalers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+type parseMemcachedMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type memcachedMetricIdentifier struct {
+	metadataTestData *parseMemcachedMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testMemcachedMetadata = []parseMemcachedMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed
+	{map[string]string{"address": "memcached:11211", "key": "jobs:pending", "value": "10"}, map[string]string{}, false},
+	// properly formed with address from auth params
+	{map[string]string{"key": "jobs", "value": "2.5", "activationValue": "1"}, map[string]string{"address": "memcached:11211"}, false},
+	// address without port
+	{map[string]string{"address": "memcached", "key": "jobs", "value": "10"}, map[string]string{}, true},
+	// missing key
+	{map[string]string{"address": "memcached:11211", "value": "10"}, map[string]string{}, true},
+	// key with whitespace
+	{map[string]string{"address": "memcached:11211", "key": "pending jobs", "value": "10"}, map[string]string{}, true},
+	// missing value
+	{map[string]string{"address": "memcached:11211", "key": "jobs"}, map[string]string{}, true},
+	// invalid activationValue
+	{map[string]string{"address": "memcached:11211", "key": "jobs", "value": "10", "activationValue": "a"}, map[string]string{}, true},
+}
+
+var memcachedMetricIdentifiers = []memcachedMetricIdentifier{
+	{&testMemcachedMetadata[1], 0, "s0-memcached-jobs-pending"},
+	{&testMemcachedMetadata[2], 1, "s1-memcached-jobs"},
+}
+
+func TestMemcachedParseMetadata(t *testing.T) {
+	for _, testData := range testMemcachedMetadata {
+		_, err := parseMemcachedMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestMemcachedGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range memcachedMetricIdentifiers {
+		meta, err := parseMemcachedMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockMemcachedScaler := memcachedScaler{
+			metadata: meta,
+			logger:   logr.Discard(),
+		}
+
+		metricSpec := mockMemcachedScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestMemcachedGetCounter(t *testing.T) {
+	values := map[string]string{"counter": "42", "float": " 7.5", "text": "many"}
+	// replies announcing a size the value doesn't have
+	badSizes := map[string]string{"negative": "-1", "huge": "4294967296"}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Could not listen:", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			line, _ := bufio.NewReader(conn).ReadString('\n')
+			key := strings.TrimSpace(strings.TrimPrefix(line, "get "))
+			if size, ok := badSizes[key]; ok {
+				_, _ = fmt.Fprintf(conn, "VALUE %s 0 %s\r\n1\r\nEND\r\n", key, size)
+			} else if value, ok := values[key]; ok {
+				_, _ = fmt.Fprintf(conn, "VALUE %s 0 %d\r\n%s\r\nEND\r\n", key, len(value), value)
+			} else {
+				_, _ = fmt.Fprint(conn, "END\r\n")
+			}
+			conn.Close()
+		}
+	}()
+
+	testCases := []struct {
+		key      string
+		expected float64
+		isError  bool
+	}{
+		{"counter", 42, false},
+		{"float", 7.5, false},
+		{"missing", 0, false},
+		{"text", 0, true},
+		{"negative", 0, true},
+		{"huge", 0, true},
+	}
+
+	for _, tc := range testCases {
+		meta, err := parseMemcachedMetadata(&ScalerConfig{TriggerMetadata: map[string]string{"address": listener.Addr().String(), "key": tc.key, "value": "10"}})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := memcachedScaler{metadata: meta, logger: logr.Discard()}
+
+		value, err := scaler.getCounter(context.Background())
+		if err != nil && !tc.isError {
+			t.Errorf("Expected success for %s but got error %s", tc.key, err)
+		}
+		if tc.isError && err == nil {
+			t.Errorf("Expected error for %s but got success", tc.key)
+		}
+		if value != tc.expected {
+			t.Errorf("Expected %f for %s but got %f", tc.expected, tc.key, value)
+		}
+	}
+}
@@ -2,11 +2,13 @@ package scalers
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	neturl "net/url"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -15,17 +17,20 @@ import (
 	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/util/jsonpath"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/yaml"
 
 	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 type metricsAPIScaler struct {
-	metricType v2beta2.MetricTargetType
-	metadata   *metricsAPIScalerMetadata
-	client     *http.Client
-	logger     logr.Logger
+	metricType    v2beta2.MetricTargetType
+	metadata      *metricsAPIScalerMetadata
+	client        *http.Client
+	queryRecorder *QueryRecorder
+	logger        logr.Logger
 }
 
 type metricsAPIScalerMetadata struct {
@@ -33,6 +38,7 @@ type metricsAPIScalerMetadata struct {
 	activationTargetValue float64
 	url                   string
 	valueLocation         string
+	format                metricsAPIFormat
 
 	// apiKeyAuth
 	enableAPIKeyAuth bool
@@ -48,6 +54,7 @@ type metricsAPIScalerMetadata struct {
 	password       string // +optional
 
 	// client certification
+	unsafeSsl bool
 	enableTLS bool
 	cert      string
 	key       string
@@ -64,6 +71,20 @@ const (
 	methodValueQuery = "query"
 )
 
+// metricsAPIFormat is the format of the responses of the metrics API
+type metricsAPIFormat string
+
+const (
+	// metricsAPIFormatJSON responses are read with a GJSON path or a JSONPath expression starting with $ or {
+	metricsAPIFormatJSON metricsAPIFormat = "json"
+	// metricsAPIFormatYAML responses are converted to JSON and read as such
+	metricsAPIFormatYAML metricsAPIFormat = "yaml"
+	// metricsAPIFormatXML responses are read with an XPath expression
+	metricsAPIFormatXML metricsAPIFormat = "xml"
+)
+
+var metricsAPIMetricNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9-]+`)
+
 // NewMetricsAPIScaler creates a new HTTP scaler
 func NewMetricsAPIScaler(config *ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
@@ -76,22 +97,17 @@ func NewMetricsAPIScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing metric API metadata: %s", err)
 	}
 
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
-
-	if meta.enableTLS || len(meta.ca) > 0 {
-		config, err := kedautil.NewTLSConfig(meta.cert, meta.key, meta.ca)
-		if err != nil {
-			return nil, err
-		}
-
-		httpClient.Transport = &http.Transport{TLSClientConfig: config}
+	httpClient, err := createTLSHTTPClient(config, meta.unsafeSsl, tlsAuthParams{cert: meta.cert, key: meta.key, ca: meta.ca})
+	if err != nil {
+		return nil, err
 	}
 
 	return &metricsAPIScaler{
-		metricType: metricType,
-		metadata:   meta,
-		client:     httpClient,
-		logger:     InitializeLogger(config, "metrics_api_scaler"),
+		metricType:    metricType,
+		metadata:      meta,
+		client:        httpClient,
+		queryRecorder: config.QueryRecorder,
+		logger:        InitializeLogger(config, "metrics_api_scaler"),
 	}, nil
 }
 
@@ -99,6 +115,12 @@ func parseMetricsAPIMetadata(config *ScalerConfig) (*metricsAPIScalerMetadata, e
 	meta := metricsAPIScalerMetadata{}
 	meta.scalerIndex = config.ScalerIndex
 
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.unsafeSsl = unsafeSsl
+
 	if val, ok := config.TriggerMetadata["targetValue"]; ok {
 		targetValue, err := strconv.ParseFloat(val, 64)
 		if err != nil {
@@ -130,6 +152,21 @@ func parseMetricsAPIMetadata(config *ScalerConfig) (*metricsAPIScalerMetadata, e
 		return nil, fmt.Errorf("no valueLocation given in metadata")
 	}
 
+	meta.format = metricsAPIFormatJSON
+	if val, ok := config.TriggerMetadata["format"]; ok && val != "" {
+		meta.format = metricsAPIFormat(strings.ToLower(val))
+		switch meta.format {
+		case metricsAPIFormatJSON, metricsAPIFormatYAML, metricsAPIFormatXML:
+		default:
+			return nil, fmt.Errorf("format must be one of %s, %s, %s but is %s", metricsAPIFormatJSON, metricsAPIFormatYAML, metricsAPIFormatXML, val)
+		}
+	}
+	if meta.format != metricsAPIFormatXML && isJSONPath(meta.valueLocation) {
+		if _, err := parseJSONPath(meta.valueLocation); err != nil {
+			return nil, err
+		}
+	}
+
 	authMode, ok := config.TriggerMetadata["authMode"]
 	// no authMode specified
 	if !ok {
@@ -215,11 +252,87 @@ func GetValueFromResponse(body []byte, valueLocation string) (float64, error) {
 	return r.Num, nil
 }
 
+// getValueFromFormattedResponse reads the value at the valueLocation of the JSON, YAML or XML response
+func getValueFromFormattedResponse(body []byte, valueLocation string, format metricsAPIFormat) (float64, error) {
+	switch format {
+	case metricsAPIFormatXML:
+		return getXMLPathValue(body, valueLocation)
+	case metricsAPIFormatYAML:
+		var err error
+		body, err = yaml.YAMLToJSON(body)
+		if err != nil {
+			return 0, fmt.Errorf("error parsing YAML: %s", err)
+		}
+	}
+
+	if isJSONPath(valueLocation) {
+		return getJSONPathValue(body, valueLocation)
+	}
+	return GetValueFromResponse(body, valueLocation)
+}
+
+// isJSONPath tells JSONPath expressions, e.g. $.queues[?(@.name=="orders")].depth, from GJSON paths
+func isJSONPath(valueLocation string) bool {
+	return strings.HasPrefix(valueLocation, "$") || strings.HasPrefix(valueLocation, "{")
+}
+
+func parseJSONPath(valueLocation string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(valueLocation, "{") {
+		valueLocation = "{" + valueLocation + "}"
+	}
+	j := jsonpath.New("valueLocation")
+	if err := j.Parse(valueLocation); err != nil {
+		return nil, fmt.Errorf("error parsing valueLocation JSONPath: %s", err)
+	}
+	return j, nil
+}
+
+// getJSONPathValue returns the number or Quantity selected by the JSONPath expression, which must select a single value
+func getJSONPathValue(body []byte, valueLocation string) (float64, error) {
+	j, err := parseJSONPath(valueLocation)
+	if err != nil {
+		return 0, err
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return 0, fmt.Errorf("error parsing JSON: %s", err)
+	}
+	results, err := j.FindResults(data)
+	if err != nil {
+		return 0, err
+	}
+
+	var values []interface{}
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, value.Interface())
+		}
+	}
+	if len(values) != 1 {
+		return 0, fmt.Errorf("valueLocation %s must select a single value, it selects %d", valueLocation, len(values))
+	}
+
+	switch value := values[0].(type) {
+	case float64:
+		return value, nil
+	case string:
+		v, err := resource.ParseQuantity(value)
+		if err != nil {
+			return 0, fmt.Errorf("valueLocation must point to value of type number or a string representing a Quantity got: '%s'", value)
+		}
+		return v.AsApproximateFloat64(), nil
+	default:
+		return 0, fmt.Errorf("valueLocation must point to value of type number or a string representing a Quantity got: '%v'", value)
+	}
+}
+
 func (s *metricsAPIScaler) getMetricValue(ctx context.Context) (float64, error) {
 	request, err := getMetricAPIServerRequest(ctx, s.metadata)
 	if err != nil {
 		return 0, err
 	}
+	s.queryRecorder.Record(request.Method + " " + request.URL.String())
 
 	r, err := s.client.Do(request)
 	if err != nil {
@@ -236,7 +349,7 @@ func (s *metricsAPIScaler) getMetricValue(ctx context.Context) (float64, error)
 	if err != nil {
 		return 0, err
 	}
-	v, err := GetValueFromResponse(b, s.metadata.valueLocation)
+	v, err := getValueFromFormattedResponse(b, s.metadata.valueLocation, s.metadata.format)
 	if err != nil {
 		return 0, err
 	}
@@ -263,7 +376,7 @@ func (s *metricsAPIScaler) IsActive(ctx context.Context) (bool, error) {
 func (s *metricsAPIScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
-			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("metric-api-%s", s.metadata.valueLocation))),
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metricName()),
 		},
 		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
 	}
@@ -273,6 +386,15 @@ func (s *metricsAPIScaler) GetMetricSpecForScaling(context.Context) []v2beta2.Me
 	return []v2beta2.MetricSpec{metricSpec}
 }
 
+// metricName keeps the name of the GJSON paths and replaces the characters of the JSONPath and XPath
+// expressions which aren't allowed in metric names
+func (s *metricsAPIScaler) metricName() string {
+	if s.metadata.format == metricsAPIFormatXML || isJSONPath(s.metadata.valueLocation) {
+		return "metric-api-" + strings.Trim(metricsAPIMetricNameInvalidChars.ReplaceAllString(s.metadata.valueLocation, "-"), "-")
+	}
+	return kedautil.NormalizeString(fmt.Sprintf("metric-api-%s", s.metadata.valueLocation))
+}
+
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *metricsAPIScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
 	val, err := s.getMetricValue(ctx)
@@ -32,6 +32,16 @@ var testMetricsAPIMetadata = []metricsAPIMetadataTestData{
 	{metadata: map[string]string{"valueLocation": "metric", "targetValue": "aa"}, raisesError: true},
 	// Missing targetValue
 	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric"}, raisesError: true},
+	// JSONPath
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": `$.queues[?(@.name=="orders")].depth`, "targetValue": "42"}, raisesError: false},
+	// invalid JSONPath
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "$.queues[?(@.name==", "targetValue": "42"}, raisesError: true},
+	// XML
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "/queues/queue[@name='orders']/depth", "format": "xml", "targetValue": "42"}, raisesError: false},
+	// YAML
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric.test", "format": "YAML", "targetValue": "42"}, raisesError: false},
+	// unsupported format
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric.test", "format": "csv", "targetValue": "42"}, raisesError: true},
 }
 
 type metricAPIAuthMetadataTestData struct {
@@ -89,6 +99,8 @@ type metricsAPIMetricIdentifier struct {
 
 var metricsAPIMetricIdentifiers = []metricsAPIMetricIdentifier{
 	{metadataTestData: &testMetricsAPIMetadata[1], scalerIndex: 1, name: "s1-metric-api-metric-test"},
+	{metadataTestData: &testMetricsAPIMetadata[7], scalerIndex: 2, name: "s2-metric-api-queues-name-orders-depth"},
+	{metadataTestData: &testMetricsAPIMetadata[9], scalerIndex: 3, name: "s3-metric-api-queues-queue-name-orders-depth"},
 }
 
 func TestMetricsAPIGetMetricSpecForScaling(t *testing.T) {
@@ -154,6 +166,52 @@ func TestGetValueFromResponse(t *testing.T) {
 	}
 }
 
+func TestGetValueFromFormattedResponse(t *testing.T) {
+	jsonBody := []byte(`{"queues":[{"name":"orders","depth":12,"size":"2k"},{"name":"invoices","depth":3}]}`)
+	yamlBody := []byte(`
+queues:
+- name: orders
+  depth: 12
+- name: invoices
+  depth: 3
+`)
+	xmlBody := []byte(`<queues><queue name="orders"><depth>12</depth></queue><queue name="invoices"><depth>3</depth></queue></queues>`)
+
+	tests := []struct {
+		name          string
+		body          []byte
+		format        metricsAPIFormat
+		valueLocation string
+		expected      float64
+		isError       bool
+	}{
+		{"GJSON path", jsonBody, metricsAPIFormatJSON, "queues.1.depth", 3, false},
+		{"JSONPath filter", jsonBody, metricsAPIFormatJSON, `$.queues[?(@.name=="orders")].depth`, 12, false},
+		{"JSONPath template", jsonBody, metricsAPIFormatJSON, "{.queues[1].depth}", 3, false},
+		{"JSONPath Quantity", jsonBody, metricsAPIFormatJSON, "$.queues[0].size", 2000, false},
+		{"JSONPath selecting several values", jsonBody, metricsAPIFormatJSON, "$.queues[*].depth", 0, true},
+		{"JSONPath selecting nothing", jsonBody, metricsAPIFormatJSON, `$.queues[?(@.name=="missing")].depth`, 0, true},
+		{"JSONPath selecting an object", jsonBody, metricsAPIFormatJSON, "$.queues[0]", 0, true},
+		{"YAML GJSON path", yamlBody, metricsAPIFormatYAML, "queues.0.depth", 12, false},
+		{"YAML JSONPath", yamlBody, metricsAPIFormatYAML, `$.queues[?(@.name=="invoices")].depth`, 3, false},
+		{"invalid YAML", []byte("queues: [a"), metricsAPIFormatYAML, "queues.0", 0, true},
+		{"XPath", xmlBody, metricsAPIFormatXML, "/queues/queue[@name='invoices']/depth", 3, false},
+		{"XPath sum", xmlBody, metricsAPIFormatXML, "sum(//depth)", 15, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := getValueFromFormattedResponse(test.body, test.valueLocation, test.format)
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
 func TestMetricAPIScalerAuthParams(t *testing.T) {
 	for _, testData := range testMetricsAPIAuthMetadata {
 		meta, err := parseMetricsAPIMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
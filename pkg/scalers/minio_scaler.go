@@ -0,0 +1,268 @@
+package scalers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	minioMetricType         = "External"
+	defaultMinIOMetricsPath = "/minio/v2/metrics/cluster"
+	// minioMetricsTokenExpiry is the validity of the tokens of the metrics requests
+	minioMetricsTokenExpiry = 5 * time.Minute
+)
+
+type minioMeasure string
+
+const (
+	// minioMeasureBucketObjects scales on the number of objects of the bucket
+	minioMeasureBucketObjects minioMeasure = "bucketObjects"
+	// minioMeasureBucketBytes scales on the size of the bucket
+	minioMeasureBucketBytes minioMeasure = "bucketBytes"
+	// minioMeasureNotificationQueue scales on the events queued to be sent to the notification targets
+	minioMeasureNotificationQueue minioMeasure = "notificationQueue"
+)
+
+// minioMeasureMetrics are the names of the MinIO cluster metrics of the measures
+var minioMeasureMetrics = map[minioMeasure]string{
+	minioMeasureBucketObjects:     "minio_bucket_usage_object_total",
+	minioMeasureBucketBytes:       "minio_bucket_usage_total_bytes",
+	minioMeasureNotificationQueue: "minio_notify_target_queue_length",
+}
+
+type minioScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *minioMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type minioMetadata struct {
+	endpoint              string
+	metricsPath           string
+	accessKey             string
+	secretKey             string
+	measure               minioMeasure
+	bucketName            string
+	targetID              string
+	targetValue           float64
+	activationTargetValue float64
+	unsafeSsl             bool
+	scalerIndex           int
+}
+
+// NewMinIOScaler creates a new minioScaler
+func NewMinIOScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseMinIOMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing minio metadata: %s", err)
+	}
+
+	return &minioScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: createHTTPClient(config, meta.unsafeSsl),
+		logger:     InitializeLogger(config, "minio_scaler"),
+	}, nil
+}
+
+func parseMinIOMetadata(config *ScalerConfig) (*minioMetadata, error) {
+	meta := minioMetadata{}
+	var err error
+
+	meta.endpoint, err = GetFromAuthOrMeta(config, "endpoint")
+	if err != nil {
+		return nil, err
+	}
+	meta.endpoint = strings.TrimSuffix(meta.endpoint, "/")
+
+	meta.metricsPath = defaultMinIOMetricsPath
+	if val, ok := config.TriggerMetadata["metricsPath"]; ok && val != "" {
+		meta.metricsPath = val
+	}
+
+	// the keys are only read from the authentication, without them the metrics must be public
+	meta.accessKey = config.AuthParams["accessKey"]
+	meta.secretKey = config.AuthParams["secretKey"]
+	if (meta.accessKey == "") != (meta.secretKey == "") {
+		return nil, fmt.Errorf("both accessKey and secretKey must be given")
+	}
+
+	meta.measure = minioMeasureBucketObjects
+	if val, ok := config.TriggerMetadata["measure"]; ok && val != "" {
+		meta.measure = minioMeasure(val)
+	}
+	switch meta.measure {
+	case minioMeasureBucketObjects, minioMeasureBucketBytes:
+		meta.bucketName = config.TriggerMetadata["bucketName"]
+		if meta.bucketName == "" {
+			return nil, fmt.Errorf("no bucketName given")
+		}
+	case minioMeasureNotificationQueue:
+		meta.targetID = config.TriggerMetadata["targetId"]
+	default:
+		return nil, fmt.Errorf("measure must be one of %s, %s, %s but is %s", minioMeasureBucketObjects, minioMeasureBucketBytes, minioMeasureNotificationQueue, meta.measure)
+	}
+
+	val, err := getParameterFromConfig(config, "targetValue", false)
+	if err != nil {
+		return nil, err
+	}
+	meta.targetValue, err = strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing targetValue: %s", err)
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok && val != "" {
+		meta.activationTargetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetValue: %s", err)
+		}
+	}
+
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// newMinIOMetricsToken creates the bearer token MinIO accepts for the metrics, which is a JWT
+// signed with the secret key, like the ones generated by mc admin prometheus generate
+func newMinIOMetricsToken(accessKey, secretKey string, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "HS512", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"exp": now.Add(minioMetricsTokenExpiry).Unix(),
+		"sub": accessKey,
+		"iss": "prometheus",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	mac := hmac.New(sha512.New, []byte(secretKey))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (s *minioScaler) getMetricValue(ctx context.Context) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.metadata.endpoint+s.metadata.metricsPath, nil)
+	if err != nil {
+		return 0, err
+	}
+	if s.metadata.accessKey != "" {
+		token, err := newMinIOMetricsToken(s.metadata.accessKey, s.metadata.secretKey, time.Now())
+		if err != nil {
+			return 0, fmt.Errorf("error creating minio metrics token: %s", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("minio metrics returned %d: %s", resp.StatusCode, body)
+	}
+
+	seriesLabels := map[string]string{}
+	switch {
+	case s.metadata.bucketName != "":
+		seriesLabels["bucket"] = s.metadata.bucketName
+	case s.metadata.targetID != "":
+		seriesLabels["target_id"] = s.metadata.targetID
+	}
+	name := minioMeasureMetrics[s.metadata.measure]
+	value, ok, err := getPrometheusExpositionSum(body, name, seriesLabels)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		// the metrics of the empty buckets and of the idle targets may not be exposed yet
+		s.logger.V(1).Info("minio metric not found", "metric", name)
+	}
+	return value, nil
+}
+
+func (s *minioScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting minio metric")
+		return false, err
+	}
+
+	return value > s.metadata.activationTargetValue, nil
+}
+
+func (s *minioScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *minioScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	var metricName string
+	if s.metadata.measure == minioMeasureNotificationQueue {
+		metricName = "minio-notification-queue"
+		if s.metadata.targetID != "" {
+			metricName = fmt.Sprintf("%s-%s", metricName, s.metadata.targetID)
+		}
+	} else {
+		metricName = fmt.Sprintf("minio-%s-%s", s.metadata.measure, s.metadata.bucketName)
+	}
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: minioMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *minioScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting minio metric")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
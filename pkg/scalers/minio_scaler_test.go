@@ -0,0 +1,174 @@
+package scalers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+type parseMinIOMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type minioMetricIdentifier struct {
+	metadataTestData *parseMinIOMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testMinIOAuthParams = map[string]string{"accessKey": "minio", "secretKey": "minio123"}
+
+var testMinIOMetadata = []parseMinIOMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed bucket objects
+	{map[string]string{"endpoint": "https://minio:9000", "bucketName": "uploads", "targetValue": "100"}, testMinIOAuthParams, false},
+	// properly formed notification queue
+	{map[string]string{"endpoint": "https://minio:9000", "measure": "notificationQueue", "targetId": "1", "targetValue": "50", "activationTargetValue": "1"}, testMinIOAuthParams, false},
+	// bucket bytes with public metrics on a custom path
+	{map[string]string{"endpoint": "https://minio:9000/", "metricsPath": "/minio/v2/metrics/bucket", "measure": "bucketBytes", "bucketName": "uploads", "targetValue": "1073741824"}, map[string]string{}, false},
+	// endpoint from authParams
+	{map[string]string{"bucketName": "uploads", "targetValue": "100"}, map[string]string{"endpoint": "https://minio:9000", "accessKey": "minio", "secretKey": "minio123"}, false},
+	// missing endpoint
+	{map[string]string{"bucketName": "uploads", "targetValue": "100"}, testMinIOAuthParams, true},
+	// missing secretKey
+	{map[string]string{"endpoint": "https://minio:9000", "bucketName": "uploads", "targetValue": "100"}, map[string]string{"accessKey": "minio"}, true},
+	// keys in metadata
+	{map[string]string{"endpoint": "https://minio:9000", "bucketName": "uploads", "targetValue": "100", "secretKey": "minio123"}, map[string]string{"accessKey": "minio"}, true},
+	// missing bucketName
+	{map[string]string{"endpoint": "https://minio:9000", "targetValue": "100"}, testMinIOAuthParams, true},
+	// invalid measure
+	{map[string]string{"endpoint": "https://minio:9000", "measure": "replicationLag", "bucketName": "uploads", "targetValue": "100"}, testMinIOAuthParams, true},
+	// missing targetValue
+	{map[string]string{"endpoint": "https://minio:9000", "bucketName": "uploads"}, testMinIOAuthParams, true},
+	// invalid targetValue
+	{map[string]string{"endpoint": "https://minio:9000", "bucketName": "uploads", "targetValue": "A"}, testMinIOAuthParams, true},
+	// invalid activationTargetValue
+	{map[string]string{"endpoint": "https://minio:9000", "bucketName": "uploads", "targetValue": "100", "activationTargetValue": "A"}, testMinIOAuthParams, true},
+	// invalid unsafeSsl
+	{map[string]string{"endpoint": "https://minio:9000", "bucketName": "uploads", "targetValue": "100", "unsafeSsl": "A"}, testMinIOAuthParams, true},
+}
+
+var minioMetricIdentifiers = []minioMetricIdentifier{
+	{&testMinIOMetadata[1], 0, "s0-minio-bucketObjects-uploads"},
+	{&testMinIOMetadata[2], 1, "s1-minio-notification-queue-1"},
+}
+
+const testMinIOMetrics = `# HELP minio_bucket_usage_object_total Total number of objects
+# TYPE minio_bucket_usage_object_total gauge
+minio_bucket_usage_object_total{bucket="uploads",server="127.0.0.1:9000"} 120
+minio_bucket_usage_object_total{bucket="thumbnails",server="127.0.0.1:9000"} 80
+# HELP minio_bucket_usage_total_bytes Total bucket size in bytes
+# TYPE minio_bucket_usage_total_bytes gauge
+minio_bucket_usage_total_bytes{bucket="uploads",server="127.0.0.1:9000"} 4096
+# HELP minio_notify_target_queue_length Number of events currently staged in the queue_dir configured for the target
+# TYPE minio_notify_target_queue_length counter
+minio_notify_target_queue_length{server="127.0.0.1:9000",target_id="1",target_name="webhook"} 7
+minio_notify_target_queue_length{server="127.0.0.1:9000",target_id="2",target_name="kafka"} 3
+`
+
+func TestMinIOParseMetadata(t *testing.T) {
+	for _, testData := range testMinIOMetadata {
+		_, err := parseMinIOMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success. testData: %v", testData)
+		}
+	}
+}
+
+func TestMinIOGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range minioMetricIdentifiers {
+		meta, err := parseMinIOMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
+			AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockMinIOScaler := minioScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockMinIOScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestMinIOMetricsToken(t *testing.T) {
+	now := time.Unix(1660000000, 0)
+	token, err := newMinIOMetricsToken("minio", "minio123", now)
+	assert.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+	assert.Len(t, parts, 3)
+
+	mac := hmac.New(sha512.New, []byte("minio123"))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), parts[2])
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	assert.NoError(t, err)
+	var claims map[string]interface{}
+	assert.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, "minio", claims["sub"])
+	assert.Equal(t, float64(now.Add(minioMetricsTokenExpiry).Unix()), claims["exp"])
+}
+
+func TestMinIOGetMetricValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != defaultMinIOMetricsPath || !strings.HasPrefix(request.Header.Get("Authorization"), "Bearer ") {
+			writer.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if _, err := writer.Write([]byte(testMinIOMetrics)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		metadata      map[string]string
+		authParams    map[string]string
+		expectedValue float64
+		isError       bool
+	}{
+		{map[string]string{"bucketName": "uploads"}, testMinIOAuthParams, 120, false},
+		{map[string]string{"measure": "bucketBytes", "bucketName": "uploads"}, testMinIOAuthParams, 4096, false},
+		{map[string]string{"measure": "bucketBytes", "bucketName": "thumbnails"}, testMinIOAuthParams, 0, false},
+		{map[string]string{"measure": "notificationQueue"}, testMinIOAuthParams, 10, false},
+		{map[string]string{"measure": "notificationQueue", "targetId": "2"}, testMinIOAuthParams, 3, false},
+		{map[string]string{"bucketName": "uploads"}, map[string]string{}, 0, true},
+	}
+
+	for _, testCase := range testCases {
+		testCase.metadata["endpoint"] = server.URL
+		testCase.metadata["targetValue"] = "10"
+		meta, err := parseMinIOMetadata(&ScalerConfig{TriggerMetadata: testCase.metadata, AuthParams: testCase.authParams})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := minioScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+		value, err := scaler.getMetricValue(context.Background())
+		if testCase.isError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedValue, value)
+		}
+	}
+}
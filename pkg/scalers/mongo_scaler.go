@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -53,11 +54,17 @@ type mongoDBMetadata struct {
 	// +required
 	collection string
 	// A mongoDB filter doc,used by specify DB.
-	// +required
+	// +optional
 	query string
+	// A mongoDB aggregation pipeline, used instead of the query to compute the value.
+	// +optional
+	aggregation string
+	// The field of the documents returned by the aggregation pipeline, which is summed to get the value.
+	// +optional
+	aggregationValueField string
 	// A threshold that is used as targetAverageValue in HPA
 	// +required
-	queryValue int64
+	queryValue float64
 	// A threshold that is used to check if scaler is active
 	// +optional
 	activationQueryValue int64
@@ -73,6 +80,8 @@ type mongoDBMetadata struct {
 // Default variables and settings
 const (
 	mongoDBDefaultTimeOut = 10 * time.Second
+	// mongoDBDefaultAggregationValueField is the field read from the documents of the aggregation pipeline
+	mongoDBDefaultAggregationValueField = "value"
 )
 
 // NewMongoDBScaler creates a new mongoDB scaler
@@ -121,16 +130,28 @@ func parseMongoDBMetadata(config *ScalerConfig) (*mongoDBMetadata, string, error
 		return nil, "", fmt.Errorf("no collection given")
 	}
 
-	if val, ok := config.TriggerMetadata["query"]; ok {
-		meta.query = val
-	} else {
-		return nil, "", fmt.Errorf("no query given")
+	// the documents are either counted with a query, or the value is computed by an aggregation pipeline
+	meta.query = config.TriggerMetadata["query"]
+	meta.aggregation = config.TriggerMetadata["aggregation"]
+	switch {
+	case meta.query == "" && meta.aggregation == "":
+		return nil, "", fmt.Errorf("no query or aggregation given")
+	case meta.query != "" && meta.aggregation != "":
+		return nil, "", fmt.Errorf("query and aggregation can't be given both")
+	case meta.aggregation != "":
+		if _, err := json2BsonPipeline(meta.aggregation); err != nil {
+			return nil, "", fmt.Errorf("failed to parse aggregation, because of %v", err)
+		}
+		meta.aggregationValueField = mongoDBDefaultAggregationValueField
+		if val, ok := config.TriggerMetadata["aggregationValueField"]; ok && val != "" {
+			meta.aggregationValueField = val
+		}
 	}
 
 	if val, ok := config.TriggerMetadata["queryValue"]; ok {
-		queryValue, err := strconv.ParseInt(val, 10, 64)
+		queryValue, err := strconv.ParseFloat(val, 64)
 		if err != nil {
-			return nil, "", fmt.Errorf("failed to convert %v to int, because of %v", queryValue, err.Error())
+			return nil, "", fmt.Errorf("failed to convert %v to number, because of %v", queryValue, err.Error())
 		}
 		meta.queryValue = queryValue
 	} else {
@@ -208,7 +229,7 @@ func (s *mongoDBScaler) IsActive(ctx context.Context) (bool, error) {
 		s.logger.Error(err, fmt.Sprintf("failed to get query result by mongoDB, because of %v", err))
 		return false, err
 	}
-	return result > s.metadata.activationQueryValue, nil
+	return result > float64(s.metadata.activationQueryValue), nil
 }
 
 // Close disposes of mongoDB connections
@@ -224,11 +245,15 @@ func (s *mongoDBScaler) Close(ctx context.Context) error {
 	return nil
 }
 
-// getQueryResult query mongoDB by meta.query
-func (s *mongoDBScaler) getQueryResult(ctx context.Context) (int64, error) {
+// getQueryResult query mongoDB by meta.query, or run the meta.aggregation pipeline
+func (s *mongoDBScaler) getQueryResult(ctx context.Context) (float64, error) {
 	ctx, cancel := context.WithTimeout(ctx, mongoDBDefaultTimeOut)
 	defer cancel()
 
+	if s.metadata.aggregation != "" {
+		return s.getAggregationResult(ctx)
+	}
+
 	filter, err := json2BsonDoc(s.metadata.query)
 	if err != nil {
 		s.logger.Error(err, fmt.Sprintf("failed to convert query param to bson.Doc, because of %v", err))
@@ -241,7 +266,59 @@ func (s *mongoDBScaler) getQueryResult(ctx context.Context) (int64, error) {
 		return 0, err
 	}
 
-	return docsNum, nil
+	return float64(docsNum), nil
+}
+
+// getAggregationResult runs the aggregation pipeline and sums the value field of the returned documents,
+// so a pipeline grouping by a field returns the total of the groups and an empty result is 0
+func (s *mongoDBScaler) getAggregationResult(ctx context.Context) (float64, error) {
+	pipeline, err := json2BsonPipeline(s.metadata.aggregation)
+	if err != nil {
+		s.logger.Error(err, fmt.Sprintf("failed to convert aggregation param to bson pipeline, because of %v", err))
+		return 0, err
+	}
+
+	cursor, err := s.client.Database(s.metadata.dbName).Collection(s.metadata.collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		s.logger.Error(err, fmt.Sprintf("failed to aggregate %v in %v, because of %v", s.metadata.dbName, s.metadata.collection, err))
+		return 0, err
+	}
+
+	var docs []bson.M
+	if err := cursor.All(ctx, &docs); err != nil {
+		s.logger.Error(err, fmt.Sprintf("failed to read the aggregation result of %v in %v, because of %v", s.metadata.dbName, s.metadata.collection, err))
+		return 0, err
+	}
+
+	return sumMongoDBAggregationField(docs, s.metadata.aggregationValueField)
+}
+
+// sumMongoDBAggregationField sums the numeric field of the documents
+func sumMongoDBAggregationField(docs []bson.M, field string) (float64, error) {
+	var sum float64
+	for _, doc := range docs {
+		val, ok := doc[field]
+		if !ok {
+			return 0, fmt.Errorf("aggregation result has no field %s", field)
+		}
+		switch v := val.(type) {
+		case int32:
+			sum += float64(v)
+		case int64:
+			sum += float64(v)
+		case float64:
+			sum += v
+		case primitive.Decimal128:
+			f, err := strconv.ParseFloat(v.String(), 64)
+			if err != nil {
+				return 0, fmt.Errorf("failed to convert %v of field %s to number, because of %v", v, field, err)
+			}
+			sum += f
+		default:
+			return 0, fmt.Errorf("field %s of the aggregation result isn't a number but %T", field, val)
+		}
+	}
+	return sum, nil
 }
 
 // GetMetrics query from mongoDB,and return to external metrics
@@ -251,7 +328,7 @@ func (s *mongoDBScaler) GetMetrics(ctx context.Context, metricName string, metri
 		return []external_metrics.ExternalMetricValue{}, fmt.Errorf("failed to inspect momgoDB, because of %v", err)
 	}
 
-	metric := GenerateMetricInMili(metricName, float64(num))
+	metric := GenerateMetricInMili(metricName, num)
 
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
@@ -262,7 +339,7 @@ func (s *mongoDBScaler) GetMetricSpecForScaling(context.Context) []v2beta2.Metri
 		Metric: v2beta2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.queryValue),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.queryValue),
 	}
 	metricSpec := v2beta2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
@@ -284,3 +361,21 @@ func json2BsonDoc(js string) (doc bsonx.Doc, err error) {
 
 	return doc, nil
 }
+
+// json2BsonPipeline convert a Json array of stages to a bson pipeline
+func json2BsonPipeline(js string) ([]bson.D, error) {
+	// the extended JSON parser only accepts a document at the top level
+	var wrapper struct {
+		Pipeline []bson.D `bson:"pipeline"`
+	}
+	err := bson.UnmarshalExtJSON([]byte(`{"pipeline":`+js+`}`), true, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wrapper.Pipeline) == 0 {
+		return nil, errors.New("empty aggregation pipeline")
+	}
+
+	return wrapper.Pipeline, nil
+}
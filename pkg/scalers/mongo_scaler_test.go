@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -62,6 +64,41 @@ var testMONGODBMetadata = []parseMongoDBMetadataTestData{
 		resolvedEnv: testMongoDBResolvedEnv,
 		raisesError: true,
 	},
+	// aggregation
+	{
+		metadata:    map[string]string{"aggregation": `[{"$match":{"status":"pending"}},{"$count":"value"}]`, "collection": "demo", "queryValue": "12", "connectionStringFromEnv": "Mongo_CONN_STR", "dbName": "test"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMongoDBResolvedEnv,
+		raisesError: false,
+	},
+	// aggregation with value field
+	{
+		metadata:    map[string]string{"aggregation": `[{"$group":{"_id":"$status","total":{"$sum":"$size"}}}]`, "aggregationValueField": "total", "collection": "demo", "queryValue": "12", "connectionStringFromEnv": "Mongo_CONN_STR", "dbName": "test"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMongoDBResolvedEnv,
+		raisesError: false,
+	},
+	// query and aggregation
+	{
+		metadata:    map[string]string{"query": `{"name":"John"}`, "aggregation": `[{"$count":"value"}]`, "collection": "demo", "queryValue": "12", "connectionStringFromEnv": "Mongo_CONN_STR", "dbName": "test"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMongoDBResolvedEnv,
+		raisesError: true,
+	},
+	// aggregation isn't an array
+	{
+		metadata:    map[string]string{"aggregation": `{"$count":"value"}`, "collection": "demo", "queryValue": "12", "connectionStringFromEnv": "Mongo_CONN_STR", "dbName": "test"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMongoDBResolvedEnv,
+		raisesError: true,
+	},
+	// empty aggregation
+	{
+		metadata:    map[string]string{"aggregation": `[]`, "collection": "demo", "queryValue": "12", "connectionStringFromEnv": "Mongo_CONN_STR", "dbName": "test"},
+		authParams:  map[string]string{},
+		resolvedEnv: testMongoDBResolvedEnv,
+		raisesError: true,
+	},
 }
 
 var mongoDBMetricIdentifiers = []mongoDBMetricIdentifier{
@@ -107,3 +144,42 @@ func TestJson2BsonDoc(t *testing.T) {
 		t.Error("the doc is nil")
 	}
 }
+
+func TestJson2BsonPipeline(t *testing.T) {
+	var testJSON = `[{"$match":{"status":"pending"}},{"$count":"value"}]`
+	pipeline, err := json2BsonPipeline(testJSON)
+	if err != nil {
+		t.Error("convert testJson to bson pipeline err:", err)
+	}
+	if len(pipeline) != 2 {
+		t.Error("expected 2 stages but got", len(pipeline))
+	}
+}
+
+func TestSumMongoDBAggregationField(t *testing.T) {
+	decimal, _ := primitive.ParseDecimal128("0.5")
+	testCases := []struct {
+		docs     []bson.M
+		expected float64
+		isError  bool
+	}{
+		{nil, 0, false},
+		{[]bson.M{{"value": int32(3)}}, 3, false},
+		{[]bson.M{{"_id": "pending", "value": int64(3)}, {"_id": "failed", "value": 1.5}, {"_id": "retrying", "value": decimal}}, 5, false},
+		{[]bson.M{{"total": int32(3)}}, 0, true},
+		{[]bson.M{{"value": "3"}}, 0, true},
+	}
+
+	for _, testCase := range testCases {
+		sum, err := sumMongoDBAggregationField(testCase.docs, "value")
+		if err != nil && !testCase.isError {
+			t.Error("Expected success but got error:", err)
+		}
+		if err == nil && testCase.isError {
+			t.Error("Expected error but got success")
+		}
+		if sum != testCase.expected {
+			t.Errorf("Expected %v but got %v", testCase.expected, sum)
+		}
+	}
+}
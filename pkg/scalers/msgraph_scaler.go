@@ -0,0 +1,201 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers/azure"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	msGraphMetricType        = "External"
+	defaultMSGraphAPIVersion = "v1.0"
+	msGraphCountProperty     = "@odata.count"
+)
+
+type msGraphScaler struct {
+	metricType  v2beta2.MetricTargetType
+	metadata    *msGraphMetadata
+	podIdentity kedav1alpha1.AuthPodIdentity
+	logger      logr.Logger
+}
+
+type msGraphMetadata struct {
+	graphInfo             azure.MSGraphInfo
+	valueLocation         string
+	targetValue           float64
+	activationTargetValue float64
+	metricName            string
+	scalerIndex           int
+}
+
+// NewMSGraphScaler creates a new msGraphScaler
+func NewMSGraphScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseMSGraphMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing msgraph metadata: %s", err)
+	}
+
+	return &msGraphScaler{
+		metricType:  metricType,
+		metadata:    meta,
+		podIdentity: config.PodIdentity,
+		logger:      InitializeLogger(config, "msgraph_scaler"),
+	}, nil
+}
+
+func parseMSGraphMetadata(config *ScalerConfig) (*msGraphMetadata, error) {
+	meta := msGraphMetadata{}
+	var err error
+
+	meta.graphInfo.Query, err = getParameterFromConfig(config, "query", false)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(meta.graphInfo.Query, "/") {
+		return nil, fmt.Errorf("query must be a path relative to the API version, starting with /")
+	}
+
+	meta.graphInfo.APIVersion = defaultMSGraphAPIVersion
+	if val, ok := config.TriggerMetadata["apiVersion"]; ok && val != "" {
+		if val != "v1.0" && val != "beta" {
+			return nil, fmt.Errorf("apiVersion must be one of v1.0, beta but is %s", val)
+		}
+		meta.graphInfo.APIVersion = val
+	}
+
+	meta.valueLocation = config.TriggerMetadata["valueLocation"]
+
+	val, err := getParameterFromConfig(config, "targetValue", false)
+	if err != nil {
+		return nil, err
+	}
+	meta.targetValue, err = strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing targetValue: %s", err)
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok && val != "" {
+		meta.activationTargetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetValue: %s", err)
+		}
+	}
+
+	meta.graphInfo.GraphEndpoint, err = azure.ParseMSGraphEndpoint(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.graphInfo.ActiveDirectoryEndpoint, err = azure.ParseActiveDirectoryEndpoint(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	switch config.PodIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		meta.graphInfo.TenantID, err = getParameterFromConfig(config, "tenantId", true)
+		if err != nil {
+			return nil, err
+		}
+		meta.graphInfo.ClientID, meta.graphInfo.ClientPassword, err = parseAzurePodIdentityParams(config)
+		if err != nil {
+			return nil, err
+		}
+	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+		// no params required to be parsed
+	default:
+		return nil, fmt.Errorf("msgraph doesn't support pod identity %s", config.PodIdentity.Provider)
+	}
+
+	meta.metricName = "msgraph"
+	if val, ok := config.TriggerMetadata["metricName"]; ok && val != "" {
+		meta.metricName = kedautil.NormalizeString(fmt.Sprintf("msgraph-%s", val))
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// getMSGraphValue returns the value of the query result: the response of a $count segment is the plain
+// number, otherwise it is the valueLocation of the JSON response, or its @odata.count if not set
+func getMSGraphValue(body []byte, valueLocation string) (float64, error) {
+	if valueLocation != "" {
+		return GetValueFromResponse(body, valueLocation)
+	}
+
+	if value, err := strconv.ParseFloat(strings.TrimSpace(string(body)), 64); err == nil {
+		return value, nil
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("msgraph response is neither a number nor JSON: %s", err)
+	}
+	count, ok := result[msGraphCountProperty].(float64)
+	if !ok {
+		return 0, fmt.Errorf("msgraph response has no %s, add $count=true to the query or set valueLocation", msGraphCountProperty)
+	}
+	return count, nil
+}
+
+func (s *msGraphScaler) getQueryValue(ctx context.Context) (float64, error) {
+	body, err := azure.GetAzureMSGraphQueryResult(ctx, s.metadata.graphInfo, s.podIdentity)
+	if err != nil {
+		return 0, err
+	}
+	return getMSGraphValue(body, s.metadata.valueLocation)
+}
+
+func (s *msGraphScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getQueryValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error running msgraph query")
+		return false, err
+	}
+
+	return value > s.metadata.activationTargetValue, nil
+}
+
+func (s *msGraphScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *msGraphScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: msGraphMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *msGraphScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getQueryValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error running msgraph query")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
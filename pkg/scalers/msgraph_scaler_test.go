@@ -0,0 +1,137 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+type parseMSGraphMetadataTestData struct {
+	metadata    map[string]string
+	isError     bool
+	resolvedEnv map[string]string
+	authParams  map[string]string
+	podIdentity kedav1alpha1.PodIdentityProvider
+}
+
+type msGraphMetricIdentifier struct {
+	metadataTestData *parseMSGraphMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+type msGraphValueTestData struct {
+	name          string
+	body          string
+	valueLocation string
+	value         float64
+	isError       bool
+}
+
+var testMSGraphResolvedEnv = map[string]string{
+	"CLIENT_PASSWORD": "yyy",
+}
+
+var testParseMSGraphMetadata = []parseMSGraphMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true, map[string]string{}, map[string]string{}, ""},
+	// properly formed
+	{map[string]string{"query": "/users/shared@contoso.com/mailFolders/inbox/messages/$count", "targetValue": "10", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, false, testMSGraphResolvedEnv, map[string]string{}, ""},
+	// properly formed with all optional parameters
+	{map[string]string{"query": "/sites/site/lists/list/items?$count=true&$top=1", "targetValue": "10", "activationTargetValue": "2", "apiVersion": "beta", "valueLocation": "value.#", "metricName": "orders", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, false, testMSGraphResolvedEnv, map[string]string{}, ""},
+	// missing query
+	{map[string]string{"targetValue": "10", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testMSGraphResolvedEnv, map[string]string{}, ""},
+	// query not starting with /
+	{map[string]string{"query": "users/$count", "targetValue": "10", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testMSGraphResolvedEnv, map[string]string{}, ""},
+	// invalid apiVersion
+	{map[string]string{"query": "/users/$count", "targetValue": "10", "apiVersion": "v2.0", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testMSGraphResolvedEnv, map[string]string{}, ""},
+	// missing targetValue
+	{map[string]string{"query": "/users/$count", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testMSGraphResolvedEnv, map[string]string{}, ""},
+	// invalid targetValue
+	{map[string]string{"query": "/users/$count", "targetValue": "A", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testMSGraphResolvedEnv, map[string]string{}, ""},
+	// invalid activationTargetValue
+	{map[string]string{"query": "/users/$count", "targetValue": "10", "activationTargetValue": "A", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testMSGraphResolvedEnv, map[string]string{}, ""},
+	// missing tenantId
+	{map[string]string{"query": "/users/$count", "targetValue": "10", "activeDirectoryClientId": "CLIENT_ID", "activeDirectoryClientPasswordFromEnv": "CLIENT_PASSWORD"}, true, testMSGraphResolvedEnv, map[string]string{}, ""},
+	// missing activeDirectoryClientPassword
+	{map[string]string{"query": "/users/$count", "targetValue": "10", "tenantId": "123", "activeDirectoryClientId": "CLIENT_ID"}, true, testMSGraphResolvedEnv, map[string]string{}, ""},
+	// connection from authParams
+	{map[string]string{"query": "/users/$count", "targetValue": "10"}, false, map[string]string{}, map[string]string{"tenantId": "123", "activeDirectoryClientId": "zzz", "activeDirectoryClientPassword": "password"}, ""},
+	// connection with podIdentity
+	{map[string]string{"query": "/users/$count", "targetValue": "10"}, false, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzure},
+	// connection with workload Identity
+	{map[string]string{"query": "/users/$count", "targetValue": "10"}, false, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload},
+	// wrong podIdentity
+	{map[string]string{"query": "/users/$count", "targetValue": "10"}, true, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProvider("notAzure")},
+	// known azure cloud
+	{map[string]string{"query": "/users/$count", "targetValue": "10", "cloud": "azureUSGovernmentCloud"}, false, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzure},
+	// private cloud
+	{map[string]string{"query": "/users/$count", "targetValue": "10", "cloud": "private", "graphEndpoint": "https://graph.private/", "activeDirectoryEndpoint": testActiveDirectoryEndpoint}, false, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzure},
+	// private cloud with missing graph endpoint
+	{map[string]string{"query": "/users/$count", "targetValue": "10", "cloud": "private", "activeDirectoryEndpoint": testActiveDirectoryEndpoint}, true, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzure},
+}
+
+var msGraphMetricIdentifiers = []msGraphMetricIdentifier{
+	{&testParseMSGraphMetadata[1], 0, "s0-msgraph"},
+	{&testParseMSGraphMetadata[2], 1, "s1-msgraph-orders"},
+}
+
+var testMSGraphValues = []msGraphValueTestData{
+	{"count segment", "42", "", 42, false},
+	{"count segment with new line", "42\n", "", 42, false},
+	{"odata count", `{"@odata.count": 7, "value": [{"id": "1"}]}`, "", 7, false},
+	{"missing odata count", `{"value": [{"id": "1"}]}`, "", 0, true},
+	{"not a number nor JSON", "forbidden", "", 0, true},
+	{"value location", `{"totalItemCount": 12, "unreadItemCount": 3}`, "unreadItemCount", 3, false},
+	{"value location of items", `{"value": [{"id": "1"}, {"id": "2"}]}`, "value.#", 2, false},
+	{"value location not a number", `{"displayName": "Inbox"}`, "displayName", 0, true},
+}
+
+func TestMSGraphParseMetadata(t *testing.T) {
+	for _, testData := range testParseMSGraphMetadata {
+		_, err := parseMSGraphMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ResolvedEnv: testData.resolvedEnv,
+			AuthParams: testData.authParams, PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.podIdentity}})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success. testData: %v", testData)
+		}
+	}
+}
+
+func TestMSGraphGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range msGraphMetricIdentifiers {
+		meta, err := parseMSGraphMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
+			ResolvedEnv: testData.metadataTestData.resolvedEnv, AuthParams: testData.metadataTestData.authParams,
+			PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.metadataTestData.podIdentity}, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockMSGraphScaler := msGraphScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockMSGraphScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestMSGraphGetValue(t *testing.T) {
+	for _, testData := range testMSGraphValues {
+		value, err := getMSGraphValue([]byte(testData.body), testData.valueLocation)
+		if err != nil && !testData.isError {
+			t.Errorf("Test: %s; Expected success but got error: %s", testData.name, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Test: %s; Expected error but got success", testData.name)
+		}
+		if err == nil && value != testData.value {
+			t.Errorf("Test: %s; Expected value %v but got %v", testData.name, testData.value, value)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	msmqMetricType                   = "External"
+	defaultMsmqTargetQueueLength     = 5
+	defaultMsmqActivationQueueLength = 0
+	// msmqWindowsExporterMetric is the metric of the msmq collector of the windows_exporter, labelled by queue name
+	msmqWindowsExporterMetric = "windows_msmq_messages_in_queue"
+)
+
+type msmqEndpointType string
+
+const (
+	// msmqAgentEndpoint is an agent running next to MSMQ which returns the message count of a queue as JSON
+	msmqAgentEndpoint msmqEndpointType = "agent"
+	// msmqWindowsExporterEndpoint is the windows_exporter exposing the MSMQ performance counters queried through WMI
+	msmqWindowsExporterEndpoint msmqEndpointType = "windowsExporter"
+)
+
+type msmqScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *msmqMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type msmqMetadata struct {
+	url                   string
+	endpointType          msmqEndpointType
+	queueName             string
+	targetQueueLength     float64
+	activationQueueLength float64
+	metricName            string
+	scalerIndex           int
+
+	// Auth
+	username    string
+	password    string
+	bearerToken string
+
+	unsafeSsl bool
+}
+
+// msmqAgentResponse is the response of the agent to GET <url>/queues/<queueName>
+type msmqAgentResponse struct {
+	Name         string   `json:"name"`
+	MessageCount *float64 `json:"messageCount"`
+}
+
+// NewMSMQScaler creates a new msmqScaler
+func NewMSMQScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseMSMQMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing msmq metadata: %s", err)
+	}
+
+	return &msmqScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: createHTTPClient(config, meta.unsafeSsl),
+		logger:     InitializeLogger(config, "msmq_scaler"),
+	}, nil
+}
+
+func parseMSMQMetadata(config *ScalerConfig) (*msmqMetadata, error) {
+	meta := msmqMetadata{}
+	var err error
+
+	meta.url, err = GetFromAuthOrMeta(config, "url")
+	if err != nil {
+		return nil, err
+	}
+	meta.url = strings.TrimSuffix(meta.url, "/")
+
+	meta.endpointType = msmqAgentEndpoint
+	if val, ok := config.TriggerMetadata["endpointType"]; ok && val != "" {
+		meta.endpointType = msmqEndpointType(val)
+	}
+	if meta.endpointType != msmqAgentEndpoint && meta.endpointType != msmqWindowsExporterEndpoint {
+		return nil, fmt.Errorf("endpointType must be %s or %s, got %s", msmqAgentEndpoint, msmqWindowsExporterEndpoint, meta.endpointType)
+	}
+
+	if val, ok := config.TriggerMetadata["queueName"]; ok && val != "" {
+		meta.queueName = val
+	} else {
+		return nil, errors.New("no queueName given")
+	}
+
+	meta.targetQueueLength = defaultMsmqTargetQueueLength
+	if val, ok := config.TriggerMetadata["queueLength"]; ok && val != "" {
+		meta.targetQueueLength, err = strconv.ParseFloat(val, 64)
+		if err != nil || meta.targetQueueLength <= 0 {
+			return nil, fmt.Errorf("queueLength must be a number greater than 0, got %s", val)
+		}
+	}
+
+	meta.activationQueueLength = defaultMsmqActivationQueueLength
+	if val, ok := config.TriggerMetadata["activationQueueLength"]; ok && val != "" {
+		meta.activationQueueLength, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationQueueLength: %s", err)
+		}
+	}
+
+	meta.username = config.AuthParams["username"]
+	meta.password = config.AuthParams["password"]
+	if meta.password != "" && meta.username == "" {
+		return nil, errors.New("password must be provided with username")
+	}
+	meta.bearerToken = config.AuthParams["bearerToken"]
+	if meta.bearerToken != "" && meta.username != "" {
+		return nil, errors.New("bearerToken and username can't be provided together")
+	}
+
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// queue names like private$\orders aren't valid in a metric name
+	queueName := strings.NewReplacer("\\", "-", "$", "").Replace(meta.queueName)
+	meta.metricName = kedautil.NormalizeString(fmt.Sprintf("msmq-%s", queueName))
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func (s *msmqScaler) IsActive(ctx context.Context) (bool, error) {
+	length, err := s.getQueueLength(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting msmq queue length")
+		return false, err
+	}
+
+	return length > s.metadata.activationQueueLength, nil
+}
+
+func (s *msmqScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (s *msmqScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetQueueLength),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: msmqMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *msmqScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	length, err := s.getQueueLength(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting msmq queue length")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, length)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// getQueueLength returns the number of messages in the queue, as reported by the agent or the windows_exporter
+func (s *msmqScaler) getQueueLength(ctx context.Context) (float64, error) {
+	requestURL := s.metadata.url
+	if s.metadata.endpointType == msmqAgentEndpoint {
+		requestURL = fmt.Sprintf("%s/queues/%s", s.metadata.url, url.PathEscape(s.metadata.queueName))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case s.metadata.username != "":
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	case s.metadata.bearerToken != "":
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.bearerToken))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("msmq %s returned status code %d for %s", s.metadata.endpointType, resp.StatusCode, req.URL.Path)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.metadata.endpointType == msmqWindowsExporterEndpoint {
+		length, ok, err := getPrometheusExpositionSum(body, msmqWindowsExporterMetric, map[string]string{"name": s.metadata.queueName})
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return 0, fmt.Errorf("windows_exporter metrics have no %s for queue %s", msmqWindowsExporterMetric, s.metadata.queueName)
+		}
+		return length, nil
+	}
+
+	var result msmqAgentResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return 0, fmt.Errorf("error decoding msmq agent response: %s", err)
+	}
+	if result.MessageCount == nil {
+		return 0, fmt.Errorf("msmq agent response has no messageCount for queue %s", s.metadata.queueName)
+	}
+	return *result.MessageCount, nil
+}
@@ -0,0 +1,141 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+type parseMSMQMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type msmqMetricIdentifier struct {
+	metadataTestData *parseMSMQMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testMsmqMetadata = []parseMSMQMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed agent
+	{map[string]string{"url": "http://msmq-agent:8080", "queueName": `private$\orders`}, map[string]string{}, false},
+	// properly formed windows_exporter with basic auth
+	{map[string]string{"url": "https://win-1:9182/metrics", "endpointType": "windowsExporter", "queueName": `win-1\private$\invoices`, "queueLength": "10", "activationQueueLength": "2", "unsafeSsl": "true"}, map[string]string{"username": "keda", "password": "secret"}, false},
+	// url from auth params with bearer token
+	{map[string]string{"queueName": "orders"}, map[string]string{"url": "http://msmq-agent:8080", "bearerToken": "token"}, false},
+	// missing url
+	{map[string]string{"queueName": "orders"}, map[string]string{}, true},
+	// missing queueName
+	{map[string]string{"url": "http://msmq-agent:8080"}, map[string]string{}, true},
+	// unsupported endpointType
+	{map[string]string{"url": "http://msmq-agent:8080", "queueName": "orders", "endpointType": "wmi"}, map[string]string{}, true},
+	// invalid queueLength
+	{map[string]string{"url": "http://msmq-agent:8080", "queueName": "orders", "queueLength": "0"}, map[string]string{}, true},
+	// invalid activationQueueLength
+	{map[string]string{"url": "http://msmq-agent:8080", "queueName": "orders", "activationQueueLength": "a"}, map[string]string{}, true},
+	// password without username
+	{map[string]string{"url": "http://msmq-agent:8080", "queueName": "orders"}, map[string]string{"password": "secret"}, true},
+	// bearer token and username
+	{map[string]string{"url": "http://msmq-agent:8080", "queueName": "orders"}, map[string]string{"username": "keda", "bearerToken": "token"}, true},
+	// invalid unsafeSsl
+	{map[string]string{"url": "http://msmq-agent:8080", "queueName": "orders", "unsafeSsl": "yes"}, map[string]string{}, true},
+}
+
+var msmqMetricIdentifiers = []msmqMetricIdentifier{
+	{&testMsmqMetadata[1], 0, "s0-msmq-private-orders"},
+	{&testMsmqMetadata[2], 1, "s1-msmq-win-1-private-invoices"},
+}
+
+func TestMSMQParseMetadata(t *testing.T) {
+	for i, testData := range testMsmqMetadata {
+		_, err := parseMSMQMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("test %d: expected success but got error: %s", i, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("test %d: expected error but got success", i)
+		}
+	}
+}
+
+func TestMSMQGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range msmqMetricIdentifiers {
+		meta, err := parseMSMQMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockMsmqScaler := msmqScaler{
+			metadata: meta,
+			logger:   logr.Discard(),
+		}
+
+		metricSpec := mockMsmqScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Errorf("Wrong External metric source name: %s, expected: %s", metricName, testData.name)
+		}
+	}
+}
+
+func TestMSMQGetQueueLength(t *testing.T) {
+	windowsExporterMetrics := `# HELP windows_msmq_messages_in_queue Count of all messages in the queue
+# TYPE windows_msmq_messages_in_queue gauge
+windows_msmq_messages_in_queue{name="win-1\\private$\\orders"} 7
+windows_msmq_messages_in_queue{name="win-1\\private$\\invoices"} 3
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.EscapedPath() {
+		case "/metrics":
+			_, _ = w.Write([]byte(windowsExporterMetrics))
+		case "/queues/private$%5Corders":
+			_, _ = w.Write([]byte(`{"name":"private$\\orders","messageCount":12}`))
+		case "/queues/unknown":
+			_, _ = w.Write([]byte(`{"name":"unknown"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		metadata map[string]string
+		expected float64
+		isError  bool
+	}{
+		{"agent", map[string]string{"url": server.URL, "queueName": `private$\orders`}, 12, false},
+		{"agent without messageCount", map[string]string{"url": server.URL, "queueName": "unknown"}, 0, true},
+		{"agent without queue", map[string]string{"url": server.URL, "queueName": "missing"}, 0, true},
+		{"windows_exporter", map[string]string{"url": server.URL + "/metrics", "endpointType": "windowsExporter", "queueName": `win-1\private$\orders`}, 7, false},
+		{"windows_exporter without metric", map[string]string{"url": server.URL + "/missing", "endpointType": "windowsExporter", "queueName": `win-1\private$\orders`}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta, err := parseMSMQMetadata(&ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"bearerToken": "token"}})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			scaler := msmqScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+			value, err := scaler.getQueueLength(context.Background())
+			if tc.isError != (err != nil) {
+				t.Fatalf("expected error %v but got %v", tc.isError, err)
+			}
+			if value != tc.expected {
+				t.Errorf("expected %v but got %v", tc.expected, value)
+			}
+		})
+	}
+}
@@ -19,10 +19,11 @@ import (
 
 // mssqlScaler exposes a data pointer to mssqlMetadata and sql.DB connection
 type mssqlScaler struct {
-	metricType v2beta2.MetricTargetType
-	metadata   *mssqlMetadata
-	connection *sql.DB
-	logger     logr.Logger
+	metricType    v2beta2.MetricTargetType
+	metadata      *mssqlMetadata
+	connection    *sql.DB
+	queryRecorder *QueryRecorder
+	logger        logr.Logger
 }
 
 // mssqlMetadata defines metadata used by KEDA to query a Microsoft SQL database
@@ -83,10 +84,11 @@ func NewMSSQLScaler(config *ScalerConfig) (Scaler, error) {
 	}
 
 	return &mssqlScaler{
-		metricType: metricType,
-		metadata:   meta,
-		connection: conn,
-		logger:     logger,
+		metricType:    metricType,
+		metadata:      meta,
+		connection:    conn,
+		queryRecorder: config.QueryRecorder,
+		logger:        logger,
 	}, nil
 }
 
@@ -259,6 +261,7 @@ func (s *mssqlScaler) GetMetrics(ctx context.Context, metricName string, metricS
 // getQueryResult returns the result of the scaler query
 func (s *mssqlScaler) getQueryResult(ctx context.Context) (float64, error) {
 	var value float64
+	s.queryRecorder.Record(s.metadata.query)
 	err := s.connection.QueryRowContext(ctx, s.metadata.query).Scan(&value)
 	switch {
 	case err == sql.ErrNoRows:
@@ -17,10 +17,11 @@ import (
 )
 
 type mySQLScaler struct {
-	metricType v2beta2.MetricTargetType
-	metadata   *mySQLMetadata
-	connection *sql.DB
-	logger     logr.Logger
+	metricType    v2beta2.MetricTargetType
+	metadata      *mySQLMetadata
+	connection    *sql.DB
+	queryRecorder *QueryRecorder
+	logger        logr.Logger
 }
 
 type mySQLMetadata struct {
@@ -34,6 +35,11 @@ type mySQLMetadata struct {
 	queryValue           float64
 	activationQueryValue float64
 	metricName           string
+
+	// TLS
+	unsafeSsl bool
+	tlsAuthParams
+	tlsConfigName string
 }
 
 // NewMySQLScaler creates a new MySQL scaler
@@ -55,10 +61,11 @@ func NewMySQLScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error establishing MySQL connection: %s", err)
 	}
 	return &mySQLScaler{
-		metricType: metricType,
-		metadata:   meta,
-		connection: conn,
-		logger:     logger,
+		metricType:    metricType,
+		metadata:      meta,
+		connection:    conn,
+		queryRecorder: config.QueryRecorder,
+		logger:        logger,
 	}, nil
 }
 
@@ -129,6 +136,22 @@ func parseMySQLMetadata(config *ScalerConfig) (*mySQLMetadata, error) {
 		}
 	}
 
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.unsafeSsl = unsafeSsl
+
+	tlsParams, err := parseTLSAuthParams(config)
+	if err != nil {
+		return nil, err
+	}
+	meta.tlsAuthParams = tlsParams
+	if meta.enableTLS {
+		// the driver looks the TLS configs up by name in a global registry, so the name is unique per trigger
+		meta.tlsConfigName = fmt.Sprintf("keda-%s-%s-%s-%d", config.ScalableObjectType, config.ScalableObjectNamespace, config.ScalableObjectName, config.ScalerIndex)
+	}
+
 	if meta.connectionString != "" {
 		meta.dbName = parseMySQLDbNameFromConnectionStr(meta.connectionString)
 	}
@@ -137,12 +160,20 @@ func parseMySQLMetadata(config *ScalerConfig) (*mySQLMetadata, error) {
 	return &meta, nil
 }
 
-// metadataToConnectionStr builds new MySQL connection string
-func metadataToConnectionStr(meta *mySQLMetadata) string {
+// metadataToConnectionStr builds new MySQL connection string, using the registered TLS config if TLS is enabled
+func metadataToConnectionStr(meta *mySQLMetadata) (string, error) {
 	var connStr string
 
 	if meta.connectionString != "" {
 		connStr = meta.connectionString
+		if meta.enableTLS {
+			config, err := mysql.ParseDSN(connStr)
+			if err != nil {
+				return "", fmt.Errorf("error parsing connection string: %s", err)
+			}
+			config.TLSConfig = meta.tlsConfigName
+			connStr = config.FormatDSN()
+		}
 	} else {
 		// Build connection str
 		config := mysql.NewConfig()
@@ -151,14 +182,34 @@ func metadataToConnectionStr(meta *mySQLMetadata) string {
 		config.Passwd = meta.password
 		config.User = meta.username
 		config.Net = "tcp"
+		if meta.enableTLS {
+			config.TLSConfig = meta.tlsConfigName
+		}
 		connStr = config.FormatDSN()
 	}
-	return connStr
+	return connStr, nil
+}
+
+// registerMySQLTLSConfig registers the TLS config of the trigger in the driver
+func registerMySQLTLSConfig(meta *mySQLMetadata) error {
+	tlsConfig, err := meta.newTLSConfig(meta.unsafeSsl)
+	if err != nil {
+		return fmt.Errorf("error creating MySQL tls config: %s", err)
+	}
+	return mysql.RegisterTLSConfig(meta.tlsConfigName, tlsConfig)
 }
 
 // newMySQLConnection creates MySQL db connection
 func newMySQLConnection(meta *mySQLMetadata, logger logr.Logger) (*sql.DB, error) {
-	connStr := metadataToConnectionStr(meta)
+	if meta.enableTLS {
+		if err := registerMySQLTLSConfig(meta); err != nil {
+			return nil, err
+		}
+	}
+	connStr, err := metadataToConnectionStr(meta)
+	if err != nil {
+		return nil, err
+	}
 	db, err := sql.Open("mysql", connStr)
 	if err != nil {
 		logger.Error(err, fmt.Sprintf("Found error when opening connection: %s", err))
@@ -185,6 +236,9 @@ func parseMySQLDbNameFromConnectionStr(connectionString string) string {
 
 // Close disposes of MySQL connections
 func (s *mySQLScaler) Close(context.Context) error {
+	if s.metadata.enableTLS {
+		mysql.DeregisterTLSConfig(s.metadata.tlsConfigName)
+	}
 	err := s.connection.Close()
 	if err != nil {
 		s.logger.Error(err, "Error closing MySQL connection")
@@ -206,6 +260,7 @@ func (s *mySQLScaler) IsActive(ctx context.Context) (bool, error) {
 // getQueryResult returns result of the scaler query
 func (s *mySQLScaler) getQueryResult(ctx context.Context) (float64, error) {
 	var value float64
+	s.queryRecorder.Record(s.metadata.query)
 	err := s.connection.QueryRowContext(ctx, s.metadata.query).Scan(&value)
 	if err != nil {
 		s.logger.Error(err, fmt.Sprintf("Could not query MySQL database: %s", err))
@@ -2,6 +2,8 @@ package scalers
 
 import (
 	"testing"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 var testMySQLResolvedEnv = map[string]string{
@@ -58,6 +60,34 @@ var testMySQLMetadata = []parseMySQLMetadataTestData{
 		resolvedEnv: testMySQLResolvedEnv,
 		raisesError: true,
 	},
+	// TLS with CA and client certificate
+	{
+		metadata:    map[string]string{"query": "query", "queryValue": "12"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname", "tls": "enable", "ca": "caaa", "cert": "ceert", "key": "keey"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: false,
+	},
+	// TLS with cert but no key
+	{
+		metadata:    map[string]string{"query": "query", "queryValue": "12"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname", "tls": "enable", "cert": "ceert"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
+	// Invalid TLS value
+	{
+		metadata:    map[string]string{"query": "query", "queryValue": "12"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname", "tls": "yes"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
+	// Invalid unsafeSsl
+	{
+		metadata:    map[string]string{"query": "query", "queryValue": "12", "unsafeSsl": "maybe"},
+		authParams:  map[string]string{"host": "test_host", "port": "test_port", "username": "test_username", "password": "MYSQL_PASSWORD", "dbName": "test_dbname"},
+		resolvedEnv: testMySQLResolvedEnv,
+		raisesError: true,
+	},
 }
 
 var mySQLMetricIdentifiers = []mySQLMetricIdentifier{
@@ -81,7 +111,7 @@ func TestMetadataToConnectionStrUseConnStr(t *testing.T) {
 	// Use existing ConnStr
 	testMeta := map[string]string{"query": "query", "queryValue": "12", "connectionStringFromEnv": "MYSQL_CONN_STR"}
 	meta, _ := parseMySQLMetadata(&ScalerConfig{ResolvedEnv: testMySQLResolvedEnv, TriggerMetadata: testMeta, AuthParams: map[string]string{}})
-	connStr := metadataToConnectionStr(meta)
+	connStr, _ := metadataToConnectionStr(meta)
 	if connStr != testMySQLResolvedEnv["MYSQL_CONN_STR"] {
 		t.Error("Expected success")
 	}
@@ -92,7 +122,40 @@ func TestMetadataToConnectionStrBuildNew(t *testing.T) {
 	expected := "test_username:pass@tcp(test_host:test_port)/test_dbname"
 	testMeta := map[string]string{"query": "query", "queryValue": "12", "host": "test_host", "port": "test_port", "username": "test_username", "passwordFromEnv": "MYSQL_PASSWORD", "dbName": "test_dbname"}
 	meta, _ := parseMySQLMetadata(&ScalerConfig{ResolvedEnv: testMySQLResolvedEnv, TriggerMetadata: testMeta, AuthParams: map[string]string{}})
-	connStr := metadataToConnectionStr(meta)
+	connStr, _ := metadataToConnectionStr(meta)
+	if connStr != expected {
+		t.Errorf("%s != %s", expected, connStr)
+	}
+}
+
+func TestMetadataToConnectionStrWithTLS(t *testing.T) {
+	testMeta := map[string]string{"query": "query", "queryValue": "12", "host": "test_host", "port": "test_port", "username": "test_username", "passwordFromEnv": "MYSQL_PASSWORD", "dbName": "test_dbname"}
+	config := &ScalerConfig{ResolvedEnv: testMySQLResolvedEnv, TriggerMetadata: testMeta, AuthParams: map[string]string{"tls": "enable"}, ScalableObjectType: "ScaledObject", ScalableObjectNamespace: "test", ScalableObjectName: "consumer", ScalerIndex: 1}
+	meta, err := parseMySQLMetadata(config)
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	expected := "test_username:pass@tcp(test_host:test_port)/test_dbname?tls=keda-ScaledObject-test-consumer-1"
+	connStr, err := metadataToConnectionStr(meta)
+	if err != nil {
+		t.Fatal("Could not build connection string:", err)
+	}
+	if connStr != expected {
+		t.Errorf("%s != %s", expected, connStr)
+	}
+
+	// the TLS config must be registered before an existing connection string is parsed
+	if err := registerMySQLTLSConfig(meta); err != nil {
+		t.Fatal("Could not register tls config:", err)
+	}
+	defer mysql.DeregisterTLSConfig(meta.tlsConfigName)
+	meta.connectionString = "user:pass@tcp(my.mysql.dev:3306)/stats_db"
+	expected = "user:pass@tcp(my.mysql.dev:3306)/stats_db?tls=keda-ScaledObject-test-consumer-1"
+	connStr, err = metadataToConnectionStr(meta)
+	if err != nil {
+		t.Fatal("Could not build connection string:", err)
+	}
 	if connStr != expected {
 		t.Errorf("%s != %s", expected, connStr)
 	}
@@ -23,6 +23,13 @@ const (
 	defaultJetStreamLagThreshold = 10
 )
 
+// supported consumer metrics
+const (
+	jetStreamLagMetricPending     = "pending"
+	jetStreamLagMetricAckPending  = "ackPending"
+	jetStreamLagMetricConsumerLag = "consumerLag"
+)
+
 type natsJetStreamScaler struct {
 	metricType v2beta2.MetricTargetType
 	stream     *streamDetail
@@ -32,10 +39,11 @@ type natsJetStreamScaler struct {
 }
 
 type natsJetStreamMetadata struct {
-	monitoringEndpoint     string
+	monitoring             natsMonitoringMetadata
 	account                string
 	stream                 string
 	consumer               string
+	lagMetric              string
 	lagThreshold           int64
 	activationLagThreshold int64
 	scalerIndex            int
@@ -82,7 +90,7 @@ type consumerConfig struct {
 }
 
 type consumerDeliveryStatus struct {
-	ConsumerSequence int64 `json:"customer_seq"`
+	ConsumerSequence int64 `json:"consumer_seq"`
 	StreamSequence   int64 `json:"stream_seq"`
 }
 
@@ -97,11 +105,16 @@ func NewNATSJetStreamScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing NATS JetStream metadata: %s", err)
 	}
 
+	httpClient, err := newNATSMonitoringHTTPClient(config, jsMetadata.monitoring)
+	if err != nil {
+		return nil, err
+	}
+
 	return &natsJetStreamScaler{
 		metricType: metricType,
 		stream:     &streamDetail{},
 		metadata:   jsMetadata,
-		httpClient: kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		httpClient: httpClient,
 		logger:     InitializeLogger(config, "nats_jetstream_scaler"),
 	}, nil
 }
@@ -109,7 +122,7 @@ func NewNATSJetStreamScaler(config *ScalerConfig) (Scaler, error) {
 func parseNATSJetStreamMetadata(config *ScalerConfig) (natsJetStreamMetadata, error) {
 	meta := natsJetStreamMetadata{}
 	var err error
-	meta.monitoringEndpoint, err = GetFromAuthOrMeta(config, "natsServerMonitoringEndpoint")
+	meta.monitoring, err = parseNATSMonitoringMetadata(config)
 	if err != nil {
 		return meta, err
 	}
@@ -129,6 +142,16 @@ func parseNATSJetStreamMetadata(config *ScalerConfig) (natsJetStreamMetadata, er
 	}
 	meta.consumer = config.TriggerMetadata["consumer"]
 
+	meta.lagMetric = jetStreamLagMetricPending
+	if val, ok := config.TriggerMetadata["lagMetric"]; ok && val != "" {
+		switch val {
+		case jetStreamLagMetricPending, jetStreamLagMetricAckPending, jetStreamLagMetricConsumerLag:
+			meta.lagMetric = val
+		default:
+			return meta, fmt.Errorf("lagMetric must be one of %s, %s, %s", jetStreamLagMetricPending, jetStreamLagMetricAckPending, jetStreamLagMetricConsumerLag)
+		}
+	}
+
 	meta.lagThreshold = defaultJetStreamLagThreshold
 
 	if val, ok := config.TriggerMetadata[lagThresholdMetricName]; ok {
@@ -152,42 +175,82 @@ func parseNATSJetStreamMetadata(config *ScalerConfig) (natsJetStreamMetadata, er
 	return meta, nil
 }
 
-func (s *natsJetStreamScaler) getNATSJetStreamEndpoint() string {
-	return fmt.Sprintf("http://%s/jsz?acc=%s&consumers=true&config=true", s.metadata.monitoringEndpoint, s.metadata.account)
+func (s *natsJetStreamScaler) getNATSJetStreamEndpoint(server string) string {
+	return s.metadata.monitoring.url(server, fmt.Sprintf("/jsz?acc=%s&consumers=true&config=true", s.metadata.account))
 }
 
 func (s *natsJetStreamScaler) IsActive(ctx context.Context) (bool, error) {
-	monitoringEndpoint := s.getNATSJetStreamEndpoint()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, monitoringEndpoint, nil)
+	totalLag, err := s.getStreamLag(ctx)
 	if err != nil {
 		return false, err
 	}
+	return totalLag > s.metadata.activationLagThreshold, nil
+}
 
-	resp, err := s.httpClient.Do(req)
+// getStreamLag returns the lag of the consumer. With cluster aggregation every server
+// of the cluster is queried and the highest lag is used, as followers only ever under-report it.
+func (s *natsJetStreamScaler) getStreamLag(ctx context.Context) (int64, error) {
+	servers, err := getNATSMonitoringServers(ctx, s.httpClient, s.metadata.monitoring)
 	if err != nil {
-		s.logger.Error(err, "unable to access NATS JetStream monitoring endpoint", "natsServerMonitoringEndpoint", s.metadata.monitoringEndpoint)
-		return false, err
+		s.logger.Error(err, "unable to discover NATS cluster servers", "natsServerMonitoringEndpoint", s.metadata.monitoring.endpoint)
+		return -1, err
+	}
+
+	var lastErr error
+	var totalLag int64
+	queried, found := false, false
+	for _, server := range servers {
+		stream, err := s.getStream(ctx, server)
+		if err != nil {
+			s.logger.Error(err, "unable to access NATS JetStream monitoring endpoint", "natsServerMonitoringEndpoint", server)
+			lastErr = err
+			continue
+		}
+		queried = true
+		if stream == nil {
+			continue
+		}
+
+		s.stream = stream
+		if lag := s.getMaxMsgLag(); !found || lag > totalLag {
+			totalLag = lag
+		}
+		found = true
+	}
+
+	if !queried {
+		return -1, lastErr
+	}
+	if !found {
+		totalLag = s.getMaxMsgLag()
+	}
+	return totalLag, nil
+}
+
+// getStream returns the stream that we are looking for as seen by the server, or nil if the
+// server doesn't hold it.
+func (s *natsJetStreamScaler) getStream(ctx context.Context, server string) (*streamDetail, error) {
+	resp, err := doNATSMonitoringRequest(ctx, s.httpClient, s.metadata.monitoring, s.getNATSJetStreamEndpoint(server))
+	if err != nil {
+		return nil, err
 	}
 
 	defer resp.Body.Close()
 	var jsAccountResp jetStreamEndpointResponse
 	if err = json.NewDecoder(resp.Body).Decode(&jsAccountResp); err != nil {
-		s.logger.Error(err, "unable to decode JetStream account response")
-		return false, err
+		return nil, fmt.Errorf("unable to decode JetStream account response: %s", err)
 	}
 
-	// Find and assign the stream that we are looking for.
 	for _, account := range jsAccountResp.Accounts {
 		if account.Name == s.metadata.account {
 			for _, stream := range account.Streams {
 				if stream.Name == s.metadata.stream {
-					s.stream = stream
+					return stream, nil
 				}
 			}
 		}
 	}
-	return s.getMaxMsgLag() > s.metadata.activationLagThreshold, nil
+	return nil, nil
 }
 
 func (s *natsJetStreamScaler) getMaxMsgLag() int64 {
@@ -195,7 +258,15 @@ func (s *natsJetStreamScaler) getMaxMsgLag() int64 {
 
 	for _, consumer := range s.stream.Consumers {
 		if consumer.Name == consumerName {
-			return int64(consumer.NumPending)
+			switch s.metadata.lagMetric {
+			case jetStreamLagMetricAckPending:
+				return int64(consumer.NumAckPending)
+			case jetStreamLagMetricConsumerLag:
+				// messages in the stream which haven't been delivered to the consumer yet
+				return s.stream.State.LastSequence - consumer.DeliveryStatus.StreamSequence
+			default:
+				return int64(consumer.NumPending)
+			}
 		}
 	}
 	return s.stream.State.LastSequence
@@ -216,36 +287,11 @@ func (s *natsJetStreamScaler) GetMetricSpecForScaling(context.Context) []v2beta2
 }
 
 func (s *natsJetStreamScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.getNATSJetStreamEndpoint(), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.httpClient.Do(req)
+	totalLag, err := s.getStreamLag(ctx)
 	if err != nil {
-		s.logger.Error(err, "unable to access NATS JetStream monitoring endpoint", "natsServerMonitoringEndpoint", s.metadata.monitoringEndpoint)
-		return []external_metrics.ExternalMetricValue{}, err
-	}
-
-	defer resp.Body.Close()
-	var jsAccountResp jetStreamEndpointResponse
-	if err = json.NewDecoder(resp.Body).Decode(&jsAccountResp); err != nil {
-		s.logger.Error(err, "unable to decode JetStream account details")
 		return []external_metrics.ExternalMetricValue{}, err
 	}
 
-	// Find and assign the stream that we are looking for.
-	for _, account := range jsAccountResp.Accounts {
-		if account.Name == s.metadata.account {
-			for _, stream := range account.Streams {
-				if stream.Name == s.metadata.stream {
-					s.stream = stream
-				}
-			}
-		}
-	}
-
-	totalLag := s.getMaxMsgLag()
 	s.logger.V(1).Info("NATS JetStream Scaler: Providing metrics based on totalLag, threshold", "totalLag", totalLag, "lagThreshold", s.metadata.lagThreshold)
 
 	metric := external_metrics.ExternalMetricValue{
@@ -37,6 +37,14 @@ var testNATSJetStreamMetadata = []parseNATSJetStreamMetadataTestData{
 	{map[string]string{"account": "$G", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, false},
 	// Missing nats server monitoring endpoint , should fail
 	{map[string]string{"account": "$G", "stream": "mystream", "consumer": "pull_consumer"}, map[string]string{"natsServerMonitoringEndpoint": ""}, true},
+	// All good + clusterAggregation, token and tls
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "clusterAggregation": "true"}, map[string]string{"token": "secret", "tls": "enable", "ca": "caaa"}, false},
+	// Invalid clusterAggregation, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "clusterAggregation": "yes please"}, map[string]string{}, true},
+	// All good + ack pending metric
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "lagMetric": "ackPending"}, map[string]string{}, false},
+	// Invalid lagMetric, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "account": "$G", "stream": "mystream", "consumer": "pull_consumer", "lagMetric": "redelivered"}, map[string]string{}, true},
 }
 
 var natsJetStreamMetricIdentifiers = []natsJetStreamMetricIdentifier{
@@ -75,3 +83,37 @@ func TestNATSJetStreamGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestNATSJetStreamGetMaxMsgLag(t *testing.T) {
+	stream := &streamDetail{
+		Name:  "mystream",
+		State: streamState{LastSequence: 100},
+		Consumers: []consumerDetail{
+			{
+				Name:           "pull_consumer",
+				NumPending:     30,
+				NumAckPending:  5,
+				DeliveryStatus: consumerDeliveryStatus{StreamSequence: 60},
+			},
+		},
+	}
+	testCases := []struct {
+		lagMetric string
+		consumer  string
+		expected  int64
+	}{
+		{jetStreamLagMetricPending, "pull_consumer", 30},
+		{jetStreamLagMetricAckPending, "pull_consumer", 5},
+		{jetStreamLagMetricConsumerLag, "pull_consumer", 40},
+		{jetStreamLagMetricPending, "missing_consumer", 100},
+	}
+	for _, tc := range testCases {
+		scaler := natsJetStreamScaler{
+			stream:   stream,
+			metadata: natsJetStreamMetadata{consumer: tc.consumer, lagMetric: tc.lagMetric},
+		}
+		if lag := scaler.getMaxMsgLag(); lag != tc.expected {
+			t.Errorf("Expected lag %d for %s of %s but got %d", tc.expected, tc.lagMetric, tc.consumer, lag)
+		}
+	}
+}
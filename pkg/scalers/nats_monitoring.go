@@ -0,0 +1,152 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+// natsMonitoringMetadata holds the settings shared by the NATS scalers to reach
+// the monitoring endpoint of the NATS servers
+type natsMonitoringMetadata struct {
+	endpoint           string
+	clusterAggregation bool
+
+	// Auth
+	token    string
+	username string
+	password string
+
+	// TLS
+	tlsAuthParams
+}
+
+type natsRoutezResponse struct {
+	Routes []natsRouteInfo `json:"routes"`
+}
+
+type natsRouteInfo struct {
+	RemoteID string `json:"remote_id"`
+	IP       string `json:"ip"`
+}
+
+func parseNATSMonitoringMetadata(config *ScalerConfig) (natsMonitoringMetadata, error) {
+	meta := natsMonitoringMetadata{}
+	var err error
+	meta.endpoint, err = GetFromAuthOrMeta(config, "natsServerMonitoringEndpoint")
+	if err != nil {
+		return meta, err
+	}
+
+	meta.clusterAggregation = false
+	if val, ok := config.TriggerMetadata["clusterAggregation"]; ok {
+		meta.clusterAggregation, err = strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing clusterAggregation: %s", err)
+		}
+	}
+
+	meta.token = config.AuthParams["token"]
+	meta.username = config.AuthParams["username"]
+	meta.password = config.AuthParams["password"]
+	if meta.token != "" && meta.username != "" {
+		return meta, errors.New("token and username can't be used together")
+	}
+	if meta.password != "" && meta.username == "" {
+		return meta, errors.New("password must be provided with username")
+	}
+
+	tlsParams, err := parseTLSAuthParams(config)
+	if err != nil {
+		return meta, err
+	}
+	meta.tlsAuthParams = tlsParams
+
+	return meta, nil
+}
+
+// newNATSMonitoringHTTPClient creates the HTTP client used to query the monitoring endpoint. Like
+// kedautil.NewTLSConfigWithPassword, the client doesn't verify the server when the CA certificate is given.
+func newNATSMonitoringHTTPClient(config *ScalerConfig, meta natsMonitoringMetadata) (*http.Client, error) {
+	httpClient, err := createTLSHTTPClient(config, meta.ca != "", meta.tlsAuthParams)
+	if err != nil {
+		return nil, fmt.Errorf("error creating NATS monitoring tls config: %s", err)
+	}
+	return httpClient, nil
+}
+
+func (m natsMonitoringMetadata) scheme() string {
+	if m.enableTLS {
+		return "https"
+	}
+	return "http"
+}
+
+// url returns the url of the monitoring path on the given server
+func (m natsMonitoringMetadata) url(server, path string) string {
+	return fmt.Sprintf("%s://%s%s", m.scheme(), server, path)
+}
+
+// doNATSMonitoringRequest sends a GET request to the monitoring endpoint, adding the configured credentials
+func doNATSMonitoringRequest(ctx context.Context, client *http.Client, meta natsMonitoringMetadata, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case meta.token != "":
+		req.Header.Set("Authorization", "Bearer "+meta.token)
+	case meta.username != "":
+		req.SetBasicAuth(meta.username, meta.password)
+	}
+
+	return client.Do(req)
+}
+
+// getNATSMonitoringServers returns the monitoring endpoints to scrape. When cluster aggregation
+// is enabled, the other servers of the cluster are discovered from the routes of the configured
+// server and are expected to expose their monitoring endpoint on the same port.
+func getNATSMonitoringServers(ctx context.Context, client *http.Client, meta natsMonitoringMetadata) ([]string, error) {
+	servers := []string{meta.endpoint}
+	if !meta.clusterAggregation {
+		return servers, nil
+	}
+
+	_, port, err := net.SplitHostPort(meta.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing natsServerMonitoringEndpoint: %s", err)
+	}
+
+	resp, err := doNATSMonitoringRequest(ctx, client, meta, meta.url(meta.endpoint, "/routez"))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("NATS routez endpoint returned status code %d", resp.StatusCode)
+	}
+
+	var routez natsRoutezResponse
+	if err := json.NewDecoder(resp.Body).Decode(&routez); err != nil {
+		return nil, fmt.Errorf("error decoding NATS routes: %s", err)
+	}
+
+	seen := map[string]bool{meta.endpoint: true}
+	for _, route := range routez.Routes {
+		if route.IP == "" {
+			continue
+		}
+		server := net.JoinHostPort(route.IP, port)
+		if !seen[server] {
+			seen[server] = true
+			servers = append(servers, server)
+		}
+	}
+	return servers, nil
+}
@@ -0,0 +1,133 @@
+package scalers
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+type parseNATSMonitoringMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+var testNATSMonitoringMetadata = []parseNATSMonitoringMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// Only endpoint
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, map[string]string{}, false},
+	// Token
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, map[string]string{"token": "secret"}, false},
+	// Token and username, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, map[string]string{"token": "secret", "username": "user"}, true},
+	// Password without username, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, map[string]string{"password": "pass"}, true},
+	// TLS with cert and key
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, map[string]string{"tls": "enable", "cert": "ceert", "key": "keey"}, false},
+	// Key without cert, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, map[string]string{"tls": "enable", "key": "keey"}, true},
+	// Invalid tls, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222"}, map[string]string{"tls": "yes"}, true},
+	// Invalid clusterAggregation, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "nats.nats:8222", "clusterAggregation": "maybe"}, map[string]string{}, true},
+}
+
+func TestParseNATSMonitoringMetadata(t *testing.T) {
+	for _, testData := range testNATSMonitoringMetadata {
+		_, err := parseNATSMonitoringMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		} else if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestNATSMonitoringURL(t *testing.T) {
+	meta := natsMonitoringMetadata{endpoint: "nats.nats:8222"}
+	if url := meta.url(meta.endpoint, "/jsz"); url != "http://nats.nats:8222/jsz" {
+		t.Errorf("Expected http url but got %s", url)
+	}
+	meta.enableTLS = true
+	if url := meta.url(meta.endpoint, "/jsz"); url != "https://nats.nats:8222/jsz" {
+		t.Errorf("Expected https url but got %s", url)
+	}
+}
+
+func TestGetNATSMonitoringServers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/routez" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"routes":[{"remote_id":"a","ip":"10.0.0.2"},{"remote_id":"b","ip":"10.0.0.3"},{"remote_id":"c","ip":"10.0.0.2"}]}`)
+	}))
+	defer server.Close()
+
+	endpoint := server.Listener.Addr().String()
+	_, port, _ := net.SplitHostPort(endpoint)
+
+	meta := natsMonitoringMetadata{endpoint: endpoint, token: "secret"}
+	servers, err := getNATSMonitoringServers(context.Background(), server.Client(), meta)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if !reflect.DeepEqual(servers, []string{endpoint}) {
+		t.Errorf("Expected only the configured server without cluster aggregation but got %v", servers)
+	}
+
+	meta.clusterAggregation = true
+	servers, err = getNATSMonitoringServers(context.Background(), server.Client(), meta)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	expected := []string{endpoint, "10.0.0.2:" + port, "10.0.0.3:" + port}
+	if !reflect.DeepEqual(servers, expected) {
+		t.Errorf("Expected %v but got %v", expected, servers)
+	}
+
+	meta.token = "wrong"
+	if _, err = getNATSMonitoringServers(context.Background(), server.Client(), meta); err == nil {
+		t.Error("Expected error with wrong token but got success")
+	}
+}
+
+func TestNewNATSMonitoringHTTPClientCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	otherServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer otherServer.Close()
+	otherCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: otherServer.Certificate().Raw}))
+
+	// without CA the server is verified with the system CAs
+	meta := natsMonitoringMetadata{tlsAuthParams: tlsAuthParams{enableTLS: true}}
+	client, err := newNATSMonitoringHTTPClient(&ScalerConfig{}, meta)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if _, err = client.Get(server.URL); err == nil {
+		t.Error("Expected error verifying the self-signed server but got success")
+	}
+
+	// with CA the server isn't verified, as with kedautil.NewTLSConfigWithPassword
+	meta.ca = otherCA
+	client, err = newNATSMonitoringHTTPClient(&ScalerConfig{}, meta)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	resp.Body.Close()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 
 	"github.com/go-logr/logr"
@@ -24,8 +25,14 @@ const (
 	threshold   = "threshold"
 	noDataError = "noDataError"
 	scalerName  = "new-relic"
+
+	facetAggregation = "facetAggregation"
+	newrelicFacetKey = "facet"
 )
 
+// newrelicFacetAggregations are the functions reducing the results of a faceted NRQL query to one value
+var newrelicFacetAggregations = map[string]bool{"max": true, "sum": true, "avg": true}
+
 type newrelicScaler struct {
 	metricType v2beta2.MetricTargetType
 	metadata   *newrelicMetadata
@@ -39,6 +46,7 @@ type newrelicMetadata struct {
 	queryKey            string
 	noDataError         bool
 	nrql                string
+	facetAggregation    string
 	threshold           float64
 	activationThreshold float64
 	scalerIndex         int
@@ -96,6 +104,13 @@ func parseNewRelicMetadata(config *ScalerConfig, logger logr.Logger) (*newrelicM
 		return nil, fmt.Errorf("no %s given", nrql)
 	}
 
+	if val, ok := config.TriggerMetadata[facetAggregation]; ok && val != "" {
+		if !newrelicFacetAggregations[val] {
+			return nil, fmt.Errorf("%s must be max, sum or avg, got %s", facetAggregation, val)
+		}
+		meta.facetAggregation = val
+	}
+
 	meta.queryKey, err = GetFromAuthOrMeta(config, queryKey)
 	if err != nil {
 		return nil, fmt.Errorf("no %s given", queryKey)
@@ -160,17 +175,74 @@ func (s *newrelicScaler) executeNewRelicQuery(ctx context.Context) (float64, err
 	if err != nil {
 		return 0, fmt.Errorf("error running NRQL %s (%s)", s.metadata.nrql, err.Error())
 	}
-	// Only use the first result from the query, as the query should not be multi row
-	for _, v := range resp.Results[0] {
-		val, ok := v.(float64)
-		if ok {
-			return val, nil
+
+	var values []float64
+	for index, result := range resp.Results {
+		// Only use the first result from the query unless the results of a FACET are aggregated
+		if index > 0 && s.metadata.facetAggregation == "" {
+			break
+		}
+		if val, ok := newrelicResultValue(result); ok {
+			values = append(values, val)
+		}
+	}
+	if len(values) == 0 {
+		if s.metadata.noDataError {
+			return 0, fmt.Errorf("query return no results %s", s.metadata.nrql)
+		}
+		return 0, nil
+	}
+	return aggregateNewRelicValues(values, s.metadata.facetAggregation), nil
+}
+
+// newrelicResultValue returns the numeric value of a result, skipping the facet and the attributes it is
+// made of which a faceted result also contains
+func newrelicResultValue(result nrdb.NRDBResult) (float64, bool) {
+	facets := map[interface{}]bool{}
+	switch facet := result[newrelicFacetKey].(type) {
+	case nil:
+	case []interface{}:
+		for _, value := range facet {
+			facets[value] = true
+		}
+	default:
+		facets[facet] = true
+	}
+
+	keys := make([]string, 0, len(result))
+	for key := range result {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		val, ok := result[key].(float64)
+		if ok && key != newrelicFacetKey && !facets[val] {
+			return val, true
 		}
 	}
-	if s.metadata.noDataError {
-		return 0, fmt.Errorf("query return no results %s", s.metadata.nrql)
+	return 0, false
+}
+
+// aggregateNewRelicValues reduces the values of the results with the facet aggregation, the first value
+// is used when there is none
+func aggregateNewRelicValues(values []float64, aggregation string) float64 {
+	result := values[0]
+	switch aggregation {
+	case "max":
+		for _, val := range values[1:] {
+			if val > result {
+				result = val
+			}
+		}
+	case "sum", "avg":
+		for _, val := range values[1:] {
+			result += val
+		}
+		if aggregation == "avg" {
+			result /= float64(len(values))
+		}
 	}
-	return 0, nil
+	return result
 }
 
 func (s *newrelicScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/newrelic/newrelic-client-go/pkg/nrdb"
 )
 
 type parseNewRelicMetadataTestData struct {
@@ -51,6 +52,10 @@ var testNewRelicMetadata = []parseNewRelicMetadataTestData{
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "false", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "0", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
 	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "noDataError": "1", "nrql": "SELECT average(cpuUsedCores) as result FROM K8sContainerSample WHERE containerName='coredns'"}, map[string]string{}, false},
+	// faceted query aggregated with max
+	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "facetAggregation": "max", "nrql": "SELECT sum(lag) FROM KafkaSample FACET partition"}, map[string]string{}, false},
+	// invalid facet aggregation
+	{map[string]string{"account": "0", "threshold": "100", "queryKey": "somekey", "facetAggregation": "median", "nrql": "SELECT sum(lag) FROM KafkaSample FACET partition"}, map[string]string{}, true},
 }
 
 var newrelicMetricIdentifiers = []newrelicMetricIdentifier{
@@ -89,3 +94,44 @@ func TestNewRelicGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestNewRelicResultValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		result   nrdb.NRDBResult
+		expected float64
+		found    bool
+	}{
+		{"single value", nrdb.NRDBResult{"result": 12.5}, 12.5, true},
+		{"string facet", nrdb.NRDBResult{"facet": "orders", "topic": "orders", "sum.lag": 40.0}, 40, true},
+		{"numeric facet", nrdb.NRDBResult{"facet": 3.0, "partition": 3.0, "sum.lag": 40.0}, 40, true},
+		{"multiple facets", nrdb.NRDBResult{"facet": []interface{}{"orders", 3.0}, "topic": "orders", "partition": 3.0, "sum.lag": 40.0}, 40, true},
+		{"no value", nrdb.NRDBResult{"facet": "orders", "topic": "orders"}, 0, false},
+	}
+
+	for _, testCase := range testCases {
+		val, found := newrelicResultValue(testCase.result)
+		if val != testCase.expected || found != testCase.found {
+			t.Errorf("%s: expected %v (%v) but got %v (%v)", testCase.name, testCase.expected, testCase.found, val, found)
+		}
+	}
+}
+
+func TestAggregateNewRelicValues(t *testing.T) {
+	values := []float64{10, 40, 25}
+	testCases := []struct {
+		aggregation string
+		expected    float64
+	}{
+		{"", 10},
+		{"max", 40},
+		{"sum", 75},
+		{"avg", 25},
+	}
+
+	for _, testCase := range testCases {
+		if val := aggregateNewRelicValues(values, testCase.aggregation); val != testCase.expected {
+			t.Errorf("%s: expected %v but got %v", testCase.aggregation, testCase.expected, val)
+		}
+	}
+}
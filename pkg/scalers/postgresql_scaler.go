@@ -4,11 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	// PostreSQL drive required for this scaler
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
@@ -17,10 +21,12 @@ import (
 )
 
 type postgreSQLScaler struct {
-	metricType v2beta2.MetricTargetType
-	metadata   *postgreSQLMetadata
-	connection *sql.DB
-	logger     logr.Logger
+	metricType    v2beta2.MetricTargetType
+	metadata      *postgreSQLMetadata
+	connection    *sql.DB
+	certDir       string
+	queryRecorder *QueryRecorder
+	logger        logr.Logger
 }
 
 type postgreSQLMetadata struct {
@@ -30,6 +36,16 @@ type postgreSQLMetadata struct {
 	query                      string
 	metricName                 string
 	scalerIndex                int
+	queryTimeout               time.Duration
+
+	// sslmode overriding the one of a given connection string
+	sslmode string
+
+	// TLS
+	ca          string
+	cert        string
+	key         string
+	keyPassword string
 }
 
 // NewPostgreSQLScaler creates a new postgreSQL scaler
@@ -46,15 +62,17 @@ func NewPostgreSQLScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing postgreSQL metadata: %s", err)
 	}
 
-	conn, err := getConnection(meta, logger)
+	conn, certDir, err := getConnection(meta, logger)
 	if err != nil {
 		return nil, fmt.Errorf("error establishing postgreSQL connection: %s", err)
 	}
 	return &postgreSQLScaler{
-		metricType: metricType,
-		metadata:   meta,
-		connection: conn,
-		logger:     logger,
+		metricType:    metricType,
+		metadata:      meta,
+		connection:    conn,
+		certDir:       certDir,
+		queryRecorder: config.QueryRecorder,
+		logger:        logger,
 	}, nil
 }
 
@@ -86,11 +104,24 @@ func parsePostgreSQLMetadata(config *ScalerConfig) (*postgreSQLMetadata, error)
 		meta.activationTargetQueryValue = activationTargetQueryValue
 	}
 
+	if val, ok := config.TriggerMetadata["queryTimeout"]; ok {
+		queryTimeoutMS, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, fmt.Errorf("queryTimeout parsing error %s", err.Error())
+		}
+		if queryTimeoutMS <= 0 {
+			return nil, fmt.Errorf("queryTimeout must be greater than 0")
+		}
+		meta.queryTimeout = time.Duration(queryTimeoutMS) * time.Millisecond
+	}
+
 	switch {
 	case config.AuthParams["connection"] != "":
 		meta.connection = config.AuthParams["connection"]
+		meta.sslmode = config.AuthParams["sslmode"]
 	case config.TriggerMetadata["connectionFromEnv"] != "":
 		meta.connection = config.ResolvedEnv[config.TriggerMetadata["connectionFromEnv"]]
+		meta.sslmode = config.AuthParams["sslmode"]
 	default:
 		host, err := GetFromAuthOrMeta(config, "host")
 		if err != nil {
@@ -135,6 +166,19 @@ func parsePostgreSQLMetadata(config *ScalerConfig) (*postgreSQLMetadata, error)
 		)
 	}
 
+	certGiven := config.AuthParams["cert"] != ""
+	keyGiven := config.AuthParams["key"] != ""
+	if certGiven && !keyGiven {
+		return nil, fmt.Errorf("key must be provided with cert")
+	}
+	if keyGiven && !certGiven {
+		return nil, fmt.Errorf("cert must be provided with key")
+	}
+	meta.ca = config.AuthParams["ca"]
+	meta.cert = config.AuthParams["cert"]
+	meta.key = config.AuthParams["key"]
+	meta.keyPassword = config.AuthParams["keyPassword"]
+
 	if val, ok := config.TriggerMetadata["metricName"]; ok {
 		meta.metricName = kedautil.NormalizeString(fmt.Sprintf("postgresql-%s", val))
 	} else {
@@ -144,22 +188,114 @@ func parsePostgreSQLMetadata(config *ScalerConfig) (*postgreSQLMetadata, error)
 	return &meta, nil
 }
 
-func getConnection(meta *postgreSQLMetadata, logger logr.Logger) (*sql.DB, error) {
-	db, err := sql.Open("postgres", meta.connection)
+func getConnection(meta *postgreSQLMetadata, logger logr.Logger) (*sql.DB, string, error) {
+	certDir, certParams, err := writePostgreSQLCerts(meta)
+	if err != nil {
+		logger.Error(err, fmt.Sprintf("Found error writing postgreSQL certificates: %s", err))
+		return nil, "", err
+	}
+
+	params := certParams
+	if meta.sslmode != "" {
+		params = append(params, fmt.Sprintf("sslmode=%s", quotePostgreSQLParam(meta.sslmode)))
+	}
+	connection, err := postgreSQLConnectionWithParams(meta.connection, params)
+	if err != nil {
+		os.RemoveAll(certDir)
+		return nil, "", err
+	}
+
+	db, err := sql.Open("postgres", connection)
 	if err != nil {
+		os.RemoveAll(certDir)
 		logger.Error(err, fmt.Sprintf("Found error opening postgreSQL: %s", err))
-		return nil, err
+		return nil, "", err
 	}
 	err = db.Ping()
 	if err != nil {
+		db.Close()
+		os.RemoveAll(certDir)
 		logger.Error(err, fmt.Sprintf("Found error pinging postgreSQL: %s", err))
-		return nil, err
+		return nil, "", err
+	}
+	return db, certDir, nil
+}
+
+// writePostgreSQLCerts writes the CA and client certificate of the trigger to a new temporary
+// directory, as the driver only reads them from files, and returns the connection parameters using them
+func writePostgreSQLCerts(meta *postgreSQLMetadata) (string, []string, error) {
+	if meta.ca == "" && meta.cert == "" {
+		return "", nil, nil
+	}
+
+	key := []byte(meta.key)
+	if meta.cert != "" && meta.keyPassword != "" {
+		var err error
+		key, err = kedautil.DecryptClientKey(meta.key, meta.keyPassword)
+		if err != nil {
+			return "", nil, fmt.Errorf("error decrypting client key: %s", err)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "keda-postgresql-")
+	if err != nil {
+		return "", nil, err
+	}
+
+	var params []string
+	files := []struct {
+		param   string
+		content []byte
+	}{
+		{"sslrootcert", []byte(meta.ca)},
+		{"sslcert", []byte(meta.cert)},
+		{"sslkey", key},
+	}
+	for _, file := range files {
+		if len(file.content) == 0 {
+			continue
+		}
+		path := filepath.Join(dir, file.param+".pem")
+		// the driver refuses keys readable by others
+		if err := os.WriteFile(path, file.content, 0600); err != nil {
+			os.RemoveAll(dir)
+			return "", nil, err
+		}
+		params = append(params, fmt.Sprintf("%s=%s", file.param, quotePostgreSQLParam(path)))
+	}
+	return dir, params, nil
+}
+
+// postgreSQLConnectionWithParams appends the key/value params to the connection string,
+// converting it first to the key/value format if it is an URL
+func postgreSQLConnectionWithParams(connection string, params []string) (string, error) {
+	if len(params) == 0 {
+		return connection, nil
+	}
+	if strings.HasPrefix(connection, "postgres://") || strings.HasPrefix(connection, "postgresql://") {
+		var err error
+		connection, err = pq.ParseURL(connection)
+		if err != nil {
+			return "", fmt.Errorf("error parsing connection string: %s", err)
+		}
 	}
-	return db, nil
+	// later values of the same key win over the ones in the connection string
+	return strings.TrimSpace(connection + " " + strings.Join(params, " ")), nil
+}
+
+func quotePostgreSQLParam(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return fmt.Sprintf("'%s'", value)
 }
 
 // Close disposes of postgres connections
 func (s *postgreSQLScaler) Close(context.Context) error {
+	if s.certDir != "" {
+		if err := os.RemoveAll(s.certDir); err != nil {
+			s.logger.Error(err, "Error removing postgreSQL certificates")
+		}
+	}
 	err := s.connection.Close()
 	if err != nil {
 		s.logger.Error(err, "Error closing postgreSQL connection")
@@ -179,7 +315,14 @@ func (s *postgreSQLScaler) IsActive(ctx context.Context) (bool, error) {
 }
 
 func (s *postgreSQLScaler) getActiveNumber(ctx context.Context) (float64, error) {
+	if s.metadata.queryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.metadata.queryTimeout)
+		defer cancel()
+	}
+
 	var id float64
+	s.queryRecorder.Record(s.metadata.query)
 	err := s.connection.QueryRowContext(ctx, s.metadata.query).Scan(&id)
 	if err != nil {
 		s.logger.Error(err, fmt.Sprintf("could not query postgreSQL: %s", err))
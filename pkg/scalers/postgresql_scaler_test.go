@@ -2,6 +2,8 @@ package scalers
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -47,7 +49,7 @@ func TestPosgresSQLGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockPostgresSQLScaler := postgreSQLScaler{"", meta, nil, logr.Discard()}
+		mockPostgresSQLScaler := postgreSQLScaler{"", meta, nil, "", nil, logr.Discard()}
 
 		metricSpec := mockPostgresSQLScaler.GetMetricSpecForScaling(context.Background())
 		metricName := metricSpec[0].External.Metric.Name
@@ -127,6 +129,48 @@ var testPostgresMetadata = []parsePostgresMetadataTestData{
 		resolvedEnv: testPostgresResolvedEnv,
 		raisesError: false,
 	},
+	// queryTimeout
+	{
+		metadata:    map[string]string{"query": "query", "targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR", "queryTimeout": "5000"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: false,
+	},
+	// invalid queryTimeout
+	{
+		metadata:    map[string]string{"query": "query", "targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR", "queryTimeout": "5s"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: true,
+	},
+	// negative queryTimeout
+	{
+		metadata:    map[string]string{"query": "query", "targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR", "queryTimeout": "-1"},
+		authParams:  map[string]string{},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: true,
+	},
+	// sslmode, ca, cert and key from trigger authentication
+	{
+		metadata:    map[string]string{"query": "query", "targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{"sslmode": "verify-full", "ca": "caaa", "cert": "ceert", "key": "keey", "keyPassword": "keeyPassword"},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: false,
+	},
+	// cert without key
+	{
+		metadata:    map[string]string{"query": "query", "targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{"sslmode": "verify-full", "cert": "ceert"},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: true,
+	},
+	// key without cert
+	{
+		metadata:    map[string]string{"query": "query", "targetQueryValue": "12", "connectionFromEnv": "POSTGRE_CONN_STR"},
+		authParams:  map[string]string{"sslmode": "verify-full", "key": "keey"},
+		resolvedEnv: testPostgresResolvedEnv,
+		raisesError: true,
+	},
 }
 
 func TestParsePosgresSQLMetadata(t *testing.T) {
@@ -140,3 +184,68 @@ func TestParsePosgresSQLMetadata(t *testing.T) {
 		}
 	}
 }
+
+type postgreSQLConnectionWithParamsTestData struct {
+	connection  string
+	params      []string
+	expected    string
+	raisesError bool
+}
+
+var testPostgreSQLConnectionWithParams = []postgreSQLConnectionWithParamsTestData{
+	// no params
+	{connection: "postgresql://localhost:5432", expected: "postgresql://localhost:5432"},
+	// key/value connection string
+	{connection: "host=localhost port=5432", params: []string{"sslmode='verify-ca'"}, expected: "host=localhost port=5432 sslmode='verify-ca'"},
+	// URL connection string
+	{connection: "postgresql://user@localhost:5432/db", params: []string{"sslmode='verify-ca'", "sslrootcert='/tmp/ca.pem'"}, expected: "dbname='db' host='localhost' port='5432' user='user' sslmode='verify-ca' sslrootcert='/tmp/ca.pem'"},
+	// invalid URL connection string
+	{connection: "postgres://localhost:port", params: []string{"sslmode='verify-ca'"}, raisesError: true},
+}
+
+func TestPostgreSQLConnectionWithParams(t *testing.T) {
+	for _, testData := range testPostgreSQLConnectionWithParams {
+		connection, err := postgreSQLConnectionWithParams(testData.connection, testData.params)
+		if err != nil && !testData.raisesError {
+			t.Error("Expected success but got error", err)
+		}
+		if err == nil && testData.raisesError {
+			t.Error("Expected error but got success")
+		}
+		if err == nil && connection != testData.expected {
+			t.Errorf("Expected connection '%s' but got '%s'", testData.expected, connection)
+		}
+	}
+}
+
+func TestQuotePostgreSQLParam(t *testing.T) {
+	if quoted := quotePostgreSQLParam(`/tmp/it's\ca.pem`); quoted != `'/tmp/it\'s\\ca.pem'` {
+		t.Errorf("Expected escaped and quoted value but got %s", quoted)
+	}
+}
+
+func TestWritePostgreSQLCerts(t *testing.T) {
+	dir, params, err := writePostgreSQLCerts(&postgreSQLMetadata{})
+	if err != nil || dir != "" || len(params) != 0 {
+		t.Errorf("Expected no certificates to be written but got %s, %v, %v", dir, params, err)
+	}
+
+	dir, params, err = writePostgreSQLCerts(&postgreSQLMetadata{ca: "caaa", cert: "ceert", key: "keey"})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if len(params) != 3 {
+		t.Fatalf("Expected 3 connection params but got %v", params)
+	}
+	for _, file := range []string{"sslrootcert.pem", "sslcert.pem", "sslkey.pem"} {
+		info, err := os.Stat(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("Expected %s to be written but got error %s", file, err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("Expected %s to be only readable by the owner but got %s", file, info.Mode().Perm())
+		}
+	}
+}
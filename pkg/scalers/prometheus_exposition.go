@@ -0,0 +1,57 @@
+package scalers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/common/expfmt"
+)
+
+// getPrometheusExpositionSum sums the samples of the metric in the Prometheus text exposition format
+// which have all the given labels, it returns false if the metric isn't exposed
+func getPrometheusExpositionSum(body []byte, name string, labels map[string]string) (float64, bool, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return 0, false, fmt.Errorf("error parsing prometheus metrics: %s", err)
+	}
+
+	family, ok := families[name]
+	if !ok {
+		return 0, false, nil
+	}
+
+	var sum float64
+METRICS:
+	for _, metric := range family.GetMetric() {
+		matched := 0
+		for _, label := range metric.GetLabel() {
+			if value, ok := labels[label.GetName()]; ok {
+				if value != label.GetValue() {
+					continue METRICS
+				}
+				matched++
+			}
+		}
+		if matched != len(labels) {
+			continue
+		}
+		// only one of them is set, depending on whether the exporter declared the type of the metric
+		sum += metric.GetGauge().GetValue() + metric.GetCounter().GetValue() + metric.GetUntyped().GetValue()
+	}
+	return sum, true, nil
+}
+
+// parsePrometheusLabels parses the name=value pairs separated by commas of the metricLabels metadata
+func parsePrometheusLabels(val string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, pair := range strings.Split(val, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("invalid metricLabels %s, must be name=value pairs separated by commas", val)
+		}
+		labels[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return labels, nil
+}
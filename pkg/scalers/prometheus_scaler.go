@@ -8,14 +8,23 @@ import (
 	"net/http"
 	url_pkg "net/url"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
 	"github.com/go-logr/logr"
 	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/kedacore/keda/v2/pkg/scalers/authentication"
+	"github.com/kedacore/keda/v2/pkg/scalers/azure"
 	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
@@ -23,29 +32,52 @@ const (
 	promServerAddress       = "serverAddress"
 	promMetricName          = "metricName"
 	promQuery               = "query"
+	promQueries             = "queries"
+	promQueryAggregation    = "queryAggregation"
 	promThreshold           = "threshold"
 	promActivationThreshold = "activationThreshold"
 	promNamespace           = "namespace"
 	promCortexScopeOrgID    = "cortexOrgID"
 	promCortexHeaderKey     = "X-Scope-OrgID"
 	ignoreNullValues        = "ignoreNullValues"
+	promAuthModes           = "authModes"
+	promSigV4AuthMode       = "sigv4"
+	promAwsRegion           = "awsRegion"
+	promAzureAuthMode       = "azure"
+	promAzureResourceURL    = "azureResourceURL"
+	// promSigV4Service is the signing name of Amazon Managed Service for Prometheus
+	promSigV4Service = "aps"
 )
 
 var (
 	defaultIgnoreNullValues = true
 )
 
+type prometheusQueryAggregation string
+
+const (
+	promQueryAggregationSum prometheusQueryAggregation = "sum"
+	promQueryAggregationMax prometheusQueryAggregation = "max"
+	promQueryAggregationAvg prometheusQueryAggregation = "avg"
+)
+
 type prometheusScaler struct {
 	metricType v2beta2.MetricTargetType
 	metadata   *prometheusMetadata
 	httpClient *http.Client
-	logger     logr.Logger
+	awsSigner  *v4.Signer
+	// azureAuthorizer caches the Azure AD token of the queries to Azure Monitor managed Prometheus
+	azureAuthorizer autorest.Authorizer
+	queryRecorder   *QueryRecorder
+	logger          logr.Logger
 }
 
 type prometheusMetadata struct {
 	serverAddress       string
 	metricName          string
 	query               string
+	queries             []string
+	queryAggregation    prometheusQueryAggregation
 	threshold           float64
 	activationThreshold float64
 	prometheusAuth      *authentication.AuthMeta
@@ -57,6 +89,15 @@ type prometheusMetadata struct {
 	// change to false/f if can not accept prometheus return null values
 	// https://github.com/kedacore/keda/issues/3065
 	ignoreNullValues bool
+
+	// sigv4
+	enableSigV4Auth  bool
+	awsRegion        string
+	awsAuthorization awsAuthorizationMetadata
+
+	// azure
+	enableAzureAuth bool
+	azureInfo       azure.ManagedPrometheusInfo
 }
 
 type promQueryResult struct {
@@ -85,7 +126,7 @@ func NewPrometheusScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing prometheus metadata: %s", err)
 	}
 
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
+	httpClient := createHTTPClient(config, false)
 
 	if meta.prometheusAuth != nil && (meta.prometheusAuth.CA != "" || meta.prometheusAuth.EnableTLS) {
 		// create http.RoundTripper with auth settings from ScalerConfig
@@ -98,14 +139,53 @@ func NewPrometheusScaler(config *ScalerConfig) (Scaler, error) {
 		}
 	}
 
+	var awsSigner *v4.Signer
+	if meta.enableSigV4Auth {
+		if awsSigner, err = newPrometheusAwsSigner(meta); err != nil {
+			return nil, err
+		}
+	}
+
+	var azureAuthorizer autorest.Authorizer
+	if meta.enableAzureAuth {
+		if azureAuthorizer, err = azure.NewManagedPrometheusAuthorizer(context.Background(), meta.azureInfo, config.PodIdentity); err != nil {
+			return nil, fmt.Errorf("error creating azure authorizer: %s", err)
+		}
+	}
+
 	return &prometheusScaler{
-		metricType: metricType,
-		metadata:   meta,
-		httpClient: httpClient,
-		logger:     logger,
+		metricType:      metricType,
+		metadata:        meta,
+		httpClient:      httpClient,
+		awsSigner:       awsSigner,
+		azureAuthorizer: azureAuthorizer,
+		queryRecorder:   config.QueryRecorder,
+		logger:          logger,
 	}, nil
 }
 
+// newPrometheusAwsSigner creates the signer of the queries to Amazon Managed Service for Prometheus,
+// with the credentials resolved like for the AWS scalers
+func newPrometheusAwsSigner(meta *prometheusMetadata) (*v4.Signer, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(meta.awsRegion),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating aws session: %s", err)
+	}
+
+	creds := sess.Config.Credentials
+	if meta.awsAuthorization.podIdentityOwner {
+		creds = credentials.NewStaticCredentials(meta.awsAuthorization.awsAccessKeyID, meta.awsAuthorization.awsSecretAccessKey, meta.awsAuthorization.awsSessionToken)
+
+		if meta.awsAuthorization.awsRoleArn != "" {
+			creds = stscreds.NewCredentials(sess, meta.awsAuthorization.awsRoleArn)
+		}
+	}
+
+	return v4.NewSigner(creds), nil
+}
+
 func parsePrometheusMetadata(config *ScalerConfig) (meta *prometheusMetadata, err error) {
 	meta = &prometheusMetadata{}
 
@@ -115,10 +195,8 @@ func parsePrometheusMetadata(config *ScalerConfig) (meta *prometheusMetadata, er
 		return nil, fmt.Errorf("no %s given", promServerAddress)
 	}
 
-	if val, ok := config.TriggerMetadata[promQuery]; ok && val != "" {
-		meta.query = val
-	} else {
-		return nil, fmt.Errorf("no %s given", promQuery)
+	if err := parsePrometheusQueries(config, meta); err != nil {
+		return nil, err
 	}
 
 	if val, ok := config.TriggerMetadata[promMetricName]; ok && val != "" {
@@ -168,15 +246,143 @@ func parsePrometheusMetadata(config *ScalerConfig) (meta *prometheusMetadata, er
 
 	meta.scalerIndex = config.ScalerIndex
 
-	// parse auth configs from ScalerConfig
-	meta.prometheusAuth, err = authentication.GetAuthConfigs(config.TriggerMetadata, config.AuthParams)
+	// parse auth configs from ScalerConfig, except sigv4 and azure which aren't shared auth modes
+	triggerMetadata := parsePrometheusLocalAuthModes(config.TriggerMetadata, meta)
+	meta.prometheusAuth, err = authentication.GetAuthConfigs(triggerMetadata, config.AuthParams)
 	if err != nil {
 		return nil, err
 	}
 
+	if meta.enableSigV4Auth {
+		if meta.prometheusAuth != nil && (meta.prometheusAuth.EnableBearerAuth || meta.prometheusAuth.EnableBasicAuth) {
+			return nil, fmt.Errorf("%s authentication can not be set with bearer or basic authentication", promSigV4AuthMode)
+		}
+
+		switch {
+		case config.AuthParams[promAwsRegion] != "":
+			meta.awsRegion = config.AuthParams[promAwsRegion]
+		case config.TriggerMetadata[promAwsRegion] != "":
+			meta.awsRegion = config.TriggerMetadata[promAwsRegion]
+		default:
+			return nil, fmt.Errorf("no %s given", promAwsRegion)
+		}
+
+		meta.awsAuthorization, err = getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if meta.enableAzureAuth {
+		if err := parsePrometheusAzureAuth(config, meta); err != nil {
+			return nil, err
+		}
+	}
+
 	return meta, nil
 }
 
+func parsePrometheusAzureAuth(config *ScalerConfig, meta *prometheusMetadata) (err error) {
+	if meta.enableSigV4Auth || (meta.prometheusAuth != nil && (meta.prometheusAuth.EnableBearerAuth || meta.prometheusAuth.EnableBasicAuth)) {
+		return fmt.Errorf("%s authentication can not be set with %s, bearer or basic authentication", promAzureAuthMode, promSigV4AuthMode)
+	}
+
+	meta.azureInfo.ResourceURL = azure.DefaultManagedPrometheusResourceURL
+	if val, ok := config.TriggerMetadata[promAzureResourceURL]; ok && val != "" {
+		meta.azureInfo.ResourceURL = val
+	}
+	meta.azureInfo.ActiveDirectoryEndpoint, err = azure.ParseActiveDirectoryEndpoint(config.TriggerMetadata)
+	if err != nil {
+		return err
+	}
+
+	switch config.PodIdentity.Provider {
+	case "", kedav1alpha1.PodIdentityProviderNone:
+		meta.azureInfo.TenantID, err = getParameterFromConfig(config, "tenantId", true)
+		if err != nil {
+			return err
+		}
+		meta.azureInfo.ClientID, meta.azureInfo.ClientPassword, err = parseAzurePodIdentityParams(config)
+		if err != nil {
+			return err
+		}
+	case kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderAzureWorkload:
+		// no params required to be parsed
+	default:
+		return fmt.Errorf("prometheus %s authentication doesn't support pod identity %s", promAzureAuthMode, config.PodIdentity.Provider)
+	}
+	return nil
+}
+
+// parsePrometheusLocalAuthModes enables the sigv4 and azure authentications if they are in the authModes,
+// and returns the trigger metadata with the other authModes
+func parsePrometheusLocalAuthModes(triggerMetadata map[string]string, meta *prometheusMetadata) map[string]string {
+	authModes, ok := triggerMetadata[promAuthModes]
+	if !ok {
+		return triggerMetadata
+	}
+
+	var otherAuthModes []string
+	for _, authMode := range strings.Split(authModes, ",") {
+		switch strings.TrimSpace(authMode) {
+		case promSigV4AuthMode:
+			meta.enableSigV4Auth = true
+		case promAzureAuthMode:
+			meta.enableAzureAuth = true
+		default:
+			otherAuthModes = append(otherAuthModes, authMode)
+		}
+	}
+	if !meta.enableSigV4Auth && !meta.enableAzureAuth {
+		return triggerMetadata
+	}
+
+	out := make(map[string]string, len(triggerMetadata))
+	for k, v := range triggerMetadata {
+		out[k] = v
+	}
+	if len(otherAuthModes) > 0 {
+		out[promAuthModes] = strings.Join(otherAuthModes, ",")
+	} else {
+		delete(out, promAuthModes)
+	}
+	return out
+}
+
+func parsePrometheusQueries(config *ScalerConfig, meta *prometheusMetadata) error {
+	meta.query = config.TriggerMetadata[promQuery]
+
+	// the queries are separated by new lines, as they may contain commas
+	for _, query := range strings.Split(config.TriggerMetadata[promQueries], "\n") {
+		if query = strings.TrimSpace(query); query != "" {
+			meta.queries = append(meta.queries, query)
+		}
+	}
+
+	switch {
+	case meta.query != "" && len(meta.queries) > 0:
+		return fmt.Errorf("configure only one of %s and %s", promQuery, promQueries)
+	case meta.query == "" && len(meta.queries) == 0:
+		return fmt.Errorf("no %s given", promQuery)
+	}
+
+	if val, ok := config.TriggerMetadata[promQueryAggregation]; ok && val != "" {
+		if len(meta.queries) == 0 {
+			return fmt.Errorf("configure %s only with %s", promQueryAggregation, promQueries)
+		}
+		switch aggregation := prometheusQueryAggregation(val); aggregation {
+		case promQueryAggregationSum, promQueryAggregationMax, promQueryAggregationAvg:
+			meta.queryAggregation = aggregation
+		default:
+			return fmt.Errorf("%s must be one of %s, %s, %s but is %s", promQueryAggregation, promQueryAggregationSum, promQueryAggregationMax, promQueryAggregationAvg, val)
+		}
+	} else {
+		meta.queryAggregation = promQueryAggregationSum
+	}
+
+	return nil
+}
+
 func (s *prometheusScaler) IsActive(ctx context.Context) (bool, error) {
 	val, err := s.ExecutePromQuery(ctx)
 	if err != nil {
@@ -205,9 +411,42 @@ func (s *prometheusScaler) GetMetricSpecForScaling(context.Context) []v2beta2.Me
 	return []v2beta2.MetricSpec{metricSpec}
 }
 
+// ExecutePromQuery returns the value of the query, or the aggregated values of the queries
 func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error) {
+	if len(s.metadata.queries) == 0 {
+		return s.executeQuery(ctx, s.metadata.query)
+	}
+
+	values := make([]float64, 0, len(s.metadata.queries))
+	for _, query := range s.metadata.queries {
+		v, err := s.executeQuery(ctx, query)
+		if err != nil {
+			return -1, err
+		}
+		values = append(values, v)
+	}
+	return aggregatePromQueryValues(values, s.metadata.queryAggregation), nil
+}
+
+func aggregatePromQueryValues(values []float64, aggregation prometheusQueryAggregation) float64 {
+	var result float64
+	for i, v := range values {
+		switch {
+		case aggregation == promQueryAggregationMax && (i == 0 || v > result):
+			result = v
+		case aggregation != promQueryAggregationMax:
+			result += v
+		}
+	}
+	if aggregation == promQueryAggregationAvg && len(values) > 0 {
+		result /= float64(len(values))
+	}
+	return result
+}
+
+func (s *prometheusScaler) executeQuery(ctx context.Context, query string) (float64, error) {
 	t := time.Now().UTC().Format(time.RFC3339)
-	queryEscaped := url_pkg.QueryEscape(s.metadata.query)
+	queryEscaped := url_pkg.QueryEscape(query)
 	url := fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", s.metadata.serverAddress, queryEscaped, t)
 
 	// set 'namespace' parameter for namespaced Prometheus requests (eg. for Thanos Querier)
@@ -215,6 +454,7 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		url = fmt.Sprintf("%s&namespace=%s", url, s.metadata.namespace)
 	}
 
+	s.queryRecorder.Record("GET " + url)
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return -1, err
@@ -230,6 +470,19 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		req.Header.Add(promCortexHeaderKey, s.metadata.cortexOrgID)
 	}
 
+	if s.azureAuthorizer != nil {
+		if req, err = autorest.Prepare(req, s.azureAuthorizer.WithAuthorization()); err != nil {
+			return -1, fmt.Errorf("error authorizing prometheus query: %s", err)
+		}
+	}
+
+	// the request is signed last, as the signature covers its headers
+	if s.awsSigner != nil {
+		if _, err := s.awsSigner.Sign(req, nil, promSigV4Service, s.metadata.awsRegion, time.Now()); err != nil {
+			return -1, fmt.Errorf("error signing prometheus query: %s", err)
+		}
+	}
+
 	r, err := s.httpClient.Do(req)
 	if err != nil {
 		return -1, err
@@ -262,7 +515,7 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		}
 		return -1, fmt.Errorf("prometheus metrics %s target may be lost, the result is empty", s.metadata.metricName)
 	} else if len(result.Data.Result) > 1 {
-		return -1, fmt.Errorf("prometheus query %s returned multiple elements", s.metadata.query)
+		return -1, fmt.Errorf("prometheus query %s returned multiple elements", query)
 	}
 
 	valueLen := len(result.Data.Result[0].Value)
@@ -272,7 +525,7 @@ func (s *prometheusScaler) ExecutePromQuery(ctx context.Context) (float64, error
 		}
 		return -1, fmt.Errorf("prometheus metrics %s target may be lost, the value list is empty", s.metadata.metricName)
 	} else if valueLen < 2 {
-		return -1, fmt.Errorf("prometheus query %s didn't return enough values", s.metadata.query)
+		return -1, fmt.Errorf("prometheus query %s didn't return enough values", query)
 	}
 
 	val := result.Data.Result[0].Value[1]
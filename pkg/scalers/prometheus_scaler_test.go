@@ -4,11 +4,17 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	url_pkg "net/url"
 	"strings"
 	"testing"
 
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 )
 
 type parsePrometheusMetadataTestData struct {
@@ -46,6 +52,18 @@ var testPromMetadata = []parsePrometheusMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": ""}, true},
 	// ignoreNullValues with wrong value
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "ignoreNullValues": "xxxx"}, true},
+	// all properly formed, with queries
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "queries": "up\n\nsum(rate(http_requests_total[2m]))\n"}, false},
+	// all properly formed, with queries and queryAggregation
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "queries": "up\ndown", "queryAggregation": "max"}, false},
+	// both query and queries
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "queries": "up\ndown"}, true},
+	// queries with only blank lines
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "queries": "\n  \n"}, true},
+	// queryAggregation without queries
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "queryAggregation": "sum"}, true},
+	// queryAggregation with wrong value
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "queries": "up\ndown", "queryAggregation": "min"}, true},
 }
 
 var prometheusMetricIdentifiers = []prometheusMetricIdentifier{
@@ -82,6 +100,47 @@ var testPrometheusAuthMetadata = []prometheusAuthMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "tls,basic"}, map[string]string{"username": "user", "password": "pass"}, true},
 }
 
+var testPrometheusSigV4Metadata = []prometheusAuthMetadataTestData{
+	// success sigv4 with access keys
+	{map[string]string{"serverAddress": "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "sigv4", "awsRegion": "us-east-1"}, map[string]string{"awsAccessKeyID": "none", "awsSecretAccessKey": "none"}, false},
+	// success sigv4 with role and region in authParams
+	{map[string]string{"serverAddress": "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "sigv4"}, map[string]string{"awsRoleArn": "none", "awsRegion": "us-east-1"}, false},
+	// success sigv4 with operator identity
+	{map[string]string{"serverAddress": "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "sigv4", "awsRegion": "us-east-1", "identityOwner": "operator"}, map[string]string{}, false},
+	// success sigv4 with tls
+	{map[string]string{"serverAddress": "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "tls, sigv4", "awsRegion": "us-east-1", "identityOwner": "operator"}, map[string]string{"cert": "ceert", "key": "keey"}, false},
+	// fail sigv4 with no region
+	{map[string]string{"serverAddress": "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "sigv4"}, map[string]string{"awsAccessKeyID": "none", "awsSecretAccessKey": "none"}, true},
+	// fail sigv4 with no credentials
+	{map[string]string{"serverAddress": "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "sigv4", "awsRegion": "us-east-1"}, map[string]string{}, true},
+	// fail sigv4 with bearer
+	{map[string]string{"serverAddress": "https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-1", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "sigv4,bearer", "awsRegion": "us-east-1", "identityOwner": "operator"}, map[string]string{"bearerToken": "tooooken"}, true},
+}
+
+type prometheusAzureMetadataTestData struct {
+	metadata    map[string]string
+	authParams  map[string]string
+	podIdentity kedav1alpha1.PodIdentityProvider
+	isError     bool
+}
+
+var testPrometheusAzureMetadata = []prometheusAzureMetadataTestData{
+	// success azure with client secret
+	{map[string]string{"serverAddress": "https://workspace.westeurope.prometheus.monitor.azure.com", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "azure"}, map[string]string{"tenantId": "tenant", "activeDirectoryClientId": "client", "activeDirectoryClientPassword": "password"}, "", false},
+	// success azure with workload identity
+	{map[string]string{"serverAddress": "https://workspace.westeurope.prometheus.monitor.azure.com", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "azure"}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzureWorkload, false},
+	// success azure with pod identity and private cloud
+	{map[string]string{"serverAddress": "https://workspace.prometheus.private", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "azure", "azureResourceURL": "https://prometheus.private", "cloud": "private", "activeDirectoryEndpoint": "https://login.private/"}, map[string]string{}, kedav1alpha1.PodIdentityProviderAzure, false},
+	// fail azure with no client secret
+	{map[string]string{"serverAddress": "https://workspace.westeurope.prometheus.monitor.azure.com", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "azure"}, map[string]string{"tenantId": "tenant", "activeDirectoryClientId": "client"}, "", true},
+	// fail azure with no tenant
+	{map[string]string{"serverAddress": "https://workspace.westeurope.prometheus.monitor.azure.com", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "azure"}, map[string]string{"activeDirectoryClientId": "client", "activeDirectoryClientPassword": "password"}, "", true},
+	// fail azure with unsupported pod identity
+	{map[string]string{"serverAddress": "https://workspace.westeurope.prometheus.monitor.azure.com", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "azure"}, map[string]string{}, kedav1alpha1.PodIdentityProviderAwsEKS, true},
+	// fail azure with bearer
+	{map[string]string{"serverAddress": "https://workspace.westeurope.prometheus.monitor.azure.com", "metricName": "http_requests_total", "threshold": "100", "query": "up", "authModes": "azure,bearer"}, map[string]string{"bearerToken": "tooooken"}, kedav1alpha1.PodIdentityProviderAzureWorkload, true},
+}
+
 func TestPrometheusParseMetadata(t *testing.T) {
 	for _, testData := range testPromMetadata {
 		_, err := parsePrometheusMetadata(&ScalerConfig{TriggerMetadata: testData.metadata})
@@ -134,6 +193,46 @@ func TestPrometheusScalerAuthParams(t *testing.T) {
 	}
 }
 
+func TestPrometheusScalerSigV4AuthParams(t *testing.T) {
+	for _, testData := range testPrometheusSigV4Metadata {
+		meta, err := parsePrometheusMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+
+		if err == nil {
+			if !meta.enableSigV4Auth {
+				t.Error("sigv4 auth mode not detected")
+			}
+			if (meta.prometheusAuth != nil && meta.prometheusAuth.EnableTLS) != strings.Contains(testData.metadata["authModes"], "tls") {
+				t.Error("wrong auth mode detected")
+			}
+		}
+	}
+}
+
+func TestPrometheusScalerAzureAuthParams(t *testing.T) {
+	for _, testData := range testPrometheusAzureMetadata {
+		meta, err := parsePrometheusMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams,
+			PodIdentity: kedav1alpha1.AuthPodIdentity{Provider: testData.podIdentity}})
+
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+
+		if err == nil && !meta.enableAzureAuth {
+			t.Error("azure auth mode not detected")
+		}
+	}
+}
+
 type prometheusQromQueryResultTestData struct {
 	name             string
 	bodyStr          string
@@ -283,3 +382,145 @@ func TestPrometheusScalerCortexHeader(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestPrometheusScalerRecordsQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+		if _, err := writer.Write([]byte(`{"data":{"result":[{"value": ["1", "2"]}]}}`)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	queryRecorder := &QueryRecorder{}
+	scaler := prometheusScaler{
+		metadata: &prometheusMetadata{
+			serverAddress: server.URL,
+			query:         `sum(rate(http_requests_total{job="api"}[2m]))`,
+			namespace:     "team-a",
+		},
+		httpClient:    http.DefaultClient,
+		queryRecorder: queryRecorder,
+		logger:        logr.Discard(),
+	}
+
+	_, err := scaler.ExecutePromQuery(context.TODO())
+	assert.NoError(t, err)
+
+	query := queryRecorder.LastQuery()
+	assert.True(t, strings.HasPrefix(query, "GET "+server.URL+"/api/v1/query?query="+url_pkg.QueryEscape(scaler.metadata.query)), query)
+	assert.True(t, strings.HasSuffix(query, "&namespace=team-a"), query)
+}
+
+func TestPrometheusScalerQueryAggregation(t *testing.T) {
+	results := map[string]string{
+		"first":  `{"data":{"result":[{"value": ["1", "2"]}]}}`,
+		"second": `{"data":{"result":[{"value": ["1", "7"]}]}}`,
+		"third":  `{"data":{"result":[{"value": ["1", "3"]}]}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, ok := results[request.URL.Query().Get("query")]
+		if !ok {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+		if _, err := writer.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		aggregation   prometheusQueryAggregation
+		queries       []string
+		expectedValue float64
+		isError       bool
+	}{
+		{promQueryAggregationSum, []string{"first", "second", "third"}, 12, false},
+		{promQueryAggregationMax, []string{"first", "second", "third"}, 7, false},
+		{promQueryAggregationAvg, []string{"first", "second", "third"}, 4, false},
+		{promQueryAggregationSum, []string{"first", "unknown"}, -1, true},
+	}
+
+	for _, testCase := range testCases {
+		scaler := prometheusScaler{
+			metadata: &prometheusMetadata{
+				serverAddress:    server.URL,
+				queries:          testCase.queries,
+				queryAggregation: testCase.aggregation,
+				ignoreNullValues: true,
+			},
+			httpClient: http.DefaultClient,
+			logger:     logr.Discard(),
+		}
+
+		value, err := scaler.ExecutePromQuery(context.TODO())
+
+		assert.Equal(t, testCase.expectedValue, value, string(testCase.aggregation))
+		if testCase.isError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestPrometheusScalerSigV4Signing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.True(t, strings.HasPrefix(request.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+		assert.Contains(t, request.Header.Get("Authorization"), "/us-east-1/aps/aws4_request")
+		assert.NotEmpty(t, request.Header.Get("X-Amz-Date"))
+		writer.WriteHeader(http.StatusOK)
+		if _, err := writer.Write([]byte(`{"data":{"result":[{"value": ["1", "2"]}]}}`)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	scaler := prometheusScaler{
+		metadata: &prometheusMetadata{
+			serverAddress:    server.URL,
+			query:            "up",
+			ignoreNullValues: true,
+			enableSigV4Auth:  true,
+			awsRegion:        "us-east-1",
+		},
+		httpClient: http.DefaultClient,
+		awsSigner:  v4.NewSigner(credentials.NewStaticCredentials("AKIDEXAMPLE", "secret", "")),
+		logger:     logr.Discard(),
+	}
+
+	value, err := scaler.ExecutePromQuery(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), value)
+}
+
+func TestPrometheusScalerAzureAuthorization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		assert.Equal(t, "Bearer token", request.Header.Get("Authorization"))
+		writer.WriteHeader(http.StatusOK)
+		if _, err := writer.Write([]byte(`{"data":{"result":[{"value": ["1", "2"]}]}}`)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	scaler := prometheusScaler{
+		metadata: &prometheusMetadata{
+			serverAddress:    server.URL,
+			query:            "up",
+			ignoreNullValues: true,
+			enableAzureAuth:  true,
+		},
+		httpClient:      http.DefaultClient,
+		azureAuthorizer: autorest.NewAPIKeyAuthorizerWithHeaders(map[string]interface{}{"Authorization": "Bearer token"}),
+		logger:          logr.Discard(),
+	}
+
+	value, err := scaler.ExecutePromQuery(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), value)
+}
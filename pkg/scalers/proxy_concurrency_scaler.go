@@ -0,0 +1,263 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const proxyConcurrencyMetricType = "External"
+
+type proxyConcurrencyProxy string
+
+const (
+	// proxyConcurrencyLinkerd reads the linkerd-proxy metrics, which have no in-flight requests gauge,
+	// so the open inbound connections are counted by default
+	proxyConcurrencyLinkerd proxyConcurrencyProxy = "linkerd"
+	// proxyConcurrencyEnvoy reads the active downstream requests of the Envoy sidecar
+	proxyConcurrencyEnvoy proxyConcurrencyProxy = "envoy"
+)
+
+// proxyConcurrencyDefaults are the admin port, metrics path, metric and labels of the sidecars
+var proxyConcurrencyDefaults = map[proxyConcurrencyProxy]struct {
+	port   string
+	path   string
+	metric string
+	labels map[string]string
+}{
+	proxyConcurrencyLinkerd: {port: "4191", path: "/metrics", metric: "tcp_open_connections", labels: map[string]string{"direction": "inbound"}},
+	proxyConcurrencyEnvoy:   {port: "15090", path: "/stats/prometheus", metric: "envoy_http_downstream_rq_active", labels: map[string]string{}},
+}
+
+type proxyConcurrencyScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *proxyConcurrencyMetadata
+	kubeClient client.Client
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type proxyConcurrencyMetadata struct {
+	proxy                 proxyConcurrencyProxy
+	podSelector           labels.Selector
+	namespace             string
+	port                  string
+	path                  string
+	metric                string
+	metricLabels          map[string]string
+	targetValue           float64
+	activationTargetValue float64
+	scalerIndex           int
+}
+
+// NewProxyConcurrencyScaler creates a new proxyConcurrencyScaler, which averages the in-flight requests
+// of the sidecar proxies of the pods matching the podSelector
+func NewProxyConcurrencyScaler(kubeClient client.Client, config *ScalerConfig) (Scaler, error) {
+	// the value is the average of the pods, compared with the target as is by default
+	metricType := v2beta2.ValueMetricType
+	if config.MetricType != "" {
+		var err error
+		metricType, err = GetMetricTargetType(config)
+		if err != nil {
+			return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+		}
+	}
+
+	meta, err := parseProxyConcurrencyMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing proxy concurrency metadata: %s", err)
+	}
+
+	return &proxyConcurrencyScaler{
+		metricType: metricType,
+		metadata:   meta,
+		kubeClient: kubeClient,
+		httpClient: createHTTPClient(config, false),
+		logger:     InitializeLogger(config, "proxy_concurrency_scaler"),
+	}, nil
+}
+
+func parseProxyConcurrencyMetadata(config *ScalerConfig) (*proxyConcurrencyMetadata, error) {
+	meta := proxyConcurrencyMetadata{}
+	var err error
+
+	meta.proxy = proxyConcurrencyProxy(config.TriggerMetadata["proxy"])
+	defaults, ok := proxyConcurrencyDefaults[meta.proxy]
+	if !ok {
+		return nil, fmt.Errorf("proxy must be one of %s, %s but is %s", proxyConcurrencyLinkerd, proxyConcurrencyEnvoy, meta.proxy)
+	}
+
+	meta.podSelector, err = labels.Parse(config.TriggerMetadata[podSelectorKey])
+	if err != nil || meta.podSelector.String() == "" {
+		return nil, fmt.Errorf("invalid pod selector")
+	}
+	meta.namespace = config.ScalableObjectNamespace
+
+	meta.port = defaults.port
+	if val, ok := config.TriggerMetadata["port"]; ok && val != "" {
+		if _, err := strconv.ParseUint(val, 10, 16); err != nil {
+			return nil, fmt.Errorf("error parsing port: %s", err)
+		}
+		meta.port = val
+	}
+	meta.path = defaults.path
+	if val, ok := config.TriggerMetadata["path"]; ok && val != "" {
+		meta.path = "/" + strings.TrimPrefix(val, "/")
+	}
+
+	// a custom metric is matched with the given labels only
+	meta.metric = defaults.metric
+	meta.metricLabels = defaults.labels
+	if val, ok := config.TriggerMetadata["metricName"]; ok && val != "" {
+		meta.metric = val
+		meta.metricLabels = map[string]string{}
+	}
+	if val, ok := config.TriggerMetadata["metricLabels"]; ok && val != "" {
+		meta.metricLabels, err = parsePrometheusLabels(val)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	val, err := getParameterFromConfig(config, "targetValue", false)
+	if err != nil {
+		return nil, err
+	}
+	meta.targetValue, err = strconv.ParseFloat(val, 64)
+	if err != nil || meta.targetValue <= 0 {
+		return nil, fmt.Errorf("targetValue must be a float greater than 0")
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok && val != "" {
+		meta.activationTargetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetValue: %s", err)
+		}
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// getPodConcurrency returns the in-flight requests of the sidecar proxy of the pod
+func (s *proxyConcurrencyScaler) getPodConcurrency(ctx context.Context, pod *corev1.Pod) (float64, error) {
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, s.metadata.port), s.metadata.path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s: api returned %d", req.URL.Path, resp.StatusCode)
+	}
+
+	value, ok, err := getPrometheusExpositionSum(body, s.metadata.metric, s.metadata.metricLabels)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("%s proxy metrics have no %s", s.metadata.proxy, s.metadata.metric)
+	}
+	return value, nil
+}
+
+// getConcurrency returns the average in-flight requests of the running pods, the pods whose proxy
+// can't be scraped are left out of the average. It is 0 without running pods.
+func (s *proxyConcurrencyScaler) getConcurrency(ctx context.Context) (float64, error) {
+	podList := &corev1.PodList{}
+	if err := s.kubeClient.List(ctx, podList, client.InNamespace(s.metadata.namespace), client.MatchingLabelsSelector{Selector: s.metadata.podSelector}); err != nil {
+		return 0, err
+	}
+
+	var sum float64
+	var scraped, running int
+	var lastErr error
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+		running++
+
+		value, err := s.getPodConcurrency(ctx, pod)
+		if err != nil {
+			s.logger.V(1).Info("error scraping the proxy of pod", "pod", pod.Name, "error", err.Error())
+			lastErr = err
+			continue
+		}
+		sum += value
+		scraped++
+	}
+
+	if scraped == 0 {
+		if running > 0 {
+			return 0, fmt.Errorf("error scraping the proxies of the %d running pods, last error: %s", running, lastErr)
+		}
+		return 0, nil
+	}
+	return sum / float64(scraped), nil
+}
+
+func (s *proxyConcurrencyScaler) IsActive(ctx context.Context) (bool, error) {
+	concurrency, err := s.getConcurrency(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting proxy concurrency")
+		return false, err
+	}
+
+	return concurrency > s.metadata.activationTargetValue, nil
+}
+
+func (s *proxyConcurrencyScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *proxyConcurrencyScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	metricName := fmt.Sprintf("proxy-concurrency-%s-%s", s.metadata.proxy, s.metadata.namespace)
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: proxyConcurrencyMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *proxyConcurrencyScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	concurrency, err := s.getConcurrency(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting proxy concurrency")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, concurrency)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
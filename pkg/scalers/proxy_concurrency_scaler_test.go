@@ -0,0 +1,179 @@
+package scalers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type parseProxyConcurrencyMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type proxyConcurrencyMetricIdentifier struct {
+	metadataTestData *parseProxyConcurrencyMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testProxyConcurrencyMetadata = []parseProxyConcurrencyMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// linkerd
+	{map[string]string{"proxy": "linkerd", "podSelector": "app=demo", "targetValue": "10"}, false},
+	// envoy with custom port, path and metric
+	{map[string]string{"proxy": "envoy", "podSelector": "app=demo", "targetValue": "10", "port": "15020", "path": "stats/prometheus", "metricName": "envoy_cluster_upstream_rq_active", "metricLabels": "cluster_name=inbound|8080||"}, false},
+	// unsupported proxy
+	{map[string]string{"proxy": "nginx", "podSelector": "app=demo", "targetValue": "10"}, true},
+	// no podSelector
+	{map[string]string{"proxy": "linkerd", "targetValue": "10"}, true},
+	// no targetValue
+	{map[string]string{"proxy": "linkerd", "podSelector": "app=demo"}, true},
+	// invalid targetValue
+	{map[string]string{"proxy": "linkerd", "podSelector": "app=demo", "targetValue": "0"}, true},
+	// invalid activationTargetValue
+	{map[string]string{"proxy": "linkerd", "podSelector": "app=demo", "targetValue": "10", "activationTargetValue": "a"}, true},
+	// invalid port
+	{map[string]string{"proxy": "envoy", "podSelector": "app=demo", "targetValue": "10", "port": "http"}, true},
+	// invalid metricLabels
+	{map[string]string{"proxy": "envoy", "podSelector": "app=demo", "targetValue": "10", "metricLabels": "cluster_name"}, true},
+}
+
+var proxyConcurrencyMetricIdentifiers = []proxyConcurrencyMetricIdentifier{
+	{&testProxyConcurrencyMetadata[1], 0, "s0-proxy-concurrency-linkerd-test"},
+	{&testProxyConcurrencyMetadata[2], 1, "s1-proxy-concurrency-envoy-test"},
+}
+
+func TestProxyConcurrencyParseMetadata(t *testing.T) {
+	for i, testData := range testProxyConcurrencyMetadata {
+		_, err := parseProxyConcurrencyMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: "test"})
+		if err != nil && !testData.isError {
+			t.Errorf("test %d: expected success but got error: %s", i, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("test %d: expected error but got success", i)
+		}
+	}
+}
+
+func TestProxyConcurrencyGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range proxyConcurrencyMetricIdentifiers {
+		meta, err := parseProxyConcurrencyMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ScalableObjectNamespace: "test", ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockProxyConcurrencyScaler := proxyConcurrencyScaler{metadata: meta}
+
+		metricSpec := mockProxyConcurrencyScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Errorf("Wrong External metric source name: %s, expected: %s", metricName, testData.name)
+		}
+	}
+}
+
+func TestProxyConcurrencyGetConcurrency(t *testing.T) {
+	linkerdMetrics := `# HELP tcp_open_connections Number of currently-open connections.
+# TYPE tcp_open_connections gauge
+tcp_open_connections{direction="inbound",peer="src",tls="true"} 4
+tcp_open_connections{direction="inbound",peer="src",tls="no_identity"} 2
+tcp_open_connections{direction="outbound",peer="dst",tls="true"} 9
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(linkerdMetrics))
+	}))
+	defer server.Close()
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newPod := func(name string, labels map[string]string, phase v1.PodPhase, ip string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", Labels: labels},
+			Status:     v1.PodStatus{Phase: phase, PodIP: ip},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		pods     []runtime.Object
+		expected float64
+		isError  bool
+	}{
+		{
+			name: "averages the running pods",
+			pods: []runtime.Object{
+				newPod("demo-1", map[string]string{"app": "demo"}, v1.PodRunning, host),
+				newPod("demo-2", map[string]string{"app": "demo"}, v1.PodRunning, host),
+				newPod("demo-3", map[string]string{"app": "demo"}, v1.PodPending, ""),
+				newPod("other", map[string]string{"app": "other"}, v1.PodRunning, host),
+			},
+			expected: 6,
+		},
+		{
+			name:     "no running pods",
+			pods:     []runtime.Object{newPod("demo-1", map[string]string{"app": "demo"}, v1.PodPending, "")},
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			meta, err := parseProxyConcurrencyMetadata(&ScalerConfig{
+				TriggerMetadata:         map[string]string{"proxy": "linkerd", "podSelector": "app=demo", "targetValue": "10", "port": port},
+				ScalableObjectNamespace: "test",
+			})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			s := proxyConcurrencyScaler{
+				metadata:   meta,
+				kubeClient: fake.NewClientBuilder().WithRuntimeObjects(test.pods...).Build(),
+				httpClient: http.DefaultClient,
+				logger:     logr.Discard(),
+			}
+
+			value, err := s.getConcurrency(context.Background())
+			if test.isError != (err != nil) {
+				t.Fatalf("expected error %v but got %v", test.isError, err)
+			}
+			if value != test.expected {
+				t.Errorf("expected %v but got %v", test.expected, value)
+			}
+		})
+	}
+
+	t.Run("proxies can't be scraped", func(t *testing.T) {
+		meta, err := parseProxyConcurrencyMetadata(&ScalerConfig{
+			TriggerMetadata:         map[string]string{"proxy": "linkerd", "podSelector": "app=demo", "targetValue": "10", "port": port, "path": "/missing"},
+			ScalableObjectNamespace: "test",
+		})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		s := proxyConcurrencyScaler{
+			metadata:   meta,
+			kubeClient: fake.NewClientBuilder().WithRuntimeObjects(newPod("demo-1", map[string]string{"app": "demo"}, v1.PodRunning, host)).Build(),
+			httpClient: http.DefaultClient,
+			logger:     logr.Discard(),
+		}
+
+		if _, err := s.getConcurrency(context.Background()); err == nil {
+			t.Error("expected error but got success")
+		}
+	})
+}
@@ -33,11 +33,15 @@ type pulsarMetadata struct {
 	activationMsgBacklogThreshold int64
 
 	// TLS
+	unsafeSsl bool
 	enableTLS bool
 	cert      string
 	key       string
 	ca        string
 
+	// token auth
+	token string
+
 	statsURL    string
 	metricName  string
 	scalerIndex int
@@ -102,14 +106,9 @@ func NewPulsarScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing pulsar metadata: %s", err)
 	}
 
-	client := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
-
-	if pulsarMetadata.enableTLS {
-		config, err := kedautil.NewTLSConfig(pulsarMetadata.cert, pulsarMetadata.key, pulsarMetadata.ca)
-		if err != nil {
-			return nil, err
-		}
-		client.Transport = &http.Transport{TLSClientConfig: config}
+	client, err := createTLSHTTPClient(config, pulsarMetadata.unsafeSsl, tlsAuthParams{cert: pulsarMetadata.cert, key: pulsarMetadata.key, ca: pulsarMetadata.ca})
+	if err != nil {
+		return nil, err
 	}
 
 	return &pulsarScaler{
@@ -173,7 +172,7 @@ func parsePulsarMetadata(config *ScalerConfig) (pulsarMetadata, error) {
 	}
 
 	meta.enableTLS = false
-	if val, ok := config.TriggerMetadata["tls"]; ok {
+	if val, err := GetFromAuthOrMeta(config, "tls"); err == nil {
 		val = strings.TrimSpace(val)
 
 		if val == enable {
@@ -190,6 +189,15 @@ func parsePulsarMetadata(config *ScalerConfig) (pulsarMetadata, error) {
 			return meta, fmt.Errorf("err incorrect value for TLS given: %s", val)
 		}
 	}
+
+	meta.token = config.AuthParams["token"]
+
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return meta, err
+	}
+	meta.unsafeSsl = unsafeSsl
+
 	meta.scalerIndex = config.ScalerIndex
 	return meta, nil
 }
@@ -202,6 +210,10 @@ func (s *pulsarScaler) GetStats(ctx context.Context) (*pulsarStats, error) {
 		return nil, fmt.Errorf("error requesting stats from url: %s", err)
 	}
 
+	if s.metadata.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.metadata.token)
+	}
+
 	res, err := s.client.Do(req)
 	if res == nil || err != nil {
 		return nil, fmt.Errorf("error requesting stats from url: %s", err)
@@ -220,10 +232,8 @@ func (s *pulsarScaler) GetStats(ctx context.Context) (*pulsarStats, error) {
 			return nil, fmt.Errorf("error unmarshalling response: %s", err)
 		}
 		return stats, nil
-	case 404:
-		return nil, fmt.Errorf("error requesting stats from url: %s", err)
 	default:
-		return nil, fmt.Errorf("error requesting stats from url: %s", err)
+		return nil, fmt.Errorf("error requesting stats from url: unexpected status code %d", res.StatusCode)
 	}
 }
 
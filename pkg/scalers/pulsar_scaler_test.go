@@ -3,6 +3,8 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -235,3 +237,50 @@ func TestPulsarGetMetric(t *testing.T) {
 		fmt.Printf("%+v\n", metric)
 	}
 }
+
+func TestPulsarTLSFromAuthParams(t *testing.T) {
+	metadata := map[string]string{"adminURL": "https://localhost:8443", "topic": "persistent://public/default/my-topic", "subscription": "sub1"}
+	authParams := map[string]string{"tls": "enable", "cert": "certdata", "key": "keydata", "ca": "cadata"}
+
+	meta, err := parsePulsarMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: authParams})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if !meta.enableTLS {
+		t.Error("Expected enableTLS to be set from authParams")
+	}
+}
+
+func TestPulsarTokenAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"subscriptions":{"sub1":{"msgBacklog":5}}}`)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{"adminURL": server.URL, "topic": "persistent://public/default/my-topic", "subscription": "sub1"}
+	for _, token := range []string{"secret", "wrong"} {
+		meta, err := parsePulsarMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"token": token}})
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+		scaler := pulsarScaler{metadata: meta, client: server.Client(), logger: logr.Discard()}
+
+		backlog, found, err := scaler.getMsgBackLog(context.Background())
+		if token == "wrong" {
+			if err == nil {
+				t.Error("Expected error with wrong token but got success")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+		if !found || backlog != 5 {
+			t.Errorf("Expected backlog 5 but got %d (found %v)", backlog, found)
+		}
+	}
+}
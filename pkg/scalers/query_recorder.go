@@ -0,0 +1,121 @@
+package scalers
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// redactedValue replaces the secrets in the recorded queries
+const redactedValue = "REDACTED"
+
+// minSecretLength is the length from which the values of the auth params are redacted, the shorter ones are
+// settings like tls=enable rather than secrets and would mangle the queries
+const minSecretLength = 4
+
+// sensitiveQueryParams are the parts of the names of the URL query parameters whose values are redacted
+var sensitiveQueryParams = []string{"token", "secret", "password", "key", "auth", "signature", "credential"}
+
+// QueryRecorder keeps the last query, request or URL a scaler issued to its upstream system with the secrets
+// of the trigger redacted, so that a misconfigured trigger can be debugged without reading the scaler source.
+// The recorder is shared by the scalers built for the same trigger, the methods of a nil recorder do nothing.
+type QueryRecorder struct {
+	lock    sync.RWMutex
+	query   string
+	secrets []string
+}
+
+// SetSecrets sets the values redacted from the recorded queries, the auth params of the trigger and the
+// environment variables its metadata references
+func (r *QueryRecorder) SetSecrets(config *ScalerConfig) {
+	if r == nil {
+		return
+	}
+	var secrets []string
+	for _, value := range config.AuthParams {
+		secrets = append(secrets, value)
+	}
+	for key, value := range config.TriggerMetadata {
+		if strings.HasSuffix(key, "FromEnv") {
+			secrets = append(secrets, config.ResolvedEnv[value])
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.secrets = r.secrets[:0]
+	for _, secret := range secrets {
+		if len(secret) >= minSecretLength {
+			r.secrets = append(r.secrets, secret, url.QueryEscape(secret))
+		}
+	}
+}
+
+// Record records the query, the secrets are redacted
+func (r *QueryRecorder) Record(query string) {
+	if r == nil {
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.query = redactQuery(query, r.secrets)
+}
+
+// LastQuery returns the last recorded query, empty if the scaler didn't record any
+func (r *QueryRecorder) LastQuery() string {
+	if r == nil {
+		return ""
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.query
+}
+
+// redactQuery replaces the secrets of the trigger, the password of the URLs and the values of the sensitive
+// URL query parameters. The URLs can be prefixed with the method of the request.
+func redactQuery(query string, secrets []string) string {
+	for _, secret := range secrets {
+		query = strings.ReplaceAll(query, secret, redactedValue)
+	}
+
+	if u, ok := parseQueryURL(query); ok {
+		return redactURL(u)
+	}
+	if parts := strings.SplitN(query, " ", 2); len(parts) == 2 {
+		if u, ok := parseQueryURL(parts[1]); ok {
+			return parts[0] + " " + redactURL(u)
+		}
+	}
+	return query
+}
+
+// parseQueryURL parses the query as an absolute URL
+func parseQueryURL(query string) (*url.URL, bool) {
+	u, err := url.Parse(query)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, false
+	}
+	return u, true
+}
+
+func redactURL(u *url.URL) string {
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redactedValue)
+	}
+	values, redacted := u.Query(), false
+	for name := range values {
+		lower := strings.ToLower(name)
+		for _, sensitive := range sensitiveQueryParams {
+			if strings.Contains(lower, sensitive) {
+				values.Set(name, redactedValue)
+				redacted = true
+				break
+			}
+		}
+	}
+	// the query is only encoded again when needed, it would reorder the parameters
+	if redacted {
+		u.RawQuery = values.Encode()
+	}
+	return u.String()
+}
@@ -2,9 +2,11 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -36,6 +38,21 @@ const (
 	defaultRabbitMQQueueLength             = 20
 	rabbitMetricType                       = "External"
 	rabbitRootVhostPath                    = "/%2F"
+	// rabbitDefaultHeartbeat and rabbitDefaultLocale are the defaults of amqp.Dial
+	rabbitDefaultHeartbeat = 10 * time.Second
+	rabbitDefaultLocale    = "en_US"
+)
+
+const (
+	classicQueueType = "classic"
+	quorumQueueType  = "quorum"
+	streamQueueType  = "stream"
+)
+
+const (
+	quorumQueueMessagesAll      = "all"
+	quorumQueueMessagesReady    = "ready"
+	quorumQueueMessagesInMemory = "inMemory"
 )
 
 const (
@@ -57,31 +74,44 @@ type rabbitMQScaler struct {
 	metadata   *rabbitMQMetadata
 	connection *amqp.Connection
 	channel    *amqp.Channel
+	tlsConfig  *tls.Config
 	httpClient *http.Client
-	logger     logr.Logger
+	// egressDialer opens the amqp connections through the egress proxy, nil without egress proxy
+	egressDialer kedautil.EgressDialer
+	smoothing    *kedautil.EWMA
+	tte          *kedautil.TimeToEmpty
+	logger       logr.Logger
 }
 
 type rabbitMQMetadata struct {
 	queueName             string
-	mode                  string        // QueueLength or MessageRate
-	value                 float64       // trigger value (queue length or publish/sec. rate)
-	activationValue       float64       // activation value
-	host                  string        // connection string for either HTTP or AMQP protocol
-	protocol              string        // either http or amqp protocol
-	vhostName             *string       // override the vhost from the connection info
-	useRegex              bool          // specify if the queueName contains a rexeg
-	excludeUnacknowledged bool          // specify if the QueueLength value should exclude Unacknowledged messages (Ready messages only)
-	pageSize              int64         // specify the page size if useRegex is enabled
-	operation             string        // specify the operation to apply in case of multiples queues
-	metricName            string        // custom metric name for trigger
-	timeout               time.Duration // custom http timeout for a specific trigger
-	scalerIndex           int           // scaler index
+	mode                  string  // QueueLength or MessageRate
+	value                 float64 // trigger value (queue length or publish/sec. rate)
+	activationValue       float64 // activation value
+	host                  string  // connection string for either HTTP or AMQP protocol
+	protocol              string  // either http or amqp protocol
+	managementHost        string  // connection string for the management API used when amqp fails
+	fallbackToHTTP        bool    // specify if the management API is used when amqp fails (protocol auto only)
+	queueType             string  // classic, quorum or stream queue, used for the amqp passive declare
+	vhostName             *string // override the vhost from the connection info
+	useRegex              bool    // specify if the queueName contains a rexeg
+	excludeUnacknowledged bool    // specify if the QueueLength value should exclude Unacknowledged messages (Ready messages only)
+	quorumQueueMessages   string  // all, ready or inMemory messages of a quorum queue counted by the QueueLength value
+	pageSize              int64   // specify the page size if useRegex is enabled
+	operation             string  // specify the operation to apply in case of multiples queues
+	metricName            string  // custom metric name for trigger
+	scalerIndex           int     // scaler index
+
+	// TLS
+	unsafeSsl bool
+	tlsAuthParams
 }
 
 type queueInfo struct {
 	Messages               int         `json:"messages"`
 	MessagesReady          int         `json:"messages_ready"`
 	MessagesUnacknowledged int         `json:"messages_unacknowledged"`
+	MessagesRAM            int         `json:"messages_ram"`
 	MessageStat            messageStat `json:"message_stats"`
 	Name                   string      `json:"name"`
 }
@@ -99,6 +129,13 @@ type publishDetail struct {
 	Rate float64 `json:"rate"`
 }
 
+type streamConsumerInfo struct {
+	OffsetLag int64 `json:"offset_lag"`
+	Queue     struct {
+		Name string `json:"name"`
+	} `json:"queue"`
+}
+
 // NewRabbitMQScaler creates a new rabbitMQ scaler
 func NewRabbitMQScaler(config *ScalerConfig) (Scaler, error) {
 	s := &rabbitMQScaler{}
@@ -116,29 +153,69 @@ func NewRabbitMQScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing rabbitmq metadata: %s", err)
 	}
 	s.metadata = meta
-	s.httpClient = kedautil.CreateHTTPClient(meta.timeout, false)
+	s.httpClient, err = createTLSHTTPClient(config, meta.unsafeSsl, meta.tlsAuthParams)
+	if err != nil {
+		return nil, fmt.Errorf("error creating rabbitmq tls config: %s", err)
+	}
+	s.egressDialer, err = getEgressDialer(config)
+	if err != nil {
+		return nil, err
+	}
 
-	if meta.protocol == amqpProtocol {
-		// Override vhost if requested.
-		host := meta.host
-		if meta.vhostName != nil {
-			hostURI, err := amqp.ParseURI(host)
-			if err != nil {
-				return nil, fmt.Errorf("error parsing rabbitmq connection string: %s", err)
+	s.smoothing, err = GetSmoothingEWMA(config)
+	if err != nil {
+		return nil, err
+	}
+
+	s.tte, err = GetTimeToEmpty(config)
+	if err != nil {
+		return nil, err
+	}
+	if s.tte != nil && meta.mode != rabbitModeQueueLength {
+		return nil, fmt.Errorf("%s is only supported with mode %s", targetTimeToEmptyKey, rabbitModeQueueLength)
+	}
+
+	if meta.enableTLS {
+		tlsConfig, err := meta.newTLSConfig(meta.unsafeSsl)
+		if err != nil {
+			return nil, fmt.Errorf("error creating rabbitmq tls config: %s", err)
+		}
+		s.tlsConfig = tlsConfig
+	}
+
+	// Streams are inspected through the management API only
+	if meta.protocol == amqpProtocol && meta.queueType != streamQueueType {
+		if err := s.connect(); err != nil {
+			if !meta.fallbackToHTTP {
+				return nil, fmt.Errorf("error establishing rabbitmq connection: %s", err)
 			}
-			hostURI.Vhost = *meta.vhostName
-			host = hostURI.String()
+			s.logger.Error(s.anonimizeRabbitMQError(err), "error establishing rabbitmq connection, the management API will be used until the connection succeeds")
 		}
+	}
+
+	return s, nil
+}
 
-		conn, ch, err := getConnectionAndChannel(host)
+// connect opens the amqp connection and channel used to inspect the queue
+func (s *rabbitMQScaler) connect() error {
+	// Override vhost if requested.
+	host := s.metadata.host
+	if s.metadata.vhostName != nil {
+		hostURI, err := amqp.ParseURI(host)
 		if err != nil {
-			return nil, fmt.Errorf("error establishing rabbitmq connection: %s", err)
+			return fmt.Errorf("error parsing rabbitmq connection string: %s", err)
 		}
-		s.connection = conn
-		s.channel = ch
+		hostURI.Vhost = *s.metadata.vhostName
+		host = hostURI.String()
 	}
 
-	return s, nil
+	conn, ch, err := getConnectionAndChannel(host, s.tlsConfig, s.egressDialer)
+	if err != nil {
+		return err
+	}
+	s.connection = conn
+	s.channel = ch
+	return nil
 }
 
 func parseRabbitMQMetadata(config *ScalerConfig) (*rabbitMQMetadata, error) {
@@ -177,6 +254,7 @@ func parseRabbitMQMetadata(config *ScalerConfig) (*rabbitMQMetadata, error) {
 		switch parsedURL.Scheme {
 		case "amqp", "amqps":
 			meta.protocol = amqpProtocol
+			meta.fallbackToHTTP = true
 		case "http", "https":
 			meta.protocol = httpProtocol
 		default:
@@ -184,6 +262,39 @@ func parseRabbitMQMetadata(config *ScalerConfig) (*rabbitMQMetadata, error) {
 		}
 	}
 
+	// Resolve the management API used as fallback when amqp fails
+	if meta.fallbackToHTTP {
+		switch {
+		case config.AuthParams["managementHost"] != "":
+			meta.managementHost = config.AuthParams["managementHost"]
+		case config.TriggerMetadata["managementHost"] != "":
+			meta.managementHost = config.TriggerMetadata["managementHost"]
+		default:
+			managementHost, err := getRabbitMQManagementHost(meta.host)
+			if err != nil {
+				return nil, fmt.Errorf("can't resolve management host from host: %s", err)
+			}
+			meta.managementHost = managementHost
+		}
+	}
+
+	// Resolve queueType
+	meta.queueType = classicQueueType
+	if val, ok := config.TriggerMetadata["queueType"]; ok {
+		switch val {
+		case classicQueueType, quorumQueueType, streamQueueType:
+			meta.queueType = val
+		default:
+			return nil, fmt.Errorf("queueType must be one of %s, %s, %s but is %s", classicQueueType, quorumQueueType, streamQueueType, val)
+		}
+	}
+
+	tlsParams, err := parseTLSAuthParams(config)
+	if err != nil {
+		return nil, err
+	}
+	meta.tlsAuthParams = tlsParams
+
 	// Resolve queueName
 	if val, ok := config.TriggerMetadata["queueName"]; ok {
 		meta.queueName = val
@@ -196,7 +307,7 @@ func parseRabbitMQMetadata(config *ScalerConfig) (*rabbitMQMetadata, error) {
 		meta.vhostName = &val
 	}
 
-	err := parseRabbitMQHttpProtocolMetadata(config, &meta)
+	err = parseRabbitMQHttpProtocolMetadata(config, &meta)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +320,10 @@ func parseRabbitMQMetadata(config *ScalerConfig) (*rabbitMQMetadata, error) {
 		return nil, fmt.Errorf("configure excludeUnacknowledged=true with http protocol only")
 	}
 
+	if err := parseRabbitMQQueueTypeMetadata(config, &meta); err != nil {
+		return nil, err
+	}
+
 	_, err = parseTrigger(&meta, config)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse trigger: %s", err)
@@ -221,28 +336,47 @@ func parseRabbitMQMetadata(config *ScalerConfig) (*rabbitMQMetadata, error) {
 		meta.metricName = kedautil.NormalizeString(fmt.Sprintf("rabbitmq-%s", url.QueryEscape(meta.queueName)))
 	}
 
-	// Resolve timeout
-	if val, ok := config.TriggerMetadata["timeout"]; ok {
-		timeoutMS, err := strconv.Atoi(val)
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse timeout: %s", err)
+	// The timeout of the trigger is applied to the management API client through the global HTTP timeout
+	if _, ok := config.TriggerMetadata[TimeoutKey]; ok {
+		if _, err := GetTimeout(config.TriggerMetadata, config.GlobalHTTPTimeout); err != nil {
+			return nil, err
 		}
 		if meta.protocol == amqpProtocol {
-			return nil, fmt.Errorf("amqp protocol doesn't support custom timeouts: %s", err)
+			return nil, fmt.Errorf("amqp protocol doesn't support custom timeouts")
 		}
-		if timeoutMS <= 0 {
-			return nil, fmt.Errorf("timeout must be greater than 0: %s", err)
-		}
-		meta.timeout = time.Duration(timeoutMS) * time.Millisecond
-	} else {
-		meta.timeout = config.GlobalHTTPTimeout
 	}
 
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.unsafeSsl = unsafeSsl
+
 	meta.scalerIndex = config.ScalerIndex
 
 	return &meta, nil
 }
 
+// getRabbitMQManagementHost derives the management API URL from an amqp connection string,
+// keeping the credentials and vhost and using the default management ports
+func getRabbitMQManagementHost(host string) (string, error) {
+	parsedURL, err := url.Parse(host)
+	if err != nil {
+		return "", err
+	}
+	switch parsedURL.Scheme {
+	case "amqp":
+		parsedURL.Scheme = "http"
+		parsedURL.Host = fmt.Sprintf("%s:15672", parsedURL.Hostname())
+	case "amqps":
+		parsedURL.Scheme = "https"
+		parsedURL.Host = fmt.Sprintf("%s:15671", parsedURL.Hostname())
+	default:
+		return "", fmt.Errorf("unknown host URL scheme `%s`", parsedURL.Scheme)
+	}
+	return parsedURL.String(), nil
+}
+
 func parseRabbitMQHttpProtocolMetadata(config *ScalerConfig, meta *rabbitMQMetadata) error {
 	// Resolve useRegex
 	if val, ok := config.TriggerMetadata["useRegex"]; ok {
@@ -279,12 +413,56 @@ func parseRabbitMQHttpProtocolMetadata(config *ScalerConfig, meta *rabbitMQMetad
 	// Resolve operation
 	meta.operation = defaultOperation
 	if val, ok := config.TriggerMetadata["operation"]; ok {
+		if val != sumOperation && val != avgOperation && val != maxOperation {
+			return fmt.Errorf("operation mode %s must be one of %s, %s, %s", val, sumOperation, avgOperation, maxOperation)
+		}
+		if !meta.useRegex {
+			return fmt.Errorf("configure operation only with useRegex")
+		}
 		meta.operation = val
 	}
 
 	return nil
 }
 
+// parseRabbitMQQueueTypeMetadata validates the settings which depend on the queue type, the messages of
+// a stream are kept once consumed so its length is the offset lag of its consumers read from the management API
+func parseRabbitMQQueueTypeMetadata(config *ScalerConfig, meta *rabbitMQMetadata) error {
+	if meta.queueType == streamQueueType {
+		if meta.protocol == amqpProtocol && !meta.fallbackToHTTP {
+			return fmt.Errorf("stream queues require the management API; use http or auto protocol")
+		}
+		if meta.useRegex {
+			return fmt.Errorf("useRegex isn't supported with stream queues")
+		}
+		if meta.excludeUnacknowledged {
+			return fmt.Errorf("excludeUnacknowledged isn't supported with stream queues")
+		}
+	}
+
+	// Resolve quorumQueueMessages
+	meta.quorumQueueMessages = quorumQueueMessagesAll
+	if val, ok := config.TriggerMetadata["quorumQueueMessages"]; ok {
+		switch val {
+		case quorumQueueMessagesAll, quorumQueueMessagesReady, quorumQueueMessagesInMemory:
+			meta.quorumQueueMessages = val
+		default:
+			return fmt.Errorf("quorumQueueMessages must be one of %s, %s, %s but is %s", quorumQueueMessagesAll, quorumQueueMessagesReady, quorumQueueMessagesInMemory, val)
+		}
+		if meta.queueType != quorumQueueType {
+			return fmt.Errorf("configure quorumQueueMessages only with queueType %s", quorumQueueType)
+		}
+		if meta.protocol == amqpProtocol {
+			return fmt.Errorf("configure quorumQueueMessages with http protocol only")
+		}
+		if meta.excludeUnacknowledged {
+			return fmt.Errorf("configure only one of excludeUnacknowledged and quorumQueueMessages")
+		}
+	}
+
+	return nil
+}
+
 func parseTrigger(meta *rabbitMQMetadata, config *ScalerConfig) (*rabbitMQMetadata, error) {
 	deprecatedQueueLengthValue, deprecatedQueueLengthPresent := config.TriggerMetadata[rabbitQueueLengthMetricName]
 	mode, modePresent := config.TriggerMetadata[rabbitModeTriggerConfigName]
@@ -355,8 +533,24 @@ func parseTrigger(meta *rabbitMQMetadata, config *ScalerConfig) (*rabbitMQMetada
 	return meta, nil
 }
 
-func getConnectionAndChannel(host string) (*amqp.Connection, *amqp.Channel, error) {
-	conn, err := amqp.Dial(host)
+func getConnectionAndChannel(host string, tlsConfig *tls.Config, egressDialer kedautil.EgressDialer) (*amqp.Connection, *amqp.Channel, error) {
+	var conn *amqp.Connection
+	var err error
+	switch {
+	case egressDialer != nil:
+		conn, err = amqp.DialConfig(host, amqp.Config{
+			Heartbeat:       rabbitDefaultHeartbeat,
+			TLSClientConfig: tlsConfig,
+			Locale:          rabbitDefaultLocale,
+			Dial: func(network, addr string) (net.Conn, error) {
+				return egressDialer.DialContext(context.Background(), network, addr)
+			},
+		})
+	case tlsConfig != nil:
+		conn, err = amqp.DialTLS(host, tlsConfig)
+	default:
+		conn, err = amqp.Dial(host)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -388,6 +582,7 @@ func (s *rabbitMQScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, s.anonimizeRabbitMQError(err)
 	}
 
+	// the values aren't smoothed for the activation, unlike the metric
 	if s.metadata.mode == rabbitModeQueueLength {
 		return float64(messages) > s.metadata.activationValue, nil
 	}
@@ -396,25 +591,72 @@ func (s *rabbitMQScaler) IsActive(ctx context.Context) (bool, error) {
 
 func (s *rabbitMQScaler) getQueueStatus() (int64, float64, error) {
 	if s.metadata.protocol == httpProtocol {
-		info, err := s.getQueueInfoViaHTTP()
+		return s.getQueueStatusViaHTTP(s.metadata.host)
+	}
+
+	// The passive declare of a stream returns the messages it retains rather than the ones left to consume
+	if s.metadata.queueType == streamQueueType {
+		return s.getQueueStatusViaHTTP(s.metadata.managementHost)
+	}
+
+	messages, err := s.getQueueStatusViaAMQP()
+	if err != nil && s.metadata.fallbackToHTTP {
+		s.logger.V(1).Info("unable to inspect queue using amqp, falling back to the management API", "error", s.anonimizeRabbitMQError(err).Error())
+		return s.getQueueStatusViaHTTP(s.metadata.managementHost)
+	}
+	return messages, 0, err
+}
+
+func (s *rabbitMQScaler) getQueueStatusViaHTTP(host string) (int64, float64, error) {
+	info, err := s.getQueueInfoViaHTTP(host)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	switch {
+	case s.metadata.queueType == streamQueueType:
+		// messages count is how far the slowest consumer is behind
+		lag, err := s.getStreamOffsetLagViaHTTP(host)
 		if err != nil {
 			return -1, -1, err
 		}
+		return lag, info.MessageStat.PublishDetail.Rate, nil
+	case s.metadata.quorumQueueMessages == quorumQueueMessagesInMemory:
+		// messages count includes only the ones kept in memory
+		return int64(info.MessagesRAM), info.MessageStat.PublishDetail.Rate, nil
+	case s.metadata.excludeUnacknowledged, s.metadata.quorumQueueMessages == quorumQueueMessagesReady:
+		// messages count includes only ready
+		return int64(info.MessagesReady), info.MessageStat.PublishDetail.Rate, nil
+	}
+	// messages count includes count of ready and unack-ed
+	return int64(info.Messages), info.MessageStat.PublishDetail.Rate, nil
+}
 
-		if s.metadata.excludeUnacknowledged {
-			// messages count includes only ready
-			return int64(info.MessagesReady), info.MessageStat.PublishDetail.Rate, nil
+func (s *rabbitMQScaler) getQueueStatusViaAMQP() (int64, error) {
+	if s.connection == nil || s.connection.IsClosed() {
+		if err := s.connect(); err != nil {
+			return -1, err
+		}
+	} else if s.channel == nil {
+		ch, err := s.connection.Channel()
+		if err != nil {
+			return -1, err
 		}
-		// messages count includes count of ready and unack-ed
-		return int64(info.Messages), info.MessageStat.PublishDetail.Rate, nil
+		s.channel = ch
 	}
 
-	items, err := s.channel.QueueInspect(s.metadata.queueName)
+	// The server closes the channel when the passive declare fails, so a new one is opened on the next call
+	var args amqp.Table
+	if s.metadata.queueType != classicQueueType {
+		args = amqp.Table{"x-queue-type": s.metadata.queueType}
+	}
+	items, err := s.channel.QueueDeclarePassive(s.metadata.queueName, true, false, false, false, args)
 	if err != nil {
-		return -1, -1, err
+		s.channel = nil
+		return -1, err
 	}
 
-	return int64(items.Messages), 0, nil
+	return int64(items.Messages), nil
 }
 
 func getJSON(s *rabbitMQScaler, url string) (queueInfo, error) {
@@ -447,11 +689,12 @@ func getJSON(s *rabbitMQScaler, url string) (queueInfo, error) {
 	return result, fmt.Errorf("error requesting rabbitMQ API status: %s, response: %s, from: %s", r.Status, body, url)
 }
 
-func (s *rabbitMQScaler) getQueueInfoViaHTTP() (*queueInfo, error) {
-	parsedURL, err := url.Parse(s.metadata.host)
+// getManagementURL returns the management API URL of the host and the escaped vhost path the API is queried for
+func (s *rabbitMQScaler) getManagementURL(host string) (string, string, error) {
+	parsedURL, err := url.Parse(host)
 
 	if err != nil {
-		return nil, err
+		return "", "", err
 	}
 
 	// Extract vhost from URL's path.
@@ -481,11 +724,20 @@ func (s *rabbitMQScaler) getQueueInfoViaHTTP() (*queueInfo, error) {
 	// Clear URL path to get the correct host.
 	parsedURL.Path = ""
 
+	return parsedURL.String(), vhost, nil
+}
+
+func (s *rabbitMQScaler) getQueueInfoViaHTTP(host string) (*queueInfo, error) {
+	managementURL, vhost, err := s.getManagementURL(host)
+	if err != nil {
+		return nil, err
+	}
+
 	var getQueueInfoManagementURI string
 	if s.metadata.useRegex {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s?page=1&use_regex=true&pagination=false&name=%s&page_size=%d", parsedURL.String(), vhost, url.QueryEscape(s.metadata.queueName), s.metadata.pageSize)
+		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s?page=1&use_regex=true&pagination=false&name=%s&page_size=%d", managementURL, vhost, url.QueryEscape(s.metadata.queueName), s.metadata.pageSize)
 	} else {
-		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s/%s", parsedURL.String(), vhost, url.QueryEscape(s.metadata.queueName))
+		getQueueInfoManagementURI = fmt.Sprintf("%s/api/queues%s/%s", managementURL, vhost, url.QueryEscape(s.metadata.queueName))
 	}
 
 	var info queueInfo
@@ -498,13 +750,47 @@ func (s *rabbitMQScaler) getQueueInfoViaHTTP() (*queueInfo, error) {
 	return &info, nil
 }
 
+// getStreamOffsetLagViaHTTP returns the largest offset lag of the consumers of the stream, a stream without
+// consumers has no lag as there is no offset it is consumed from
+func (s *rabbitMQScaler) getStreamOffsetLagViaHTTP(host string) (int64, error) {
+	managementURL, vhost, err := s.getManagementURL(host)
+	if err != nil {
+		return -1, err
+	}
+
+	getStreamConsumersManagementURI := fmt.Sprintf("%s/api/stream/consumers%s", managementURL, vhost)
+	r, err := s.httpClient.Get(getStreamConsumersManagementURI)
+	if err != nil {
+		return -1, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(r.Body)
+		return -1, fmt.Errorf("error requesting rabbitMQ API stream consumers: %s, response: %s, from: %s", r.Status, body, getStreamConsumersManagementURI)
+	}
+
+	var consumers []streamConsumerInfo
+	if err := json.NewDecoder(r.Body).Decode(&consumers); err != nil {
+		return -1, err
+	}
+
+	var lag int64
+	for _, consumer := range consumers {
+		if consumer.Queue.Name == s.metadata.queueName && consumer.OffsetLag > lag {
+			lag = consumer.OffsetLag
+		}
+	}
+	return lag, nil
+}
+
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
 		},
-		Target: GetMetricTargetMili(s.metricType, s.metadata.value),
+		Target: timeToEmptyMetricTarget(s.tte, s.metricType, s.metadata.value),
 	}
 	metricSpec := v2beta2.MetricSpec{
 		External: externalMetric, Type: rabbitMetricType,
@@ -515,19 +801,28 @@ func (s *rabbitMQScaler) GetMetricSpecForScaling(context.Context) []v2beta2.Metr
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *rabbitMQScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	metrics, _, err := s.GetMetricsAndActivity(ctx, metricName, metricSelector)
+	return metrics, err
+}
+
+// GetMetricsAndActivity returns the metric and whether the scaler is active from a single read of the queue status
+func (s *rabbitMQScaler) GetMetricsAndActivity(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, bool, error) {
 	messages, publishRate, err := s.getQueueStatus()
 	if err != nil {
-		return []external_metrics.ExternalMetricValue{}, s.anonimizeRabbitMQError(err)
+		return []external_metrics.ExternalMetricValue{}, false, s.anonimizeRabbitMQError(err)
 	}
 
+	// the values aren't smoothed for the activation, unlike the metric
 	var metric external_metrics.ExternalMetricValue
+	isActive := float64(messages) > s.metadata.activationValue
 	if s.metadata.mode == rabbitModeQueueLength {
-		metric = GenerateMetricInMili(metricName, float64(messages))
+		metric = GenerateMetricInMili(metricName, timeToEmptyMetricValue(s.tte, smoothMetricValue(s.smoothing, float64(messages))))
 	} else {
-		metric = GenerateMetricInMili(metricName, publishRate)
+		metric = GenerateMetricInMili(metricName, smoothMetricValue(s.smoothing, publishRate))
+		isActive = isActive || publishRate > s.metadata.activationValue
 	}
 
-	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+	return append([]external_metrics.ExternalMetricValue{}, metric), isActive, nil
 }
 
 func getComposedQueue(s *rabbitMQScaler, q []queueInfo) (queueInfo, error) {
@@ -537,19 +832,22 @@ func getComposedQueue(s *rabbitMQScaler, q []queueInfo) (queueInfo, error) {
 	if len(q) > 0 {
 		switch s.metadata.operation {
 		case sumOperation:
-			sumMessages, sumReady, sumRate := getSum(q)
+			sumMessages, sumReady, sumRAM, sumRate := getSum(q)
 			queue.Messages = sumMessages
 			queue.MessagesReady = sumReady
+			queue.MessagesRAM = sumRAM
 			queue.MessageStat.PublishDetail.Rate = sumRate
 		case avgOperation:
-			avgMessages, avgReady, avgRate := getAverage(q)
+			avgMessages, avgReady, avgRAM, avgRate := getAverage(q)
 			queue.Messages = avgMessages
 			queue.MessagesReady = avgReady
+			queue.MessagesRAM = avgRAM
 			queue.MessageStat.PublishDetail.Rate = avgRate
 		case maxOperation:
-			maxMessages, maxReady, maxRate := getMaximum(q)
+			maxMessages, maxReady, maxRAM, maxRate := getMaximum(q)
 			queue.Messages = maxMessages
 			queue.MessagesReady = maxReady
+			queue.MessagesRAM = maxRAM
 			queue.MessageStat.PublishDetail.Rate = maxRate
 		default:
 			return queue, fmt.Errorf("operation mode %s must be one of %s, %s, %s", s.metadata.operation, sumOperation, avgOperation, maxOperation)
@@ -562,27 +860,30 @@ func getComposedQueue(s *rabbitMQScaler, q []queueInfo) (queueInfo, error) {
 	return queue, nil
 }
 
-func getSum(q []queueInfo) (int, int, float64) {
+func getSum(q []queueInfo) (int, int, int, float64) {
 	var sumMessages int
 	var sumMessagesReady int
+	var sumMessagesRAM int
 	var sumRate float64
 	for _, value := range q {
 		sumMessages += value.Messages
 		sumMessagesReady += value.MessagesReady
+		sumMessagesRAM += value.MessagesRAM
 		sumRate += value.MessageStat.PublishDetail.Rate
 	}
-	return sumMessages, sumMessagesReady, sumRate
+	return sumMessages, sumMessagesReady, sumMessagesRAM, sumRate
 }
 
-func getAverage(q []queueInfo) (int, int, float64) {
-	sumMessages, sumReady, sumRate := getSum(q)
+func getAverage(q []queueInfo) (int, int, int, float64) {
+	sumMessages, sumReady, sumRAM, sumRate := getSum(q)
 	len := len(q)
-	return sumMessages / len, sumReady / len, sumRate / float64(len)
+	return sumMessages / len, sumReady / len, sumRAM / len, sumRate / float64(len)
 }
 
-func getMaximum(q []queueInfo) (int, int, float64) {
+func getMaximum(q []queueInfo) (int, int, int, float64) {
 	var maxMessages int
 	var maxReady int
+	var maxRAM int
 	var maxRate float64
 	for _, value := range q {
 		if value.Messages > maxMessages {
@@ -591,11 +892,14 @@ func getMaximum(q []queueInfo) (int, int, float64) {
 		if value.MessagesReady > maxReady {
 			maxReady = value.MessagesReady
 		}
+		if value.MessagesRAM > maxRAM {
+			maxRAM = value.MessagesRAM
+		}
 		if value.MessageStat.PublishDetail.Rate > maxRate {
 			maxRate = value.MessageStat.PublishDetail.Rate
 		}
 	}
-	return maxMessages, maxReady, maxRate
+	return maxMessages, maxReady, maxRAM, maxRate
 }
 
 // Mask host for log purposes
@@ -4,8 +4,10 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-redis/redis/v8"
@@ -21,6 +23,17 @@ const (
 	defaultActivationListLength = 0
 	defaultDBIdx                = 0
 	defaultEnableTLS            = false
+	// redisDialTimeout and redisDialKeepAlive are the defaults of go-redis
+	redisDialTimeout   = 5 * time.Second
+	redisDialKeepAlive = 5 * time.Minute
+)
+
+// Read preferences of the redis-cluster scalers, which route the read-only commands to the master by default
+const (
+	redisReadPreferenceMaster  = "master"
+	redisReadPreferenceReplica = "replica"
+	redisReadPreferenceLatency = "latency"
+	redisReadPreferenceRandom  = "random"
 )
 
 type redisAddressParser func(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error)
@@ -43,10 +56,16 @@ type redisConnectionInfo struct {
 	hosts            []string
 	ports            []string
 	enableTLS        bool
+	unsafeSsl        bool
+	// tlsServerName overrides the server name checked against the certificates of the cluster nodes,
+	// which are redirected to by address
+	tlsServerName  string
+	maxRedirects   int
+	readPreference string
 }
 
 type redisMetadata struct {
-	listLength           int64
+	listLength           float64
 	activationListLength int64
 	listName             string
 	databaseIndex        int
@@ -185,7 +204,7 @@ func parseRedisMetadata(config *ScalerConfig, parserFn redisAddressParser) (*red
 
 	meta.listLength = defaultListLength
 	if val, ok := config.TriggerMetadata["listLength"]; ok {
-		listLength, err := strconv.ParseInt(val, 10, 64)
+		listLength, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("list length parsing error %s", err.Error())
 		}
@@ -242,7 +261,7 @@ func (s *redisScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricS
 		Metric: v2beta2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, metricName),
 		},
-		Target: GetMetricTarget(s.metricType, s.metadata.listLength),
+		Target: GetMetricTargetMili(s.metricType, s.metadata.listLength),
 	}
 	metricSpec := v2beta2.MetricSpec{
 		External: externalMetric, Type: externalMetricType,
@@ -325,6 +344,12 @@ func parseRedisAddress(metadata, resolvedEnv, authParams map[string]string) (red
 		info.enableTLS = tls
 	}
 
+	unsafeSsl, err := GetUnsafeSsl(metadata)
+	if err != nil {
+		return info, err
+	}
+	info.unsafeSsl = unsafeSsl
+
 	return info, nil
 }
 
@@ -403,6 +428,34 @@ func parseRedisClusterAddress(metadata, resolvedEnv, authParams map[string]strin
 		info.enableTLS = tls
 	}
 
+	unsafeSsl, err := GetUnsafeSsl(metadata)
+	if err != nil {
+		return info, err
+	}
+	info.unsafeSsl = unsafeSsl
+
+	info.tlsServerName = metadata["tlsServerName"]
+
+	if val, ok := metadata["maxRedirects"]; ok && val != "" {
+		maxRedirects, err := strconv.Atoi(val)
+		if err != nil {
+			return info, fmt.Errorf("maxRedirects parsing error %s", err.Error())
+		}
+		if maxRedirects < 1 {
+			return info, fmt.Errorf("maxRedirects must be a positive number")
+		}
+		info.maxRedirects = maxRedirects
+	}
+
+	if val, ok := metadata["readPreference"]; ok && val != "" {
+		switch val {
+		case redisReadPreferenceMaster, redisReadPreferenceReplica, redisReadPreferenceLatency, redisReadPreferenceRandom:
+			info.readPreference = val
+		default:
+			return info, fmt.Errorf("readPreference must be one of %s, %s, %s or %s", redisReadPreferenceMaster, redisReadPreferenceReplica, redisReadPreferenceLatency, redisReadPreferenceRandom)
+		}
+	}
+
 	return info, nil
 }
 
@@ -460,19 +513,35 @@ func parseRedisSentinelAddress(metadata, resolvedEnv, authParams map[string]stri
 		info.enableTLS = tls
 	}
 
+	unsafeSsl, err := GetUnsafeSsl(metadata)
+	if err != nil {
+		return info, err
+	}
+	info.unsafeSsl = unsafeSsl
+
 	return info, nil
 }
 
 func getRedisClusterClient(ctx context.Context, info redisConnectionInfo) (*redis.ClusterClient, error) {
 	options := &redis.ClusterOptions{
-		Addrs:    info.addresses,
-		Username: info.username,
-		Password: info.password,
+		Addrs:        info.addresses,
+		Username:     info.username,
+		Password:     info.password,
+		MaxRedirects: info.maxRedirects,
+	}
+	switch info.readPreference {
+	case redisReadPreferenceReplica:
+		options.ReadOnly = true
+	case redisReadPreferenceLatency:
+		options.RouteByLatency = true
+	case redisReadPreferenceRandom:
+		options.RouteRandomly = true
 	}
 	if info.enableTLS {
 		options.TLSConfig = &tls.Config{
-			InsecureSkipVerify: info.enableTLS,
+			InsecureSkipVerify: info.unsafeSsl,
 		}
+		options.Dialer = redisClusterTLSDialer(options.TLSConfig, info.tlsServerName)
 	}
 
 	// confirm if connected
@@ -483,6 +552,28 @@ func getRedisClusterClient(ctx context.Context, info redisConnectionInfo) (*redi
 	return c, nil
 }
 
+// redisClusterTLSDialer dials the cluster nodes with the server name of every node, or the given one, as
+// the nodes are redirected to by MOVED and ASK replies which managed clusters often send with IP addresses
+func redisClusterTLSDialer(tlsConfig *tls.Config, serverName string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		config := tlsConfig.Clone()
+		config.ServerName = serverName
+		if config.ServerName == "" {
+			host, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+			config.ServerName = host
+		}
+
+		dialer := &tls.Dialer{
+			NetDialer: &net.Dialer{Timeout: redisDialTimeout, KeepAlive: redisDialKeepAlive},
+			Config:    config,
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}
+}
+
 func getRedisSentinelClient(ctx context.Context, info redisConnectionInfo, dbIndex int) (*redis.Client, error) {
 	options := &redis.FailoverOptions{
 		Username:         info.username,
@@ -495,7 +586,7 @@ func getRedisSentinelClient(ctx context.Context, info redisConnectionInfo, dbInd
 	}
 	if info.enableTLS {
 		options.TLSConfig = &tls.Config{
-			InsecureSkipVerify: info.enableTLS,
+			InsecureSkipVerify: info.unsafeSsl,
 		}
 	}
 
@@ -516,7 +607,7 @@ func getRedisClient(ctx context.Context, info redisConnectionInfo, dbIndex int)
 	}
 	if info.enableTLS {
 		options.TLSConfig = &tls.Config{
-			InsecureSkipVerify: info.enableTLS,
+			InsecureSkipVerify: info.unsafeSsl,
 		}
 	}
 
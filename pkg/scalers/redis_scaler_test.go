@@ -2,7 +2,11 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -292,6 +296,54 @@ func TestParseRedisClusterMetadata(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "tls server name, max redirects and read preference given",
+			metadata: map[string]string{
+				"hosts":          "a, b, c",
+				"ports":          "1, 2, 3",
+				"listName":       "mylist",
+				"enableTLS":      "true",
+				"tlsServerName":  "clustercfg.example.com",
+				"maxRedirects":   "8",
+				"readPreference": "replica",
+			},
+			wantMeta: &redisMetadata{
+				listLength: 5,
+				listName:   "mylist",
+				connectionInfo: redisConnectionInfo{
+					addresses:      []string{"a:1", "b:2", "c:3"},
+					hosts:          []string{"a", "b", "c"},
+					ports:          []string{"1", "2", "3"},
+					enableTLS:      true,
+					tlsServerName:  "clustercfg.example.com",
+					maxRedirects:   8,
+					readPreference: "replica",
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid max redirects",
+			metadata: map[string]string{
+				"hosts":        "a, b, c",
+				"ports":        "1, 2, 3",
+				"listName":     "mylist",
+				"maxRedirects": "0",
+			},
+			wantMeta: nil,
+			wantErr:  errors.New("maxRedirects must be a positive number"),
+		},
+		{
+			name: "invalid read preference",
+			metadata: map[string]string{
+				"hosts":          "a, b, c",
+				"ports":          "1, 2, 3",
+				"listName":       "mylist",
+				"readPreference": "nearest",
+			},
+			wantMeta: nil,
+			wantErr:  errors.New("readPreference must be one of master, replica, latency or random"),
+		},
 	}
 
 	for _, testCase := range cases {
@@ -717,3 +769,37 @@ func TestParseRedisSentinelMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestRedisClusterTLSDialer(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+	addr := server.Listener.Addr().String()
+
+	cases := []struct {
+		name       string
+		serverName string
+		wantErr    bool
+	}{
+		// the test certificate is valid for 127.0.0.1 and example.com
+		{"server name of the node", "", false},
+		{"server name given", "example.com", false},
+		{"wrong server name given", "redis.example.org", true},
+	}
+
+	for _, testCase := range cases {
+		c := testCase
+		t.Run(c.name, func(t *testing.T) {
+			dial := redisClusterTLSDialer(&tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}, c.serverName)
+			conn, err := dial(context.Background(), "tcp", addr)
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, conn.Close())
+		})
+	}
+}
@@ -0,0 +1,363 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	defaultSalesforceLoginURL    = "https://login.salesforce.com"
+	defaultSalesforceAPIVersion  = "55.0"
+	defaultSalesforceTargetValue = 5
+	salesforceMetricType         = "External"
+)
+
+type salesforceScalingMode string
+
+const (
+	// salesforceScalingModeBulkAPIJobs scales on the Bulk API 2.0 ingest jobs queued or in progress
+	salesforceScalingModeBulkAPIJobs salesforceScalingMode = "bulkApiJobs"
+	// salesforceScalingModePlatformEventLag scales on how far the subscribers of a platform event are behind
+	salesforceScalingModePlatformEventLag salesforceScalingMode = "platformEventLag"
+)
+
+// salesforcePendingJobStates are the states of the ingest jobs whose data is uploaded and not processed yet
+var salesforcePendingJobStates = map[string]bool{
+	"UploadComplete": true,
+	"InProgress":     true,
+}
+
+var errSalesforceInvalidSession = errors.New("salesforce session is invalid")
+
+type salesforceScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *salesforceMetadata
+	httpClient *http.Client
+	jwtConfig  *jwt.Config
+	token      *oauth2.Token
+	tokenLock  sync.Mutex
+	logger     logr.Logger
+}
+
+type salesforceMetadata struct {
+	loginURL              string
+	clientID              string
+	username              string
+	privateKey            string
+	apiVersion            string
+	scalingMode           salesforceScalingMode
+	jobObject             string
+	eventName             string
+	subscriberName        string
+	targetValue           float64
+	activationTargetValue float64
+	unsafeSsl             bool
+	scalerIndex           int
+}
+
+type salesforceJobsResponse struct {
+	Records []struct {
+		Object string `json:"object"`
+		State  string `json:"state"`
+	} `json:"records"`
+	NextRecordsURL string `json:"nextRecordsUrl"`
+}
+
+type salesforceQueryResponse struct {
+	Records []struct {
+		Name     string `json:"Name"`
+		Position int64  `json:"Position"`
+		Tip      int64  `json:"Tip"`
+	} `json:"records"`
+}
+
+// NewSalesforceScaler creates a new salesforceScaler
+func NewSalesforceScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseSalesforceMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing salesforce metadata: %s", err)
+	}
+
+	return &salesforceScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: createHTTPClient(config, meta.unsafeSsl),
+		jwtConfig: &jwt.Config{
+			Email:      meta.clientID,
+			Subject:    meta.username,
+			PrivateKey: []byte(meta.privateKey),
+			Audience:   meta.loginURL,
+			TokenURL:   fmt.Sprintf("%s/services/oauth2/token", meta.loginURL),
+		},
+		logger: InitializeLogger(config, "salesforce_scaler"),
+	}, nil
+}
+
+func parseSalesforceMetadata(config *ScalerConfig) (*salesforceMetadata, error) {
+	meta := salesforceMetadata{}
+	var err error
+
+	meta.loginURL = defaultSalesforceLoginURL
+	if val, ok := config.TriggerMetadata["loginURL"]; ok && val != "" {
+		meta.loginURL = strings.TrimSuffix(val, "/")
+	}
+
+	meta.clientID, err = GetFromAuthOrMeta(config, "clientId")
+	if err != nil {
+		return nil, err
+	}
+	meta.username, err = GetFromAuthOrMeta(config, "username")
+	if err != nil {
+		return nil, err
+	}
+	meta.privateKey = config.AuthParams["privateKey"]
+	if meta.privateKey == "" {
+		return nil, fmt.Errorf("no privateKey given")
+	}
+
+	meta.apiVersion = defaultSalesforceAPIVersion
+	if val, ok := config.TriggerMetadata["apiVersion"]; ok && val != "" {
+		if _, err := strconv.ParseFloat(val, 64); err != nil {
+			return nil, fmt.Errorf("apiVersion must be a version like %s but is %s", defaultSalesforceAPIVersion, val)
+		}
+		meta.apiVersion = val
+	}
+
+	meta.scalingMode = salesforceScalingModeBulkAPIJobs
+	if val, ok := config.TriggerMetadata["scalingMode"]; ok && val != "" {
+		meta.scalingMode = salesforceScalingMode(val)
+	}
+	switch meta.scalingMode {
+	case salesforceScalingModeBulkAPIJobs:
+		meta.jobObject = config.TriggerMetadata["jobObject"]
+	case salesforceScalingModePlatformEventLag:
+		meta.eventName = config.TriggerMetadata["eventName"]
+		if meta.eventName == "" {
+			return nil, fmt.Errorf("no eventName given")
+		}
+		meta.subscriberName = config.TriggerMetadata["subscriberName"]
+	default:
+		return nil, fmt.Errorf("scalingMode must be one of %s, %s but is %s", salesforceScalingModeBulkAPIJobs, salesforceScalingModePlatformEventLag, meta.scalingMode)
+	}
+
+	meta.targetValue = defaultSalesforceTargetValue
+	if val, ok := config.TriggerMetadata["targetValue"]; ok && val != "" {
+		meta.targetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing targetValue: %s", err)
+		}
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok && val != "" {
+		meta.activationTargetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetValue: %s", err)
+		}
+	}
+
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// getToken returns the cached access token, or requests a new one with the OAuth JWT bearer flow
+func (s *salesforceScaler) getToken(ctx context.Context) (*oauth2.Token, error) {
+	s.tokenLock.Lock()
+	defer s.tokenLock.Unlock()
+
+	// Salesforce tokens have no expiry, they are valid until the session times out
+	if s.token == nil {
+		token, err := s.jwtConfig.TokenSource(context.WithValue(ctx, oauth2.HTTPClient, s.httpClient)).Token()
+		if err != nil {
+			return nil, fmt.Errorf("error getting salesforce access token: %s", err)
+		}
+		if instanceURL, _ := token.Extra("instance_url").(string); instanceURL == "" {
+			return nil, fmt.Errorf("salesforce access token has no instance_url")
+		}
+		s.token = token
+	}
+	return s.token, nil
+}
+
+func (s *salesforceScaler) resetToken() {
+	s.tokenLock.Lock()
+	defer s.tokenLock.Unlock()
+	s.token = nil
+}
+
+// getJSON requests the path of the instance, logging in again once if the session is invalid
+func (s *salesforceScaler) getJSON(ctx context.Context, path string, result interface{}) error {
+	err := s.doGetJSON(ctx, path, result)
+	if errors.Is(err, errSalesforceInvalidSession) {
+		s.resetToken()
+		err = s.doGetJSON(ctx, path, result)
+	}
+	return err
+}
+
+func (s *salesforceScaler) doGetJSON(ctx context.Context, path string, result interface{}) error {
+	token, err := s.getToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	instanceURL, _ := token.Extra("instance_url").(string)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(instanceURL, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	token.SetAuthHeader(req)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return json.Unmarshal(body, result)
+	case http.StatusUnauthorized:
+		return errSalesforceInvalidSession
+	default:
+		return fmt.Errorf("salesforce api returned %s: %s", resp.Status, body)
+	}
+}
+
+// getPendingJobCount returns the number of Bulk API 2.0 ingest jobs queued or in progress
+func (s *salesforceScaler) getPendingJobCount(ctx context.Context) (int64, error) {
+	var count int64
+	path := fmt.Sprintf("/services/data/v%s/jobs/ingest", s.metadata.apiVersion)
+	for path != "" {
+		var jobs salesforceJobsResponse
+		if err := s.getJSON(ctx, path, &jobs); err != nil {
+			return -1, err
+		}
+		for _, job := range jobs.Records {
+			if salesforcePendingJobStates[job.State] && (s.metadata.jobObject == "" || job.Object == s.metadata.jobObject) {
+				count++
+			}
+		}
+		path = jobs.NextRecordsURL
+	}
+	return count, nil
+}
+
+// getPlatformEventLag returns the largest difference between the replay ID of the last event published on the
+// platform event and the replay ID of the last event processed by its subscribers
+func (s *salesforceScaler) getPlatformEventLag(ctx context.Context) (int64, error) {
+	query := fmt.Sprintf("SELECT Name, Position, Tip FROM EventBusSubscriber WHERE Topic = '%s'", escapeSOQL(s.metadata.eventName))
+	if s.metadata.subscriberName != "" {
+		query += fmt.Sprintf(" AND Name = '%s'", escapeSOQL(s.metadata.subscriberName))
+	}
+
+	var result salesforceQueryResponse
+	if err := s.getJSON(ctx, fmt.Sprintf("/services/data/v%s/query?q=%s", s.metadata.apiVersion, url.QueryEscape(query)), &result); err != nil {
+		return -1, err
+	}
+	if len(result.Records) == 0 {
+		return -1, fmt.Errorf("no subscriber of platform event %s found", s.metadata.eventName)
+	}
+
+	var lag int64
+	for _, subscriber := range result.Records {
+		if subscriber.Tip-subscriber.Position > lag {
+			lag = subscriber.Tip - subscriber.Position
+		}
+	}
+	return lag, nil
+}
+
+func escapeSOQL(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+}
+
+func (s *salesforceScaler) getMetricValue(ctx context.Context) (int64, error) {
+	if s.metadata.scalingMode == salesforceScalingModePlatformEventLag {
+		return s.getPlatformEventLag(ctx)
+	}
+	return s.getPendingJobCount(ctx)
+}
+
+// IsActive determines if there are pending jobs or events to process
+func (s *salesforceScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting salesforce metric value")
+		return false, err
+	}
+
+	return float64(value) > s.metadata.activationTargetValue, nil
+}
+
+// Close does nothing in case of salesforceScaler
+func (s *salesforceScaler) Close(context.Context) error {
+	return nil
+}
+
+// GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
+func (s *salesforceScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	var metricName string
+	if s.metadata.scalingMode == salesforceScalingModePlatformEventLag {
+		metricName = fmt.Sprintf("salesforce-%s", s.metadata.eventName)
+	} else {
+		metricName = "salesforce-bulk-api-jobs"
+		if s.metadata.jobObject != "" {
+			metricName = fmt.Sprintf("%s-%s", metricName, s.metadata.jobObject)
+		}
+	}
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: salesforceMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+// GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
+func (s *salesforceScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting salesforce metric value")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(value))
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
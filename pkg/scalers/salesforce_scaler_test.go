@@ -0,0 +1,168 @@
+package scalers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type parseSalesforceMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type salesforceMetricIdentifier struct {
+	metadataTestData *parseSalesforceMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testSalesforceAuthParams = map[string]string{
+	"clientId":   "consumer-key",
+	"username":   "integration@example.com",
+	"privateKey": "private-key",
+}
+
+var testSalesforceMetadata = []parseSalesforceMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed bulk api jobs
+	{map[string]string{}, testSalesforceAuthParams, false},
+	// properly formed bulk api jobs of an object
+	{map[string]string{"scalingMode": "bulkApiJobs", "jobObject": "Account", "targetValue": "2", "activationTargetValue": "1", "apiVersion": "56.0", "loginURL": "https://test.salesforce.com/"}, testSalesforceAuthParams, false},
+	// properly formed platform event lag
+	{map[string]string{"scalingMode": "platformEventLag", "eventName": "Order_Event__e", "subscriberName": "OrderTrigger", "targetValue": "100"}, testSalesforceAuthParams, false},
+	// clientId and username in metadata
+	{map[string]string{"clientId": "consumer-key", "username": "integration@example.com"}, map[string]string{"privateKey": "private-key"}, false},
+	// missing clientId
+	{map[string]string{"username": "integration@example.com"}, map[string]string{"privateKey": "private-key"}, true},
+	// missing username
+	{map[string]string{"clientId": "consumer-key"}, map[string]string{"privateKey": "private-key"}, true},
+	// private key in metadata
+	{map[string]string{"clientId": "consumer-key", "username": "integration@example.com", "privateKey": "private-key"}, map[string]string{}, true},
+	// invalid apiVersion
+	{map[string]string{"apiVersion": "latest"}, testSalesforceAuthParams, true},
+	// invalid scalingMode
+	{map[string]string{"scalingMode": "cdcLag"}, testSalesforceAuthParams, true},
+	// platform event lag without eventName
+	{map[string]string{"scalingMode": "platformEventLag"}, testSalesforceAuthParams, true},
+	// invalid targetValue
+	{map[string]string{"targetValue": "A"}, testSalesforceAuthParams, true},
+	// invalid activationTargetValue
+	{map[string]string{"activationTargetValue": "A"}, testSalesforceAuthParams, true},
+	// invalid unsafeSsl
+	{map[string]string{"unsafeSsl": "A"}, testSalesforceAuthParams, true},
+}
+
+var salesforceMetricIdentifiers = []salesforceMetricIdentifier{
+	{&testSalesforceMetadata[1], 0, "s0-salesforce-bulk-api-jobs"},
+	{&testSalesforceMetadata[2], 1, "s1-salesforce-bulk-api-jobs-Account"},
+	{&testSalesforceMetadata[3], 2, "s2-salesforce-Order_Event__e"},
+}
+
+func TestSalesforceParseMetadata(t *testing.T) {
+	for _, testData := range testSalesforceMetadata {
+		_, err := parseSalesforceMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success. testData: %v", testData)
+		}
+	}
+}
+
+func TestSalesforceGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range salesforceMetricIdentifiers {
+		meta, err := parseSalesforceMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSalesforceScaler := salesforceScaler{metadata: meta}
+
+		metricSpec := mockSalesforceScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func newSalesforceTestServer(t *testing.T) (*httptest.Server, *int) {
+	logins := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/oauth2/token":
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.FormValue("grant_type"))
+			assert.NotEmpty(t, r.FormValue("assertion"))
+			logins++
+			fmt.Fprintf(w, `{"access_token": "token-%d", "instance_url": "%s", "token_type": "Bearer"}`, logins, server.URL)
+			return
+		}
+
+		// the first token is expired
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `[{"errorCode": "INVALID_SESSION_ID"}]`)
+			return
+		}
+
+		switch r.URL.RequestURI() {
+		case "/services/data/v55.0/jobs/ingest":
+			fmt.Fprint(w, `{"done": false, "records": [{"object": "Account", "state": "UploadComplete"}, {"object": "Contact", "state": "InProgress"}, {"object": "Account", "state": "Open"}], "nextRecordsUrl": "/services/data/v55.0/jobs/ingest?queryLocator=2"}`)
+		case "/services/data/v55.0/jobs/ingest?queryLocator=2":
+			fmt.Fprint(w, `{"done": true, "records": [{"object": "Account", "state": "InProgress"}, {"object": "Account", "state": "JobComplete"}], "nextRecordsUrl": null}`)
+		case "/services/data/v55.0/query?q=SELECT+Name%2C+Position%2C+Tip+FROM+EventBusSubscriber+WHERE+Topic+%3D+%27Order_Event__e%27":
+			fmt.Fprint(w, `{"records": [{"Name": "OrderTrigger", "Position": 90, "Tip": 100}, {"Name": "OrderFlow", "Position": 70, "Tip": 100}]}`)
+		default:
+			t.Error("Unexpected request", r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server, &logins
+}
+
+func TestSalesforceGetMetrics(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	tests := []struct {
+		metadata map[string]string
+		expected int64
+	}{
+		{map[string]string{}, 3},
+		{map[string]string{"jobObject": "Account"}, 2},
+		{map[string]string{"scalingMode": "platformEventLag", "eventName": "Order_Event__e"}, 30},
+	}
+	for _, test := range tests {
+		server, logins := newSalesforceTestServer(t)
+
+		test.metadata["loginURL"] = server.URL
+		s, err := NewSalesforceScaler(&ScalerConfig{
+			TriggerMetadata:   test.metadata,
+			AuthParams:        map[string]string{"clientId": "consumer-key", "username": "integration@example.com", "privateKey": string(privateKey)},
+			GlobalHTTPTimeout: 1000 * time.Millisecond,
+		})
+		assert.NoError(t, err)
+
+		metrics, err := s.GetMetrics(context.Background(), "s0-salesforce", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, metrics[0].Value.Value())
+		// the scaler logged in again when the first session was invalid
+		assert.Equal(t, 2, *logins)
+
+		server.Close()
+	}
+}
@@ -18,7 +18,11 @@ package scalers
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
+	"math"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +36,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 func init() {
@@ -64,6 +69,34 @@ type PushScaler interface {
 	Run(ctx context.Context, active chan<- bool)
 }
 
+// PushScalerStreamState is the state of the stream of a push scaler
+type PushScalerStreamState struct {
+	// ScalerIndex is the index of the trigger of the push scaler
+	ScalerIndex int
+	Connected   bool
+	// ConsecutiveFailures is the number of failed attempts to open the stream since it was last connected
+	ConsecutiveFailures int
+	// Err is the error of the last failed attempt
+	Err error
+}
+
+// StreamStateReporter is implemented by the push scalers reporting the state of their stream
+type StreamStateReporter interface {
+	PushScaler
+
+	// SetStreamStateHandler sets the function called when the stream connects or fails, it must be set before Run
+	SetStreamStateHandler(handler func(PushScalerStreamState))
+}
+
+// JobPriorityScaler interface
+type JobPriorityScaler interface {
+	Scaler
+
+	// GetJobPriorities returns the priorities of a sample of the pending work, which the ScaledJobs
+	// map to the priority class and deadline of the jobs they create
+	GetJobPriorities(ctx context.Context) ([]string, error)
+}
+
 // ScalerConfig contains config fields common for all scalers
 type ScalerConfig struct {
 	// ScalableObjectName specifies name of the ScaledObject/ScaledJob that owns this scaler
@@ -95,6 +128,67 @@ type ScalerConfig struct {
 
 	// MetricType
 	MetricType v2beta2.MetricTargetType
+
+	// EgressProxy is the gateway the traffic of the scaler goes through, set by the trigger authentication
+	EgressProxy *url.URL
+
+	// RootCAs are the CA certificates the HTTP clients of the scaler trust, set by the trigger authentication,
+	// nil for the ones of the cluster
+	RootCAs *x509.CertPool
+	// CustomCA are the PEM encoded CA certificates of the trigger authentication RootCAs is built from
+	CustomCA string
+
+	// RateEstimator keeps the samples of the backlog of the trigger across the scalers built for it and the
+	// restarts of KEDA, nil if the scaler isn't built by the ScaleHandler
+	RateEstimator *kedautil.RateEstimator
+
+	// QueryRecorder keeps the last query the scaler issued upstream, shared by the scalers built for the trigger,
+	// nil if the scaler isn't built by the ScaleHandler
+	QueryRecorder *QueryRecorder
+}
+
+const (
+	// UnsafeSslKey is the trigger metadata field used by all scalers supporting TLS to skip the verification
+	// of the server certificate
+	UnsafeSslKey     = "unsafeSsl"
+	defaultUnsafeSsl = false
+
+	// TimeoutKey is the trigger metadata field overriding the timeout of the requests of the scaler, in
+	// milliseconds, otherwise set by KEDA_HTTP_DEFAULT_TIMEOUT for all the triggers
+	TimeoutKey = "timeout"
+
+	// targetTimeToEmptyKey is the trigger metadata field of the queue scalers scaling on the estimated time
+	// to empty the backlog, in seconds
+	targetTimeToEmptyKey = "targetTimeToEmptySeconds"
+)
+
+// GetUnsafeSsl returns whether the trigger skips the verification of the server certificate
+func GetUnsafeSsl(triggerMetadata map[string]string) (bool, error) {
+	val, ok := triggerMetadata[UnsafeSslKey]
+	if !ok || val == "" {
+		return defaultUnsafeSsl, nil
+	}
+	unsafeSsl, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, fmt.Errorf("error parsing %s: %s", UnsafeSslKey, err)
+	}
+	return unsafeSsl, nil
+}
+
+// GetTimeout returns the timeout of the requests of the trigger, the global timeout unless its metadata overrides it
+func GetTimeout(triggerMetadata map[string]string, globalTimeout time.Duration) (time.Duration, error) {
+	val, ok := triggerMetadata[TimeoutKey]
+	if !ok || val == "" {
+		return globalTimeout, nil
+	}
+	timeoutMS, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing %s: %s", TimeoutKey, err)
+	}
+	if timeoutMS <= 0 {
+		return 0, fmt.Errorf("%s must be greater than 0, got %d", TimeoutKey, timeoutMS)
+	}
+	return time.Duration(timeoutMS) * time.Millisecond, nil
 }
 
 // GetFromAuthOrMeta helps getting a field from Auth or Meta sections
@@ -166,14 +260,15 @@ func GetMetricTarget(metricType v2beta2.MetricTargetType, metricValue int64) v2b
 	return target
 }
 
-// GetMetricTargetMili returns a metric target for a valid given metric target type (Value or AverageValue) and value in mili scale
+// GetMetricTargetMili returns a metric target for a valid given metric target type (Value or AverageValue) and value in mili scale,
+// which allows fractional targets like 0.5
 func GetMetricTargetMili(metricType v2beta2.MetricTargetType, metricValue float64) v2beta2.MetricTarget {
 	target := v2beta2.MetricTarget{
 		Type: metricType,
 	}
 
 	// Construct the target size as a quantity
-	metricValueMili := int64(metricValue * 1000)
+	metricValueMili := toMili(metricValue)
 	targetQty := resource.NewMilliQuantity(metricValueMili, resource.DecimalSI)
 	if metricType == v2beta2.AverageValueMetricType {
 		target.AverageValue = targetQty
@@ -186,10 +281,88 @@ func GetMetricTargetMili(metricType v2beta2.MetricTargetType, metricValue float6
 
 // GenerateMetricInMili returns a externalMetricValue with mili as metric scale
 func GenerateMetricInMili(metricName string, value float64) external_metrics.ExternalMetricValue {
-	valueMili := int64(value * 1000)
+	valueMili := toMili(value)
 	return external_metrics.ExternalMetricValue{
 		MetricName: metricName,
 		Value:      *resource.NewMilliQuantity(valueMili, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 }
+
+// toMili converts the value to mili scale, rounding it as values like 1.005 aren't exact floats
+func toMili(value float64) int64 {
+	return int64(math.Round(value * 1000))
+}
+
+// GetSmoothingEWMA returns the moving average used to smooth the reported metric over the number of
+// samples given by the smoothingWindow metadata, or nil if smoothing is not enabled.
+// The average is kept by the scaler instance, so it starts over from the next sample whenever the scaler
+// is rebuilt, e.g. after the scalable object changed or the scaler failed, and the operator and the metrics
+// server each keep their own. Only the metric reported to the HPA is smoothed: the activation compares the
+// raw value, so the first messages activate the workload and an empty queue deactivates it right away.
+func GetSmoothingEWMA(config *ScalerConfig) (*kedautil.EWMA, error) {
+	val, ok := config.TriggerMetadata["smoothingWindow"]
+	if !ok || val == "" {
+		return nil, nil
+	}
+
+	window, err := strconv.Atoi(val)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing smoothingWindow: %s", err)
+	}
+	if window < 1 {
+		return nil, fmt.Errorf("smoothingWindow must be a positive number")
+	}
+	return kedautil.NewEWMA(window), nil
+}
+
+// smoothMetricValue adds the value to the moving average, if any, and returns the smoothed value
+func smoothMetricValue(ewma *kedautil.EWMA, value float64) float64 {
+	if ewma == nil {
+		return value
+	}
+	return ewma.Add(value)
+}
+
+// GetTimeToEmpty returns the estimator of the time to empty the backlog used to scale on the targetTimeToEmptySeconds
+// metadata, or nil if it is not given
+func GetTimeToEmpty(config *ScalerConfig) (*kedautil.TimeToEmpty, error) {
+	val, ok := config.TriggerMetadata[targetTimeToEmptyKey]
+	if !ok || val == "" {
+		return nil, nil
+	}
+
+	target, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %s", targetTimeToEmptyKey, err)
+	}
+	if target <= 0 {
+		return nil, fmt.Errorf("%s must be a positive number", targetTimeToEmptyKey)
+	}
+	// the jobs are created for the backlog, and the time to empty is scaled with a Value target
+	if config.ScalableObjectType == "ScaledJob" {
+		return nil, fmt.Errorf("%s isn't supported for ScaledJobs", targetTimeToEmptyKey)
+	}
+	if config.MetricType != "" && config.MetricType != v2beta2.ValueMetricType {
+		return nil, fmt.Errorf("%s is only supported with the %s metric type", targetTimeToEmptyKey, v2beta2.ValueMetricType)
+	}
+	return kedautil.NewTimeToEmpty(target, config.RateEstimator), nil
+}
+
+// timeToEmptyMetricValue adds the backlog to the estimator, if any, and returns the estimated time to empty it
+func timeToEmptyMetricValue(tte *kedautil.TimeToEmpty, backlog float64) float64 {
+	if tte == nil {
+		return backlog
+	}
+	return tte.Add(backlog, time.Now())
+}
+
+// timeToEmptyMetricTarget returns the target time to empty if the estimator is given, the target value otherwise.
+// The HPA scales the replicas by the ratio of a Value metric to its target, so the replicas are scaled by the
+// ratio of the estimated time to empty to the target one, at the processing rate per replica observed so far
+func timeToEmptyMetricTarget(tte *kedautil.TimeToEmpty, metricType v2beta2.MetricTargetType, targetValue float64) v2beta2.MetricTarget {
+	if tte == nil {
+		return GetMetricTargetMili(metricType, targetValue)
+	}
+	return GetMetricTargetMili(v2beta2.ValueMetricType, tte.Target())
+}
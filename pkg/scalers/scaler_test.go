@@ -3,6 +3,7 @@ package scalers
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
@@ -86,6 +87,98 @@ func TestGetMetricTarget(t *testing.T) {
 	}
 }
 
+func TestGetMetricTargetMili(t *testing.T) {
+	cases := []struct {
+		name             string
+		metricType       v2beta2.MetricTargetType
+		metricValue      float64
+		wantmetricTarget v2beta2.MetricTarget
+	}{
+		{
+			name:             "fractional average value",
+			metricType:       v2beta2.AverageValueMetricType,
+			metricValue:      0.5,
+			wantmetricTarget: v2beta2.MetricTarget{Type: v2beta2.AverageValueMetricType, AverageValue: resource.NewMilliQuantity(500, resource.DecimalSI)},
+		},
+		{
+			name:             "inexact fractional value",
+			metricType:       v2beta2.ValueMetricType,
+			metricValue:      1.005,
+			wantmetricTarget: v2beta2.MetricTarget{Type: v2beta2.ValueMetricType, Value: resource.NewMilliQuantity(1005, resource.DecimalSI)},
+		},
+		{
+			name:             "whole value",
+			metricType:       v2beta2.AverageValueMetricType,
+			metricValue:      20,
+			wantmetricTarget: v2beta2.MetricTarget{Type: v2beta2.AverageValueMetricType, AverageValue: resource.NewMilliQuantity(20000, resource.DecimalSI)},
+		},
+	}
+
+	for _, testCase := range cases {
+		c := testCase
+		t.Run(c.name, func(t *testing.T) {
+			metricTarget := GetMetricTargetMili(c.metricType, c.metricValue)
+			assert.Equal(t, c.wantmetricTarget, metricTarget)
+		})
+	}
+}
+
+func TestGetUnsafeSsl(t *testing.T) {
+	cases := []struct {
+		name     string
+		metadata map[string]string
+		want     bool
+		wantErr  bool
+	}{
+		{name: "not set", metadata: map[string]string{}, want: false},
+		{name: "empty", metadata: map[string]string{"unsafeSsl": ""}, want: false},
+		{name: "enabled", metadata: map[string]string{"unsafeSsl": "true"}, want: true},
+		{name: "disabled", metadata: map[string]string{"unsafeSsl": "false"}, want: false},
+		{name: "invalid", metadata: map[string]string{"unsafeSsl": "yes"}, wantErr: true},
+	}
+
+	for _, testCase := range cases {
+		c := testCase
+		t.Run(c.name, func(t *testing.T) {
+			unsafeSsl, err := GetUnsafeSsl(c.metadata)
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, c.want, unsafeSsl)
+		})
+	}
+}
+
+func TestGetTimeout(t *testing.T) {
+	cases := []struct {
+		name     string
+		metadata map[string]string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{name: "not set", metadata: map[string]string{}, want: 3 * time.Second},
+		{name: "empty", metadata: map[string]string{"timeout": ""}, want: 3 * time.Second},
+		{name: "overridden", metadata: map[string]string{"timeout": "15000"}, want: 15 * time.Second},
+		{name: "zero", metadata: map[string]string{"timeout": "0"}, wantErr: true},
+		{name: "invalid", metadata: map[string]string{"timeout": "15s"}, wantErr: true},
+	}
+
+	for _, testCase := range cases {
+		c := testCase
+		t.Run(c.name, func(t *testing.T) {
+			timeout, err := GetTimeout(c.metadata, 3*time.Second)
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, c.want, timeout)
+		})
+	}
+}
+
 func TestRemoveIndexFromMetricName(t *testing.T) {
 	cases := []struct {
 		scalerIndex                          int
@@ -122,3 +215,88 @@ func TestRemoveIndexFromMetricName(t *testing.T) {
 		}
 	}
 }
+
+func TestGetSmoothingEWMA(t *testing.T) {
+	testCases := []struct {
+		metadata map[string]string
+		enabled  bool
+		isError  bool
+	}{
+		{map[string]string{}, false, false},
+		{map[string]string{"smoothingWindow": ""}, false, false},
+		{map[string]string{"smoothingWindow": "5"}, true, false},
+		{map[string]string{"smoothingWindow": "0"}, false, true},
+		{map[string]string{"smoothingWindow": "five"}, false, true},
+	}
+	for _, tc := range testCases {
+		ewma, err := GetSmoothingEWMA(&ScalerConfig{TriggerMetadata: tc.metadata})
+		if err != nil && !tc.isError {
+			t.Errorf("Expected success for %v but got error %s", tc.metadata, err)
+		}
+		if tc.isError && err == nil {
+			t.Errorf("Expected error for %v but got success", tc.metadata)
+		}
+		if (ewma != nil) != tc.enabled {
+			t.Errorf("Expected smoothing enabled %v for %v", tc.enabled, tc.metadata)
+		}
+	}
+}
+
+func TestSmoothMetricValue(t *testing.T) {
+	if value := smoothMetricValue(nil, 42); value != 42 {
+		t.Errorf("Expected value to be reported as is without smoothing but got %v", value)
+	}
+
+	ewma, _ := GetSmoothingEWMA(&ScalerConfig{TriggerMetadata: map[string]string{"smoothingWindow": "3"}})
+	smoothMetricValue(ewma, 0)
+	if value := smoothMetricValue(ewma, 100); value != 50 {
+		t.Errorf("Expected smoothed value 50 but got %v", value)
+	}
+}
+
+func TestGetTimeToEmpty(t *testing.T) {
+	testCases := []struct {
+		config  *ScalerConfig
+		enabled bool
+		isError bool
+	}{
+		{&ScalerConfig{TriggerMetadata: map[string]string{}}, false, false},
+		{&ScalerConfig{TriggerMetadata: map[string]string{"targetTimeToEmptySeconds": ""}}, false, false},
+		{&ScalerConfig{TriggerMetadata: map[string]string{"targetTimeToEmptySeconds": "120"}}, true, false},
+		{&ScalerConfig{TriggerMetadata: map[string]string{"targetTimeToEmptySeconds": "120"}, MetricType: v2beta2.ValueMetricType}, true, false},
+		{&ScalerConfig{TriggerMetadata: map[string]string{"targetTimeToEmptySeconds": "120"}, MetricType: v2beta2.AverageValueMetricType}, false, true},
+		{&ScalerConfig{TriggerMetadata: map[string]string{"targetTimeToEmptySeconds": "120"}, ScalableObjectType: "ScaledJob"}, false, true},
+		{&ScalerConfig{TriggerMetadata: map[string]string{"targetTimeToEmptySeconds": "0"}}, false, true},
+		{&ScalerConfig{TriggerMetadata: map[string]string{"targetTimeToEmptySeconds": "two minutes"}}, false, true},
+	}
+	for _, tc := range testCases {
+		tte, err := GetTimeToEmpty(tc.config)
+		if err != nil && !tc.isError {
+			t.Errorf("Expected success for %v but got error %s", tc.config.TriggerMetadata, err)
+		}
+		if tc.isError && err == nil {
+			t.Errorf("Expected error for %v but got success", tc.config.TriggerMetadata)
+		}
+		if (tte != nil) != tc.enabled {
+			t.Errorf("Expected time to empty enabled %v for %v", tc.enabled, tc.config.TriggerMetadata)
+		}
+	}
+}
+
+func TestTimeToEmptyMetricTarget(t *testing.T) {
+	target := timeToEmptyMetricTarget(nil, v2beta2.AverageValueMetricType, 5)
+	assert.Equal(t, v2beta2.AverageValueMetricType, target.Type)
+	assert.Equal(t, int64(5), target.AverageValue.Value())
+
+	tte, _ := GetTimeToEmpty(&ScalerConfig{TriggerMetadata: map[string]string{"targetTimeToEmptySeconds": "120"}})
+	target = timeToEmptyMetricTarget(tte, v2beta2.AverageValueMetricType, 5)
+	assert.Equal(t, v2beta2.ValueMetricType, target.Type)
+	assert.Equal(t, int64(120), target.Value.Value())
+
+	if value := timeToEmptyMetricValue(nil, 42); value != 42 {
+		t.Errorf("Expected value to be reported as is without time to empty but got %v", value)
+	}
+	if value := timeToEmptyMetricValue(tte, 42); value != 120 {
+		t.Errorf("Expected the target time to empty for the first sample but got %v", value)
+	}
+}
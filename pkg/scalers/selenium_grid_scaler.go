@@ -34,6 +34,7 @@ type seleniumGridScalerMetadata struct {
 	targetValue         int64
 	activationThreshold int64
 	browserVersion      string
+	platformName        string
 	unsafeSsl           bool
 	scalerIndex         int
 }
@@ -66,10 +67,13 @@ type seleniumSession struct {
 type capability struct {
 	BrowserName    string `json:"browserName"`
 	BrowserVersion string `json:"browserVersion"`
+	PlatformName   string `json:"platformName"`
 }
 
 const (
 	DefaultBrowserVersion string = "latest"
+	// anyPlatformName is the platformName of the requests which can run on any platform
+	anyPlatformName string = "ANY"
 )
 
 func NewSeleniumGridScaler(config *ScalerConfig) (Scaler, error) {
@@ -86,7 +90,7 @@ func NewSeleniumGridScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing selenium grid metadata: %s", err)
 	}
 
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, meta.unsafeSsl)
+	httpClient := createHTTPClient(config, meta.unsafeSsl)
 
 	return &seleniumGridScaler{
 		metricType: metricType,
@@ -123,7 +127,7 @@ func parseSeleniumGridScalerMetadata(config *ScalerConfig) (*seleniumGridScalerM
 	if val, ok := config.TriggerMetadata["activationThreshold"]; ok {
 		activationThreshold, err := strconv.ParseInt(val, 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing unsafeSsl: %s", err)
+			return nil, fmt.Errorf("error parsing activationThreshold: %s", err)
 		}
 		meta.activationThreshold = activationThreshold
 	}
@@ -134,13 +138,14 @@ func parseSeleniumGridScalerMetadata(config *ScalerConfig) (*seleniumGridScalerM
 		meta.browserVersion = DefaultBrowserVersion
 	}
 
-	if val, ok := config.TriggerMetadata["unsafeSsl"]; ok {
-		parsedVal, err := strconv.ParseBool(val)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing unsafeSsl: %s", err)
-		}
-		meta.unsafeSsl = parsedVal
+	// the platform of the nodes, to only count the sessions of their node deployment when several platforms are scaled
+	meta.platformName = config.TriggerMetadata["platformName"]
+
+	unsafeSsl, err := GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
 	}
+	meta.unsafeSsl = unsafeSsl
 
 	meta.scalerIndex = config.ScalerIndex
 	return &meta, nil
@@ -163,7 +168,11 @@ func (s *seleniumGridScaler) GetMetrics(ctx context.Context, metricName string,
 }
 
 func (s *seleniumGridScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
-	metricName := kedautil.NormalizeString(fmt.Sprintf("seleniumgrid-%s", s.metadata.browserName))
+	metricName := fmt.Sprintf("seleniumgrid-%s", s.metadata.browserName)
+	if s.metadata.platformName != "" {
+		metricName = fmt.Sprintf("%s-%s", metricName, s.metadata.platformName)
+	}
+	metricName = kedautil.NormalizeString(metricName)
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
 			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, metricName),
@@ -214,14 +223,22 @@ func (s *seleniumGridScaler) getSessionsCount(ctx context.Context, logger logr.L
 	if err != nil {
 		return -1, err
 	}
-	v, err := getCountFromSeleniumResponse(b, s.metadata.browserName, s.metadata.browserVersion, s.metadata.sessionBrowserName, logger)
+	v, err := getCountFromSeleniumResponse(b, s.metadata.browserName, s.metadata.browserVersion, s.metadata.sessionBrowserName, s.metadata.platformName, logger)
 	if err != nil {
 		return -1, err
 	}
 	return v, nil
 }
 
-func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion string, sessionBrowserName string, logger logr.Logger) (int64, error) {
+// seleniumPlatformMatches returns true if the capability can run on the platform, any platform matching if it isn't set
+func seleniumPlatformMatches(capability capability, platformName string) bool {
+	if platformName == "" || capability.PlatformName == "" || strings.EqualFold(capability.PlatformName, anyPlatformName) {
+		return true
+	}
+	return strings.EqualFold(capability.PlatformName, platformName)
+}
+
+func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion string, sessionBrowserName string, platformName string, logger logr.Logger) (int64, error) {
 	var count int64
 	var seleniumResponse = seleniumResponse{}
 
@@ -233,7 +250,7 @@ func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion s
 	for _, sessionQueueRequest := range sessionQueueRequests {
 		var capability = capability{}
 		if err := json.Unmarshal([]byte(sessionQueueRequest), &capability); err == nil {
-			if capability.BrowserName == browserName {
+			if capability.BrowserName == browserName && seleniumPlatformMatches(capability, platformName) {
 				if strings.HasPrefix(capability.BrowserVersion, browserVersion) {
 					count++
 				} else if browserVersion == DefaultBrowserVersion {
@@ -249,7 +266,7 @@ func getCountFromSeleniumResponse(b []byte, browserName string, browserVersion s
 	for _, session := range sessions {
 		var capability = capability{}
 		if err := json.Unmarshal([]byte(session.Capabilities), &capability); err == nil {
-			if capability.BrowserName == sessionBrowserName {
+			if capability.BrowserName == sessionBrowserName && seleniumPlatformMatches(capability, platformName) {
 				if strings.HasPrefix(capability.BrowserVersion, browserVersion) {
 					count++
 				} else if browserVersion == DefaultBrowserVersion {
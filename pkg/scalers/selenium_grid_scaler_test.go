@@ -13,6 +13,7 @@ func Test_getCountFromSeleniumResponse(t *testing.T) {
 		browserName        string
 		sessionBrowserName string
 		browserVersion     string
+		platformName       string
 	}
 	tests := []struct {
 		name    string
@@ -319,10 +320,44 @@ func Test_getCountFromSeleniumResponse(t *testing.T) {
 			want:    1,
 			wantErr: false,
 		},
+		{
+			name: "sessions of other platforms should not be counted when platformName is given",
+			args: args{
+				b: []byte(`{
+					"data": {
+						"grid":{
+							"maxSession": 1,
+							"nodeCount": 1
+						},
+						"sessionsInfo": {
+							"sessionQueueRequests": ["{\n  \"browserName\": \"chrome\",\n  \"platformName\": \"Windows 11\"\n}","{\n  \"browserName\": \"chrome\",\n  \"platformName\": \"linux\"\n}","{\n  \"browserName\": \"chrome\",\n  \"platformName\": \"ANY\"\n}","{\n  \"browserName\": \"chrome\"\n}"],
+							"sessions": [
+								{
+									"id": "0f9c5a941aa4d755a54b84be1f6535b1",
+									"capabilities": "{\n  \"browserName\": \"chrome\",\n  \"browserVersion\": \"91.0.4472.114\",\n  \"platformName\": \"windows\"\n}",
+									"nodeId": "d44dcbc5-0b2c-4d5e-abf4-6f6aa5e0983c"
+								},
+								{
+									"id": "0f9c5a941aa4d755a54b84be1f6535b2",
+									"capabilities": "{\n  \"browserName\": \"chrome\",\n  \"browserVersion\": \"91.0.4472.114\",\n  \"platformName\": \"LINUX\"\n}",
+									"nodeId": "d44dcbc5-0b2c-4d5e-abf4-6f6aa5e0983d"
+								}
+							]
+						}
+					}
+				}`),
+				browserName:        "chrome",
+				sessionBrowserName: "chrome",
+				browserVersion:     "latest",
+				platformName:       "linux",
+			},
+			want:    4,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := getCountFromSeleniumResponse(tt.args.b, tt.args.browserName, tt.args.browserVersion, tt.args.sessionBrowserName, logr.Discard())
+			got, err := getCountFromSeleniumResponse(tt.args.b, tt.args.browserName, tt.args.browserVersion, tt.args.sessionBrowserName, tt.args.platformName, logr.Discard())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getCountFromSeleniumResponse() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -425,6 +460,27 @@ func Test_parseSeleniumGridScalerMetadata(t *testing.T) {
 				unsafeSsl:          false,
 			},
 		},
+		{
+			name: "valid url, browsername and platformName should return metadata",
+			args: args{
+				config: &ScalerConfig{
+					TriggerMetadata: map[string]string{
+						"url":          "http://selenium-hub:4444/graphql",
+						"browserName":  "chrome",
+						"platformName": "linux",
+					},
+				},
+			},
+			wantErr: false,
+			want: &seleniumGridScalerMetadata{
+				url:                "http://selenium-hub:4444/graphql",
+				browserName:        "chrome",
+				sessionBrowserName: "chrome",
+				targetValue:        1,
+				browserVersion:     "latest",
+				platformName:       "linux",
+			},
+		},
 		{
 			name: "valid url, browsername, unsafeSsl and activationThreshold should return metadata",
 			args: args{
@@ -0,0 +1,292 @@
+package scalers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	signedHTTPMetricType             = "External"
+	defaultSignedHTTPSignatureHeader = "X-Signature"
+	// defaultSignedHTTPSignedContent signs the request line, the timestamp and the body
+	defaultSignedHTTPSignedContent = "{method}\n{path}\n{timestamp}\n{body}"
+	defaultSignedHTTPHeaderFormat  = "{signature}"
+)
+
+type signedHTTPScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *signedHTTPMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type signedHTTPMetadata struct {
+	url                   string
+	method                string
+	body                  string
+	valueLocation         string
+	targetValue           float64
+	activationTargetValue float64
+
+	// signing
+	secret             string
+	signatureAlgorithm string
+	signatureEncoding  string
+	signatureHeader    string
+	// headerFormat is the value of the signature header, with the {signature} and {timestamp} placeholders
+	headerFormat string
+	// signedContent is the signed payload, with the {method}, {path}, {timestamp} and {body} placeholders
+	signedContent   string
+	timestampHeader string
+
+	unsafeSsl   bool
+	scalerIndex int
+}
+
+// NewSignedHTTPScaler creates a new signedHTTPScaler
+func NewSignedHTTPScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseSignedHTTPMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing signed http metadata: %s", err)
+	}
+
+	return &signedHTTPScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: createHTTPClient(config, meta.unsafeSsl),
+		logger:     InitializeLogger(config, "signed_http_scaler"),
+	}, nil
+}
+
+func parseSignedHTTPMetadata(config *ScalerConfig) (*signedHTTPMetadata, error) {
+	meta := signedHTTPMetadata{}
+	var err error
+
+	meta.url, err = getParameterFromConfig(config, "url", false)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.method = http.MethodGet
+	if val, ok := config.TriggerMetadata["method"]; ok && val != "" {
+		meta.method = strings.ToUpper(val)
+		if meta.method != http.MethodGet && meta.method != http.MethodPost {
+			return nil, fmt.Errorf("method must be one of GET, POST but is %s", val)
+		}
+	}
+	meta.body = config.TriggerMetadata["body"]
+	if meta.body != "" && meta.method != http.MethodPost {
+		return nil, fmt.Errorf("body is only supported with the POST method")
+	}
+
+	meta.valueLocation, err = getParameterFromConfig(config, "valueLocation", false)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := getParameterFromConfig(config, "targetValue", false)
+	if err != nil {
+		return nil, err
+	}
+	meta.targetValue, err = strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing targetValue: %s", err)
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok && val != "" {
+		meta.activationTargetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetValue: %s", err)
+		}
+	}
+
+	// the secret is only read from the authentication, to keep it out of the ScaledObject
+	meta.secret = config.AuthParams["secret"]
+	if meta.secret == "" {
+		return nil, fmt.Errorf("no secret given")
+	}
+
+	meta.signatureAlgorithm = "sha256"
+	if val, ok := config.TriggerMetadata["signatureAlgorithm"]; ok && val != "" {
+		if getSignedHTTPHash(val) == nil {
+			return nil, fmt.Errorf("signatureAlgorithm must be one of sha1, sha256, sha512 but is %s", val)
+		}
+		meta.signatureAlgorithm = val
+	}
+
+	meta.signatureEncoding = "hex"
+	if val, ok := config.TriggerMetadata["signatureEncoding"]; ok && val != "" {
+		if val != "hex" && val != "base64" {
+			return nil, fmt.Errorf("signatureEncoding must be one of hex, base64 but is %s", val)
+		}
+		meta.signatureEncoding = val
+	}
+
+	meta.signatureHeader = defaultSignedHTTPSignatureHeader
+	if val, ok := config.TriggerMetadata["signatureHeader"]; ok && val != "" {
+		meta.signatureHeader = val
+	}
+
+	meta.headerFormat = defaultSignedHTTPHeaderFormat
+	if val, ok := config.TriggerMetadata["signatureHeaderFormat"]; ok && val != "" {
+		if !strings.Contains(val, "{signature}") {
+			return nil, fmt.Errorf("signatureHeaderFormat must contain {signature}")
+		}
+		meta.headerFormat = val
+	}
+
+	meta.signedContent = defaultSignedHTTPSignedContent
+	if val, ok := config.TriggerMetadata["signedContent"]; ok && val != "" {
+		meta.signedContent = val
+	}
+
+	meta.timestampHeader = config.TriggerMetadata["timestampHeader"]
+
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func getSignedHTTPHash(algorithm string) func() hash.Hash {
+	switch algorithm {
+	case "sha1":
+		return sha1.New
+	case "sha256":
+		return sha256.New
+	case "sha512":
+		return sha512.New
+	}
+	return nil
+}
+
+// sign returns the signature of the request, encoded as configured
+func (m *signedHTTPMetadata) sign(method, path, timestamp, body string) string {
+	content := strings.NewReplacer(
+		"{method}", method,
+		"{path}", path,
+		"{timestamp}", timestamp,
+		"{body}", body,
+	).Replace(m.signedContent)
+
+	mac := hmac.New(getSignedHTTPHash(m.signatureAlgorithm), []byte(m.secret))
+	mac.Write([]byte(content))
+	if m.signatureEncoding == "base64" {
+		return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *signedHTTPScaler) newSignedRequest(ctx context.Context, now time.Time) (*http.Request, error) {
+	var body io.Reader
+	if s.metadata.body != "" {
+		body = strings.NewReader(s.metadata.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, s.metadata.method, s.metadata.url, body)
+	if err != nil {
+		return nil, err
+	}
+	if s.metadata.body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := s.metadata.sign(s.metadata.method, req.URL.RequestURI(), timestamp, s.metadata.body)
+	req.Header.Set(s.metadata.signatureHeader, strings.NewReplacer(
+		"{signature}", signature,
+		"{timestamp}", timestamp,
+	).Replace(s.metadata.headerFormat))
+	if s.metadata.timestampHeader != "" {
+		req.Header.Set(s.metadata.timestampHeader, timestamp)
+	}
+	return req, nil
+}
+
+func (s *signedHTTPScaler) getMetricValue(ctx context.Context) (float64, error) {
+	req, err := s.newSignedRequest(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s: api returned %d", req.URL.Path, resp.StatusCode)
+	}
+
+	return GetValueFromResponse(body, s.metadata.valueLocation)
+}
+
+func (s *signedHTTPScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error requesting signed http endpoint")
+		return false, err
+	}
+
+	return value > s.metadata.activationTargetValue, nil
+}
+
+func (s *signedHTTPScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *signedHTTPScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("signed-http-%s", s.metadata.valueLocation))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: signedHTTPMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *signedHTTPScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getMetricValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error requesting signed http endpoint")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
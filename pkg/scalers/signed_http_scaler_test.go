@@ -0,0 +1,150 @@
+package scalers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+type parseSignedHTTPMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type signedHTTPMetricIdentifier struct {
+	metadataTestData *parseSignedHTTPMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testSignedHTTPAuthParams = map[string]string{"secret": "whsec"}
+
+var testParseSignedHTTPMetadata = []parseSignedHTTPMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count", "targetValue": "10"}, testSignedHTTPAuthParams, false},
+	// properly formed with all the optional parameters
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "pending", "targetValue": "10", "activationTargetValue": "1", "method": "post", "body": `{"queue":"events"}`, "signatureAlgorithm": "sha512", "signatureEncoding": "base64", "signatureHeader": "Provider-Signature", "signatureHeaderFormat": "t={timestamp},v1={signature}", "signedContent": "{timestamp}.{body}", "timestampHeader": "X-Timestamp", "unsafeSsl": "true"}, testSignedHTTPAuthParams, false},
+	// missing url
+	{map[string]string{"valueLocation": "backlog.count", "targetValue": "10"}, testSignedHTTPAuthParams, true},
+	// missing valueLocation
+	{map[string]string{"url": "https://api.example.com/backlog", "targetValue": "10"}, testSignedHTTPAuthParams, true},
+	// missing targetValue
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count"}, testSignedHTTPAuthParams, true},
+	// invalid targetValue
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count", "targetValue": "A"}, testSignedHTTPAuthParams, true},
+	// invalid activationTargetValue
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count", "targetValue": "10", "activationTargetValue": "A"}, testSignedHTTPAuthParams, true},
+	// missing secret
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count", "targetValue": "10"}, map[string]string{}, true},
+	// secret in metadata
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count", "targetValue": "10", "secret": "whsec"}, map[string]string{}, true},
+	// invalid method
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count", "targetValue": "10", "method": "PUT"}, testSignedHTTPAuthParams, true},
+	// body with GET
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count", "targetValue": "10", "body": "{}"}, testSignedHTTPAuthParams, true},
+	// invalid signatureAlgorithm
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count", "targetValue": "10", "signatureAlgorithm": "md5"}, testSignedHTTPAuthParams, true},
+	// invalid signatureEncoding
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count", "targetValue": "10", "signatureEncoding": "base32"}, testSignedHTTPAuthParams, true},
+	// signatureHeaderFormat without signature
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count", "targetValue": "10", "signatureHeaderFormat": "t={timestamp}"}, testSignedHTTPAuthParams, true},
+	// invalid unsafeSsl
+	{map[string]string{"url": "https://api.example.com/backlog", "valueLocation": "backlog.count", "targetValue": "10", "unsafeSsl": "A"}, testSignedHTTPAuthParams, true},
+}
+
+var signedHTTPMetricIdentifiers = []signedHTTPMetricIdentifier{
+	{&testParseSignedHTTPMetadata[1], 0, "s0-signed-http-backlog-count"},
+	{&testParseSignedHTTPMetadata[2], 1, "s1-signed-http-pending"},
+}
+
+func TestSignedHTTPParseMetadata(t *testing.T) {
+	for _, testData := range testParseSignedHTTPMetadata {
+		_, err := parseSignedHTTPMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success. testData: %v", testData)
+		}
+	}
+}
+
+func TestSignedHTTPGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range signedHTTPMetricIdentifiers {
+		meta, err := parseSignedHTTPMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata,
+			AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSignedHTTPScaler := signedHTTPScaler{metadata: meta, logger: logr.Discard()}
+
+		metricSpec := mockSignedHTTPScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestSignedHTTPGetMetricValue(t *testing.T) {
+	now := time.Now()
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// verify the signature the way a provider would
+		timestamp := request.Header.Get("X-Timestamp")
+		mac := hmac.New(sha256.New, []byte("whsec"))
+		mac.Write([]byte(fmt.Sprintf("%s.%s.%s", timestamp, request.URL.RequestURI(), body)))
+		expected := fmt.Sprintf("t=%s,v1=%s", timestamp, base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+		if request.Header.Get("Provider-Signature") != expected {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		writer.WriteHeader(http.StatusOK)
+		if _, err := writer.Write([]byte(`{"backlog":{"count":42}}`)); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	meta, err := parseSignedHTTPMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"url": server.URL + "/backlog?queue=events", "valueLocation": "backlog.count", "targetValue": "10",
+			"method": "POST", "body": `{"queue":"events"}`, "signatureEncoding": "base64", "signatureHeader": "Provider-Signature",
+			"signatureHeaderFormat": "t={timestamp},v1={signature}", "signedContent": "{timestamp}.{path}.{body}", "timestampHeader": "X-Timestamp"},
+		AuthParams: testSignedHTTPAuthParams,
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := signedHTTPScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+	value, err := scaler.getMetricValue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, float64(42), value)
+
+	// a wrong secret is rejected by the endpoint
+	meta.secret = "wrong"
+	_, err = scaler.getMetricValue(context.Background())
+	assert.Error(t, err)
+
+	req, err := scaler.newSignedRequest(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprint(now.Unix()), req.Header.Get("X-Timestamp"))
+}
@@ -81,6 +81,8 @@ type SolaceMetadata struct {
 	// Activation Target Message Count
 	activationMsgCountTarget      int
 	activationMsgSpoolUsageTarget int // Spool Use Target in Megabytes
+	// Skip the verification of the SEMP server certificate
+	unsafeSsl bool
 	// Scaler index
 	scalerIndex int
 }
@@ -114,9 +116,6 @@ type solaceSEMPMetadata struct {
 
 // Constructor for SolaceScaler
 func NewSolaceScaler(config *ScalerConfig) (Scaler, error) {
-	// Create HTTP Client
-	httpClient := kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false)
-
 	metricType, err := GetMetricTargetType(config)
 	if err != nil {
 		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
@@ -131,6 +130,9 @@ func NewSolaceScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, err
 	}
 
+	// Create HTTP Client
+	httpClient := createHTTPClient(config, solaceMetadata.unsafeSsl)
+
 	return &SolaceScaler{
 		metricType: metricType,
 		metadata:   solaceMetadata,
@@ -220,6 +222,10 @@ func parseSolaceMetadata(config *ScalerConfig) (*SolaceMetadata, error) {
 		return nil, e
 	}
 
+	if meta.unsafeSsl, e = GetUnsafeSsl(config.TriggerMetadata); e != nil {
+		return nil, e
+	}
+
 	meta.scalerIndex = config.ScalerIndex
 
 	return &meta, nil
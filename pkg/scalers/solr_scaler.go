@@ -0,0 +1,257 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	solrMetricType        = "External"
+	defaultSolrQuery      = "*:*"
+	defaultSolrStatsValue = "sum"
+)
+
+var solrStatsValues = map[string]bool{"min": true, "max": true, "sum": true, "count": true, "missing": true, "mean": true}
+
+type solrScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *solrMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type solrMetadata struct {
+	host                       string
+	collection                 string
+	query                      string
+	filterQuery                string
+	statsField                 string
+	statsValue                 string
+	targetQueryValue           float64
+	activationTargetQueryValue float64
+	metricName                 string
+	scalerIndex                int
+
+	// Auth
+	username string
+	password string
+
+	unsafeSsl bool
+}
+
+type solrSelectResponse struct {
+	Response struct {
+		NumFound int64 `json:"numFound"`
+	} `json:"response"`
+	Stats struct {
+		StatsFields map[string]map[string]interface{} `json:"stats_fields"`
+	} `json:"stats"`
+}
+
+// NewSolrScaler creates a new solrScaler
+func NewSolrScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseSolrMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing solr metadata: %s", err)
+	}
+
+	return &solrScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: createHTTPClient(config, meta.unsafeSsl),
+		logger:     InitializeLogger(config, "solr_scaler"),
+	}, nil
+}
+
+func parseSolrMetadata(config *ScalerConfig) (*solrMetadata, error) {
+	meta := solrMetadata{}
+	var err error
+
+	meta.host, err = GetFromAuthOrMeta(config, "host")
+	if err != nil {
+		return nil, err
+	}
+	meta.host = strings.TrimSuffix(meta.host, "/")
+
+	meta.collection, err = GetFromAuthOrMeta(config, "collection")
+	if err != nil {
+		return nil, err
+	}
+
+	meta.query = defaultSolrQuery
+	if val, ok := config.TriggerMetadata["query"]; ok && val != "" {
+		meta.query = val
+	}
+	meta.filterQuery = config.TriggerMetadata["filterQuery"]
+
+	meta.statsField = config.TriggerMetadata["statsField"]
+	meta.statsValue = defaultSolrStatsValue
+	if val, ok := config.TriggerMetadata["statsValue"]; ok && val != "" {
+		if meta.statsField == "" {
+			return nil, errors.New("statsValue must be provided with statsField")
+		}
+		if !solrStatsValues[val] {
+			return nil, fmt.Errorf("statsValue must be one of min, max, sum, count, missing or mean, got %s", val)
+		}
+		meta.statsValue = val
+	}
+
+	if val, ok := config.TriggerMetadata["targetQueryValue"]; ok {
+		targetQueryValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing targetQueryValue: %s", err)
+		}
+		meta.targetQueryValue = targetQueryValue
+	} else {
+		return nil, errors.New("no targetQueryValue given")
+	}
+
+	meta.activationTargetQueryValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetQueryValue"]; ok {
+		activationTargetQueryValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetQueryValue: %s", err)
+		}
+		meta.activationTargetQueryValue = activationTargetQueryValue
+	}
+
+	meta.username = config.AuthParams["username"]
+	if config.AuthParams["password"] != "" {
+		meta.password = config.AuthParams["password"]
+	} else if config.TriggerMetadata["passwordFromEnv"] != "" {
+		meta.password = config.ResolvedEnv[config.TriggerMetadata["passwordFromEnv"]]
+	}
+	if meta.password != "" && meta.username == "" {
+		return nil, errors.New("password must be provided with username")
+	}
+
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if val, ok := config.TriggerMetadata["metricName"]; ok {
+		meta.metricName = kedautil.NormalizeString(fmt.Sprintf("solr-%s", val))
+	} else {
+		meta.metricName = kedautil.NormalizeString(fmt.Sprintf("solr-%s", meta.collection))
+	}
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func (s *solrScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getQueryResult(ctx)
+	if err != nil {
+		s.logger.Error(err, "error querying solr")
+		return false, err
+	}
+
+	return value > s.metadata.activationTargetQueryValue, nil
+}
+
+func (s *solrScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (s *solrScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetQueryValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: solrMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *solrScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getQueryResult(ctx)
+	if err != nil {
+		s.logger.Error(err, "error querying solr")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// getQueryResult returns the numFound of the query, or the statsValue of the statsField of the matched documents
+func (s *solrScaler) getQueryResult(ctx context.Context) (float64, error) {
+	params := url.Values{}
+	params.Set("q", s.metadata.query)
+	params.Set("rows", "0")
+	params.Set("wt", "json")
+	if s.metadata.filterQuery != "" {
+		params.Set("fq", s.metadata.filterQuery)
+	}
+	if s.metadata.statsField != "" {
+		params.Set("stats", "true")
+		params.Set("stats.field", s.metadata.statsField)
+	}
+
+	selectURL := fmt.Sprintf("%s/solr/%s/select?%s", s.metadata.host, url.PathEscape(s.metadata.collection), params.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, selectURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.metadata.username != "" {
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("solr returned status code %d for %s", resp.StatusCode, req.URL.Path)
+	}
+
+	var result solrSelectResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("error decoding solr response: %s", err)
+	}
+
+	if s.metadata.statsField == "" {
+		return float64(result.Response.NumFound), nil
+	}
+
+	stats, ok := result.Stats.StatsFields[s.metadata.statsField]
+	if !ok {
+		return 0, fmt.Errorf("solr response doesn't contain stats for field %s", s.metadata.statsField)
+	}
+	switch value := stats[s.metadata.statsValue].(type) {
+	case float64:
+		return value, nil
+	case nil:
+		// min, max and mean are null when no document matches the query
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("%s of the stats of field %s must be a number, got %v", s.metadata.statsValue, s.metadata.statsField, value)
+	}
+}
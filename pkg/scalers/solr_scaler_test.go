@@ -0,0 +1,140 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+type parseSolrMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type solrMetricIdentifier struct {
+	metadataTestData *parseSolrMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testSolrMetadata = []parseSolrMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed
+	{map[string]string{"host": "http://solr:8983", "collection": "tasks", "targetQueryValue": "10"}, map[string]string{}, false},
+	// properly formed with stats and basic auth
+	{map[string]string{"host": "http://solr:8983", "collection": "tasks", "query": "status:pending", "statsField": "size", "statsValue": "max", "targetQueryValue": "2.5", "activationTargetQueryValue": "1", "metricName": "pending"}, map[string]string{"username": "solr", "password": "SolrRocks"}, false},
+	// missing host
+	{map[string]string{"collection": "tasks", "targetQueryValue": "10"}, map[string]string{}, true},
+	// missing collection
+	{map[string]string{"host": "http://solr:8983", "targetQueryValue": "10"}, map[string]string{}, true},
+	// missing targetQueryValue
+	{map[string]string{"host": "http://solr:8983", "collection": "tasks"}, map[string]string{}, true},
+	// invalid activationTargetQueryValue
+	{map[string]string{"host": "http://solr:8983", "collection": "tasks", "targetQueryValue": "10", "activationTargetQueryValue": "a"}, map[string]string{}, true},
+	// statsValue without statsField
+	{map[string]string{"host": "http://solr:8983", "collection": "tasks", "targetQueryValue": "10", "statsValue": "sum"}, map[string]string{}, true},
+	// invalid statsValue
+	{map[string]string{"host": "http://solr:8983", "collection": "tasks", "targetQueryValue": "10", "statsField": "size", "statsValue": "median"}, map[string]string{}, true},
+	// password without username
+	{map[string]string{"host": "http://solr:8983", "collection": "tasks", "targetQueryValue": "10"}, map[string]string{"password": "SolrRocks"}, true},
+}
+
+var solrMetricIdentifiers = []solrMetricIdentifier{
+	{&testSolrMetadata[1], 0, "s0-solr-tasks"},
+	{&testSolrMetadata[2], 1, "s1-solr-pending"},
+}
+
+func TestSolrParseMetadata(t *testing.T) {
+	for _, testData := range testSolrMetadata {
+		_, err := parseSolrMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestSolrGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range solrMetricIdentifiers {
+		meta, err := parseSolrMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSolrScaler := solrScaler{
+			metadata: meta,
+			logger:   logr.Discard(),
+		}
+
+		metricSpec := mockSolrScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestSolrGetQueryResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "solr" || password != "SolrRocks" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/solr/tasks/select" || r.URL.Query().Get("rows") != "0" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.URL.Query().Get("stats.field") {
+		case "":
+			_, _ = w.Write([]byte(`{"response":{"numFound":12,"start":0,"docs":[]}}`))
+		case "size":
+			_, _ = w.Write([]byte(`{"response":{"numFound":12},"stats":{"stats_fields":{"size":{"min":1.0,"max":9.0,"sum":30.5,"count":12}}}}`))
+		case "empty":
+			_, _ = w.Write([]byte(`{"response":{"numFound":0},"stats":{"stats_fields":{"empty":{"min":null,"max":null,"sum":0.0,"count":0}}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"response":{"numFound":0},"stats":{"stats_fields":{}}}`))
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		metadata map[string]string
+		expected float64
+		isError  bool
+	}{
+		{map[string]string{}, 12, false},
+		{map[string]string{"statsField": "size"}, 30.5, false},
+		{map[string]string{"statsField": "size", "statsValue": "max"}, 9, false},
+		{map[string]string{"statsField": "empty", "statsValue": "max"}, 0, false},
+		{map[string]string{"statsField": "unknown"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		tc.metadata["host"] = server.URL
+		tc.metadata["collection"] = "tasks"
+		tc.metadata["targetQueryValue"] = "10"
+		meta, err := parseSolrMetadata(&ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{"username": "solr", "password": "SolrRocks"}})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := solrScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+		value, err := scaler.getQueryResult(context.Background())
+		if err != nil && !tc.isError {
+			t.Errorf("Expected success for %v but got error %s", tc.metadata, err)
+		}
+		if tc.isError && err == nil {
+			t.Errorf("Expected error for %v but got success", tc.metadata)
+		}
+		if value != tc.expected {
+			t.Errorf("Expected %f for %v but got %f", tc.expected, tc.metadata, value)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	splunkMetricType  = "External"
+	defaultSplunkApp  = "search"
+	defaultSplunkUser = "nobody"
+)
+
+type splunkScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *splunkMetadata
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type splunkMetadata struct {
+	host                  string
+	app                   string
+	savedSearchName       string
+	query                 string
+	valueField            string
+	targetValue           float64
+	activationTargetValue float64
+	metricName            string
+	scalerIndex           int
+
+	// Auth
+	username string
+	password string
+	apiToken string
+
+	// TLS
+	unsafeSsl bool
+	ca        string
+}
+
+type splunkSearchResponse struct {
+	Results []map[string]interface{} `json:"results"`
+}
+
+// NewSplunkScaler creates a new splunkScaler
+func NewSplunkScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseSplunkMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing splunk metadata: %s", err)
+	}
+
+	httpClient, err := createTLSHTTPClient(config, meta.unsafeSsl, tlsAuthParams{ca: meta.ca})
+	if err != nil {
+		return nil, fmt.Errorf("error creating splunk tls config: %s", err)
+	}
+
+	return &splunkScaler{
+		metricType: metricType,
+		metadata:   meta,
+		httpClient: httpClient,
+		logger:     InitializeLogger(config, "splunk_scaler"),
+	}, nil
+}
+
+func parseSplunkMetadata(config *ScalerConfig) (*splunkMetadata, error) {
+	meta := splunkMetadata{}
+	var err error
+
+	meta.host, err = GetFromAuthOrMeta(config, "host")
+	if err != nil {
+		return nil, err
+	}
+	meta.host = strings.TrimSuffix(meta.host, "/")
+
+	meta.app = defaultSplunkApp
+	if val, ok := config.TriggerMetadata["app"]; ok && val != "" {
+		meta.app = val
+	}
+
+	meta.savedSearchName = config.TriggerMetadata["savedSearchName"]
+	meta.query = config.TriggerMetadata["query"]
+	switch {
+	case meta.savedSearchName == "" && meta.query == "":
+		return nil, errors.New("either savedSearchName or query must be given")
+	case meta.savedSearchName != "" && meta.query != "":
+		return nil, errors.New("savedSearchName and query can't be used together")
+	}
+
+	meta.valueField = config.TriggerMetadata["valueField"]
+	if meta.valueField == "" {
+		return nil, errors.New("no valueField given")
+	}
+
+	if val, ok := config.TriggerMetadata["targetValue"]; ok {
+		targetValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing targetValue: %s", err)
+		}
+		meta.targetValue = targetValue
+	} else {
+		return nil, errors.New("no targetValue given")
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok {
+		activationTargetValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetValue: %s", err)
+		}
+		meta.activationTargetValue = activationTargetValue
+	}
+
+	meta.apiToken = config.AuthParams["apiToken"]
+	meta.username = config.AuthParams["username"]
+	meta.password = config.AuthParams["password"]
+	switch {
+	case meta.apiToken == "" && (meta.username == "" || meta.password == ""):
+		return nil, errors.New("either apiToken or username and password must be given")
+	case meta.apiToken != "" && meta.password != "":
+		return nil, errors.New("apiToken and password can't be used together")
+	}
+
+	meta.unsafeSsl, err = GetUnsafeSsl(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.ca = config.AuthParams["ca"]
+
+	if val, ok := config.TriggerMetadata["metricName"]; ok {
+		meta.metricName = kedautil.NormalizeString(fmt.Sprintf("splunk-%s", val))
+	} else if meta.savedSearchName != "" {
+		meta.metricName = kedautil.NormalizeString(fmt.Sprintf("splunk-%s", meta.savedSearchName))
+	} else {
+		meta.metricName = "splunk-query"
+	}
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func (s *splunkScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getSearchResult(ctx)
+	if err != nil {
+		s.logger.Error(err, "error running splunk search")
+		return false, err
+	}
+
+	return value > s.metadata.activationTargetValue, nil
+}
+
+func (s *splunkScaler) Close(context.Context) error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (s *splunkScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, s.metadata.metricName),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: splunkMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *splunkScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getSearchResult(ctx)
+	if err != nil {
+		s.logger.Error(err, "error running splunk search")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// getSearchResult runs the saved search, or the query, as a oneshot search job and returns
+// the valueField of the first result, or 0 if the search returns no results
+func (s *splunkScaler) getSearchResult(ctx context.Context) (float64, error) {
+	search := s.metadata.query
+	if s.metadata.savedSearchName != "" {
+		search = fmt.Sprintf("| savedsearch %s", strconv.Quote(s.metadata.savedSearchName))
+	} else if !strings.HasPrefix(strings.TrimSpace(search), "|") && !strings.HasPrefix(strings.TrimSpace(search), "search ") {
+		// the REST API requires queries to start with a generating command
+		search = "search " + search
+	}
+
+	owner := defaultSplunkUser
+	if s.metadata.username != "" {
+		owner = s.metadata.username
+	}
+	jobsURL := fmt.Sprintf("%s/servicesNS/%s/%s/search/jobs", s.metadata.host, url.PathEscape(owner), url.PathEscape(s.metadata.app))
+	form := url.Values{}
+	form.Set("search", search)
+	form.Set("exec_mode", "oneshot")
+	form.Set("output_mode", "json")
+	form.Set("count", "1")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, jobsURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if s.metadata.apiToken != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.apiToken))
+	} else {
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("splunk returned status code %d for %s", resp.StatusCode, req.URL.Path)
+	}
+
+	var result splunkSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("error decoding splunk response: %s", err)
+	}
+	if len(result.Results) == 0 {
+		return 0, nil
+	}
+
+	// Splunk returns the field values as strings
+	switch value := result.Results[0][s.metadata.valueField].(type) {
+	case string:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, fmt.Errorf("field %s of the splunk search result must be a number, got %s", s.metadata.valueField, value)
+		}
+		return v, nil
+	case float64:
+		return value, nil
+	case nil:
+		return 0, fmt.Errorf("splunk search result doesn't contain field %s", s.metadata.valueField)
+	default:
+		return 0, fmt.Errorf("field %s of the splunk search result must be a number, got %v", s.metadata.valueField, value)
+	}
+}
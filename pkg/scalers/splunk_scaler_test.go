@@ -0,0 +1,151 @@
+package scalers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+type parseSplunkMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type splunkMetricIdentifier struct {
+	metadataTestData *parseSplunkMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testSplunkAuthParams = map[string]string{"apiToken": "token"}
+
+var testSplunkMetadata = []parseSplunkMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed saved search
+	{map[string]string{"host": "https://splunk:8089", "savedSearchName": "queue-size", "valueField": "count", "targetValue": "10"}, testSplunkAuthParams, false},
+	// properly formed query with basic auth
+	{map[string]string{"host": "https://splunk:8089", "query": "index=main | stats count", "valueField": "count", "targetValue": "2.5", "activationTargetValue": "1"}, map[string]string{"username": "admin", "password": "changeme"}, false},
+	// missing host
+	{map[string]string{"savedSearchName": "queue-size", "valueField": "count", "targetValue": "10"}, testSplunkAuthParams, true},
+	// missing savedSearchName and query
+	{map[string]string{"host": "https://splunk:8089", "valueField": "count", "targetValue": "10"}, testSplunkAuthParams, true},
+	// savedSearchName and query together
+	{map[string]string{"host": "https://splunk:8089", "savedSearchName": "queue-size", "query": "index=main", "valueField": "count", "targetValue": "10"}, testSplunkAuthParams, true},
+	// missing valueField
+	{map[string]string{"host": "https://splunk:8089", "savedSearchName": "queue-size", "targetValue": "10"}, testSplunkAuthParams, true},
+	// missing targetValue
+	{map[string]string{"host": "https://splunk:8089", "savedSearchName": "queue-size", "valueField": "count"}, testSplunkAuthParams, true},
+	// invalid activationTargetValue
+	{map[string]string{"host": "https://splunk:8089", "savedSearchName": "queue-size", "valueField": "count", "targetValue": "10", "activationTargetValue": "a"}, testSplunkAuthParams, true},
+	// missing auth
+	{map[string]string{"host": "https://splunk:8089", "savedSearchName": "queue-size", "valueField": "count", "targetValue": "10"}, map[string]string{}, true},
+	// username without password
+	{map[string]string{"host": "https://splunk:8089", "savedSearchName": "queue-size", "valueField": "count", "targetValue": "10"}, map[string]string{"username": "admin"}, true},
+	// invalid unsafeSsl
+	{map[string]string{"host": "https://splunk:8089", "savedSearchName": "queue-size", "valueField": "count", "targetValue": "10", "unsafeSsl": "a"}, testSplunkAuthParams, true},
+}
+
+var splunkMetricIdentifiers = []splunkMetricIdentifier{
+	{&testSplunkMetadata[1], 0, "s0-splunk-queue-size"},
+	{&testSplunkMetadata[2], 1, "s1-splunk-query"},
+}
+
+func TestSplunkParseMetadata(t *testing.T) {
+	for _, testData := range testSplunkMetadata {
+		_, err := parseSplunkMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestSplunkGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range splunkMetricIdentifiers {
+		meta, err := parseSplunkMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockSplunkScaler := splunkScaler{
+			metadata: meta,
+			logger:   logr.Discard(),
+		}
+
+		metricSpec := mockSplunkScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+func TestSplunkGetSearchResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.Form.Get("exec_mode") != "oneshot" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch r.Form.Get("search") {
+		case `| savedsearch "queue-size"`:
+			_, _ = w.Write([]byte(`{"results":[{"count":"42"}]}`))
+		case "search index=main | stats count":
+			_, _ = w.Write([]byte(`{"results":[{"count":"7.5"}]}`))
+		case `| savedsearch "empty"`:
+			_, _ = w.Write([]byte(`{"results":[]}`))
+		case `| savedsearch "text"`:
+			_, _ = w.Write([]byte(`{"results":[{"count":"many"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		metadata map[string]string
+		expected float64
+		isError  bool
+	}{
+		{map[string]string{"savedSearchName": "queue-size"}, 42, false},
+		{map[string]string{"query": "index=main | stats count"}, 7.5, false},
+		{map[string]string{"savedSearchName": "empty"}, 0, false},
+		{map[string]string{"savedSearchName": "queue-size", "valueField": "missing"}, 0, true},
+		{map[string]string{"savedSearchName": "text"}, 0, true},
+		{map[string]string{"savedSearchName": "unknown"}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		tc.metadata["host"] = server.URL
+		tc.metadata["targetValue"] = "10"
+		if _, ok := tc.metadata["valueField"]; !ok {
+			tc.metadata["valueField"] = "count"
+		}
+		meta, err := parseSplunkMetadata(&ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: testSplunkAuthParams})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := splunkScaler{metadata: meta, httpClient: http.DefaultClient, logger: logr.Discard()}
+
+		value, err := scaler.getSearchResult(context.Background())
+		if err != nil && !tc.isError {
+			t.Errorf("Expected success for %v but got error %s", tc.metadata, err)
+		}
+		if tc.isError && err == nil {
+			t.Errorf("Expected error for %v but got success", tc.metadata)
+		}
+		if value != tc.expected {
+			t.Errorf("Expected %f for %v but got %f", tc.expected, tc.metadata, value)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package scalers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -17,6 +18,9 @@ import (
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 )
 
+// errStackDriverMetricNotFound is returned when no time series matches the filter
+var errStackDriverMetricNotFound = errors.New("could not find stackdriver metric")
+
 // StackDriverClient is a generic client to fetch metrics from Stackdriver. Can be used
 // for a stackdriver scaler in the future
 type StackDriverClient struct {
@@ -203,7 +207,7 @@ func (s StackDriverClient) GetMetrics(
 	resp, err := it.Next()
 
 	if err == iterator.Done {
-		return value, fmt.Errorf("could not find stackdriver metric with filter %s", filter)
+		return value, fmt.Errorf("%w with filter %s", errStackDriverMetricNotFound, filter)
 	}
 
 	if err != nil {
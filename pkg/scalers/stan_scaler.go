@@ -45,13 +45,13 @@ type stanScaler struct {
 }
 
 type stanMetadata struct {
-	natsServerMonitoringEndpoint string
-	queueGroup                   string
-	durableName                  string
-	subject                      string
-	lagThreshold                 int64
-	activationLagThreshold       int64
-	scalerIndex                  int
+	monitoring             natsMonitoringMetadata
+	queueGroup             string
+	durableName            string
+	subject                string
+	lagThreshold           int64
+	activationLagThreshold int64
+	scalerIndex            int
 }
 
 const (
@@ -59,6 +59,8 @@ const (
 	defaultStanLagThreshold = 10
 )
 
+var errStanChannelNotFound = errors.New("streaming channel not found")
+
 // NewStanScaler creates a new stanScaler
 func NewStanScaler(config *ScalerConfig) (Scaler, error) {
 	metricType, err := GetMetricTargetType(config)
@@ -71,11 +73,16 @@ func NewStanScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing stan metadata: %s", err)
 	}
 
+	httpClient, err := newNATSMonitoringHTTPClient(config, stanMetadata.monitoring)
+	if err != nil {
+		return nil, err
+	}
+
 	return &stanScaler{
 		channelInfo: &monitorChannelInfo{},
 		metricType:  metricType,
 		metadata:    stanMetadata,
-		httpClient:  kedautil.CreateHTTPClient(config.GlobalHTTPTimeout, false),
+		httpClient:  httpClient,
 		logger:      InitializeLogger(config, "stan_scaler"),
 	}, nil
 }
@@ -83,7 +90,7 @@ func NewStanScaler(config *ScalerConfig) (Scaler, error) {
 func parseStanMetadata(config *ScalerConfig) (stanMetadata, error) {
 	meta := stanMetadata{}
 	var err error
-	meta.natsServerMonitoringEndpoint, err = GetFromAuthOrMeta(config, "natsServerMonitoringEndpoint")
+	meta.monitoring, err = parseNATSMonitoringMetadata(config)
 	if err != nil {
 		return meta, err
 	}
@@ -128,51 +135,83 @@ func parseStanMetadata(config *ScalerConfig) (stanMetadata, error) {
 
 // IsActive determines if we need to scale from zero
 func (s *stanScaler) IsActive(ctx context.Context) (bool, error) {
-	monitoringEndpoint := s.getMonitoringEndpoint()
-
-	req, err := http.NewRequestWithContext(ctx, "GET", monitoringEndpoint, nil)
-	if err != nil {
+	if err := s.getChannelInfo(ctx); err != nil {
+		if errors.Is(err, errStanChannelNotFound) {
+			return false, nil
+		}
 		return false, err
 	}
-	resp, err := s.httpClient.Do(req)
+	return s.hasPendingMessage() || s.getMaxMsgLag() > s.metadata.activationLagThreshold, nil
+}
+
+// getChannelInfo fetches the channel from the monitoring endpoint. With cluster aggregation every
+// server of the cluster is queried and the most recent view of the channel, held by the leader, is used.
+func (s *stanScaler) getChannelInfo(ctx context.Context) error {
+	servers, err := getNATSMonitoringServers(ctx, s.httpClient, s.metadata.monitoring)
 	if err != nil {
-		s.logger.Error(err, "Unable to access the nats streaming broker monitoring endpoint", "natsServerMonitoringEndpoint", s.metadata.natsServerMonitoringEndpoint)
-		return false, err
+		s.logger.Error(err, "Unable to discover the nats cluster servers", "natsServerMonitoringEndpoint", s.metadata.monitoring.endpoint)
+		return err
 	}
 
-	if resp.StatusCode == 404 {
-		req, err := http.NewRequestWithContext(ctx, "GET", s.getSTANChannelsEndpoint(), nil)
+	var lastErr error
+	found := false
+	for _, server := range servers {
+		channelInfo, err := s.getServerChannelInfo(ctx, server)
 		if err != nil {
-			return false, err
+			lastErr = err
+			continue
+		}
+		if !found || channelInfo.LastSequence > s.channelInfo.LastSequence {
+			s.channelInfo = channelInfo
 		}
-		baseResp, err := s.httpClient.Do(req)
+		found = true
+	}
+
+	if !found {
+		return lastErr
+	}
+	return nil
+}
+
+func (s *stanScaler) getServerChannelInfo(ctx context.Context, server string) (*monitorChannelInfo, error) {
+	monitoringEndpoint := s.getMonitoringEndpoint(server)
+
+	resp, err := doNATSMonitoringRequest(ctx, s.httpClient, s.metadata.monitoring, monitoringEndpoint)
+	if err != nil {
+		s.logger.Error(err, "Unable to access the nats streaming broker monitoring endpoint", "natsServerMonitoringEndpoint", server)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		baseResp, err := doNATSMonitoringRequest(ctx, s.httpClient, s.metadata.monitoring, s.getSTANChannelsEndpoint(server))
 		if err != nil {
-			return false, err
+			return nil, err
 		}
 		defer baseResp.Body.Close()
 		if baseResp.StatusCode == 404 {
 			s.logger.Info("Streaming broker endpoint returned 404. Please ensure it has been created", "url", monitoringEndpoint, "channelName", s.metadata.subject)
 		} else {
-			s.logger.Info("Unable to connect to STAN. Please ensure you have configured the ScaledObject with the correct endpoint.", "baseResp.StatusCode", baseResp.StatusCode, "natsServerMonitoringEndpoint", s.metadata.natsServerMonitoringEndpoint)
+			s.logger.Info("Unable to connect to STAN. Please ensure you have configured the ScaledObject with the correct endpoint.", "baseResp.StatusCode", baseResp.StatusCode, "natsServerMonitoringEndpoint", server)
 		}
 
-		return false, err
+		return nil, errStanChannelNotFound
 	}
 
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&s.channelInfo); err != nil {
+	channelInfo := &monitorChannelInfo{}
+	if err := json.NewDecoder(resp.Body).Decode(channelInfo); err != nil {
 		s.logger.Error(err, "Unable to decode channel info as %v", err)
-		return false, err
+		return nil, err
 	}
-	return s.hasPendingMessage() || s.getMaxMsgLag() > s.metadata.activationLagThreshold, nil
+	return channelInfo, nil
 }
 
-func (s *stanScaler) getSTANChannelsEndpoint() string {
-	return "http://" + s.metadata.natsServerMonitoringEndpoint + "/streaming/channelsz"
+func (s *stanScaler) getSTANChannelsEndpoint(server string) string {
+	return s.metadata.monitoring.url(server, "/streaming/channelsz")
 }
 
-func (s *stanScaler) getMonitoringEndpoint() string {
-	return s.getSTANChannelsEndpoint() + "?channel=" + s.metadata.subject + "&subs=1"
+func (s *stanScaler) getMonitoringEndpoint(server string) string {
+	return s.getSTANChannelsEndpoint(server) + "?channel=" + s.metadata.subject + "&subs=1"
 }
 
 func (s *stanScaler) getMaxMsgLag() int64 {
@@ -227,20 +266,7 @@ func (s *stanScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSp
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *stanScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", s.getMonitoringEndpoint(), nil)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := s.httpClient.Do(req)
-
-	if err != nil {
-		s.logger.Error(err, "Unable to access the nats streaming broker monitoring endpoint", "natsServerMonitoringEndpoint", s.metadata.natsServerMonitoringEndpoint)
-		return []external_metrics.ExternalMetricValue{}, err
-	}
-
-	defer resp.Body.Close()
-	if err := json.NewDecoder(resp.Body).Decode(&s.channelInfo); err != nil {
-		s.logger.Error(err, "Unable to decode channel info as %v", err)
+	if err := s.getChannelInfo(ctx); err != nil {
 		return []external_metrics.ExternalMetricValue{}, err
 	}
 	totalLag := s.getMaxMsgLag()
@@ -35,6 +35,10 @@ var testStanMetadata = []parseStanMetadataTestData{
 	{map[string]string{"queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject"}, map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss"}, false},
 	// Missing nats server monitoring endpoint , should fail
 	{map[string]string{"queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject"}, map[string]string{"natsServerMonitoringEndpoint": ""}, true},
+	// All good + basic auth and mTLS
+	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss:8222", "queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject", "clusterAggregation": "true"}, map[string]string{"username": "user", "password": "pass", "tls": "enable", "cert": "ceert", "key": "keey"}, false},
+	// Cert without key, should fail
+	{map[string]string{"natsServerMonitoringEndpoint": "stan-nats-ss", "queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject"}, map[string]string{"tls": "enable", "cert": "ceert"}, true},
 }
 
 var stanMetricIdentifiers = []stanMetricIdentifier{
@@ -0,0 +1,236 @@
+package scalers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	enumspb "go.temporal.io/api/enums/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	temporalMetricType                  = "External"
+	defaultTemporalNamespace            = "default"
+	defaultTemporalTargetQueueSize      = 5
+	defaultTemporalActivationQueueSize  = 0
+	temporalTaskQueueTypeWorkflow       = "workflow"
+	temporalTaskQueueTypeActivity       = "activity"
+	temporalDescribeTaskQueueTimeout    = 10 * time.Second
+	temporalAuthorizationHeader         = "authorization"
+	temporalDefaultTaskQueueTypesConfig = temporalTaskQueueTypeWorkflow + "," + temporalTaskQueueTypeActivity
+)
+
+type temporalScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *temporalMetadata
+	connection *grpc.ClientConn
+	client     workflowservice.WorkflowServiceClient
+	logger     logr.Logger
+}
+
+type temporalMetadata struct {
+	endpoint                  string
+	namespace                 string
+	taskQueue                 string
+	taskQueueTypes            []enumspb.TaskQueueType
+	targetQueueSize           float64
+	activationTargetQueueSize int64
+	scalerIndex               int
+
+	// Auth
+	apiKey string
+
+	// TLS
+	tlsAuthParams
+}
+
+// NewTemporalScaler creates a new temporalScaler
+func NewTemporalScaler(config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseTemporalMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing temporal metadata: %s", err)
+	}
+
+	conn, err := newTemporalConnection(meta)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to temporal frontend: %s", err)
+	}
+
+	return &temporalScaler{
+		metricType: metricType,
+		metadata:   meta,
+		connection: conn,
+		client:     workflowservice.NewWorkflowServiceClient(conn),
+		logger:     InitializeLogger(config, "temporal_scaler"),
+	}, nil
+}
+
+func parseTemporalMetadata(config *ScalerConfig) (*temporalMetadata, error) {
+	meta := temporalMetadata{}
+
+	endpoint, err := GetFromAuthOrMeta(config, "endpoint")
+	if err != nil {
+		return nil, err
+	}
+	meta.endpoint = endpoint
+
+	meta.namespace = defaultTemporalNamespace
+	if val, ok := config.TriggerMetadata["namespace"]; ok && val != "" {
+		meta.namespace = val
+	}
+
+	if val, ok := config.TriggerMetadata["taskQueue"]; ok && val != "" {
+		meta.taskQueue = val
+	} else {
+		return nil, errors.New("no taskQueue given")
+	}
+
+	taskQueueTypes := temporalDefaultTaskQueueTypesConfig
+	if val, ok := config.TriggerMetadata["taskQueueTypes"]; ok && val != "" {
+		taskQueueTypes = val
+	}
+	for _, t := range strings.Split(taskQueueTypes, ",") {
+		switch strings.TrimSpace(t) {
+		case temporalTaskQueueTypeWorkflow:
+			meta.taskQueueTypes = append(meta.taskQueueTypes, enumspb.TASK_QUEUE_TYPE_WORKFLOW)
+		case temporalTaskQueueTypeActivity:
+			meta.taskQueueTypes = append(meta.taskQueueTypes, enumspb.TASK_QUEUE_TYPE_ACTIVITY)
+		default:
+			return nil, fmt.Errorf("taskQueueTypes must only contain %s or %s, got %s", temporalTaskQueueTypeWorkflow, temporalTaskQueueTypeActivity, t)
+		}
+	}
+
+	meta.targetQueueSize = defaultTemporalTargetQueueSize
+	if val, ok := config.TriggerMetadata["targetQueueSize"]; ok {
+		queueSize, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing targetQueueSize: %s", err)
+		}
+		meta.targetQueueSize = queueSize
+	}
+
+	meta.activationTargetQueueSize = defaultTemporalActivationQueueSize
+	if val, ok := config.TriggerMetadata["activationTargetQueueSize"]; ok {
+		activationQueueSize, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetQueueSize: %s", err)
+		}
+		meta.activationTargetQueueSize = activationQueueSize
+	}
+
+	meta.apiKey = config.AuthParams["apiKey"]
+
+	tlsParams, err := parseTLSAuthParams(config)
+	if err != nil {
+		return nil, err
+	}
+	meta.tlsAuthParams = tlsParams
+
+	if meta.apiKey != "" && !meta.enableTLS {
+		return nil, errors.New("apiKey can only be used with tls enabled")
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+func newTemporalConnection(meta *temporalMetadata) (*grpc.ClientConn, error) {
+	if !meta.enableTLS {
+		return grpc.Dial(meta.endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	tlsConfig, err := kedautil.NewTLSConfigWithPassword(meta.cert, meta.key, meta.keyPassword, meta.ca)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.Dial(meta.endpoint, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+}
+
+func (s *temporalScaler) IsActive(ctx context.Context) (bool, error) {
+	backlog, err := s.getTaskQueueBacklog(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return backlog > s.metadata.activationTargetQueueSize, nil
+}
+
+func (s *temporalScaler) Close(context.Context) error {
+	if s.connection != nil {
+		return s.connection.Close()
+	}
+	return nil
+}
+
+func (s *temporalScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(fmt.Sprintf("temporal-%s-%s", s.metadata.namespace, s.metadata.taskQueue))),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetQueueSize),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: temporalMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *temporalScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	backlog, err := s.getTaskQueueBacklog(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting temporal task queue backlog")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, float64(backlog))
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+// getTaskQueueBacklog returns the sum of the approximate backlog of the configured task queue types
+func (s *temporalScaler) getTaskQueueBacklog(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, temporalDescribeTaskQueueTimeout)
+	defer cancel()
+
+	if s.metadata.apiKey != "" {
+		ctx = grpcmetadata.AppendToOutgoingContext(ctx, temporalAuthorizationHeader, "Bearer "+s.metadata.apiKey)
+	}
+
+	var backlog int64
+	for _, taskQueueType := range s.metadata.taskQueueTypes {
+		resp, err := s.client.DescribeTaskQueue(ctx, &workflowservice.DescribeTaskQueueRequest{
+			Namespace: s.metadata.namespace,
+			TaskQueue: &taskqueuepb.TaskQueue{
+				Name: s.metadata.taskQueue,
+				Kind: enumspb.TASK_QUEUE_KIND_NORMAL,
+			},
+			TaskQueueType:          taskQueueType,
+			IncludeTaskQueueStatus: true,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("error describing %s task queue %s: %s", taskQueueType, s.metadata.taskQueue, err)
+		}
+		backlog += resp.GetTaskQueueStatus().GetBacklogCountHint()
+	}
+
+	return backlog, nil
+}
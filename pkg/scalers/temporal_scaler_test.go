@@ -0,0 +1,161 @@
+package scalers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	enumspb "go.temporal.io/api/enums/v1"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"google.golang.org/grpc"
+	grpcmetadata "google.golang.org/grpc/metadata"
+)
+
+type parseTemporalMetadataTestData struct {
+	metadata   map[string]string
+	authParams map[string]string
+	isError    bool
+}
+
+type temporalMetricIdentifier struct {
+	metadataTestData *parseTemporalMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testTemporalMetadata = []parseTemporalMetadataTestData{
+	// nothing passed
+	{map[string]string{}, map[string]string{}, true},
+	// properly formed
+	{map[string]string{"endpoint": "temporal-frontend:7233", "namespace": "orders", "taskQueue": "payments"}, map[string]string{}, false},
+	// missing endpoint
+	{map[string]string{"taskQueue": "payments"}, map[string]string{}, true},
+	// missing taskQueue
+	{map[string]string{"endpoint": "temporal-frontend:7233"}, map[string]string{}, true},
+	// endpoint from auth params
+	{map[string]string{"taskQueue": "payments"}, map[string]string{"endpoint": "temporal-frontend:7233"}, false},
+	// activity task queue only
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "payments", "taskQueueTypes": "activity"}, map[string]string{}, false},
+	// invalid task queue type
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "payments", "taskQueueTypes": "workflow,query"}, map[string]string{}, true},
+	// invalid targetQueueSize
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "payments", "targetQueueSize": "a"}, map[string]string{}, true},
+	// invalid activationTargetQueueSize
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "payments", "activationTargetQueueSize": "a"}, map[string]string{}, true},
+	// mTLS
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "payments"}, map[string]string{"tls": "enable", "cert": "ceert", "key": "keey"}, false},
+	// cert without key
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "payments"}, map[string]string{"tls": "enable", "cert": "ceert"}, true},
+	// invalid tls value
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "payments"}, map[string]string{"tls": "yes"}, true},
+	// apiKey with tls
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "payments"}, map[string]string{"tls": "enable", "apiKey": "secret"}, false},
+	// apiKey without tls
+	{map[string]string{"endpoint": "temporal-frontend:7233", "taskQueue": "payments"}, map[string]string{"apiKey": "secret"}, true},
+}
+
+var temporalMetricIdentifiers = []temporalMetricIdentifier{
+	{&testTemporalMetadata[1], 0, "s0-temporal-orders-payments"},
+	{&testTemporalMetadata[4], 1, "s1-temporal-default-payments"},
+}
+
+func TestTemporalParseMetadata(t *testing.T) {
+	for _, testData := range testTemporalMetadata {
+		_, err := parseTemporalMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Error("Expected success but got error", err)
+		}
+		if testData.isError && err == nil {
+			t.Error("Expected error but got success")
+		}
+	}
+}
+
+func TestTemporalTaskQueueTypes(t *testing.T) {
+	meta, err := parseTemporalMetadata(&ScalerConfig{TriggerMetadata: testTemporalMetadata[1].metadata})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if len(meta.taskQueueTypes) != 2 {
+		t.Errorf("Expected both workflow and activity task queues by default but got %v", meta.taskQueueTypes)
+	}
+
+	meta, err = parseTemporalMetadata(&ScalerConfig{TriggerMetadata: testTemporalMetadata[5].metadata})
+	if err != nil {
+		t.Fatal("Expected success but got error", err)
+	}
+	if len(meta.taskQueueTypes) != 1 || meta.taskQueueTypes[0] != enumspb.TASK_QUEUE_TYPE_ACTIVITY {
+		t.Errorf("Expected only the activity task queue but got %v", meta.taskQueueTypes)
+	}
+}
+
+func TestTemporalGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range temporalMetricIdentifiers {
+		meta, err := parseTemporalMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, AuthParams: testData.metadataTestData.authParams, ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockTemporalScaler := temporalScaler{
+			metadata: meta,
+			logger:   logr.Discard(),
+		}
+
+		metricSpec := mockTemporalScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Error("Wrong External metric source name:", metricName)
+		}
+	}
+}
+
+type mockTemporalWorkflowServiceClient struct {
+	workflowservice.WorkflowServiceClient
+	backlogs      map[enumspb.TaskQueueType]int64
+	authorization []string
+}
+
+func (c *mockTemporalWorkflowServiceClient) DescribeTaskQueue(ctx context.Context, in *workflowservice.DescribeTaskQueueRequest, opts ...grpc.CallOption) (*workflowservice.DescribeTaskQueueResponse, error) {
+	md, _ := grpcmetadata.FromOutgoingContext(ctx)
+	c.authorization = md.Get(temporalAuthorizationHeader)
+	return &workflowservice.DescribeTaskQueueResponse{
+		TaskQueueStatus: &taskqueuepb.TaskQueueStatus{BacklogCountHint: c.backlogs[in.GetTaskQueueType()]},
+	}, nil
+}
+
+func TestTemporalGetTaskQueueBacklog(t *testing.T) {
+	client := &mockTemporalWorkflowServiceClient{
+		backlogs: map[enumspb.TaskQueueType]int64{
+			enumspb.TASK_QUEUE_TYPE_WORKFLOW: 3,
+			enumspb.TASK_QUEUE_TYPE_ACTIVITY: 7,
+		},
+	}
+
+	for _, testData := range []struct {
+		metadata   map[string]string
+		authParams map[string]string
+		backlog    int64
+	}{
+		{testTemporalMetadata[1].metadata, testTemporalMetadata[1].authParams, 10},
+		{testTemporalMetadata[5].metadata, testTemporalMetadata[5].authParams, 7},
+		{testTemporalMetadata[12].metadata, testTemporalMetadata[12].authParams, 10},
+	} {
+		meta, err := parseTemporalMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, AuthParams: testData.authParams})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := temporalScaler{metadata: meta, client: client, logger: logr.Discard()}
+
+		backlog, err := scaler.getTaskQueueBacklog(context.Background())
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+		if backlog != testData.backlog {
+			t.Errorf("Expected backlog %d but got %d", testData.backlog, backlog)
+		}
+
+		if meta.apiKey != "" && (len(client.authorization) != 1 || client.authorization[0] != "Bearer "+meta.apiKey) {
+			t.Errorf("Expected apiKey to be sent as bearer token but got %v", client.authorization)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	windowsPerfCounterMetricType = "External"
+	// windowsPerfCounterDefaultPort and windowsPerfCounterDefaultPath are where the windows_exporter serves its metrics
+	windowsPerfCounterDefaultPort = "9182"
+	windowsPerfCounterDefaultPath = "/metrics"
+	// windowsPerfCounterDefaultNodeSelector selects the Windows nodes running the windows_exporter
+	windowsPerfCounterDefaultNodeSelector = "kubernetes.io/os=windows"
+)
+
+type windowsPerfCounterTargets string
+
+const (
+	// windowsPerfCounterNodes scrapes the windows_exporter of the nodes, usually deployed as a host process DaemonSet
+	windowsPerfCounterNodes windowsPerfCounterTargets = "nodes"
+	// windowsPerfCounterPods scrapes the windows_exporter of the pods, usually running as a sidecar
+	windowsPerfCounterPods windowsPerfCounterTargets = "pods"
+)
+
+type windowsPerfCounterAggregation string
+
+const (
+	windowsPerfCounterSum     windowsPerfCounterAggregation = "sum"
+	windowsPerfCounterAverage windowsPerfCounterAggregation = "average"
+	windowsPerfCounterMax     windowsPerfCounterAggregation = "max"
+	windowsPerfCounterMin     windowsPerfCounterAggregation = "min"
+)
+
+type windowsPerfCounterScaler struct {
+	metricType v2beta2.MetricTargetType
+	metadata   *windowsPerfCounterMetadata
+	kubeClient client.Client
+	httpClient *http.Client
+	logger     logr.Logger
+}
+
+type windowsPerfCounterMetadata struct {
+	targets               windowsPerfCounterTargets
+	selector              labels.Selector
+	namespace             string
+	port                  string
+	path                  string
+	metric                string
+	metricLabels          map[string]string
+	aggregation           windowsPerfCounterAggregation
+	targetValue           float64
+	activationTargetValue float64
+	scalerIndex           int
+}
+
+// NewWindowsPerfCounterScaler creates a new windowsPerfCounterScaler, which aggregates a metric of the
+// windows_exporter of the Windows nodes or pods, e.g. the length of a MSMQ queue
+func NewWindowsPerfCounterScaler(kubeClient client.Client, config *ScalerConfig) (Scaler, error) {
+	metricType, err := GetMetricTargetType(config)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scaler metric type: %s", err)
+	}
+
+	meta, err := parseWindowsPerfCounterMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing windows performance counter metadata: %s", err)
+	}
+
+	return &windowsPerfCounterScaler{
+		metricType: metricType,
+		metadata:   meta,
+		kubeClient: kubeClient,
+		httpClient: createHTTPClient(config, false),
+		logger:     InitializeLogger(config, "windows_performance_counter_scaler"),
+	}, nil
+}
+
+func parseWindowsPerfCounterMetadata(config *ScalerConfig) (*windowsPerfCounterMetadata, error) {
+	meta := windowsPerfCounterMetadata{}
+	var err error
+
+	meta.targets = windowsPerfCounterNodes
+	if val, ok := config.TriggerMetadata["targets"]; ok && val != "" {
+		meta.targets = windowsPerfCounterTargets(val)
+	}
+	switch meta.targets {
+	case windowsPerfCounterNodes:
+		selector := windowsPerfCounterDefaultNodeSelector
+		if val, ok := config.TriggerMetadata["nodeSelector"]; ok && val != "" {
+			selector = val
+		}
+		meta.selector, err = labels.Parse(selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node selector: %s", err)
+		}
+	case windowsPerfCounterPods:
+		meta.selector, err = labels.Parse(config.TriggerMetadata[podSelectorKey])
+		if err != nil || meta.selector.String() == "" {
+			return nil, fmt.Errorf("invalid pod selector")
+		}
+		meta.namespace = config.ScalableObjectNamespace
+	default:
+		return nil, fmt.Errorf("targets must be one of %s, %s but is %s", windowsPerfCounterNodes, windowsPerfCounterPods, meta.targets)
+	}
+
+	meta.port = windowsPerfCounterDefaultPort
+	if val, ok := config.TriggerMetadata["port"]; ok && val != "" {
+		if _, err := strconv.ParseUint(val, 10, 16); err != nil {
+			return nil, fmt.Errorf("error parsing port: %s", err)
+		}
+		meta.port = val
+	}
+	meta.path = windowsPerfCounterDefaultPath
+	if val, ok := config.TriggerMetadata["path"]; ok && val != "" {
+		meta.path = "/" + strings.TrimPrefix(val, "/")
+	}
+
+	if val, ok := config.TriggerMetadata["metricName"]; ok && val != "" {
+		meta.metric = val
+	} else {
+		return nil, fmt.Errorf("no metricName given")
+	}
+	meta.metricLabels = map[string]string{}
+	if val, ok := config.TriggerMetadata["metricLabels"]; ok && val != "" {
+		meta.metricLabels, err = parsePrometheusLabels(val)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	meta.aggregation = windowsPerfCounterSum
+	if val, ok := config.TriggerMetadata["aggregation"]; ok && val != "" {
+		meta.aggregation = windowsPerfCounterAggregation(val)
+	}
+	switch meta.aggregation {
+	case windowsPerfCounterSum, windowsPerfCounterAverage, windowsPerfCounterMax, windowsPerfCounterMin:
+	default:
+		return nil, fmt.Errorf("aggregation must be one of %s, %s, %s, %s but is %s",
+			windowsPerfCounterSum, windowsPerfCounterAverage, windowsPerfCounterMax, windowsPerfCounterMin, meta.aggregation)
+	}
+
+	val, err := getParameterFromConfig(config, "targetValue", false)
+	if err != nil {
+		return nil, err
+	}
+	meta.targetValue, err = strconv.ParseFloat(val, 64)
+	if err != nil || meta.targetValue <= 0 {
+		return nil, fmt.Errorf("targetValue must be a float greater than 0")
+	}
+
+	meta.activationTargetValue = 0
+	if val, ok := config.TriggerMetadata["activationTargetValue"]; ok && val != "" {
+		meta.activationTargetValue, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing activationTargetValue: %s", err)
+		}
+	}
+
+	meta.scalerIndex = config.ScalerIndex
+
+	return &meta, nil
+}
+
+// getAddresses returns the name and the IP of the ready nodes, or of the running pods, matching the selector
+func (s *windowsPerfCounterScaler) getAddresses(ctx context.Context) (map[string]string, error) {
+	addresses := map[string]string{}
+
+	if s.metadata.targets == windowsPerfCounterPods {
+		podList := &corev1.PodList{}
+		if err := s.kubeClient.List(ctx, podList, client.InNamespace(s.metadata.namespace), client.MatchingLabelsSelector{Selector: s.metadata.selector}); err != nil {
+			return nil, err
+		}
+		for _, pod := range podList.Items {
+			if pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != "" && pod.GetDeletionTimestamp() == nil {
+				addresses[pod.Name] = pod.Status.PodIP
+			}
+		}
+		return addresses, nil
+	}
+
+	nodeList := &corev1.NodeList{}
+	if err := s.kubeClient.List(ctx, nodeList, client.MatchingLabelsSelector{Selector: s.metadata.selector}); err != nil {
+		return nil, err
+	}
+	for _, node := range nodeList.Items {
+		if !isNodeReady(&node) {
+			continue
+		}
+		for _, address := range node.Status.Addresses {
+			if address.Type == corev1.NodeInternalIP {
+				addresses[node.Name] = address.Address
+				break
+			}
+		}
+	}
+	return addresses, nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// getTargetValue returns the sum of the samples of the metric exposed by the windows_exporter of the address
+func (s *windowsPerfCounterScaler) getTargetValue(ctx context.Context, address string) (float64, error) {
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(address, s.metadata.port), s.metadata.path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%s: api returned %d", req.URL.Path, resp.StatusCode)
+	}
+
+	value, ok, err := getPrometheusExpositionSum(body, s.metadata.metric, s.metadata.metricLabels)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("windows_exporter metrics have no %s", s.metadata.metric)
+	}
+	return value, nil
+}
+
+// getValue aggregates the values of the targets, the targets which can't be scraped are left out.
+// It is 0 without targets.
+func (s *windowsPerfCounterScaler) getValue(ctx context.Context) (float64, error) {
+	addresses, err := s.getAddresses(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var values []float64
+	var lastErr error
+	for name, address := range addresses {
+		value, err := s.getTargetValue(ctx, address)
+		if err != nil {
+			s.logger.V(1).Info("error scraping the windows_exporter", string(s.metadata.targets), name, "error", err.Error())
+			lastErr = err
+			continue
+		}
+		values = append(values, value)
+	}
+
+	if len(values) == 0 {
+		if len(addresses) > 0 {
+			return 0, fmt.Errorf("error scraping the windows_exporter of the %d %s, last error: %s", len(addresses), s.metadata.targets, lastErr)
+		}
+		return 0, nil
+	}
+	return aggregateWindowsPerfCounterValues(values, s.metadata.aggregation), nil
+}
+
+func aggregateWindowsPerfCounterValues(values []float64, aggregation windowsPerfCounterAggregation) float64 {
+	var result float64
+	switch aggregation {
+	case windowsPerfCounterMax:
+		result = math.Inf(-1)
+		for _, value := range values {
+			result = math.Max(result, value)
+		}
+	case windowsPerfCounterMin:
+		result = math.Inf(1)
+		for _, value := range values {
+			result = math.Min(result, value)
+		}
+	default:
+		for _, value := range values {
+			result += value
+		}
+		if aggregation == windowsPerfCounterAverage {
+			result /= float64(len(values))
+		}
+	}
+	return result
+}
+
+func (s *windowsPerfCounterScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting windows performance counter")
+		return false, err
+	}
+
+	return value > s.metadata.activationTargetValue, nil
+}
+
+func (s *windowsPerfCounterScaler) Close(context.Context) error {
+	return nil
+}
+
+func (s *windowsPerfCounterScaler) GetMetricSpecForScaling(context.Context) []v2beta2.MetricSpec {
+	metricName := fmt.Sprintf("windows-performance-counter-%s", s.metadata.metric)
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: GenerateMetricNameWithIndex(s.metadata.scalerIndex, kedautil.NormalizeString(metricName)),
+		},
+		Target: GetMetricTargetMili(s.metricType, s.metadata.targetValue),
+	}
+	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: windowsPerfCounterMetricType}
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+func (s *windowsPerfCounterScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getValue(ctx)
+	if err != nil {
+		s.logger.Error(err, "error getting windows performance counter")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := GenerateMetricInMili(metricName, value)
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
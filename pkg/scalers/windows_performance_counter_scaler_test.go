@@ -0,0 +1,222 @@
+package scalers
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type parseWindowsPerfCounterMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+}
+
+type windowsPerfCounterMetricIdentifier struct {
+	metadataTestData *parseWindowsPerfCounterMetadataTestData
+	scalerIndex      int
+	name             string
+}
+
+var testWindowsPerfCounterMetadata = []parseWindowsPerfCounterMetadataTestData{
+	// nothing passed
+	{map[string]string{}, true},
+	// nodes
+	{map[string]string{"metricName": "windows_msmq_messages_in_queue", "targetValue": "100"}, false},
+	// pods with custom port, path, labels and aggregation
+	{map[string]string{"targets": "pods", "podSelector": "app=demo", "metricName": "windows_mssql_genstats_user_connections", "metricLabels": "mssql_instance=SQLEXPRESS", "aggregation": "max", "port": "9100", "path": "custom", "targetValue": "10", "activationTargetValue": "1"}, false},
+	// custom nodeSelector
+	{map[string]string{"nodeSelector": "agentpool=win", "metricName": "windows_msmq_messages_in_queue", "targetValue": "100"}, false},
+	// unsupported targets
+	{map[string]string{"targets": "services", "metricName": "windows_msmq_messages_in_queue", "targetValue": "100"}, true},
+	// pods without podSelector
+	{map[string]string{"targets": "pods", "metricName": "windows_msmq_messages_in_queue", "targetValue": "100"}, true},
+	// invalid nodeSelector
+	{map[string]string{"nodeSelector": "agentpool in (", "metricName": "windows_msmq_messages_in_queue", "targetValue": "100"}, true},
+	// no metricName
+	{map[string]string{"targetValue": "100"}, true},
+	// invalid metricLabels
+	{map[string]string{"metricName": "windows_msmq_messages_in_queue", "metricLabels": "name", "targetValue": "100"}, true},
+	// unsupported aggregation
+	{map[string]string{"metricName": "windows_msmq_messages_in_queue", "aggregation": "median", "targetValue": "100"}, true},
+	// no targetValue
+	{map[string]string{"metricName": "windows_msmq_messages_in_queue"}, true},
+	// invalid targetValue
+	{map[string]string{"metricName": "windows_msmq_messages_in_queue", "targetValue": "0"}, true},
+	// invalid activationTargetValue
+	{map[string]string{"metricName": "windows_msmq_messages_in_queue", "targetValue": "100", "activationTargetValue": "a"}, true},
+	// invalid port
+	{map[string]string{"metricName": "windows_msmq_messages_in_queue", "targetValue": "100", "port": "http"}, true},
+}
+
+var windowsPerfCounterMetricIdentifiers = []windowsPerfCounterMetricIdentifier{
+	{&testWindowsPerfCounterMetadata[1], 0, "s0-windows-performance-counter-windows_msmq_messages_in_queue"},
+	{&testWindowsPerfCounterMetadata[2], 1, "s1-windows-performance-counter-windows_mssql_genstats_user_connections"},
+}
+
+func TestWindowsPerfCounterParseMetadata(t *testing.T) {
+	for i, testData := range testWindowsPerfCounterMetadata {
+		_, err := parseWindowsPerfCounterMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ScalableObjectNamespace: "test"})
+		if err != nil && !testData.isError {
+			t.Errorf("test %d: expected success but got error: %s", i, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("test %d: expected error but got success", i)
+		}
+	}
+}
+
+func TestWindowsPerfCounterGetMetricSpecForScaling(t *testing.T) {
+	for _, testData := range windowsPerfCounterMetricIdentifiers {
+		meta, err := parseWindowsPerfCounterMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ScalableObjectNamespace: "test", ScalerIndex: testData.scalerIndex})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		mockWindowsPerfCounterScaler := windowsPerfCounterScaler{metadata: meta}
+
+		metricSpec := mockWindowsPerfCounterScaler.GetMetricSpecForScaling(context.Background())
+		metricName := metricSpec[0].External.Metric.Name
+		if metricName != testData.name {
+			t.Errorf("Wrong External metric source name: %s, expected: %s", metricName, testData.name)
+		}
+	}
+}
+
+func TestWindowsPerfCounterGetValue(t *testing.T) {
+	windowsExporterMetrics := `# HELP windows_msmq_messages_in_queue Count of all messages in the queue
+# TYPE windows_msmq_messages_in_queue gauge
+windows_msmq_messages_in_queue{name="private$\\orders"} 7
+windows_msmq_messages_in_queue{name="private$\\invoices"} 3
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(windowsExporterMetrics))
+	}))
+	defer server.Close()
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newNode := func(name string, labels map[string]string, ready v1.ConditionStatus) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: ready}},
+				Addresses:  []v1.NodeAddress{{Type: v1.NodeHostName, Address: name}, {Type: v1.NodeInternalIP, Address: host}},
+			},
+		}
+	}
+	windows := map[string]string{"kubernetes.io/os": "windows"}
+	newPod := func(name string, labels map[string]string, phase v1.PodPhase, ip string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", Labels: labels},
+			Status:     v1.PodStatus{Phase: phase, PodIP: ip},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		objects  []runtime.Object
+		expected float64
+		isError  bool
+	}{
+		{
+			name:     "sums the ready windows nodes",
+			metadata: map[string]string{},
+			objects: []runtime.Object{
+				newNode("win-1", windows, v1.ConditionTrue),
+				newNode("win-2", windows, v1.ConditionTrue),
+				newNode("win-3", windows, v1.ConditionFalse),
+				newNode("linux-1", map[string]string{"kubernetes.io/os": "linux"}, v1.ConditionTrue),
+			},
+			expected: 20,
+		},
+		{
+			name:     "filters the samples by label",
+			metadata: map[string]string{"metricLabels": `name=private$\orders`},
+			objects:  []runtime.Object{newNode("win-1", windows, v1.ConditionTrue), newNode("win-2", windows, v1.ConditionTrue)},
+			expected: 14,
+		},
+		{
+			name:     "averages the running pods",
+			metadata: map[string]string{"targets": "pods", "podSelector": "app=demo", "aggregation": "average"},
+			objects: []runtime.Object{
+				newPod("demo-1", map[string]string{"app": "demo"}, v1.PodRunning, host),
+				newPod("demo-2", map[string]string{"app": "demo"}, v1.PodRunning, host),
+				newPod("demo-3", map[string]string{"app": "demo"}, v1.PodPending, ""),
+			},
+			expected: 10,
+		},
+		{
+			name:     "no windows nodes",
+			metadata: map[string]string{},
+			objects:  []runtime.Object{newNode("linux-1", map[string]string{"kubernetes.io/os": "linux"}, v1.ConditionTrue)},
+			expected: 0,
+		},
+		{
+			name:     "windows_exporter can't be scraped",
+			metadata: map[string]string{"path": "/missing"},
+			objects:  []runtime.Object{newNode("win-1", windows, v1.ConditionTrue)},
+			isError:  true,
+		},
+		{
+			name:     "metric isn't exposed",
+			metadata: map[string]string{"metricName": "windows_msmq_bytes_in_queue"},
+			objects:  []runtime.Object{newNode("win-1", windows, v1.ConditionTrue)},
+			isError:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata := map[string]string{"metricName": "windows_msmq_messages_in_queue", "targetValue": "100", "port": port}
+			for k, v := range test.metadata {
+				metadata[k] = v
+			}
+			meta, err := parseWindowsPerfCounterMetadata(&ScalerConfig{TriggerMetadata: metadata, ScalableObjectNamespace: "test"})
+			if err != nil {
+				t.Fatal("Could not parse metadata:", err)
+			}
+			s := windowsPerfCounterScaler{
+				metadata:   meta,
+				kubeClient: fake.NewClientBuilder().WithRuntimeObjects(test.objects...).Build(),
+				httpClient: http.DefaultClient,
+				logger:     logr.Discard(),
+			}
+
+			value, err := s.getValue(context.Background())
+			if test.isError != (err != nil) {
+				t.Fatalf("expected error %v but got %v", test.isError, err)
+			}
+			if value != test.expected {
+				t.Errorf("expected %v but got %v", test.expected, value)
+			}
+		})
+	}
+}
+
+func TestAggregateWindowsPerfCounterValues(t *testing.T) {
+	values := []float64{4, 1, 7}
+	for aggregation, expected := range map[windowsPerfCounterAggregation]float64{
+		windowsPerfCounterSum:     12,
+		windowsPerfCounterAverage: 4,
+		windowsPerfCounterMax:     7,
+		windowsPerfCounterMin:     1,
+	} {
+		if value := aggregateWindowsPerfCounterValues(values, aggregation); value != expected {
+			t.Errorf("%s: expected %v but got %v", aggregation, expected, value)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package scalers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xmlNode is an element of a parsed XML document
+type xmlNode struct {
+	name     string
+	attrs    map[string]string
+	text     strings.Builder
+	children []*xmlNode
+}
+
+// content returns the text of the element and of its descendants
+func (n *xmlNode) content() string {
+	var b strings.Builder
+	b.WriteString(n.text.String())
+	for _, child := range n.children {
+		b.WriteString(child.content())
+	}
+	return b.String()
+}
+
+func parseXMLDocument(body []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = false
+
+	document := &xmlNode{}
+	stack := []*xmlNode{document}
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing XML: %s", err)
+		}
+
+		parent := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name.Local, attrs: map[string]string{}}
+			for _, attr := range t.Attr {
+				node.attrs[attr.Name.Local] = attr.Value
+			}
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			parent.text.Write(t)
+		}
+	}
+	if len(document.children) == 0 {
+		return nil, fmt.Errorf("error parsing XML: no root element")
+	}
+	return document, nil
+}
+
+// getXMLPathValue evaluates a subset of XPath 1.0 on the XML document and returns the number it selects.
+// The paths are made of /child and //descendant steps matching an element name or *, filtered by [n], [@attr],
+// [@attr='value'] and [child='value'] predicates and optionally ending with an @attr or text() step.
+// count(path) returns the number of matched elements and sum(path) the sum of their values.
+func getXMLPathValue(body []byte, expression string) (float64, error) {
+	document, err := parseXMLDocument(body)
+	if err != nil {
+		return 0, err
+	}
+
+	expression = strings.TrimSpace(expression)
+	function := ""
+	for _, f := range []string{"count", "sum"} {
+		if strings.HasPrefix(expression, f+"(") && strings.HasSuffix(expression, ")") {
+			function = f
+			expression = strings.TrimSpace(expression[len(f)+1 : len(expression)-1])
+		}
+	}
+
+	values, err := evaluateXMLPath(document, expression)
+	if err != nil {
+		return 0, err
+	}
+
+	switch function {
+	case "count":
+		return float64(len(values)), nil
+	case "sum":
+		sum := float64(0)
+		for _, value := range values {
+			v, err := parseXMLPathNumber(value)
+			if err != nil {
+				return 0, err
+			}
+			sum += v
+		}
+		return sum, nil
+	}
+
+	if len(values) != 1 {
+		return 0, fmt.Errorf("valueLocation %s must select a single value, it selects %d", expression, len(values))
+	}
+	return parseXMLPathNumber(values[0])
+}
+
+func parseXMLPathNumber(value string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("valueLocation must point to a number, got: '%s'", value)
+	}
+	return v, nil
+}
+
+// evaluateXMLPath returns the text of the elements or the values of the attributes selected by the path
+func evaluateXMLPath(document *xmlNode, path string) ([]string, error) {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	nodes := []*xmlNode{document}
+	for path != "" {
+		descendant := strings.HasPrefix(path, "//")
+		path = strings.TrimPrefix(strings.TrimPrefix(path, "/"), "/")
+
+		step, rest, err := splitXMLPathStep(path)
+		if err != nil {
+			return nil, err
+		}
+		path = rest
+		if step == "" {
+			return nil, fmt.Errorf("invalid XPath: empty step")
+		}
+
+		switch {
+		case step == "text()" || strings.HasPrefix(step, "@"):
+			if path != "" {
+				return nil, fmt.Errorf("invalid XPath: %s must be the last step", step)
+			}
+			values := []string{}
+			for _, node := range nodes {
+				if step == "text()" {
+					values = append(values, node.text.String())
+				} else if value, ok := node.attrs[step[1:]]; ok {
+					values = append(values, value)
+				}
+			}
+			return values, nil
+		default:
+			nodes, err = selectXMLPathStep(nodes, step, descendant)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	values := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		values = append(values, node.content())
+	}
+	return values, nil
+}
+
+// splitXMLPathStep returns the first step of the path, the slashes in its predicates don't end it
+func splitXMLPathStep(path string) (string, string, error) {
+	depth := 0
+	var quote rune
+	for i, c := range path {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '/' && depth == 0:
+			return path[:i], path[i:], nil
+		}
+	}
+	if depth != 0 || quote != 0 {
+		return "", "", fmt.Errorf("invalid XPath: unbalanced predicate in %s", path)
+	}
+	return path, "", nil
+}
+
+// selectXMLPathStep returns the children, or the descendants, of the nodes matching the name and predicates of the step
+func selectXMLPathStep(nodes []*xmlNode, step string, descendant bool) ([]*xmlNode, error) {
+	name := step
+	var predicates []string
+	if i := strings.Index(step, "["); i >= 0 {
+		name = step[:i]
+		for rest := step[i:]; rest != ""; {
+			end := strings.Index(rest, "]")
+			if !strings.HasPrefix(rest, "[") || end < 0 {
+				return nil, fmt.Errorf("invalid XPath predicate in %s", step)
+			}
+			predicates = append(predicates, strings.TrimSpace(rest[1:end]))
+			rest = rest[end+1:]
+		}
+	}
+
+	var selected []*xmlNode
+	for _, node := range nodes {
+		var candidates []*xmlNode
+		if descendant {
+			candidates = xmlDescendants(node)
+		} else {
+			candidates = node.children
+		}
+
+		matching := []*xmlNode{}
+		for _, candidate := range candidates {
+			if name == "*" || candidate.name == name {
+				matching = append(matching, candidate)
+			}
+		}
+		for _, predicate := range predicates {
+			var err error
+			matching, err = filterXMLPathPredicate(matching, predicate)
+			if err != nil {
+				return nil, err
+			}
+		}
+		selected = append(selected, matching...)
+	}
+	return selected, nil
+}
+
+func xmlDescendants(node *xmlNode) []*xmlNode {
+	var descendants []*xmlNode
+	for _, child := range node.children {
+		descendants = append(descendants, child)
+		descendants = append(descendants, xmlDescendants(child)...)
+	}
+	return descendants
+}
+
+func filterXMLPathPredicate(nodes []*xmlNode, predicate string) ([]*xmlNode, error) {
+	if position, err := strconv.Atoi(predicate); err == nil {
+		if position < 1 || position > len(nodes) {
+			return []*xmlNode{}, nil
+		}
+		return []*xmlNode{nodes[position-1]}, nil
+	}
+
+	key, value, hasValue := predicate, "", false
+	if i := strings.Index(predicate, "="); i >= 0 {
+		key, value, hasValue = strings.TrimSpace(predicate[:i]), strings.TrimSpace(predicate[i+1:]), true
+		if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+			return nil, fmt.Errorf("invalid XPath predicate [%s], the value must be quoted", predicate)
+		}
+		value = value[1 : len(value)-1]
+	}
+
+	filtered := []*xmlNode{}
+	for _, node := range nodes {
+		if strings.HasPrefix(key, "@") {
+			attr, ok := node.attrs[key[1:]]
+			if ok && (!hasValue || attr == value) {
+				filtered = append(filtered, node)
+			}
+			continue
+		}
+		for _, child := range node.children {
+			if child.name == key && (!hasValue || strings.TrimSpace(child.content()) == value) {
+				filtered = append(filtered, node)
+				break
+			}
+		}
+	}
+	return filtered, nil
+}
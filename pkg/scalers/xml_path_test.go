@@ -0,0 +1,65 @@
+package scalers
+
+import (
+	"testing"
+)
+
+func TestGetXMLPathValue(t *testing.T) {
+	body := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<server>
+	<queues>
+		<queue name="orders" durable="true"><depth>12</depth><consumers>2</consumers></queue>
+		<queue name="invoices"><depth>3</depth><consumers>0</consumers></queue>
+		<queue name="refunds"><depth> 1.5 </depth><consumers>1</consumers></queue>
+	</queues>
+	<stats pending="7"/>
+</server>`)
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   float64
+		isError    bool
+	}{
+		{"absolute path", "/server/stats/@pending", 7, false},
+		{"relative path", "server/stats/@pending", 7, false},
+		{"attribute predicate", "/server/queues/queue[@name='invoices']/depth", 3, false},
+		{"double quoted predicate", `/server/queues/queue[@name="orders"]/depth/text()`, 12, false},
+		{"child predicate", "//queue[consumers='1']/depth", 1.5, false},
+		{"position predicate", "/server/queues/queue[2]/depth", 3, false},
+		{"attribute presence predicate", "//queue[@durable]/depth", 12, false},
+		{"wildcard", "/server/*/queue[1]/consumers", 2, false},
+		{"descendant", "//stats/@pending", 7, false},
+		{"count", "count(//queue)", 3, false},
+		{"count with predicate", "count(//queue[@name='missing'])", 0, false},
+		{"sum", "sum(/server/queues/queue/depth)", 16.5, false},
+		{"several values", "//depth", 0, true},
+		{"no value", "/server/missing", 0, true},
+		{"not a number", "//queue[1]/@name", 0, true},
+		{"unquoted predicate value", "//queue[@name=orders]/depth", 0, true},
+		{"unbalanced predicate", "//queue[@name='orders'/depth", 0, true},
+		{"attribute before the last step", "//queue/@name/depth", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := getXMLPathValue(body, test.expression)
+			if test.isError {
+				if err == nil {
+					t.Errorf("expected error but got %v", value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected success but got error: %s", err)
+			}
+			if value != test.expected {
+				t.Errorf("expected %v but got %v", test.expected, value)
+			}
+		})
+	}
+
+	if _, err := getXMLPathValue([]byte("not xml"), "/a"); err == nil {
+		t.Error("expected error for a body without root element")
+	}
+}
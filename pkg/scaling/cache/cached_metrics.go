@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+)
+
+const (
+	// cachedMetricsRefreshInterval is the time after which the polling loop writes the cached metrics again
+	// when their values didn't change, so that the metrics server can tell they are still up to date
+	cachedMetricsRefreshInterval = time.Minute
+
+	// cachedMetricsMaxPollingIntervals is the number of polling intervals, on top of cachedMetricsRefreshInterval,
+	// after which the cached metrics are stale, the scalers are queried again when the polling loop stopped
+	// updating them
+	cachedMetricsMaxPollingIntervals = 2
+)
+
+// cachedMetrics are the metric values of the triggers using cached metrics, as persisted in the
+// CachedMetricsAnnotation
+type cachedMetrics struct {
+	Time    metav1.Time                            `json:"time"`
+	Metrics []external_metrics.ExternalMetricValue `json:"metrics"`
+}
+
+// EncodeCachedMetrics returns the annotation value of the metrics fetched by the polling loop at now
+func EncodeCachedMetrics(metrics []external_metrics.ExternalMetricValue, now time.Time) (string, error) {
+	value, err := json.Marshal(cachedMetrics{Time: metav1.NewTime(now), Metrics: metrics})
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// CachedMetricsOutdated returns whether the annotation value of the cached metrics has to be written again with
+// the metrics fetched by the polling loop at now: it is missing or invalid, the values of the metrics changed, or
+// it was written more than cachedMetricsRefreshInterval ago
+func CachedMetricsOutdated(value string, metrics []external_metrics.ExternalMetricValue, now time.Time) bool {
+	cached := cachedMetrics{}
+	if err := json.Unmarshal([]byte(value), &cached); err != nil {
+		return true
+	}
+	if now.Sub(cached.Time.Time) >= cachedMetricsRefreshInterval || len(cached.Metrics) != len(metrics) {
+		return true
+	}
+	// the timestamps of the metrics are the ones of each poll, only the values are compared
+	for i, metric := range metrics {
+		if cached.Metrics[i].MetricName != metric.MetricName || cached.Metrics[i].Value.Cmp(metric.Value) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCachedMetricsForScaler returns the metrics of the scaler fetched by the polling loop, false if its
+// trigger doesn't use cached metrics or they are missing or stale
+func (c *ScalersCache) GetCachedMetricsForScaler(scaledObject *kedav1alpha1.ScaledObject, id int, metricName string, now time.Time) ([]external_metrics.ExternalMetricValue, bool) {
+	if id < 0 || id >= len(scaledObject.Spec.Triggers) || !scaledObject.Spec.Triggers[id].UseCachedMetrics {
+		return nil, false
+	}
+	value, ok := scaledObject.Annotations[kedacontrollerutil.CachedMetricsAnnotation]
+	if !ok {
+		return nil, false
+	}
+	cached := cachedMetrics{}
+	if err := json.Unmarshal([]byte(value), &cached); err != nil {
+		c.Logger.V(1).Info("Ignoring invalid cached metrics", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "Error", err)
+		return nil, false
+	}
+
+	withTriggers := kedav1alpha1.WithTriggers{Spec: kedav1alpha1.WithTriggersSpec{PollingInterval: scaledObject.Spec.PollingInterval}}
+	if now.Sub(cached.Time.Time) > cachedMetricsRefreshInterval+cachedMetricsMaxPollingIntervals*withTriggers.GetPollingInterval() {
+		return nil, false
+	}
+
+	var metrics []external_metrics.ExternalMetricValue
+	for _, metric := range cached.Metrics {
+		if strings.EqualFold(metric.MetricName, metricName) {
+			metrics = append(metrics, metric)
+		}
+	}
+	return metrics, len(metrics) > 0
+}
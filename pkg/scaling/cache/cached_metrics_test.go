@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+func TestGetCachedMetricsForScaler(t *testing.T) {
+	now := time.Now()
+	pollingInterval := int32(30)
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queueLength", Value: *resource.NewQuantity(12, resource.DecimalSI)},
+		{MetricName: "s1-rate", Value: *resource.NewQuantity(3, resource.DecimalSI)},
+	}
+	fresh, err := EncodeCachedMetrics(metrics, now.Add(-30*time.Second))
+	assert.Nil(t, err)
+	stale, err := EncodeCachedMetrics(metrics, now.Add(-150*time.Second))
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name       string
+		annotation string
+		useCached  bool
+		metricName string
+		expected   int64
+		found      bool
+	}{
+		{"fresh metrics", fresh, true, "s0-queueLength", 12, true},
+		{"other metric", fresh, true, "s1-rate", 3, true},
+		{"trigger without cached metrics", fresh, false, "s0-queueLength", 0, false},
+		{"stale metrics", stale, true, "s0-queueLength", 0, false},
+		{"unknown metric", fresh, true, "s0-lag", 0, false},
+		{"no annotation", "", true, "s0-queueLength", 0, false},
+		{"invalid annotation", "{", true, "s0-queueLength", 0, false},
+	}
+
+	c := &ScalersCache{Logger: logr.Discard()}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scaledObject := &kedav1alpha1.ScaledObject{
+				Spec: kedav1alpha1.ScaledObjectSpec{
+					PollingInterval: &pollingInterval,
+					Triggers:        []kedav1alpha1.ScaleTriggers{{Type: "rabbitmq", UseCachedMetrics: tc.useCached}},
+				},
+			}
+			if tc.annotation != "" {
+				scaledObject.Annotations = map[string]string{kedacontrollerutil.CachedMetricsAnnotation: tc.annotation}
+			}
+
+			cached, found := c.GetCachedMetricsForScaler(scaledObject, 0, tc.metricName, now)
+			assert.Equal(t, tc.found, found)
+			if tc.found {
+				assert.Len(t, cached, 1)
+				assert.Equal(t, tc.expected, cached[0].Value.Value())
+			}
+		})
+	}
+}
+
+func TestCachedMetricsOutdated(t *testing.T) {
+	now := time.Now()
+	metrics := []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queueLength", Value: *resource.NewQuantity(12, resource.DecimalSI), Timestamp: metav1.NewTime(now)},
+	}
+	changed := []external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queueLength", Value: *resource.NewQuantity(13, resource.DecimalSI), Timestamp: metav1.NewTime(now)},
+	}
+	recent, err := EncodeCachedMetrics([]external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queueLength", Value: *resource.NewMilliQuantity(12000, resource.DecimalSI), Timestamp: metav1.NewTime(now.Add(-30 * time.Second))},
+	}, now.Add(-30*time.Second))
+	assert.Nil(t, err)
+	old, err := EncodeCachedMetrics(metrics, now.Add(-cachedMetricsRefreshInterval))
+	assert.Nil(t, err)
+
+	assert.False(t, CachedMetricsOutdated(recent, metrics, now), "unchanged metrics written recently")
+	assert.True(t, CachedMetricsOutdated(recent, changed, now), "changed metrics")
+	assert.True(t, CachedMetricsOutdated(recent, append(metrics, changed...), now), "added metric")
+	assert.True(t, CachedMetricsOutdated(old, metrics, now), "unchanged metrics to refresh")
+	assert.True(t, CachedMetricsOutdated("", metrics, now), "no annotation")
+	assert.True(t, CachedMetricsOutdated("{", metrics, now), "invalid annotation")
+}
+
+func TestIsScaledObjectActiveReturnsCachedMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	metricSpecs := func(metricName string) []v2beta2.MetricSpec {
+		return []v2beta2.MetricSpec{createMetricSpec(5, metricName)}
+	}
+
+	// the activity of the triggers with cached metrics is taken from their metrics, IsActive isn't called
+	cachedScaler := mock_scalers.NewMockScaler(ctrl)
+	cachedScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricSpecs("s0-queueLength"))
+	cachedScaler.EXPECT().GetMetrics(gomock.Any(), "s0-queueLength", gomock.Any()).Return([]external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queueLength", Value: *resource.NewQuantity(4, resource.DecimalSI)},
+	}, nil)
+
+	// the metrics of the triggers without cached metrics are only fetched by the metrics server
+	queriedScaler := mock_scalers.NewMockScaler(ctrl)
+	queriedScaler.EXPECT().IsActive(gomock.Any()).Return(true, nil)
+	queriedScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricSpecs("s1-queueLength"))
+
+	c := &ScalersCache{
+		Scalers:  []ScalerBuilder{{Scaler: cachedScaler}, {Scaler: queriedScaler}},
+		Logger:   logr.Discard(),
+		Recorder: record.NewFakeRecorder(1),
+	}
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "test"},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "rabbitmq", UseCachedMetrics: true, Metadata: map[string]string{"activationValue": "4"}},
+				{Type: "rabbitmq"},
+			},
+		},
+	}
+
+	isActive, isError, metrics := c.IsScaledObjectActive(context.Background(), scaledObject)
+	assert.True(t, isActive)
+	assert.False(t, isError)
+	assert.Len(t, metrics, 1)
+	assert.Equal(t, "s0-queueLength", metrics[0].MetricName)
+	assert.Equal(t, int64(4), metrics[0].Value.Value())
+
+	// the cached trigger is active once its metric is above the activation threshold
+	cachedScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricSpecs("s0-queueLength"))
+	cachedScaler.EXPECT().GetMetrics(gomock.Any(), "s0-queueLength", gomock.Any()).Return([]external_metrics.ExternalMetricValue{
+		{MetricName: "s0-queueLength", Value: *resource.NewQuantity(5, resource.DecimalSI)},
+	}, nil)
+	c.Scalers = c.Scalers[:1]
+	scaledObject.Spec.Triggers = scaledObject.Spec.Triggers[:1]
+
+	isActive, isError, metrics = c.IsScaledObjectActive(context.Background(), scaledObject)
+	assert.True(t, isActive)
+	assert.False(t, isError)
+	assert.Len(t, metrics, 1)
+}
+
+func TestIsScaledObjectActiveCachedMetricsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	failingScaler := mock_scalers.NewMockScaler(ctrl)
+	failingScaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2beta2.MetricSpec{createMetricSpec(5, "s0-queueLength")})
+	failingScaler.EXPECT().GetMetrics(gomock.Any(), "s0-queueLength", gomock.Any()).Return(nil, errors.New("unavailable"))
+
+	recorder := record.NewFakeRecorder(1)
+	c := &ScalersCache{
+		Scalers: []ScalerBuilder{{
+			Scaler: failingScaler,
+			Factory: func() (scalers.Scaler, error) {
+				return nil, errors.New("unavailable")
+			},
+		}},
+		Logger:   logr.Discard(),
+		Recorder: recorder,
+	}
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "test"},
+			Triggers:       []kedav1alpha1.ScaleTriggers{{Type: "rabbitmq", UseCachedMetrics: true}},
+		},
+	}
+
+	// the error is reported and no metric is cached for the trigger
+	isActive, isError, metrics := c.IsScaledObjectActive(context.Background(), scaledObject)
+	assert.False(t, isActive)
+	assert.True(t, isError)
+	assert.Empty(t, metrics)
+	assert.Len(t, recorder.Events, 1)
+}
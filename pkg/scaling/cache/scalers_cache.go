@@ -36,14 +36,21 @@ import (
 
 type ScalersCache struct {
 	Generation int64
-	Scalers    []ScalerBuilder
-	Logger     logr.Logger
-	Recorder   record.EventRecorder
+	// TemplateGeneration is the generation of the ScalingTemplate of the ScaledObject, 0 without template
+	TemplateGeneration int64
+	Scalers            []ScalerBuilder
+	Logger             logr.Logger
+	Recorder           record.EventRecorder
+	// BuildError is the error building the scalers of a newer generation, the scalers
+	// of the last generation which could be built are used until it is fixed
+	BuildError error
 }
 
 type ScalerBuilder struct {
 	Scaler  scalers.Scaler
 	Factory func() (scalers.Scaler, error)
+	// QueryRecorder keeps the last query of the scalers built by the factory, nil for the activation sources
+	QueryRecorder *scalers.QueryRecorder
 }
 
 func (c *ScalersCache) GetScalers() []scalers.Scaler {
@@ -64,6 +71,25 @@ func (c *ScalersCache) GetPushScalers() []scalers.PushScaler {
 	return result
 }
 
+// GetScaledJobPriorities returns the priorities of the pending work reported by the scalers,
+// the scalers failing to report them are skipped
+func (c *ScalersCache) GetScaledJobPriorities(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) []string {
+	var result []string
+	for _, s := range c.Scalers {
+		ps, ok := s.Scaler.(scalers.JobPriorityScaler)
+		if !ok {
+			continue
+		}
+		priorities, err := ps.GetJobPriorities(ctx)
+		if err != nil {
+			c.Logger.V(1).Info("Error getting the job priorities, but continue", "ScaledJob", scaledJob.Name, "Error", err)
+			continue
+		}
+		result = append(result, priorities...)
+	}
+	return result
+}
+
 func (c *ScalersCache) GetMetricsForScaler(ctx context.Context, id int, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
 	if id < 0 || id >= len(c.Scalers) {
 		return nil, fmt.Errorf("scaler with id %d not found. Len = %d", id, len(c.Scalers))
@@ -81,11 +107,60 @@ func (c *ScalersCache) GetMetricsForScaler(ctx context.Context, id int, metricNa
 	return ns.GetMetrics(ctx, metricName, metricSelector)
 }
 
+// IsScaledObjectActive returns whether a trigger of the ScaledObject is active and whether a trigger failed,
+// with the metrics of the triggers using cached metrics. With ScalingModifiers, the triggers are active
+// when the composite metric is above its activation target.
 func (c *ScalersCache) IsScaledObjectActive(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) (bool, bool, []external_metrics.ExternalMetricValue) {
 	isActive := false
 	isError := false
+	metrics := []external_metrics.ExternalMetricValue{}
+
+	logger := c.Logger.WithValues("scaledobject.Name", scaledObject.Name, "scaledObject.Namespace", scaledObject.Namespace,
+		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
+
+	compositeMetric, err := ParseScalingModifiers(scaledObject)
+	if err != nil {
+		logger.Error(err, "Error parsing scalingModifiers")
+		c.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+		return false, true, metrics
+	}
+	triggerValues := map[string]float64{}
+
 	// Let's collect status of all scalers, no matter if any scaler raises error or is active
 	for i, s := range c.Scalers {
+		if compositeMetric != nil && i < len(scaledObject.Spec.Triggers) {
+			triggerMetrics, err := c.getScalerMetrics(ctx, i)
+			if err != nil {
+				isError = true
+				logger.Error(err, "Error getting the metrics of the composite metric")
+				c.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+				continue
+			}
+			AddTriggerMetrics(triggerValues, scaledObject.Spec.Triggers[i], triggerMetrics)
+			if scaledObject.Spec.Triggers[i].UseCachedMetrics {
+				metrics = append(metrics, triggerMetrics...)
+			}
+			continue
+		}
+
+		// the activity of the triggers using cached metrics is taken from the metrics to cache, so that their
+		// scaler is queried once
+		if i < len(scaledObject.Spec.Triggers) && scaledObject.Spec.Triggers[i].UseCachedMetrics {
+			triggerMetrics, isTriggerActive, err := c.getScalerMetricsAndActivity(ctx, i, scaledObject.Spec.Triggers[i])
+			if err != nil {
+				isError = true
+				logger.Error(err, "Error getting the metrics to cache")
+				c.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+				continue
+			}
+			metrics = append(metrics, triggerMetrics...)
+			if isTriggerActive {
+				isActive = true
+				logger.V(1).Info("Scaler for scaledObject is active", "scalerIndex", i)
+			}
+			continue
+		}
+
 		isTriggerActive, err := s.Scaler.IsActive(ctx)
 		if err != nil {
 			var ns scalers.Scaler
@@ -95,25 +170,96 @@ func (c *ScalersCache) IsScaledObjectActive(ctx context.Context, scaledObject *k
 			}
 		}
 
-		logger := c.Logger.WithValues("scaledobject.Name", scaledObject.Name, "scaledObject.Namespace", scaledObject.Namespace,
-			"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
-
 		if err != nil {
 			isError = true
 			logger.Error(err, "Error getting scale decision")
 			c.Recorder.Event(scaledObject, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
-		} else if isTriggerActive {
+			continue
+		}
+
+		if isTriggerActive {
 			isActive = true
-			if externalMetricsSpec := s.Scaler.GetMetricSpecForScaling(ctx)[0].External; externalMetricsSpec != nil {
+			// activation sources don't have any metric spec
+			metricSpecs := s.Scaler.GetMetricSpecForScaling(ctx)
+			if len(metricSpecs) == 0 {
+				logger.V(1).Info("Activation source for scaledObject is active", "scalerIndex", i)
+				continue
+			}
+			if externalMetricsSpec := metricSpecs[0].External; externalMetricsSpec != nil {
 				logger.V(1).Info("Scaler for scaledObject is active", "Metrics Name", externalMetricsSpec.Metric.Name)
 			}
-			if resourceMetricsSpec := s.Scaler.GetMetricSpecForScaling(ctx)[0].Resource; resourceMetricsSpec != nil {
+			if resourceMetricsSpec := metricSpecs[0].Resource; resourceMetricsSpec != nil {
 				logger.V(1).Info("Scaler for scaledObject is active", "Metrics Name", resourceMetricsSpec.Name)
 			}
 		}
 	}
 
-	return isActive, isError, []external_metrics.ExternalMetricValue{}
+	if compositeMetric != nil {
+		value, err := compositeMetric.Formula.Evaluate(triggerValues)
+		if err != nil {
+			isError = true
+			logger.Error(err, "Error evaluating the formula of scalingModifiers")
+		} else if value > compositeMetric.ActivationTarget {
+			isActive = true
+			logger.V(1).Info("Composite metric for scaledObject is active", "Metrics Name", kedav1alpha1.CompositeMetricName, "Value", value)
+		}
+	}
+
+	return isActive, isError, metrics
+}
+
+// getScalerMetrics returns the external metrics of the scaler, the metrics fetched before an error are
+// returned with it
+func (c *ScalersCache) getScalerMetrics(ctx context.Context, id int) ([]external_metrics.ExternalMetricValue, error) {
+	var metrics []external_metrics.ExternalMetricValue
+	for _, metricSpec := range c.Scalers[id].Scaler.GetMetricSpecForScaling(ctx) {
+		if metricSpec.External == nil {
+			continue
+		}
+		m, err := c.GetMetricsForScaler(ctx, id, metricSpec.External.Metric.Name, labels.Everything())
+		if err != nil {
+			return metrics, err
+		}
+		metrics = append(metrics, m...)
+	}
+	return metrics, nil
+}
+
+// getScalerMetricsAndActivity returns the external metrics of the scaler and whether it is active, each metric is
+// fetched once. The scalers which can't tell their activity from their metrics are active once one of them is
+// above the activation threshold of the trigger, 0 by default.
+func (c *ScalersCache) getScalerMetricsAndActivity(ctx context.Context, id int, trigger kedav1alpha1.ScaleTriggers) ([]external_metrics.ExternalMetricValue, bool, error) {
+	threshold, err := scalers.GetActivationThreshold(trigger.Metadata)
+	if err != nil {
+		return nil, false, err
+	}
+	activationThreshold := 0.0
+	if threshold != nil {
+		activationThreshold = *threshold
+	}
+
+	var metrics []external_metrics.ExternalMetricValue
+	isActive := false
+	for _, metricSpec := range c.Scalers[id].Scaler.GetMetricSpecForScaling(ctx) {
+		if metricSpec.External == nil {
+			continue
+		}
+		metricName := metricSpec.External.Metric.Name
+		m, active, err := scalers.GetMetricsAndActivity(ctx, c.Scalers[id].Scaler, metricName, labels.Everything(), activationThreshold)
+		if err != nil {
+			var ns scalers.Scaler
+			ns, err = c.refreshScaler(ctx, id)
+			if err == nil {
+				m, active, err = scalers.GetMetricsAndActivity(ctx, ns, metricName, labels.Everything(), activationThreshold)
+			}
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		metrics = append(metrics, m...)
+		isActive = isActive || active
+	}
+	return metrics, isActive, nil
 }
 
 func (c *ScalersCache) IsScaledJobActive(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (bool, int64, int64) {
@@ -208,8 +354,9 @@ func (c *ScalersCache) refreshScaler(ctx context.Context, id int) (scalers.Scale
 	}
 
 	c.Scalers[id] = ScalerBuilder{
-		Scaler:  ns,
-		Factory: sb.Factory,
+		Scaler:        ns,
+		Factory:       sb.Factory,
+		QueryRecorder: sb.QueryRecorder,
 	}
 	sb.Scaler.Close(ctx)
 
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+// CompositeMetric is the metric combining the metrics of the triggers of a ScaledObject with the formula of
+// its ScalingModifiers
+type CompositeMetric struct {
+	Formula          *kedautil.Formula
+	Target           float64
+	ActivationTarget float64
+	MetricType       v2beta2.MetricTargetType
+}
+
+// ParseScalingModifiers returns the composite metric of the ScalingModifiers of the ScaledObject, nil
+// without ScalingModifiers. The formula can only reference the names of the triggers.
+func ParseScalingModifiers(scaledObject *kedav1alpha1.ScaledObject) (*CompositeMetric, error) {
+	if scaledObject.Spec.Advanced == nil || scaledObject.Spec.Advanced.ScalingModifiers == nil {
+		return nil, nil
+	}
+	modifiers := scaledObject.Spec.Advanced.ScalingModifiers
+	if scaledObject.GetTriggerAggregation() == kedav1alpha1.TriggerAggregationSum {
+		return nil, fmt.Errorf("scalingModifiers can't be combined with the sum triggerAggregation")
+	}
+	if modifiers.Formula == "" {
+		return nil, fmt.Errorf("scalingModifiers must have a formula")
+	}
+
+	var names []string
+	for _, trigger := range scaledObject.Spec.Triggers {
+		if trigger.Name != "" {
+			names = append(names, trigger.Name)
+		}
+	}
+	formula, err := kedautil.ParseConditionalFormula(modifiers.Formula, names)
+	if err != nil {
+		return nil, err
+	}
+
+	metric := &CompositeMetric{Formula: formula, MetricType: v2beta2.AverageValueMetricType}
+	metric.Target, err = strconv.ParseFloat(modifiers.Target, 64)
+	if err != nil || metric.Target <= 0 {
+		return nil, fmt.Errorf("scalingModifiers target must be a number greater than 0, got %q", modifiers.Target)
+	}
+	if modifiers.ActivationTarget != "" {
+		metric.ActivationTarget, err = strconv.ParseFloat(modifiers.ActivationTarget, 64)
+		if err != nil {
+			return nil, fmt.Errorf("scalingModifiers activationTarget must be a number, got %q", modifiers.ActivationTarget)
+		}
+	}
+	switch modifiers.MetricType {
+	case "":
+	case v2beta2.AverageValueMetricType, v2beta2.ValueMetricType:
+		metric.MetricType = modifiers.MetricType
+	default:
+		return nil, fmt.Errorf("scalingModifiers metricType must be AverageValue or Value, got %s", modifiers.MetricType)
+	}
+	return metric, nil
+}
+
+// AddTriggerMetrics adds the values of the metrics of the trigger to the values the formula is evaluated
+// with, the triggers without name can't be referenced
+func AddTriggerMetrics(triggerValues map[string]float64, trigger kedav1alpha1.ScaleTriggers, metrics []external_metrics.ExternalMetricValue) {
+	if trigger.Name == "" {
+		return
+	}
+	value := triggerValues[trigger.Name]
+	for _, metric := range metrics {
+		value += metric.Value.AsApproximateFloat64()
+	}
+	triggerValues[trigger.Name] = value
+}
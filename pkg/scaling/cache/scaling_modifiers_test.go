@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	mock_scalers "github.com/kedacore/keda/v2/pkg/mock/mock_scaler"
+)
+
+func createScalingModifiersScaledObject(advanced *kedav1alpha1.AdvancedConfig) *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "test"},
+			Advanced:       advanced,
+			Triggers:       []kedav1alpha1.ScaleTriggers{{Type: "rabbitmq", Name: "trig1"}, {Type: "kafka", Name: "trig2"}},
+		},
+	}
+}
+
+func TestParseScalingModifiers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		advanced *kedav1alpha1.AdvancedConfig
+		expected *CompositeMetric
+		isError  bool
+	}{
+		{"no advanced config", nil, nil, false},
+		{"no scaling modifiers", &kedav1alpha1.AdvancedConfig{}, nil, false},
+		{"default metric type", &kedav1alpha1.AdvancedConfig{ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "trig1 + trig2", Target: "2.5"}},
+			&CompositeMetric{Target: 2.5, MetricType: v2beta2.AverageValueMetricType}, false},
+		{"value metric type", &kedav1alpha1.AdvancedConfig{ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "trig1 > 1 ? trig1 : trig2", Target: "10", ActivationTarget: "3", MetricType: v2beta2.ValueMetricType}},
+			&CompositeMetric{Target: 10, ActivationTarget: 3, MetricType: v2beta2.ValueMetricType}, false},
+		{"unknown trigger", &kedav1alpha1.AdvancedConfig{ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "trig1 + trig3", Target: "1"}}, nil, true},
+		{"no formula", &kedav1alpha1.AdvancedConfig{ScalingModifiers: &kedav1alpha1.ScalingModifiers{Target: "1"}}, nil, true},
+		{"no target", &kedav1alpha1.AdvancedConfig{ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "trig1"}}, nil, true},
+		{"zero target", &kedav1alpha1.AdvancedConfig{ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "trig1", Target: "0"}}, nil, true},
+		{"invalid activation target", &kedav1alpha1.AdvancedConfig{ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "trig1", Target: "1", ActivationTarget: "a"}}, nil, true},
+		{"utilization metric type", &kedav1alpha1.AdvancedConfig{ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "trig1", Target: "1", MetricType: v2beta2.UtilizationMetricType}}, nil, true},
+		{"sum aggregation", &kedav1alpha1.AdvancedConfig{TriggerAggregation: kedav1alpha1.TriggerAggregationSum, ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "trig1", Target: "1"}}, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			metric, err := ParseScalingModifiers(createScalingModifiersScaledObject(tc.advanced))
+			assert.Equal(t, tc.isError, err != nil, "error %v", err)
+			if tc.expected == nil {
+				assert.Nil(t, metric)
+				return
+			}
+			assert.NotNil(t, metric.Formula)
+			assert.Equal(t, tc.expected.Target, metric.Target)
+			assert.Equal(t, tc.expected.ActivationTarget, metric.ActivationTarget)
+			assert.Equal(t, tc.expected.MetricType, metric.MetricType)
+		})
+	}
+}
+
+func TestIsScaledObjectActiveWithScalingModifiers(t *testing.T) {
+	createScaler := func(ctrl *gomock.Controller, metricName string, value int64) *mock_scalers.MockScaler {
+		scaler := mock_scalers.NewMockScaler(ctrl)
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return([]v2beta2.MetricSpec{createMetricSpec(5, metricName)})
+		scaler.EXPECT().GetMetrics(gomock.Any(), metricName, gomock.Any()).Return([]external_metrics.ExternalMetricValue{
+			{MetricName: metricName, Value: *resource.NewQuantity(value, resource.DecimalSI)},
+		}, nil)
+		return scaler
+	}
+
+	testCases := []struct {
+		name             string
+		activationTarget string
+		expected         bool
+	}{
+		{"above the activation target", "3", true},
+		{"at the activation target", "4", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			// the triggers are only active through the composite metric
+			c := &ScalersCache{
+				Scalers: []ScalerBuilder{
+					{Scaler: createScaler(ctrl, "s0-queueLength", 6)},
+					{Scaler: createScaler(ctrl, "s1-lag", 2)},
+				},
+				Logger:   logr.Discard(),
+				Recorder: record.NewFakeRecorder(1),
+			}
+			scaledObject := createScalingModifiersScaledObject(&kedav1alpha1.AdvancedConfig{
+				ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "(trig1 + trig2)/2", Target: "1", ActivationTarget: tc.activationTarget},
+			})
+
+			isActive, isError, _ := c.IsScaledObjectActive(context.Background(), scaledObject)
+			assert.False(t, isError)
+			assert.Equal(t, tc.expected, isActive)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	jobStateConfigMapPrefix = "keda-scaledjob-state-"
+	jobStateKey             = "state"
+	// jobStateCreatedJobTTL is how long a created Job which isn't listed is accounted for,
+	// so Jobs deleted before they have been observed aren't counted forever
+	jobStateCreatedJobTTL = 10 * time.Minute
+)
+
+// jobState is the accounting of a ScaledJob which is persisted, so the Jobs created right before
+// an operator restart or a leader change are counted even if they aren't listed yet
+type jobState struct {
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+	LastScaleJobs int64        `json:"lastScaleJobs,omitempty"`
+	// CreatedJobs are the Jobs created by KEDA which haven't been listed yet, by name
+	CreatedJobs map[string]metav1.Time `json:"createdJobs,omitempty"`
+}
+
+// jobStateStore persists the jobState of ScaledJobs
+type jobStateStore interface {
+	Get(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (*jobState, error)
+	Save(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, state *jobState) error
+}
+
+// configMapJobStateStore persists the jobState in a ConfigMap owned by the ScaledJob,
+// so it is garbage collected together with the ScaledJob
+type configMapJobStateStore struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+var jobStatePersistedCache *bool
+
+// isJobStatePersisted returns true if the accounting of the ScaledJobs is persisted
+func isJobStatePersisted() (bool, error) {
+	if jobStatePersistedCache != nil {
+		return *jobStatePersistedCache, nil
+	}
+	persisted, err := kedautil.ResolveOsEnvBool("KEDA_SCALEDJOB_PERSIST_STATE", false)
+	if err != nil {
+		return false, fmt.Errorf("invalid KEDA_SCALEDJOB_PERSIST_STATE: %s", err)
+	}
+	jobStatePersistedCache = &persisted
+	return persisted, nil
+}
+
+func jobStateConfigMapName(scaledJob *kedav1alpha1.ScaledJob) string {
+	return jobStateConfigMapPrefix + scaledJob.Name
+}
+
+func (s *configMapJobStateStore) Get(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) (*jobState, error) {
+	state := &jobState{}
+	configMap := &corev1.ConfigMap{}
+	err := s.client.Get(ctx, types.NamespacedName{Name: jobStateConfigMapName(scaledJob), Namespace: scaledJob.Namespace}, configMap)
+	if errors.IsNotFound(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if data := configMap.Data[jobStateKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), state); err != nil {
+			return nil, fmt.Errorf("error decoding ScaledJob state: %s", err)
+		}
+	}
+	return state, nil
+}
+
+func (s *configMapJobStateStore) Save(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, state *jobState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobStateConfigMapName(scaledJob),
+			Namespace: scaledJob.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, s.client, configMap, func() error {
+		configMap.Data = map[string]string{jobStateKey: string(data)}
+		return controllerutil.SetControllerReference(scaledJob, configMap, s.scheme)
+	})
+	return err
+}
+
+// unobservedJobCount removes the created Jobs which are listed, or which have expired, from the state
+// and returns the number of remaining ones
+func (state *jobState) unobservedJobCount(jobs []batchv1.Job, now time.Time) int64 {
+	listed := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		listed[job.Name] = true
+	}
+
+	for name, created := range state.CreatedJobs {
+		if listed[name] || now.Sub(created.Time) > jobStateCreatedJobTTL {
+			delete(state.CreatedJobs, name)
+		}
+	}
+	return int64(len(state.CreatedJobs))
+}
+
+// recordScale adds the created Jobs to the state
+func (state *jobState) recordScale(createdJobs []string, now time.Time) {
+	scaleTime := metav1.NewTime(now)
+	state.LastScaleTime = &scaleTime
+	state.LastScaleJobs = int64(len(createdJobs))
+	if state.CreatedJobs == nil {
+		state.CreatedJobs = make(map[string]metav1.Time, len(createdJobs))
+	}
+	for _, name := range createdJobs {
+		state.CreatedJobs[name] = scaleTime
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestJobStateUnobservedJobCount(t *testing.T) {
+	now := time.Now()
+	state := &jobState{}
+	state.recordScale([]string{"job-a", "job-b"}, now.Add(-jobStateCreatedJobTTL-time.Minute))
+	state.recordScale([]string{"job-c", "job-d"}, now)
+	assert.Equal(t, int64(2), state.LastScaleJobs)
+
+	// job-a and job-b have expired, job-c is listed
+	count := state.unobservedJobCount([]batchv1.Job{{ObjectMeta: metav1.ObjectMeta{Name: "job-c"}}}, now)
+	assert.Equal(t, int64(1), count)
+	assert.Contains(t, state.CreatedJobs, "job-d")
+}
+
+func TestConfigMapJobStateStore(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	scaledJob := &kedav1alpha1.ScaledJob{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"}}
+	store := &configMapJobStateStore{client: fake.NewClientBuilder().WithScheme(scheme).Build(), scheme: scheme}
+
+	state, err := store.Get(context.Background(), scaledJob)
+	assert.NoError(t, err)
+	assert.Empty(t, state.CreatedJobs)
+
+	state.recordScale([]string{"job-a"}, time.Now())
+	assert.NoError(t, store.Save(context.Background(), scaledJob, state))
+
+	// the state survives a new store, like after an operator restart
+	restored, err := (&configMapJobStateStore{client: store.client, scheme: scheme}).Get(context.Background(), scaledJob)
+	assert.NoError(t, err)
+	assert.Contains(t, restored.CreatedJobs, "job-a")
+	assert.Equal(t, int64(1), restored.LastScaleJobs)
+}
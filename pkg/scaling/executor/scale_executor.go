@@ -38,7 +38,7 @@ const (
 
 // ScaleExecutor contains methods RequestJobScale and RequestScale
 type ScaleExecutor interface {
-	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64)
+	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64, priorities []string)
 	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool)
 }
 
@@ -48,17 +48,28 @@ type scaleExecutor struct {
 	reconcilerScheme *runtime.Scheme
 	logger           logr.Logger
 	recorder         record.EventRecorder
+	// jobStateStore persists the accounting of the ScaledJobs, it is nil when it isn't persisted
+	jobStateStore jobStateStore
 }
 
 // NewScaleExecutor creates a ScaleExecutor object
 func NewScaleExecutor(client runtimeclient.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, recorder record.EventRecorder) ScaleExecutor {
-	return &scaleExecutor{
+	executor := &scaleExecutor{
 		client:           client,
 		scaleClient:      scaleClient,
 		reconcilerScheme: reconcilerScheme,
 		logger:           logf.Log.WithName("scaleexecutor"),
 		recorder:         recorder,
 	}
+
+	persisted, err := isJobStatePersisted()
+	if err != nil {
+		executor.logger.Error(err, "ScaledJob state won't be persisted")
+	}
+	if persisted {
+		executor.jobStateStore = &configMapJobStateStore{client: client, scheme: reconcilerScheme}
+	}
+	return executor
 }
 
 func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Logger, object interface{}) error {
@@ -86,6 +97,28 @@ func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Lo
 	return err
 }
 
+// recordScalingDecision adds the replica count change to the ScaledObject scaling history, when enabled
+func (e *scaleExecutor) recordScalingDecision(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, fromReplicas int32, toReplicas int32, reason string, message string) error {
+	if scaledObject.Spec.Advanced == nil || scaledObject.Spec.Advanced.ScalingHistoryLimit == nil || *scaledObject.Spec.Advanced.ScalingHistoryLimit <= 0 {
+		return nil
+	}
+
+	patch := runtimeclient.MergeFrom(scaledObject.DeepCopy())
+	scaledObject.Status.AddScalingDecision(kedav1alpha1.ScalingDecision{
+		Time:         metav1.Now(),
+		FromReplicas: fromReplicas,
+		ToReplicas:   toReplicas,
+		Reason:       reason,
+		Message:      message,
+	}, *scaledObject.Spec.Advanced.ScalingHistoryLimit)
+
+	err := e.client.Status().Patch(ctx, scaledObject, patch)
+	if err != nil {
+		logger.Error(err, "Failed to patch Objects Status")
+	}
+	return err
+}
+
 func (e *scaleExecutor) setCondition(ctx context.Context, logger logr.Logger, object interface{}, status metav1.ConditionStatus, reason string, message string, setCondition func(kedav1alpha1.Conditions, metav1.ConditionStatus, string, string)) error {
 	var patch runtimeclient.Patch
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/go-logr/logr"
 	batchv1 "k8s.io/api/batch/v1"
@@ -38,11 +39,20 @@ const (
 	defaultFailedJobsHistoryLimit     = int32(100)
 )
 
-func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64) {
+func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64, priorities []string) {
 	logger := e.logger.WithValues("scaledJob.Name", scaledJob.Name, "scaledJob.Namespace", scaledJob.Namespace)
 
 	runningJobCount := e.getRunningJobCount(ctx, scaledJob)
 	pendingJobCount := e.getPendingJobCount(ctx, scaledJob)
+
+	state := e.getJobState(ctx, logger, scaledJob)
+	if state != nil {
+		// Jobs created by the previous scale actions which aren't listed yet are still starting
+		unobservedJobCount := state.unobservedJobCount(e.getJobs(ctx, scaledJob), time.Now())
+		runningJobCount += unobservedJobCount
+		pendingJobCount += unobservedJobCount
+	}
+
 	logger.Info("Scaling Jobs", "Number of running Jobs", runningJobCount)
 	logger.Info("Scaling Jobs", "Number of pending Jobs ", pendingJobCount)
 
@@ -60,11 +70,20 @@ func (e *scaleExecutor) RequestJobScale(ctx context.Context, scaledJob *kedav1al
 		if err != nil {
 			logger.Error(err, "Failed to update last active time")
 		}
-		e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale)
+		createdJobs := e.createJobs(ctx, logger, scaledJob, scaleTo, effectiveMaxScale, getJobPriorityMapping(scaledJob, priorities))
+		if state != nil && len(createdJobs) > 0 {
+			state.recordScale(createdJobs, time.Now())
+		}
 	} else {
 		logger.V(1).Info("No change in activity")
 	}
 
+	if state != nil {
+		if err := e.jobStateStore.Save(ctx, scaledJob, state); err != nil {
+			logger.Error(err, "Failed to persist ScaledJob state")
+		}
+	}
+
 	condition := scaledJob.Status.Conditions.GetActiveCondition()
 	if condition.IsUnknown() || condition.IsTrue() != isActive {
 		if isActive {
@@ -100,7 +119,22 @@ func (e *scaleExecutor) getScalingDecision(scaledJob *kedav1alpha1.ScaledJob, ru
 	return effectiveMaxScale, scaleTo
 }
 
-func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, maxScale int64) {
+// getJobPriorityMapping returns the first mapping of the ScaledJob whose priority is reported by the triggers
+func getJobPriorityMapping(scaledJob *kedav1alpha1.ScaledJob, priorities []string) *kedav1alpha1.JobPriorityMapping {
+	reported := make(map[string]bool, len(priorities))
+	for _, priority := range priorities {
+		reported[priority] = true
+	}
+	for i, mapping := range scaledJob.Spec.PriorityMapping {
+		if reported[mapping.Priority] {
+			return &scaledJob.Spec.PriorityMapping[i]
+		}
+	}
+	return nil
+}
+
+// createJobs creates the Jobs, with the priority class and deadline of the priority mapping if any, and returns the names of the created ones
+func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob, scaleTo int64, maxScale int64, priorityMapping *kedav1alpha1.JobPriorityMapping) []string {
 	scaledJob.Spec.JobTargetRef.Template.GenerateName = scaledJob.GetName() + "-"
 	if scaledJob.Spec.JobTargetRef.Template.Labels == nil {
 		scaledJob.Spec.JobTargetRef.Template.Labels = map[string]string{}
@@ -113,6 +147,9 @@ func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scal
 		scaleTo = maxScale
 	}
 	logger.Info("Creating jobs", "Number of jobs", scaleTo)
+	if priorityMapping != nil {
+		logger.V(1).Info("Creating jobs with priority", "Priority", priorityMapping.Priority)
+	}
 
 	labels := map[string]string{
 		"app.kubernetes.io/name":       scaledJob.GetName(),
@@ -125,6 +162,7 @@ func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scal
 		labels[key] = value
 	}
 
+	createdJobs := make([]string, 0, scaleTo)
 	for i := 0; i < int(scaleTo); i++ {
 		job := &batchv1.Job{
 			ObjectMeta: metav1.ObjectMeta{
@@ -142,6 +180,18 @@ func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scal
 			job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
 		}
 
+		if priorityMapping != nil {
+			if priorityMapping.PriorityClassName != "" {
+				job.Spec.Template.Spec.PriorityClassName = priorityMapping.PriorityClassName
+				// the priority is resolved from the class by the admission, a copied value would be rejected
+				job.Spec.Template.Spec.Priority = nil
+			}
+			if priorityMapping.ActiveDeadlineSeconds != nil {
+				deadline := *priorityMapping.ActiveDeadlineSeconds
+				job.Spec.ActiveDeadlineSeconds = &deadline
+			}
+		}
+
 		// Set ScaledJob instance as the owner and controller
 		err := controllerutil.SetControllerReference(scaledJob, job, e.reconcilerScheme)
 		if err != nil {
@@ -151,10 +201,40 @@ func (e *scaleExecutor) createJobs(ctx context.Context, logger logr.Logger, scal
 		err = e.client.Create(ctx, job)
 		if err != nil {
 			logger.Error(err, "Failed to create a new Job")
+			continue
 		}
+		createdJobs = append(createdJobs, job.Name)
 	}
 	logger.Info("Created jobs", "Number of jobs", scaleTo)
 	e.recorder.Eventf(scaledJob, corev1.EventTypeNormal, eventreason.KEDAJobsCreated, "Created %d jobs", scaleTo)
+	return createdJobs
+}
+
+// getJobState returns the persisted state of the ScaledJob, or nil when it isn't persisted
+func (e *scaleExecutor) getJobState(ctx context.Context, logger logr.Logger, scaledJob *kedav1alpha1.ScaledJob) *jobState {
+	if e.jobStateStore == nil {
+		return nil
+	}
+
+	state, err := e.jobStateStore.Get(ctx, scaledJob)
+	if err != nil {
+		logger.Error(err, "Failed to get ScaledJob state")
+		return nil
+	}
+	return state
+}
+
+func (e *scaleExecutor) getJobs(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob) []batchv1.Job {
+	opts := []client.ListOption{
+		client.InNamespace(scaledJob.GetNamespace()),
+		client.MatchingLabels(map[string]string{"scaledjob.keda.sh/name": scaledJob.GetName()}),
+	}
+
+	jobs := &batchv1.JobList{}
+	if err := e.client.List(ctx, jobs, opts...); err != nil {
+		return nil
+	}
+	return jobs.Items
 }
 
 func (e *scaleExecutor) isJobFinished(j *batchv1.Job) bool {
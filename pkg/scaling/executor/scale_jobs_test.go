@@ -28,6 +28,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -286,6 +287,54 @@ func TestGetPendingJobCount(t *testing.T) {
 	}
 }
 
+func TestGetJobPriorityMapping(t *testing.T) {
+	deadline := int64(600)
+	scaledJob := &kedav1alpha1.ScaledJob{
+		Spec: kedav1alpha1.ScaledJobSpec{
+			PriorityMapping: []kedav1alpha1.JobPriorityMapping{
+				{Priority: "high", PriorityClassName: "high-priority", ActiveDeadlineSeconds: &deadline},
+				{Priority: "low", PriorityClassName: "low-priority"},
+			},
+		},
+	}
+
+	assert.Nil(t, getJobPriorityMapping(scaledJob, nil))
+	assert.Nil(t, getJobPriorityMapping(scaledJob, []string{"medium"}))
+	assert.Equal(t, "low-priority", getJobPriorityMapping(scaledJob, []string{"low", "medium"}).PriorityClassName)
+	// the first mapping of the ScaledJob is applied whatever the order of the reported priorities
+	assert.Equal(t, "high-priority", getJobPriorityMapping(scaledJob, []string{"low", "high"}).PriorityClassName)
+}
+
+func TestCreateJobsWithPriorityMapping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var created []*batchv1.Job
+	client := mock_client.NewMockClient(ctrl)
+	client.EXPECT().Create(gomock.Any(), gomock.Any()).Do(func(_ context.Context, obj runtimeclient.Object, _ ...runtimeclient.CreateOption) {
+		created = append(created, obj.(*batchv1.Job))
+	}).Return(nil).Times(2)
+
+	scaleExecutor := getMockScaleExecutor(client)
+	scaleExecutor.recorder = record.NewFakeRecorder(1)
+	scaleExecutor.reconcilerScheme = runtime.NewScheme()
+	assert.NoError(t, kedav1alpha1.AddToScheme(scaleExecutor.reconcilerScheme))
+	scaledJob := getMockScaledJobWithDefault()
+	scaledJob.Spec.JobTargetRef = &batchv1.JobSpec{}
+
+	deadline := int64(600)
+	scaleExecutor.createJobs(context.Background(), scaleExecutor.logger, scaledJob, 2, 2, &kedav1alpha1.JobPriorityMapping{Priority: "high", PriorityClassName: "high-priority", ActiveDeadlineSeconds: &deadline})
+
+	assert.Len(t, created, 2)
+	for _, job := range created {
+		assert.Equal(t, "high-priority", job.Spec.Template.Spec.PriorityClassName)
+		assert.Equal(t, int64(600), *job.Spec.ActiveDeadlineSeconds)
+	}
+	// the template of the ScaledJob isn't changed
+	assert.Empty(t, scaledJob.Spec.JobTargetRef.Template.Spec.PriorityClassName)
+	assert.Nil(t, scaledJob.Spec.JobTargetRef.ActiveDeadlineSeconds)
+}
+
 type mockJobParameter struct {
 	Name             string
 	CompletionTime   string
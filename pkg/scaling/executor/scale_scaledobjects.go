@@ -18,6 +18,7 @@ package executor
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -33,6 +34,13 @@ import (
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 )
 
+const (
+	// reasons of the scaling decisions which aren't reported as an event
+	scalingDecisionPausedReason          = "PausedReplicaCount"
+	scalingDecisionFallbackReason        = "FallbackReplicaCount"
+	scalingDecisionMinReplicaCountReason = "MinReplicaCount"
+)
+
 func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool) {
 	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
 		"scaledObject.Namespace", scaledObject.Namespace,
@@ -92,6 +100,12 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		return
 	}
 
+	pausedDirection, err := GetPausedScaleDirection(scaledObject)
+	if err != nil {
+		logger.Error(err, "error getting the paused scale direction on the current ScaledObject.")
+		return
+	}
+
 	status := scaledObject.Status.DeepCopy()
 	if pausedCount != nil {
 		// Scale the target to the paused replica count
@@ -112,6 +126,9 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 				return
 			}
 			logger.Info("Successfully scaled target to paused replicas count", "paused replicas", *pausedCount)
+			if err := e.recordScalingDecision(ctx, logger, scaledObject, currentReplicas, *pausedCount, scalingDecisionPausedReason, "Scaled to the paused replica count"); err != nil {
+				logger.Error(err, "Error recording scaling decision")
+			}
 		}
 		return
 	}
@@ -135,7 +152,11 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// replica count is equal to 0
 
 			// Scale the ScaleTarget up
-			e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale)
+			if pausedDirection == kedacontrollerutil.PausedScaleDirectionUp {
+				logger.V(1).Info("ScaleTarget not scaled up from zero or idle, scale-up is paused")
+			} else {
+				e.scaleFromZeroOrIdle(ctx, logger, scaledObject, currentScale)
+			}
 		case isError:
 			// some triggers are active, but some responded with error
 
@@ -166,7 +187,11 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// there is a fallback replicas count defined
 
 			// Scale to the fallback replicas count
-			e.doFallbackScaling(ctx, scaledObject, currentScale, logger, currentReplicas)
+			if isScalePaused(pausedDirection, currentReplicas, scaledObject.Spec.Fallback.Replicas) {
+				logger.V(1).Info("ScaleTarget not scaled to the fallback replicas count, scaling is paused in this direction", "pausedDirection", pausedDirection)
+			} else {
+				e.doFallbackScaling(ctx, scaledObject, currentScale, logger, currentReplicas)
+			}
 		case isError && scaledObject.Spec.Fallback == nil:
 			// there are no active triggers, but a scaler responded with an error
 			// AND
@@ -191,8 +216,12 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 			// there is no minimum configured or minimum is set to ZERO
 
 			// Try to scale the deployment down, HPA will handle other scale down operations
-			e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale)
-		case currentReplicas < minReplicas && scaledObject.Spec.IdleReplicaCount == nil:
+			if pausedDirection == kedacontrollerutil.PausedScaleDirectionDown {
+				logger.V(1).Info("ScaleTarget not scaled down to zero or idle, scale-down is paused")
+			} else {
+				e.scaleToZeroOrIdle(ctx, logger, scaledObject, currentScale)
+			}
+		case currentReplicas < minReplicas && scaledObject.Spec.IdleReplicaCount == nil && pausedDirection != kedacontrollerutil.PausedScaleDirectionUp:
 			// there are no active triggers
 			// AND
 			// ScaleTarget replicas count is less than minimum replica count specified in ScaledObject
@@ -205,6 +234,9 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 				logger.Info("Successfully set ScaleTarget replicas count to ScaledObject minReplicaCount",
 					"Original Replicas Count", currentReplicas,
 					"New Replicas Count", *scaledObject.Spec.MinReplicaCount)
+				if err := e.recordScalingDecision(ctx, logger, scaledObject, currentReplicas, *scaledObject.Spec.MinReplicaCount, scalingDecisionMinReplicaCountReason, "Scaled to the minReplicaCount"); err != nil {
+					logger.Error(err, "Error recording scaling decision")
+				}
 			}
 		default:
 			// there are no active triggers
@@ -236,6 +268,9 @@ func (e *scaleExecutor) doFallbackScaling(ctx context.Context, scaledObject *ked
 		logger.Info("Successfully set ScaleTarget replicas count to ScaledObject fallback.replicas",
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", scaledObject.Spec.Fallback.Replicas)
+		if err := e.recordScalingDecision(ctx, logger, scaledObject, currentReplicas, scaledObject.Spec.Fallback.Replicas, scalingDecisionFallbackReason, "Scaled to the fallback replica count because triggers are failing"); err != nil {
+			logger.Error(err, "Error recording scaling decision")
+		}
 	}
 	if e := e.setFallbackCondition(ctx, logger, scaledObject, metav1.ConditionTrue, "FallbackExists", "At least one trigger is falling back on this scaled object"); e != nil {
 		logger.Error(e, "Error setting fallback condition")
@@ -273,6 +308,9 @@ func (e *scaleExecutor) scaleToZeroOrIdle(ctx context.Context, logger logr.Logge
 
 			e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetDeactivated,
 				"Deactivated %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, scaleToReplicas)
+			if err := e.recordScalingDecision(ctx, logger, scaledObject, currentReplicas, scaleToReplicas, eventreason.KEDAScaleTargetDeactivated, "Scaled down because triggers are not active"); err != nil {
+				logger.Error(err, "Error recording scaling decision")
+			}
 			if err := e.setActiveCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "ScalerNotActive", "Scaling is not performed because triggers are not active"); err != nil {
 				logger.Error(err, "Error in setting active condition")
 				return
@@ -311,6 +349,9 @@ func (e *scaleExecutor) scaleFromZeroOrIdle(ctx context.Context, logger logr.Log
 			"Original Replicas Count", currentReplicas,
 			"New Replicas Count", replicas)
 		e.recorder.Eventf(scaledObject, corev1.EventTypeNormal, eventreason.KEDAScaleTargetActivated, "Scaled %s %s/%s from %d to %d", scaledObject.Status.ScaleTargetKind, scaledObject.Namespace, scaledObject.Spec.ScaleTargetRef.Name, currentReplicas, replicas)
+		if err := e.recordScalingDecision(ctx, logger, scaledObject, currentReplicas, replicas, eventreason.KEDAScaleTargetActivated, "Scaled up because triggers are active"); err != nil {
+			logger.Error(err, "Error recording scaling decision")
+		}
 
 		// Scale was successful. Update lastScaleTime and lastActiveTime on the scaledObject
 		if err := e.updateLastActiveTime(ctx, logger, scaledObject); err != nil {
@@ -358,7 +399,8 @@ func getIdleOrMinimumReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (bool
 	return false, *scaledObject.Spec.MinReplicaCount
 }
 
-// GetPausedReplicaCount returns the paused replica count of the ScaledObject.
+// GetPausedReplicaCount returns the paused replica count of the ScaledObject,
+// or the frozen replica count while the ScaledObject is frozen.
 // If not paused, it returns nil.
 func GetPausedReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (*int32, error) {
 	if scaledObject.Annotations != nil {
@@ -370,6 +412,38 @@ func GetPausedReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (*int32, err
 			count := int32(conv)
 			return &count, nil
 		}
+		if _, ok := scaledObject.Annotations[kedacontrollerutil.FreezeDurationAnnotation]; ok && IsFrozen(scaledObject, time.Now()) {
+			count := *scaledObject.Status.FrozenReplicaCount
+			return &count, nil
+		}
 	}
 	return nil, nil
 }
+
+// GetPausedScaleDirection returns the direction of the scaling paused by the paused-scale-direction annotation
+// of the ScaledObject, down or up, or an empty string if neither is paused
+func GetPausedScaleDirection(scaledObject *kedav1alpha1.ScaledObject) (string, error) {
+	direction, ok := scaledObject.Annotations[kedacontrollerutil.PausedScaleDirectionAnnotation]
+	if !ok {
+		return "", nil
+	}
+	switch direction {
+	case kedacontrollerutil.PausedScaleDirectionDown, kedacontrollerutil.PausedScaleDirectionUp:
+		return direction, nil
+	default:
+		return "", fmt.Errorf("%s must be %s or %s, got %q", kedacontrollerutil.PausedScaleDirectionAnnotation,
+			kedacontrollerutil.PausedScaleDirectionDown, kedacontrollerutil.PausedScaleDirectionUp, direction)
+	}
+}
+
+// isScalePaused returns true if scaling from the current to the desired replicas goes in the paused direction
+func isScalePaused(pausedDirection string, currentReplicas, desiredReplicas int32) bool {
+	return (pausedDirection == kedacontrollerutil.PausedScaleDirectionDown && desiredReplicas < currentReplicas) ||
+		(pausedDirection == kedacontrollerutil.PausedScaleDirectionUp && desiredReplicas > currentReplicas)
+}
+
+// IsFrozen returns true if the replica count of the ScaledObject is frozen at the given time
+func IsFrozen(scaledObject *kedav1alpha1.ScaledObject, now time.Time) bool {
+	return scaledObject.Status.FrozenReplicaCount != nil && scaledObject.Status.FrozenUntil != nil &&
+		now.Before(scaledObject.Status.FrozenUntil.Time)
+}
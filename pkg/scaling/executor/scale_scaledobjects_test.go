@@ -19,6 +19,7 @@ package executor
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -275,6 +276,77 @@ func TestScaleFromMinReplicasWhenActive(t *testing.T) {
 	assert.Equal(t, true, condition.IsTrue())
 }
 
+func TestScalingHistoryWhenActivated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	mockScaleInterface := mock_scale.NewMockScaleInterface(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	minReplicas := int32(0)
+	historyLimit := int32(2)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "name",
+			Namespace: "namespace",
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MinReplicaCount: &minReplicas,
+			Advanced: &v1alpha1.AdvancedConfig{
+				ScalingHistoryLimit: &historyLimit,
+			},
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+			ScalingHistory: []v1alpha1.ScalingDecision{
+				{FromReplicas: 0, ToReplicas: 1, Reason: "KEDAScaleTargetActivated"},
+				{FromReplicas: 3, ToReplicas: 0, Reason: "KEDAScaleTargetDeactivated"},
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &minReplicas,
+		},
+	})
+
+	scale := &autoscalingv1.Scale{
+		Spec: autoscalingv1.ScaleSpec{
+			Replicas: minReplicas,
+		},
+	}
+
+	mockScaleClient.EXPECT().Scales(gomock.Any()).Return(mockScaleInterface).Times(2)
+	mockScaleInterface.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(scale, nil)
+	mockScaleInterface.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Eq(scale), gomock.Any())
+
+	client.EXPECT().Status().Return(statusWriter).Times(4)
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(4)
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, true, false)
+
+	assert.Equal(t, int32(1), scale.Spec.Replicas)
+	assert.Equal(t, 2, len(scaledObject.Status.ScalingHistory))
+	decision := scaledObject.Status.ScalingHistory[1]
+	assert.Equal(t, int32(0), decision.FromReplicas)
+	assert.Equal(t, int32(1), decision.ToReplicas)
+	assert.Equal(t, "KEDAScaleTargetActivated", decision.Reason)
+	assert.Equal(t, "KEDAScaleTargetDeactivated", scaledObject.Status.ScalingHistory[0].Reason)
+}
+
 func TestScaleToIdleReplicasWhenNotActive(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	client := mock_client.NewMockClient(ctrl)
@@ -460,3 +532,113 @@ func TestScaleToPausedReplicasCount(t *testing.T) {
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
 	assert.Equal(t, false, condition.IsTrue())
 }
+
+func TestGetPausedReplicaCountWhenFrozen(t *testing.T) {
+	now := time.Now()
+	frozenReplicaCount := int32(3)
+	pausedReplicaCount := int32(1)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		frozenUntil time.Time
+		expected    *int32
+	}{
+		{"frozen", map[string]string{"autoscaling.keda.sh/freeze-duration": "30m"}, now.Add(time.Minute), &frozenReplicaCount},
+		{"freeze elapsed", map[string]string{"autoscaling.keda.sh/freeze-duration": "30m"}, now.Add(-time.Minute), nil},
+		{"freeze annotation removed", map[string]string{}, now.Add(time.Minute), nil},
+		{"paused takes precedence", map[string]string{"autoscaling.keda.sh/freeze-duration": "30m", "autoscaling.keda.sh/paused-replicas": "1"}, now.Add(time.Minute), &pausedReplicaCount},
+	}
+
+	for _, test := range tests {
+		frozenUntil := v1.NewTime(test.frozenUntil)
+		scaledObject := &v1alpha1.ScaledObject{
+			ObjectMeta: v1.ObjectMeta{Annotations: test.annotations},
+			Status: v1alpha1.ScaledObjectStatus{
+				FrozenReplicaCount: &frozenReplicaCount,
+				FrozenUntil:        &frozenUntil,
+			},
+		}
+
+		count, err := GetPausedReplicaCount(scaledObject)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, count, test.name)
+	}
+}
+
+func TestGetPausedScaleDirection(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    string
+		isError     bool
+	}{
+		{"not paused", map[string]string{}, "", false},
+		{"scale-down paused", map[string]string{"autoscaling.keda.sh/paused-scale-direction": "down"}, "down", false},
+		{"scale-up paused", map[string]string{"autoscaling.keda.sh/paused-scale-direction": "up"}, "up", false},
+		{"invalid direction", map[string]string{"autoscaling.keda.sh/paused-scale-direction": "sideways"}, "", true},
+	}
+
+	for _, test := range tests {
+		direction, err := GetPausedScaleDirection(&v1alpha1.ScaledObject{ObjectMeta: v1.ObjectMeta{Annotations: test.annotations}})
+		assert.Equal(t, test.isError, err != nil, test.name)
+		assert.Equal(t, test.expected, direction, test.name)
+	}
+
+	assert.True(t, isScalePaused("down", 5, 2))
+	assert.False(t, isScalePaused("down", 2, 5))
+	assert.True(t, isScalePaused("up", 2, 5))
+	assert.False(t, isScalePaused("up", 5, 2))
+	assert.False(t, isScalePaused("", 5, 2))
+}
+
+func TestNoScaleToZeroWhenScaleDownPaused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := mock_client.NewMockClient(ctrl)
+	recorder := record.NewFakeRecorder(1)
+	mockScaleClient := mock_scale.NewMockScalesGetter(ctrl)
+	statusWriter := mock_client.NewMockStatusWriter(ctrl)
+
+	scaleExecutor := NewScaleExecutor(client, mockScaleClient, nil, recorder)
+
+	minReplicas := int32(0)
+
+	scaledObject := v1alpha1.ScaledObject{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "name",
+			Namespace:   "namespace",
+			Annotations: map[string]string{"autoscaling.keda.sh/paused-scale-direction": "down"},
+		},
+		Spec: v1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &v1alpha1.ScaleTarget{
+				Name: "name",
+			},
+			MinReplicaCount: &minReplicas,
+		},
+		Status: v1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &v1alpha1.GroupVersionKindResource{
+				Group: "apps",
+				Kind:  "Deployment",
+			},
+		},
+	}
+
+	scaledObject.Status.Conditions = *v1alpha1.GetInitializedConditions()
+
+	numberOfReplicas := int32(10)
+
+	client.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numberOfReplicas,
+		},
+	})
+
+	// the scale target isn't scaled, only the conditions are updated
+	client.EXPECT().Status().Return(statusWriter).AnyTimes()
+	statusWriter.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	scaleExecutor.RequestScale(context.TODO(), &scaledObject, false, false)
+
+	condition := scaledObject.Status.Conditions.GetActiveCondition()
+	assert.Equal(t, true, condition.IsFalse())
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	"github.com/kedacore/keda/v2/pkg/eventreason"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+// pushStreams keeps the state of the streams of the push scalers of a ScaledObject, by trigger index
+type pushStreams struct {
+	states map[int]scalers.PushScalerStreamState
+	lock   sync.Mutex
+}
+
+func newPushStreams() *pushStreams {
+	return &pushStreams{
+		states: map[int]scalers.PushScalerStreamState{},
+	}
+}
+
+// condition returns the status, reason and message of the PushStreamsConnected condition for the states of the streams
+func (p *pushStreams) condition() (metav1.ConditionStatus, string, string) {
+	indexes := make([]int, 0, len(p.states))
+	for index := range p.states {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	var disconnected []string
+	for _, index := range indexes {
+		state := p.states[index]
+		if !state.Connected {
+			disconnected = append(disconnected, fmt.Sprintf("stream of trigger %d is disconnected after %d failed attempts: %s", index, state.ConsecutiveFailures, state.Err))
+		}
+	}
+	if len(disconnected) > 0 {
+		return metav1.ConditionFalse, kedav1alpha1.ScaledObjectConditionPushStreamDisconnectedReason, strings.Join(disconnected, "; ")
+	}
+	return metav1.ConditionTrue, kedav1alpha1.ScaledObjectConditionPushStreamsConnectedReason, "The streams of the push scalers are connected"
+}
+
+// handlePushStreamState reflects the state of the stream of a push scaler in the PushStreamsConnected condition
+// of the ScaledObject, and emits an event when the stream gets disconnected or connected again. The ScaledObject
+// is fetched for each state, as the object the push scalers were started with is refreshed by the polling loop.
+func (h *scaleHandler) handlePushStreamState(ctx context.Context, streams *pushStreams, key types.NamespacedName, state scalers.PushScalerStreamState) {
+	streams.lock.Lock()
+	defer streams.lock.Unlock()
+
+	previous, known := streams.states[state.ScalerIndex]
+	streams.states[state.ScalerIndex] = state
+
+	// the conditions are patched on the latest ScaledObject, not to revert the changes of the other conditions
+	latest := &kedav1alpha1.ScaledObject{}
+	if err := h.client.Get(ctx, key, latest); err != nil {
+		h.logger.Error(err, "error getting ScaledObject to update the push streams condition", "namespace", key.Namespace, "name", key.Name)
+		return
+	}
+
+	switch {
+	case !state.Connected && (!known || previous.Connected):
+		h.recorder.Event(latest, corev1.EventTypeWarning, eventreason.KEDAPushStreamDisconnected,
+			fmt.Sprintf("Stream of trigger %d is disconnected: %s", state.ScalerIndex, state.Err))
+	case state.Connected && known && !previous.Connected:
+		h.recorder.Event(latest, corev1.EventTypeNormal, eventreason.KEDAPushStreamConnected,
+			fmt.Sprintf("Stream of trigger %d is connected again", state.ScalerIndex))
+	}
+
+	status, reason, message := streams.condition()
+	conditions := latest.Status.Conditions.DeepCopy()
+	current := conditions.GetPushStreamsConnectedCondition()
+	if current.Status == status && current.Message == message {
+		return
+	}
+	conditions.SetPushStreamsConnectedCondition(status, reason, message)
+	_ = kedacontrollerutil.SetStatusConditions(ctx, h.client, h.logger, latest, &conditions)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	"github.com/kedacore/keda/v2/pkg/scalers"
+)
+
+func TestHandlePushStreamState(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			Conditions: *kedav1alpha1.GetInitializedConditions(),
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaledObject).Build()
+	recorder := record.NewFakeRecorder(10)
+	handler := &scaleHandler{
+		client:   client,
+		logger:   logf.Log.WithName("scalehandler"),
+		recorder: recorder,
+	}
+	streams := newPushStreams()
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "test", Name: "test"}
+
+	getCondition := func() kedav1alpha1.Condition {
+		latest := &kedav1alpha1.ScaledObject{}
+		assert.NoError(t, client.Get(ctx, key, latest))
+		return latest.Status.Conditions.GetPushStreamsConnectedCondition()
+	}
+
+	handler.handlePushStreamState(ctx, streams, key, scalers.PushScalerStreamState{ScalerIndex: 0, Connected: true})
+	condition := getCondition()
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Empty(t, recorder.Events)
+
+	// the ScaledObject is updated by the reconciler while the streams run
+	updated := &kedav1alpha1.ScaledObject{}
+	assert.NoError(t, client.Get(ctx, key, updated))
+	updated.Status.Conditions.SetReadyCondition(metav1.ConditionTrue, "ScaledObjectReady", "ScaledObject is defined correctly and is ready for scaling")
+	assert.NoError(t, client.Status().Update(ctx, updated))
+
+	// the disconnection is reported once
+	for failures := 1; failures <= 2; failures++ {
+		handler.handlePushStreamState(ctx, streams, key, scalers.PushScalerStreamState{ScalerIndex: 0, ConsecutiveFailures: failures, Err: errors.New("unavailable")})
+	}
+	condition = getCondition()
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, kedav1alpha1.ScaledObjectConditionPushStreamDisconnectedReason, condition.Reason)
+	assert.Equal(t, "stream of trigger 0 is disconnected after 2 failed attempts: unavailable", condition.Message)
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "KEDAPushStreamDisconnected")
+
+	handler.handlePushStreamState(ctx, streams, key, scalers.PushScalerStreamState{ScalerIndex: 0, Connected: true})
+	assert.Equal(t, metav1.ConditionTrue, getCondition().Status)
+	assert.Contains(t, <-recorder.Events, "KEDAPushStreamConnected")
+
+	// the other conditions are kept
+	latest := &kedav1alpha1.ScaledObject{}
+	assert.NoError(t, client.Get(ctx, key, latest))
+	assert.Len(t, latest.Status.Conditions, 4)
+	assert.Equal(t, metav1.ConditionTrue, latest.Status.Conditions.GetReadyCondition().Status)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+const (
+	// rateSamplesPersistInterval is the minimum time between two writes of the rate samples of a scalable object
+	rateSamplesPersistInterval = time.Minute
+	// rateSampleMaxAge is the age after which persisted samples aren't restored anymore
+	rateSampleMaxAge = 15 * time.Minute
+)
+
+// persistedRateSamples are the rate samples of a trigger, as persisted in the ProcessingRateSamplesAnnotation
+type persistedRateSamples struct {
+	Type    string                `json:"type"`
+	Samples []kedautil.RateSample `json:"samples"`
+}
+
+type triggerRateEstimator struct {
+	triggerType string
+	estimator   *kedautil.RateEstimator
+}
+
+// rateEstimators keeps the RateEstimators of the triggers of the scalable objects, so the samples survive
+// the scalers being rebuilt, and restores them from the ProcessingRateSamplesAnnotation when KEDA restarts
+type rateEstimators struct {
+	estimators map[string]map[int]*triggerRateEstimator
+	persisted  map[string]time.Time
+	lock       sync.Mutex
+}
+
+func newRateEstimators() *rateEstimators {
+	return &rateEstimators{
+		estimators: map[string]map[int]*triggerRateEstimator{},
+		persisted:  map[string]time.Time{},
+	}
+}
+
+// get returns the RateEstimator of the trigger, restored from the annotation of the scalable object
+// when it is created. The samples are dropped when the type of the trigger changes.
+func (r *rateEstimators) get(withTriggers *kedav1alpha1.WithTriggers, triggerIndex int, triggerType string, now time.Time) *kedautil.RateEstimator {
+	key := withTriggers.GenerateIdenitifier()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, ok := r.estimators[key]; !ok {
+		r.estimators[key] = map[int]*triggerRateEstimator{}
+	}
+	if existing, ok := r.estimators[key][triggerIndex]; ok && existing.triggerType == triggerType {
+		return existing.estimator
+	}
+
+	estimator := kedautil.NewRateEstimator(kedautil.DefaultRateSamples)
+	if persisted, ok := decodeRateSamples(withTriggers.Annotations)[triggerIndex]; ok && persisted.Type == triggerType {
+		samples := make([]kedautil.RateSample, 0, len(persisted.Samples))
+		for _, sample := range persisted.Samples {
+			if now.Sub(sample.Time) <= rateSampleMaxAge {
+				samples = append(samples, sample)
+			}
+		}
+		estimator.Restore(samples)
+	}
+	r.estimators[key][triggerIndex] = &triggerRateEstimator{triggerType: triggerType, estimator: estimator}
+	return estimator
+}
+
+// encodeIfDue returns the annotation value of the samples of the triggers of the scalable object, false if
+// they were persisted less than rateSamplesPersistInterval ago or there are no samples. The samples are only
+// marked as persisted by markPersisted, once they are written.
+func (r *rateEstimators) encodeIfDue(withTriggers *kedav1alpha1.WithTriggers, now time.Time) (string, bool, error) {
+	key := withTriggers.GenerateIdenitifier()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if now.Sub(r.persisted[key]) < rateSamplesPersistInterval {
+		return "", false, nil
+	}
+
+	persisted := map[int]persistedRateSamples{}
+	for index, trigger := range withTriggers.Spec.Triggers {
+		existing, ok := r.estimators[key][index]
+		if !ok || existing.triggerType != trigger.Type {
+			continue
+		}
+		if samples := existing.estimator.Samples(); len(samples) > 0 {
+			persisted[index] = persistedRateSamples{Type: trigger.Type, Samples: samples}
+		}
+	}
+	if len(persisted) == 0 {
+		return "", false, nil
+	}
+
+	value, err := json.Marshal(persisted)
+	if err != nil {
+		return "", false, err
+	}
+	return string(value), true, nil
+}
+
+// markPersisted records that the samples of the scalable object were written at now
+func (r *rateEstimators) markPersisted(withTriggers *kedav1alpha1.WithTriggers, now time.Time) {
+	key := withTriggers.GenerateIdenitifier()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.persisted[key] = now
+}
+
+// forget drops the RateEstimators of the scalable object
+func (r *rateEstimators) forget(withTriggers *kedav1alpha1.WithTriggers) {
+	key := withTriggers.GenerateIdenitifier()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.estimators, key)
+	delete(r.persisted, key)
+}
+
+// decodeRateSamples returns the rate samples persisted in the annotations by trigger index, invalid values
+// are ignored as the samples are rebuilt from the next polls
+func decodeRateSamples(annotations map[string]string) map[int]persistedRateSamples {
+	persisted := map[int]persistedRateSamples{}
+	if value, ok := annotations[kedacontrollerutil.ProcessingRateSamplesAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &persisted); err != nil {
+			return map[int]persistedRateSamples{}
+		}
+	}
+	return persisted
+}
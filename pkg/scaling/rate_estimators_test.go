@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
+)
+
+func TestRateEstimatorsGet(t *testing.T) {
+	estimators := newRateEstimators()
+	now := time.Now()
+	withTriggers := &kedav1alpha1.WithTriggers{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+			Annotations: map[string]string{
+				kedacontrollerutil.ProcessingRateSamplesAnnotation: `{"0":{"type":"kafka","samples":[` +
+					`{"time":"` + now.Add(-time.Hour).Format(time.RFC3339) + `","backlog":10,"completed":-1},` +
+					`{"time":"` + now.Add(-time.Minute).Format(time.RFC3339) + `","backlog":20,"completed":-1}]}}`,
+			},
+		},
+	}
+
+	estimator := estimators.get(withTriggers, 0, "kafka", now)
+	samples := estimator.Samples()
+	// the samples too old are dropped
+	assert.Len(t, samples, 1)
+	assert.Equal(t, float64(20), samples[0].Backlog)
+
+	// the estimator is kept across the scalers of the trigger
+	assert.Same(t, estimator, estimators.get(withTriggers, 0, "kafka", now))
+
+	// the samples are dropped when the trigger type changes
+	assert.Empty(t, estimators.get(withTriggers, 0, "rabbitmq", now).Samples())
+}
+
+// patchCountingClient counts the patches sent to the client
+type patchCountingClient struct {
+	client.Client
+	patches int
+}
+
+func (c *patchCountingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.patches++
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func TestPersistAnnotations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{{Type: "aws-sqs-queue", UseCachedMetrics: true}},
+		},
+	}
+	client := &patchCountingClient{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(scaledObject).Build()}
+	handler := &scaleHandler{
+		client:         client,
+		logger:         logf.Log.WithName("scalehandler"),
+		rateEstimators: newRateEstimators(),
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test", Namespace: "test"}
+	metrics := []external_metrics.ExternalMetricValue{{MetricName: "s0-queueLength", Value: *resource.NewQuantity(10, resource.DecimalSI)}}
+
+	// nothing is written without samples nor cached metrics
+	assert.NoError(t, client.Get(ctx, key, scaledObject))
+	assert.NoError(t, handler.persistAnnotations(ctx, scaledObject, false, nil))
+	assert.Equal(t, 0, client.patches)
+
+	withTriggers, err := asDuckWithTriggers(scaledObject)
+	assert.NoError(t, err)
+	now := time.Now().Truncate(time.Second)
+	handler.rateEstimators.get(withTriggers, 0, "aws-sqs-queue", now).Add(kedautil.RateSample{Time: now, Backlog: 10, Completed: -1})
+
+	// the patch is rejected when the object changed since it was fetched, the samples are written by the next poll
+	stale := scaledObject.DeepCopy()
+	scaledObject.Labels = map[string]string{"changed": "true"}
+	assert.NoError(t, client.Update(ctx, scaledObject))
+	assert.True(t, apierrors.IsConflict(handler.persistAnnotations(ctx, stale, true, metrics)))
+
+	// the rate samples and the cached metrics are written in a single patch
+	client.patches = 0
+	assert.NoError(t, client.Get(ctx, key, scaledObject))
+	assert.NoError(t, handler.persistAnnotations(ctx, scaledObject, true, metrics))
+	assert.Equal(t, 1, client.patches)
+	persisted := &kedav1alpha1.ScaledObject{}
+	assert.NoError(t, client.Get(ctx, key, persisted))
+	assert.Contains(t, persisted.Annotations, kedacontrollerutil.ProcessingRateSamplesAnnotation)
+	assert.Contains(t, persisted.Annotations, kedacontrollerutil.CachedMetricsAnnotation)
+	assert.Equal(t, "true", persisted.Labels["changed"])
+
+	// the samples are restored after a restart
+	withTriggers, err = asDuckWithTriggers(persisted)
+	assert.NoError(t, err)
+	samples := newRateEstimators().get(withTriggers, 0, "aws-sqs-queue", now).Samples()
+	assert.Len(t, samples, 1)
+	assert.True(t, now.Equal(samples[0].Time))
+	assert.Equal(t, float64(10), samples[0].Backlog)
+
+	// neither the samples before the interval nor the unchanged metrics are written again
+	assert.NoError(t, handler.persistAnnotations(ctx, persisted, true, metrics))
+	assert.Equal(t, 1, client.patches)
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -32,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedautil "github.com/kedacore/keda/v2/pkg/util"
 )
 
 const (
@@ -95,6 +97,9 @@ func ResolveScaleTargetPodSpec(ctx context.Context, kubeClient client.Client, lo
 		return &podTemplateSpec, obj.Spec.ScaleTargetRef.EnvSourceContainerName, nil
 	case *kedav1alpha1.ScaledJob:
 		return &obj.Spec.JobTargetRef.Template, obj.Spec.EnvSourceContainerName, nil
+	case *kedav1alpha1.MetricSource:
+		// a MetricSource has no scale target to resolve the environment from
+		return nil, "", nil
 	default:
 		return nil, "", fmt.Errorf("unknown scalable object type %v", scalableObject)
 	}
@@ -147,6 +152,10 @@ func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, log
 		} else if podIdentity.Provider == kedav1alpha1.PodIdentityProviderAwsKiam {
 			authParams["awsRoleArn"] = podTemplateSpec.ObjectMeta.Annotations[kedav1alpha1.PodIdentityAnnotationKiam]
 		}
+		// the role given in the trigger parameters takes precedence over the one of the pod identity
+		if triggerAuthRef != nil && triggerAuthRef.Parameters["awsRoleArn"] != "" {
+			authParams["awsRoleArn"] = triggerAuthRef.Parameters["awsRoleArn"]
+		}
 		return authParams, podIdentity, nil
 	}
 
@@ -155,7 +164,9 @@ func ResolveAuthRefAndPodIdentity(ctx context.Context, client client.Client, log
 }
 
 // resolveAuthRef provides authentication parameters needed authenticate scaler with the environment.
-// based on authentication method defined in TriggerAuthentication, authParams and podIdentity is returned
+// based on authentication method defined in TriggerAuthentication, authParams and podIdentity is returned.
+// The parameters of the overlay TriggerAuthentication and the inline parameters override the ones of the
+// referenced resource, so a ClusterTriggerAuthentication can be shared with only a few parameters changed
 func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logger,
 	triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, podSpec *corev1.PodSpec,
 	namespace string) (map[string]string, kedav1alpha1.AuthPodIdentity) {
@@ -167,74 +178,101 @@ func resolveAuthRef(ctx context.Context, client client.Client, logger logr.Logge
 		if err != nil {
 			logger.Error(err, "Error getting triggerAuth", "triggerAuthRef.Name", triggerAuthRef.Name)
 		} else {
-			if triggerAuthSpec.PodIdentity != nil {
-				podIdentity = *triggerAuthSpec.PodIdentity
-			}
-			if triggerAuthSpec.Env != nil {
-				for _, e := range triggerAuthSpec.Env {
-					if podSpec == nil {
-						result[e.Parameter] = ""
-						continue
-					}
-					env, err := ResolveContainerEnv(ctx, client, logger, podSpec, e.ContainerName, namespace)
-					if err != nil {
-						result[e.Parameter] = ""
-					} else {
-						result[e.Parameter] = env[e.Name]
-					}
+			podIdentity = resolveAuthSpec(ctx, client, logger, triggerAuthRef.Name, triggerAuthSpec, triggerNamespace, podSpec, namespace, result)
+		}
+
+		if triggerAuthRef.Overlay != "" {
+			overlay := &kedav1alpha1.TriggerAuthentication{}
+			if err := client.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Overlay, Namespace: namespace}, overlay); err != nil {
+				logger.Error(err, "Error getting overlay triggerAuth", "triggerAuthRef.Overlay", triggerAuthRef.Overlay)
+			} else {
+				overlayPodIdentity := resolveAuthSpec(ctx, client, logger, triggerAuthRef.Overlay, &overlay.Spec, namespace, podSpec, namespace, result)
+				if overlay.Spec.PodIdentity != nil {
+					podIdentity = overlayPodIdentity
 				}
 			}
-			if triggerAuthSpec.SecretTargetRef != nil {
-				for _, e := range triggerAuthSpec.SecretTargetRef {
-					result[e.Parameter] = resolveAuthSecret(ctx, client, logger, e.Name, triggerNamespace, e.Key)
-				}
+		}
+
+		for parameter, value := range triggerAuthRef.Parameters {
+			result[parameter] = value
+		}
+	}
+
+	return result, podIdentity
+}
+
+// resolveAuthSpec adds the authentication parameters of the TriggerAuthentication spec to result and returns its pod identity
+func resolveAuthSpec(ctx context.Context, client client.Client, logger logr.Logger, triggerAuthName string,
+	triggerAuthSpec *kedav1alpha1.TriggerAuthenticationSpec, triggerNamespace string, podSpec *corev1.PodSpec,
+	namespace string, result map[string]string) kedav1alpha1.AuthPodIdentity {
+	var podIdentity kedav1alpha1.AuthPodIdentity
+
+	if triggerAuthSpec.PodIdentity != nil {
+		podIdentity = *triggerAuthSpec.PodIdentity
+	}
+	if triggerAuthSpec.Env != nil {
+		for _, e := range triggerAuthSpec.Env {
+			if podSpec == nil {
+				result[e.Parameter] = ""
+				continue
+			}
+			env, err := ResolveContainerEnv(ctx, client, logger, podSpec, e.ContainerName, namespace)
+			if err != nil {
+				result[e.Parameter] = ""
+			} else {
+				result[e.Parameter] = env[e.Name]
 			}
-			if triggerAuthSpec.HashiCorpVault != nil && len(triggerAuthSpec.HashiCorpVault.Secrets) > 0 {
-				vault := NewHashicorpVaultHandler(triggerAuthSpec.HashiCorpVault)
-				err := vault.Initialize(logger)
+		}
+	}
+	if triggerAuthSpec.SecretTargetRef != nil {
+		for _, e := range triggerAuthSpec.SecretTargetRef {
+			result[e.Parameter] = resolveAuthSecret(ctx, client, logger, e.Name, triggerNamespace, e.Key)
+		}
+	}
+	if triggerAuthSpec.HashiCorpVault != nil && len(triggerAuthSpec.HashiCorpVault.Secrets) > 0 {
+		vault := NewHashicorpVaultHandler(triggerAuthSpec.HashiCorpVault)
+		err := vault.Initialize(logger)
+		if err != nil {
+			logger.Error(err, "Error authenticate to Vault", "triggerAuthRef.Name", triggerAuthName)
+		} else {
+			for _, e := range triggerAuthSpec.HashiCorpVault.Secrets {
+				secret, err := vault.Read(e.Path)
 				if err != nil {
-					logger.Error(err, "Error authenticate to Vault", "triggerAuthRef.Name", triggerAuthRef.Name)
+					logger.Error(err, "Error trying to read secret from Vault", "triggerAuthRef.Name", triggerAuthName,
+						"secret.path", e.Path)
 				} else {
-					for _, e := range triggerAuthSpec.HashiCorpVault.Secrets {
-						secret, err := vault.Read(e.Path)
-						if err != nil {
-							logger.Error(err, "Error trying to read secret from Vault", "triggerAuthRef.Name", triggerAuthRef.Name,
-								"secret.path", e.Path)
-						} else {
-							if secret == nil {
-								// sometimes there is no error, but `vault.Read(e.Path)` is not being able to parse the secret and returns nil
-								logger.Error(fmt.Errorf("unable to parse secret, is the provided path correct?"), "Error trying to read secret from Vault",
-									"triggerAuthRef.Name", triggerAuthRef.Name, "secret.path", e.Path)
-							} else {
-								result[e.Parameter] = resolveVaultSecret(logger, secret.Data, e.Key)
-							}
-						}
+					if secret == nil {
+						// sometimes there is no error, but `vault.Read(e.Path)` is not being able to parse the secret and returns nil
+						logger.Error(fmt.Errorf("unable to parse secret, is the provided path correct?"), "Error trying to read secret from Vault",
+							"triggerAuthRef.Name", triggerAuthName, "secret.path", e.Path)
+					} else {
+						result[e.Parameter] = resolveVaultSecret(logger, secret.Data, e.Key)
 					}
-
-					vault.Stop()
 				}
 			}
-			if triggerAuthSpec.AzureKeyVault != nil && len(triggerAuthSpec.AzureKeyVault.Secrets) > 0 {
-				vaultHandler := NewAzureKeyVaultHandler(triggerAuthSpec.AzureKeyVault, podIdentity)
-				err := vaultHandler.Initialize(ctx, client, logger, triggerNamespace)
+
+			vault.Stop()
+		}
+	}
+	if triggerAuthSpec.AzureKeyVault != nil && len(triggerAuthSpec.AzureKeyVault.Secrets) > 0 {
+		vaultHandler := NewAzureKeyVaultHandler(triggerAuthSpec.AzureKeyVault, podIdentity)
+		err := vaultHandler.Initialize(ctx, client, logger, triggerNamespace)
+		if err != nil {
+			logger.Error(err, "Error authenticating to Azure Key Vault", "triggerAuthRef.Name", triggerAuthName)
+		} else {
+			for _, secret := range triggerAuthSpec.AzureKeyVault.Secrets {
+				res, err := vaultHandler.Read(ctx, secret.Name, secret.Version)
 				if err != nil {
-					logger.Error(err, "Error authenticating to Azure Key Vault", "triggerAuthRef.Name", triggerAuthRef.Name)
+					logger.Error(err, "Error trying to read secret from Azure Key Vault", "triggerAuthRef.Name", triggerAuthName,
+						"secret.Name", secret.Name, "secret.Version", secret.Version)
 				} else {
-					for _, secret := range triggerAuthSpec.AzureKeyVault.Secrets {
-						res, err := vaultHandler.Read(ctx, secret.Name, secret.Version)
-						if err != nil {
-							logger.Error(err, "Error trying to read secret from Azure Key Vault", "triggerAuthRef.Name", triggerAuthRef.Name,
-								"secret.Name", secret.Name, "secret.Version", secret.Version)
-						} else {
-							result[secret.Parameter] = res
-						}
-					}
+					result[secret.Parameter] = res
 				}
 			}
 		}
 	}
 
-	return result, podIdentity
+	return podIdentity
 }
 
 var clusterObjectNamespaceCache *string
@@ -258,6 +296,44 @@ func getClusterObjectNamespace() (string, error) {
 	return strData, nil
 }
 
+var (
+	authorizationGrantRequired     bool
+	authorizationGrantRequiredErr  error
+	authorizationGrantRequiredOnce sync.Once
+)
+
+// isAuthorizationGrantRequired returns true if a ClusterTriggerAuthentication can only be used
+// from namespaces which have been granted access through an AuthorizationGrant
+func isAuthorizationGrantRequired() (bool, error) {
+	authorizationGrantRequiredOnce.Do(func() {
+		authorizationGrantRequired, authorizationGrantRequiredErr = kedautil.ResolveOsEnvBool("KEDA_REQUIRE_AUTHORIZATION_GRANT", false)
+		if authorizationGrantRequiredErr != nil {
+			authorizationGrantRequiredErr = fmt.Errorf("invalid KEDA_REQUIRE_AUTHORIZATION_GRANT: %s", authorizationGrantRequiredErr)
+		}
+	})
+	return authorizationGrantRequired, authorizationGrantRequiredErr
+}
+
+// verifyAuthorizationGrant checks that the namespace is allowed to use the ClusterTriggerAuthentication,
+// it is a no-op unless authorization grants are required
+func verifyAuthorizationGrant(ctx context.Context, client client.Client, clusterTriggerAuthName, namespace string) error {
+	required, err := isAuthorizationGrantRequired()
+	if err != nil || !required {
+		return err
+	}
+
+	grants := &kedav1alpha1.AuthorizationGrantList{}
+	if err := client.List(ctx, grants); err != nil {
+		return err
+	}
+	for i := range grants.Items {
+		if grants.Items[i].Grants(clusterTriggerAuthName, namespace) {
+			return nil
+		}
+	}
+	return fmt.Errorf("namespace %s has no AuthorizationGrant for ClusterTriggerAuthentication %s", namespace, clusterTriggerAuthName)
+}
+
 func getTriggerAuthSpec(ctx context.Context, client client.Client, triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, namespace string) (*kedav1alpha1.TriggerAuthenticationSpec, string, error) {
 	if triggerAuthRef.Kind == "" || triggerAuthRef.Kind == "TriggerAuthentication" {
 		triggerAuth := &kedav1alpha1.TriggerAuthentication{}
@@ -271,6 +347,9 @@ func getTriggerAuthSpec(ctx context.Context, client client.Client, triggerAuthRe
 		if err != nil {
 			return nil, "", err
 		}
+		if err := verifyAuthorizationGrant(ctx, client, triggerAuthRef.Name, namespace); err != nil {
+			return nil, "", err
+		}
 		triggerAuth := &kedav1alpha1.ClusterTriggerAuthentication{}
 		err = client.Get(ctx, types.NamespacedName{Name: triggerAuthRef.Name}, triggerAuth)
 		if err != nil {
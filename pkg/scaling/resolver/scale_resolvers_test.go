@@ -18,6 +18,7 @@ package resolver
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -326,6 +327,74 @@ func TestResolveAuthRef(t *testing.T) {
 			expected:            map[string]string{"host": ""},
 			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderNone},
 		},
+		{
+			name: "clustertriggerauth with overlay and parameters",
+			existing: []runtime.Object{
+				&kedav1alpha1.ClusterTriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: triggerAuthenticationName,
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{
+								Parameter: "host",
+								Name:      secretName,
+								Key:       secretKey,
+							},
+							{
+								Parameter: "password",
+								Name:      secretName,
+								Key:       secretKey,
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: clusterNamespace,
+						Name:      secretName,
+					},
+					Data: map[string][]byte{secretKey: []byte(secretData)}},
+				&kedav1alpha1.TriggerAuthentication{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "overlay",
+					},
+					Spec: kedav1alpha1.TriggerAuthenticationSpec{
+						PodIdentity: &kedav1alpha1.AuthPodIdentity{
+							Provider: kedav1alpha1.PodIdentityProviderAwsEKS,
+						},
+						SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+							{
+								Parameter: "password",
+								Name:      "overlay-secret",
+								Key:       secretKey,
+							},
+						},
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "overlay-secret",
+					},
+					Data: map[string][]byte{secretKey: []byte("overlaySecretData")}},
+			},
+			soar: &kedav1alpha1.ScaledObjectAuthRef{
+				Name:       triggerAuthenticationName,
+				Kind:       "ClusterTriggerAuthentication",
+				Overlay:    "overlay",
+				Parameters: map[string]string{"vhost": "tenant", "host": "inline-host"},
+			},
+			expected:            map[string]string{"host": "inline-host", "password": "overlaySecretData", "vhost": "tenant"},
+			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS},
+		},
+		{
+			name:                "parameters without existing clustertriggerauth",
+			soar:                &kedav1alpha1.ScaledObjectAuthRef{Name: "notthere", Kind: "ClusterTriggerAuthentication", Parameters: map[string]string{"vhost": "tenant"}},
+			expected:            map[string]string{"vhost": "tenant"},
+			expectedPodIdentity: kedav1alpha1.AuthPodIdentity{},
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -349,6 +418,117 @@ func TestResolveAuthRef(t *testing.T) {
 	}
 }
 
+func TestResolveAuthRefWithAuthorizationGrant(t *testing.T) {
+	if err := kedav1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		t.Errorf("Expected Error because: %v", err)
+	}
+	clusterTriggerAuth := &kedav1alpha1.ClusterTriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: triggerAuthenticationName,
+		},
+		Spec: kedav1alpha1.TriggerAuthenticationSpec{
+			SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+				{
+					Parameter: "host",
+					Name:      secretName,
+					Key:       secretKey,
+				},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: clusterNamespace,
+			Name:      secretName,
+		},
+		Data: map[string][]byte{secretKey: []byte(secretData)},
+	}
+	tests := []struct {
+		name     string
+		existing []runtime.Object
+		expected map[string]string
+	}{
+		{
+			name:     "no authorization grant",
+			existing: []runtime.Object{clusterTriggerAuth, secret},
+			expected: make(map[string]string),
+		},
+		{
+			name: "authorization grant for another namespace",
+			existing: []runtime.Object{clusterTriggerAuth, secret,
+				&kedav1alpha1.AuthorizationGrant{
+					ObjectMeta: metav1.ObjectMeta{Name: "grant"},
+					Spec: kedav1alpha1.AuthorizationGrantSpec{
+						ClusterTriggerAuthentication: triggerAuthenticationName,
+						Namespaces:                   []string{"other-namespace"},
+					},
+				},
+			},
+			expected: make(map[string]string),
+		},
+		{
+			name: "authorization grant for another clustertriggerauth",
+			existing: []runtime.Object{clusterTriggerAuth, secret,
+				&kedav1alpha1.AuthorizationGrant{
+					ObjectMeta: metav1.ObjectMeta{Name: "grant"},
+					Spec: kedav1alpha1.AuthorizationGrantSpec{
+						ClusterTriggerAuthentication: "other-triggerauth",
+						Namespaces:                   []string{namespace},
+					},
+				},
+			},
+			expected: make(map[string]string),
+		},
+		{
+			name: "authorization grant for namespace",
+			existing: []runtime.Object{clusterTriggerAuth, secret,
+				&kedav1alpha1.AuthorizationGrant{
+					ObjectMeta: metav1.ObjectMeta{Name: "grant"},
+					Spec: kedav1alpha1.AuthorizationGrantSpec{
+						ClusterTriggerAuthentication: triggerAuthenticationName,
+						Namespaces:                   []string{"other-namespace", namespace},
+					},
+				},
+			},
+			expected: map[string]string{"host": secretData},
+		},
+		{
+			name: "authorization grant for all namespaces",
+			existing: []runtime.Object{clusterTriggerAuth, secret,
+				&kedav1alpha1.AuthorizationGrant{
+					ObjectMeta: metav1.ObjectMeta{Name: "grant"},
+					Spec: kedav1alpha1.AuthorizationGrantSpec{
+						ClusterTriggerAuthentication: triggerAuthenticationName,
+						Namespaces:                   []string{"*"},
+					},
+				},
+			},
+			expected: map[string]string{"host": secretData},
+		},
+	}
+
+	clusterObjectNamespaceCache = &clusterNamespace // Inject test cluster namespace.
+	t.Setenv("KEDA_REQUIRE_AUTHORIZATION_GRANT", "true")
+	authorizationGrantRequiredOnce = sync.Once{}
+	defer func() { authorizationGrantRequiredOnce = sync.Once{} }()
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			gotMap, _ := resolveAuthRef(
+				context.Background(),
+				fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(test.existing...).Build(),
+				logf.Log.WithName("test"),
+				&kedav1alpha1.ScaledObjectAuthRef{Name: triggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+				nil,
+				namespace)
+			if diff := cmp.Diff(gotMap, test.expected); diff != "" {
+				t.Errorf("Returned authParams are different: %s", diff)
+			}
+		})
+	}
+}
+
 func TestResolveDependentEnv(t *testing.T) {
 	tests := []struct {
 		name      string
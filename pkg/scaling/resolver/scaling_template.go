@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+// ResolveScalingTemplate returns a copy of the ScaledObject with the triggers and the advanced configuration
+// of its ScalingTemplate applied, along with the generation of the template. The ScaledObject itself is
+// returned when it doesn't reference a template.
+func ResolveScalingTemplate(ctx context.Context, client client.Client, scaledObject *kedav1alpha1.ScaledObject) (*kedav1alpha1.ScaledObject, int64, error) {
+	if scaledObject.Spec.TemplateRef == nil {
+		return scaledObject, 0, nil
+	}
+
+	template := &kedav1alpha1.ScalingTemplate{}
+	if err := client.Get(ctx, types.NamespacedName{Name: scaledObject.Spec.TemplateRef.Name}, template); err != nil {
+		return nil, 0, fmt.Errorf("error getting ScalingTemplate %s: %s", scaledObject.Spec.TemplateRef.Name, err)
+	}
+
+	resolved := scaledObject.DeepCopy()
+	if err := applyScalingTemplate(&resolved.Spec, &template.Spec); err != nil {
+		return nil, 0, fmt.Errorf("error applying ScalingTemplate %s: %s", template.Name, err)
+	}
+	return resolved, template.Generation, nil
+}
+
+// applyScalingTemplate puts the triggers of the template first, overridden by the triggers of the spec with
+// the same name, followed by the other triggers of the spec. The advanced configuration of the template is
+// used when the spec has none.
+func applyScalingTemplate(spec *kedav1alpha1.ScaledObjectSpec, template *kedav1alpha1.ScalingTemplateSpec) error {
+	triggers := make([]kedav1alpha1.ScaleTriggers, 0, len(template.Triggers)+len(spec.Triggers))
+	overrides := make(map[int]bool, len(spec.Triggers))
+	for _, templateTrigger := range template.Triggers {
+		trigger := *templateTrigger.DeepCopy()
+		for i, override := range spec.Triggers {
+			if override.Name == "" || override.Name != trigger.Name {
+				continue
+			}
+			if override.Type != trigger.Type {
+				return fmt.Errorf("trigger %s has type %s but %s in the template", override.Name, override.Type, trigger.Type)
+			}
+			trigger.Metadata = mergeTemplateValues(trigger.Metadata, override.Metadata)
+			trigger.Labels = mergeTemplateValues(trigger.Labels, override.Labels)
+			if override.AuthenticationRef != nil {
+				trigger.AuthenticationRef = override.AuthenticationRef.DeepCopy()
+			}
+			if override.MetricType != "" {
+				trigger.MetricType = override.MetricType
+			}
+			if override.Weight != nil {
+				weight := override.Weight.DeepCopy()
+				trigger.Weight = &weight
+			}
+			overrides[i] = true
+		}
+		triggers = append(triggers, trigger)
+	}
+	for i, trigger := range spec.Triggers {
+		if !overrides[i] {
+			triggers = append(triggers, trigger)
+		}
+	}
+	spec.Triggers = triggers
+
+	if spec.Advanced == nil && template.Advanced != nil {
+		spec.Advanced = template.Advanced.DeepCopy()
+	}
+	return nil
+}
+
+// mergeTemplateValues returns the values of the template overridden by those of the ScaledObject
+func mergeTemplateValues(template, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return template
+	}
+	merged := make(map[string]string, len(template)+len(overrides))
+	for key, value := range template {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+)
+
+func TestApplyScalingTemplate(t *testing.T) {
+	historyLimit := int32(5)
+	template := kedav1alpha1.ScalingTemplateSpec{
+		Triggers: []kedav1alpha1.ScaleTriggers{
+			{
+				Type:              "kafka",
+				Name:              "lag",
+				Metadata:          map[string]string{"bootstrapServers": "kafka:9092", "lagThreshold": "50"},
+				AuthenticationRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "kafka", Kind: "ClusterTriggerAuthentication"},
+			},
+			{
+				Type:     "cpu",
+				Name:     "cpu",
+				Metadata: map[string]string{"value": "80"},
+			},
+		},
+		Advanced: &kedav1alpha1.AdvancedConfig{ScalingHistoryLimit: &historyLimit},
+	}
+
+	tests := []struct {
+		name     string
+		spec     kedav1alpha1.ScaledObjectSpec
+		expected kedav1alpha1.ScaledObjectSpec
+		isError  bool
+	}{
+		{
+			name: "template only",
+			spec: kedav1alpha1.ScaledObjectSpec{},
+			expected: kedav1alpha1.ScaledObjectSpec{
+				Triggers: template.Triggers,
+				Advanced: template.Advanced,
+			},
+		},
+		{
+			name: "overridden and additional triggers",
+			spec: kedav1alpha1.ScaledObjectSpec{
+				Triggers: []kedav1alpha1.ScaleTriggers{
+					{Type: "cron", Metadata: map[string]string{"timezone": "UTC"}},
+					{Type: "kafka", Name: "lag", Metadata: map[string]string{"topic": "orders", "lagThreshold": "10"}},
+				},
+				Advanced: &kedav1alpha1.AdvancedConfig{RestoreToOriginalReplicaCount: true},
+			},
+			expected: kedav1alpha1.ScaledObjectSpec{
+				Triggers: []kedav1alpha1.ScaleTriggers{
+					{
+						Type:              "kafka",
+						Name:              "lag",
+						Metadata:          map[string]string{"bootstrapServers": "kafka:9092", "lagThreshold": "10", "topic": "orders"},
+						AuthenticationRef: &kedav1alpha1.ScaledObjectAuthRef{Name: "kafka", Kind: "ClusterTriggerAuthentication"},
+					},
+					template.Triggers[1],
+					{Type: "cron", Metadata: map[string]string{"timezone": "UTC"}},
+				},
+				Advanced: &kedav1alpha1.AdvancedConfig{RestoreToOriginalReplicaCount: true},
+			},
+		},
+		{
+			name: "overridden trigger of another type",
+			spec: kedav1alpha1.ScaledObjectSpec{
+				Triggers: []kedav1alpha1.ScaleTriggers{
+					{Type: "rabbitmq", Name: "lag", Metadata: map[string]string{"queueName": "orders"}},
+				},
+			},
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			spec := test.spec.DeepCopy()
+			err := applyScalingTemplate(spec, template.DeepCopy())
+			if test.isError {
+				if err == nil {
+					t.Error("Expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.expected, *spec); diff != "" {
+				t.Errorf("Resolved spec is different: %s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveScalingTemplate(t *testing.T) {
+	if err := kedav1alpha1.AddToScheme(scheme.Scheme); err != nil {
+		t.Errorf("Expected Error because: %v", err)
+	}
+	template := &kedav1alpha1.ScalingTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "kafka-lag", Generation: 3},
+		Spec: kedav1alpha1.ScalingTemplateSpec{
+			Triggers: []kedav1alpha1.ScaleTriggers{{Type: "kafka", Name: "lag", Metadata: map[string]string{"lagThreshold": "50"}}},
+		},
+	}
+	client := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(template).Build()
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: namespace},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			TemplateRef: &kedav1alpha1.ScalingTemplateRef{Name: "kafka-lag"},
+		},
+	}
+	resolved, generation, err := ResolveScalingTemplate(context.Background(), client, scaledObject)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if generation != 3 {
+		t.Errorf("Expected template generation 3 but got %d", generation)
+	}
+	if len(resolved.Spec.Triggers) != 1 || len(scaledObject.Spec.Triggers) != 0 {
+		t.Errorf("Expected the triggers to be resolved in a copy, got %d resolved and %d original triggers", len(resolved.Spec.Triggers), len(scaledObject.Spec.Triggers))
+	}
+
+	scaledObject.Spec.TemplateRef.Name = "missing"
+	if _, _, err := ResolveScalingTemplate(context.Background(), client, scaledObject); err == nil {
+		t.Error("Expected an error for a missing ScalingTemplate")
+	}
+
+	withoutTemplate := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: namespace}}
+	if resolved, _, err := ResolveScalingTemplate(context.Background(), client, withoutTemplate); err != nil || resolved != withoutTemplate {
+		t.Errorf("Expected the ScaledObject without template to be returned as is, got %v", err)
+	}
+}
@@ -19,6 +19,7 @@ package scaling
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,10 +29,12 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/metrics/pkg/apis/external_metrics"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/v2/controllers/keda/util"
 	"github.com/kedacore/keda/v2/pkg/eventreason"
 	"github.com/kedacore/keda/v2/pkg/scalers"
 	"github.com/kedacore/keda/v2/pkg/scaling/cache"
@@ -54,21 +57,34 @@ type scaleHandler struct {
 	scaleLoopContexts *sync.Map
 	scaleExecutor     executor.ScaleExecutor
 	globalHTTPTimeout time.Duration
+	forbidUnsafeSsl   bool
+	disabledScalers   map[string]bool
 	recorder          record.EventRecorder
 	scalerCaches      map[string]*cache.ScalersCache
+	rateEstimators    *rateEstimators
 	lock              *sync.RWMutex
 }
 
-// NewScaleHandler creates a ScaleHandler object
-func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, recorder record.EventRecorder) ScaleHandler {
+// NewScaleHandler creates a ScaleHandler object, the triggers of the disabledScalers types are rejected
+func NewScaleHandler(client client.Client, scaleClient scale.ScalesGetter, reconcilerScheme *runtime.Scheme, globalHTTPTimeout time.Duration, forbidUnsafeSsl bool, disabledScalers []string, recorder record.EventRecorder) ScaleHandler {
+	disabled := make(map[string]bool, len(disabledScalers))
+	for _, scalerType := range disabledScalers {
+		if scalerType = strings.TrimSpace(scalerType); scalerType != "" {
+			disabled[scalerType] = true
+		}
+	}
+
 	return &scaleHandler{
 		client:            client,
 		logger:            logf.Log.WithName("scalehandler"),
 		scaleLoopContexts: &sync.Map{},
 		scaleExecutor:     executor.NewScaleExecutor(client, scaleClient, reconcilerScheme, recorder),
 		globalHTTPTimeout: globalHTTPTimeout,
+		forbidUnsafeSsl:   forbidUnsafeSsl,
+		disabledScalers:   disabled,
 		recorder:          recorder,
 		scalerCaches:      map[string]*cache.ScalersCache{},
+		rateEstimators:    newRateEstimators(),
 		lock:              &sync.RWMutex{},
 	}
 }
@@ -129,6 +145,7 @@ func (h *scaleHandler) DeleteScalableObject(ctx context.Context, scalableObject
 		if err != nil {
 			h.logger.Error(err, "error clearing scalers cache")
 		}
+		h.rateEstimators.forget(withTriggers)
 		h.recorder.Event(withTriggers, corev1.EventTypeNormal, eventreason.KEDAScalersStopped, "Stopped scalers watch")
 	} else {
 		h.logger.V(1).Info("ScaledObject was not found in controller cache", "key", key)
@@ -164,6 +181,18 @@ func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1a
 }
 
 func (h *scaleHandler) GetScalersCache(ctx context.Context, scalableObject interface{}) (*cache.ScalersCache, error) {
+	// the scalers of a ScaledObject are built from the triggers of its ScalingTemplate too, and rebuilt
+	// when the template changes
+	var templateGeneration int64
+	var templateErr error
+	if scaledObject, ok := scalableObject.(*kedav1alpha1.ScaledObject); ok {
+		var resolved *kedav1alpha1.ScaledObject
+		resolved, templateGeneration, templateErr = resolver.ResolveScalingTemplate(ctx, h.client, scaledObject)
+		if templateErr == nil {
+			scalableObject = resolved
+		}
+	}
+
 	withTriggers, err := asDuckWithTriggers(scalableObject)
 	if err != nil {
 		return nil, err
@@ -172,7 +201,7 @@ func (h *scaleHandler) GetScalersCache(ctx context.Context, scalableObject inter
 	key := withTriggers.GenerateIdenitifier()
 
 	h.lock.RLock()
-	if cache, ok := h.scalerCaches[key]; ok && cache.Generation == withTriggers.Generation {
+	if cache, ok := h.scalerCaches[key]; ok && templateErr == nil && cache.Generation == withTriggers.Generation && cache.TemplateGeneration == templateGeneration {
 		h.lock.RUnlock()
 		return cache, nil
 	}
@@ -180,27 +209,43 @@ func (h *scaleHandler) GetScalersCache(ctx context.Context, scalableObject inter
 
 	h.lock.Lock()
 	defer h.lock.Unlock()
-	if cache, ok := h.scalerCaches[key]; ok && cache.Generation == withTriggers.Generation {
-		return cache, nil
-	} else if ok {
-		cache.Close(ctx)
+	oldCache, hasOldCache := h.scalerCaches[key]
+	if hasOldCache && templateErr == nil && oldCache.Generation == withTriggers.Generation && oldCache.TemplateGeneration == templateGeneration {
+		return oldCache, nil
 	}
 
-	podTemplateSpec, containerName, err := resolver.ResolveScaleTargetPodSpec(ctx, h.client, h.logger, scalableObject)
+	var podTemplateSpec *corev1.PodTemplateSpec
+	var containerName string
+	var scalers []cache.ScalerBuilder
+	err = templateErr
+	if err == nil {
+		podTemplateSpec, containerName, err = resolver.ResolveScaleTargetPodSpec(ctx, h.client, h.logger, scalableObject)
+	}
+	if err == nil {
+		scalers, err = h.buildScalers(ctx, withTriggers, podTemplateSpec, containerName)
+	}
 	if err != nil {
-		return nil, err
+		if !hasOldCache {
+			return nil, err
+		}
+		// roll back to the scalers of the last generation which could be built, so the HPA
+		// keeps getting the metrics it was created for
+		h.logger.Error(err, "error building scalers, using the scalers of the last generation", "object", withTriggers, "generation", oldCache.Generation)
+		lastGoodCache := *oldCache
+		lastGoodCache.BuildError = err
+		return &lastGoodCache, nil
 	}
 
-	scalers, err := h.buildScalers(ctx, withTriggers, podTemplateSpec, containerName)
-	if err != nil {
-		return nil, err
+	if hasOldCache {
+		oldCache.Close(ctx)
 	}
 
 	h.scalerCaches[key] = &cache.ScalersCache{
-		Generation: withTriggers.Generation,
-		Scalers:    scalers,
-		Logger:     h.logger,
-		Recorder:   h.recorder,
+		Generation:         withTriggers.Generation,
+		TemplateGeneration: templateGeneration,
+		Scalers:            scalers,
+		Logger:             h.logger,
+		Recorder:           h.recorder,
 	}
 
 	return h.scalerCaches[key], nil
@@ -225,6 +270,65 @@ func (h *scaleHandler) ClearScalersCache(ctx context.Context, scalableObject int
 	return nil
 }
 
+// persistAnnotations writes the annotations of the polling loop to the scalable object in a single patch: the
+// rate samples of its triggers, at most every rateSamplesPersistInterval, and for ScaledObjects with triggers
+// using cached metrics, the metrics fetched by the poll, served to the HPA by the metrics server. The unchanged
+// metrics are only written again when the annotation has to be refreshed. The patch is rejected when the object
+// changed since the poll fetched it, the annotations are then written by the next poll.
+func (h *scaleHandler) persistAnnotations(ctx context.Context, scalableObject client.Object, useCachedMetrics bool, metrics []external_metrics.ExternalMetricValue) error {
+	withTriggers, err := asDuckWithTriggers(scalableObject)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	annotations := map[string]string{}
+	rateSamples, rateSamplesDue, err := h.rateEstimators.encodeIfDue(withTriggers, now)
+	if err != nil {
+		return err
+	}
+	if rateSamplesDue {
+		annotations[kedacontrollerutil.ProcessingRateSamplesAnnotation] = rateSamples
+	}
+	if useCachedMetrics && cache.CachedMetricsOutdated(scalableObject.GetAnnotations()[kedacontrollerutil.CachedMetricsAnnotation], metrics, now) {
+		value, err := cache.EncodeCachedMetrics(metrics, now)
+		if err != nil {
+			return err
+		}
+		annotations[kedacontrollerutil.CachedMetricsAnnotation] = value
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+
+	patched := scalableObject.DeepCopyObject().(client.Object)
+	merged := patched.GetAnnotations()
+	if merged == nil {
+		merged = map[string]string{}
+	}
+	for annotation, value := range annotations {
+		merged[annotation] = value
+	}
+	patched.SetAnnotations(merged)
+	if err := h.client.Patch(ctx, patched, client.MergeFromWithOptions(scalableObject, client.MergeFromWithOptimisticLock{})); err != nil {
+		return err
+	}
+	if rateSamplesDue {
+		h.rateEstimators.markPersisted(withTriggers, now)
+	}
+	return nil
+}
+
+// usesCachedMetrics returns true if a trigger of the ScaledObject uses cached metrics
+func usesCachedMetrics(scaledObject *kedav1alpha1.ScaledObject) bool {
+	for _, trigger := range scaledObject.Spec.Triggers {
+		if trigger.UseCachedMetrics {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scalableObject interface{}, scalingMutex sync.Locker) {
 	logger := h.logger.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
 	cache, err := h.GetScalersCache(ctx, scalableObject)
@@ -233,7 +337,18 @@ func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav
 		return
 	}
 
+	// the ScaledObjects report the state of the push scalers streams in their conditions
+	_, isScaledObject := scalableObject.(*kedav1alpha1.ScaledObject)
+	key := types.NamespacedName{Namespace: withTriggers.Namespace, Name: withTriggers.Name}
+	streams := newPushStreams()
+
 	for _, ps := range cache.GetPushScalers() {
+		if reporter, ok := ps.(scalers.StreamStateReporter); ok && isScaledObject {
+			reporter.SetStreamStateHandler(func(state scalers.PushScalerStreamState) {
+				h.handlePushStreamState(ctx, streams, key, state)
+			})
+		}
+
 		go func(s scalers.PushScaler) {
 			activeCh := make(chan bool)
 			go s.Run(ctx, activeCh)
@@ -275,8 +390,18 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 			h.logger.Error(err, "Error getting scaledObject", "object", scalableObject)
 			return
 		}
-		isActive, isError, _ := cache.IsScaledObjectActive(ctx, obj)
-		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError)
+		resolved, _, err := resolver.ResolveScalingTemplate(ctx, h.client, obj)
+		if err != nil {
+			h.logger.Error(err, "Error resolving the ScalingTemplate of scaledObject", "object", scalableObject)
+			return
+		}
+		isActive, isError, metrics := cache.IsScaledObjectActive(ctx, resolved)
+		h.scaleExecutor.RequestScale(ctx, resolved, isActive, isError)
+		h.logQueries(obj, cache)
+		// the metrics of the failing triggers are dropped, so the metrics server queries them and reports the error
+		if err := h.persistAnnotations(ctx, obj, usesCachedMetrics(resolved), metrics); err != nil {
+			h.logger.Error(err, "Error persisting the annotations of scaledObject", "object", scalableObject)
+		}
 	case *kedav1alpha1.ScaledJob:
 		err = h.client.Get(ctx, types.NamespacedName{Name: obj.Name, Namespace: obj.Namespace}, obj)
 		if err != nil {
@@ -284,21 +409,58 @@ func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interfac
 			return
 		}
 		isActive, scaleTo, maxScale := cache.IsScaledJobActive(ctx, obj)
-		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, scaleTo, maxScale)
+		h.logQueries(obj, cache)
+		if err := h.persistAnnotations(ctx, obj, false, nil); err != nil {
+			h.logger.Error(err, "Error persisting the annotations of scaledJob", "object", scalableObject)
+		}
+		var priorities []string
+		if isActive && len(obj.Spec.PriorityMapping) > 0 {
+			priorities = cache.GetScaledJobPriorities(ctx, obj)
+		}
+		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, scaleTo, maxScale, priorities)
+	}
+}
+
+// logQueries logs the last query of each trigger of the scalable object, if it has the debug-queries annotation
+func (h *scaleHandler) logQueries(scalableObject client.Object, scalersCache *cache.ScalersCache) {
+	if scalableObject.GetAnnotations()[kedacontrollerutil.DebugQueriesAnnotation] != "true" {
+		return
+	}
+	logger := h.logger.WithValues("namespace", scalableObject.GetNamespace(), "name", scalableObject.GetName())
+	for triggerIndex, builder := range scalersCache.Scalers {
+		if query := builder.QueryRecorder.LastQuery(); query != "" {
+			logger.Info("Last query of the trigger", "triggerIndex", triggerIndex, "query", query)
+		}
 	}
 }
 
 // buildScalers returns list of Scalers for the specified triggers
 func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, podTemplateSpec *corev1.PodTemplateSpec, containerName string) ([]cache.ScalerBuilder, error) {
 	logger := h.logger.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
-	var err error
 	resolvedEnv := make(map[string]string)
 	result := make([]cache.ScalerBuilder, 0, len(withTriggers.Spec.Triggers))
 
 	for i, t := range withTriggers.Spec.Triggers {
 		triggerIndex, trigger := i, t
+		queryRecorder := &scalers.QueryRecorder{}
 
 		factory := func() (scalers.Scaler, error) {
+			if h.disabledScalers[trigger.Type] {
+				return nil, fmt.Errorf("trigger %d is of type %s, which is disabled in this cluster", triggerIndex, trigger.Type)
+			}
+
+			unsafeSsl, err := scalers.GetUnsafeSsl(trigger.Metadata)
+			if err != nil {
+				return nil, err
+			}
+			if unsafeSsl && h.forbidUnsafeSsl {
+				return nil, fmt.Errorf("trigger %d of type %s skips TLS verification with %s, which is forbidden in this cluster", triggerIndex, trigger.Type, scalers.UnsafeSslKey)
+			}
+			timeout, err := scalers.GetTimeout(trigger.Metadata, h.globalHTTPTimeout)
+			if err != nil {
+				return nil, err
+			}
+
 			if podTemplateSpec != nil {
 				resolvedEnv, err = resolver.ResolveContainerEnv(ctx, h.client, logger, &podTemplateSpec.Spec, containerName, withTriggers.Namespace)
 				if err != nil {
@@ -312,17 +474,30 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 				TriggerMetadata:         trigger.Metadata,
 				ResolvedEnv:             resolvedEnv,
 				AuthParams:              make(map[string]string),
-				GlobalHTTPTimeout:       h.globalHTTPTimeout,
+				GlobalHTTPTimeout:       timeout,
 				ScalerIndex:             triggerIndex,
 				MetricType:              trigger.MetricType,
+				RateEstimator:           h.rateEstimators.get(withTriggers, triggerIndex, trigger.Type, time.Now()),
+				QueryRecorder:           queryRecorder,
 			}
 
 			config.AuthParams, config.PodIdentity, err = resolver.ResolveAuthRefAndPodIdentity(ctx, h.client, logger, trigger.AuthenticationRef, podTemplateSpec, withTriggers.Namespace)
 			if err != nil {
 				return nil, err
 			}
+			if err := scalers.ResolveEgressProxy(config); err != nil {
+				return nil, err
+			}
+			if err := scalers.ResolveCustomCA(config); err != nil {
+				return nil, err
+			}
+			queryRecorder.SetSecrets(config)
 
-			return buildScaler(ctx, h.client, trigger.Type, config)
+			scaler, err := buildScaler(ctx, h.client, trigger.Type, config)
+			if err != nil {
+				return scaler, err
+			}
+			return applyActivationThreshold(trigger, scaler)
 		}
 
 		scaler, err := factory()
@@ -338,6 +513,53 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 			return nil, err
 		}
 
+		if unsafeSsl, _ := scalers.GetUnsafeSsl(trigger.Metadata); unsafeSsl {
+			h.recorder.Event(withTriggers, corev1.EventTypeWarning, eventreason.KEDAScalerUnsafeSsl, fmt.Sprintf("Trigger %d of type %s skips TLS certificate verification", triggerIndex, trigger.Type))
+		}
+
+		result = append(result, cache.ScalerBuilder{
+			Scaler:        scaler,
+			Factory:       factory,
+			QueryRecorder: queryRecorder,
+		})
+	}
+
+	// activation sources are indexed after the triggers
+	for i, s := range withTriggers.Spec.ActivationSources {
+		sourceIndex, source := len(withTriggers.Spec.Triggers)+i, s
+
+		factory := func() (scalers.Scaler, error) {
+			metadata := make(map[string]string, len(source.Metadata)+2)
+			for key, value := range source.Metadata {
+				metadata[key] = value
+			}
+			metadata["scalerAddress"] = source.ScalerAddress
+			if source.TLSCertFile != "" {
+				metadata["tlsCertFile"] = source.TLSCertFile
+			}
+
+			return scalers.NewActivationSourceScaler(&scalers.ScalerConfig{
+				ScalableObjectName:      withTriggers.Name,
+				ScalableObjectNamespace: withTriggers.Namespace,
+				ScalableObjectType:      withTriggers.Kind,
+				TriggerMetadata:         metadata,
+				ResolvedEnv:             resolvedEnv,
+				AuthParams:              make(map[string]string),
+				GlobalHTTPTimeout:       h.globalHTTPTimeout,
+				ScalerIndex:             sourceIndex,
+			})
+		}
+
+		scaler, err := factory()
+		if err != nil {
+			h.recorder.Event(withTriggers, corev1.EventTypeWarning, eventreason.KEDAScalerFailed, err.Error())
+			h.logger.Error(err, "error building activation source", "activationSource", source.Name, "object", withTriggers)
+			for _, builder := range result {
+				builder.Scaler.Close(ctx)
+			}
+			return nil, err
+		}
+
 		result = append(result, cache.ScalerBuilder{
 			Scaler:  scaler,
 			Factory: factory,
@@ -347,6 +569,51 @@ func (h *scaleHandler) buildScalers(ctx context.Context, withTriggers *kedav1alp
 	return result, nil
 }
 
+// triggersWithActivationThreshold are the trigger types whose scaler parses activationThreshold or activationValue
+// itself, with its own activation semantics
+var triggersWithActivationThreshold = map[string]bool{
+	"azure-data-explorer": true,
+	"azure-log-analytics": true,
+	"etcd":                true,
+	"gcp-cloudtasks":      true,
+	"gcp-pubsub":          true,
+	"graphite":            true,
+	"kubernetes-workload": true,
+	"loki":                true,
+	"memcached":           true,
+	"new-relic":           true,
+	"openstack-metric":    true,
+	"predictkube":         true,
+	"prometheus":          true,
+	"rabbitmq":            true,
+	"selenium-grid":       true,
+}
+
+// applyActivationThreshold makes the trigger active once its metric is above the standard activation threshold
+// of its metadata. The metadata of the external triggers is forwarded to the external scaler, the push
+// scalers report their activity themselves and the scalers of triggersWithActivationThreshold parse it, so
+// they handle the threshold.
+func applyActivationThreshold(trigger kedav1alpha1.ScaleTriggers, scaler scalers.Scaler) (scalers.Scaler, error) {
+	if triggersWithActivationThreshold[trigger.Type] {
+		return scaler, nil
+	}
+	threshold, err := scalers.GetActivationThreshold(trigger.Metadata)
+	if err != nil || threshold == nil {
+		return scaler, err
+	}
+
+	switch trigger.Type {
+	case "cpu", "memory":
+		return scaler, fmt.Errorf("%s triggers are always active, they don't support an activation threshold", trigger.Type)
+	case "external", "external-push":
+		return scaler, nil
+	}
+	if _, ok := scaler.(scalers.PushScaler); ok {
+		return scaler, nil
+	}
+	return scalers.NewActivationThresholdScaler(scaler, *threshold), nil
+}
+
 func buildScaler(ctx context.Context, client client.Client, triggerType string, config *scalers.ScalerConfig) (scalers.Scaler, error) {
 	// TRIGGERS-START
 	switch triggerType {
@@ -366,6 +633,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewAwsSqsQueueScaler(config)
 	case "azure-app-insights":
 		return scalers.NewAzureAppInsightsScaler(config)
+	case "azure-batch":
+		return scalers.NewAzureBatchScaler(config)
 	case "azure-blob":
 		return scalers.NewAzureBlobScaler(config)
 	case "azure-data-explorer":
@@ -384,14 +653,22 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewAzureServiceBusScaler(ctx, config)
 	case "cassandra":
 		return scalers.NewCassandraScaler(config)
+	case "ceph-rgw":
+		return scalers.NewCephRGWScaler(config)
+	case "couchdb":
+		return scalers.NewCouchDBScaler(config)
 	case "cpu":
 		return scalers.NewCPUMemoryScaler(corev1.ResourceCPU, config)
 	case "cron":
 		return scalers.NewCronScaler(config)
 	case "datadog":
 		return scalers.NewDatadogScaler(ctx, config)
+	case "druid":
+		return scalers.NewDruidScaler(config)
 	case "elasticsearch":
 		return scalers.NewElasticsearchScaler(config)
+	case "etcd":
+		return scalers.NewEtcdScaler(config)
 	case "external":
 		return scalers.NewExternalScaler(config)
 	// TODO: use other way for test.
@@ -399,14 +676,20 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewExternalMockScaler(config)
 	case "external-push":
 		return scalers.NewExternalPushScaler(config)
+	case "gcp-cloudtasks":
+		return scalers.NewCloudTasksScaler(config)
 	case "gcp-pubsub":
 		return scalers.NewPubSubScaler(config)
 	case "gcp-stackdriver":
 		return scalers.NewStackdriverScaler(ctx, config)
 	case "gcp-storage":
 		return scalers.NewGcsScaler(config)
+	case "gitlab-runner":
+		return scalers.NewGitLabRunnerScaler(config)
 	case "graphite":
 		return scalers.NewGraphiteScaler(config)
+	case "haproxy":
+		return scalers.NewHAProxyScaler(config)
 	case "huawei-cloudeye":
 		return scalers.NewHuaweiCloudeyeScaler(config)
 	case "ibmmq":
@@ -417,14 +700,28 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewKafkaScaler(config)
 	case "kubernetes-workload":
 		return scalers.NewKubernetesWorkloadScaler(client, config)
+	case "kubevirt":
+		return scalers.NewKubeVirtScaler(client, config)
 	case "liiklus":
 		return scalers.NewLiiklusScaler(config)
+	case "log-buffer":
+		return scalers.NewLogBufferScaler(config)
+	case "loki":
+		return scalers.NewLokiScaler(config)
+	case "memcached":
+		return scalers.NewMemcachedScaler(config)
 	case "memory":
 		return scalers.NewCPUMemoryScaler(corev1.ResourceMemory, config)
 	case "metrics-api":
 		return scalers.NewMetricsAPIScaler(config)
+	case "minio":
+		return scalers.NewMinIOScaler(config)
 	case "mongodb":
 		return scalers.NewMongoDBScaler(ctx, config)
+	case "msgraph":
+		return scalers.NewMSGraphScaler(config)
+	case "msmq":
+		return scalers.NewMSMQScaler(config)
 	case "mssql":
 		return scalers.NewMSSQLScaler(config)
 	case "mysql":
@@ -443,6 +740,8 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewPredictKubeScaler(ctx, config)
 	case "prometheus":
 		return scalers.NewPrometheusScaler(config)
+	case "proxy-concurrency":
+		return scalers.NewProxyConcurrencyScaler(client, config)
 	case "pulsar":
 		return scalers.NewPulsarScaler(config)
 	case "rabbitmq":
@@ -459,12 +758,24 @@ func buildScaler(ctx context.Context, client client.Client, triggerType string,
 		return scalers.NewRedisStreamsScaler(ctx, false, true, config)
 	case "redis-streams":
 		return scalers.NewRedisStreamsScaler(ctx, false, false, config)
+	case "salesforce":
+		return scalers.NewSalesforceScaler(config)
 	case "selenium-grid":
 		return scalers.NewSeleniumGridScaler(config)
+	case "signed-http":
+		return scalers.NewSignedHTTPScaler(config)
 	case "solace-event-queue":
 		return scalers.NewSolaceScaler(config)
+	case "solr":
+		return scalers.NewSolrScaler(config)
+	case "splunk":
+		return scalers.NewSplunkScaler(config)
 	case "stan":
 		return scalers.NewStanScaler(config)
+	case "temporal":
+		return scalers.NewTemporalScaler(config)
+	case "windows-performance-counter":
+		return scalers.NewWindowsPerfCounterScaler(client, config)
 	default:
 		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
 	}
@@ -478,8 +789,9 @@ func asDuckWithTriggers(scalableObject interface{}) (*kedav1alpha1.WithTriggers,
 			TypeMeta:   obj.TypeMeta,
 			ObjectMeta: obj.ObjectMeta,
 			Spec: kedav1alpha1.WithTriggersSpec{
-				PollingInterval: obj.Spec.PollingInterval,
-				Triggers:        obj.Spec.Triggers,
+				PollingInterval:   obj.Spec.PollingInterval,
+				Triggers:          obj.Spec.Triggers,
+				ActivationSources: obj.Spec.ActivationSources,
 			},
 		}, nil
 	case *kedav1alpha1.ScaledJob:
@@ -491,6 +803,14 @@ func asDuckWithTriggers(scalableObject interface{}) (*kedav1alpha1.WithTriggers,
 				Triggers:        obj.Spec.Triggers,
 			},
 		}, nil
+	case *kedav1alpha1.MetricSource:
+		return &kedav1alpha1.WithTriggers{
+			TypeMeta:   obj.TypeMeta,
+			ObjectMeta: obj.ObjectMeta,
+			Spec: kedav1alpha1.WithTriggersSpec{
+				Triggers: obj.Spec.Triggers,
+			},
+		}, nil
 	default:
 		// here could be the conversion from unknown Duck type potentially in the future
 		return nil, fmt.Errorf("unknown scalable object type %v", scalableObject)
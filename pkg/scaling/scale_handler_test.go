@@ -20,13 +20,18 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/v2/apis/keda/v1alpha1"
@@ -85,7 +90,7 @@ func TestCheckScaledObjectFindFirstActiveNotIgnoreOthers(t *testing.T) {
 	activeFactory := func() (scalers.Scaler, error) {
 		scaler := mock_scalers.NewMockScaler(ctrl)
 		scaler.EXPECT().IsActive(gomock.Any()).Return(true, nil)
-		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Times(2).Return(metricsSpecs)
+		scaler.EXPECT().GetMetricSpecForScaling(gomock.Any()).Return(metricsSpecs)
 		scaler.EXPECT().Close(gomock.Any())
 		return scaler, nil
 	}
@@ -144,3 +149,117 @@ func createMetricSpec(averageValue int64) v2beta2.MetricSpec {
 		},
 	}
 }
+
+func TestGetScalersCacheRollsBackToLastGoodGeneration(t *testing.T) {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Generation: 1},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "test"},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "cpu", Metadata: map[string]string{"type": "Utilization", "value": "50"}},
+			},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &kedav1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+	handler := NewScaleHandler(client, nil, scheme, time.Second, false, nil, record.NewFakeRecorder(10))
+
+	cache, err := handler.GetScalersCache(context.Background(), scaledObject)
+	assert.NoError(t, err)
+	assert.Nil(t, cache.BuildError)
+	assert.Equal(t, int64(1), cache.Generation)
+
+	// an invalid quantity can't be built, the scalers of the first generation are kept
+	scaledObject.Generation = 2
+	scaledObject.Spec.Triggers[0].Metadata = map[string]string{"type": "AverageValue", "value": "50 Mi"}
+	cache, err = handler.GetScalersCache(context.Background(), scaledObject)
+	assert.NoError(t, err)
+	assert.Error(t, cache.BuildError)
+	assert.Equal(t, int64(1), cache.Generation)
+	assert.Len(t, cache.GetScalers(), 1)
+
+	scaledObject.Generation = 3
+	scaledObject.Spec.Triggers[0].Metadata = map[string]string{"type": "AverageValue", "value": "50Mi"}
+	cache, err = handler.GetScalersCache(context.Background(), scaledObject)
+	assert.NoError(t, err)
+	assert.Nil(t, cache.BuildError)
+	assert.Equal(t, int64(3), cache.Generation)
+
+	// without a previous generation the error is returned
+	_, err = handler.GetScalersCache(context.Background(), &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test", Generation: 1},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "test"},
+			Triggers:       []kedav1alpha1.ScaleTriggers{{Type: "cpu", Metadata: map[string]string{"type": "AverageValue", "value": "50 Mi"}}},
+		},
+		Status: scaledObject.Status,
+	})
+	assert.Error(t, err)
+}
+
+func TestGetScalersCacheRejectsDisabledScalers(t *testing.T) {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Generation: 1},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "test"},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "cpu", Metadata: map[string]string{"type": "Utilization", "value": "50"}},
+			},
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			ScaleTargetGVKR: &kedav1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+
+	handler := NewScaleHandler(client, nil, scheme, time.Second, false, []string{"postgresql", " mssql"}, record.NewFakeRecorder(10))
+	_, err := handler.GetScalersCache(context.Background(), scaledObject)
+	assert.NoError(t, err)
+
+	handler = NewScaleHandler(client, nil, scheme, time.Second, false, []string{"postgresql", " cpu ", ""}, record.NewFakeRecorder(10))
+	_, err = handler.GetScalersCache(context.Background(), scaledObject)
+	assert.ErrorContains(t, err, "type cpu, which is disabled")
+}
+
+func TestApplyActivationThreshold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	scaler := mock_scalers.NewMockScaler(ctrl)
+	pushScaler := mock_scalers.NewMockPushScaler(ctrl)
+	threshold := map[string]string{"activationThreshold": "5"}
+
+	s, err := applyActivationThreshold(kedav1alpha1.ScaleTriggers{Type: "datadog", Metadata: map[string]string{}}, scaler)
+	assert.NoError(t, err)
+	assert.Same(t, scaler, s)
+
+	s, err = applyActivationThreshold(kedav1alpha1.ScaleTriggers{Type: "datadog", Metadata: threshold}, scaler)
+	assert.NoError(t, err)
+	assert.NotSame(t, scaler, s)
+
+	s, err = applyActivationThreshold(kedav1alpha1.ScaleTriggers{Type: "prometheus", Metadata: threshold}, scaler)
+	assert.NoError(t, err)
+	assert.Same(t, scaler, s)
+
+	s, err = applyActivationThreshold(kedav1alpha1.ScaleTriggers{Type: "external", Metadata: threshold}, scaler)
+	assert.NoError(t, err)
+	assert.Same(t, scaler, s)
+
+	s, err = applyActivationThreshold(kedav1alpha1.ScaleTriggers{Type: "datadog", Metadata: threshold}, pushScaler)
+	assert.NoError(t, err)
+	assert.Same(t, pushScaler, s)
+
+	_, err = applyActivationThreshold(kedav1alpha1.ScaleTriggers{Type: "cpu", Metadata: threshold}, scaler)
+	assert.ErrorContains(t, err, "don't support an activation threshold")
+
+	_, err = applyActivationThreshold(kedav1alpha1.ScaleTriggers{Type: "datadog", Metadata: map[string]string{"activationValue": "a"}}, scaler)
+	assert.Error(t, err)
+}
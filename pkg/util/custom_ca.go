@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultCustomCADir is the directory the custom CA certificates of the cluster are loaded from, unless
+// KEDA_CUSTOM_CA_DIR is set
+const DefaultCustomCADir = "/custom/ca"
+
+var (
+	// customCAs are the PEM encoded custom CA certificates of the cluster
+	customCAs [][]byte
+	// rootCAs are the CA certificates trusted by the HTTP clients, nil for the ones of the system
+	rootCAs *x509.CertPool
+)
+
+// LoadCustomCAs loads the PEM encoded CA certificates of the files of the directory, usually a mounted
+// ConfigMap or Secret. The HTTP clients trust them on top of the CA certificates of the system.
+// A missing directory isn't an error.
+func LoadCustomCAs(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading custom CA directory %s: %s", dir, err)
+	}
+
+	var certs [][]byte
+	for _, entry := range entries {
+		// the mounted volumes keep their data in hidden directories linked from the visible files
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		cert, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("error reading custom CA %s: %s", entry.Name(), err)
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(cert) {
+			return fmt.Errorf("custom CA %s has no PEM encoded certificate", entry.Name())
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil
+	}
+
+	pool, err := newRootCAs(certs)
+	if err != nil {
+		return err
+	}
+	customCAs, rootCAs = certs, pool
+	return nil
+}
+
+// NewRootCAs returns the CA certificates trusted by the HTTP clients with the PEM encoded CA certificates
+// added, like the ones of a trigger authentication. The empty CA certificates are skipped.
+func NewRootCAs(caCerts ...string) (*x509.CertPool, error) {
+	certs := make([][]byte, 0, len(customCAs)+len(caCerts))
+	certs = append(certs, customCAs...)
+	for _, caCert := range caCerts {
+		if caCert == "" {
+			continue
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM([]byte(caCert)) {
+			return nil, fmt.Errorf("CA has no PEM encoded certificate")
+		}
+		certs = append(certs, []byte(caCert))
+	}
+	return newRootCAs(certs)
+}
+
+// SetRootCAs makes the transport trust the CA certificates instead of the ones of the cluster, it does
+// nothing without CA certificates
+func SetRootCAs(transport *http.Transport, pool *x509.CertPool) {
+	if pool == nil {
+		return
+	}
+	transport.TLSClientConfig.RootCAs = pool
+}
+
+func newRootCAs(certs [][]byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, fmt.Errorf("error loading system CA certificates: %s", err)
+	}
+	for _, cert := range certs {
+		pool.AppendCertsFromPEM(cert)
+	}
+	return pool, nil
+}
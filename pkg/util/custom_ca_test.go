@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadCustomCAs(t *testing.T) {
+	defer func() { customCAs, rootCAs = nil, nil }()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	if err := LoadCustomCAs(filepath.Join(t.TempDir(), "missing")); err != nil {
+		t.Fatalf("expected a missing directory to be ignored but got %s", err)
+	}
+	if _, err := CreateHTTPClient(time.Second, false).Get(server.URL); err == nil {
+		t.Fatal("expected the certificate of the server not to be trusted without custom CA")
+	}
+
+	invalidDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(invalidDir, "ca.crt"), []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadCustomCAs(invalidDir); err == nil {
+		t.Fatal("expected an error for a file without certificate")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ca.crt"), serverCA, 0600); err != nil {
+		t.Fatal(err)
+	}
+	// the hidden entries of the mounted volumes are skipped
+	if err := os.Mkdir(filepath.Join(dir, "..data"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadCustomCAs(dir); err != nil {
+		t.Fatalf("expected no error but got %s", err)
+	}
+	resp, err := CreateHTTPClient(time.Second, false).Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the certificate of the server to be trusted but got %s", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	if _, err := NewRootCAs("not a certificate"); err == nil {
+		t.Fatal("expected an error for a custom CA without certificate")
+	}
+
+	pool, err := NewRootCAs(string(serverCA))
+	if err != nil {
+		t.Fatalf("expected no error but got %s", err)
+	}
+	httpClient := CreateHTTPClient(time.Second, false)
+	SetRootCAs(httpClient.Transport.(*http.Transport), pool)
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the certificate of the server to be trusted but got %s", err)
+	}
+	resp.Body.Close()
+
+	// the HTTP clients without custom CA keep trusting the CA certificates of the cluster
+	if _, err := CreateHTTPClient(time.Second, false).Get(server.URL); err == nil {
+		t.Fatal("expected the custom CA to only be trusted by the HTTP client it's set on")
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+const egressDialTimeout = 30 * time.Second
+
+// EgressDialer opens connections through the egress proxy
+type EgressDialer interface {
+	proxy.Dialer
+	proxy.ContextDialer
+}
+
+// ParseEgressProxy parses the URL of the egress gateway, a SOCKS5 proxy (socks5:// or socks5h://) or an HTTP
+// proxy accepting CONNECT requests (http:// or https://). The username and password are set on the URL.
+func ParseEgressProxy(proxyURL, username, password string) (*url.URL, error) {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing egress proxy: %s", err)
+	}
+	switch u.Scheme {
+	case "socks5", "socks5h", "http", "https":
+	default:
+		return nil, fmt.Errorf("egress proxy scheme must be socks5, socks5h, http or https, got %q", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("egress proxy %s has no host", proxyURL)
+	}
+
+	if password != "" && username == "" {
+		return nil, fmt.Errorf("egress proxy password must be provided with a username")
+	}
+	if username != "" {
+		u.User = url.UserPassword(username, password)
+	}
+	return u, nil
+}
+
+// SetEgressProxy sends the requests of the transport through the egress proxy instead of the proxy of the
+// environment, it does nothing without proxy
+func SetEgressProxy(transport *http.Transport, proxyURL *url.URL) {
+	if proxyURL == nil {
+		return
+	}
+	u := *proxyURL
+	// the transport resolves the host names on the SOCKS5 proxy, it only knows the socks5 scheme
+	if u.Scheme == "socks5h" {
+		u.Scheme = "socks5"
+	}
+	transport.Proxy = http.ProxyURL(&u)
+}
+
+// NewEgressDialer returns a dialer opening the TCP connections through the egress proxy
+func NewEgressDialer(proxyURL *url.URL) (EgressDialer, error) {
+	forward := &net.Dialer{Timeout: egressDialTimeout, KeepAlive: egressDialTimeout}
+
+	switch proxyURL.Scheme {
+	case "http", "https":
+		return &httpConnectDialer{proxyURL: proxyURL, forward: forward}, nil
+	default:
+		dialer, err := proxy.FromURL(proxyURL, forward)
+		if err != nil {
+			return nil, err
+		}
+		egressDialer, ok := dialer.(EgressDialer)
+		if !ok {
+			return nil, fmt.Errorf("egress proxy %s doesn't support contexts", proxyURL.Redacted())
+		}
+		return egressDialer, nil
+	}
+}
+
+// httpConnectDialer tunnels the connections through an HTTP proxy with CONNECT requests
+type httpConnectDialer struct {
+	proxyURL *url.URL
+	forward  *net.Dialer
+}
+
+func (d *httpConnectDialer) Dial(network, addr string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, addr)
+}
+
+func (d *httpConnectDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	proxyAddr := d.proxyURL.Host
+	if d.proxyURL.Port() == "" {
+		port := "80"
+		if d.proxyURL.Scheme == "https" {
+			port = "443"
+		}
+		proxyAddr = net.JoinHostPort(d.proxyURL.Hostname(), port)
+	}
+
+	conn, err := d.forward.DialContext(ctx, network, proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to egress proxy: %s", err)
+	}
+	if d.proxyURL.Scheme == "https" {
+		tlsConfig := &tls.Config{ServerName: d.proxyURL.Hostname()}
+		applyFIPSTLSSettings(tlsConfig)
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	// the deadline of the context bounds the CONNECT request
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{})
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if d.proxyURL.User != nil {
+		password, _ := d.proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(d.proxyURL.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error sending CONNECT to egress proxy: %s", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error reading CONNECT response of egress proxy: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("egress proxy refused to connect to %s: %s", addr, resp.Status)
+	}
+	if reader.Buffered() > 0 {
+		// the server spoke first, its data was read with the response
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn returns the data already read from the connection before reading from it again
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
@@ -16,6 +16,16 @@ func ResolveOsEnvInt(envName string, defaultValue int) (int, error) {
 	return defaultValue, nil
 }
 
+func ResolveOsEnvBool(envName string, defaultValue bool) (bool, error) {
+	valueStr, found := os.LookupEnv(envName)
+
+	if found && valueStr != "" {
+		return strconv.ParseBool(valueStr)
+	}
+
+	return defaultValue, nil
+}
+
 func ResolveOsEnvDuration(envName string) (*time.Duration, error) {
 	valueStr, found := os.LookupEnv(envName)
 
@@ -41,3 +41,18 @@ func TestResolveValidOsEnvDuration(t *testing.T) {
 	assert.Equal(t, time.Duration(30)*time.Minute, *actual)
 	assert.Nil(t, err)
 }
+
+func TestResolveOsEnvBool(t *testing.T) {
+	actual, err := ResolveOsEnvBool("missing_bool", true)
+	assert.True(t, actual)
+	assert.Nil(t, err)
+
+	t.Setenv("valid_bool", "true")
+	actual, err = ResolveOsEnvBool("valid_bool", false)
+	assert.True(t, actual)
+	assert.Nil(t, err)
+
+	t.Setenv("invalid_bool", "yes please")
+	_, err = ResolveOsEnvBool("invalid_bool", false)
+	assert.NotNil(t, err)
+}
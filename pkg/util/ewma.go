@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+)
+
+// EWMA is an exponentially weighted moving average, safe for concurrent use
+type EWMA struct {
+	alpha       float64
+	value       float64
+	initialized bool
+	lock        sync.Mutex
+}
+
+// NewEWMA creates an EWMA averaging over roughly the last window samples
+func NewEWMA(window int) *EWMA {
+	return &EWMA{
+		alpha: 2 / (float64(window) + 1),
+	}
+}
+
+// Add adds a sample and returns the new average, the first sample initializes the average
+func (e *EWMA) Add(sample float64) float64 {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if !e.initialized {
+		e.value = sample
+		e.initialized = true
+		return e.value
+	}
+
+	e.value = e.alpha*sample + (1-e.alpha)*e.value
+	return e.value
+}
+
+// Value returns the current average, 0 if no sample was added
+func (e *EWMA) Value() float64 {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.value
+}
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEWMA(t *testing.T) {
+	ewma := NewEWMA(3)
+
+	if value := ewma.Add(10); value != 10 {
+		t.Errorf("Expected first sample to initialize the average to 10 but got %v", value)
+	}
+	if value := ewma.Add(20); value != 15 {
+		t.Errorf("Expected average 15 but got %v", value)
+	}
+	if value := ewma.Add(0); value != 7.5 {
+		t.Errorf("Expected average 7.5 but got %v", value)
+	}
+}
+
+func TestEWMAWindowOfOne(t *testing.T) {
+	ewma := NewEWMA(1)
+
+	for _, sample := range []float64{10, 100, 3} {
+		if value := ewma.Add(sample); math.Abs(value-sample) > 1e-9 {
+			t.Errorf("Expected average to follow the samples with a window of 1, expected %v but got %v", sample, value)
+		}
+	}
+}
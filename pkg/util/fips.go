@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/tls"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	fipsEnabledPath = "/proc/sys/crypto/fips_enabled"
+
+	// CryptoModeFIPS is reported when the host runs in FIPS mode
+	CryptoModeFIPS = "fips"
+	// CryptoModeStandard is reported when the host doesn't run in FIPS mode
+	CryptoModeStandard = "standard"
+)
+
+var (
+	fipsEnabled     bool
+	fipsEnabledOnce sync.Once
+
+	// fipsCipherSuites are the TLS 1.2 cipher suites approved by FIPS 140-2
+	fipsCipherSuites = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	}
+
+	// fipsCurves are the elliptic curves approved by FIPS 140-2
+	fipsCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+)
+
+// IsFIPSEnabled returns true if the host kernel runs in FIPS mode
+func IsFIPSEnabled() bool {
+	fipsEnabledOnce.Do(func() {
+		fipsEnabled = isFIPSEnabled(fipsEnabledPath)
+	})
+	return fipsEnabled
+}
+
+func isFIPSEnabled(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(data)) == "1"
+}
+
+// CryptoMode returns the crypto mode in use, CryptoModeFIPS or CryptoModeStandard
+func CryptoMode() string {
+	if IsFIPSEnabled() {
+		return CryptoModeFIPS
+	}
+	return CryptoModeStandard
+}
+
+// applyFIPSTLSSettings restricts the TLS config to FIPS approved versions, cipher suites and curves
+// when the host runs in FIPS mode
+func applyFIPSTLSSettings(config *tls.Config) {
+	if config == nil || !IsFIPSEnabled() {
+		return
+	}
+	setFIPSTLSSettings(config)
+}
+
+func setFIPSTLSSettings(config *tls.Config) {
+	config.MinVersion = tls.VersionTLS12
+	config.CipherSuites = fipsCipherSuites
+	config.CurvePreferences = fipsCurves
+}
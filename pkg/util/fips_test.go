@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsFIPSEnabled(t *testing.T) {
+	dir := t.TempDir()
+
+	testCases := []struct {
+		content  string
+		expected bool
+	}{
+		{"1\n", true},
+		{"0\n", false},
+		{"", false},
+	}
+	for i, tc := range testCases {
+		path := filepath.Join(dir, fmt.Sprintf("fips_enabled_%d", i))
+		if err := os.WriteFile(path, []byte(tc.content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if enabled := isFIPSEnabled(path); enabled != tc.expected {
+			t.Errorf("Expected %v for %q but got %v", tc.expected, tc.content, enabled)
+		}
+	}
+
+	if isFIPSEnabled(filepath.Join(dir, "missing")) {
+		t.Error("Expected FIPS mode to be disabled when the file doesn't exist")
+	}
+}
+
+func TestSetFIPSTLSSettings(t *testing.T) {
+	config := &tls.Config{}
+	setFIPSTLSSettings(config)
+
+	if config.MinVersion != tls.VersionTLS12 {
+		t.Errorf("Expected min version TLS 1.2 but got %x", config.MinVersion)
+	}
+	for _, suite := range config.CipherSuites {
+		if suite == tls.TLS_RSA_WITH_AES_128_CBC_SHA {
+			t.Error("Expected only FIPS approved cipher suites")
+		}
+	}
+	if len(config.CurvePreferences) == 0 {
+		t.Error("Expected curve preferences to be restricted")
+	}
+}
@@ -0,0 +1,379 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Formula is an arithmetic expression over named values, with the +, -, * and / operators, parentheses
+// and numbers. Conditional formulas have the <, <=, >, >=, == and != comparisons, the &&, || and !
+// operators and the cond ? a : b ternary operator as well, conditions are 1 when true and 0 when false.
+type Formula struct {
+	root formulaNode
+}
+
+// ParseFormula parses the formula, which can only reference the given names
+func ParseFormula(formula string, names []string) (*Formula, error) {
+	return parseFormula(formula, names, false)
+}
+
+// ParseConditionalFormula parses the formula with conditions, which can only reference the given names
+func ParseConditionalFormula(formula string, names []string) (*Formula, error) {
+	return parseFormula(formula, names, true)
+}
+
+func parseFormula(formula string, names []string, conditional bool) (*Formula, error) {
+	tokens, err := tokenizeFormula(formula)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing formula %q: %s", formula, err)
+	}
+
+	known := make(map[string]bool, len(names))
+	for _, name := range names {
+		known[name] = true
+	}
+	p := &formulaParser{tokens: tokens, known: known, conditional: conditional}
+	root, err := p.parseTernary()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %s at position %d", p.tokens[p.pos].text, p.tokens[p.pos].pos)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid formula %q: %s", formula, err)
+	}
+	return &Formula{root: root}, nil
+}
+
+// Evaluate returns the value of the formula for the given values, failing on a division by zero. Only the
+// branch of a ternary operator matching its condition is evaluated.
+func (f *Formula) Evaluate(values map[string]float64) (float64, error) {
+	return f.root.evaluate(values)
+}
+
+type formulaTokenKind int
+
+const (
+	formulaNumber formulaTokenKind = iota
+	formulaName
+	formulaOperator
+)
+
+type formulaToken struct {
+	kind formulaTokenKind
+	text string
+	pos  int
+}
+
+// formulaOperators are the operators of the formulas, the two characters ones first
+var formulaOperators = []string{"<=", ">=", "==", "!=", "&&", "||", "+", "-", "*", "/", "(", ")", "<", ">", "!", "?", ":"}
+
+func tokenizeFormula(formula string) ([]formulaToken, error) {
+	var tokens []formulaToken
+	for pos := 0; pos < len(formula); {
+		c := formula[pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			pos++
+		case isFormulaDigit(c) || c == '.':
+			end := pos
+			for end < len(formula) && (isFormulaDigit(formula[end]) || formula[end] == '.') {
+				end++
+			}
+			if end < len(formula) && (formula[end] == 'e' || formula[end] == 'E') {
+				end++
+				if end < len(formula) && (formula[end] == '+' || formula[end] == '-') {
+					end++
+				}
+				for end < len(formula) && isFormulaDigit(formula[end]) {
+					end++
+				}
+			}
+			if _, err := strconv.ParseFloat(formula[pos:end], 64); err != nil {
+				return nil, fmt.Errorf("invalid number %s at position %d", formula[pos:end], pos)
+			}
+			tokens = append(tokens, formulaToken{kind: formulaNumber, text: formula[pos:end], pos: pos})
+			pos = end
+		case isFormulaLetter(c):
+			end := pos
+			for end < len(formula) && (isFormulaLetter(formula[end]) || isFormulaDigit(formula[end])) {
+				end++
+			}
+			tokens = append(tokens, formulaToken{kind: formulaName, text: formula[pos:end], pos: pos})
+			pos = end
+		default:
+			operator := ""
+			for _, op := range formulaOperators {
+				if strings.HasPrefix(formula[pos:], op) {
+					operator = op
+					break
+				}
+			}
+			if operator == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, pos)
+			}
+			tokens = append(tokens, formulaToken{kind: formulaOperator, text: operator, pos: pos})
+			pos += len(operator)
+		}
+	}
+	return tokens, nil
+}
+
+func isFormulaDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isFormulaLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+// formulaParser is a recursive descent parser of the formulas, the operators have the precedence of Go
+type formulaParser struct {
+	tokens      []formulaToken
+	pos         int
+	known       map[string]bool
+	conditional bool
+}
+
+// accept consumes the next token if it is one of the operators
+func (p *formulaParser) accept(operators ...string) (string, bool) {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != formulaOperator {
+		return "", false
+	}
+	for _, op := range operators {
+		if p.tokens[p.pos].text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *formulaParser) parseTernary() (formulaNode, error) {
+	cond, err := p.parseBinary(0)
+	if err != nil || !p.conditional {
+		return cond, err
+	}
+	if _, ok := p.accept("?"); !ok {
+		return cond, nil
+	}
+	x, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept(":"); !ok {
+		return nil, fmt.Errorf("missing : of the ternary operator")
+	}
+	y, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	return &ternaryNode{cond: cond, x: x, y: y}, nil
+}
+
+// formulaPrecedences are the binary operators by increasing precedence, the first ones are only
+// allowed in conditional formulas
+var formulaPrecedences = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/"},
+}
+
+const formulaConditionalPrecedences = 3
+
+func (p *formulaParser) parseBinary(precedence int) (formulaNode, error) {
+	if precedence == len(formulaPrecedences) {
+		return p.parseUnary()
+	}
+	if !p.conditional && precedence < formulaConditionalPrecedences {
+		return p.parseBinary(formulaConditionalPrecedences)
+	}
+
+	x, err := p.parseBinary(precedence + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(formulaPrecedences[precedence]...)
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseBinary(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryNode{op: op, x: x, y: y}
+	}
+}
+
+func (p *formulaParser) parseUnary() (formulaNode, error) {
+	operators := []string{"-", "+"}
+	if p.conditional {
+		operators = append(operators, "!")
+	}
+	if op, ok := p.accept(operators...); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op, x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *formulaParser) parsePrimary() (formulaNode, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("unexpected end of formula")
+	}
+	token := p.tokens[p.pos]
+	switch token.kind {
+	case formulaNumber:
+		p.pos++
+		value, _ := strconv.ParseFloat(token.text, 64)
+		return numberNode(value), nil
+	case formulaName:
+		if !p.known[token.text] {
+			return nil, fmt.Errorf("unknown name %s", token.text)
+		}
+		p.pos++
+		return nameNode(token.text), nil
+	}
+	if _, ok := p.accept("("); ok {
+		x, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, fmt.Errorf("missing ) for ( at position %d", token.pos)
+		}
+		return x, nil
+	}
+	return nil, fmt.Errorf("unexpected %s at position %d", token.text, token.pos)
+}
+
+type formulaNode interface {
+	evaluate(values map[string]float64) (float64, error)
+}
+
+type numberNode float64
+
+func (n numberNode) evaluate(map[string]float64) (float64, error) {
+	return float64(n), nil
+}
+
+type nameNode string
+
+func (n nameNode) evaluate(values map[string]float64) (float64, error) {
+	value, ok := values[string(n)]
+	if !ok {
+		return 0, fmt.Errorf("no value for %s", n)
+	}
+	return value, nil
+}
+
+type unaryNode struct {
+	op string
+	x  formulaNode
+}
+
+func (n *unaryNode) evaluate(values map[string]float64) (float64, error) {
+	x, err := n.x.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case "-":
+		return -x, nil
+	case "!":
+		return formulaCondition(x == 0), nil
+	}
+	return x, nil
+}
+
+type binaryNode struct {
+	op   string
+	x, y formulaNode
+}
+
+func (n *binaryNode) evaluate(values map[string]float64) (float64, error) {
+	x, err := n.x.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+	// the second condition isn't evaluated when the first one decides
+	if (n.op == "&&" && x == 0) || (n.op == "||" && x != 0) {
+		return formulaCondition(x != 0), nil
+	}
+	y, err := n.y.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case "+":
+		return x + y, nil
+	case "-":
+		return x - y, nil
+	case "*":
+		return x * y, nil
+	case "/":
+		if y == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return x / y, nil
+	case "==":
+		return formulaCondition(x == y), nil
+	case "!=":
+		return formulaCondition(x != y), nil
+	case "<":
+		return formulaCondition(x < y), nil
+	case "<=":
+		return formulaCondition(x <= y), nil
+	case ">":
+		return formulaCondition(x > y), nil
+	case ">=":
+		return formulaCondition(x >= y), nil
+	default: // && and ||
+		return formulaCondition(y != 0), nil
+	}
+}
+
+type ternaryNode struct {
+	cond, x, y formulaNode
+}
+
+func (n *ternaryNode) evaluate(values map[string]float64) (float64, error) {
+	cond, err := n.cond.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+	if cond != 0 {
+		return n.x.evaluate(values)
+	}
+	return n.y.evaluate(values)
+}
+
+// formulaCondition returns the value of a condition, 1 when true and 0 when false
+func formulaCondition(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
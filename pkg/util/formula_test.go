@@ -0,0 +1,127 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestParseFormula(t *testing.T) {
+	testCases := []struct {
+		formula string
+		isError bool
+	}{
+		{"a/b", false},
+		{"(a + b) * 2.5 - -c", false},
+		{"a", false},
+		{"d", true},
+		{"a %  b", true},
+		{"a == b", true},
+		{"max(a, b)", true},
+		{`"a"`, true},
+		{"a +", true},
+	}
+
+	for _, testCase := range testCases {
+		_, err := ParseFormula(testCase.formula, []string{"a", "b", "c"})
+		if err != nil && !testCase.isError {
+			t.Errorf("%s: expected success but got error %s", testCase.formula, err)
+		}
+		if err == nil && testCase.isError {
+			t.Errorf("%s: expected error but got success", testCase.formula)
+		}
+	}
+}
+
+func TestEvaluateFormula(t *testing.T) {
+	values := map[string]float64{"a": 10, "b": 4, "c": 0}
+
+	testCases := []struct {
+		formula  string
+		expected float64
+		isError  bool
+	}{
+		{"a/b", 2.5, false},
+		{"(a + b) * 2 - -b", 32, false},
+		{"a - b * 2", 2, false},
+		{"a / c", 0, true},
+	}
+
+	for _, testCase := range testCases {
+		formula, err := ParseFormula(testCase.formula, []string{"a", "b", "c"})
+		if err != nil {
+			t.Fatalf("%s: expected success but got error %s", testCase.formula, err)
+		}
+		value, err := formula.Evaluate(values)
+		if err != nil && !testCase.isError {
+			t.Errorf("%s: expected success but got error %s", testCase.formula, err)
+		}
+		if err == nil && testCase.isError {
+			t.Errorf("%s: expected error but got success", testCase.formula)
+		}
+		if err == nil && value != testCase.expected {
+			t.Errorf("%s: expected %v but got %v", testCase.formula, testCase.expected, value)
+		}
+	}
+}
+
+func TestParseConditionalFormula(t *testing.T) {
+	testCases := []struct {
+		formula string
+		isError bool
+	}{
+		{"(a + b)/2 > 10 ? a : b", false},
+		{"a > 1 && b <= 2 || !(c == 0) ? 1 : a > 2 ? 2 : 3", false},
+		{"a >= b", false},
+		{"a ? b", true},
+		{"a ? b : d", true},
+		{"a > ", true},
+		{"(a > b", true},
+		{"a & b", true},
+	}
+
+	for _, testCase := range testCases {
+		_, err := ParseConditionalFormula(testCase.formula, []string{"a", "b", "c"})
+		if err != nil && !testCase.isError {
+			t.Errorf("%s: expected success but got error %s", testCase.formula, err)
+		}
+		if err == nil && testCase.isError {
+			t.Errorf("%s: expected error but got success", testCase.formula)
+		}
+	}
+}
+
+func TestEvaluateConditionalFormula(t *testing.T) {
+	values := map[string]float64{"a": 10, "b": 4, "c": 0}
+
+	testCases := []struct {
+		formula  string
+		expected float64
+		isError  bool
+	}{
+		{"(a + b)/2 > 5 ? a : b", 10, false},
+		{"(a + b)/2 > 10 ? a : b", 4, false},
+		{"a > 20 ? 1 : b > 2 ? 2 : 3", 2, false},
+		{"c != 0 ? a / c : 0", 0, false},
+		{"c != 0 && a / c > 1", 0, false},
+		{"b > 1 || a / c > 1", 1, false},
+		{"!(a == 10) + (b <= 4)", 1, false},
+		{"-a * 2 + b", -16, false},
+		{"c == 0 ? a / c : 0", 0, true},
+	}
+
+	for _, testCase := range testCases {
+		formula, err := ParseConditionalFormula(testCase.formula, []string{"a", "b", "c"})
+		if err != nil {
+			t.Fatalf("%s: expected success but got error %s", testCase.formula, err)
+		}
+		value, err := formula.Evaluate(values)
+		if err != nil && !testCase.isError {
+			t.Errorf("%s: expected success but got error %s", testCase.formula, err)
+		}
+		if err == nil && testCase.isError {
+			t.Errorf("%s: expected error but got success", testCase.formula)
+		}
+		if err == nil && value != testCase.expected {
+			t.Errorf("%s: expected %v but got %v", testCase.formula, testCase.expected, value)
+		}
+	}
+}
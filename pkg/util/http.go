@@ -31,16 +31,19 @@ type HTTPDoer interface {
 
 // CreateHTTPClient returns a new HTTP client with the timeout set to
 // timeoutMS milliseconds, or 300 milliseconds if timeoutMS <= 0.
-// unsafeSsl parameter allows to avoid tls cert validation if it's required
+// unsafeSsl parameter allows to avoid tls cert validation if it's required.
+// The client trusts the custom CA certificates of the cluster on top of the ones of the system.
 func CreateHTTPClient(timeout time.Duration, unsafeSsl bool) *http.Client {
 	// default the timeout to 300ms
 	if timeout <= 0 {
 		timeout = 300 * time.Millisecond
 	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: unsafeSsl, RootCAs: rootCAs}
+	applyFIPSTLSSettings(tlsConfig)
 	httpClient := &http.Client{
 		Timeout: timeout,
 		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: unsafeSsl},
+			TLSClientConfig: tlsConfig,
 			Proxy:           http.ProxyFromEnvironment,
 		},
 	}
@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultRateSamples is the number of samples the rates of a trigger are estimated over
+const DefaultRateSamples = 10
+
+// RateSample is a sample of the backlog of a trigger, with the number of items completed since the
+// previous sample, negative if the scaler doesn't know it
+type RateSample struct {
+	Time      time.Time `json:"time"`
+	Backlog   float64   `json:"backlog"`
+	Completed float64   `json:"completed"`
+}
+
+// RateEstimator estimates the processing and arrival rates of a trigger from a ring buffer of its last
+// samples, safe for concurrent use
+type RateEstimator struct {
+	samples []RateSample
+	next    int
+	count   int
+	lock    sync.Mutex
+}
+
+// NewRateEstimator creates a RateEstimator keeping the last size samples
+func NewRateEstimator(size int) *RateEstimator {
+	if size < 2 {
+		size = 2
+	}
+	return &RateEstimator{
+		samples: make([]RateSample, size),
+	}
+}
+
+// Add adds a sample, samples older than the last one are ignored
+func (e *RateEstimator) Add(sample RateSample) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.add(sample)
+}
+
+func (e *RateEstimator) add(sample RateSample) {
+	if e.count > 0 && sample.Time.Before(e.samples[(e.next+len(e.samples)-1)%len(e.samples)].Time) {
+		return
+	}
+	e.samples[e.next] = sample
+	e.next = (e.next + 1) % len(e.samples)
+	if e.count < len(e.samples) {
+		e.count++
+	}
+}
+
+// Samples returns the samples, oldest first
+func (e *RateEstimator) Samples() []RateSample {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.ordered()
+}
+
+func (e *RateEstimator) ordered() []RateSample {
+	samples := make([]RateSample, 0, e.count)
+	for i := e.count; i > 0; i-- {
+		samples = append(samples, e.samples[(e.next+len(e.samples)-i)%len(e.samples)])
+	}
+	return samples
+}
+
+// Restore replaces the samples with the given ones, oldest first, keeping the last ones if there are too many
+func (e *RateEstimator) Restore(samples []RateSample) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.next, e.count = 0, 0
+	for _, sample := range samples {
+		e.add(sample)
+	}
+}
+
+// ProcessingRate returns the number of items completed per second over the samples, false if there aren't
+// enough samples. Where the completed count isn't known, the backlog drained since the previous sample is
+// used, so a backlog which grows or stays the same isn't drained.
+func (e *RateEstimator) ProcessingRate() (float64, bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	samples := e.ordered()
+	elapsed, ok := rateSamplesSpan(samples)
+	if !ok {
+		return 0, false
+	}
+
+	completed := float64(0)
+	for i := 1; i < len(samples); i++ {
+		if samples[i].Completed >= 0 {
+			completed += samples[i].Completed
+		} else if drained := samples[i-1].Backlog - samples[i].Backlog; drained > 0 {
+			completed += drained
+		}
+	}
+	return completed / elapsed, true
+}
+
+// ArrivalRate returns the number of items added to the backlog per second over the samples, false if there
+// aren't enough samples. Where the completed count isn't known, only the growth of the backlog is counted.
+func (e *RateEstimator) ArrivalRate() (float64, bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	samples := e.ordered()
+	elapsed, ok := rateSamplesSpan(samples)
+	if !ok {
+		return 0, false
+	}
+
+	arrived := float64(0)
+	for i := 1; i < len(samples); i++ {
+		growth := samples[i].Backlog - samples[i-1].Backlog
+		if samples[i].Completed >= 0 {
+			growth += samples[i].Completed
+		}
+		if growth > 0 {
+			arrived += growth
+		}
+	}
+	return arrived / elapsed, true
+}
+
+// rateSamplesSpan returns the seconds between the first and the last sample, false if no time elapsed between them
+func rateSamplesSpan(samples []RateSample) (float64, bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+	elapsed := samples[len(samples)-1].Time.Sub(samples[0].Time).Seconds()
+	return elapsed, elapsed > 0
+}
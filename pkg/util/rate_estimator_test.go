@@ -0,0 +1,113 @@
+package util
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRateEstimatorRingBuffer(t *testing.T) {
+	estimator := NewRateEstimator(3)
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		estimator.Add(RateSample{Time: start.Add(time.Duration(i) * time.Second), Backlog: float64(i), Completed: -1})
+	}
+	// samples older than the last one are ignored
+	estimator.Add(RateSample{Time: start, Backlog: 100, Completed: -1})
+
+	samples := estimator.Samples()
+	if len(samples) != 3 {
+		t.Fatalf("Expected the last 3 samples but got %v", samples)
+	}
+	for i, sample := range samples {
+		if sample.Backlog != float64(i+2) {
+			t.Errorf("Expected sample %d to have backlog %d but got %v", i, i+2, sample.Backlog)
+		}
+	}
+}
+
+func TestRateEstimatorRestore(t *testing.T) {
+	estimator := NewRateEstimator(2)
+	start := time.Now()
+
+	estimator.Add(RateSample{Time: start, Backlog: 1000, Completed: -1})
+	estimator.Restore([]RateSample{
+		{Time: start, Backlog: 30, Completed: -1},
+		{Time: start.Add(10 * time.Second), Backlog: 20, Completed: -1},
+		{Time: start.Add(20 * time.Second), Backlog: 10, Completed: -1},
+	})
+
+	samples := estimator.Samples()
+	if len(samples) != 2 || samples[0].Backlog != 20 || samples[1].Backlog != 10 {
+		t.Errorf("Expected the last 2 restored samples but got %v", samples)
+	}
+}
+
+func TestRateEstimatorRates(t *testing.T) {
+	start := time.Now()
+
+	testCases := []struct {
+		name       string
+		samples    []RateSample
+		known      bool
+		processing float64
+		arrival    float64
+	}{
+		{
+			name:    "no samples",
+			samples: nil,
+		},
+		{
+			name:    "single sample",
+			samples: []RateSample{{Time: start, Backlog: 10, Completed: -1}},
+		},
+		{
+			name: "backlog drained",
+			samples: []RateSample{
+				{Time: start, Backlog: 100, Completed: -1},
+				{Time: start.Add(10 * time.Second), Backlog: 80, Completed: -1},
+				{Time: start.Add(20 * time.Second), Backlog: 90, Completed: -1},
+			},
+			known:      true,
+			processing: 1,
+			arrival:    0.5,
+		},
+		{
+			name: "completed counts",
+			samples: []RateSample{
+				{Time: start, Backlog: 100, Completed: -1},
+				{Time: start.Add(10 * time.Second), Backlog: 100, Completed: 50},
+				{Time: start.Add(20 * time.Second), Backlog: 80, Completed: 30},
+			},
+			known:      true,
+			processing: 4,
+			arrival:    3,
+		},
+		{
+			name: "unknown completed count",
+			samples: []RateSample{
+				{Time: start, Backlog: 100, Completed: -1},
+				{Time: start.Add(10 * time.Second), Backlog: 100, Completed: 50},
+				{Time: start.Add(20 * time.Second), Backlog: 80, Completed: -1},
+			},
+			known:      true,
+			processing: 3.5,
+			arrival:    2.5,
+		},
+	}
+
+	for _, testCase := range testCases {
+		estimator := NewRateEstimator(DefaultRateSamples)
+		estimator.Restore(testCase.samples)
+
+		processing, known := estimator.ProcessingRate()
+		if known != testCase.known || math.Abs(processing-testCase.processing) > 0.001 {
+			t.Errorf("%s: expected processing rate %v (%v) but got %v (%v)", testCase.name, testCase.processing, testCase.known, processing, known)
+		}
+		arrival, known := estimator.ArrivalRate()
+		if known != testCase.known || math.Abs(arrival-testCase.arrival) > 0.001 {
+			t.Errorf("%s: expected arrival rate %v (%v) but got %v (%v)", testCase.name, testCase.arrival, testCase.known, arrival, known)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The KEDA Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"time"
+)
+
+// timeToEmptyMaxRatio caps the estimated time to empty relatively to the target, so a backlog which isn't
+// drained doubles the replicas at each evaluation instead of scaling to the maximum at once
+const timeToEmptyMaxRatio = 2
+
+// TimeToEmpty estimates the time to empty a backlog at the processing rate of its RateEstimator, safe for
+// concurrent use
+type TimeToEmpty struct {
+	target    float64
+	estimator *RateEstimator
+}
+
+// NewTimeToEmpty creates a TimeToEmpty for the target time to empty in seconds, sampling the backlog into
+// the estimator, which keeps the samples across the scalers of the trigger if given
+func NewTimeToEmpty(target float64, estimator *RateEstimator) *TimeToEmpty {
+	if estimator == nil {
+		estimator = NewRateEstimator(DefaultRateSamples)
+	}
+	return &TimeToEmpty{
+		target:    target,
+		estimator: estimator,
+	}
+}
+
+// Target returns the target time to empty in seconds
+func (t *TimeToEmpty) Target() float64 {
+	return t.target
+}
+
+// Add adds a sample of the backlog and returns the estimated time to empty it in seconds at the current
+// processing rate. The target is returned until a rate is known.
+func (t *TimeToEmpty) Add(backlog float64, now time.Time) float64 {
+	return t.AddSample(RateSample{Time: now, Backlog: backlog, Completed: -1})
+}
+
+// AddSample is Add for the scalers knowing the number of items completed since the previous sample
+func (t *TimeToEmpty) AddSample(sample RateSample) float64 {
+	if sample.Backlog < 0 {
+		sample.Backlog = 0
+	}
+	t.estimator.Add(sample)
+	rate, known := t.estimator.ProcessingRate()
+
+	switch {
+	case sample.Backlog == 0:
+		return 0
+	case !known:
+		return t.target
+	case rate <= 0:
+		return t.target * timeToEmptyMaxRatio
+	}
+
+	estimate := sample.Backlog / rate
+	if estimate > t.target*timeToEmptyMaxRatio {
+		return t.target * timeToEmptyMaxRatio
+	}
+	return estimate
+}
@@ -0,0 +1,63 @@
+package util
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTimeToEmpty(t *testing.T) {
+	tte := NewTimeToEmpty(60, nil)
+	start := time.Now()
+
+	testCases := []struct {
+		backlog  float64
+		offset   time.Duration
+		expected float64
+	}{
+		// no rate is known, the target is returned
+		{100, 0, 60},
+		// 20 messages drained in 10 seconds, 80 left at 2 messages per second
+		{80, 10 * time.Second, 40},
+		// the backlog grows, the rate goes down to 1 message per second over the samples
+		{100, 20 * time.Second, 100},
+		// the estimate is capped to twice the target
+		{1000, 30 * time.Second, 120},
+		// an empty backlog is emptied
+		{0, 40 * time.Second, 0},
+	}
+
+	for i, testCase := range testCases {
+		value := tte.Add(testCase.backlog, start.Add(testCase.offset))
+		if math.Abs(value-testCase.expected) > 0.1 {
+			t.Errorf("Sample %d: expected %v but got %v", i, testCase.expected, value)
+		}
+	}
+}
+
+func TestTimeToEmptyWithoutDrain(t *testing.T) {
+	tte := NewTimeToEmpty(30, nil)
+	start := time.Now()
+
+	tte.Add(50, start)
+	if value := tte.Add(50, start.Add(15*time.Second)); value != 60 {
+		t.Errorf("Expected a backlog which isn't drained to return twice the target but got %v", value)
+	}
+}
+
+func TestTimeToEmptyWithCompletedCounts(t *testing.T) {
+	estimator := NewRateEstimator(DefaultRateSamples)
+	tte := NewTimeToEmpty(60, estimator)
+	start := time.Now()
+
+	tte.AddSample(RateSample{Time: start, Backlog: 100, Completed: -1})
+	// the backlog stays the same but 50 messages were completed in 10 seconds
+	if value := tte.AddSample(RateSample{Time: start.Add(10 * time.Second), Backlog: 100, Completed: 50}); value != 20 {
+		t.Errorf("Expected the time to empty at 5 messages per second but got %v", value)
+	}
+
+	// the samples are kept in the given estimator
+	if samples := estimator.Samples(); len(samples) != 2 {
+		t.Errorf("Expected the samples to be added to the estimator but got %v", samples)
+	}
+}
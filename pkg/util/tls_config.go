@@ -25,7 +25,8 @@ import (
 	"github.com/youmark/pkcs8"
 )
 
-func decryptClientKey(clientKey, clientKeyPassword string) ([]byte, error) {
+// DecryptClientKey decrypts the given PKCS#8 encoded client key with the password and returns it PEM encoded
+func DecryptClientKey(clientKey, clientKeyPassword string) ([]byte, error) {
 	block, _ := pem.Decode([]byte(clientKey))
 
 	key, err := pkcs8.ParsePKCS8PrivateKey(block.Bytes, []byte(clientKeyPassword))
@@ -63,7 +64,7 @@ func NewTLSConfigWithPassword(clientCert, clientKey, clientKeyPassword, caCert s
 		key := []byte(clientKey)
 		if clientKeyPassword != "" {
 			var err error
-			key, err = decryptClientKey(clientKey, clientKeyPassword)
+			key, err = DecryptClientKey(clientKey, clientKeyPassword)
 			if err != nil {
 				return nil, fmt.Errorf("error decrypt X509Key: %s", err)
 			}
@@ -86,9 +87,10 @@ func NewTLSConfigWithPassword(clientCert, clientKey, clientKeyPassword, caCert s
 	}
 
 	if !valid {
-		config = nil
+		return nil, nil
 	}
 
+	applyFIPSTLSSettings(config)
 	return config, nil
 }
 